@@ -3,6 +3,24 @@ package crud
 
 import (
 	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mux"
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/allocationapi"
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/barcodeapi"
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/disposalapi"
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/expiryapi"
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/inventoryapi"
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/locationapi"
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/lotapi"
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/medicineapi"
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/orderapi"
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/purchaseorderapi"
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/recallapi"
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/reservationapi"
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/stockapi"
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/stocktakeapi"
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/supplierapi"
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/tagapi"
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/temperatureapi"
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/transferapi"
 	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/crud/userapi"
 	"github.com/EnesDemirtas/medisync/apis/services/warehouse/route/sys/checkapi"
 	"github.com/EnesDemirtas/medisync/foundation/web"
@@ -21,6 +39,139 @@ func (add) Add(app *web.App, cfg mux.Config) {
 	userapi.Routes(app, userapi.Config{
 		UserBus: cfg.BusDomain.User,
 		AuthSrv: cfg.AuthSrv,
+		Log:     cfg.Log,
+	})
+
+	tagapi.Routes(app, tagapi.Config{
+		TagBus:  cfg.BusDomain.Tag,
+		AuthSrv: cfg.AuthSrv,
+		Log:     cfg.Log,
+	})
+
+	medicineapi.Routes(app, medicineapi.Config{
+		MedicineBus: cfg.BusDomain.Medicine,
+		AuthSrv:     cfg.AuthSrv,
+		Log:         cfg.Log,
+	})
+
+	lotapi.Routes(app, lotapi.Config{
+		LotBus:  cfg.BusDomain.Lot,
+		AuthSrv: cfg.AuthSrv,
+		Log:     cfg.Log,
+	})
+
+	barcodeapi.Routes(app, barcodeapi.Config{
+		MedicineBus: cfg.BusDomain.Medicine,
+		LotBus:      cfg.BusDomain.Lot,
+		AuthSrv:     cfg.AuthSrv,
+		Log:         cfg.Log,
+	})
+
+	inventoryapi.Routes(app, inventoryapi.Config{
+		InventoryBus: cfg.BusDomain.Inventory,
+		MedicineBus:  cfg.BusDomain.Medicine,
+		StockBus:     cfg.BusDomain.Stock,
+		AuthSrv:      cfg.AuthSrv,
+		Log:          cfg.Log,
+		DB:           cfg.DB,
+	})
+
+	stockapi.Routes(app, stockapi.Config{
+		StockBus:     cfg.BusDomain.Stock,
+		InventoryBus: cfg.BusDomain.Inventory,
+		AuthSrv:      cfg.AuthSrv,
+		Log:          cfg.Log,
+		DB:           cfg.DB,
+	})
+
+	transferapi.Routes(app, transferapi.Config{
+		TransferBus:  cfg.BusDomain.Transfer,
+		InventoryBus: cfg.BusDomain.Inventory,
+		AuthSrv:      cfg.AuthSrv,
+		Log:          cfg.Log,
+		DB:           cfg.DB,
+	})
+
+	reservationapi.Routes(app, reservationapi.Config{
+		ReservationBus: cfg.BusDomain.Reservation,
+		InventoryBus:   cfg.BusDomain.Inventory,
+		AuthSrv:        cfg.AuthSrv,
+		Log:            cfg.Log,
+		DB:             cfg.DB,
+	})
+
+	allocationapi.Routes(app, allocationapi.Config{
+		AllocationBus: cfg.BusDomain.Allocation,
+		AuthSrv:       cfg.AuthSrv,
+		Log:           cfg.Log,
+		DB:            cfg.DB,
+	})
+
+	orderapi.Routes(app, orderapi.Config{
+		OrderBus:     cfg.BusDomain.Order,
+		InventoryBus: cfg.BusDomain.Inventory,
+		AuthSrv:      cfg.AuthSrv,
+		Log:          cfg.Log,
+		DB:           cfg.DB,
+	})
+
+	supplierapi.Routes(app, supplierapi.Config{
+		SupplierBus: cfg.BusDomain.Supplier,
+		AuthSrv:     cfg.AuthSrv,
+		Log:         cfg.Log,
+	})
+
+	purchaseorderapi.Routes(app, purchaseorderapi.Config{
+		PurchaseOrderBus: cfg.BusDomain.PurchaseOrder,
+		InventoryBus:     cfg.BusDomain.Inventory,
+		AuthSrv:          cfg.AuthSrv,
+		Log:              cfg.Log,
+		DB:               cfg.DB,
+	})
+
+	stocktakeapi.Routes(app, stocktakeapi.Config{
+		StocktakeBus: cfg.BusDomain.Stocktake,
+		InventoryBus: cfg.BusDomain.Inventory,
+		AuthSrv:      cfg.AuthSrv,
+		Log:          cfg.Log,
+		DB:           cfg.DB,
+	})
+
+	locationapi.Routes(app, locationapi.Config{
+		LocationBus:  cfg.BusDomain.Location,
+		InventoryBus: cfg.BusDomain.Inventory,
+		AuthSrv:      cfg.AuthSrv,
+		Log:          cfg.Log,
+		DB:           cfg.DB,
+	})
+
+	recallapi.Routes(app, recallapi.Config{
+		RecallBus: cfg.BusDomain.Recall,
+		AuthSrv:   cfg.AuthSrv,
+		Log:       cfg.Log,
+		DB:        cfg.DB,
+	})
+
+	disposalapi.Routes(app, disposalapi.Config{
+		DisposalBus:  cfg.BusDomain.Disposal,
+		InventoryBus: cfg.BusDomain.Inventory,
+		AuthSrv:      cfg.AuthSrv,
+		Log:          cfg.Log,
+		DB:           cfg.DB,
+	})
+
+	temperatureapi.Routes(app, temperatureapi.Config{
+		TemperatureBus: cfg.BusDomain.Temperature,
+		InventoryBus:   cfg.BusDomain.Inventory,
+		AuthSrv:        cfg.AuthSrv,
+		Log:            cfg.Log,
+		DB:             cfg.DB,
+	})
+
+	expiryapi.Routes(app, expiryapi.Config{
+		ExpiryBus: cfg.BusDomain.Expiry,
+		AuthSrv:   cfg.AuthSrv,
+		Log:       cfg.Log,
 	})
 
 	checkapi.Routes(app, checkapi.Config{
@@ -18,6 +18,40 @@ import (
 	"github.com/EnesDemirtas/medisync/app/api/debug"
 	"github.com/EnesDemirtas/medisync/business/api/delegate"
 	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/domain/allocationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/allocationbus/stores/allocationdb"
+	"github.com/EnesDemirtas/medisync/business/domain/disposalbus"
+	"github.com/EnesDemirtas/medisync/business/domain/disposalbus/stores/disposaldb"
+	"github.com/EnesDemirtas/medisync/business/domain/expirybus"
+	"github.com/EnesDemirtas/medisync/business/domain/expirybus/stores/expirydb"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus/stores/inventorydb"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus/stores/locationdb"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus/stores/lotdb"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus/stores/medicinedb"
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus"
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus/stores/orderdb"
+	"github.com/EnesDemirtas/medisync/business/domain/purchaseorderbus"
+	"github.com/EnesDemirtas/medisync/business/domain/purchaseorderbus/stores/purchaseorderdb"
+	"github.com/EnesDemirtas/medisync/business/domain/recallbus"
+	"github.com/EnesDemirtas/medisync/business/domain/recallbus/stores/recalldb"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus/stores/reservationdb"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus/stores/stockdb"
+	"github.com/EnesDemirtas/medisync/business/domain/stocktakebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stocktakebus/stores/stocktakedb"
+	"github.com/EnesDemirtas/medisync/business/domain/supplierbus"
+	"github.com/EnesDemirtas/medisync/business/domain/supplierbus/stores/supplierdb"
+	"github.com/EnesDemirtas/medisync/business/domain/tagbus"
+	"github.com/EnesDemirtas/medisync/business/domain/tagbus/stores/tagdb"
+	"github.com/EnesDemirtas/medisync/business/domain/temperaturebus"
+	"github.com/EnesDemirtas/medisync/business/domain/temperaturebus/stores/temperaturedb"
+	"github.com/EnesDemirtas/medisync/business/domain/transferbus"
+	"github.com/EnesDemirtas/medisync/business/domain/transferbus/stores/transferdb"
 	"github.com/EnesDemirtas/medisync/business/domain/userbus"
 	"github.com/EnesDemirtas/medisync/business/domain/userbus/stores/userdb"
 	"github.com/EnesDemirtas/medisync/foundation/logger"
@@ -92,6 +126,19 @@ func run(ctx context.Context, log *logger.Logger) error {
 			MaxOpenConns int    `conf:"default:0"`
 			DisableTLS   bool   `conf:"default:true"`
 		}
+		Expiry struct {
+			Interval time.Duration `conf:"default:1h"`
+			Horizons []int         `conf:"default:90;30;7"`
+		}
+		Reservation struct {
+			SweepInterval time.Duration `conf:"default:1m"`
+		}
+		Snapshot struct {
+			Interval time.Duration `conf:"default:24h"`
+		}
+		Allocation struct {
+			MinShelfLife time.Duration `conf:"default:720h"`
+		}
 		Tempo struct {
 			ReporterURI string  `conf:"default:tempo.warehouse-system.svc.cluster.local:4317"`
 			ServiceName string  `conf:"default:sales"`
@@ -186,7 +233,24 @@ func run(ctx context.Context, log *logger.Logger) error {
 	log.Info(ctx, "startup", "status", "initializing business support")
 
 	delegate := delegate.New(log)
-	userBus  := userbus.NewCore(log, delegate, userdb.NewStore(log, db))
+	userBus      := userbus.NewCore(log, delegate, userdb.NewStore(log, db))
+	tagBus       := tagbus.NewCore(log, delegate, tagdb.NewStore(log, db))
+	medicineBus  := medicinebus.NewCore(log, tagBus, delegate, medicinedb.NewStore(log, db))
+	lotBus       := lotbus.NewCore(log, medicineBus, delegate, lotdb.NewStore(log, db))
+	inventoryBus := inventorybus.NewCore(log, medicineBus, lotBus, delegate, inventorydb.NewStore(log, db))
+	locationBus  := locationbus.NewCore(log, inventoryBus, delegate, locationdb.NewStore(log, db))
+	stockBus     := stockbus.NewCore(log, inventoryBus, medicineBus, lotBus, locationBus, delegate, stockdb.NewStore(log, db))
+	transferBus  := transferbus.NewCore(log, inventoryBus, stockBus, delegate, transferdb.NewStore(log, db))
+	expiryBus    := expirybus.NewCore(log, delegate, expirydb.NewStore(log, db))
+	reservationBus := reservationbus.NewCore(log, medicineBus, stockBus, delegate, reservationdb.NewStore(log, db))
+	allocationBus := allocationbus.NewCore(log, medicineBus, stockBus, cfg.Allocation.MinShelfLife, delegate, allocationdb.NewStore(log, db))
+	orderBus := orderbus.NewCore(log, medicineBus, lotBus, stockBus, allocationBus, delegate, orderdb.NewStore(log, db))
+	supplierBus := supplierbus.NewCore(log, delegate, supplierdb.NewStore(log, db))
+	purchaseOrderBus := purchaseorderbus.NewCore(log, supplierBus, medicineBus, lotBus, stockBus, delegate, purchaseorderdb.NewStore(log, db))
+	stocktakeBus := stocktakebus.NewCore(log, inventoryBus, lotBus, stockBus, delegate, stocktakedb.NewStore(log, db))
+	recallBus := recallbus.NewCore(log, lotBus, inventoryBus, stockBus, delegate, recalldb.NewStore(log, db))
+	disposalBus := disposalbus.NewCore(log, userBus, lotBus, stockBus, delegate, disposaldb.NewStore(log, db))
+	temperatureBus := temperaturebus.NewCore(log, inventoryBus, delegate, temperaturedb.NewStore(log, db))
 
 	// ---------------------------------------------------------------
 	// Start Debug Service
@@ -199,6 +263,65 @@ func run(ctx context.Context, log *logger.Logger) error {
 		}
 	}()
 
+	// ---------------------------------------------------------------
+	// Start Expiry Watcher
+
+	// The watcher is stopped and waited on when run returns so a scan is
+	// never cut off half way by the database being closed.
+	watchCtx, stopWatch := context.WithCancel(ctx)
+	watchDone := make(chan struct{})
+
+	go func() {
+		defer close(watchDone)
+		log.Info(ctx, "startup", "status", "expiry watcher started", "interval", cfg.Expiry.Interval, "horizons", cfg.Expiry.Horizons)
+
+		expiryBus.Watch(watchCtx, cfg.Expiry.Interval, cfg.Expiry.Horizons)
+	}()
+
+	defer func() {
+		stopWatch()
+		<-watchDone
+		log.Info(ctx, "shutdown", "status", "expiry watcher stopped")
+	}()
+
+	// ---------------------------------------------------------------
+	// Start Reservation Sweeper
+
+	sweepCtx, stopSweep := context.WithCancel(ctx)
+	sweepDone := make(chan struct{})
+
+	go func() {
+		defer close(sweepDone)
+		log.Info(ctx, "startup", "status", "reservation sweeper started", "interval", cfg.Reservation.SweepInterval)
+
+		reservationBus.Watch(sweepCtx, cfg.Reservation.SweepInterval)
+	}()
+
+	defer func() {
+		stopSweep()
+		<-sweepDone
+		log.Info(ctx, "shutdown", "status", "reservation sweeper stopped")
+	}()
+
+	// ---------------------------------------------------------------
+	// Start Stock Snapshotter
+
+	snapshotCtx, stopSnapshot := context.WithCancel(ctx)
+	snapshotDone := make(chan struct{})
+
+	go func() {
+		defer close(snapshotDone)
+		log.Info(ctx, "startup", "status", "stock snapshotter started", "interval", cfg.Snapshot.Interval)
+
+		stockBus.Watch(snapshotCtx, cfg.Snapshot.Interval)
+	}()
+
+	defer func() {
+		stopSnapshot()
+		<-snapshotDone
+		log.Info(ctx, "shutdown", "status", "stock snapshotter stopped")
+	}()
+
 	// ----------------------------------------------------------------
 	// Start API Service
 
@@ -217,6 +340,23 @@ func run(ctx context.Context, log *logger.Logger) error {
 		BusDomain:	mux.BusDomain{
 			Delegate: 	delegate,
 			User:		userBus,
+			Tag:		tagBus,
+			Medicine:	medicineBus,
+			Lot:		lotBus,
+			Inventory:	inventoryBus,
+			Location:	locationBus,
+			Stock:		stockBus,
+			Transfer:	transferBus,
+			Expiry:		expiryBus,
+			Reservation: reservationBus,
+			Allocation:  allocationBus,
+			Order:       orderBus,
+			Supplier:    supplierBus,
+			PurchaseOrder: purchaseOrderBus,
+			Stocktake:     stocktakeBus,
+			Recall:        recallBus,
+			Disposal:      disposalBus,
+			Temperature:   temperatureBus,
 		},
 	}
 
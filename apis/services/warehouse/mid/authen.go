@@ -8,6 +8,7 @@ import (
 	"github.com/EnesDemirtas/medisync/app/api/authsrv"
 	"github.com/EnesDemirtas/medisync/app/api/errs"
 	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
 	"github.com/EnesDemirtas/medisync/foundation/logger"
 	"github.com/EnesDemirtas/medisync/foundation/web"
 )
@@ -34,4 +35,50 @@ func Authenticate(log *logger.Logger, authsrv *authsrv.AuthSrv) web.MidHandler {
 	}
 
 	return m
-} 
\ No newline at end of file
+} 
+
+// Countersign validates the JWT of a second user from the
+// `X-Countersign-Authorization` header and checks they are allowed to
+// countersign the request of the authenticated user. The header is optional,
+// the business layer decides which requests need a countersign.
+func Countersign(log *logger.Logger, authSrv *authsrv.AuthSrv) web.MidHandler {
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			authorization := r.Header.Get("x-countersign-authorization")
+			if authorization == "" {
+				return handler(ctx, w, r)
+			}
+
+			userID, err := mid.GetUserID(ctx)
+			if err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			ctxAuth, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+
+			resp, err := authSrv.Authenticate(ctxAuth, authorization)
+			if err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			authz := authsrv.Authorize{
+				Claims: resp.Claims,
+				UserID: userID,
+				Rule:   auth.RuleCountersign,
+			}
+
+			if err := authSrv.Authorize(ctxAuth, authz); err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			ctx = mid.SetCountersignerID(ctx, resp.UserID)
+
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
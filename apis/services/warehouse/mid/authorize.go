@@ -9,6 +9,19 @@ import (
 	"github.com/EnesDemirtas/medisync/app/api/authsrv"
 	"github.com/EnesDemirtas/medisync/app/api/errs"
 	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/business/domain/disposalbus"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/tagbus"
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus"
+	"github.com/EnesDemirtas/medisync/business/domain/purchaseorderbus"
+	"github.com/EnesDemirtas/medisync/business/domain/recallbus"
+	"github.com/EnesDemirtas/medisync/business/domain/supplierbus"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stocktakebus"
+	"github.com/EnesDemirtas/medisync/business/domain/transferbus"
 	"github.com/EnesDemirtas/medisync/business/domain/userbus"
 	"github.com/EnesDemirtas/medisync/foundation/logger"
 	"github.com/EnesDemirtas/medisync/foundation/web"
@@ -98,4 +111,667 @@ func AuthorizeUser(log *logger.Logger, authSrv *authsrv.AuthSrv, userBus *userbu
 	}
 
 	return m
-}
\ No newline at end of file
+}
+
+// AuthorizeMedicine executes the specified role and extracts the specified
+// medicine from the DB if a medicine id is specified in the call.
+func AuthorizeMedicine(log *logger.Logger, authSrv *authsrv.AuthSrv, medicineBus *medicinebus.Core, rule string) web.MidHandler {
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			userID, err := mid.GetUserID(ctx)
+			if err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			if id := web.Param(r, "medicine_id"); id != "" {
+				medicineID, err := uuid.Parse(id)
+				if err != nil {
+					return errs.New(errs.Unauthenticated, ErrInvalidID)
+				}
+
+				med, err := medicineBus.QueryByID(ctx, medicineID)
+				if err != nil {
+					switch {
+					case errors.Is(err, medicinebus.ErrNotFound):
+						return errs.New(errs.NotFound, err)
+					default:
+						return errs.Newf(errs.Internal, "querybyid: medicineID[%s]: %s", medicineID, err)
+					}
+				}
+
+				ctx = mid.SetMedicine(ctx, med)
+			}
+
+			ctxAuth, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+
+			auth := authsrv.Authorize{
+				Claims: mid.GetClaims(ctx),
+				UserID: userID,
+				Rule:   rule,
+			}
+
+			if err := authSrv.Authorize(ctxAuth, auth); err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
+
+// AuthorizeInventory executes the specified role and extracts the specified
+// inventory from the DB if an inventory id is specified in the call.
+func AuthorizeInventory(log *logger.Logger, authSrv *authsrv.AuthSrv, inventoryBus *inventorybus.Core, rule string) web.MidHandler {
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			userID, err := mid.GetUserID(ctx)
+			if err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			if id := web.Param(r, "inventory_id"); id != "" {
+				inventoryID, err := uuid.Parse(id)
+				if err != nil {
+					return errs.New(errs.Unauthenticated, ErrInvalidID)
+				}
+
+				inv, err := inventoryBus.QueryByID(ctx, inventoryID)
+				if err != nil {
+					switch {
+					case errors.Is(err, inventorybus.ErrNotFound):
+						return errs.New(errs.NotFound, err)
+					default:
+						return errs.Newf(errs.Internal, "querybyid: inventoryID[%s]: %s", inventoryID, err)
+					}
+				}
+
+				ctx = mid.SetInventory(ctx, inv)
+			}
+
+			ctxAuth, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+
+			auth := authsrv.Authorize{
+				Claims: mid.GetClaims(ctx),
+				UserID: userID,
+				Rule:   rule,
+			}
+
+			if err := authSrv.Authorize(ctxAuth, auth); err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
+
+// AuthorizeTag executes the specified role and extracts the specified tag
+// from the DB if a tag id is specified in the call.
+func AuthorizeTag(log *logger.Logger, authSrv *authsrv.AuthSrv, tagBus *tagbus.Core, rule string) web.MidHandler {
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			userID, err := mid.GetUserID(ctx)
+			if err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			if id := web.Param(r, "tag_id"); id != "" {
+				tagID, err := uuid.Parse(id)
+				if err != nil {
+					return errs.New(errs.Unauthenticated, ErrInvalidID)
+				}
+
+				tag, err := tagBus.QueryByID(ctx, tagID)
+				if err != nil {
+					switch {
+					case errors.Is(err, tagbus.ErrNotFound):
+						return errs.New(errs.NotFound, err)
+					default:
+						return errs.Newf(errs.Internal, "querybyid: tagID[%s]: %s", tagID, err)
+					}
+				}
+
+				ctx = mid.SetTag(ctx, tag)
+			}
+
+			ctxAuth, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+
+			auth := authsrv.Authorize{
+				Claims: mid.GetClaims(ctx),
+				UserID: userID,
+				Rule:   rule,
+			}
+
+			if err := authSrv.Authorize(ctxAuth, auth); err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
+
+// AuthorizeLot executes the specified role and extracts the specified
+// lot from the DB if a lot id is specified in the call.
+func AuthorizeLot(log *logger.Logger, authSrv *authsrv.AuthSrv, lotBus *lotbus.Core, rule string) web.MidHandler {
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			userID, err := mid.GetUserID(ctx)
+			if err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			if id := web.Param(r, "lot_id"); id != "" {
+				lotID, err := uuid.Parse(id)
+				if err != nil {
+					return errs.New(errs.Unauthenticated, ErrInvalidID)
+				}
+
+				lot, err := lotBus.QueryByID(ctx, lotID)
+				if err != nil {
+					switch {
+					case errors.Is(err, lotbus.ErrNotFound):
+						return errs.New(errs.NotFound, err)
+					default:
+						return errs.Newf(errs.Internal, "querybyid: lotID[%s]: %s", lotID, err)
+					}
+				}
+
+				ctx = mid.SetLot(ctx, lot)
+			}
+
+			ctxAuth, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+
+			auth := authsrv.Authorize{
+				Claims: mid.GetClaims(ctx),
+				UserID: userID,
+				Rule:   rule,
+			}
+
+			if err := authSrv.Authorize(ctxAuth, auth); err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
+
+// AuthorizeTransfer executes the specified role and extracts the specified
+// transfer from the DB if a transfer id is specified in the call.
+func AuthorizeTransfer(log *logger.Logger, authSrv *authsrv.AuthSrv, transferBus *transferbus.Core, rule string) web.MidHandler {
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			userID, err := mid.GetUserID(ctx)
+			if err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			if id := web.Param(r, "transfer_id"); id != "" {
+				transferID, err := uuid.Parse(id)
+				if err != nil {
+					return errs.New(errs.Unauthenticated, ErrInvalidID)
+				}
+
+				tr, err := transferBus.QueryByID(ctx, transferID)
+				if err != nil {
+					switch {
+					case errors.Is(err, transferbus.ErrNotFound):
+						return errs.New(errs.NotFound, err)
+					default:
+						return errs.Newf(errs.Internal, "querybyid: transferID[%s]: %s", transferID, err)
+					}
+				}
+
+				ctx = mid.SetTransfer(ctx, tr)
+			}
+
+			ctxAuth, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+
+			auth := authsrv.Authorize{
+				Claims: mid.GetClaims(ctx),
+				UserID: userID,
+				Rule:   rule,
+			}
+
+			if err := authSrv.Authorize(ctxAuth, auth); err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
+
+// AuthorizeReservation executes the specified role and extracts the specified
+// reservation from the DB if a reservation id is specified in the call.
+func AuthorizeReservation(log *logger.Logger, authSrv *authsrv.AuthSrv, reservationBus *reservationbus.Core, rule string) web.MidHandler {
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			userID, err := mid.GetUserID(ctx)
+			if err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			if id := web.Param(r, "reservation_id"); id != "" {
+				reservationID, err := uuid.Parse(id)
+				if err != nil {
+					return errs.New(errs.Unauthenticated, ErrInvalidID)
+				}
+
+				res, err := reservationBus.QueryByID(ctx, reservationID)
+				if err != nil {
+					switch {
+					case errors.Is(err, reservationbus.ErrNotFound):
+						return errs.New(errs.NotFound, err)
+					default:
+						return errs.Newf(errs.Internal, "querybyid: reservationID[%s]: %s", reservationID, err)
+					}
+				}
+
+				ctx = mid.SetReservation(ctx, res)
+			}
+
+			ctxAuth, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+
+			auth := authsrv.Authorize{
+				Claims: mid.GetClaims(ctx),
+				UserID: userID,
+				Rule:   rule,
+			}
+
+			if err := authSrv.Authorize(ctxAuth, auth); err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
+
+// AuthorizeOrder executes the specified role and extracts the specified
+// order from the DB if an order id is specified in the call.
+func AuthorizeOrder(log *logger.Logger, authSrv *authsrv.AuthSrv, orderBus *orderbus.Core, rule string) web.MidHandler {
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			userID, err := mid.GetUserID(ctx)
+			if err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			if id := web.Param(r, "order_id"); id != "" {
+				orderID, err := uuid.Parse(id)
+				if err != nil {
+					return errs.New(errs.Unauthenticated, ErrInvalidID)
+				}
+
+				ord, err := orderBus.QueryByID(ctx, orderID)
+				if err != nil {
+					switch {
+					case errors.Is(err, orderbus.ErrNotFound):
+						return errs.New(errs.NotFound, err)
+					default:
+						return errs.Newf(errs.Internal, "querybyid: orderID[%s]: %s", orderID, err)
+					}
+				}
+
+				ctx = mid.SetOrder(ctx, ord)
+			}
+
+			ctxAuth, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+
+			auth := authsrv.Authorize{
+				Claims: mid.GetClaims(ctx),
+				UserID: userID,
+				Rule:   rule,
+			}
+
+			if err := authSrv.Authorize(ctxAuth, auth); err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
+
+// AuthorizeSupplier executes the specified role and extracts the specified
+// supplier from the DB if a supplier id is specified in the call.
+func AuthorizeSupplier(log *logger.Logger, authSrv *authsrv.AuthSrv, supplierBus *supplierbus.Core, rule string) web.MidHandler {
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			userID, err := mid.GetUserID(ctx)
+			if err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			if id := web.Param(r, "supplier_id"); id != "" {
+				supplierID, err := uuid.Parse(id)
+				if err != nil {
+					return errs.New(errs.Unauthenticated, ErrInvalidID)
+				}
+
+				sup, err := supplierBus.QueryByID(ctx, supplierID)
+				if err != nil {
+					switch {
+					case errors.Is(err, supplierbus.ErrNotFound):
+						return errs.New(errs.NotFound, err)
+					default:
+						return errs.Newf(errs.Internal, "querybyid: supplierID[%s]: %s", supplierID, err)
+					}
+				}
+
+				ctx = mid.SetSupplier(ctx, sup)
+			}
+
+			ctxAuth, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+
+			auth := authsrv.Authorize{
+				Claims: mid.GetClaims(ctx),
+				UserID: userID,
+				Rule:   rule,
+			}
+
+			if err := authSrv.Authorize(ctxAuth, auth); err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
+
+// AuthorizePurchaseOrder executes the specified role and extracts the specified
+// purchase order from the DB if a purchase order id is specified in the call.
+func AuthorizePurchaseOrder(log *logger.Logger, authSrv *authsrv.AuthSrv, purchaseOrderBus *purchaseorderbus.Core, rule string) web.MidHandler {
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			userID, err := mid.GetUserID(ctx)
+			if err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			if id := web.Param(r, "purchase_order_id"); id != "" {
+				purchaseOrderID, err := uuid.Parse(id)
+				if err != nil {
+					return errs.New(errs.Unauthenticated, ErrInvalidID)
+				}
+
+				po, err := purchaseOrderBus.QueryByID(ctx, purchaseOrderID)
+				if err != nil {
+					switch {
+					case errors.Is(err, purchaseorderbus.ErrNotFound):
+						return errs.New(errs.NotFound, err)
+					default:
+						return errs.Newf(errs.Internal, "querybyid: purchaseOrderID[%s]: %s", purchaseOrderID, err)
+					}
+				}
+
+				ctx = mid.SetPurchaseOrder(ctx, po)
+			}
+
+			ctxAuth, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+
+			auth := authsrv.Authorize{
+				Claims: mid.GetClaims(ctx),
+				UserID: userID,
+				Rule:   rule,
+			}
+
+			if err := authSrv.Authorize(ctxAuth, auth); err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
+
+// AuthorizeStocktake executes the specified role and extracts the specified
+// stocktake from the DB if a stocktake id is specified in the call.
+func AuthorizeStocktake(log *logger.Logger, authSrv *authsrv.AuthSrv, stocktakeBus *stocktakebus.Core, rule string) web.MidHandler {
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			userID, err := mid.GetUserID(ctx)
+			if err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			if id := web.Param(r, "stocktake_id"); id != "" {
+				stocktakeID, err := uuid.Parse(id)
+				if err != nil {
+					return errs.New(errs.Unauthenticated, ErrInvalidID)
+				}
+
+				stk, err := stocktakeBus.QueryByID(ctx, stocktakeID)
+				if err != nil {
+					switch {
+					case errors.Is(err, stocktakebus.ErrNotFound):
+						return errs.New(errs.NotFound, err)
+					default:
+						return errs.Newf(errs.Internal, "querybyid: stocktakeID[%s]: %s", stocktakeID, err)
+					}
+				}
+
+				ctx = mid.SetStocktake(ctx, stk)
+			}
+
+			ctxAuth, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+
+			auth := authsrv.Authorize{
+				Claims: mid.GetClaims(ctx),
+				UserID: userID,
+				Rule:   rule,
+			}
+
+			if err := authSrv.Authorize(ctxAuth, auth); err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
+
+// AuthorizeLocation executes the specified role and extracts the specified
+// location from the DB if a location id is specified in the call.
+func AuthorizeLocation(log *logger.Logger, authSrv *authsrv.AuthSrv, locationBus *locationbus.Core, rule string) web.MidHandler {
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			userID, err := mid.GetUserID(ctx)
+			if err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			if id := web.Param(r, "location_id"); id != "" {
+				locationID, err := uuid.Parse(id)
+				if err != nil {
+					return errs.New(errs.Unauthenticated, ErrInvalidID)
+				}
+
+				loc, err := locationBus.QueryByID(ctx, locationID)
+				if err != nil {
+					switch {
+					case errors.Is(err, locationbus.ErrNotFound):
+						return errs.New(errs.NotFound, err)
+					default:
+						return errs.Newf(errs.Internal, "querybyid: locationID[%s]: %s", locationID, err)
+					}
+				}
+
+				ctx = mid.SetLocation(ctx, loc)
+			}
+
+			ctxAuth, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+
+			auth := authsrv.Authorize{
+				Claims: mid.GetClaims(ctx),
+				UserID: userID,
+				Rule:   rule,
+			}
+
+			if err := authSrv.Authorize(ctxAuth, auth); err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
+
+// AuthorizeRecall executes the specified role and extracts the specified
+// recall from the DB if a recall id is specified in the call.
+func AuthorizeRecall(log *logger.Logger, authSrv *authsrv.AuthSrv, recallBus *recallbus.Core, rule string) web.MidHandler {
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			userID, err := mid.GetUserID(ctx)
+			if err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			if id := web.Param(r, "recall_id"); id != "" {
+				recallID, err := uuid.Parse(id)
+				if err != nil {
+					return errs.New(errs.Unauthenticated, ErrInvalidID)
+				}
+
+				rcl, err := recallBus.QueryByID(ctx, recallID)
+				if err != nil {
+					switch {
+					case errors.Is(err, recallbus.ErrNotFound):
+						return errs.New(errs.NotFound, err)
+					default:
+						return errs.Newf(errs.Internal, "querybyid: recallID[%s]: %s", recallID, err)
+					}
+				}
+
+				ctx = mid.SetRecall(ctx, rcl)
+			}
+
+			ctxAuth, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+
+			auth := authsrv.Authorize{
+				Claims: mid.GetClaims(ctx),
+				UserID: userID,
+				Rule:   rule,
+			}
+
+			if err := authSrv.Authorize(ctxAuth, auth); err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
+
+// AuthorizeDisposal executes the specified role and extracts the specified
+// disposal from the DB if a disposal id is specified in the call.
+func AuthorizeDisposal(log *logger.Logger, authSrv *authsrv.AuthSrv, disposalBus *disposalbus.Core, rule string) web.MidHandler {
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			userID, err := mid.GetUserID(ctx)
+			if err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			if id := web.Param(r, "disposal_id"); id != "" {
+				disposalID, err := uuid.Parse(id)
+				if err != nil {
+					return errs.New(errs.Unauthenticated, ErrInvalidID)
+				}
+
+				dsp, err := disposalBus.QueryByID(ctx, disposalID)
+				if err != nil {
+					switch {
+					case errors.Is(err, disposalbus.ErrNotFound):
+						return errs.New(errs.NotFound, err)
+					default:
+						return errs.Newf(errs.Internal, "querybyid: disposalID[%s]: %s", disposalID, err)
+					}
+				}
+
+				ctx = mid.SetDisposal(ctx, dsp)
+			}
+
+			ctxAuth, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+
+			auth := authsrv.Authorize{
+				Claims: mid.GetClaims(ctx),
+				UserID: userID,
+				Rule:   rule,
+			}
+
+			if err := authSrv.Authorize(ctxAuth, auth); err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
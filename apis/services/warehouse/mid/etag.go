@@ -0,0 +1,12 @@
+package mid
+
+import (
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+// RequireIfMatch rejects requests that don't say which version of the
+// resource they intend to change.
+func RequireIfMatch() web.MidHandler {
+	return mid.RequireIfMatch()
+}
@@ -0,0 +1,14 @@
+package mid
+
+import (
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+// ExecuteInTransaction starts a transaction around all the storage calls within
+// the scope of the handler function.
+func ExecuteInTransaction(log *logger.Logger, bgn transaction.Beginner) web.MidHandler {
+	return mid.ExecuteInTransaction(log, bgn)
+}
@@ -9,6 +9,23 @@ import (
 	"github.com/EnesDemirtas/medisync/app/api/authsrv"
 	"github.com/EnesDemirtas/medisync/app/api/mid"
 	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/EnesDemirtas/medisync/business/domain/allocationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/disposalbus"
+	"github.com/EnesDemirtas/medisync/business/domain/expirybus"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus"
+	"github.com/EnesDemirtas/medisync/business/domain/purchaseorderbus"
+	"github.com/EnesDemirtas/medisync/business/domain/recallbus"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stocktakebus"
+	"github.com/EnesDemirtas/medisync/business/domain/supplierbus"
+	"github.com/EnesDemirtas/medisync/business/domain/tagbus"
+	"github.com/EnesDemirtas/medisync/business/domain/temperaturebus"
+	"github.com/EnesDemirtas/medisync/business/domain/transferbus"
 	"github.com/EnesDemirtas/medisync/business/domain/userbus"
 	"github.com/EnesDemirtas/medisync/foundation/logger"
 	"github.com/EnesDemirtas/medisync/foundation/web"
@@ -30,8 +47,25 @@ func WithCORS(origins []string) func(opts *Options) {
 
 // BusDomain represents the set of core business packages.
 type BusDomain struct {
-	Delegate *delegate.Delegate
-	User     *userbus.Core
+	Delegate      *delegate.Delegate
+	User          *userbus.Core
+	Tag           *tagbus.Core
+	Medicine      *medicinebus.Core
+	Lot           *lotbus.Core
+	Inventory     *inventorybus.Core
+	Location      *locationbus.Core
+	Stock         *stockbus.Core
+	Transfer      *transferbus.Core
+	Expiry        *expirybus.Core
+	Reservation   *reservationbus.Core
+	Allocation    *allocationbus.Core
+	Order         *orderbus.Core
+	Supplier      *supplierbus.Core
+	PurchaseOrder *purchaseorderbus.Core
+	Stocktake     *stocktakebus.Core
+	Recall        *recallbus.Core
+	Disposal      *disposalbus.Core
+	Temperature   *temperaturebus.Core
 }
 
 // Config contains all the mandatory systems required by handlers.
@@ -0,0 +1,49 @@
+// Package allocationapi maintains the web based api for allocation access.
+package allocationapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/domain/allocationapp"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+type api struct {
+	allocationApp *allocationapp.Core
+}
+
+func newAPI(allocationApp *allocationapp.Core) *api {
+	return &api{
+		allocationApp: allocationApp,
+	}
+}
+
+func (api *api) plan(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app allocationapp.Request
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	plan, err := api.allocationApp.Plan(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, plan, http.StatusOK)
+}
+
+func (api *api) confirm(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app allocationapp.Confirmation
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	movs, err := api.allocationApp.Confirm(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, movs, http.StatusCreated)
+}
@@ -0,0 +1,37 @@
+package allocationapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mid"
+	"github.com/EnesDemirtas/medisync/app/api/authsrv"
+	"github.com/EnesDemirtas/medisync/app/domain/allocationapp"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/domain/allocationbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+	"github.com/jmoiron/sqlx"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	AllocationBus *allocationbus.Core
+	AuthSrv       *authsrv.AuthSrv
+	Log           *logger.Logger
+	DB            *sqlx.DB
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.Log, cfg.AuthSrv)
+	ruleAny := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAny)
+	countersign := mid.Countersign(cfg.Log, cfg.AuthSrv)
+	tran := mid.ExecuteInTransaction(cfg.Log, sqldb.NewBeginner(cfg.DB))
+
+	api := newAPI(allocationapp.NewCore(cfg.AllocationBus))
+	app.Handle(http.MethodPost, version, "/allocations/plan", api.plan, authen, ruleAny)
+	app.Handle(http.MethodPost, version, "/allocations/confirm", api.confirm, authen, ruleAny, countersign, tran)
+}
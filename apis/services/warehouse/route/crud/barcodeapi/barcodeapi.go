@@ -0,0 +1,35 @@
+// Package barcodeapi maintains the web based api for barcode lookups.
+package barcodeapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/domain/barcodeapp"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+type api struct {
+	barcodeApp *barcodeapp.Core
+}
+
+func newAPI(barcodeApp *barcodeapp.Core) *api {
+	return &api{
+		barcodeApp: barcodeApp,
+	}
+}
+
+func (api *api) lookup(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app barcodeapp.Scan
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	res, err := api.barcodeApp.Lookup(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, res, http.StatusOK)
+}
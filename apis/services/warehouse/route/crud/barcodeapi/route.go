@@ -0,0 +1,33 @@
+package barcodeapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mid"
+	"github.com/EnesDemirtas/medisync/app/api/authsrv"
+	"github.com/EnesDemirtas/medisync/app/domain/barcodeapp"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	MedicineBus *medicinebus.Core
+	LotBus      *lotbus.Core
+	AuthSrv     *authsrv.AuthSrv
+	Log         *logger.Logger
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.Log, cfg.AuthSrv)
+	ruleAny := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAny)
+
+	api := newAPI(barcodeapp.NewCore(cfg.MedicineBus, cfg.LotBus))
+	app.Handle(http.MethodPost, version, "/barcodes/lookup", api.lookup, authen, ruleAny)
+}
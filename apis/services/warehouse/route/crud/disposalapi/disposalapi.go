@@ -0,0 +1,81 @@
+// Package disposalapi maintains the web based api for disposal access.
+package disposalapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/domain/disposalapp"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+type api struct {
+	disposalApp *disposalapp.Core
+}
+
+func newAPI(disposalApp *disposalapp.Core) *api {
+	return &api{
+		disposalApp: disposalApp,
+	}
+}
+
+func (api *api) create(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app disposalapp.NewDisposal
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	dsp, err := api.disposalApp.Create(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, dsp, http.StatusCreated)
+}
+
+func (api *api) certify(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app disposalapp.NewCertificate
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	dsp, err := api.disposalApp.Certify(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, dsp, http.StatusOK)
+}
+
+func (api *api) query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return err
+	}
+
+	dsps, err := api.disposalApp.Query(ctx, qp)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, dsps, http.StatusOK)
+}
+
+func (api *api) queryByID(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	dsp, err := api.disposalApp.QueryByID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, dsp, http.StatusOK)
+}
+
+func (api *api) queryRegister(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	rgs, err := api.disposalApp.QueryRegister(ctx, parseRegisterParams(r))
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, rgs, http.StatusOK)
+}
@@ -0,0 +1,73 @@
+package disposalapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/app/domain/disposalapp"
+)
+
+func parseQueryParams(r *http.Request) (disposalapp.QueryParams, error) {
+	const (
+		orderBy                   = "orderBy"
+		filterByDisposalID        = "disposal_id"
+		filterByInventoryID       = "inventory_id"
+		filterByMethod            = "method"
+		filterByWitnessID         = "witness_id"
+		filterByStartDisposedDate = "start_disposed_date"
+		filterByEndDisposedDate   = "end_disposed_date"
+	)
+
+	values := r.URL.Query()
+
+	var filter disposalapp.QueryParams
+
+	pg, err := page.ParseHTTP(r)
+	if err != nil {
+		return disposalapp.QueryParams{}, err
+	}
+
+	filter.Page = pg.Number
+	filter.Rows = pg.RowsPerPage
+
+	if orderBy := values.Get(orderBy); orderBy != "" {
+		filter.OrderBy = orderBy
+	}
+
+	if disposalID := values.Get(filterByDisposalID); disposalID != "" {
+		filter.ID = disposalID
+	}
+
+	if inventoryID := values.Get(filterByInventoryID); inventoryID != "" {
+		filter.InventoryID = inventoryID
+	}
+
+	if method := values.Get(filterByMethod); method != "" {
+		filter.Method = method
+	}
+
+	if witnessID := values.Get(filterByWitnessID); witnessID != "" {
+		filter.WitnessID = witnessID
+	}
+
+	if startDate := values.Get(filterByStartDisposedDate); startDate != "" {
+		filter.StartDisposedDate = startDate
+	}
+
+	if endDate := values.Get(filterByEndDisposedDate); endDate != "" {
+		filter.EndDisposedDate = endDate
+	}
+
+	return filter, nil
+}
+
+func parseRegisterParams(r *http.Request) disposalapp.RegisterParams {
+	values := r.URL.Query()
+
+	return disposalapp.RegisterParams{
+		StartDate:   values.Get("start_date"),
+		EndDate:     values.Get("end_date"),
+		InventoryID: values.Get("inventory_id"),
+		MedicineID:  values.Get("medicine_id"),
+	}
+}
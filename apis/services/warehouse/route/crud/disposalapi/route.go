@@ -0,0 +1,44 @@
+package disposalapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mid"
+	"github.com/EnesDemirtas/medisync/app/api/authsrv"
+	"github.com/EnesDemirtas/medisync/app/domain/disposalapp"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/domain/disposalbus"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+	"github.com/jmoiron/sqlx"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	DisposalBus  *disposalbus.Core
+	InventoryBus *inventorybus.Core
+	AuthSrv      *authsrv.AuthSrv
+	Log          *logger.Logger
+	DB           *sqlx.DB
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.Log, cfg.AuthSrv)
+	ruleAny := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAny)
+	ruleAuthorizeInventory := mid.AuthorizeInventory(cfg.Log, cfg.AuthSrv, cfg.InventoryBus, auth.RuleAny)
+	ruleAuthorizeDisposal := mid.AuthorizeDisposal(cfg.Log, cfg.AuthSrv, cfg.DisposalBus, auth.RuleAny)
+	countersign := mid.Countersign(cfg.Log, cfg.AuthSrv)
+	tran := mid.ExecuteInTransaction(cfg.Log, sqldb.NewBeginner(cfg.DB))
+
+	api := newAPI(disposalapp.NewCore(cfg.DisposalBus))
+	app.Handle(http.MethodGet, version, "/disposals", api.query, authen, ruleAny)
+	app.Handle(http.MethodGet, version, "/disposals/{disposal_id}", api.queryByID, authen, ruleAuthorizeDisposal)
+	app.Handle(http.MethodPut, version, "/disposals/{disposal_id}/certificate", api.certify, authen, ruleAuthorizeDisposal)
+	app.Handle(http.MethodPost, version, "/inventories/{inventory_id}/disposals", api.create, authen, ruleAuthorizeInventory, countersign, tran)
+	app.Handle(http.MethodGet, version, "/disposal-register", api.queryRegister, authen, ruleAny)
+}
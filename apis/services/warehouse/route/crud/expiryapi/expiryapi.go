@@ -0,0 +1,34 @@
+// Package expiryapi maintains the web based api for expiry alert access.
+package expiryapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/domain/expiryapp"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+type api struct {
+	expiryApp *expiryapp.Core
+}
+
+func newAPI(expiryApp *expiryapp.Core) *api {
+	return &api{
+		expiryApp: expiryApp,
+	}
+}
+
+func (api *api) query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return err
+	}
+
+	alerts, err := api.expiryApp.Query(ctx, qp)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, alerts, http.StatusOK)
+}
@@ -0,0 +1,52 @@
+package expiryapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/app/domain/expiryapp"
+)
+
+func parseQueryParams(r *http.Request) (expiryapp.QueryParams, error) {
+	const (
+		orderBy             = "orderBy"
+		filterByInventoryID = "inventory_id"
+		filterByLotID       = "lot_id"
+		filterByMedicineID  = "medicine_id"
+		filterByExpired     = "expired"
+	)
+
+	values := r.URL.Query()
+
+	var filter expiryapp.QueryParams
+
+	pg, err := page.ParseHTTP(r)
+	if err != nil {
+		return expiryapp.QueryParams{}, err
+	}
+
+	filter.Page = pg.Number
+	filter.Rows = pg.RowsPerPage
+
+	if orderBy := values.Get(orderBy); orderBy != "" {
+		filter.OrderBy = orderBy
+	}
+
+	if inventoryID := values.Get(filterByInventoryID); inventoryID != "" {
+		filter.InventoryID = inventoryID
+	}
+
+	if lotID := values.Get(filterByLotID); lotID != "" {
+		filter.LotID = lotID
+	}
+
+	if medicineID := values.Get(filterByMedicineID); medicineID != "" {
+		filter.MedicineID = medicineID
+	}
+
+	if expired := values.Get(filterByExpired); expired != "" {
+		filter.Expired = expired
+	}
+
+	return filter, nil
+}
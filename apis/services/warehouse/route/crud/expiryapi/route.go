@@ -0,0 +1,31 @@
+package expiryapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mid"
+	"github.com/EnesDemirtas/medisync/app/api/authsrv"
+	"github.com/EnesDemirtas/medisync/app/domain/expiryapp"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/domain/expirybus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	ExpiryBus *expirybus.Core
+	AuthSrv   *authsrv.AuthSrv
+	Log       *logger.Logger
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.Log, cfg.AuthSrv)
+	ruleAny := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAny)
+
+	api := newAPI(expiryapp.NewCore(cfg.ExpiryBus))
+	app.Handle(http.MethodGet, version, "/expiry-alerts", api.query, authen, ruleAny)
+}
@@ -0,0 +1,70 @@
+package inventoryapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/app/domain/inventoryapp"
+)
+
+func parseQueryParams(r *http.Request) (inventoryapp.QueryParams, error) {
+	const (
+		orderBy             = "orderBy"
+		filterByInventoryID = "inventory_id"
+		filterByName        = "name"
+		filterByDescription = "description"
+		filterByMedicineID  = "medicine_id"
+	)
+
+	values := r.URL.Query()
+
+	var filter inventoryapp.QueryParams
+
+	pg, err := page.ParseHTTP(r)
+	if err != nil {
+		return inventoryapp.QueryParams{}, err
+	}
+
+	filter.Page = pg.Number
+	filter.Rows = pg.RowsPerPage
+
+	if orderBy := values.Get(orderBy); orderBy != "" {
+		filter.OrderBy = orderBy
+	}
+
+	if inventoryID := values.Get(filterByInventoryID); inventoryID != "" {
+		filter.ID = inventoryID
+	}
+
+	if name := values.Get(filterByName); name != "" {
+		filter.Name = name
+	}
+
+	if description := values.Get(filterByDescription); description != "" {
+		filter.Description = description
+	}
+
+	if medicineID := values.Get(filterByMedicineID); medicineID != "" {
+		filter.MedicineID = medicineID
+	}
+
+	return filter, nil
+}
+
+func parseStockLevelParams(r *http.Request) inventoryapp.StockLevelParams {
+	values := r.URL.Query()
+
+	return inventoryapp.StockLevelParams{
+		InventoryID: values.Get("inventory_id"),
+		MedicineID:  values.Get("medicine_id"),
+	}
+}
+
+func parseViolationParams(r *http.Request) inventoryapp.ViolationParams {
+	values := r.URL.Query()
+
+	return inventoryapp.ViolationParams{
+		InventoryID: values.Get("inventory_id"),
+		MedicineID:  values.Get("medicine_id"),
+	}
+}
@@ -0,0 +1,161 @@
+// Package inventoryapi maintains the web based api for inventory access.
+package inventoryapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/domain/inventoryapp"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+type api struct {
+	inventoryApp *inventoryapp.Core
+}
+
+func newAPI(inventoryApp *inventoryapp.Core) *api {
+	return &api{
+		inventoryApp: inventoryApp,
+	}
+}
+
+func (api *api) create(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app inventoryapp.NewInventory
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	inv, err := api.inventoryApp.Create(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, inv, http.StatusCreated)
+}
+
+func (api *api) update(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app inventoryapp.UpdateInventory
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	inv, err := api.inventoryApp.Update(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, inv, http.StatusOK)
+}
+
+func (api *api) delete(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	if err := api.inventoryApp.Delete(ctx); err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, nil, http.StatusNoContent)
+}
+
+func (api *api) query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return err
+	}
+
+	invs, err := api.inventoryApp.Query(ctx, qp)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, invs, http.StatusOK)
+}
+
+func (api *api) queryByID(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	if asOf := r.URL.Query().Get("asOf"); asOf != "" {
+		inv, err := api.inventoryApp.QueryByIDAsOf(ctx, asOf)
+		if err != nil {
+			return err
+		}
+
+		return web.Respond(ctx, w, inv, http.StatusOK)
+	}
+
+	inv, err := api.inventoryApp.QueryByID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, inv, http.StatusOK)
+}
+
+func (api *api) setReorderPoint(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app inventoryapp.NewReorderPoint
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	rp, err := api.inventoryApp.SetReorderPoint(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, rp, http.StatusOK)
+}
+
+func (api *api) deleteReorderPoint(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	if err := api.inventoryApp.DeleteReorderPoint(ctx); err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, nil, http.StatusNoContent)
+}
+
+func (api *api) queryReorderPoints(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	sls, err := api.inventoryApp.QueryReorderPoints(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, sls, http.StatusOK)
+}
+
+func (api *api) moveStatus(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app inventoryapp.StatusMove
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	lb, err := api.inventoryApp.MoveStatus(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, lb, http.StatusCreated)
+}
+
+func (api *api) queryBuckets(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	lbs, err := api.inventoryApp.QueryBuckets(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, lbs, http.StatusOK)
+}
+
+func (api *api) queryLowStock(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	sls, err := api.inventoryApp.QueryLowStock(ctx, parseStockLevelParams(r))
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, sls, http.StatusOK)
+}
+
+func (api *api) queryViolations(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	vls, err := api.inventoryApp.QueryViolations(ctx, parseViolationParams(r))
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, vls, http.StatusOK)
+}
@@ -0,0 +1,55 @@
+package inventoryapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mid"
+	"github.com/EnesDemirtas/medisync/app/api/authsrv"
+	"github.com/EnesDemirtas/medisync/app/domain/inventoryapp"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+	"github.com/jmoiron/sqlx"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	InventoryBus *inventorybus.Core
+	MedicineBus  *medicinebus.Core
+	StockBus     *stockbus.Core
+	AuthSrv      *authsrv.AuthSrv
+	Log          *logger.Logger
+	DB           *sqlx.DB
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.Log, cfg.AuthSrv)
+	ruleAny := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAny)
+	ruleAdmin := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAdminOnly)
+	ruleAuthorizeInventory := mid.AuthorizeInventory(cfg.Log, cfg.AuthSrv, cfg.InventoryBus, auth.RuleAny)
+	ruleAuthorizeInventoryAdmin := mid.AuthorizeInventory(cfg.Log, cfg.AuthSrv, cfg.InventoryBus, auth.RuleAdminOnly)
+	ruleAuthorizeMedicine := mid.AuthorizeMedicine(cfg.Log, cfg.AuthSrv, cfg.MedicineBus, auth.RuleAny)
+	ifMatch := mid.RequireIfMatch()
+	tran := mid.ExecuteInTransaction(cfg.Log, sqldb.NewBeginner(cfg.DB))
+
+	api := newAPI(inventoryapp.NewCore(cfg.InventoryBus, cfg.StockBus))
+	app.Handle(http.MethodGet, version, "/inventories", api.query, authen, ruleAny)
+	app.Handle(http.MethodGet, version, "/inventories/{inventory_id}", api.queryByID, authen, ruleAuthorizeInventory)
+	app.Handle(http.MethodPost, version, "/inventories", api.create, authen, ruleAdmin)
+	app.Handle(http.MethodPut, version, "/inventories/{inventory_id}", api.update, authen, ruleAuthorizeInventoryAdmin, ifMatch)
+	app.Handle(http.MethodDelete, version, "/inventories/{inventory_id}", api.delete, authen, ruleAuthorizeInventoryAdmin)
+	app.Handle(http.MethodGet, version, "/inventories/{inventory_id}/reorder-points", api.queryReorderPoints, authen, ruleAuthorizeInventory)
+	app.Handle(http.MethodPut, version, "/inventories/{inventory_id}/reorder-points/{medicine_id}", api.setReorderPoint, authen, ruleAuthorizeInventoryAdmin, ruleAuthorizeMedicine)
+	app.Handle(http.MethodDelete, version, "/inventories/{inventory_id}/reorder-points/{medicine_id}", api.deleteReorderPoint, authen, ruleAuthorizeInventoryAdmin, ruleAuthorizeMedicine)
+	app.Handle(http.MethodGet, version, "/inventories/{inventory_id}/buckets", api.queryBuckets, authen, ruleAuthorizeInventory)
+	app.Handle(http.MethodPost, version, "/inventories/{inventory_id}/status-moves", api.moveStatus, authen, ruleAuthorizeInventory, tran)
+	app.Handle(http.MethodGet, version, "/low-stock", api.queryLowStock, authen, ruleAny)
+	app.Handle(http.MethodGet, version, "/storage-violations", api.queryViolations, authen, ruleAny)
+}
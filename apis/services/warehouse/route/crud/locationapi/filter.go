@@ -0,0 +1,63 @@
+package locationapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/app/domain/locationapp"
+)
+
+func parseQueryParams(r *http.Request) (locationapp.QueryParams, error) {
+	const (
+		orderBy             = "orderBy"
+		filterByLocationID  = "location_id"
+		filterByInventoryID = "inventory_id"
+		filterByParentID    = "parent_id"
+		filterByKind        = "kind"
+	)
+
+	values := r.URL.Query()
+
+	var filter locationapp.QueryParams
+
+	pg, err := page.ParseHTTP(r)
+	if err != nil {
+		return locationapp.QueryParams{}, err
+	}
+
+	filter.Page = pg.Number
+	filter.Rows = pg.RowsPerPage
+
+	if orderBy := values.Get(orderBy); orderBy != "" {
+		filter.OrderBy = orderBy
+	}
+
+	if locationID := values.Get(filterByLocationID); locationID != "" {
+		filter.ID = locationID
+	}
+
+	if inventoryID := values.Get(filterByInventoryID); inventoryID != "" {
+		filter.InventoryID = inventoryID
+	}
+
+	if parentID := values.Get(filterByParentID); parentID != "" {
+		filter.ParentID = parentID
+	}
+
+	if kind := values.Get(filterByKind); kind != "" {
+		filter.Kind = kind
+	}
+
+	return filter, nil
+}
+
+func parsePlacementParams(r *http.Request) locationapp.PlacementParams {
+	values := r.URL.Query()
+
+	return locationapp.PlacementParams{
+		InventoryID: values.Get("inventory_id"),
+		LocationID:  values.Get("location_id"),
+		MedicineID:  values.Get("medicine_id"),
+		LotID:       values.Get("lot_id"),
+	}
+}
@@ -0,0 +1,112 @@
+// Package locationapi maintains the web based api for location access.
+package locationapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/domain/locationapp"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+type api struct {
+	locationApp *locationapp.Core
+}
+
+func newAPI(locationApp *locationapp.Core) *api {
+	return &api{
+		locationApp: locationApp,
+	}
+}
+
+func (api *api) create(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app locationapp.NewLocation
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	loc, err := api.locationApp.Create(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, loc, http.StatusCreated)
+}
+
+func (api *api) update(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app locationapp.UpdateLocation
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	loc, err := api.locationApp.Update(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, loc, http.StatusOK)
+}
+
+func (api *api) delete(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	if err := api.locationApp.Delete(ctx); err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, nil, http.StatusNoContent)
+}
+
+func (api *api) query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return err
+	}
+
+	locs, err := api.locationApp.Query(ctx, qp)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, locs, http.StatusOK)
+}
+
+func (api *api) queryByID(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	loc, err := api.locationApp.QueryByID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, loc, http.StatusOK)
+}
+
+func (api *api) relocate(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app locationapp.Relocation
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	placements, err := api.locationApp.Relocate(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, placements, http.StatusOK)
+}
+
+func (api *api) queryPlacements(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	placements, err := api.locationApp.QueryPlacements(ctx, parsePlacementParams(r))
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, placements, http.StatusOK)
+}
+
+func (api *api) queryRollups(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	rollups, err := api.locationApp.QueryRollups(ctx, parsePlacementParams(r))
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, rollups, http.StatusOK)
+}
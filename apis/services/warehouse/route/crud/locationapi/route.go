@@ -0,0 +1,48 @@
+package locationapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mid"
+	"github.com/EnesDemirtas/medisync/app/api/authsrv"
+	"github.com/EnesDemirtas/medisync/app/domain/locationapp"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+	"github.com/jmoiron/sqlx"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	LocationBus  *locationbus.Core
+	InventoryBus *inventorybus.Core
+	AuthSrv      *authsrv.AuthSrv
+	Log          *logger.Logger
+	DB           *sqlx.DB
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.Log, cfg.AuthSrv)
+	ruleAny := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAny)
+	ruleAuthorizeInventory := mid.AuthorizeInventory(cfg.Log, cfg.AuthSrv, cfg.InventoryBus, auth.RuleAny)
+	ruleAuthorizeInventoryAdmin := mid.AuthorizeInventory(cfg.Log, cfg.AuthSrv, cfg.InventoryBus, auth.RuleAdminOnly)
+	ruleAuthorizeLocation := mid.AuthorizeLocation(cfg.Log, cfg.AuthSrv, cfg.LocationBus, auth.RuleAny)
+	ruleAuthorizeLocationAdmin := mid.AuthorizeLocation(cfg.Log, cfg.AuthSrv, cfg.LocationBus, auth.RuleAdminOnly)
+	tran := mid.ExecuteInTransaction(cfg.Log, sqldb.NewBeginner(cfg.DB))
+
+	api := newAPI(locationapp.NewCore(cfg.LocationBus))
+	app.Handle(http.MethodGet, version, "/locations", api.query, authen, ruleAny)
+	app.Handle(http.MethodGet, version, "/locations/{location_id}", api.queryByID, authen, ruleAuthorizeLocation)
+	app.Handle(http.MethodPost, version, "/inventories/{inventory_id}/locations", api.create, authen, ruleAuthorizeInventoryAdmin)
+	app.Handle(http.MethodPut, version, "/locations/{location_id}", api.update, authen, ruleAuthorizeLocationAdmin)
+	app.Handle(http.MethodDelete, version, "/locations/{location_id}", api.delete, authen, ruleAuthorizeLocationAdmin)
+	app.Handle(http.MethodPost, version, "/inventories/{inventory_id}/relocations", api.relocate, authen, ruleAuthorizeInventory, tran)
+	app.Handle(http.MethodGet, version, "/inventories/{inventory_id}/rollups", api.queryRollups, authen, ruleAuthorizeInventory)
+	app.Handle(http.MethodGet, version, "/placements", api.queryPlacements, authen, ruleAny)
+}
@@ -0,0 +1,62 @@
+package lotapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/app/domain/lotapp"
+)
+
+func parseQueryParams(r *http.Request) (lotapp.QueryParams, error) {
+	const (
+		orderBy                 = "orderBy"
+		filterByLotID           = "lot_id"
+		filterByMedicineID      = "medicine_id"
+		filterByNumber          = "lot_number"
+		filterByStartExpiryDate = "start_expiry_date"
+		filterByEndExpiryDate   = "end_expiry_date"
+		filterByQuarantined     = "quarantined"
+	)
+
+	values := r.URL.Query()
+
+	var filter lotapp.QueryParams
+
+	pg, err := page.ParseHTTP(r)
+	if err != nil {
+		return lotapp.QueryParams{}, err
+	}
+
+	filter.Page = pg.Number
+	filter.Rows = pg.RowsPerPage
+
+	if orderBy := values.Get(orderBy); orderBy != "" {
+		filter.OrderBy = orderBy
+	}
+
+	if lotID := values.Get(filterByLotID); lotID != "" {
+		filter.ID = lotID
+	}
+
+	if medicineID := values.Get(filterByMedicineID); medicineID != "" {
+		filter.MedicineID = medicineID
+	}
+
+	if number := values.Get(filterByNumber); number != "" {
+		filter.Number = number
+	}
+
+	if startDate := values.Get(filterByStartExpiryDate); startDate != "" {
+		filter.StartExpiryDate = startDate
+	}
+
+	if endDate := values.Get(filterByEndExpiryDate); endDate != "" {
+		filter.EndExpiryDate = endDate
+	}
+
+	if quarantined := values.Get(filterByQuarantined); quarantined != "" {
+		filter.Quarantined = quarantined
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,80 @@
+// Package lotapi maintains the web based api for lot access.
+package lotapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/domain/lotapp"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+type api struct {
+	lotApp *lotapp.Core
+}
+
+func newAPI(lotApp *lotapp.Core) *api {
+	return &api{
+		lotApp: lotApp,
+	}
+}
+
+func (api *api) create(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app lotapp.NewLot
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	lot, err := api.lotApp.Create(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, lot, http.StatusCreated)
+}
+
+func (api *api) update(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app lotapp.UpdateLot
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	lot, err := api.lotApp.Update(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, lot, http.StatusOK)
+}
+
+func (api *api) delete(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	if err := api.lotApp.Delete(ctx); err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, nil, http.StatusNoContent)
+}
+
+func (api *api) query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return err
+	}
+
+	lots, err := api.lotApp.Query(ctx, qp)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, lots, http.StatusOK)
+}
+
+func (api *api) queryByID(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	lot, err := api.lotApp.QueryByID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, lot, http.StatusOK)
+}
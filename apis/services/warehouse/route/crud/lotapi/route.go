@@ -0,0 +1,38 @@
+package lotapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mid"
+	"github.com/EnesDemirtas/medisync/app/api/authsrv"
+	"github.com/EnesDemirtas/medisync/app/domain/lotapp"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	LotBus  *lotbus.Core
+	AuthSrv *authsrv.AuthSrv
+	Log     *logger.Logger
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.Log, cfg.AuthSrv)
+	ruleAny := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAny)
+	ruleAdmin := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAdminOnly)
+	ruleAuthorizeLot := mid.AuthorizeLot(cfg.Log, cfg.AuthSrv, cfg.LotBus, auth.RuleAny)
+	ruleAuthorizeLotAdmin := mid.AuthorizeLot(cfg.Log, cfg.AuthSrv, cfg.LotBus, auth.RuleAdminOnly)
+
+	api := newAPI(lotapp.NewCore(cfg.LotBus))
+	app.Handle(http.MethodGet, version, "/lots", api.query, authen, ruleAny)
+	app.Handle(http.MethodGet, version, "/lots/{lot_id}", api.queryByID, authen, ruleAuthorizeLot)
+	app.Handle(http.MethodPost, version, "/lots", api.create, authen, ruleAdmin)
+	app.Handle(http.MethodPut, version, "/lots/{lot_id}", api.update, authen, ruleAuthorizeLotAdmin)
+	app.Handle(http.MethodDelete, version, "/lots/{lot_id}", api.delete, authen, ruleAuthorizeLotAdmin)
+}
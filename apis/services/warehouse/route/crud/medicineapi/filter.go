@@ -0,0 +1,83 @@
+package medicineapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/app/domain/medicineapp"
+)
+
+func parseQueryParams(r *http.Request) (medicineapp.QueryParams, error) {
+	const (
+		orderBy                 = "orderBy"
+		filterByMedicineID      = "medicine_id"
+		filterByName            = "name"
+		filterByDescription     = "description"
+		filterByManufacturer    = "manufacturer"
+		filterByType            = "type"
+		filterByGTIN            = "gtin"
+		filterBySchedule        = "schedule"
+		filterByTags            = "tags"
+		filterByStartExpiryDate = "start_expiry_date"
+		filterByEndExpiryDate   = "end_expiry_date"
+	)
+
+	values := r.URL.Query()
+
+	var filter medicineapp.QueryParams
+
+	pg, err := page.ParseHTTP(r)
+	if err != nil {
+		return medicineapp.QueryParams{}, err
+	}
+
+	filter.Page = pg.Number
+	filter.Rows = pg.RowsPerPage
+
+	if orderBy := values.Get(orderBy); orderBy != "" {
+		filter.OrderBy = orderBy
+	}
+
+	if medicineID := values.Get(filterByMedicineID); medicineID != "" {
+		filter.ID = medicineID
+	}
+
+	if name := values.Get(filterByName); name != "" {
+		filter.Name = name
+	}
+
+	if description := values.Get(filterByDescription); description != "" {
+		filter.Description = description
+	}
+
+	if manufacturer := values.Get(filterByManufacturer); manufacturer != "" {
+		filter.Manufacturer = manufacturer
+	}
+
+	if mtype := values.Get(filterByType); mtype != "" {
+		filter.Type = mtype
+	}
+
+	if gtin := values.Get(filterByGTIN); gtin != "" {
+		filter.GTIN = gtin
+	}
+
+	if schedule := values.Get(filterBySchedule); schedule != "" {
+		filter.Schedule = schedule
+	}
+
+	if tags := values.Get(filterByTags); tags != "" {
+		filter.Tags = strings.Split(tags, ",")
+	}
+
+	if startDate := values.Get(filterByStartExpiryDate); startDate != "" {
+		filter.StartExpiryDate = startDate
+	}
+
+	if endDate := values.Get(filterByEndExpiryDate); endDate != "" {
+		filter.EndExpiryDate = endDate
+	}
+
+	return filter, nil
+}
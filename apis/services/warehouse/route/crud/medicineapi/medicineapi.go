@@ -0,0 +1,80 @@
+// Package medicineapi maintains the web based api for medicine access.
+package medicineapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/domain/medicineapp"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+type api struct {
+	medicineApp *medicineapp.Core
+}
+
+func newAPI(medicineApp *medicineapp.Core) *api {
+	return &api{
+		medicineApp: medicineApp,
+	}
+}
+
+func (api *api) create(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app medicineapp.NewMedicine
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	med, err := api.medicineApp.Create(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, med, http.StatusCreated)
+}
+
+func (api *api) update(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app medicineapp.UpdateMedicine
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	med, err := api.medicineApp.Update(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, med, http.StatusOK)
+}
+
+func (api *api) delete(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	if err := api.medicineApp.Delete(ctx); err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, nil, http.StatusNoContent)
+}
+
+func (api *api) query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return err
+	}
+
+	meds, err := api.medicineApp.Query(ctx, qp)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, meds, http.StatusOK)
+}
+
+func (api *api) queryByID(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	med, err := api.medicineApp.QueryByID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, med, http.StatusOK)
+}
@@ -0,0 +1,39 @@
+package medicineapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mid"
+	"github.com/EnesDemirtas/medisync/app/api/authsrv"
+	"github.com/EnesDemirtas/medisync/app/domain/medicineapp"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	MedicineBus *medicinebus.Core
+	AuthSrv     *authsrv.AuthSrv
+	Log         *logger.Logger
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.Log, cfg.AuthSrv)
+	ruleAny := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAny)
+	ruleAdmin := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAdminOnly)
+	ruleAuthorizeMedicine := mid.AuthorizeMedicine(cfg.Log, cfg.AuthSrv, cfg.MedicineBus, auth.RuleAny)
+	ruleAuthorizeMedicineAdmin := mid.AuthorizeMedicine(cfg.Log, cfg.AuthSrv, cfg.MedicineBus, auth.RuleAdminOnly)
+	ifMatch := mid.RequireIfMatch()
+
+	api := newAPI(medicineapp.NewCore(cfg.MedicineBus))
+	app.Handle(http.MethodGet, version, "/medicines", api.query, authen, ruleAny)
+	app.Handle(http.MethodGet, version, "/medicines/{medicine_id}", api.queryByID, authen, ruleAuthorizeMedicine)
+	app.Handle(http.MethodPost, version, "/medicines", api.create, authen, ruleAdmin)
+	app.Handle(http.MethodPut, version, "/medicines/{medicine_id}", api.update, authen, ruleAuthorizeMedicineAdmin, ifMatch)
+	app.Handle(http.MethodDelete, version, "/medicines/{medicine_id}", api.delete, authen, ruleAuthorizeMedicineAdmin)
+}
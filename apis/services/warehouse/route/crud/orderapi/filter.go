@@ -0,0 +1,52 @@
+package orderapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/app/domain/orderapp"
+)
+
+func parseQueryParams(r *http.Request) (orderapp.QueryParams, error) {
+	const (
+		orderBy                 = "orderBy"
+		filterByOrderID         = "order_id"
+		filterByInventoryID     = "inventory_id"
+		filterByStatus          = "status"
+		filterByDestinationType = "destination_type"
+	)
+
+	values := r.URL.Query()
+
+	var filter orderapp.QueryParams
+
+	pg, err := page.ParseHTTP(r)
+	if err != nil {
+		return orderapp.QueryParams{}, err
+	}
+
+	filter.Page = pg.Number
+	filter.Rows = pg.RowsPerPage
+
+	if orderBy := values.Get(orderBy); orderBy != "" {
+		filter.OrderBy = orderBy
+	}
+
+	if orderID := values.Get(filterByOrderID); orderID != "" {
+		filter.ID = orderID
+	}
+
+	if inventoryID := values.Get(filterByInventoryID); inventoryID != "" {
+		filter.InventoryID = inventoryID
+	}
+
+	if status := values.Get(filterByStatus); status != "" {
+		filter.Status = status
+	}
+
+	if destinationType := values.Get(filterByDestinationType); destinationType != "" {
+		filter.DestinationType = destinationType
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,113 @@
+// Package orderapi maintains the web based api for order access.
+package orderapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/domain/orderapp"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+type api struct {
+	orderApp *orderapp.Core
+}
+
+func newAPI(orderApp *orderapp.Core) *api {
+	return &api{
+		orderApp: orderApp,
+	}
+}
+
+func (api *api) create(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app orderapp.NewOrder
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	ord, err := api.orderApp.Create(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, ord, http.StatusCreated)
+}
+
+func (api *api) update(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app orderapp.UpdateOrder
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	ord, err := api.orderApp.Update(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, ord, http.StatusOK)
+}
+
+func (api *api) approve(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	ord, err := api.orderApp.Approve(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, ord, http.StatusOK)
+}
+
+func (api *api) pick(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app orderapp.Picking
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	ord, err := api.orderApp.Pick(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, ord, http.StatusOK)
+}
+
+func (api *api) ship(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	ord, err := api.orderApp.Ship(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, ord, http.StatusOK)
+}
+
+func (api *api) cancel(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	ord, err := api.orderApp.Cancel(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, ord, http.StatusOK)
+}
+
+func (api *api) query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return err
+	}
+
+	ords, err := api.orderApp.Query(ctx, qp)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, ords, http.StatusOK)
+}
+
+func (api *api) queryByID(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	ord, err := api.orderApp.QueryByID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, ord, http.StatusOK)
+}
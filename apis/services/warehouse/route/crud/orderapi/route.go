@@ -0,0 +1,48 @@
+package orderapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mid"
+	"github.com/EnesDemirtas/medisync/app/api/authsrv"
+	"github.com/EnesDemirtas/medisync/app/domain/orderapp"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+	"github.com/jmoiron/sqlx"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	OrderBus     *orderbus.Core
+	InventoryBus *inventorybus.Core
+	AuthSrv      *authsrv.AuthSrv
+	Log          *logger.Logger
+	DB           *sqlx.DB
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.Log, cfg.AuthSrv)
+	ruleAny := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAny)
+	ruleAuthorizeInventory := mid.AuthorizeInventory(cfg.Log, cfg.AuthSrv, cfg.InventoryBus, auth.RuleAny)
+	ruleAuthorizeOrder := mid.AuthorizeOrder(cfg.Log, cfg.AuthSrv, cfg.OrderBus, auth.RuleAny)
+	ruleAuthorizeOrderAdmin := mid.AuthorizeOrder(cfg.Log, cfg.AuthSrv, cfg.OrderBus, auth.RuleAdminOnly)
+	countersign := mid.Countersign(cfg.Log, cfg.AuthSrv)
+	tran := mid.ExecuteInTransaction(cfg.Log, sqldb.NewBeginner(cfg.DB))
+
+	api := newAPI(orderapp.NewCore(cfg.OrderBus))
+	app.Handle(http.MethodGet, version, "/orders", api.query, authen, ruleAny)
+	app.Handle(http.MethodGet, version, "/orders/{order_id}", api.queryByID, authen, ruleAuthorizeOrder)
+	app.Handle(http.MethodPost, version, "/inventories/{inventory_id}/orders", api.create, authen, ruleAuthorizeInventory, tran)
+	app.Handle(http.MethodPut, version, "/orders/{order_id}", api.update, authen, ruleAuthorizeOrder, tran)
+	app.Handle(http.MethodPost, version, "/orders/{order_id}/approve", api.approve, authen, ruleAuthorizeOrderAdmin, tran)
+	app.Handle(http.MethodPost, version, "/orders/{order_id}/pick", api.pick, authen, ruleAuthorizeOrder, tran)
+	app.Handle(http.MethodPost, version, "/orders/{order_id}/ship", api.ship, authen, ruleAuthorizeOrder, countersign, tran)
+	app.Handle(http.MethodPost, version, "/orders/{order_id}/cancel", api.cancel, authen, ruleAuthorizeOrder, tran)
+}
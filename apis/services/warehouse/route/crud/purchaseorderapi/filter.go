@@ -0,0 +1,52 @@
+package purchaseorderapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/app/domain/purchaseorderapp"
+)
+
+func parseQueryParams(r *http.Request) (purchaseorderapp.QueryParams, error) {
+	const (
+		orderBy                 = "orderBy"
+		filterByPurchaseOrderID = "purchase_order_id"
+		filterBySupplierID      = "supplier_id"
+		filterByInventoryID     = "inventory_id"
+		filterByStatus          = "status"
+	)
+
+	values := r.URL.Query()
+
+	var filter purchaseorderapp.QueryParams
+
+	pg, err := page.ParseHTTP(r)
+	if err != nil {
+		return purchaseorderapp.QueryParams{}, err
+	}
+
+	filter.Page = pg.Number
+	filter.Rows = pg.RowsPerPage
+
+	if orderBy := values.Get(orderBy); orderBy != "" {
+		filter.OrderBy = orderBy
+	}
+
+	if purchaseOrderID := values.Get(filterByPurchaseOrderID); purchaseOrderID != "" {
+		filter.ID = purchaseOrderID
+	}
+
+	if supplierID := values.Get(filterBySupplierID); supplierID != "" {
+		filter.SupplierID = supplierID
+	}
+
+	if inventoryID := values.Get(filterByInventoryID); inventoryID != "" {
+		filter.InventoryID = inventoryID
+	}
+
+	if status := values.Get(filterByStatus); status != "" {
+		filter.Status = status
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,132 @@
+// Package purchaseorderapi maintains the web based api for purchase order
+// access.
+package purchaseorderapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/domain/purchaseorderapp"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+type api struct {
+	purchaseOrderApp *purchaseorderapp.Core
+}
+
+func newAPI(purchaseOrderApp *purchaseorderapp.Core) *api {
+	return &api{
+		purchaseOrderApp: purchaseOrderApp,
+	}
+}
+
+func (api *api) create(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app purchaseorderapp.NewPurchaseOrder
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	po, err := api.purchaseOrderApp.Create(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, po, http.StatusCreated)
+}
+
+func (api *api) update(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app purchaseorderapp.UpdatePurchaseOrder
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	po, err := api.purchaseOrderApp.Update(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, po, http.StatusOK)
+}
+
+func (api *api) approve(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	po, err := api.purchaseOrderApp.Approve(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, po, http.StatusOK)
+}
+
+func (api *api) send(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	po, err := api.purchaseOrderApp.Send(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, po, http.StatusOK)
+}
+
+func (api *api) receive(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app purchaseorderapp.NewDelivery
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	rcpt, err := api.purchaseOrderApp.Receive(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, rcpt, http.StatusCreated)
+}
+
+func (api *api) close(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	po, err := api.purchaseOrderApp.Close(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, po, http.StatusOK)
+}
+
+func (api *api) cancel(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	po, err := api.purchaseOrderApp.Cancel(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, po, http.StatusOK)
+}
+
+func (api *api) query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return err
+	}
+
+	pos, err := api.purchaseOrderApp.Query(ctx, qp)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, pos, http.StatusOK)
+}
+
+func (api *api) queryByID(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	po, err := api.purchaseOrderApp.QueryByID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, po, http.StatusOK)
+}
+
+func (api *api) queryDeliveries(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	dlvs, err := api.purchaseOrderApp.QueryDeliveries(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, dlvs, http.StatusOK)
+}
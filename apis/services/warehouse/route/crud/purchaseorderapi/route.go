@@ -0,0 +1,50 @@
+package purchaseorderapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mid"
+	"github.com/EnesDemirtas/medisync/app/api/authsrv"
+	"github.com/EnesDemirtas/medisync/app/domain/purchaseorderapp"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/purchaseorderbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+	"github.com/jmoiron/sqlx"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	PurchaseOrderBus *purchaseorderbus.Core
+	InventoryBus     *inventorybus.Core
+	AuthSrv          *authsrv.AuthSrv
+	Log              *logger.Logger
+	DB               *sqlx.DB
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.Log, cfg.AuthSrv)
+	ruleAny := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAny)
+	ruleAuthorizeInventory := mid.AuthorizeInventory(cfg.Log, cfg.AuthSrv, cfg.InventoryBus, auth.RuleAny)
+	ruleAuthorizePurchaseOrder := mid.AuthorizePurchaseOrder(cfg.Log, cfg.AuthSrv, cfg.PurchaseOrderBus, auth.RuleAny)
+	ruleAuthorizePurchaseOrderAdmin := mid.AuthorizePurchaseOrder(cfg.Log, cfg.AuthSrv, cfg.PurchaseOrderBus, auth.RuleAdminOnly)
+	countersign := mid.Countersign(cfg.Log, cfg.AuthSrv)
+	tran := mid.ExecuteInTransaction(cfg.Log, sqldb.NewBeginner(cfg.DB))
+
+	api := newAPI(purchaseorderapp.NewCore(cfg.PurchaseOrderBus))
+	app.Handle(http.MethodGet, version, "/purchase-orders", api.query, authen, ruleAny)
+	app.Handle(http.MethodGet, version, "/purchase-orders/{purchase_order_id}", api.queryByID, authen, ruleAuthorizePurchaseOrder)
+	app.Handle(http.MethodGet, version, "/purchase-orders/{purchase_order_id}/deliveries", api.queryDeliveries, authen, ruleAuthorizePurchaseOrder)
+	app.Handle(http.MethodPost, version, "/inventories/{inventory_id}/purchase-orders", api.create, authen, ruleAuthorizeInventory, tran)
+	app.Handle(http.MethodPut, version, "/purchase-orders/{purchase_order_id}", api.update, authen, ruleAuthorizePurchaseOrder, tran)
+	app.Handle(http.MethodPost, version, "/purchase-orders/{purchase_order_id}/approve", api.approve, authen, ruleAuthorizePurchaseOrderAdmin, tran)
+	app.Handle(http.MethodPost, version, "/purchase-orders/{purchase_order_id}/send", api.send, authen, ruleAuthorizePurchaseOrder, tran)
+	app.Handle(http.MethodPost, version, "/purchase-orders/{purchase_order_id}/deliveries", api.receive, authen, ruleAuthorizePurchaseOrder, countersign, tran)
+	app.Handle(http.MethodPost, version, "/purchase-orders/{purchase_order_id}/close", api.close, authen, ruleAuthorizePurchaseOrderAdmin, tran)
+	app.Handle(http.MethodPost, version, "/purchase-orders/{purchase_order_id}/cancel", api.cancel, authen, ruleAuthorizePurchaseOrder, tran)
+}
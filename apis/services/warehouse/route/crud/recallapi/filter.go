@@ -0,0 +1,57 @@
+package recallapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/app/domain/recallapp"
+)
+
+func parseQueryParams(r *http.Request) (recallapp.QueryParams, error) {
+	const (
+		orderBy            = "orderBy"
+		filterByRecallID   = "recall_id"
+		filterByMedicineID = "medicine_id"
+		filterByLotID      = "lot_id"
+		filterBySeverity   = "severity"
+		filterByStatus     = "status"
+	)
+
+	values := r.URL.Query()
+
+	var filter recallapp.QueryParams
+
+	pg, err := page.ParseHTTP(r)
+	if err != nil {
+		return recallapp.QueryParams{}, err
+	}
+
+	filter.Page = pg.Number
+	filter.Rows = pg.RowsPerPage
+
+	if orderBy := values.Get(orderBy); orderBy != "" {
+		filter.OrderBy = orderBy
+	}
+
+	if recallID := values.Get(filterByRecallID); recallID != "" {
+		filter.ID = recallID
+	}
+
+	if medicineID := values.Get(filterByMedicineID); medicineID != "" {
+		filter.MedicineID = medicineID
+	}
+
+	if lotID := values.Get(filterByLotID); lotID != "" {
+		filter.LotID = lotID
+	}
+
+	if severity := values.Get(filterBySeverity); severity != "" {
+		filter.Severity = severity
+	}
+
+	if status := values.Get(filterByStatus); status != "" {
+		filter.Status = status
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,90 @@
+// Package recallapi maintains the web based api for recall access.
+package recallapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/domain/recallapp"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+type api struct {
+	recallApp *recallapp.Core
+}
+
+func newAPI(recallApp *recallapp.Core) *api {
+	return &api{
+		recallApp: recallApp,
+	}
+}
+
+func (api *api) create(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app recallapp.NewRecall
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	rcl, err := api.recallApp.Create(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, rcl, http.StatusCreated)
+}
+
+func (api *api) resolve(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app recallapp.Resolution
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	rcl, err := api.recallApp.Resolve(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, rcl, http.StatusOK)
+}
+
+func (api *api) cancel(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	rcl, err := api.recallApp.Cancel(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, rcl, http.StatusOK)
+}
+
+func (api *api) query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return err
+	}
+
+	rcls, err := api.recallApp.Query(ctx, qp)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, rcls, http.StatusOK)
+}
+
+func (api *api) queryByID(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	rcl, err := api.recallApp.QueryByID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, rcl, http.StatusOK)
+}
+
+func (api *api) queryReport(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	rpt, err := api.recallApp.QueryReport(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, rpt, http.StatusOK)
+}
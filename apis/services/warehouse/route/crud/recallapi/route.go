@@ -0,0 +1,44 @@
+package recallapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mid"
+	"github.com/EnesDemirtas/medisync/app/api/authsrv"
+	"github.com/EnesDemirtas/medisync/app/domain/recallapp"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/domain/recallbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+	"github.com/jmoiron/sqlx"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	RecallBus *recallbus.Core
+	AuthSrv   *authsrv.AuthSrv
+	Log       *logger.Logger
+	DB        *sqlx.DB
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.Log, cfg.AuthSrv)
+	ruleAny := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAny)
+	ruleAdmin := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAdminOnly)
+	ruleAuthorizeRecall := mid.AuthorizeRecall(cfg.Log, cfg.AuthSrv, cfg.RecallBus, auth.RuleAny)
+	ruleAuthorizeRecallAdmin := mid.AuthorizeRecall(cfg.Log, cfg.AuthSrv, cfg.RecallBus, auth.RuleAdminOnly)
+	countersign := mid.Countersign(cfg.Log, cfg.AuthSrv)
+	tran := mid.ExecuteInTransaction(cfg.Log, sqldb.NewBeginner(cfg.DB))
+
+	api := newAPI(recallapp.NewCore(cfg.RecallBus))
+	app.Handle(http.MethodGet, version, "/recalls", api.query, authen, ruleAny)
+	app.Handle(http.MethodGet, version, "/recalls/{recall_id}", api.queryByID, authen, ruleAuthorizeRecall)
+	app.Handle(http.MethodGet, version, "/recalls/{recall_id}/report", api.queryReport, authen, ruleAuthorizeRecall)
+	app.Handle(http.MethodPost, version, "/recalls", api.create, authen, ruleAdmin, tran)
+	app.Handle(http.MethodPost, version, "/recalls/{recall_id}/resolutions", api.resolve, authen, ruleAuthorizeRecall, countersign, tran)
+	app.Handle(http.MethodPost, version, "/recalls/{recall_id}/cancel", api.cancel, authen, ruleAuthorizeRecallAdmin, tran)
+}
@@ -0,0 +1,52 @@
+package reservationapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/app/domain/reservationapp"
+)
+
+func parseQueryParams(r *http.Request) (reservationapp.QueryParams, error) {
+	const (
+		orderBy               = "orderBy"
+		filterByReservationID = "reservation_id"
+		filterByInventoryID   = "inventory_id"
+		filterByMedicineID    = "medicine_id"
+		filterByStatus        = "status"
+	)
+
+	values := r.URL.Query()
+
+	var filter reservationapp.QueryParams
+
+	pg, err := page.ParseHTTP(r)
+	if err != nil {
+		return reservationapp.QueryParams{}, err
+	}
+
+	filter.Page = pg.Number
+	filter.Rows = pg.RowsPerPage
+
+	if orderBy := values.Get(orderBy); orderBy != "" {
+		filter.OrderBy = orderBy
+	}
+
+	if reservationID := values.Get(filterByReservationID); reservationID != "" {
+		filter.ID = reservationID
+	}
+
+	if inventoryID := values.Get(filterByInventoryID); inventoryID != "" {
+		filter.InventoryID = inventoryID
+	}
+
+	if medicineID := values.Get(filterByMedicineID); medicineID != "" {
+		filter.MedicineID = medicineID
+	}
+
+	if status := values.Get(filterByStatus); status != "" {
+		filter.Status = status
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,90 @@
+// Package reservationapi maintains the web based api for reservation access.
+package reservationapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/domain/reservationapp"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+type api struct {
+	reservationApp *reservationapp.Core
+}
+
+func newAPI(reservationApp *reservationapp.Core) *api {
+	return &api{
+		reservationApp: reservationApp,
+	}
+}
+
+func (api *api) reserve(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app reservationapp.NewReservation
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	res, err := api.reservationApp.Reserve(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, res, http.StatusCreated)
+}
+
+func (api *api) consume(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app reservationapp.Consumption
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	res, err := api.reservationApp.Consume(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, res, http.StatusOK)
+}
+
+func (api *api) release(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	res, err := api.reservationApp.Release(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, res, http.StatusOK)
+}
+
+func (api *api) queryAvailability(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	avs, err := api.reservationApp.QueryAvailability(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, avs, http.StatusOK)
+}
+
+func (api *api) query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return err
+	}
+
+	rss, err := api.reservationApp.Query(ctx, qp)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, rss, http.StatusOK)
+}
+
+func (api *api) queryByID(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	res, err := api.reservationApp.QueryByID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, res, http.StatusOK)
+}
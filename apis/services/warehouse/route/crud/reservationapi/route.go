@@ -0,0 +1,45 @@
+package reservationapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mid"
+	"github.com/EnesDemirtas/medisync/app/api/authsrv"
+	"github.com/EnesDemirtas/medisync/app/domain/reservationapp"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+	"github.com/jmoiron/sqlx"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	ReservationBus *reservationbus.Core
+	InventoryBus   *inventorybus.Core
+	AuthSrv        *authsrv.AuthSrv
+	Log            *logger.Logger
+	DB             *sqlx.DB
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.Log, cfg.AuthSrv)
+	ruleAny := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAny)
+	ruleAuthorizeInventory := mid.AuthorizeInventory(cfg.Log, cfg.AuthSrv, cfg.InventoryBus, auth.RuleAny)
+	ruleAuthorizeReservation := mid.AuthorizeReservation(cfg.Log, cfg.AuthSrv, cfg.ReservationBus, auth.RuleAny)
+	countersign := mid.Countersign(cfg.Log, cfg.AuthSrv)
+	tran := mid.ExecuteInTransaction(cfg.Log, sqldb.NewBeginner(cfg.DB))
+
+	api := newAPI(reservationapp.NewCore(cfg.ReservationBus))
+	app.Handle(http.MethodGet, version, "/reservations", api.query, authen, ruleAny)
+	app.Handle(http.MethodGet, version, "/reservations/{reservation_id}", api.queryByID, authen, ruleAuthorizeReservation)
+	app.Handle(http.MethodGet, version, "/inventories/{inventory_id}/availability", api.queryAvailability, authen, ruleAuthorizeInventory)
+	app.Handle(http.MethodPost, version, "/inventories/{inventory_id}/reservations", api.reserve, authen, ruleAuthorizeInventory, tran)
+	app.Handle(http.MethodPost, version, "/reservations/{reservation_id}/dispense", api.consume, authen, ruleAuthorizeReservation, countersign, tran)
+	app.Handle(http.MethodDelete, version, "/reservations/{reservation_id}", api.release, authen, ruleAuthorizeReservation, tran)
+}
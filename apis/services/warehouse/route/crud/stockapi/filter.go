@@ -0,0 +1,83 @@
+package stockapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/app/domain/stockapp"
+)
+
+func parseQueryParams(r *http.Request) (stockapp.QueryParams, error) {
+	const (
+		orderBy                  = "orderBy"
+		filterByMedicineID       = "medicine_id"
+		filterByLotID            = "lot_id"
+		filterByType             = "type"
+		filterByUserID           = "user_id"
+		filterByStartCreatedDate = "start_created_date"
+		filterByEndCreatedDate   = "end_created_date"
+	)
+
+	values := r.URL.Query()
+
+	var filter stockapp.QueryParams
+
+	pg, err := page.ParseHTTP(r)
+	if err != nil {
+		return stockapp.QueryParams{}, err
+	}
+
+	filter.Page = pg.Number
+	filter.Rows = pg.RowsPerPage
+
+	if orderBy := values.Get(orderBy); orderBy != "" {
+		filter.OrderBy = orderBy
+	}
+
+	if medicineID := values.Get(filterByMedicineID); medicineID != "" {
+		filter.MedicineID = medicineID
+	}
+
+	if lotID := values.Get(filterByLotID); lotID != "" {
+		filter.LotID = lotID
+	}
+
+	if typ := values.Get(filterByType); typ != "" {
+		filter.Type = typ
+	}
+
+	if userID := values.Get(filterByUserID); userID != "" {
+		filter.UserID = userID
+	}
+
+	if startDate := values.Get(filterByStartCreatedDate); startDate != "" {
+		filter.StartCreatedDate = startDate
+	}
+
+	if endDate := values.Get(filterByEndCreatedDate); endDate != "" {
+		filter.EndCreatedDate = endDate
+	}
+
+	return filter, nil
+}
+
+func parseHoldingParams(r *http.Request) stockapp.HoldingParams {
+	values := r.URL.Query()
+
+	return stockapp.HoldingParams{
+		AsOf:        values.Get("asOf"),
+		InventoryID: values.Get("inventory_id"),
+		MedicineID:  values.Get("medicine_id"),
+		LotID:       values.Get("lot_id"),
+	}
+}
+
+func parseRegisterParams(r *http.Request) stockapp.RegisterParams {
+	values := r.URL.Query()
+
+	return stockapp.RegisterParams{
+		MedicineID: values.Get("medicine_id"),
+		StartDate:  values.Get("start_date"),
+		EndDate:    values.Get("end_date"),
+	}
+}
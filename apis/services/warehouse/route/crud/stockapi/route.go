@@ -0,0 +1,42 @@
+package stockapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mid"
+	"github.com/EnesDemirtas/medisync/app/api/authsrv"
+	"github.com/EnesDemirtas/medisync/app/domain/stockapp"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+	"github.com/jmoiron/sqlx"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	StockBus     *stockbus.Core
+	InventoryBus *inventorybus.Core
+	AuthSrv      *authsrv.AuthSrv
+	Log          *logger.Logger
+	DB           *sqlx.DB
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.Log, cfg.AuthSrv)
+	ruleAny := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAny)
+	ruleAuthorizeInventory := mid.AuthorizeInventory(cfg.Log, cfg.AuthSrv, cfg.InventoryBus, auth.RuleAny)
+	countersign := mid.Countersign(cfg.Log, cfg.AuthSrv)
+	tran := mid.ExecuteInTransaction(cfg.Log, sqldb.NewBeginner(cfg.DB))
+
+	api := newAPI(stockapp.NewCore(cfg.StockBus))
+	app.Handle(http.MethodGet, version, "/inventories/{inventory_id}/movements", api.query, authen, ruleAuthorizeInventory)
+	app.Handle(http.MethodPost, version, "/inventories/{inventory_id}/movements", api.create, authen, ruleAuthorizeInventory, countersign, tran)
+	app.Handle(http.MethodGet, version, "/inventories/{inventory_id}/register", api.queryRegister, authen, ruleAuthorizeInventory)
+	app.Handle(http.MethodGet, version, "/holdings", api.queryHoldings, authen, ruleAny)
+}
@@ -0,0 +1,67 @@
+// Package stockapi maintains the web based api for the stock ledger.
+package stockapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/domain/stockapp"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+type api struct {
+	stockApp *stockapp.Core
+}
+
+func newAPI(stockApp *stockapp.Core) *api {
+	return &api{
+		stockApp: stockApp,
+	}
+}
+
+func (api *api) create(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app stockapp.NewMovement
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	mov, err := api.stockApp.Create(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, mov, http.StatusCreated)
+}
+
+func (api *api) query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return err
+	}
+
+	movs, err := api.stockApp.Query(ctx, qp)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, movs, http.StatusOK)
+}
+
+func (api *api) queryHoldings(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	rpt, err := api.stockApp.QueryHoldings(ctx, parseHoldingParams(r))
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, rpt, http.StatusOK)
+}
+
+func (api *api) queryRegister(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	rgs, err := api.stockApp.QueryRegister(ctx, parseRegisterParams(r))
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, rgs, http.StatusOK)
+}
@@ -0,0 +1,47 @@
+package stocktakeapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/app/domain/stocktakeapp"
+)
+
+func parseQueryParams(r *http.Request) (stocktakeapp.QueryParams, error) {
+	const (
+		orderBy             = "orderBy"
+		filterByStocktakeID = "stocktake_id"
+		filterByInventoryID = "inventory_id"
+		filterByStatus      = "status"
+	)
+
+	values := r.URL.Query()
+
+	var filter stocktakeapp.QueryParams
+
+	pg, err := page.ParseHTTP(r)
+	if err != nil {
+		return stocktakeapp.QueryParams{}, err
+	}
+
+	filter.Page = pg.Number
+	filter.Rows = pg.RowsPerPage
+
+	if orderBy := values.Get(orderBy); orderBy != "" {
+		filter.OrderBy = orderBy
+	}
+
+	if stocktakeID := values.Get(filterByStocktakeID); stocktakeID != "" {
+		filter.ID = stocktakeID
+	}
+
+	if inventoryID := values.Get(filterByInventoryID); inventoryID != "" {
+		filter.InventoryID = inventoryID
+	}
+
+	if status := values.Get(filterByStatus); status != "" {
+		filter.Status = status
+	}
+
+	return filter, nil
+}
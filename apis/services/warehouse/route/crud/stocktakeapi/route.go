@@ -0,0 +1,47 @@
+package stocktakeapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mid"
+	"github.com/EnesDemirtas/medisync/app/api/authsrv"
+	"github.com/EnesDemirtas/medisync/app/domain/stocktakeapp"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/stocktakebus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+	"github.com/jmoiron/sqlx"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	StocktakeBus *stocktakebus.Core
+	InventoryBus *inventorybus.Core
+	AuthSrv      *authsrv.AuthSrv
+	Log          *logger.Logger
+	DB           *sqlx.DB
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.Log, cfg.AuthSrv)
+	ruleAny := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAny)
+	ruleAuthorizeInventory := mid.AuthorizeInventory(cfg.Log, cfg.AuthSrv, cfg.InventoryBus, auth.RuleAny)
+	ruleAuthorizeStocktake := mid.AuthorizeStocktake(cfg.Log, cfg.AuthSrv, cfg.StocktakeBus, auth.RuleAny)
+	ruleAuthorizeStocktakeAdmin := mid.AuthorizeStocktake(cfg.Log, cfg.AuthSrv, cfg.StocktakeBus, auth.RuleAdminOnly)
+	countersign := mid.Countersign(cfg.Log, cfg.AuthSrv)
+	tran := mid.ExecuteInTransaction(cfg.Log, sqldb.NewBeginner(cfg.DB))
+
+	api := newAPI(stocktakeapp.NewCore(cfg.StocktakeBus))
+	app.Handle(http.MethodGet, version, "/stocktakes", api.query, authen, ruleAny)
+	app.Handle(http.MethodGet, version, "/stocktakes/{stocktake_id}", api.queryByID, authen, ruleAuthorizeStocktake)
+	app.Handle(http.MethodGet, version, "/stocktakes/{stocktake_id}/variances", api.queryVariances, authen, ruleAuthorizeStocktake)
+	app.Handle(http.MethodPost, version, "/inventories/{inventory_id}/stocktakes", api.open, authen, ruleAuthorizeInventory, tran)
+	app.Handle(http.MethodPost, version, "/stocktakes/{stocktake_id}/counts", api.submitCounts, authen, ruleAuthorizeStocktake, tran)
+	app.Handle(http.MethodPost, version, "/stocktakes/{stocktake_id}/approve", api.approve, authen, ruleAuthorizeStocktakeAdmin, countersign, tran)
+	app.Handle(http.MethodPost, version, "/stocktakes/{stocktake_id}/cancel", api.cancel, authen, ruleAuthorizeStocktake, tran)
+}
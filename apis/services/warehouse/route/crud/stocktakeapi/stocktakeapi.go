@@ -0,0 +1,99 @@
+// Package stocktakeapi maintains the web based api for stocktake access.
+package stocktakeapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/domain/stocktakeapp"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+type api struct {
+	stocktakeApp *stocktakeapp.Core
+}
+
+func newAPI(stocktakeApp *stocktakeapp.Core) *api {
+	return &api{
+		stocktakeApp: stocktakeApp,
+	}
+}
+
+func (api *api) open(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app stocktakeapp.NewStocktake
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	stk, err := api.stocktakeApp.Open(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, stk, http.StatusCreated)
+}
+
+func (api *api) submitCounts(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app stocktakeapp.Counting
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	stk, err := api.stocktakeApp.SubmitCounts(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, stk, http.StatusOK)
+}
+
+func (api *api) approve(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	stk, err := api.stocktakeApp.Approve(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, stk, http.StatusOK)
+}
+
+func (api *api) cancel(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	stk, err := api.stocktakeApp.Cancel(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, stk, http.StatusOK)
+}
+
+func (api *api) query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return err
+	}
+
+	stks, err := api.stocktakeApp.Query(ctx, qp)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, stks, http.StatusOK)
+}
+
+func (api *api) queryByID(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	stk, err := api.stocktakeApp.QueryByID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, stk, http.StatusOK)
+}
+
+func (api *api) queryVariances(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	rpt, err := api.stocktakeApp.QueryVariances(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, rpt, http.StatusOK)
+}
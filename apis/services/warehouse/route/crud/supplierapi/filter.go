@@ -0,0 +1,42 @@
+package supplierapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/app/domain/supplierapp"
+)
+
+func parseQueryParams(r *http.Request) (supplierapp.QueryParams, error) {
+	const (
+		orderBy            = "orderBy"
+		filterBySupplierID = "supplier_id"
+		filterByName       = "name"
+	)
+
+	values := r.URL.Query()
+
+	var filter supplierapp.QueryParams
+
+	pg, err := page.ParseHTTP(r)
+	if err != nil {
+		return supplierapp.QueryParams{}, err
+	}
+
+	filter.Page = pg.Number
+	filter.Rows = pg.RowsPerPage
+
+	if orderBy := values.Get(orderBy); orderBy != "" {
+		filter.OrderBy = orderBy
+	}
+
+	if supplierID := values.Get(filterBySupplierID); supplierID != "" {
+		filter.ID = supplierID
+	}
+
+	if name := values.Get(filterByName); name != "" {
+		filter.Name = name
+	}
+
+	return filter, nil
+}
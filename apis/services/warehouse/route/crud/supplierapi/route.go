@@ -0,0 +1,39 @@
+package supplierapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mid"
+	"github.com/EnesDemirtas/medisync/app/api/authsrv"
+	"github.com/EnesDemirtas/medisync/app/domain/supplierapp"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/domain/supplierbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	SupplierBus *supplierbus.Core
+	AuthSrv     *authsrv.AuthSrv
+	Log         *logger.Logger
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.Log, cfg.AuthSrv)
+	ruleAny := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAny)
+	ruleAdmin := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAdminOnly)
+	ruleAuthorizeSupplier := mid.AuthorizeSupplier(cfg.Log, cfg.AuthSrv, cfg.SupplierBus, auth.RuleAny)
+	ruleAuthorizeSupplierAdmin := mid.AuthorizeSupplier(cfg.Log, cfg.AuthSrv, cfg.SupplierBus, auth.RuleAdminOnly)
+	ifMatch := mid.RequireIfMatch()
+
+	api := newAPI(supplierapp.NewCore(cfg.SupplierBus))
+	app.Handle(http.MethodGet, version, "/suppliers", api.query, authen, ruleAny)
+	app.Handle(http.MethodGet, version, "/suppliers/{supplier_id}", api.queryByID, authen, ruleAuthorizeSupplier)
+	app.Handle(http.MethodPost, version, "/suppliers", api.create, authen, ruleAdmin)
+	app.Handle(http.MethodPut, version, "/suppliers/{supplier_id}", api.update, authen, ruleAuthorizeSupplierAdmin, ifMatch)
+	app.Handle(http.MethodDelete, version, "/suppliers/{supplier_id}", api.delete, authen, ruleAuthorizeSupplierAdmin)
+}
@@ -0,0 +1,48 @@
+package tagapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/app/domain/tagapp"
+)
+
+func parseQueryParams(r *http.Request) (tagapp.QueryParams, error) {
+	const (
+		orderBy       = "orderBy"
+		filterByTagID = "tag_id"
+		filterByName  = "name"
+	)
+
+	values := r.URL.Query()
+
+	var filter tagapp.QueryParams
+
+	pg, err := page.ParseHTTP(r)
+	if err != nil {
+		return tagapp.QueryParams{}, err
+	}
+
+	filter.Page = pg.Number
+	filter.Rows = pg.RowsPerPage
+
+	if orderBy := values.Get(orderBy); orderBy != "" {
+		filter.OrderBy = orderBy
+	}
+
+	if tagID := values.Get(filterByTagID); tagID != "" {
+		filter.ID = tagID
+	}
+
+	if name := values.Get(filterByName); name != "" {
+		filter.Name = name
+	}
+
+	return filter, nil
+}
+
+func parseDeleteParams(r *http.Request) tagapp.DeleteParams {
+	return tagapp.DeleteParams{
+		Cascade: r.URL.Query().Get("cascade"),
+	}
+}
@@ -0,0 +1,39 @@
+package tagapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mid"
+	"github.com/EnesDemirtas/medisync/app/api/authsrv"
+	"github.com/EnesDemirtas/medisync/app/domain/tagapp"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/domain/tagbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	TagBus  *tagbus.Core
+	AuthSrv *authsrv.AuthSrv
+	Log     *logger.Logger
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.Log, cfg.AuthSrv)
+	ruleAny := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAny)
+	ruleAdmin := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAdminOnly)
+	ruleAuthorizeTag := mid.AuthorizeTag(cfg.Log, cfg.AuthSrv, cfg.TagBus, auth.RuleAny)
+	ruleAuthorizeTagAdmin := mid.AuthorizeTag(cfg.Log, cfg.AuthSrv, cfg.TagBus, auth.RuleAdminOnly)
+	ifMatch := mid.RequireIfMatch()
+
+	api := newAPI(tagapp.NewCore(cfg.TagBus))
+	app.Handle(http.MethodGet, version, "/tags", api.query, authen, ruleAny)
+	app.Handle(http.MethodGet, version, "/tags/{tag_id}", api.queryByID, authen, ruleAuthorizeTag)
+	app.Handle(http.MethodPost, version, "/tags", api.create, authen, ruleAdmin)
+	app.Handle(http.MethodPut, version, "/tags/{tag_id}", api.update, authen, ruleAuthorizeTagAdmin, ifMatch)
+	app.Handle(http.MethodDelete, version, "/tags/{tag_id}", api.delete, authen, ruleAuthorizeTagAdmin)
+}
@@ -0,0 +1,66 @@
+package temperatureapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/app/domain/temperatureapp"
+)
+
+func parseQueryParams(r *http.Request) (temperatureapp.QueryParams, error) {
+	const (
+		orderBy                  = "orderBy"
+		filterByExcursionID      = "excursion_id"
+		filterByInventoryID      = "inventory_id"
+		filterByOngoing          = "ongoing"
+		filterByStartStartedDate = "start_started_date"
+		filterByEndStartedDate   = "end_started_date"
+	)
+
+	values := r.URL.Query()
+
+	var filter temperatureapp.QueryParams
+
+	pg, err := page.ParseHTTP(r)
+	if err != nil {
+		return temperatureapp.QueryParams{}, err
+	}
+
+	filter.Page = pg.Number
+	filter.Rows = pg.RowsPerPage
+
+	if orderBy := values.Get(orderBy); orderBy != "" {
+		filter.OrderBy = orderBy
+	}
+
+	if excursionID := values.Get(filterByExcursionID); excursionID != "" {
+		filter.ID = excursionID
+	}
+
+	if inventoryID := values.Get(filterByInventoryID); inventoryID != "" {
+		filter.InventoryID = inventoryID
+	}
+
+	if ongoing := values.Get(filterByOngoing); ongoing != "" {
+		filter.Ongoing = ongoing
+	}
+
+	if startDate := values.Get(filterByStartStartedDate); startDate != "" {
+		filter.StartStartedDate = startDate
+	}
+
+	if endDate := values.Get(filterByEndStartedDate); endDate != "" {
+		filter.EndStartedDate = endDate
+	}
+
+	return filter, nil
+}
+
+func parseReadingParams(r *http.Request) temperatureapp.ReadingParams {
+	values := r.URL.Query()
+
+	return temperatureapp.ReadingParams{
+		StartDate: values.Get("start_date"),
+		EndDate:   values.Get("end_date"),
+	}
+}
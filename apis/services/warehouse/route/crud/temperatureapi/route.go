@@ -0,0 +1,44 @@
+package temperatureapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mid"
+	"github.com/EnesDemirtas/medisync/app/api/authsrv"
+	"github.com/EnesDemirtas/medisync/app/domain/temperatureapp"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/temperaturebus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+	"github.com/jmoiron/sqlx"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	TemperatureBus *temperaturebus.Core
+	InventoryBus   *inventorybus.Core
+	AuthSrv        *authsrv.AuthSrv
+	Log            *logger.Logger
+	DB             *sqlx.DB
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.Log, cfg.AuthSrv)
+	ruleAny := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAny)
+	ruleAuthorizeInventory := mid.AuthorizeInventory(cfg.Log, cfg.AuthSrv, cfg.InventoryBus, auth.RuleAny)
+	ruleAuthorizeInventoryAdmin := mid.AuthorizeInventory(cfg.Log, cfg.AuthSrv, cfg.InventoryBus, auth.RuleAdminOnly)
+	tran := mid.ExecuteInTransaction(cfg.Log, sqldb.NewBeginner(cfg.DB))
+
+	api := newAPI(temperatureapp.NewCore(cfg.TemperatureBus))
+	app.Handle(http.MethodGet, version, "/inventories/{inventory_id}/temperature-range", api.queryRange, authen, ruleAuthorizeInventory)
+	app.Handle(http.MethodPut, version, "/inventories/{inventory_id}/temperature-range", api.setRange, authen, ruleAuthorizeInventoryAdmin)
+	app.Handle(http.MethodGet, version, "/inventories/{inventory_id}/temperature-readings", api.queryReadings, authen, ruleAuthorizeInventory)
+	app.Handle(http.MethodPost, version, "/inventories/{inventory_id}/temperature-readings", api.ingest, authen, ruleAuthorizeInventory, tran)
+	app.Handle(http.MethodPost, version, "/inventories/{inventory_id}/temperature-readings/csv", api.ingestCSV, authen, ruleAuthorizeInventory, tran)
+	app.Handle(http.MethodGet, version, "/excursions", api.query, authen, ruleAny)
+}
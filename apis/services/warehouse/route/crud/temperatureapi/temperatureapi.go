@@ -0,0 +1,93 @@
+// Package temperatureapi maintains the web based api for temperature access.
+package temperatureapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/domain/temperatureapp"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+// maxCSVBytes caps the size of a data logger export accepted in one upload.
+const maxCSVBytes = 10 << 20
+
+type api struct {
+	temperatureApp *temperatureapp.Core
+}
+
+func newAPI(temperatureApp *temperatureapp.Core) *api {
+	return &api{
+		temperatureApp: temperatureApp,
+	}
+}
+
+func (api *api) setRange(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app temperatureapp.NewRange
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	rng, err := api.temperatureApp.SetRange(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, rng, http.StatusOK)
+}
+
+func (api *api) queryRange(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	rng, err := api.temperatureApp.QueryRange(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, rng, http.StatusOK)
+}
+
+func (api *api) ingest(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app temperatureapp.NewIngestion
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	ing, err := api.temperatureApp.Ingest(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, ing, http.StatusCreated)
+}
+
+func (api *api) ingestCSV(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	ing, err := api.temperatureApp.IngestCSV(ctx, http.MaxBytesReader(w, r.Body, maxCSVBytes))
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, ing, http.StatusCreated)
+}
+
+func (api *api) queryReadings(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	rds, err := api.temperatureApp.QueryReadings(ctx, parseReadingParams(r))
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, rds, http.StatusOK)
+}
+
+func (api *api) query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return err
+	}
+
+	excs, err := api.temperatureApp.Query(ctx, qp)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, excs, http.StatusOK)
+}
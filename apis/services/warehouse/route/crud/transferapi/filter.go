@@ -0,0 +1,52 @@
+package transferapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/app/domain/transferapp"
+)
+
+func parseQueryParams(r *http.Request) (transferapp.QueryParams, error) {
+	const (
+		orderBy               = "orderBy"
+		filterByTransferID    = "transfer_id"
+		filterBySourceID      = "source_inventory_id"
+		filterByDestinationID = "destination_inventory_id"
+		filterByStatus        = "status"
+	)
+
+	values := r.URL.Query()
+
+	var filter transferapp.QueryParams
+
+	pg, err := page.ParseHTTP(r)
+	if err != nil {
+		return transferapp.QueryParams{}, err
+	}
+
+	filter.Page = pg.Number
+	filter.Rows = pg.RowsPerPage
+
+	if orderBy := values.Get(orderBy); orderBy != "" {
+		filter.OrderBy = orderBy
+	}
+
+	if transferID := values.Get(filterByTransferID); transferID != "" {
+		filter.ID = transferID
+	}
+
+	if sourceID := values.Get(filterBySourceID); sourceID != "" {
+		filter.SourceID = sourceID
+	}
+
+	if destinationID := values.Get(filterByDestinationID); destinationID != "" {
+		filter.DestinationID = destinationID
+	}
+
+	if status := values.Get(filterByStatus); status != "" {
+		filter.Status = status
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,43 @@
+package transferapi
+
+import (
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/apis/services/warehouse/mid"
+	"github.com/EnesDemirtas/medisync/app/api/authsrv"
+	"github.com/EnesDemirtas/medisync/app/domain/transferapp"
+	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/transferbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+	"github.com/jmoiron/sqlx"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	TransferBus  *transferbus.Core
+	InventoryBus *inventorybus.Core
+	AuthSrv      *authsrv.AuthSrv
+	Log          *logger.Logger
+	DB           *sqlx.DB
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	authen := mid.Authenticate(cfg.Log, cfg.AuthSrv)
+	ruleAny := mid.Authorize(cfg.Log, cfg.AuthSrv, auth.RuleAny)
+	ruleAuthorizeInventory := mid.AuthorizeInventory(cfg.Log, cfg.AuthSrv, cfg.InventoryBus, auth.RuleAny)
+	ruleAuthorizeTransfer := mid.AuthorizeTransfer(cfg.Log, cfg.AuthSrv, cfg.TransferBus, auth.RuleAny)
+	countersign := mid.Countersign(cfg.Log, cfg.AuthSrv)
+	tran := mid.ExecuteInTransaction(cfg.Log, sqldb.NewBeginner(cfg.DB))
+
+	api := newAPI(transferapp.NewCore(cfg.TransferBus))
+	app.Handle(http.MethodGet, version, "/transfers", api.query, authen, ruleAny)
+	app.Handle(http.MethodGet, version, "/transfers/{transfer_id}", api.queryByID, authen, ruleAuthorizeTransfer)
+	app.Handle(http.MethodPost, version, "/inventories/{inventory_id}/transfers", api.dispatch, authen, ruleAuthorizeInventory, countersign, tran)
+	app.Handle(http.MethodPost, version, "/transfers/{transfer_id}/receipts", api.receive, authen, ruleAuthorizeTransfer, countersign, tran)
+}
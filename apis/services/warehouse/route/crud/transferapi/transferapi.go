@@ -0,0 +1,72 @@
+// Package transferapi maintains the web based api for transfer access.
+package transferapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/domain/transferapp"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+type api struct {
+	transferApp *transferapp.Core
+}
+
+func newAPI(transferApp *transferapp.Core) *api {
+	return &api{
+		transferApp: transferApp,
+	}
+}
+
+func (api *api) dispatch(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app transferapp.NewTransfer
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	tr, err := api.transferApp.Dispatch(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, tr, http.StatusCreated)
+}
+
+func (api *api) receive(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app transferapp.Receipt
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	tr, err := api.transferApp.Receive(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, tr, http.StatusOK)
+}
+
+func (api *api) query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return err
+	}
+
+	trs, err := api.transferApp.Query(ctx, qp)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, trs, http.StatusOK)
+}
+
+func (api *api) queryByID(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	tr, err := api.transferApp.QueryByID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, tr, http.StatusOK)
+}
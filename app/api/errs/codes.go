@@ -103,6 +103,14 @@ var (
 	// Unauthenticated indicates the request does not have valid
 	// authentication credentials for the operation.
 	Unauthenticated = ErrCode{value: 16}
+
+	// PreconditionFailed indicates a conditional request was made against
+	// a version of the resource that is no longer current.
+	PreconditionFailed = ErrCode{value: 17}
+
+	// PreconditionRequired indicates the operation must be made as a
+	// conditional request, for example carrying an If-Match header.
+	PreconditionRequired = ErrCode{value: 18}
 )
 
 // ErrCode represents an error code in the system.
@@ -145,27 +153,29 @@ func (ec ErrCode) Equal(ec2 ErrCode) bool {
 	return ec.value == ec2.value
 }
 
-var codeNames [17]string
-var codeStatus [17]int
+var codeNames [19]string
+var codeStatus [19]int
 
 var codeNumbers = map[string]ErrCode{
-	"ok":                  OK,
-	"canceled":            Canceled,
-	"unknown":             Unknown,
-	"invalid_argument":    InvalidArgument,
-	"deadline_exceeded":   DeadlineExceeded,
-	"not_found":           NotFound,
-	"already_exists":      AlreadyExists,
-	"permission_denied":   PermissionDenied,
-	"resource_exhausted":  ResourceExhausted,
-	"failed_precondition": FailedPrecondition,
-	"aborted":             Aborted,
-	"out_of_range":        OutOfRange,
-	"unimplemented":       Unimplemented,
-	"internal":            Internal,
-	"unavailable":         Unavailable,
-	"data_loss":           DataLoss,
-	"unauthenticated":     Unauthenticated,
+	"ok":                    OK,
+	"canceled":              Canceled,
+	"unknown":               Unknown,
+	"invalid_argument":      InvalidArgument,
+	"deadline_exceeded":     DeadlineExceeded,
+	"not_found":             NotFound,
+	"already_exists":        AlreadyExists,
+	"permission_denied":     PermissionDenied,
+	"resource_exhausted":    ResourceExhausted,
+	"failed_precondition":   FailedPrecondition,
+	"aborted":               Aborted,
+	"out_of_range":          OutOfRange,
+	"unimplemented":         Unimplemented,
+	"internal":              Internal,
+	"unavailable":           Unavailable,
+	"data_loss":             DataLoss,
+	"unauthenticated":       Unauthenticated,
+	"precondition_failed":   PreconditionFailed,
+	"precondition_required": PreconditionRequired,
 }
 
 func init() {
@@ -186,6 +196,8 @@ func init() {
 	codeNames[Unavailable.value] = "unavailable"
 	codeNames[DataLoss.value] = "data_loss"
 	codeNames[Unauthenticated.value] = "unauthenticated"
+	codeNames[PreconditionFailed.value] = "precondition_failed"
+	codeNames[PreconditionRequired.value] = "precondition_required"
 
 	codeStatus[OK.value] = 200
 	codeStatus[Canceled.value] = 499
@@ -204,4 +216,6 @@ func init() {
 	codeStatus[Unavailable.value] = 503
 	codeStatus[DataLoss.value] = 500
 	codeStatus[Unauthenticated.value] = 401
+	codeStatus[PreconditionFailed.value] = 412
+	codeStatus[PreconditionRequired.value] = 428
 }
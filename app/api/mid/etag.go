@@ -0,0 +1,78 @@
+package mid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/foundation/web"
+)
+
+// RequireIfMatch rejects requests that don't carry an If-Match header with
+// the version of the resource being changed. The version is stored in the
+// context for the app layer to compare against the loaded resource.
+func RequireIfMatch() web.MidHandler {
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			ifMatch := r.Header.Get("If-Match")
+			if ifMatch == "" {
+				return errs.Newf(errs.PreconditionRequired, "If-Match header required")
+			}
+
+			version, err := ParseETag(ifMatch)
+			if err != nil {
+				return errs.New(errs.FailedPrecondition, err)
+			}
+
+			ctx = context.WithValue(ctx, ifMatchKey, version)
+
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
+
+// CheckIfMatch compares the version sent in the If-Match header against the
+// current version of the resource.
+func CheckIfMatch(ctx context.Context, version int) error {
+	v, ok := ctx.Value(ifMatchKey).(int)
+	if !ok {
+		return errs.Newf(errs.PreconditionRequired, "If-Match header required")
+	}
+
+	if v != version {
+		return errs.Newf(errs.PreconditionFailed, "resource was modified: version[%d] current[%d]", v, version)
+	}
+
+	return nil
+}
+
+// ETag formats the version of a resource as a strong entity tag.
+func ETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// ParseETag returns the version held by an entity tag. Weak tags are
+// accepted since the version is all that is compared.
+func ParseETag(etag string) (int, error) {
+	etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+
+	s, err := strconv.Unquote(etag)
+	if err != nil {
+		return 0, fmt.Errorf("parse etag %q: %w", etag, errors.New("expected quoted version"))
+	}
+
+	version, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("parse etag %q: %w", etag, err)
+	}
+
+	return version, nil
+}
@@ -0,0 +1,51 @@
+package mid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+)
+
+func Test_IfMatch(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		name    string
+		ifMatch string
+		version int
+		expCode int
+	}{
+		{name: "match", ifMatch: `"2"`, version: 2},
+		{name: "weak-match", ifMatch: `W/"2"`, version: 2},
+		{name: "stale", ifMatch: `"1"`, version: 2, expCode: http.StatusPreconditionFailed},
+		{name: "missing", version: 2, expCode: http.StatusPreconditionRequired},
+		{name: "malformed", ifMatch: "2", version: 2, expCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+				return CheckIfMatch(ctx, tt.version)
+			}
+
+			r := httptest.NewRequest(http.MethodPut, "/", nil)
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			err := RequireIfMatch()(handler)(context.Background(), httptest.NewRecorder(), r)
+
+			var code int
+			if err != nil {
+				code = errs.GetError(err).Code.HTTPStatus()
+			}
+
+			if code != tt.expCode {
+				t.Errorf("Should get status %d: got %d: %v", tt.expCode, code, err)
+			}
+		})
+	}
+}
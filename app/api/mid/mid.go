@@ -7,9 +7,19 @@ import (
 	"errors"
 
 	"github.com/EnesDemirtas/medisync/business/api/auth"
+	"github.com/EnesDemirtas/medisync/business/domain/disposalbus"
 	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
 	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus"
+	"github.com/EnesDemirtas/medisync/business/domain/purchaseorderbus"
+	"github.com/EnesDemirtas/medisync/business/domain/recallbus"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stocktakebus"
+	"github.com/EnesDemirtas/medisync/business/domain/supplierbus"
 	"github.com/EnesDemirtas/medisync/business/domain/tagbus"
+	"github.com/EnesDemirtas/medisync/business/domain/transferbus"
 	"github.com/EnesDemirtas/medisync/business/domain/userbus"
 	"github.com/google/uuid"
 )
@@ -23,6 +33,18 @@ const (
 	tagKey
 	medicineKey
 	inventoryKey
+	lotKey
+	transferKey
+	ifMatchKey
+	reservationKey
+	orderKey
+	supplierKey
+	purchaseOrderKey
+	stocktakeKey
+	locationKey
+	recallKey
+	disposalKey
+	countersignerIDKey
 )
 
 func SetClaims(ctx context.Context, claims auth.Claims) context.Context {
@@ -47,6 +69,17 @@ func GetUserID(ctx context.Context) (uuid.UUID, error) {
 	return v, nil
 }
 
+// GetCountersignerID returns the ID of the user countersigning the request
+// from the context. It is the zero value when nobody countersigned it.
+func GetCountersignerID(ctx context.Context) uuid.UUID {
+	v, ok := ctx.Value(countersignerIDKey).(uuid.UUID)
+	if !ok {
+		return uuid.UUID{}
+	}
+
+	return v
+}
+
 // GetUser returns the user from the context.
 func GetUser(ctx context.Context) (userbus.User, error) {
 	v, ok := ctx.Value(userKey).(userbus.User)
@@ -61,6 +94,10 @@ func SetUserID(ctx context.Context, userID uuid.UUID) context.Context {
 	return context.WithValue(ctx, userIDKey, userID)
 }
 
+func SetCountersignerID(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, countersignerIDKey, userID)
+}
+
 func SetUser(ctx context.Context, usr userbus.User) context.Context {
 	return context.WithValue(ctx, userKey, usr)
 }
@@ -105,4 +142,143 @@ func GetInventory(ctx context.Context) (inventorybus.Inventory, error) {
 
 func SetInventory(ctx context.Context, inv inventorybus.Inventory) context.Context {
 	return context.WithValue(ctx, inventoryKey, inv)
-}
\ No newline at end of file
+}
+// GetLot returns the lot from the context.
+func GetLot(ctx context.Context) (lotbus.Lot, error) {
+	v, ok := ctx.Value(lotKey).(lotbus.Lot)
+	if !ok {
+		return lotbus.Lot{}, errors.New("lot not found in context")
+	}
+
+	return v, nil
+}
+
+func SetLot(ctx context.Context, lot lotbus.Lot) context.Context {
+	return context.WithValue(ctx, lotKey, lot)
+}
+
+// GetTransfer returns the transfer from the context.
+func GetTransfer(ctx context.Context) (transferbus.Transfer, error) {
+	v, ok := ctx.Value(transferKey).(transferbus.Transfer)
+	if !ok {
+		return transferbus.Transfer{}, errors.New("transfer not found in context")
+	}
+
+	return v, nil
+}
+
+func SetTransfer(ctx context.Context, tr transferbus.Transfer) context.Context {
+	return context.WithValue(ctx, transferKey, tr)
+}
+
+// GetReservation returns the reservation from the context.
+func GetReservation(ctx context.Context) (reservationbus.Reservation, error) {
+	v, ok := ctx.Value(reservationKey).(reservationbus.Reservation)
+	if !ok {
+		return reservationbus.Reservation{}, errors.New("reservation not found in context")
+	}
+
+	return v, nil
+}
+
+func SetReservation(ctx context.Context, res reservationbus.Reservation) context.Context {
+	return context.WithValue(ctx, reservationKey, res)
+}
+
+// GetOrder returns the order from the context.
+func GetOrder(ctx context.Context) (orderbus.Order, error) {
+	v, ok := ctx.Value(orderKey).(orderbus.Order)
+	if !ok {
+		return orderbus.Order{}, errors.New("order not found in context")
+	}
+
+	return v, nil
+}
+
+func SetOrder(ctx context.Context, ord orderbus.Order) context.Context {
+	return context.WithValue(ctx, orderKey, ord)
+}
+
+// GetSupplier returns the supplier from the context.
+func GetSupplier(ctx context.Context) (supplierbus.Supplier, error) {
+	v, ok := ctx.Value(supplierKey).(supplierbus.Supplier)
+	if !ok {
+		return supplierbus.Supplier{}, errors.New("supplier not found in context")
+	}
+
+	return v, nil
+}
+
+func SetSupplier(ctx context.Context, sup supplierbus.Supplier) context.Context {
+	return context.WithValue(ctx, supplierKey, sup)
+}
+
+// GetPurchaseOrder returns the purchase order from the context.
+func GetPurchaseOrder(ctx context.Context) (purchaseorderbus.PurchaseOrder, error) {
+	v, ok := ctx.Value(purchaseOrderKey).(purchaseorderbus.PurchaseOrder)
+	if !ok {
+		return purchaseorderbus.PurchaseOrder{}, errors.New("purchase order not found in context")
+	}
+
+	return v, nil
+}
+
+func SetPurchaseOrder(ctx context.Context, po purchaseorderbus.PurchaseOrder) context.Context {
+	return context.WithValue(ctx, purchaseOrderKey, po)
+}
+
+// GetStocktake returns the stocktake from the context.
+func GetStocktake(ctx context.Context) (stocktakebus.Stocktake, error) {
+	v, ok := ctx.Value(stocktakeKey).(stocktakebus.Stocktake)
+	if !ok {
+		return stocktakebus.Stocktake{}, errors.New("stocktake not found in context")
+	}
+
+	return v, nil
+}
+
+func SetStocktake(ctx context.Context, stk stocktakebus.Stocktake) context.Context {
+	return context.WithValue(ctx, stocktakeKey, stk)
+}
+
+// GetLocation returns the location from the context.
+func GetLocation(ctx context.Context) (locationbus.Location, error) {
+	v, ok := ctx.Value(locationKey).(locationbus.Location)
+	if !ok {
+		return locationbus.Location{}, errors.New("location not found in context")
+	}
+
+	return v, nil
+}
+
+func SetLocation(ctx context.Context, loc locationbus.Location) context.Context {
+	return context.WithValue(ctx, locationKey, loc)
+}
+
+// GetRecall returns the recall from the context.
+func GetRecall(ctx context.Context) (recallbus.Recall, error) {
+	v, ok := ctx.Value(recallKey).(recallbus.Recall)
+	if !ok {
+		return recallbus.Recall{}, errors.New("recall not found in context")
+	}
+
+	return v, nil
+}
+
+func SetRecall(ctx context.Context, rcl recallbus.Recall) context.Context {
+	return context.WithValue(ctx, recallKey, rcl)
+}
+
+// GetDisposal returns the disposal from the context.
+func GetDisposal(ctx context.Context) (disposalbus.Disposal, error) {
+	v, ok := ctx.Value(disposalKey).(disposalbus.Disposal)
+	if !ok {
+		return disposalbus.Disposal{}, errors.New("disposal not found in context")
+	}
+
+	return v, nil
+}
+
+func SetDisposal(ctx context.Context, dsp disposalbus.Disposal) context.Context {
+	return context.WithValue(ctx, disposalKey, dsp)
+}
@@ -0,0 +1,106 @@
+// Package allocationapp maintains the app layer api for the allocation domain.
+package allocationapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/allocationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+)
+
+// Core manages the set of app layer api functions for the allocation domain.
+type Core struct {
+	allocationBus *allocationbus.Core
+}
+
+// NewCore constructs an allocation core API for use.
+func NewCore(allocationBus *allocationbus.Core) *Core {
+	return &Core{
+		allocationBus: allocationBus,
+	}
+}
+
+// Plan returns the lots the request would be drawn from.
+func (c *Core) Plan(ctx context.Context, app Request) (Plan, error) {
+	req, err := toBusRequest(app)
+	if err != nil {
+		return Plan{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	plan, err := c.allocationBus.Plan(ctx, req)
+	if err != nil {
+		return Plan{}, toAppError(err, "plan: req[%+v]: %s", app, err)
+	}
+
+	return toAppPlan(plan), nil
+}
+
+// Confirm dispenses the lines of a plan.
+func (c *Core) Confirm(ctx context.Context, app Confirmation) ([]Movement, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	conf, err := toBusConfirmation(app, userID)
+	if err != nil {
+		return nil, errs.New(errs.FailedPrecondition, err)
+	}
+
+	conf.CountersignedBy = mid.GetCountersignerID(ctx)
+
+	allocationBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return nil, errs.New(errs.Internal, err)
+	}
+
+	movs, err := allocationBus.Confirm(ctx, conf)
+	if err != nil {
+		return nil, toAppError(err, "confirm: conf[%+v]: %s", app, err)
+	}
+
+	return toAppMovements(movs), nil
+}
+
+// executeUnderTransaction returns an allocation core bound to the transaction
+// the transaction middleware placed in the context.
+func (c *Core) executeUnderTransaction(ctx context.Context) (*allocationbus.Core, error) {
+	tx, ok := transaction.Get(ctx)
+	if !ok {
+		return nil, errors.New("transaction missing in context")
+	}
+
+	return c.allocationBus.ExecuteUnderTransaction(tx)
+}
+
+// toAppError maps the business errors an allocation can fail with to the
+// matching app error.
+func toAppError(err error, format string, v ...any) error {
+	switch {
+	case errors.Is(err, allocationbus.ErrInvalidQuantity),
+		errors.Is(err, allocationbus.ErrInvalidShelfLife),
+		errors.Is(err, allocationbus.ErrNoLines),
+		errors.Is(err, allocationbus.ErrUnavailable),
+		errors.Is(err, inventorybus.ErrInsufficientStock),
+		errors.Is(err, stockbus.ErrQuarantined),
+		errors.Is(err, stockbus.ErrUnavailable),
+		errors.Is(err, stockbus.ErrReserved),
+		errors.Is(err, stockbus.ErrCountersignRequired),
+		errors.Is(err, stockbus.ErrSelfCountersign),
+		errors.Is(err, stockbus.ErrInvalidQuantity):
+		return errs.New(errs.FailedPrecondition, err)
+
+	case errors.Is(err, medicinebus.ErrNotFound),
+		errors.Is(err, lotbus.ErrNotFound):
+		return errs.New(errs.NotFound, err)
+	}
+
+	return errs.Newf(errs.Internal, format, v...)
+}
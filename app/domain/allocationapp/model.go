@@ -0,0 +1,210 @@
+package allocationapp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/business/domain/allocationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// Line represents the quantity drawn from a lot held by an inventory.
+type Line struct {
+	InventoryID string `json:"inventoryID"`
+	LotID       string `json:"lotID"`
+	LotNumber   string `json:"lotNumber"`
+	ExpiryDate  string `json:"expiryDate"`
+	Quantity    int    `json:"quantity"`
+}
+
+// Plan represents the lots picked to fulfil an outbound request.
+type Plan struct {
+	MedicineID string `json:"medicineID"`
+	Quantity   int    `json:"quantity"`
+	Allocated  int    `json:"allocated"`
+	Shortfall  int    `json:"shortfall"`
+	UsableFrom string `json:"usableFrom"`
+	Lines      []Line `json:"lines"`
+}
+
+func toAppPlan(plan allocationbus.Plan) Plan {
+	lines := make([]Line, len(plan.Lines))
+	for i, line := range plan.Lines {
+		lines[i] = Line{
+			InventoryID: line.InventoryID.String(),
+			LotID:       line.LotID.String(),
+			LotNumber:   line.LotNumber,
+			ExpiryDate:  line.ExpiryDate.Format(time.RFC3339),
+			Quantity:    line.Quantity,
+		}
+	}
+
+	return Plan{
+		MedicineID: plan.MedicineID.String(),
+		Quantity:   plan.Quantity,
+		Allocated:  plan.Allocated,
+		Shortfall:  plan.Shortfall(),
+		UsableFrom: plan.UsableFrom.Format(time.RFC3339),
+		Lines:      lines,
+	}
+}
+
+// Movement represents a stock movement recorded by confirming a plan.
+type Movement struct {
+	ID          string `json:"id"`
+	InventoryID string `json:"inventoryID"`
+	MedicineID  string `json:"medicineID"`
+	LotID       string `json:"lotID"`
+	Type        string `json:"type"`
+	Quantity    int    `json:"quantity"`
+	Balance     int    `json:"balance"`
+	Reason      string `json:"reason"`
+	UserID      string `json:"userID"`
+	DateCreated string `json:"dateCreated"`
+}
+
+func toAppMovements(movs []stockbus.Movement) []Movement {
+	items := make([]Movement, len(movs))
+	for i, mov := range movs {
+		items[i] = Movement{
+			ID:          mov.ID.String(),
+			InventoryID: mov.InventoryID.String(),
+			MedicineID:  mov.MedicineID.String(),
+			LotID:       mov.LotID.String(),
+			Type:        mov.Type.Name(),
+			Quantity:    mov.Quantity,
+			Balance:     mov.Balance,
+			Reason:      mov.Reason,
+			UserID:      mov.UserID.String(),
+			DateCreated: mov.DateCreated.Format(time.RFC3339),
+		}
+	}
+
+	return items
+}
+
+// Request defines the outbound request to allocate. MinShelfLife is a
+// duration such as "720h" and defaults to the configured minimum.
+type Request struct {
+	MedicineID   string `json:"medicineID" validate:"required"`
+	Quantity     int    `json:"quantity" validate:"required,gte=1"`
+	InventoryID  string `json:"inventoryID"`
+	MinShelfLife string `json:"minShelfLife"`
+}
+
+func toBusRequest(app Request) (allocationbus.Request, error) {
+	medicineID, err := uuid.Parse(app.MedicineID)
+	if err != nil {
+		return allocationbus.Request{}, fmt.Errorf("parse: %w", err)
+	}
+
+	req := allocationbus.Request{
+		MedicineID: medicineID,
+		Quantity:   app.Quantity,
+	}
+
+	if app.InventoryID != "" {
+		inventoryID, err := uuid.Parse(app.InventoryID)
+		if err != nil {
+			return allocationbus.Request{}, fmt.Errorf("parse: %w", err)
+		}
+		req.InventoryID = &inventoryID
+	}
+
+	if req.MinShelfLife, err = parseShelfLife(app.MinShelfLife); err != nil {
+		return allocationbus.Request{}, err
+	}
+
+	return req, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app Request) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// ConfirmationLine defines the quantity to draw from a lot held by an
+// inventory.
+type ConfirmationLine struct {
+	InventoryID string `json:"inventoryID" validate:"required"`
+	LotID       string `json:"lotID" validate:"required"`
+	Quantity    int    `json:"quantity" validate:"required"`
+}
+
+// Confirmation defines the lines of a plan to dispense.
+type Confirmation struct {
+	MedicineID   string             `json:"medicineID" validate:"required"`
+	Lines        []ConfirmationLine `json:"lines" validate:"required,min=1,dive"`
+	MinShelfLife string             `json:"minShelfLife"`
+	Reason       string             `json:"reason"`
+}
+
+func toBusConfirmation(app Confirmation, userID uuid.UUID) (allocationbus.Confirmation, error) {
+	medicineID, err := uuid.Parse(app.MedicineID)
+	if err != nil {
+		return allocationbus.Confirmation{}, fmt.Errorf("parse: %w", err)
+	}
+
+	lines := make([]allocationbus.ConfirmationLine, len(app.Lines))
+	for i, line := range app.Lines {
+		inventoryID, err := uuid.Parse(line.InventoryID)
+		if err != nil {
+			return allocationbus.Confirmation{}, fmt.Errorf("parse: %w", err)
+		}
+
+		lotID, err := uuid.Parse(line.LotID)
+		if err != nil {
+			return allocationbus.Confirmation{}, fmt.Errorf("parse: %w", err)
+		}
+
+		lines[i] = allocationbus.ConfirmationLine{
+			InventoryID: inventoryID,
+			LotID:       lotID,
+			Quantity:    line.Quantity,
+		}
+	}
+
+	conf := allocationbus.Confirmation{
+		MedicineID: medicineID,
+		Lines:      lines,
+		Reason:     app.Reason,
+		UserID:     userID,
+	}
+
+	if conf.MinShelfLife, err = parseShelfLife(app.MinShelfLife); err != nil {
+		return allocationbus.Confirmation{}, err
+	}
+
+	return conf, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app Confirmation) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// parseShelfLife returns nil when no shelf life was given so the configured
+// minimum applies.
+func parseShelfLife(s string) (*time.Duration, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return nil, fmt.Errorf("parse min shelf life: %w", err)
+	}
+
+	return &d, nil
+}
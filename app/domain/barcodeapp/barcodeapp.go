@@ -0,0 +1,65 @@
+// Package barcodeapp maintains the app layer api for resolving scanned
+// GS1 barcodes to medicines and lots.
+package barcodeapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/business/api/gs1"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+)
+
+// Core manages the set of app layer api functions for barcode lookups.
+type Core struct {
+	medicineBus *medicinebus.Core
+	lotBus      *lotbus.Core
+}
+
+// NewCore constructs a barcode core API for use.
+func NewCore(medicineBus *medicinebus.Core, lotBus *lotbus.Core) *Core {
+	return &Core{
+		medicineBus: medicineBus,
+		lotBus:      lotBus,
+	}
+}
+
+// Lookup decodes the scanned code and resolves it to the medicine carrying
+// the GTIN and, when the code holds a lot number, to the registered lot. A
+// scanned expiry that doesn't match the registered lot is flagged in the
+// resolution.
+func (c *Core) Lookup(ctx context.Context, app Scan) (Resolution, error) {
+	el, err := gs1.Parse(app.Code)
+	if err != nil {
+		return Resolution{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	med, err := c.medicineBus.QueryByGTIN(ctx, el.GTIN)
+	if err != nil {
+		if errors.Is(err, medicinebus.ErrNotFound) {
+			return Resolution{}, errs.Newf(errs.NotFound, "no medicine with gtin %s", el.GTIN)
+		}
+		return Resolution{}, errs.Newf(errs.Internal, "querybygtin: gtin[%s]: %s", el.GTIN, err)
+	}
+
+	if el.Batch == "" {
+		return toAppResolution(el, med, nil), nil
+	}
+
+	var filter lotbus.QueryFilter
+	filter.WithMedicineID(med.ID)
+	filter.WithNumber(el.Batch)
+
+	lots, err := c.lotBus.Query(ctx, filter, lotbus.DefaultOrderBy, 1, 1)
+	if err != nil {
+		return Resolution{}, errs.Newf(errs.Internal, "query lots: medicineID[%s] lot[%s]: %s", med.ID, el.Batch, err)
+	}
+
+	if len(lots) == 0 {
+		return toAppResolution(el, med, nil), nil
+	}
+
+	return toAppResolution(el, med, &lots[0]), nil
+}
@@ -0,0 +1,93 @@
+package barcodeapp
+
+import (
+	"time"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/business/api/gs1"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+)
+
+// Scan defines the data read from a barcode. Code holds the GS1 element
+// string, either raw with FNC1 separators or in the human readable form.
+type Scan struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// Validate checks the data in the model is considered clean.
+func (app Scan) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// Medicine represents the medicine a code resolved to.
+type Medicine struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Manufacturer string `json:"manufacturer"`
+	GTIN         string `json:"gtin"`
+}
+
+// Lot represents the registered lot a code resolved to.
+type Lot struct {
+	ID              string `json:"id"`
+	Number          string `json:"number"`
+	ExpiryDate      string `json:"expiryDate"`
+	ManufactureDate string `json:"manufactureDate,omitempty"`
+}
+
+// Resolution represents the result of looking up a scanned code. Lot is
+// empty when the scanned lot number has not been registered yet, in which
+// case the decoded lot number and expiry can be used to register it.
+// ExpiryMismatch is set when the scanned expiry differs from the one the lot
+// was registered with, the pack should then be checked before it is used.
+type Resolution struct {
+	GTIN           string   `json:"gtin"`
+	LotNumber      string   `json:"lotNumber,omitempty"`
+	Serial         string   `json:"serial,omitempty"`
+	ExpiryDate     string   `json:"expiryDate,omitempty"`
+	ExpiryMismatch bool     `json:"expiryMismatch,omitempty"`
+	Medicine       Medicine `json:"medicine"`
+	Lot            *Lot     `json:"lot,omitempty"`
+}
+
+func toAppResolution(el gs1.Element, med medicinebus.Medicine, lot *lotbus.Lot) Resolution {
+	res := Resolution{
+		GTIN:      el.GTIN,
+		LotNumber: el.Batch,
+		Serial:    el.Serial,
+		Medicine: Medicine{
+			ID:           med.ID.String(),
+			Name:         med.Name,
+			Manufacturer: med.Manufacturer,
+			GTIN:         med.GTIN,
+		},
+	}
+
+	if !el.ExpiryDate.IsZero() {
+		res.ExpiryDate = el.ExpiryDate.Format(time.DateOnly)
+	}
+
+	if lot != nil {
+		res.Lot = &Lot{
+			ID:         lot.ID.String(),
+			Number:     lot.Number,
+			ExpiryDate: lot.ExpiryDate.Format(time.RFC3339),
+		}
+
+		if !lot.ManufactureDate.IsZero() {
+			res.Lot.ManufactureDate = lot.ManufactureDate.Format(time.RFC3339)
+		}
+
+		if res.ExpiryDate != "" && res.ExpiryDate != lot.ExpiryDate.UTC().Format(time.DateOnly) {
+			res.ExpiryMismatch = true
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,173 @@
+// Package disposalapp maintains the app layer api for the disposal domain.
+package disposalapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/disposalbus"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+)
+
+// Core manages the set of app layer api functions for the disposal domain.
+type Core struct {
+	disposalBus *disposalbus.Core
+}
+
+// NewCore constructs a disposal core API for use.
+func NewCore(disposalBus *disposalbus.Core) *Core {
+	return &Core{
+		disposalBus: disposalBus,
+	}
+}
+
+// Create records a disposal of stock held by the inventory in context.
+func (c *Core) Create(ctx context.Context, app NewDisposal) (Disposal, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return Disposal{}, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Disposal{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	nd, err := toBusNewDisposal(app, inv.ID, userID)
+	if err != nil {
+		return Disposal{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	nd.WitnessID = mid.GetCountersignerID(ctx)
+
+	tx, ok := transaction.Get(ctx)
+	if !ok {
+		return Disposal{}, errs.Newf(errs.Internal, "transaction missing in context")
+	}
+
+	disposalBus, err := c.disposalBus.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return Disposal{}, errs.New(errs.Internal, err)
+	}
+
+	dsp, err := disposalBus.Create(ctx, nd)
+	if err != nil {
+		return Disposal{}, toAppError(err, "create: inventoryID[%s] nd[%+v]: %s", inv.ID, app, err)
+	}
+
+	return toAppDisposal(dsp), nil
+}
+
+// Certify attaches the certificate of destruction to the disposal in
+// context.
+func (c *Core) Certify(ctx context.Context, app NewCertificate) (Disposal, error) {
+	dsp, err := mid.GetDisposal(ctx)
+	if err != nil {
+		return Disposal{}, errs.Newf(errs.Internal, "disposal missing in context: %s", err)
+	}
+
+	cert, err := toBusCertificate(app)
+	if err != nil {
+		return Disposal{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	updDsp, err := c.disposalBus.Certify(ctx, dsp, cert)
+	if err != nil {
+		return Disposal{}, toAppError(err, "certify: disposalID[%s]: %s", dsp.ID, err)
+	}
+
+	return toAppDisposal(updDsp), nil
+}
+
+// Query returns a list of disposals with paging.
+func (c *Core) Query(ctx context.Context, qp QueryParams) (page.Document[Disposal], error) {
+	if err := validatePaging(qp); err != nil {
+		return page.Document[Disposal]{}, err
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return page.Document[Disposal]{}, err
+	}
+
+	orderBy, err := parseOrder(qp)
+	if err != nil {
+		return page.Document[Disposal]{}, err
+	}
+
+	dsps, err := c.disposalBus.Query(ctx, filter, orderBy, qp.Page, qp.Rows)
+	if err != nil {
+		return page.Document[Disposal]{}, errs.Newf(errs.Internal, "query: %s", err)
+	}
+
+	total, err := c.disposalBus.Count(ctx, filter)
+	if err != nil {
+		return page.Document[Disposal]{}, errs.Newf(errs.Internal, "count: %s", err)
+	}
+
+	return page.NewDocument(toAppDisposals(dsps), total, qp.Page, qp.Rows), nil
+}
+
+// QueryByID returns a disposal by its ID.
+func (c *Core) QueryByID(ctx context.Context) (Disposal, error) {
+	dsp, err := mid.GetDisposal(ctx)
+	if err != nil {
+		return Disposal{}, errs.Newf(errs.Internal, "querybyid: %s", err)
+	}
+
+	return toAppDisposal(dsp), nil
+}
+
+// QueryRegister returns the disposal register of a period.
+func (c *Core) QueryRegister(ctx context.Context, rp RegisterParams) (Register, error) {
+	filter, err := parseRegisterFilter(rp)
+	if err != nil {
+		return Register{}, err
+	}
+
+	rgs, err := c.disposalBus.QueryRegister(ctx, filter)
+	if err != nil {
+		return Register{}, toAppError(err, "queryregister: %s", err)
+	}
+
+	return toAppRegister(rgs), nil
+}
+
+// toAppError maps the business errors a disposal can fail with to the
+// matching app error.
+func toAppError(err error, format string, v ...any) error {
+	switch {
+	case errors.Is(err, disposalbus.ErrNoLines),
+		errors.Is(err, disposalbus.ErrInvalidQuantity),
+		errors.Is(err, disposalbus.ErrStatusRequired),
+		errors.Is(err, disposalbus.ErrDuplicateLine),
+		errors.Is(err, disposalbus.ErrWitnessRequired),
+		errors.Is(err, disposalbus.ErrSelfWitness),
+		errors.Is(err, disposalbus.ErrWitnessDisabled),
+		errors.Is(err, disposalbus.ErrFutureDisposal),
+		errors.Is(err, disposalbus.ErrCertificateRequired),
+		errors.Is(err, disposalbus.ErrCertificateIssued),
+		errors.Is(err, disposalbus.ErrInvalidPeriod),
+		errors.Is(err, inventorybus.ErrInsufficientStock),
+		errors.Is(err, locationbus.ErrNotBin),
+		errors.Is(err, locationbus.ErrInsufficientStock),
+		errors.Is(err, stockbus.ErrInvalidQuantity):
+		return errs.New(errs.FailedPrecondition, err)
+
+	case errors.Is(err, disposalbus.ErrNotFound),
+		errors.Is(err, userbus.ErrNotFound),
+		errors.Is(err, lotbus.ErrNotFound),
+		errors.Is(err, locationbus.ErrNotFound):
+		return errs.New(errs.NotFound, err)
+	}
+
+	return errs.Newf(errs.Internal, format, v...)
+}
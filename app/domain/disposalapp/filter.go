@@ -0,0 +1,110 @@
+package disposalapp
+
+import (
+	"errors"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/disposalbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+func parseFilter(qp QueryParams) (disposalbus.QueryFilter, error) {
+	var filter disposalbus.QueryFilter
+
+	if qp.ID != "" {
+		id, err := uuid.Parse(qp.ID)
+		if err != nil {
+			return disposalbus.QueryFilter{}, validate.NewFieldsError("disposal_id", err)
+		}
+		filter.WithDisposalID(id)
+	}
+
+	if qp.InventoryID != "" {
+		id, err := uuid.Parse(qp.InventoryID)
+		if err != nil {
+			return disposalbus.QueryFilter{}, validate.NewFieldsError("inventory_id", err)
+		}
+		filter.WithInventoryID(id)
+	}
+
+	if qp.Method != "" {
+		method, err := disposalbus.ParseMethod(qp.Method)
+		if err != nil {
+			return disposalbus.QueryFilter{}, validate.NewFieldsError("method", err)
+		}
+		filter.WithMethod(method)
+	}
+
+	if qp.WitnessID != "" {
+		id, err := uuid.Parse(qp.WitnessID)
+		if err != nil {
+			return disposalbus.QueryFilter{}, validate.NewFieldsError("witness_id", err)
+		}
+		filter.WithWitnessID(id)
+	}
+
+	if qp.StartDisposedDate != "" {
+		t, err := time.Parse(time.RFC3339, qp.StartDisposedDate)
+		if err != nil {
+			return disposalbus.QueryFilter{}, validate.NewFieldsError("start_disposed_date", err)
+		}
+		filter.WithStartDisposedDate(t)
+	}
+
+	if qp.EndDisposedDate != "" {
+		t, err := time.Parse(time.RFC3339, qp.EndDisposedDate)
+		if err != nil {
+			return disposalbus.QueryFilter{}, validate.NewFieldsError("end_disposed_date", err)
+		}
+		filter.WithEndDisposedDate(t)
+	}
+
+	return filter, nil
+}
+
+func parseRegisterFilter(rp RegisterParams) (disposalbus.RegisterFilter, error) {
+	var filter disposalbus.RegisterFilter
+
+	if rp.StartDate == "" {
+		return disposalbus.RegisterFilter{}, validate.NewFieldsError("start_date", errNotProvided)
+	}
+
+	start, err := time.Parse(time.RFC3339, rp.StartDate)
+	if err != nil {
+		return disposalbus.RegisterFilter{}, validate.NewFieldsError("start_date", err)
+	}
+	filter.StartDate = start
+
+	if rp.EndDate == "" {
+		return disposalbus.RegisterFilter{}, validate.NewFieldsError("end_date", errNotProvided)
+	}
+
+	end, err := time.Parse(time.RFC3339, rp.EndDate)
+	if err != nil {
+		return disposalbus.RegisterFilter{}, validate.NewFieldsError("end_date", err)
+	}
+	filter.EndDate = end
+
+	if end.Before(start) {
+		return disposalbus.RegisterFilter{}, validate.NewFieldsError("end_date", errors.New("before start_date"))
+	}
+
+	if rp.InventoryID != "" {
+		id, err := uuid.Parse(rp.InventoryID)
+		if err != nil {
+			return disposalbus.RegisterFilter{}, validate.NewFieldsError("inventory_id", err)
+		}
+		filter.WithInventoryID(id)
+	}
+
+	if rp.MedicineID != "" {
+		id, err := uuid.Parse(rp.MedicineID)
+		if err != nil {
+			return disposalbus.RegisterFilter{}, validate.NewFieldsError("medicine_id", err)
+		}
+		filter.WithMedicineID(id)
+	}
+
+	return filter, nil
+}
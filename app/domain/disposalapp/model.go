@@ -0,0 +1,308 @@
+package disposalapp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/business/domain/disposalbus"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Page              int    `query:"page"`
+	Rows              int    `query:"rows"`
+	OrderBy           string `query:"orderBy"`
+	ID                string `query:"disposal_id"`
+	InventoryID       string `query:"inventory_id"`
+	Method            string `query:"method"`
+	WitnessID         string `query:"witness_id"`
+	StartDisposedDate string `query:"start_disposed_date"`
+	EndDisposedDate   string `query:"end_disposed_date"`
+}
+
+// RegisterParams represents the set of possible query strings for the
+// disposal register. The period is required.
+type RegisterParams struct {
+	StartDate   string `query:"start_date"`
+	EndDate     string `query:"end_date"`
+	InventoryID string `query:"inventory_id"`
+	MedicineID  string `query:"medicine_id"`
+}
+
+// Line represents the quantity of a lot taken out of a status by a disposal.
+type Line struct {
+	MedicineID string `json:"medicineID"`
+	LotID      string `json:"lotID"`
+	LocationID string `json:"locationID,omitempty"`
+	Status     string `json:"status"`
+	Quantity   int    `json:"quantity"`
+}
+
+// Certificate represents the certificate of destruction of a disposal.
+type Certificate struct {
+	Number     string `json:"number"`
+	Issuer     string `json:"issuer"`
+	DateIssued string `json:"dateIssued,omitempty"`
+}
+
+func toAppCertificate(cert disposalbus.Certificate) *Certificate {
+	if !cert.Issued() {
+		return nil
+	}
+
+	app := Certificate{
+		Number: cert.Number,
+		Issuer: cert.Issuer,
+	}
+
+	if !cert.DateIssued.IsZero() {
+		app.DateIssued = cert.DateIssued.Format(time.RFC3339)
+	}
+
+	return &app
+}
+
+// Disposal represents information about an individual disposal. Certificate
+// is left out until one is issued.
+type Disposal struct {
+	ID           string       `json:"id"`
+	InventoryID  string       `json:"inventoryID"`
+	Method       string       `json:"method"`
+	Reason       string       `json:"reason"`
+	Lines        []Line       `json:"lines"`
+	DisposedBy   string       `json:"disposedBy"`
+	WitnessedBy  string       `json:"witnessedBy"`
+	Certificate  *Certificate `json:"certificate,omitempty"`
+	DateDisposed string       `json:"dateDisposed"`
+	DateCreated  string       `json:"dateCreated"`
+	DateUpdated  string       `json:"dateUpdated"`
+}
+
+func toAppDisposal(dsp disposalbus.Disposal) Disposal {
+	lines := make([]Line, len(dsp.Lines))
+	for i, line := range dsp.Lines {
+		lines[i] = Line{
+			MedicineID: line.MedicineID.String(),
+			LotID:      line.LotID.String(),
+			Status:     line.Status.Name(),
+			Quantity:   line.Quantity,
+		}
+
+		if line.LocationID != uuid.Nil {
+			lines[i].LocationID = line.LocationID.String()
+		}
+	}
+
+	return Disposal{
+		ID:           dsp.ID.String(),
+		InventoryID:  dsp.InventoryID.String(),
+		Method:       dsp.Method.Name(),
+		Reason:       dsp.Reason,
+		Lines:        lines,
+		DisposedBy:   dsp.DisposedBy.String(),
+		WitnessedBy:  dsp.WitnessedBy.String(),
+		Certificate:  toAppCertificate(dsp.Certificate),
+		DateDisposed: dsp.DateDisposed.Format(time.RFC3339),
+		DateCreated:  dsp.DateCreated.Format(time.RFC3339),
+		DateUpdated:  dsp.DateUpdated.Format(time.RFC3339),
+	}
+}
+
+func toAppDisposals(dsps []disposalbus.Disposal) []Disposal {
+	items := make([]Disposal, len(dsps))
+	for i, dsp := range dsps {
+		items[i] = toAppDisposal(dsp)
+	}
+
+	return items
+}
+
+// RegisterEntry represents a line of the disposal register.
+type RegisterEntry struct {
+	DisposalID        string `json:"disposalID"`
+	InventoryID       string `json:"inventoryID"`
+	MedicineID        string `json:"medicineID"`
+	LotID             string `json:"lotID"`
+	Status            string `json:"status"`
+	Quantity          int    `json:"quantity"`
+	Method            string `json:"method"`
+	DisposedBy        string `json:"disposedBy"`
+	WitnessedBy       string `json:"witnessedBy"`
+	CertificateNumber string `json:"certificateNumber,omitempty"`
+	DateDisposed      string `json:"dateDisposed"`
+}
+
+// RegisterTotal represents the quantity of a medicine disposed of over the
+// period of the register.
+type RegisterTotal struct {
+	MedicineID string `json:"medicineID"`
+	Quantity   int    `json:"quantity"`
+}
+
+// Register represents every disposal over a period.
+type Register struct {
+	StartDate string          `json:"startDate"`
+	EndDate   string          `json:"endDate"`
+	Entries   []RegisterEntry `json:"entries"`
+	Totals    []RegisterTotal `json:"totals"`
+}
+
+func toAppRegister(rgs disposalbus.Register) Register {
+	entries := make([]RegisterEntry, len(rgs.Entries))
+	for i, e := range rgs.Entries {
+		entries[i] = RegisterEntry{
+			DisposalID:        e.DisposalID.String(),
+			InventoryID:       e.InventoryID.String(),
+			MedicineID:        e.MedicineID.String(),
+			LotID:             e.LotID.String(),
+			Status:            e.Status.Name(),
+			Quantity:          e.Quantity,
+			Method:            e.Method.Name(),
+			DisposedBy:        e.DisposedBy.String(),
+			WitnessedBy:       e.WitnessedBy.String(),
+			CertificateNumber: e.CertificateNumber,
+			DateDisposed:      e.DateDisposed.Format(time.RFC3339),
+		}
+	}
+
+	totals := make([]RegisterTotal, len(rgs.Totals))
+	for i, t := range rgs.Totals {
+		totals[i] = RegisterTotal{
+			MedicineID: t.MedicineID.String(),
+			Quantity:   t.Quantity,
+		}
+	}
+
+	return Register{
+		StartDate: rgs.StartDate.Format(time.RFC3339),
+		EndDate:   rgs.EndDate.Format(time.RFC3339),
+		Entries:   entries,
+		Totals:    totals,
+	}
+}
+
+// NewLine defines the quantity of a lot to take out of a status.
+// LocationID is left out for stock that is not put away.
+type NewLine struct {
+	LotID      string `json:"lotID" validate:"required,uuid"`
+	LocationID string `json:"locationID" validate:"omitempty,uuid"`
+	Status     string `json:"status" validate:"required"`
+	Quantity   int    `json:"quantity" validate:"required,gt=0"`
+}
+
+// NewCertificate defines the certificate of destruction of a disposal.
+type NewCertificate struct {
+	Number     string `json:"number" validate:"required"`
+	Issuer     string `json:"issuer" validate:"required"`
+	DateIssued string `json:"dateIssued"`
+}
+
+func toBusCertificate(app NewCertificate) (disposalbus.Certificate, error) {
+	cert := disposalbus.Certificate{
+		Number: app.Number,
+		Issuer: app.Issuer,
+	}
+
+	if app.DateIssued != "" {
+		t, err := time.Parse(time.RFC3339, app.DateIssued)
+		if err != nil {
+			return disposalbus.Certificate{}, fmt.Errorf("parse: %w", err)
+		}
+		cert.DateIssued = t
+	}
+
+	return cert, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewCertificate) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// NewDisposal defines the data needed to record a disposal of stock held by
+// the inventory in the path. DateDisposed defaults to now and Certificate
+// can be attached later when it is issued after the fact. The witness signs
+// the request with their own token in the X-Countersign-Authorization header.
+type NewDisposal struct {
+	Method       string          `json:"method" validate:"required"`
+	Reason       string          `json:"reason"`
+	Lines        []NewLine       `json:"lines" validate:"required,min=1,dive"`
+	Certificate  *NewCertificate `json:"certificate"`
+	DateDisposed string          `json:"dateDisposed"`
+}
+
+func toBusNewDisposal(app NewDisposal, inventoryID uuid.UUID, userID uuid.UUID) (disposalbus.NewDisposal, error) {
+	method, err := disposalbus.ParseMethod(app.Method)
+	if err != nil {
+		return disposalbus.NewDisposal{}, fmt.Errorf("parse: %w", err)
+	}
+
+	lines := make([]disposalbus.NewLine, len(app.Lines))
+	for i, line := range app.Lines {
+		lotID, err := uuid.Parse(line.LotID)
+		if err != nil {
+			return disposalbus.NewDisposal{}, fmt.Errorf("parse: %w", err)
+		}
+
+		var locationID uuid.UUID
+		if line.LocationID != "" {
+			locationID, err = uuid.Parse(line.LocationID)
+			if err != nil {
+				return disposalbus.NewDisposal{}, fmt.Errorf("parse: %w", err)
+			}
+		}
+
+		status, err := inventorybus.ParseStatus(line.Status)
+		if err != nil {
+			return disposalbus.NewDisposal{}, fmt.Errorf("parse: %w", err)
+		}
+
+		lines[i] = disposalbus.NewLine{
+			LotID:      lotID,
+			LocationID: locationID,
+			Status:     status,
+			Quantity:   line.Quantity,
+		}
+	}
+
+	nd := disposalbus.NewDisposal{
+		InventoryID: inventoryID,
+		Method:      method,
+		Reason:      app.Reason,
+		Lines:       lines,
+		UserID:      userID,
+	}
+
+	if app.Certificate != nil {
+		nd.Certificate, err = toBusCertificate(*app.Certificate)
+		if err != nil {
+			return disposalbus.NewDisposal{}, err
+		}
+	}
+
+	if app.DateDisposed != "" {
+		nd.DateDisposed, err = time.Parse(time.RFC3339, app.DateDisposed)
+		if err != nil {
+			return disposalbus.NewDisposal{}, fmt.Errorf("parse: %w", err)
+		}
+	}
+
+	return nd, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewDisposal) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package disposalapp
+
+import (
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/disposalbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+)
+
+func parseOrder(qp QueryParams) (order.By, error) {
+	const (
+		orderByID           = "disposal_id"
+		orderByMethod       = "method"
+		orderByDateDisposed = "date_disposed"
+	)
+
+	var orderByFields = map[string]string{
+		orderByID:           disposalbus.OrderByID,
+		orderByMethod:       disposalbus.OrderByMethod,
+		orderByDateDisposed: disposalbus.OrderByDateDisposed,
+	}
+
+	orderBy, err := order.Parse(qp.OrderBy, order.NewBy(orderByDateDisposed, order.DESC))
+	if err != nil {
+		return order.By{}, err
+	}
+
+	if _, exists := orderByFields[orderBy.Field]; !exists {
+		return order.By{}, validate.NewFieldsError(orderBy.Field, errors.New("order field does not exist"))
+	}
+
+	orderBy.Field = orderByFields[orderBy.Field]
+
+	return orderBy, nil
+}
@@ -0,0 +1,51 @@
+// Package expiryapp maintains the app layer api for the expiry domain.
+package expiryapp
+
+import (
+	"context"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/business/domain/expirybus"
+)
+
+// Core manages the set of app layer api functions for the expiry domain.
+type Core struct {
+	expiryBus *expirybus.Core
+}
+
+// NewCore constructs an expiry core API for use.
+func NewCore(expiryBus *expirybus.Core) *Core {
+	return &Core{
+		expiryBus: expiryBus,
+	}
+}
+
+// Query returns a list of expiry alerts with paging.
+func (c *Core) Query(ctx context.Context, qp QueryParams) (page.Document[Alert], error) {
+	if err := validatePaging(qp); err != nil {
+		return page.Document[Alert]{}, err
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return page.Document[Alert]{}, err
+	}
+
+	orderBy, err := parseOrder(qp)
+	if err != nil {
+		return page.Document[Alert]{}, err
+	}
+
+	alerts, err := c.expiryBus.Query(ctx, filter, orderBy, qp.Page, qp.Rows)
+	if err != nil {
+		return page.Document[Alert]{}, errs.Newf(errs.Internal, "query: %s", err)
+	}
+
+	total, err := c.expiryBus.Count(ctx, filter)
+	if err != nil {
+		return page.Document[Alert]{}, errs.Newf(errs.Internal, "count: %s", err)
+	}
+
+	return page.NewDocument(toAppAlerts(alerts), total, qp.Page, qp.Rows), nil
+}
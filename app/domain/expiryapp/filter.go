@@ -0,0 +1,47 @@
+package expiryapp
+
+import (
+	"strconv"
+
+	"github.com/EnesDemirtas/medisync/business/domain/expirybus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+func parseFilter(qp QueryParams) (expirybus.QueryFilter, error) {
+	var filter expirybus.QueryFilter
+
+	if qp.InventoryID != "" {
+		id, err := uuid.Parse(qp.InventoryID)
+		if err != nil {
+			return expirybus.QueryFilter{}, validate.NewFieldsError("inventory_id", err)
+		}
+		filter.WithInventoryID(id)
+	}
+
+	if qp.LotID != "" {
+		id, err := uuid.Parse(qp.LotID)
+		if err != nil {
+			return expirybus.QueryFilter{}, validate.NewFieldsError("lot_id", err)
+		}
+		filter.WithLotID(id)
+	}
+
+	if qp.MedicineID != "" {
+		id, err := uuid.Parse(qp.MedicineID)
+		if err != nil {
+			return expirybus.QueryFilter{}, validate.NewFieldsError("medicine_id", err)
+		}
+		filter.WithMedicineID(id)
+	}
+
+	if qp.Expired != "" {
+		expired, err := strconv.ParseBool(qp.Expired)
+		if err != nil {
+			return expirybus.QueryFilter{}, validate.NewFieldsError("expired", err)
+		}
+		filter.WithExpired(expired)
+	}
+
+	return filter, nil
+}
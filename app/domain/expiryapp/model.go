@@ -0,0 +1,52 @@
+package expiryapp
+
+import (
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/expirybus"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Page        int    `query:"page"`
+	Rows        int    `query:"rows"`
+	OrderBy     string `query:"orderBy"`
+	InventoryID string `query:"inventory_id"`
+	LotID       string `query:"lot_id"`
+	MedicineID  string `query:"medicine_id"`
+	Expired     string `query:"expired"`
+}
+
+// Alert represents stock found to be expiring within a horizon.
+type Alert struct {
+	InventoryID string `json:"inventoryID"`
+	LotID       string `json:"lotID"`
+	MedicineID  string `json:"medicineID"`
+	ExpiryDate  string `json:"expiryDate"`
+	Quantity    int    `json:"quantity"`
+	Horizon     int    `json:"horizon"`
+	Expired     bool   `json:"expired"`
+	DateAlerted string `json:"dateAlerted"`
+}
+
+func toAppAlert(alert expirybus.Alert) Alert {
+	return Alert{
+		InventoryID: alert.InventoryID.String(),
+		LotID:       alert.LotID.String(),
+		MedicineID:  alert.MedicineID.String(),
+		ExpiryDate:  alert.ExpiryDate.Format(time.RFC3339),
+		Quantity:    alert.Quantity,
+		Horizon:     alert.Horizon,
+		Expired:     alert.Expired(),
+		DateAlerted: alert.DateAlerted.Format(time.RFC3339),
+	}
+}
+
+func toAppAlerts(alerts []expirybus.Alert) []Alert {
+	items := make([]Alert, len(alerts))
+	for i, alert := range alerts {
+		items[i] = toAppAlert(alert)
+	}
+
+	return items
+}
@@ -0,0 +1,42 @@
+package expiryapp
+
+import (
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/expirybus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+)
+
+func parseOrder(qp QueryParams) (order.By, error) {
+	const (
+		orderByInventoryID = "inventory_id"
+		orderByLotID       = "lot_id"
+		orderByMedicineID  = "medicine_id"
+		orderByExpiryDate  = "expiry_date"
+		orderByHorizon     = "horizon"
+		orderByDateAlerted = "date_alerted"
+	)
+
+	var orderByFields = map[string]string{
+		orderByInventoryID: expirybus.OrderByInventoryID,
+		orderByLotID:       expirybus.OrderByLotID,
+		orderByMedicineID:  expirybus.OrderByMedicineID,
+		orderByExpiryDate:  expirybus.OrderByExpiryDate,
+		orderByHorizon:     expirybus.OrderByHorizon,
+		orderByDateAlerted: expirybus.OrderByDateAlerted,
+	}
+
+	orderBy, err := order.Parse(qp.OrderBy, order.NewBy(orderByExpiryDate, order.ASC))
+	if err != nil {
+		return order.By{}, err
+	}
+
+	if _, exists := orderByFields[orderBy.Field]; !exists {
+		return order.By{}, validate.NewFieldsError(orderBy.Field, errors.New("order field does not exist"))
+	}
+
+	orderBy.Field = orderByFields[orderBy.Field]
+
+	return orderBy, nil
+}
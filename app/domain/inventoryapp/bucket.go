@@ -0,0 +1,172 @@
+package inventoryapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// Buckets represents stock split by status.
+type Buckets struct {
+	Available   int `json:"available"`
+	Quarantined int `json:"quarantined"`
+	Damaged     int `json:"damaged"`
+	Expired     int `json:"expired"`
+	OnHand      int `json:"onHand"`
+}
+
+func toAppBuckets(b inventorybus.Buckets) Buckets {
+	return Buckets{
+		Available:   b.Available,
+		Quarantined: b.Quarantined,
+		Damaged:     b.Damaged,
+		Expired:     b.Expired,
+		OnHand:      b.OnHand(),
+	}
+}
+
+// LotBuckets represents the stock of a lot held by an inventory split by
+// status.
+type LotBuckets struct {
+	InventoryID string `json:"inventoryID"`
+	MedicineID  string `json:"medicineID"`
+	LotID       string `json:"lotID"`
+	Buckets
+}
+
+func toAppLotBuckets(lb inventorybus.LotBuckets) LotBuckets {
+	return LotBuckets{
+		InventoryID: lb.InventoryID.String(),
+		MedicineID:  lb.MedicineID.String(),
+		LotID:       lb.LotID.String(),
+		Buckets:     toAppBuckets(lb.Buckets),
+	}
+}
+
+func toAppLotBucketsSlice(lbs []inventorybus.LotBuckets) []LotBuckets {
+	items := make([]LotBuckets, len(lbs))
+	for i, lb := range lbs {
+		items[i] = toAppLotBuckets(lb)
+	}
+
+	return items
+}
+
+// StatusMove defines the data needed to move stock of a lot held by the
+// inventory in the path between statuses.
+type StatusMove struct {
+	LotID    string `json:"lotID" validate:"required,uuid"`
+	From     string `json:"from" validate:"required"`
+	To       string `json:"to" validate:"required"`
+	Quantity int    `json:"quantity" validate:"required,gt=0"`
+	Reason   string `json:"reason" validate:"required"`
+}
+
+func toBusStatusMove(app StatusMove, inventoryID uuid.UUID, userID uuid.UUID) (inventorybus.StatusMove, error) {
+	lotID, err := uuid.Parse(app.LotID)
+	if err != nil {
+		return inventorybus.StatusMove{}, fmt.Errorf("parse: %w", err)
+	}
+
+	from, err := inventorybus.ParseStatus(app.From)
+	if err != nil {
+		return inventorybus.StatusMove{}, fmt.Errorf("parse: %w", err)
+	}
+
+	to, err := inventorybus.ParseStatus(app.To)
+	if err != nil {
+		return inventorybus.StatusMove{}, fmt.Errorf("parse: %w", err)
+	}
+
+	sm := inventorybus.StatusMove{
+		InventoryID: inventoryID,
+		LotID:       lotID,
+		From:        from,
+		To:          to,
+		Quantity:    app.Quantity,
+		Reason:      app.Reason,
+		UserID:      userID,
+	}
+
+	return sm, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app StatusMove) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// =============================================================================
+
+// MoveStatus moves stock of a lot held by the inventory in context between
+// statuses.
+func (c *Core) MoveStatus(ctx context.Context, app StatusMove) (LotBuckets, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return LotBuckets{}, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return LotBuckets{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	sm, err := toBusStatusMove(app, inv.ID, userID)
+	if err != nil {
+		return LotBuckets{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	tx, ok := transaction.Get(ctx)
+	if !ok {
+		return LotBuckets{}, errs.Newf(errs.Internal, "transaction missing in context")
+	}
+
+	inventoryBus, err := c.inventoryBus.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return LotBuckets{}, errs.New(errs.Internal, err)
+	}
+
+	lb, err := inventoryBus.MoveStatus(ctx, sm)
+	if err != nil {
+		switch {
+		case errors.Is(err, inventorybus.ErrInsufficientStock),
+			errors.Is(err, inventorybus.ErrReasonRequired),
+			errors.Is(err, inventorybus.ErrSameStatus),
+			errors.Is(err, inventorybus.ErrInvalidQuantity):
+			return LotBuckets{}, errs.New(errs.FailedPrecondition, err)
+		}
+		return LotBuckets{}, errs.Newf(errs.Internal, "movestatus: inventoryID[%s] sm[%+v]: %s", inv.ID, app, err)
+	}
+
+	return toAppLotBuckets(lb), nil
+}
+
+// QueryBuckets returns the stock of every lot held by the inventory in
+// context split by status.
+func (c *Core) QueryBuckets(ctx context.Context) ([]LotBuckets, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	var filter inventorybus.BucketFilter
+	filter.WithInventoryID(inv.ID)
+
+	lbs, err := c.inventoryBus.QueryBuckets(ctx, filter)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "querybuckets: inventoryID[%s]: %s", inv.ID, err)
+	}
+
+	return toAppLotBucketsSlice(lbs), nil
+}
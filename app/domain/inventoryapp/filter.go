@@ -1,7 +1,7 @@
 package inventoryapp
 
 import (
-	"github.com/EnesDemirtas/medisync/business/core/crud/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
 	"github.com/EnesDemirtas/medisync/foundation/validate"
 	"github.com/google/uuid"
 )
@@ -25,7 +25,60 @@ func parseFilter(qp QueryParams) (inventorybus.QueryFilter, error) {
 		filter.WithDescription(qp.Description)
 	}
 
+	if qp.MedicineID != "" {
+		id, err := uuid.Parse(qp.MedicineID)
+		if err != nil {
+			return inventorybus.QueryFilter{}, validate.NewFieldsError("medicine_id", err)
+		}
+		filter.WithMedicine(id)
+	}
+
 	// TODO: Add missing filters.
 
 	return filter, nil
-}
\ No newline at end of file
+}
+
+func parseStockLevelFilter(qp StockLevelParams) (inventorybus.StockLevelFilter, error) {
+	var filter inventorybus.StockLevelFilter
+	filter.WithBelowThreshold(true)
+
+	if qp.InventoryID != "" {
+		id, err := uuid.Parse(qp.InventoryID)
+		if err != nil {
+			return inventorybus.StockLevelFilter{}, validate.NewFieldsError("inventory_id", err)
+		}
+		filter.WithInventoryID(id)
+	}
+
+	if qp.MedicineID != "" {
+		id, err := uuid.Parse(qp.MedicineID)
+		if err != nil {
+			return inventorybus.StockLevelFilter{}, validate.NewFieldsError("medicine_id", err)
+		}
+		filter.WithMedicineID(id)
+	}
+
+	return filter, nil
+}
+
+func parseViolationFilter(qp ViolationParams) (inventorybus.BucketFilter, error) {
+	var filter inventorybus.BucketFilter
+
+	if qp.InventoryID != "" {
+		id, err := uuid.Parse(qp.InventoryID)
+		if err != nil {
+			return inventorybus.BucketFilter{}, validate.NewFieldsError("inventory_id", err)
+		}
+		filter.WithInventoryID(id)
+	}
+
+	if qp.MedicineID != "" {
+		id, err := uuid.Parse(qp.MedicineID)
+		if err != nil {
+			return inventorybus.BucketFilter{}, validate.NewFieldsError("medicine_id", err)
+		}
+		filter.WithMedicineID(id)
+	}
+
+	return filter, nil
+}
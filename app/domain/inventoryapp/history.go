@@ -0,0 +1,76 @@
+package inventoryapp
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+)
+
+// InventoryAsOf represents what an inventory held at a point in time. It
+// carries no version since it can't be updated.
+type InventoryAsOf struct {
+	ID            string         `json:"id"`
+	Name          string         `json:"name"`
+	Description   string         `json:"description"`
+	LotQuantities map[string]int `json:"lotQuantities"`
+	AsOf          string         `json:"asOf"`
+}
+
+func toAppInventoryAsOf(app Inventory, hs []stockbus.Holding, asOf time.Time) InventoryAsOf {
+	lotQua := make(map[string]int, len(hs))
+	for _, h := range hs {
+		lotQua[h.LotID.String()] = h.Quantity
+	}
+
+	return InventoryAsOf{
+		ID:            app.ID,
+		Name:          app.Name,
+		Description:   app.Description,
+		LotQuantities: lotQua,
+		AsOf:          asOf.Format(time.RFC3339),
+	}
+}
+
+// parseAsOf parses the point in time of a historical query.
+func parseAsOf(asOf string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, asOf)
+	if err != nil {
+		return time.Time{}, validate.NewFieldsError("asOf", err)
+	}
+
+	return t, nil
+}
+
+// =============================================================================
+
+// QueryByIDAsOf returns the quantities the inventory in context held at the
+// time asked for, rebuilt from the stock ledger.
+func (c *Core) QueryByIDAsOf(ctx context.Context, asOf string) (InventoryAsOf, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return InventoryAsOf{}, errs.Newf(errs.Internal, "querybyidasof: %s", err)
+	}
+
+	t, err := parseAsOf(asOf)
+	if err != nil {
+		return InventoryAsOf{}, err
+	}
+
+	var filter stockbus.HoldingFilter
+	filter.WithInventoryID(inv.ID)
+
+	hs, err := c.stockBus.QueryHoldings(ctx, filter, t)
+	if err != nil {
+		if errors.Is(err, stockbus.ErrFutureAsOf) || errors.Is(err, stockbus.ErrBeforeHistory) {
+			return InventoryAsOf{}, errs.New(errs.FailedPrecondition, err)
+		}
+		return InventoryAsOf{}, errs.Newf(errs.Internal, "queryholdings: inventoryID[%s] asOf[%s]: %s", inv.ID, asOf, err)
+	}
+
+	return toAppInventoryAsOf(toAppInventory(inv), hs, t), nil
+}
@@ -3,28 +3,35 @@ package inventoryapp
 
 import (
 	"context"
+	"errors"
 
 	"github.com/EnesDemirtas/medisync/app/api/errs"
 	"github.com/EnesDemirtas/medisync/app/api/mid"
 	"github.com/EnesDemirtas/medisync/app/api/page"
-	"github.com/EnesDemirtas/medisync/business/core/crud/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
 )
 
 // Core manages the set of app layer api functions for the inventory domain.
 type Core struct {
 	inventoryBus *inventorybus.Core
+	stockBus     *stockbus.Core
 }
 
 // NewCore constructs an inventory core API for use.
-func NewCore(inventoryBus *inventorybus.Core) *Core {
-	return &Core {
+func NewCore(inventoryBus *inventorybus.Core, stockBus *stockbus.Core) *Core {
+	return &Core{
 		inventoryBus: inventoryBus,
+		stockBus:     stockBus,
 	}
 }
 
 // Create adds a new inventory to the system.
 func (c *Core) Create(ctx context.Context, app NewInventory) (Inventory, error) {
-	ni := toBusNewInventory(app)
+	ni, err := toBusNewInventory(app)
+	if err != nil {
+		return Inventory{}, errs.New(errs.FailedPrecondition, err)
+	}
 
 	inv, err := c.inventoryBus.Create(ctx, ni)
 	if err != nil {
@@ -41,13 +48,20 @@ func (c *Core) Update(ctx context.Context, app UpdateInventory) (Inventory, erro
 		return Inventory{}, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
 	}
 
-	busUpdInv, err := toBusUpdateInventory(app)
-	if err != nil {
+	if err := mid.CheckIfMatch(ctx, inv.Version); err != nil {
 		return Inventory{}, err
 	}
 
-	updInv, err := c.inventoryBus.Update(ctx, inv, busUpdInv)
+	ui, err := toBusUpdateInventory(app)
+	if err != nil {
+		return Inventory{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	updInv, err := c.inventoryBus.Update(ctx, inv, ui)
 	if err != nil {
+		if errors.Is(err, inventorybus.ErrVersionConflict) {
+			return Inventory{}, errs.New(errs.PreconditionFailed, err)
+		}
 		return Inventory{}, errs.Newf(errs.Internal, "update: inventoryID[%s] up[%+v]: %s", inv.ID, app, err)
 	}
 
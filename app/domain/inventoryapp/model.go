@@ -1,12 +1,13 @@
 package inventoryapp
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/EnesDemirtas/medisync/app/api/errs"
-	"github.com/EnesDemirtas/medisync/business/core/crud/inventorybus"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
 	"github.com/EnesDemirtas/medisync/foundation/validate"
-	"github.com/google/uuid"
 )
 
 // QueryParams represents the set of possible query strings.
@@ -17,34 +18,78 @@ type QueryParams struct {
 	ID 			string 	`json:"inventory_id"`
 	Name		string  `json:"name"`
 	Description string  `json:"description"`
+	MedicineID  string  `json:"medicine_id"`
 }
 
-// Inventory represents information about an indiviual inventory.
+// Inventory represents information about an indiviual inventory. Medicines
+// holds the stock of every medicine split by status.
 type Inventory struct {
 	ID 				   string 		  `json:"id"`
 	Name    		   string 		  `json:"name"`
 	Description 	   string 		  `json:"description"`
-	MedicineQuantities map[string]int `json:"medicineQuantities"`
+	Capabilities	   []string		  `json:"capabilities"`
+	LotQuantities 	   map[string]int `json:"lotQuantities"`
+	Medicines 		   map[string]Buckets `json:"medicines"`
+	Version 		   int 			  `json:"version"`
 	DateCreated 	   string 		  `json:"dateCreated"`
 	DateUpdated 	   string 		  `json:"dateUpdated"`
 }
 
 func toAppInventory(inv inventorybus.Inventory) Inventory {
-	medQua := make(map[string]int, len(inv.MedicineQuantities))
-	for k, v := range inv.MedicineQuantities {
-		medQua[k.String()] = v
+	lotQua := make(map[string]int, len(inv.LotQuantities))
+	for k, v := range inv.LotQuantities {
+		lotQua[k.String()] = v
+	}
+
+	meds := make(map[string]Buckets, len(inv.MedicineBuckets))
+	for k, v := range inv.MedicineBuckets {
+		meds[k.String()] = toAppBuckets(v)
 	}
 
 	return Inventory{
 		ID:			 inv.ID.String(),
 		Name:		 inv.Name,
 		Description: inv.Description,
-		MedicineQuantities: medQua,
+		Capabilities: toAppCapabilities(inv.Capabilities),
+		LotQuantities: lotQua,
+		Medicines:	 meds,
+		Version:	 inv.Version,
 		DateCreated: inv.DateCreated.Format(time.RFC3339),
 		DateUpdated: inv.DateUpdated.Format(time.RFC3339),
 	}
 }
 
+func toAppCapabilities(caps []inventorybus.Capability) []string {
+	items := make([]string, len(caps))
+	for i, c := range caps {
+		items[i] = c.Name()
+	}
+
+	return items
+}
+
+func toBusCapabilities(app []string) ([]inventorybus.Capability, error) {
+	if app == nil {
+		return nil, nil
+	}
+
+	caps := make([]inventorybus.Capability, len(app))
+	for i, name := range app {
+		c, err := inventorybus.ParseCapability(name)
+		if err != nil {
+			return nil, fmt.Errorf("parse: %w", err)
+		}
+		caps[i] = c
+	}
+
+	return caps, nil
+}
+
+// ETag implements the web.ETagger interface.
+func (app Inventory) ETag() string {
+	return mid.ETag(app.Version)
+}
+
 func toAppInventories(invs []inventorybus.Inventory) []Inventory {
 	items := make([]Inventory, len(invs))
 	for i, inv := range invs {
@@ -56,17 +101,24 @@ func toAppInventories(invs []inventorybus.Inventory) []Inventory {
 
 // NewInventory defines the data needed to adda new inventory.
 type NewInventory struct {
-	Name			   string `json:"name" validate:"required"`
-	Description 	   string `json:"description"`
+	Name			   string   `json:"name" validate:"required"`
+	Description 	   string   `json:"description"`
+	Capabilities	   []string `json:"capabilities"`
 }
 
-func toBusNewInventory(app NewInventory) inventorybus.NewInventory {
+func toBusNewInventory(app NewInventory) (inventorybus.NewInventory, error) {
+	caps, err := toBusCapabilities(app.Capabilities)
+	if err != nil {
+		return inventorybus.NewInventory{}, err
+	}
+
 	inv := inventorybus.NewInventory{
 		Name: 		 app.Name,
 		Description: app.Description,
+		Capabilities: caps,
 	}
 
-	return inv
+	return inv, nil
 }
 
 // Validate checks the data in the model is considered clean.
@@ -82,25 +134,19 @@ func (app NewInventory) Validate() error {
 type UpdateInventory struct {
 	Name 			   *string 		  `json:"name"`
 	Description        *string 		  `json:"description"`
-	MedicineQuantities map[string]int `json:"medicineQuantities" validate:"omitempty"`
+	Capabilities	   []string		  `json:"capabilities"`
 }
 
 func toBusUpdateInventory(app UpdateInventory) (inventorybus.UpdateInventory, error) {
-	meds := make(map[uuid.UUID]int, len(app.MedicineQuantities))
-	for idStr, qua := range app.MedicineQuantities {
-		id, err := uuid.Parse(idStr)
-		if err != nil {
-			return inventorybus.UpdateInventory{}, errs.Newf(errs.Internal, "parse: medicineID[%s]: %s", idStr, err)
-		}
-		meds[id] = qua
+	caps, err := toBusCapabilities(app.Capabilities)
+	if err != nil {
+		return inventorybus.UpdateInventory{}, err
 	}
 
-	// TODO: Check specified medicine ids are valid.
-
 	inv := inventorybus.UpdateInventory{
 		Name: 			app.Name,
 		Description:    app.Description,
-		MedicineQuantities: meds,
+		Capabilities:	caps,
 	}
 
 	return inv, nil
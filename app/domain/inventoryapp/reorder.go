@@ -0,0 +1,191 @@
+package inventoryapp
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// StockLevelParams represents the set of possible query strings for the
+// low stock report.
+type StockLevelParams struct {
+	InventoryID string `json:"inventory_id"`
+	MedicineID  string `json:"medicine_id"`
+}
+
+// ReorderPoint represents the stock level settings of a medicine held by an
+// inventory.
+type ReorderPoint struct {
+	InventoryID  string `json:"inventoryID"`
+	MedicineID   string `json:"medicineID"`
+	MinQuantity  int    `json:"minQuantity"`
+	MaxQuantity  int    `json:"maxQuantity"`
+	ReorderPoint int    `json:"reorderPoint"`
+	DateCreated  string `json:"dateCreated"`
+	DateUpdated  string `json:"dateUpdated"`
+}
+
+func toAppReorderPoint(rp inventorybus.ReorderPoint) ReorderPoint {
+	return ReorderPoint{
+		InventoryID:  rp.InventoryID.String(),
+		MedicineID:   rp.MedicineID.String(),
+		MinQuantity:  rp.MinQuantity,
+		MaxQuantity:  rp.MaxQuantity,
+		ReorderPoint: rp.ReorderPoint,
+		DateCreated:  rp.DateCreated.Format(time.RFC3339),
+		DateUpdated:  rp.DateUpdated.Format(time.RFC3339),
+	}
+}
+
+// StockLevel represents the quantity on hand of a medicine against its
+// reorder point settings.
+type StockLevel struct {
+	ReorderPoint
+	OnHand            int  `json:"onHand"`
+	BelowThreshold    bool `json:"belowThreshold"`
+	SuggestedQuantity int  `json:"suggestedQuantity"`
+}
+
+func toAppStockLevel(sl inventorybus.StockLevel) StockLevel {
+	return StockLevel{
+		ReorderPoint:      toAppReorderPoint(sl.ReorderPoint),
+		OnHand:            sl.OnHand,
+		BelowThreshold:    sl.BelowThreshold(),
+		SuggestedQuantity: sl.SuggestedQuantity(),
+	}
+}
+
+func toAppStockLevels(sls []inventorybus.StockLevel) []StockLevel {
+	items := make([]StockLevel, len(sls))
+	for i, sl := range sls {
+		items[i] = toAppStockLevel(sl)
+	}
+
+	return items
+}
+
+// NewReorderPoint defines the data needed to set the stock level settings
+// of a medicine in an inventory.
+type NewReorderPoint struct {
+	MinQuantity  int `json:"minQuantity" validate:"min=0"`
+	MaxQuantity  int `json:"maxQuantity" validate:"min=0"`
+	ReorderPoint int `json:"reorderPoint" validate:"min=0"`
+}
+
+func toBusNewReorderPoint(app NewReorderPoint, inventoryID uuid.UUID, medicineID uuid.UUID) inventorybus.NewReorderPoint {
+	return inventorybus.NewReorderPoint{
+		InventoryID:  inventoryID,
+		MedicineID:   medicineID,
+		MinQuantity:  app.MinQuantity,
+		MaxQuantity:  app.MaxQuantity,
+		ReorderPoint: app.ReorderPoint,
+	}
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewReorderPoint) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// =============================================================================
+
+// SetReorderPoint sets the stock level settings of the medicine in context
+// for the inventory in context.
+func (c *Core) SetReorderPoint(ctx context.Context, app NewReorderPoint) (ReorderPoint, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return ReorderPoint{}, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	med, err := mid.GetMedicine(ctx)
+	if err != nil {
+		return ReorderPoint{}, errs.Newf(errs.Internal, "medicine missing in context: %s", err)
+	}
+
+	rp, err := c.inventoryBus.SetReorderPoint(ctx, toBusNewReorderPoint(app, inv.ID, med.ID))
+	if err != nil {
+		if errors.Is(err, inventorybus.ErrInvalidReorderPoint) {
+			return ReorderPoint{}, errs.New(errs.FailedPrecondition, err)
+		}
+		if errors.Is(err, medicinebus.ErrNotFound) {
+			return ReorderPoint{}, errs.New(errs.NotFound, err)
+		}
+		return ReorderPoint{}, errs.Newf(errs.Internal, "setreorderpoint: inventoryID[%s] medicineID[%s]: %s", inv.ID, med.ID, err)
+	}
+
+	return toAppReorderPoint(rp), nil
+}
+
+// DeleteReorderPoint removes the stock level settings of the medicine in
+// context for the inventory in context.
+func (c *Core) DeleteReorderPoint(ctx context.Context) error {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	med, err := mid.GetMedicine(ctx)
+	if err != nil {
+		return errs.Newf(errs.Internal, "medicine missing in context: %s", err)
+	}
+
+	rp, err := c.inventoryBus.QueryReorderPoint(ctx, inv.ID, med.ID)
+	if err != nil {
+		if errors.Is(err, inventorybus.ErrReorderPointNotFound) {
+			return errs.New(errs.NotFound, err)
+		}
+		return errs.Newf(errs.Internal, "queryreorderpoint: inventoryID[%s] medicineID[%s]: %s", inv.ID, med.ID, err)
+	}
+
+	if err := c.inventoryBus.DeleteReorderPoint(ctx, rp); err != nil {
+		return errs.Newf(errs.Internal, "deletereorderpoint: inventoryID[%s] medicineID[%s]: %s", inv.ID, med.ID, err)
+	}
+
+	return nil
+}
+
+// QueryReorderPoints returns the stock levels of every medicine with reorder
+// point settings in the inventory in context.
+func (c *Core) QueryReorderPoints(ctx context.Context) ([]StockLevel, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	var filter inventorybus.StockLevelFilter
+	filter.WithInventoryID(inv.ID)
+
+	sls, err := c.inventoryBus.QueryStockLevels(ctx, filter)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "querystocklevels: inventoryID[%s]: %s", inv.ID, err)
+	}
+
+	return toAppStockLevels(sls), nil
+}
+
+// QueryLowStock returns every medicine at or below its reorder point along
+// with the quantity suggested to reorder.
+func (c *Core) QueryLowStock(ctx context.Context, qp StockLevelParams) ([]StockLevel, error) {
+	filter, err := parseStockLevelFilter(qp)
+	if err != nil {
+		return nil, err
+	}
+
+	sls, err := c.inventoryBus.QueryStockLevels(ctx, filter)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "querystocklevels: %s", err)
+	}
+
+	return toAppStockLevels(sls), nil
+}
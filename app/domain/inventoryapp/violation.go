@@ -0,0 +1,68 @@
+package inventoryapp
+
+import (
+	"context"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+)
+
+// ViolationParams represents the set of possible query strings for the
+// storage violations report.
+type ViolationParams struct {
+	InventoryID string `json:"inventory_id"`
+	MedicineID  string `json:"medicine_id"`
+}
+
+// Violation represents stock of a lot held by an inventory that is not able
+// to provide the storage conditions its medicine requires.
+type Violation struct {
+	InventoryID      string   `json:"inventoryID"`
+	MedicineID       string   `json:"medicineID"`
+	LotID            string   `json:"lotID"`
+	OnHand           int      `json:"onHand"`
+	Temperature      string   `json:"temperature"`
+	ProtectFromLight bool     `json:"protectFromLight"`
+	Capabilities     []string `json:"capabilities"`
+	Reasons          []string `json:"reasons"`
+}
+
+func toAppViolation(vl inventorybus.Violation) Violation {
+	return Violation{
+		InventoryID:      vl.InventoryID.String(),
+		MedicineID:       vl.MedicineID.String(),
+		LotID:            vl.LotID.String(),
+		OnHand:           vl.OnHand,
+		Temperature:      vl.Storage.Temperature.Name(),
+		ProtectFromLight: vl.Storage.ProtectFromLight,
+		Capabilities:     toAppCapabilities(vl.Capabilities),
+		Reasons:          vl.Reasons,
+	}
+}
+
+func toAppViolations(vls []inventorybus.Violation) []Violation {
+	items := make([]Violation, len(vls))
+	for i, vl := range vls {
+		items[i] = toAppViolation(vl)
+	}
+
+	return items
+}
+
+// =============================================================================
+
+// QueryViolations returns the stock held by inventories that do not meet the
+// storage requirements of its medicine.
+func (c *Core) QueryViolations(ctx context.Context, qp ViolationParams) ([]Violation, error) {
+	filter, err := parseViolationFilter(qp)
+	if err != nil {
+		return nil, err
+	}
+
+	vls, err := c.inventoryBus.QueryViolations(ctx, filter)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "queryviolations: %s", err)
+	}
+
+	return toAppViolations(vls), nil
+}
@@ -0,0 +1,83 @@
+package locationapp
+
+import (
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+func parseFilter(qp QueryParams) (locationbus.QueryFilter, error) {
+	var filter locationbus.QueryFilter
+
+	if qp.ID != "" {
+		id, err := uuid.Parse(qp.ID)
+		if err != nil {
+			return locationbus.QueryFilter{}, validate.NewFieldsError("location_id", err)
+		}
+		filter.WithLocationID(id)
+	}
+
+	if qp.InventoryID != "" {
+		id, err := uuid.Parse(qp.InventoryID)
+		if err != nil {
+			return locationbus.QueryFilter{}, validate.NewFieldsError("inventory_id", err)
+		}
+		filter.WithInventoryID(id)
+	}
+
+	if qp.ParentID != "" {
+		id, err := uuid.Parse(qp.ParentID)
+		if err != nil {
+			return locationbus.QueryFilter{}, validate.NewFieldsError("parent_id", err)
+		}
+		filter.WithParentID(id)
+	}
+
+	if qp.Kind != "" {
+		kind, err := locationbus.ParseKind(qp.Kind)
+		if err != nil {
+			return locationbus.QueryFilter{}, validate.NewFieldsError("kind", err)
+		}
+		filter.WithKind(kind)
+	}
+
+	return filter, nil
+}
+
+func parsePlacementFilter(qp PlacementParams) (locationbus.PlacementFilter, error) {
+	var filter locationbus.PlacementFilter
+
+	if qp.InventoryID != "" {
+		id, err := uuid.Parse(qp.InventoryID)
+		if err != nil {
+			return locationbus.PlacementFilter{}, validate.NewFieldsError("inventory_id", err)
+		}
+		filter.WithInventoryID(id)
+	}
+
+	if qp.LocationID != "" {
+		id, err := uuid.Parse(qp.LocationID)
+		if err != nil {
+			return locationbus.PlacementFilter{}, validate.NewFieldsError("location_id", err)
+		}
+		filter.WithLocationID(id)
+	}
+
+	if qp.MedicineID != "" {
+		id, err := uuid.Parse(qp.MedicineID)
+		if err != nil {
+			return locationbus.PlacementFilter{}, validate.NewFieldsError("medicine_id", err)
+		}
+		filter.WithMedicineID(id)
+	}
+
+	if qp.LotID != "" {
+		id, err := uuid.Parse(qp.LotID)
+		if err != nil {
+			return locationbus.PlacementFilter{}, validate.NewFieldsError("lot_id", err)
+		}
+		filter.WithLotID(id)
+	}
+
+	return filter, nil
+}
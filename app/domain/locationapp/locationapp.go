@@ -0,0 +1,211 @@
+// Package locationapp maintains the app layer api for the location domain.
+package locationapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+)
+
+// Core manages the set of app layer api functions for the location domain.
+type Core struct {
+	locationBus *locationbus.Core
+}
+
+// NewCore constructs a location core API for use.
+func NewCore(locationBus *locationbus.Core) *Core {
+	return &Core{
+		locationBus: locationBus,
+	}
+}
+
+// Create adds a new location to the inventory in context.
+func (c *Core) Create(ctx context.Context, app NewLocation) (Location, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return Location{}, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	nl, err := toBusNewLocation(app, inv.ID)
+	if err != nil {
+		return Location{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	loc, err := c.locationBus.Create(ctx, nl)
+	if err != nil {
+		return Location{}, toAppError(err, "create: loc[%+v]: %s", nl, err)
+	}
+
+	return toAppLocation(loc), nil
+}
+
+// Update updates an existing location.
+func (c *Core) Update(ctx context.Context, app UpdateLocation) (Location, error) {
+	loc, err := mid.GetLocation(ctx)
+	if err != nil {
+		return Location{}, errs.Newf(errs.Internal, "location missing in context: %s", err)
+	}
+
+	updLoc, err := c.locationBus.Update(ctx, loc, toBusUpdateLocation(app))
+	if err != nil {
+		return Location{}, toAppError(err, "update: locationID[%s] ul[%+v]: %s", loc.ID, app, err)
+	}
+
+	return toAppLocation(updLoc), nil
+}
+
+// Delete removes a location.
+func (c *Core) Delete(ctx context.Context) error {
+	loc, err := mid.GetLocation(ctx)
+	if err != nil {
+		return errs.Newf(errs.Internal, "locationID missing in context: %s", err)
+	}
+
+	if err := c.locationBus.Delete(ctx, loc); err != nil {
+		return toAppError(err, "delete: locationID[%s]: %s", loc.ID, err)
+	}
+
+	return nil
+}
+
+// Query returns a list of locations with paging.
+func (c *Core) Query(ctx context.Context, qp QueryParams) (page.Document[Location], error) {
+	if err := validatePaging(qp); err != nil {
+		return page.Document[Location]{}, err
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return page.Document[Location]{}, err
+	}
+
+	orderBy, err := parseOrder(qp)
+	if err != nil {
+		return page.Document[Location]{}, err
+	}
+
+	locs, err := c.locationBus.Query(ctx, filter, orderBy, qp.Page, qp.Rows)
+	if err != nil {
+		return page.Document[Location]{}, errs.Newf(errs.Internal, "query: %s", err)
+	}
+
+	total, err := c.locationBus.Count(ctx, filter)
+	if err != nil {
+		return page.Document[Location]{}, errs.Newf(errs.Internal, "count: %s", err)
+	}
+
+	return page.NewDocument(toAppLocations(locs), total, qp.Page, qp.Rows), nil
+}
+
+// QueryByID returns a location by its ID.
+func (c *Core) QueryByID(ctx context.Context) (Location, error) {
+	loc, err := mid.GetLocation(ctx)
+	if err != nil {
+		return Location{}, errs.Newf(errs.Internal, "querybyid: %s", err)
+	}
+
+	return toAppLocation(loc), nil
+}
+
+// Relocate moves stock of a lot between the bins of the inventory in context
+// and returns where the lot is held afterwards.
+func (c *Core) Relocate(ctx context.Context, app Relocation) ([]Placement, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	r, err := toBusRelocation(app, inv.ID)
+	if err != nil {
+		return nil, errs.New(errs.FailedPrecondition, err)
+	}
+
+	locationBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return nil, errs.New(errs.Internal, err)
+	}
+
+	placements, err := locationBus.Relocate(ctx, r)
+	if err != nil {
+		return nil, toAppError(err, "relocate: r[%+v]: %s", r, err)
+	}
+
+	return toAppPlacements(placements), nil
+}
+
+// QueryPlacements returns the bins holding stock along with the stock that
+// has not been put away yet.
+func (c *Core) QueryPlacements(ctx context.Context, qp PlacementParams) ([]Placement, error) {
+	filter, err := parsePlacementFilter(qp)
+	if err != nil {
+		return nil, err
+	}
+
+	placements, err := c.locationBus.QueryPlacements(ctx, filter)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "queryplacements: %s", err)
+	}
+
+	return toAppPlacements(placements), nil
+}
+
+// QueryRollups returns every location of the inventory in context with the
+// quantity held in the bins at or below it.
+func (c *Core) QueryRollups(ctx context.Context, qp PlacementParams) ([]Rollup, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	qp.InventoryID = inv.ID.String()
+
+	filter, err := parsePlacementFilter(qp)
+	if err != nil {
+		return nil, err
+	}
+
+	rollups, err := c.locationBus.QueryRollups(ctx, filter)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "queryrollups: inventoryID[%s]: %s", inv.ID, err)
+	}
+
+	return toAppRollups(rollups), nil
+}
+
+// executeUnderTransaction returns a location core bound to the transaction
+// the transaction middleware placed in the context.
+func (c *Core) executeUnderTransaction(ctx context.Context) (*locationbus.Core, error) {
+	tx, ok := transaction.Get(ctx)
+	if !ok {
+		return nil, errors.New("transaction missing in context")
+	}
+
+	return c.locationBus.ExecuteUnderTransaction(tx)
+}
+
+// toAppError maps the business errors a location can fail with to the
+// matching app error.
+func toAppError(err error, format string, v ...any) error {
+	switch {
+	case errors.Is(err, locationbus.ErrUniqueCode),
+		errors.Is(err, locationbus.ErrInvalidParent),
+		errors.Is(err, locationbus.ErrInUse),
+		errors.Is(err, locationbus.ErrNotBin),
+		errors.Is(err, locationbus.ErrInvalidQuantity),
+		errors.Is(err, locationbus.ErrSameLocation),
+		errors.Is(err, locationbus.ErrInsufficientStock):
+		return errs.New(errs.FailedPrecondition, err)
+
+	case errors.Is(err, locationbus.ErrNotFound),
+		errors.Is(err, inventorybus.ErrNotFound):
+		return errs.New(errs.NotFound, err)
+	}
+
+	return errs.Newf(errs.Internal, format, v...)
+}
@@ -0,0 +1,246 @@
+package locationapp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Page        int    `query:"page"`
+	Rows        int    `query:"rows"`
+	OrderBy     string `query:"orderBy"`
+	ID          string `query:"location_id"`
+	InventoryID string `query:"inventory_id"`
+	ParentID    string `query:"parent_id"`
+	Kind        string `query:"kind"`
+}
+
+// PlacementParams represents the set of possible query strings for the
+// placement and rollup queries.
+type PlacementParams struct {
+	InventoryID string `query:"inventory_id"`
+	LocationID  string `query:"location_id"`
+	MedicineID  string `query:"medicine_id"`
+	LotID       string `query:"lot_id"`
+}
+
+// Location represents information about an individual location.
+type Location struct {
+	ID          string `json:"id"`
+	InventoryID string `json:"inventoryID"`
+	ParentID    string `json:"parentID,omitempty"`
+	Kind        string `json:"kind"`
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	DateCreated string `json:"dateCreated"`
+	DateUpdated string `json:"dateUpdated"`
+}
+
+func toAppLocation(loc locationbus.Location) Location {
+	app := Location{
+		ID:          loc.ID.String(),
+		InventoryID: loc.InventoryID.String(),
+		Kind:        loc.Kind.Name(),
+		Code:        loc.Code,
+		Name:        loc.Name,
+		Path:        loc.Path,
+		DateCreated: loc.DateCreated.Format(time.RFC3339),
+		DateUpdated: loc.DateUpdated.Format(time.RFC3339),
+	}
+
+	if loc.ParentID != uuid.Nil {
+		app.ParentID = loc.ParentID.String()
+	}
+
+	return app
+}
+
+func toAppLocations(locs []locationbus.Location) []Location {
+	items := make([]Location, len(locs))
+	for i, loc := range locs {
+		items[i] = toAppLocation(loc)
+	}
+
+	return items
+}
+
+// Placement represents the quantity of a lot held in a bin. Stock that has
+// not been put away is reported without a location.
+type Placement struct {
+	InventoryID string `json:"inventoryID"`
+	LocationID  string `json:"locationID,omitempty"`
+	Path        string `json:"path,omitempty"`
+	MedicineID  string `json:"medicineID"`
+	LotID       string `json:"lotID"`
+	Quantity    int    `json:"quantity"`
+	Unplaced    bool   `json:"unplaced"`
+}
+
+func toAppPlacement(p locationbus.Placement) Placement {
+	app := Placement{
+		InventoryID: p.InventoryID.String(),
+		Path:        p.Path,
+		MedicineID:  p.MedicineID.String(),
+		LotID:       p.LotID.String(),
+		Quantity:    p.Quantity,
+		Unplaced:    p.Unplaced(),
+	}
+
+	if !p.Unplaced() {
+		app.LocationID = p.LocationID.String()
+	}
+
+	return app
+}
+
+func toAppPlacements(ps []locationbus.Placement) []Placement {
+	items := make([]Placement, len(ps))
+	for i, p := range ps {
+		items[i] = toAppPlacement(p)
+	}
+
+	return items
+}
+
+// Rollup represents a location with the total quantity held in the bins at
+// or below it.
+type Rollup struct {
+	Location
+	Quantity int `json:"quantity"`
+}
+
+func toAppRollups(rs []locationbus.Rollup) []Rollup {
+	items := make([]Rollup, len(rs))
+	for i, r := range rs {
+		items[i] = Rollup{
+			Location: toAppLocation(r.Location),
+			Quantity: r.Quantity,
+		}
+	}
+
+	return items
+}
+
+// NewLocation defines the data needed to add a location to the inventory in
+// the path. ParentID is left out for rooms.
+type NewLocation struct {
+	ParentID string `json:"parentID" validate:"omitempty,uuid"`
+	Kind     string `json:"kind" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+	Name     string `json:"name" validate:"required"`
+}
+
+func toBusNewLocation(app NewLocation, inventoryID uuid.UUID) (locationbus.NewLocation, error) {
+	kind, err := locationbus.ParseKind(app.Kind)
+	if err != nil {
+		return locationbus.NewLocation{}, fmt.Errorf("parse: %w", err)
+	}
+
+	var parentID uuid.UUID
+	if app.ParentID != "" {
+		parentID, err = uuid.Parse(app.ParentID)
+		if err != nil {
+			return locationbus.NewLocation{}, fmt.Errorf("parse: %w", err)
+		}
+	}
+
+	nl := locationbus.NewLocation{
+		InventoryID: inventoryID,
+		ParentID:    parentID,
+		Kind:        kind,
+		Code:        app.Code,
+		Name:        app.Name,
+	}
+
+	return nl, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewLocation) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// UpdateLocation defines the data needed to update a location.
+type UpdateLocation struct {
+	Code *string `json:"code" validate:"omitempty,min=1"`
+	Name *string `json:"name" validate:"omitempty,min=1"`
+}
+
+func toBusUpdateLocation(app UpdateLocation) locationbus.UpdateLocation {
+	return locationbus.UpdateLocation{
+		Code: app.Code,
+		Name: app.Name,
+	}
+}
+
+// Validate checks the data in the model is considered clean.
+func (app UpdateLocation) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// Relocation defines the data needed to move stock of a lot between bins of
+// the inventory in the path. FromID is left out to put unplaced stock away
+// and ToID is left out to take stock out of a bin.
+type Relocation struct {
+	LotID    string `json:"lotID" validate:"required,uuid"`
+	FromID   string `json:"fromID" validate:"omitempty,uuid"`
+	ToID     string `json:"toID" validate:"omitempty,uuid"`
+	Quantity int    `json:"quantity" validate:"required,gt=0"`
+}
+
+func toBusRelocation(app Relocation, inventoryID uuid.UUID) (locationbus.Relocation, error) {
+	lotID, err := uuid.Parse(app.LotID)
+	if err != nil {
+		return locationbus.Relocation{}, fmt.Errorf("parse: %w", err)
+	}
+
+	var fromID, toID uuid.UUID
+
+	if app.FromID != "" {
+		fromID, err = uuid.Parse(app.FromID)
+		if err != nil {
+			return locationbus.Relocation{}, fmt.Errorf("parse: %w", err)
+		}
+	}
+
+	if app.ToID != "" {
+		toID, err = uuid.Parse(app.ToID)
+		if err != nil {
+			return locationbus.Relocation{}, fmt.Errorf("parse: %w", err)
+		}
+	}
+
+	r := locationbus.Relocation{
+		InventoryID: inventoryID,
+		LotID:       lotID,
+		FromID:      fromID,
+		ToID:        toID,
+		Quantity:    app.Quantity,
+	}
+
+	return r, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app Relocation) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
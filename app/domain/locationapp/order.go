@@ -0,0 +1,38 @@
+package locationapp
+
+import (
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+)
+
+func parseOrder(qp QueryParams) (order.By, error) {
+	const (
+		orderByID          = "location_id"
+		orderByPath        = "path"
+		orderByKind        = "kind"
+		orderByDateCreated = "date_created"
+	)
+
+	var orderByFields = map[string]string{
+		orderByID:          locationbus.OrderByID,
+		orderByPath:        locationbus.OrderByPath,
+		orderByKind:        locationbus.OrderByKind,
+		orderByDateCreated: locationbus.OrderByDateCreated,
+	}
+
+	orderBy, err := order.Parse(qp.OrderBy, order.NewBy(orderByPath, order.ASC))
+	if err != nil {
+		return order.By{}, err
+	}
+
+	if _, exists := orderByFields[orderBy.Field]; !exists {
+		return order.By{}, validate.NewFieldsError(orderBy.Field, errors.New("order field does not exist"))
+	}
+
+	orderBy.Field = orderByFields[orderBy.Field]
+
+	return orderBy, nil
+}
@@ -0,0 +1,60 @@
+package lotapp
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+func parseFilter(qp QueryParams) (lotbus.QueryFilter, error) {
+	var filter lotbus.QueryFilter
+
+	if qp.ID != "" {
+		id, err := uuid.Parse(qp.ID)
+		if err != nil {
+			return lotbus.QueryFilter{}, validate.NewFieldsError("lot_id", err)
+		}
+		filter.WithLotID(id)
+	}
+
+	if qp.MedicineID != "" {
+		id, err := uuid.Parse(qp.MedicineID)
+		if err != nil {
+			return lotbus.QueryFilter{}, validate.NewFieldsError("medicine_id", err)
+		}
+		filter.WithMedicineID(id)
+	}
+
+	if qp.Number != "" {
+		filter.WithNumber(qp.Number)
+	}
+
+	if qp.StartExpiryDate != "" {
+		t, err := time.Parse(time.RFC3339, qp.StartExpiryDate)
+		if err != nil {
+			return lotbus.QueryFilter{}, validate.NewFieldsError("start_expiry_date", err)
+		}
+		filter.WithStartExpiryDate(t)
+	}
+
+	if qp.EndExpiryDate != "" {
+		t, err := time.Parse(time.RFC3339, qp.EndExpiryDate)
+		if err != nil {
+			return lotbus.QueryFilter{}, validate.NewFieldsError("end_expiry_date", err)
+		}
+		filter.WithEndExpiryDate(t)
+	}
+
+	if qp.Quarantined != "" {
+		quarantined, err := strconv.ParseBool(qp.Quarantined)
+		if err != nil {
+			return lotbus.QueryFilter{}, validate.NewFieldsError("quarantined", err)
+		}
+		filter.WithQuarantined(quarantined)
+	}
+
+	return filter, nil
+}
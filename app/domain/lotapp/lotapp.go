@@ -0,0 +1,127 @@
+// Package lotapp maintains the app layer api for the lot domain.
+package lotapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+)
+
+// Core manages the set of app layer api functions for the lot domain.
+type Core struct {
+	lotBus *lotbus.Core
+}
+
+// NewCore constructs a lot core API for use.
+func NewCore(lotBus *lotbus.Core) *Core {
+	return &Core{
+		lotBus: lotBus,
+	}
+}
+
+// Create adds a new lot to the system.
+func (c *Core) Create(ctx context.Context, app NewLot) (Lot, error) {
+	nl, err := toBusNewLot(app)
+	if err != nil {
+		return Lot{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	lot, err := c.lotBus.Create(ctx, nl)
+	if err != nil {
+		switch {
+		case errors.Is(err, medicinebus.ErrNotFound):
+			return Lot{}, errs.New(errs.NotFound, err)
+		case errors.Is(err, lotbus.ErrUniqueNumber):
+			return Lot{}, errs.New(errs.Aborted, lotbus.ErrUniqueNumber)
+		case errors.Is(err, lotbus.ErrInvalidDates):
+			return Lot{}, errs.New(errs.FailedPrecondition, lotbus.ErrInvalidDates)
+		}
+		return Lot{}, errs.Newf(errs.Internal, "create: lot[%+v]: %s", nl, err)
+	}
+
+	return toAppLot(lot), nil
+}
+
+// Update updates an existing lot.
+func (c *Core) Update(ctx context.Context, app UpdateLot) (Lot, error) {
+	lot, err := mid.GetLot(ctx)
+	if err != nil {
+		return Lot{}, errs.Newf(errs.Internal, "lot missing in context: %s", err)
+	}
+
+	ul, err := toBusUpdateLot(app)
+	if err != nil {
+		return Lot{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	updLot, err := c.lotBus.Update(ctx, lot, ul)
+	if err != nil {
+		switch {
+		case errors.Is(err, lotbus.ErrUniqueNumber):
+			return Lot{}, errs.New(errs.Aborted, lotbus.ErrUniqueNumber)
+		case errors.Is(err, lotbus.ErrInvalidDates):
+			return Lot{}, errs.New(errs.FailedPrecondition, lotbus.ErrInvalidDates)
+		}
+		return Lot{}, errs.Newf(errs.Internal, "update: lotID[%s] ul[%+v]: %s", lot.ID, app, err)
+	}
+
+	return toAppLot(updLot), nil
+}
+
+// Delete removes a lot from the system.
+func (c *Core) Delete(ctx context.Context) error {
+	lot, err := mid.GetLot(ctx)
+	if err != nil {
+		return errs.Newf(errs.Internal, "lotID missing in context: %s", err)
+	}
+
+	if err := c.lotBus.Delete(ctx, lot); err != nil {
+		return errs.Newf(errs.Internal, "delete: lotID[%s]: %s", lot.ID, err)
+	}
+
+	return nil
+}
+
+// Query returns a list of lots with paging.
+func (c *Core) Query(ctx context.Context, qp QueryParams) (page.Document[Lot], error) {
+	if err := validatePaging(qp); err != nil {
+		return page.Document[Lot]{}, err
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return page.Document[Lot]{}, err
+	}
+
+	orderBy, err := parseOrder(qp)
+	if err != nil {
+		return page.Document[Lot]{}, err
+	}
+
+	lots, err := c.lotBus.Query(ctx, filter, orderBy, qp.Page, qp.Rows)
+	if err != nil {
+		return page.Document[Lot]{}, errs.Newf(errs.Internal, "query: %s", err)
+	}
+
+	total, err := c.lotBus.Count(ctx, filter)
+	if err != nil {
+		return page.Document[Lot]{}, errs.Newf(errs.Internal, "count: %s", err)
+	}
+
+	return page.NewDocument(toAppLots(lots), total, qp.Page, qp.Rows), nil
+}
+
+// QueryByID returns a lot by its ID.
+func (c *Core) QueryByID(ctx context.Context) (Lot, error) {
+	lot, err := mid.GetLot(ctx)
+	if err != nil {
+		return Lot{}, errs.Newf(errs.Internal, "querybyid: %s", err)
+	}
+
+	return toAppLot(lot), nil
+}
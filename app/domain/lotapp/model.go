@@ -0,0 +1,153 @@
+package lotapp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Page            int    `query:"page"`
+	Rows            int    `query:"rows"`
+	OrderBy         string `query:"orderBy"`
+	ID              string `query:"lot_id"`
+	MedicineID      string `query:"medicine_id"`
+	Number          string `query:"lot_number"`
+	StartExpiryDate string `query:"start_expiry_date"`
+	EndExpiryDate   string `query:"end_expiry_date"`
+	Quarantined     string `query:"quarantined"`
+}
+
+// Lot represents information about an individual lot.
+type Lot struct {
+	ID              string `json:"id"`
+	MedicineID      string `json:"medicineID"`
+	Number          string `json:"number"`
+	ExpiryDate      string `json:"expiryDate"`
+	ManufactureDate string `json:"manufactureDate,omitempty"`
+	Quarantined     bool   `json:"quarantined"`
+	DateCreated     string `json:"dateCreated"`
+	DateUpdated     string `json:"dateUpdated"`
+}
+
+func toAppLot(lot lotbus.Lot) Lot {
+	var manufactureDate string
+	if !lot.ManufactureDate.IsZero() {
+		manufactureDate = lot.ManufactureDate.Format(time.RFC3339)
+	}
+
+	return Lot{
+		ID:              lot.ID.String(),
+		MedicineID:      lot.MedicineID.String(),
+		Number:          lot.Number,
+		ExpiryDate:      lot.ExpiryDate.Format(time.RFC3339),
+		ManufactureDate: manufactureDate,
+		Quarantined:     lot.Quarantined,
+		DateCreated:     lot.DateCreated.Format(time.RFC3339),
+		DateUpdated:     lot.DateUpdated.Format(time.RFC3339),
+	}
+}
+
+func toAppLots(lots []lotbus.Lot) []Lot {
+	items := make([]Lot, len(lots))
+	for i, lot := range lots {
+		items[i] = toAppLot(lot)
+	}
+
+	return items
+}
+
+// NewLot defines the data needed to add a new lot.
+type NewLot struct {
+	MedicineID      string `json:"medicineID" validate:"required"`
+	Number          string `json:"number" validate:"required"`
+	ExpiryDate      string `json:"expiryDate" validate:"required"`
+	ManufactureDate string `json:"manufactureDate"`
+}
+
+func toBusNewLot(app NewLot) (lotbus.NewLot, error) {
+	medicineID, err := uuid.Parse(app.MedicineID)
+	if err != nil {
+		return lotbus.NewLot{}, fmt.Errorf("parse: %w", err)
+	}
+
+	expiryDate, err := time.Parse(time.RFC3339, app.ExpiryDate)
+	if err != nil {
+		return lotbus.NewLot{}, fmt.Errorf("parse: %w", err)
+	}
+
+	var manufactureDate time.Time
+	if app.ManufactureDate != "" {
+		manufactureDate, err = time.Parse(time.RFC3339, app.ManufactureDate)
+		if err != nil {
+			return lotbus.NewLot{}, fmt.Errorf("parse: %w", err)
+		}
+	}
+
+	nl := lotbus.NewLot{
+		MedicineID:      medicineID,
+		Number:          app.Number,
+		ExpiryDate:      expiryDate,
+		ManufactureDate: manufactureDate,
+	}
+
+	return nl, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewLot) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// UpdateLot defines the data needed to update a lot.
+type UpdateLot struct {
+	Number          *string `json:"number"`
+	ExpiryDate      *string `json:"expiryDate"`
+	ManufactureDate *string `json:"manufactureDate"`
+}
+
+func toBusUpdateLot(app UpdateLot) (lotbus.UpdateLot, error) {
+	var expiryDate *time.Time
+	if app.ExpiryDate != nil {
+		t, err := time.Parse(time.RFC3339, *app.ExpiryDate)
+		if err != nil {
+			return lotbus.UpdateLot{}, fmt.Errorf("parse: %w", err)
+		}
+		expiryDate = &t
+	}
+
+	var manufactureDate *time.Time
+	if app.ManufactureDate != nil {
+		t, err := time.Parse(time.RFC3339, *app.ManufactureDate)
+		if err != nil {
+			return lotbus.UpdateLot{}, fmt.Errorf("parse: %w", err)
+		}
+		manufactureDate = &t
+	}
+
+	ul := lotbus.UpdateLot{
+		Number:          app.Number,
+		ExpiryDate:      expiryDate,
+		ManufactureDate: manufactureDate,
+	}
+
+	return ul, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app UpdateLot) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package lotapp
+
+import (
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+)
+
+func parseOrder(qp QueryParams) (order.By, error) {
+	const (
+		orderByID              = "lot_id"
+		orderByMedicineID      = "medicine_id"
+		orderByNumber          = "lot_number"
+		orderByExpiryDate      = "expiry_date"
+		orderByManufactureDate = "manufacture_date"
+	)
+
+	var orderByFields = map[string]string{
+		orderByID:              lotbus.OrderByID,
+		orderByMedicineID:      lotbus.OrderByMedicineID,
+		orderByNumber:          lotbus.OrderByNumber,
+		orderByExpiryDate:      lotbus.OrderByExpiryDate,
+		orderByManufactureDate: lotbus.OrderByManufactureDate,
+	}
+
+	orderBy, err := order.Parse(qp.OrderBy, order.NewBy(orderByExpiryDate, order.ASC))
+	if err != nil {
+		return order.By{}, err
+	}
+
+	if _, exists := orderByFields[orderBy.Field]; !exists {
+		return order.By{}, validate.NewFieldsError(orderBy.Field, errors.New("order field does not exist"))
+	}
+
+	orderBy.Field = orderByFields[orderBy.Field]
+
+	return orderBy, nil
+}
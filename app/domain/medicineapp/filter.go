@@ -3,7 +3,8 @@ package medicineapp
 import (
 	"time"
 
-	"github.com/EnesDemirtas/medisync/business/core/crud/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/api/gs1"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
 	"github.com/EnesDemirtas/medisync/foundation/validate"
 	"github.com/google/uuid"
 )
@@ -35,6 +36,22 @@ func parseFilter(qp QueryParams) (medicinebus.QueryFilter, error) {
 		filter.WithType(qp.Type)
 	}
 
+	if qp.GTIN != "" {
+		gtin, err := gs1.NormalizeGTIN(qp.GTIN)
+		if err != nil {
+			return medicinebus.QueryFilter{}, validate.NewFieldsError("gtin", err)
+		}
+		filter.WithGTIN(gtin)
+	}
+
+	if qp.Schedule != "" {
+		schedule, err := medicinebus.ParseSchedule(qp.Schedule)
+		if err != nil {
+			return medicinebus.QueryFilter{}, validate.NewFieldsError("schedule", err)
+		}
+		filter.WithSchedule(schedule)
+	}
+
 	if qp.Tags != nil {
 		tags := make([]uuid.UUID, len(qp.Tags))
 		for i, tagStr := range qp.Tags {
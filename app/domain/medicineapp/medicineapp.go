@@ -3,11 +3,12 @@ package medicineapp
 
 import (
 	"context"
+	"errors"
 
 	"github.com/EnesDemirtas/medisync/app/api/errs"
 	"github.com/EnesDemirtas/medisync/app/api/mid"
 	"github.com/EnesDemirtas/medisync/app/api/page"
-	"github.com/EnesDemirtas/medisync/business/core/crud/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
 )
 
 // Core manages the set of app layer api functions for the medicine domain.
@@ -31,6 +32,12 @@ func (c *Core) Create(ctx context.Context, app NewMedicine) (Medicine, error) {
 
 	med, err := c.medicineBus.Create(ctx, nm)
 	if err != nil {
+		switch {
+		case errors.Is(err, medicinebus.ErrInvalidGTIN):
+			return Medicine{}, errs.New(errs.FailedPrecondition, err)
+		case errors.Is(err, medicinebus.ErrUniqueGTIN):
+			return Medicine{}, errs.New(errs.Aborted, medicinebus.ErrUniqueGTIN)
+		}
 		return Medicine{}, errs.Newf(errs.Internal, "create: med[%+v]: %s", med, err)
 	}
 
@@ -46,11 +53,23 @@ func (c *Core) Update(ctx context.Context, app UpdateMedicine) (Medicine, error)
 	
 	busUpdMed, err := toBusUpdateMedicine(app)
 	if err != nil {
+		return Medicine{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	if err := mid.CheckIfMatch(ctx, med.Version); err != nil {
 		return Medicine{}, err
 	}
 
 	um, err := c.medicineBus.Update(ctx, med, busUpdMed)
 	if err != nil {
+		switch {
+		case errors.Is(err, medicinebus.ErrVersionConflict):
+			return Medicine{}, errs.New(errs.PreconditionFailed, err)
+		case errors.Is(err, medicinebus.ErrInvalidGTIN):
+			return Medicine{}, errs.New(errs.FailedPrecondition, err)
+		case errors.Is(err, medicinebus.ErrUniqueGTIN):
+			return Medicine{}, errs.New(errs.Aborted, medicinebus.ErrUniqueGTIN)
+		}
 		return Medicine{}, errs.Newf(errs.Internal, "update: medicineID[%s] um[%+v]: %s", med.ID, app, err)
 	}
 
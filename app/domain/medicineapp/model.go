@@ -5,7 +5,8 @@ import (
 	"time"
 
 	"github.com/EnesDemirtas/medisync/app/api/errs"
-	"github.com/EnesDemirtas/medisync/business/core/crud/medicinebus"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
 	"github.com/EnesDemirtas/medisync/foundation/validate"
 	"github.com/google/uuid"
 )
@@ -22,11 +23,42 @@ type QueryParams struct {
 	Description      string `query:"desctiption"`
 	Manufacturer     string `query:"manufacturer"`
 	Type   			 string `query:"type"`
+	GTIN			 string `query:"gtin"`
+	Schedule		 string `query:"schedule"`
 	Tags			 []string `query:"tags"`
 	StartExpiryDate  string `query:"start_expiry_date"`
 	EndExpiryDate    string `query:"end_expiry_date"`
 }
 
+// Storage represents the conditions a medicine has to be stored under.
+type Storage struct {
+	Temperature		 string `json:"temperature"`
+	ProtectFromLight bool	`json:"protectFromLight"`
+}
+
+func toAppStorage(storage medicinebus.Storage) Storage {
+	return Storage{
+		Temperature:	  storage.Temperature.Name(),
+		ProtectFromLight: storage.ProtectFromLight,
+	}
+}
+
+func toBusStorage(app Storage) (medicinebus.Storage, error) {
+	storage := medicinebus.Storage{
+		ProtectFromLight: app.ProtectFromLight,
+	}
+
+	if app.Temperature != "" {
+		temperature, err := medicinebus.ParseTemperature(app.Temperature)
+		if err != nil {
+			return medicinebus.Storage{}, err
+		}
+		storage.Temperature = temperature
+	}
+
+	return storage, nil
+}
+
 // Medicine represents information about an individual medicine.
 type Medicine struct {
 	ID			 string   `json:"id"`
@@ -34,8 +66,11 @@ type Medicine struct {
 	Description  string   `json:"description"`
 	Manufacturer string   `json:"manufacturer"`
 	Type   		 string   `json:"type"`
+	GTIN		 string   `json:"gtin"`
+	Schedule	 string   `json:"schedule"`
+	Storage		 Storage  `json:"storage"`
 	Tags		 []string `json:"tags"`
-	ExpiryDate   string   `json:"expiryDate"`
+	Version		 int      `json:"version"`
 	DateCreated  string   `json:"dateCreated"`
 	DateUpdated  string   `json:"dateUpdated"`
 }
@@ -52,13 +87,21 @@ func toAppMedicine(med medicinebus.Medicine) Medicine {
 		Description:  med.Description,
 		Manufacturer: med.Manufacturer,
 		Type:		  med.Type,
+		GTIN:		  med.GTIN,
+		Schedule:	  med.Schedule.Name(),
+		Storage:	  toAppStorage(med.Storage),
 		Tags:		  tags,
-		ExpiryDate:   med.ExpiryDate.Format(time.RFC3339),
+		Version:	  med.Version,
 		DateCreated:  med.DateCreated.Format(time.RFC3339),
 		DateUpdated:  med.DateUpdated.Format(time.RFC3339),
 	}
 }
 
+// ETag implements the web.ETagger interface.
+func (app Medicine) ETag() string {
+	return mid.ETag(app.Version)
+}
+
 func toAppMedicines(meds []medicinebus.Medicine) []Medicine {
 	items := make([]Medicine, len(meds))
 	for i, med := range meds {
@@ -74,8 +117,10 @@ type NewMedicine struct {
 	Description  string   `json:"description"`
 	Manufacturer string   `json:"manufacturer"`
 	Type         string   `json:"type"`
+	GTIN         string   `json:"gtin" validate:"omitempty,numeric"`
+	Schedule     string   `json:"schedule"`
+	Storage      Storage  `json:"storage"`
 	Tags         []string `json:"tags"`
-	ExpiryDate   string   `json:"expiryDate"`
 }
 
 func toBusNewMedicine(app NewMedicine) (medicinebus.NewMedicine, error) {
@@ -91,25 +136,29 @@ func toBusNewMedicine(app NewMedicine) (medicinebus.NewMedicine, error) {
 		}
 	}
 
-	var expiryDate time.Time
-	if app.ExpiryDate != "" {
-		var err error
-		expiryDate, err = time.Parse(time.RFC3339, app.ExpiryDate)
-		if err != nil {
-			return medicinebus.NewMedicine{}, fmt.Errorf("parse: %w", err)
-		}
-	}
-
-
 	med := medicinebus.NewMedicine{
 		Name:		  app.Name,
 		Description:  app.Description,
 		Manufacturer: app.Manufacturer,
 		Type:		  app.Type,
+		GTIN:		  app.GTIN,
 		Tags:         tags,
-		ExpiryDate:   expiryDate,
 	}
 
+	if app.Schedule != "" {
+		schedule, err := medicinebus.ParseSchedule(app.Schedule)
+		if err != nil {
+			return medicinebus.NewMedicine{}, fmt.Errorf("parse: %w", err)
+		}
+		med.Schedule = schedule
+	}
+
+	storage, err := toBusStorage(app.Storage)
+	if err != nil {
+		return medicinebus.NewMedicine{}, fmt.Errorf("parse: %w", err)
+	}
+	med.Storage = storage
+
 	return med, nil
 }
 
@@ -128,8 +177,10 @@ type UpdateMedicine struct {
 	Description  *string  `json:"description"`
 	Manufacturer *string  `json:"manufacturer"`
 	Type		 *string  `json:"type"`
+	GTIN		 *string  `json:"gtin" validate:"omitempty,numeric"`
+	Schedule	 *string  `json:"schedule"`
+	Storage		 *Storage `json:"storage"`
 	Tags 		 []string `json:"tags"`
-	ExpiryDate   *string  `json:"expiryDate"`
 }
 
 func toBusUpdateMedicine (app UpdateMedicine) (medicinebus.UpdateMedicine, error) {
@@ -145,22 +196,29 @@ func toBusUpdateMedicine (app UpdateMedicine) (medicinebus.UpdateMedicine, error
 		}
 	}
 
-	var expiryDate time.Time
-	if app.ExpiryDate != nil {
-		var err error
-		expiryDate, err = time.Parse(time.RFC3339, *app.ExpiryDate)
-		if err != nil {
-			return medicinebus.UpdateMedicine{}, fmt.Errorf("parse: %w", err)
-		}
-	}
-
 	um := medicinebus.UpdateMedicine{
 		Name:		  app.Name,
 		Description:  app.Description,
 		Manufacturer: app.Manufacturer,
 		Type:		  app.Type,
+		GTIN:		  app.GTIN,
 		Tags:		  tags,
-		ExpiryDate:   &expiryDate,
+	}
+
+	if app.Schedule != nil {
+		schedule, err := medicinebus.ParseSchedule(*app.Schedule)
+		if err != nil {
+			return medicinebus.UpdateMedicine{}, fmt.Errorf("parse: %w", err)
+		}
+		um.Schedule = &schedule
+	}
+
+	if app.Storage != nil {
+		storage, err := toBusStorage(*app.Storage)
+		if err != nil {
+			return medicinebus.UpdateMedicine{}, fmt.Errorf("parse: %w", err)
+		}
+		um.Storage = &storage
 	}
 
 	return um, nil
@@ -4,7 +4,7 @@ import (
 	"errors"
 
 	"github.com/EnesDemirtas/medisync/business/api/order"
-	"github.com/EnesDemirtas/medisync/business/core/crud/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
 	"github.com/EnesDemirtas/medisync/foundation/validate"
 )
 
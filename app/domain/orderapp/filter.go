@@ -0,0 +1,45 @@
+package orderapp
+
+import (
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+func parseFilter(qp QueryParams) (orderbus.QueryFilter, error) {
+	var filter orderbus.QueryFilter
+
+	if qp.ID != "" {
+		id, err := uuid.Parse(qp.ID)
+		if err != nil {
+			return orderbus.QueryFilter{}, validate.NewFieldsError("order_id", err)
+		}
+		filter.WithOrderID(id)
+	}
+
+	if qp.InventoryID != "" {
+		id, err := uuid.Parse(qp.InventoryID)
+		if err != nil {
+			return orderbus.QueryFilter{}, validate.NewFieldsError("inventory_id", err)
+		}
+		filter.WithInventoryID(id)
+	}
+
+	if qp.Status != "" {
+		status, err := orderbus.ParseStatus(qp.Status)
+		if err != nil {
+			return orderbus.QueryFilter{}, validate.NewFieldsError("status", err)
+		}
+		filter.WithStatus(status)
+	}
+
+	if qp.DestinationType != "" {
+		dt, err := orderbus.ParseDestinationType(qp.DestinationType)
+		if err != nil {
+			return orderbus.QueryFilter{}, validate.NewFieldsError("destination_type", err)
+		}
+		filter.WithDestinationType(dt)
+	}
+
+	return filter, nil
+}
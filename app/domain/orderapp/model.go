@@ -0,0 +1,279 @@
+package orderapp
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Page            int    `query:"page"`
+	Rows            int    `query:"rows"`
+	OrderBy         string `query:"orderBy"`
+	ID              string `query:"order_id"`
+	InventoryID     string `query:"inventory_id"`
+	Status          string `query:"status"`
+	DestinationType string `query:"destination_type"`
+}
+
+// Pick represents the quantity of a lot picked for a line.
+type Pick struct {
+	LotID    string `json:"lotID"`
+	Quantity int    `json:"quantity"`
+}
+
+// Line represents a single medicine on an order.
+type Line struct {
+	MedicineID string `json:"medicineID"`
+	Quantity   int    `json:"quantity"`
+	Picked     int    `json:"picked"`
+	Picks      []Pick `json:"picks"`
+}
+
+// Order represents information about an individual order.
+type Order struct {
+	ID              string `json:"id"`
+	InventoryID     string `json:"inventoryID"`
+	DestinationType string `json:"destinationType"`
+	Destination     string `json:"destination"`
+	Status          string `json:"status"`
+	Note            string `json:"note"`
+	Lines           []Line `json:"lines"`
+	CreatedBy       string `json:"createdBy"`
+	ApprovedBy      string `json:"approvedBy,omitempty"`
+	DateApproved    string `json:"dateApproved,omitempty"`
+	PickedBy        string `json:"pickedBy,omitempty"`
+	DatePicked      string `json:"datePicked,omitempty"`
+	ShippedBy       string `json:"shippedBy,omitempty"`
+	DateShipped     string `json:"dateShipped,omitempty"`
+	DateCreated     string `json:"dateCreated"`
+	DateUpdated     string `json:"dateUpdated"`
+}
+
+func toAppOrder(ord orderbus.Order) Order {
+	lines := make([]Line, len(ord.Lines))
+	for i, line := range ord.Lines {
+		picks := make([]Pick, len(line.Picks))
+		for j, pick := range line.Picks {
+			picks[j] = Pick{
+				LotID:    pick.LotID.String(),
+				Quantity: pick.Quantity,
+			}
+		}
+
+		lines[i] = Line{
+			MedicineID: line.MedicineID.String(),
+			Quantity:   line.Quantity,
+			Picked:     line.Picked(),
+			Picks:      picks,
+		}
+	}
+
+	app := Order{
+		ID:              ord.ID.String(),
+		InventoryID:     ord.InventoryID.String(),
+		DestinationType: ord.Destination.Type.Name(),
+		Destination:     ord.Destination.Name,
+		Status:          ord.Status.Name(),
+		Note:            ord.Note,
+		Lines:           lines,
+		CreatedBy:       ord.CreatedBy.String(),
+		DateCreated:     ord.DateCreated.Format(time.RFC3339),
+		DateUpdated:     ord.DateUpdated.Format(time.RFC3339),
+	}
+
+	if ord.ApprovedBy != uuid.Nil {
+		app.ApprovedBy = ord.ApprovedBy.String()
+		app.DateApproved = ord.DateApproved.Format(time.RFC3339)
+	}
+
+	if ord.PickedBy != uuid.Nil {
+		app.PickedBy = ord.PickedBy.String()
+		app.DatePicked = ord.DatePicked.Format(time.RFC3339)
+	}
+
+	if ord.ShippedBy != uuid.Nil {
+		app.ShippedBy = ord.ShippedBy.String()
+		app.DateShipped = ord.DateShipped.Format(time.RFC3339)
+	}
+
+	return app
+}
+
+func toAppOrders(ords []orderbus.Order) []Order {
+	items := make([]Order, len(ords))
+	for i, ord := range ords {
+		items[i] = toAppOrder(ord)
+	}
+
+	return items
+}
+
+// NewLine defines the data needed to add a medicine to an order.
+type NewLine struct {
+	MedicineID string `json:"medicineID" validate:"required"`
+	Quantity   int    `json:"quantity" validate:"required"`
+}
+
+func toBusNewLines(app []NewLine) ([]orderbus.NewLine, error) {
+	if app == nil {
+		return nil, nil
+	}
+
+	lines := make([]orderbus.NewLine, len(app))
+	for i, line := range app {
+		medicineID, err := uuid.Parse(line.MedicineID)
+		if err != nil {
+			return nil, fmt.Errorf("parse: %w", err)
+		}
+
+		lines[i] = orderbus.NewLine{
+			MedicineID: medicineID,
+			Quantity:   line.Quantity,
+		}
+	}
+
+	return lines, nil
+}
+
+// NewOrder defines the data needed to draft a new order out of the inventory
+// in the path.
+type NewOrder struct {
+	DestinationType string    `json:"destinationType" validate:"required"`
+	Destination     string    `json:"destination" validate:"required"`
+	Note            string    `json:"note"`
+	Lines           []NewLine `json:"lines" validate:"required,min=1,dive"`
+}
+
+func toBusNewOrder(app NewOrder, inventoryID uuid.UUID, userID uuid.UUID) (orderbus.NewOrder, error) {
+	dt, err := orderbus.ParseDestinationType(app.DestinationType)
+	if err != nil {
+		return orderbus.NewOrder{}, fmt.Errorf("parse: %w", err)
+	}
+
+	lines, err := toBusNewLines(app.Lines)
+	if err != nil {
+		return orderbus.NewOrder{}, err
+	}
+
+	no := orderbus.NewOrder{
+		InventoryID: inventoryID,
+		Destination: orderbus.Destination{
+			Type: dt,
+			Name: app.Destination,
+		},
+		Note:   app.Note,
+		Lines:  lines,
+		UserID: userID,
+	}
+
+	return no, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewOrder) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// UpdateOrder defines the data needed to update a draft order. The
+// destination type and name are updated together.
+type UpdateOrder struct {
+	DestinationType *string   `json:"destinationType"`
+	Destination     *string   `json:"destination"`
+	Note            *string   `json:"note"`
+	Lines           []NewLine `json:"lines" validate:"omitempty,min=1,dive"`
+}
+
+func toBusUpdateOrder(app UpdateOrder) (orderbus.UpdateOrder, error) {
+	var dest *orderbus.Destination
+	if app.DestinationType != nil || app.Destination != nil {
+		if app.DestinationType == nil || app.Destination == nil {
+			return orderbus.UpdateOrder{}, errors.New("destination type and destination must be updated together")
+		}
+
+		dt, err := orderbus.ParseDestinationType(*app.DestinationType)
+		if err != nil {
+			return orderbus.UpdateOrder{}, fmt.Errorf("parse: %w", err)
+		}
+
+		dest = &orderbus.Destination{
+			Type: dt,
+			Name: *app.Destination,
+		}
+	}
+
+	lines, err := toBusNewLines(app.Lines)
+	if err != nil {
+		return orderbus.UpdateOrder{}, err
+	}
+
+	uo := orderbus.UpdateOrder{
+		Destination: dest,
+		Note:        app.Note,
+		Lines:       lines,
+	}
+
+	return uo, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app UpdateOrder) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// PickLine defines the quantity picked from a lot.
+type PickLine struct {
+	LotID    string `json:"lotID" validate:"required"`
+	Quantity int    `json:"quantity" validate:"required"`
+}
+
+// Picking defines the lots picked for an order. Leaving the lines out picks
+// the lots that expire first.
+type Picking struct {
+	Lines []PickLine `json:"lines" validate:"dive"`
+}
+
+func toBusPicking(app Picking, userID uuid.UUID) (orderbus.Picking, error) {
+	lines := make([]orderbus.PickLine, len(app.Lines))
+	for i, line := range app.Lines {
+		lotID, err := uuid.Parse(line.LotID)
+		if err != nil {
+			return orderbus.Picking{}, fmt.Errorf("parse: %w", err)
+		}
+
+		lines[i] = orderbus.PickLine{
+			LotID:    lotID,
+			Quantity: line.Quantity,
+		}
+	}
+
+	pk := orderbus.Picking{
+		Lines:  lines,
+		UserID: userID,
+	}
+
+	return pk, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app Picking) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
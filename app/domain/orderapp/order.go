@@ -0,0 +1,38 @@
+package orderapp
+
+import (
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+)
+
+func parseOrder(qp QueryParams) (order.By, error) {
+	const (
+		orderByID          = "order_id"
+		orderByStatus      = "status"
+		orderByDateCreated = "date_created"
+		orderByDateShipped = "date_shipped"
+	)
+
+	var orderByFields = map[string]string{
+		orderByID:          orderbus.OrderByID,
+		orderByStatus:      orderbus.OrderByStatus,
+		orderByDateCreated: orderbus.OrderByDateCreated,
+		orderByDateShipped: orderbus.OrderByDateShipped,
+	}
+
+	orderBy, err := order.Parse(qp.OrderBy, order.NewBy(orderByDateCreated, order.ASC))
+	if err != nil {
+		return order.By{}, err
+	}
+
+	if _, exists := orderByFields[orderBy.Field]; !exists {
+		return order.By{}, validate.NewFieldsError(orderBy.Field, errors.New("order field does not exist"))
+	}
+
+	orderBy.Field = orderByFields[orderBy.Field]
+
+	return orderBy, nil
+}
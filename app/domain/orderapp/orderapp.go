@@ -0,0 +1,265 @@
+// Package orderapp maintains the app layer api for the order domain.
+package orderapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+)
+
+// Core manages the set of app layer api functions for the order domain.
+type Core struct {
+	orderBus *orderbus.Core
+}
+
+// NewCore constructs an order core API for use.
+func NewCore(orderBus *orderbus.Core) *Core {
+	return &Core{
+		orderBus: orderBus,
+	}
+}
+
+// Create drafts a new order out of the inventory in context.
+func (c *Core) Create(ctx context.Context, app NewOrder) (Order, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return Order{}, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Order{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	no, err := toBusNewOrder(app, inv.ID, userID)
+	if err != nil {
+		return Order{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	orderBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Order{}, errs.New(errs.Internal, err)
+	}
+
+	ord, err := orderBus.Create(ctx, no)
+	if err != nil {
+		return Order{}, toAppError(err, "create: inventoryID[%s] no[%+v]: %s", inv.ID, app, err)
+	}
+
+	return toAppOrder(ord), nil
+}
+
+// Update modifies the draft order in context.
+func (c *Core) Update(ctx context.Context, app UpdateOrder) (Order, error) {
+	ord, err := mid.GetOrder(ctx)
+	if err != nil {
+		return Order{}, errs.Newf(errs.Internal, "order missing in context: %s", err)
+	}
+
+	uo, err := toBusUpdateOrder(app)
+	if err != nil {
+		return Order{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	orderBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Order{}, errs.New(errs.Internal, err)
+	}
+
+	updOrd, err := orderBus.Update(ctx, ord.ID, uo)
+	if err != nil {
+		return Order{}, toAppError(err, "update: orderID[%s] uo[%+v]: %s", ord.ID, app, err)
+	}
+
+	return toAppOrder(updOrd), nil
+}
+
+// Approve releases the order in context for picking.
+func (c *Core) Approve(ctx context.Context) (Order, error) {
+	ord, err := mid.GetOrder(ctx)
+	if err != nil {
+		return Order{}, errs.Newf(errs.Internal, "order missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Order{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	orderBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Order{}, errs.New(errs.Internal, err)
+	}
+
+	updOrd, err := orderBus.Approve(ctx, ord.ID, userID)
+	if err != nil {
+		return Order{}, toAppError(err, "approve: orderID[%s]: %s", ord.ID, err)
+	}
+
+	return toAppOrder(updOrd), nil
+}
+
+// Pick records the lots the order in context is drawn from.
+func (c *Core) Pick(ctx context.Context, app Picking) (Order, error) {
+	ord, err := mid.GetOrder(ctx)
+	if err != nil {
+		return Order{}, errs.Newf(errs.Internal, "order missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Order{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	pk, err := toBusPicking(app, userID)
+	if err != nil {
+		return Order{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	orderBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Order{}, errs.New(errs.Internal, err)
+	}
+
+	updOrd, err := orderBus.Pick(ctx, ord.ID, pk)
+	if err != nil {
+		return Order{}, toAppError(err, "pick: orderID[%s] pk[%+v]: %s", ord.ID, app, err)
+	}
+
+	return toAppOrder(updOrd), nil
+}
+
+// Ship takes the picked stock of the order in context out of its inventory.
+func (c *Core) Ship(ctx context.Context) (Order, error) {
+	ord, err := mid.GetOrder(ctx)
+	if err != nil {
+		return Order{}, errs.Newf(errs.Internal, "order missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Order{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	orderBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Order{}, errs.New(errs.Internal, err)
+	}
+
+	updOrd, err := orderBus.Ship(ctx, ord.ID, userID, mid.GetCountersignerID(ctx))
+	if err != nil {
+		return Order{}, toAppError(err, "ship: orderID[%s]: %s", ord.ID, err)
+	}
+
+	return toAppOrder(updOrd), nil
+}
+
+// Cancel stops the order in context.
+func (c *Core) Cancel(ctx context.Context) (Order, error) {
+	ord, err := mid.GetOrder(ctx)
+	if err != nil {
+		return Order{}, errs.Newf(errs.Internal, "order missing in context: %s", err)
+	}
+
+	orderBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Order{}, errs.New(errs.Internal, err)
+	}
+
+	updOrd, err := orderBus.Cancel(ctx, ord.ID)
+	if err != nil {
+		return Order{}, toAppError(err, "cancel: orderID[%s]: %s", ord.ID, err)
+	}
+
+	return toAppOrder(updOrd), nil
+}
+
+// Query returns a list of orders with paging.
+func (c *Core) Query(ctx context.Context, qp QueryParams) (page.Document[Order], error) {
+	if err := validatePaging(qp); err != nil {
+		return page.Document[Order]{}, err
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return page.Document[Order]{}, err
+	}
+
+	orderBy, err := parseOrder(qp)
+	if err != nil {
+		return page.Document[Order]{}, err
+	}
+
+	ords, err := c.orderBus.Query(ctx, filter, orderBy, qp.Page, qp.Rows)
+	if err != nil {
+		return page.Document[Order]{}, errs.Newf(errs.Internal, "query: %s", err)
+	}
+
+	total, err := c.orderBus.Count(ctx, filter)
+	if err != nil {
+		return page.Document[Order]{}, errs.Newf(errs.Internal, "count: %s", err)
+	}
+
+	return page.NewDocument(toAppOrders(ords), total, qp.Page, qp.Rows), nil
+}
+
+// QueryByID returns an order by its ID.
+func (c *Core) QueryByID(ctx context.Context) (Order, error) {
+	ord, err := mid.GetOrder(ctx)
+	if err != nil {
+		return Order{}, errs.Newf(errs.Internal, "querybyid: %s", err)
+	}
+
+	return toAppOrder(ord), nil
+}
+
+// executeUnderTransaction returns an order core bound to the transaction the
+// transaction middleware placed in the context.
+func (c *Core) executeUnderTransaction(ctx context.Context) (*orderbus.Core, error) {
+	tx, ok := transaction.Get(ctx)
+	if !ok {
+		return nil, errors.New("transaction missing in context")
+	}
+
+	return c.orderBus.ExecuteUnderTransaction(tx)
+}
+
+// toAppError maps the business errors an order can fail with to the matching
+// app error.
+func toAppError(err error, format string, v ...any) error {
+	switch {
+	case errors.Is(err, orderbus.ErrNoLines),
+		errors.Is(err, orderbus.ErrDuplicateLine),
+		errors.Is(err, orderbus.ErrInvalidQuantity),
+		errors.Is(err, orderbus.ErrDestinationMissing),
+		errors.Is(err, orderbus.ErrInvalidTransition),
+		errors.Is(err, orderbus.ErrUnknownMedicine),
+		errors.Is(err, orderbus.ErrDuplicatePick),
+		errors.Is(err, orderbus.ErrPickMismatch),
+		errors.Is(err, orderbus.ErrShortPick),
+		errors.Is(err, inventorybus.ErrInsufficientStock),
+		errors.Is(err, stockbus.ErrQuarantined),
+		errors.Is(err, stockbus.ErrUnavailable),
+		errors.Is(err, stockbus.ErrReserved),
+		errors.Is(err, stockbus.ErrCountersignRequired),
+		errors.Is(err, stockbus.ErrSelfCountersign),
+		errors.Is(err, stockbus.ErrInvalidQuantity):
+		return errs.New(errs.FailedPrecondition, err)
+
+	case errors.Is(err, orderbus.ErrNotFound),
+		errors.Is(err, medicinebus.ErrNotFound),
+		errors.Is(err, lotbus.ErrNotFound):
+		return errs.New(errs.NotFound, err)
+	}
+
+	return errs.Newf(errs.Internal, format, v...)
+}
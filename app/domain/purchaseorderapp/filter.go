@@ -0,0 +1,45 @@
+package purchaseorderapp
+
+import (
+	"github.com/EnesDemirtas/medisync/business/domain/purchaseorderbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+func parseFilter(qp QueryParams) (purchaseorderbus.QueryFilter, error) {
+	var filter purchaseorderbus.QueryFilter
+
+	if qp.ID != "" {
+		id, err := uuid.Parse(qp.ID)
+		if err != nil {
+			return purchaseorderbus.QueryFilter{}, validate.NewFieldsError("purchase_order_id", err)
+		}
+		filter.WithPurchaseOrderID(id)
+	}
+
+	if qp.SupplierID != "" {
+		id, err := uuid.Parse(qp.SupplierID)
+		if err != nil {
+			return purchaseorderbus.QueryFilter{}, validate.NewFieldsError("supplier_id", err)
+		}
+		filter.WithSupplierID(id)
+	}
+
+	if qp.InventoryID != "" {
+		id, err := uuid.Parse(qp.InventoryID)
+		if err != nil {
+			return purchaseorderbus.QueryFilter{}, validate.NewFieldsError("inventory_id", err)
+		}
+		filter.WithInventoryID(id)
+	}
+
+	if qp.Status != "" {
+		status, err := purchaseorderbus.ParseStatus(qp.Status)
+		if err != nil {
+			return purchaseorderbus.QueryFilter{}, validate.NewFieldsError("status", err)
+		}
+		filter.WithStatus(status)
+	}
+
+	return filter, nil
+}
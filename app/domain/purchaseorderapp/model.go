@@ -0,0 +1,324 @@
+package purchaseorderapp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/business/domain/purchaseorderbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Page        int    `query:"page"`
+	Rows        int    `query:"rows"`
+	OrderBy     string `query:"orderBy"`
+	ID          string `query:"purchase_order_id"`
+	SupplierID  string `query:"supplier_id"`
+	InventoryID string `query:"inventory_id"`
+	Status      string `query:"status"`
+}
+
+// Line represents a single medicine on a purchase order. Short and Over show
+// how far the deliveries so far are from the ordered quantity.
+type Line struct {
+	MedicineID string `json:"medicineID"`
+	Quantity   int    `json:"quantity"`
+	Received   int    `json:"received"`
+	Short      int    `json:"short"`
+	Over       int    `json:"over"`
+}
+
+// PurchaseOrder represents information about an individual purchase order.
+type PurchaseOrder struct {
+	ID           string `json:"id"`
+	SupplierID   string `json:"supplierID"`
+	InventoryID  string `json:"inventoryID"`
+	Status       string `json:"status"`
+	Note         string `json:"note"`
+	Lines        []Line `json:"lines"`
+	CreatedBy    string `json:"createdBy"`
+	ApprovedBy   string `json:"approvedBy,omitempty"`
+	DateApproved string `json:"dateApproved,omitempty"`
+	SentBy       string `json:"sentBy,omitempty"`
+	DateSent     string `json:"dateSent,omitempty"`
+	DateCreated  string `json:"dateCreated"`
+	DateUpdated  string `json:"dateUpdated"`
+}
+
+func toAppPurchaseOrder(po purchaseorderbus.PurchaseOrder) PurchaseOrder {
+	lines := make([]Line, len(po.Lines))
+	for i, line := range po.Lines {
+		lines[i] = Line{
+			MedicineID: line.MedicineID.String(),
+			Quantity:   line.Quantity,
+			Received:   line.Received,
+			Short:      line.Short(),
+			Over:       line.Over(),
+		}
+	}
+
+	app := PurchaseOrder{
+		ID:          po.ID.String(),
+		SupplierID:  po.SupplierID.String(),
+		InventoryID: po.InventoryID.String(),
+		Status:      po.Status.Name(),
+		Note:        po.Note,
+		Lines:       lines,
+		CreatedBy:   po.CreatedBy.String(),
+		DateCreated: po.DateCreated.Format(time.RFC3339),
+		DateUpdated: po.DateUpdated.Format(time.RFC3339),
+	}
+
+	if po.ApprovedBy != uuid.Nil {
+		app.ApprovedBy = po.ApprovedBy.String()
+		app.DateApproved = po.DateApproved.Format(time.RFC3339)
+	}
+
+	if po.SentBy != uuid.Nil {
+		app.SentBy = po.SentBy.String()
+		app.DateSent = po.DateSent.Format(time.RFC3339)
+	}
+
+	return app
+}
+
+func toAppPurchaseOrders(pos []purchaseorderbus.PurchaseOrder) []PurchaseOrder {
+	items := make([]PurchaseOrder, len(pos))
+	for i, po := range pos {
+		items[i] = toAppPurchaseOrder(po)
+	}
+
+	return items
+}
+
+// DeliveryLine represents the quantity of a lot received in a delivery.
+type DeliveryLine struct {
+	MedicineID string `json:"medicineID"`
+	LotID      string `json:"lotID"`
+	LotNumber  string `json:"lotNumber"`
+	ExpiryDate string `json:"expiryDate"`
+	Quantity   int    `json:"quantity"`
+}
+
+// Delivery represents a shipment received against a purchase order.
+type Delivery struct {
+	ID              string         `json:"id"`
+	PurchaseOrderID string         `json:"purchaseOrderID"`
+	InventoryID     string         `json:"inventoryID"`
+	Note            string         `json:"note"`
+	Lines           []DeliveryLine `json:"lines"`
+	ReceivedBy      string         `json:"receivedBy"`
+	DateReceived    string         `json:"dateReceived"`
+}
+
+func toAppDelivery(dlv purchaseorderbus.Delivery) Delivery {
+	lines := make([]DeliveryLine, len(dlv.Lines))
+	for i, line := range dlv.Lines {
+		lines[i] = DeliveryLine{
+			MedicineID: line.MedicineID.String(),
+			LotID:      line.LotID.String(),
+			LotNumber:  line.LotNumber,
+			ExpiryDate: line.ExpiryDate.Format(time.RFC3339),
+			Quantity:   line.Quantity,
+		}
+	}
+
+	return Delivery{
+		ID:              dlv.ID.String(),
+		PurchaseOrderID: dlv.PurchaseOrderID.String(),
+		InventoryID:     dlv.InventoryID.String(),
+		Note:            dlv.Note,
+		Lines:           lines,
+		ReceivedBy:      dlv.ReceivedBy.String(),
+		DateReceived:    dlv.DateReceived.Format(time.RFC3339),
+	}
+}
+
+func toAppDeliveries(dlvs []purchaseorderbus.Delivery) []Delivery {
+	items := make([]Delivery, len(dlvs))
+	for i, dlv := range dlvs {
+		items[i] = toAppDelivery(dlv)
+	}
+
+	return items
+}
+
+// Receipt represents a received delivery together with the state of the
+// purchase order it was received against.
+type Receipt struct {
+	Delivery      Delivery      `json:"delivery"`
+	PurchaseOrder PurchaseOrder `json:"purchaseOrder"`
+}
+
+// NewLine defines the data needed to add a medicine to a purchase order.
+type NewLine struct {
+	MedicineID string `json:"medicineID" validate:"required"`
+	Quantity   int    `json:"quantity" validate:"required"`
+}
+
+func toBusNewLines(app []NewLine) ([]purchaseorderbus.NewLine, error) {
+	if app == nil {
+		return nil, nil
+	}
+
+	lines := make([]purchaseorderbus.NewLine, len(app))
+	for i, line := range app {
+		medicineID, err := uuid.Parse(line.MedicineID)
+		if err != nil {
+			return nil, fmt.Errorf("parse: %w", err)
+		}
+
+		lines[i] = purchaseorderbus.NewLine{
+			MedicineID: medicineID,
+			Quantity:   line.Quantity,
+		}
+	}
+
+	return lines, nil
+}
+
+// NewPurchaseOrder defines the data needed to draft a new purchase order
+// into the inventory in the path.
+type NewPurchaseOrder struct {
+	SupplierID string    `json:"supplierID" validate:"required"`
+	Note       string    `json:"note"`
+	Lines      []NewLine `json:"lines" validate:"required,min=1,dive"`
+}
+
+func toBusNewPurchaseOrder(app NewPurchaseOrder, inventoryID uuid.UUID, userID uuid.UUID) (purchaseorderbus.NewPurchaseOrder, error) {
+	supplierID, err := uuid.Parse(app.SupplierID)
+	if err != nil {
+		return purchaseorderbus.NewPurchaseOrder{}, fmt.Errorf("parse: %w", err)
+	}
+
+	lines, err := toBusNewLines(app.Lines)
+	if err != nil {
+		return purchaseorderbus.NewPurchaseOrder{}, err
+	}
+
+	npo := purchaseorderbus.NewPurchaseOrder{
+		SupplierID:  supplierID,
+		InventoryID: inventoryID,
+		Note:        app.Note,
+		Lines:       lines,
+		UserID:      userID,
+	}
+
+	return npo, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewPurchaseOrder) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// UpdatePurchaseOrder defines the data needed to update a draft purchase
+// order.
+type UpdatePurchaseOrder struct {
+	SupplierID *string   `json:"supplierID"`
+	Note       *string   `json:"note"`
+	Lines      []NewLine `json:"lines" validate:"omitempty,min=1,dive"`
+}
+
+func toBusUpdatePurchaseOrder(app UpdatePurchaseOrder) (purchaseorderbus.UpdatePurchaseOrder, error) {
+	var supplierID *uuid.UUID
+	if app.SupplierID != nil {
+		id, err := uuid.Parse(*app.SupplierID)
+		if err != nil {
+			return purchaseorderbus.UpdatePurchaseOrder{}, fmt.Errorf("parse: %w", err)
+		}
+		supplierID = &id
+	}
+
+	lines, err := toBusNewLines(app.Lines)
+	if err != nil {
+		return purchaseorderbus.UpdatePurchaseOrder{}, err
+	}
+
+	upo := purchaseorderbus.UpdatePurchaseOrder{
+		SupplierID: supplierID,
+		Note:       app.Note,
+		Lines:      lines,
+	}
+
+	return upo, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app UpdatePurchaseOrder) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// NewDeliveryLine defines the quantity of a lot received.
+type NewDeliveryLine struct {
+	MedicineID      string `json:"medicineID" validate:"required"`
+	LotNumber       string `json:"lotNumber" validate:"required"`
+	ExpiryDate      string `json:"expiryDate" validate:"required"`
+	ManufactureDate string `json:"manufactureDate"`
+	Quantity        int    `json:"quantity" validate:"required"`
+}
+
+// NewDelivery defines the data needed to receive a delivery.
+type NewDelivery struct {
+	Note  string            `json:"note"`
+	Lines []NewDeliveryLine `json:"lines" validate:"required,min=1,dive"`
+}
+
+func toBusNewDelivery(app NewDelivery, userID uuid.UUID) (purchaseorderbus.NewDelivery, error) {
+	lines := make([]purchaseorderbus.NewDeliveryLine, len(app.Lines))
+	for i, line := range app.Lines {
+		medicineID, err := uuid.Parse(line.MedicineID)
+		if err != nil {
+			return purchaseorderbus.NewDelivery{}, fmt.Errorf("parse: %w", err)
+		}
+
+		expiryDate, err := time.Parse(time.RFC3339, line.ExpiryDate)
+		if err != nil {
+			return purchaseorderbus.NewDelivery{}, fmt.Errorf("parse expiryDate: %w", err)
+		}
+
+		var manufactureDate time.Time
+		if line.ManufactureDate != "" {
+			manufactureDate, err = time.Parse(time.RFC3339, line.ManufactureDate)
+			if err != nil {
+				return purchaseorderbus.NewDelivery{}, fmt.Errorf("parse manufactureDate: %w", err)
+			}
+		}
+
+		lines[i] = purchaseorderbus.NewDeliveryLine{
+			MedicineID:      medicineID,
+			LotNumber:       line.LotNumber,
+			ExpiryDate:      expiryDate,
+			ManufactureDate: manufactureDate,
+			Quantity:        line.Quantity,
+		}
+	}
+
+	nd := purchaseorderbus.NewDelivery{
+		Note:   app.Note,
+		Lines:  lines,
+		UserID: userID,
+	}
+
+	return nd, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewDelivery) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
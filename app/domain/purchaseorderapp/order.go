@@ -0,0 +1,38 @@
+package purchaseorderapp
+
+import (
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/purchaseorderbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+)
+
+func parseOrder(qp QueryParams) (order.By, error) {
+	const (
+		orderByID          = "purchase_order_id"
+		orderByStatus      = "status"
+		orderByDateCreated = "date_created"
+		orderByDateSent    = "date_sent"
+	)
+
+	var orderByFields = map[string]string{
+		orderByID:          purchaseorderbus.OrderByID,
+		orderByStatus:      purchaseorderbus.OrderByStatus,
+		orderByDateCreated: purchaseorderbus.OrderByDateCreated,
+		orderByDateSent:    purchaseorderbus.OrderByDateSent,
+	}
+
+	orderBy, err := order.Parse(qp.OrderBy, order.NewBy(orderByDateCreated, order.ASC))
+	if err != nil {
+		return order.By{}, err
+	}
+
+	if _, exists := orderByFields[orderBy.Field]; !exists {
+		return order.By{}, validate.NewFieldsError(orderBy.Field, errors.New("order field does not exist"))
+	}
+
+	orderBy.Field = orderByFields[orderBy.Field]
+
+	return orderBy, nil
+}
@@ -0,0 +1,308 @@
+// Package purchaseorderapp maintains the app layer api for the purchase order
+// domain.
+package purchaseorderapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/purchaseorderbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/supplierbus"
+)
+
+// Core manages the set of app layer api functions for the purchase order
+// domain.
+type Core struct {
+	purchaseOrderBus *purchaseorderbus.Core
+}
+
+// NewCore constructs a purchase order core API for use.
+func NewCore(purchaseOrderBus *purchaseorderbus.Core) *Core {
+	return &Core{
+		purchaseOrderBus: purchaseOrderBus,
+	}
+}
+
+// Create drafts a new purchase order into the inventory in context.
+func (c *Core) Create(ctx context.Context, app NewPurchaseOrder) (PurchaseOrder, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return PurchaseOrder{}, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return PurchaseOrder{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	npo, err := toBusNewPurchaseOrder(app, inv.ID, userID)
+	if err != nil {
+		return PurchaseOrder{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	purchaseOrderBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return PurchaseOrder{}, errs.New(errs.Internal, err)
+	}
+
+	po, err := purchaseOrderBus.Create(ctx, npo)
+	if err != nil {
+		return PurchaseOrder{}, toAppError(err, "create: inventoryID[%s] npo[%+v]: %s", inv.ID, app, err)
+	}
+
+	return toAppPurchaseOrder(po), nil
+}
+
+// Update modifies the draft purchase order in context.
+func (c *Core) Update(ctx context.Context, app UpdatePurchaseOrder) (PurchaseOrder, error) {
+	po, err := mid.GetPurchaseOrder(ctx)
+	if err != nil {
+		return PurchaseOrder{}, errs.Newf(errs.Internal, "purchase order missing in context: %s", err)
+	}
+
+	upo, err := toBusUpdatePurchaseOrder(app)
+	if err != nil {
+		return PurchaseOrder{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	purchaseOrderBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return PurchaseOrder{}, errs.New(errs.Internal, err)
+	}
+
+	updPO, err := purchaseOrderBus.Update(ctx, po.ID, upo)
+	if err != nil {
+		return PurchaseOrder{}, toAppError(err, "update: purchaseOrderID[%s] upo[%+v]: %s", po.ID, app, err)
+	}
+
+	return toAppPurchaseOrder(updPO), nil
+}
+
+// Approve releases the purchase order in context to be sent.
+func (c *Core) Approve(ctx context.Context) (PurchaseOrder, error) {
+	po, err := mid.GetPurchaseOrder(ctx)
+	if err != nil {
+		return PurchaseOrder{}, errs.Newf(errs.Internal, "purchase order missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return PurchaseOrder{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	purchaseOrderBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return PurchaseOrder{}, errs.New(errs.Internal, err)
+	}
+
+	updPO, err := purchaseOrderBus.Approve(ctx, po.ID, userID)
+	if err != nil {
+		return PurchaseOrder{}, toAppError(err, "approve: purchaseOrderID[%s]: %s", po.ID, err)
+	}
+
+	return toAppPurchaseOrder(updPO), nil
+}
+
+// Send records that the purchase order in context went out to the supplier.
+func (c *Core) Send(ctx context.Context) (PurchaseOrder, error) {
+	po, err := mid.GetPurchaseOrder(ctx)
+	if err != nil {
+		return PurchaseOrder{}, errs.Newf(errs.Internal, "purchase order missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return PurchaseOrder{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	purchaseOrderBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return PurchaseOrder{}, errs.New(errs.Internal, err)
+	}
+
+	updPO, err := purchaseOrderBus.Send(ctx, po.ID, userID)
+	if err != nil {
+		return PurchaseOrder{}, toAppError(err, "send: purchaseOrderID[%s]: %s", po.ID, err)
+	}
+
+	return toAppPurchaseOrder(updPO), nil
+}
+
+// Receive records a delivery against the purchase order in context.
+func (c *Core) Receive(ctx context.Context, app NewDelivery) (Receipt, error) {
+	po, err := mid.GetPurchaseOrder(ctx)
+	if err != nil {
+		return Receipt{}, errs.Newf(errs.Internal, "purchase order missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Receipt{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	nd, err := toBusNewDelivery(app, userID)
+	if err != nil {
+		return Receipt{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	nd.CountersignedBy = mid.GetCountersignerID(ctx)
+
+	purchaseOrderBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Receipt{}, errs.New(errs.Internal, err)
+	}
+
+	dlv, updPO, err := purchaseOrderBus.Receive(ctx, po.ID, nd)
+	if err != nil {
+		return Receipt{}, toAppError(err, "receive: purchaseOrderID[%s] nd[%+v]: %s", po.ID, app, err)
+	}
+
+	rcpt := Receipt{
+		Delivery:      toAppDelivery(dlv),
+		PurchaseOrder: toAppPurchaseOrder(updPO),
+	}
+
+	return rcpt, nil
+}
+
+// Close stops waiting on the rest of the purchase order in context.
+func (c *Core) Close(ctx context.Context) (PurchaseOrder, error) {
+	po, err := mid.GetPurchaseOrder(ctx)
+	if err != nil {
+		return PurchaseOrder{}, errs.Newf(errs.Internal, "purchase order missing in context: %s", err)
+	}
+
+	purchaseOrderBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return PurchaseOrder{}, errs.New(errs.Internal, err)
+	}
+
+	updPO, err := purchaseOrderBus.Close(ctx, po.ID)
+	if err != nil {
+		return PurchaseOrder{}, toAppError(err, "close: purchaseOrderID[%s]: %s", po.ID, err)
+	}
+
+	return toAppPurchaseOrder(updPO), nil
+}
+
+// Cancel stops the purchase order in context.
+func (c *Core) Cancel(ctx context.Context) (PurchaseOrder, error) {
+	po, err := mid.GetPurchaseOrder(ctx)
+	if err != nil {
+		return PurchaseOrder{}, errs.Newf(errs.Internal, "purchase order missing in context: %s", err)
+	}
+
+	purchaseOrderBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return PurchaseOrder{}, errs.New(errs.Internal, err)
+	}
+
+	updPO, err := purchaseOrderBus.Cancel(ctx, po.ID)
+	if err != nil {
+		return PurchaseOrder{}, toAppError(err, "cancel: purchaseOrderID[%s]: %s", po.ID, err)
+	}
+
+	return toAppPurchaseOrder(updPO), nil
+}
+
+// Query returns a list of purchase orders with paging.
+func (c *Core) Query(ctx context.Context, qp QueryParams) (page.Document[PurchaseOrder], error) {
+	if err := validatePaging(qp); err != nil {
+		return page.Document[PurchaseOrder]{}, err
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return page.Document[PurchaseOrder]{}, err
+	}
+
+	orderBy, err := parseOrder(qp)
+	if err != nil {
+		return page.Document[PurchaseOrder]{}, err
+	}
+
+	pos, err := c.purchaseOrderBus.Query(ctx, filter, orderBy, qp.Page, qp.Rows)
+	if err != nil {
+		return page.Document[PurchaseOrder]{}, errs.Newf(errs.Internal, "query: %s", err)
+	}
+
+	total, err := c.purchaseOrderBus.Count(ctx, filter)
+	if err != nil {
+		return page.Document[PurchaseOrder]{}, errs.Newf(errs.Internal, "count: %s", err)
+	}
+
+	return page.NewDocument(toAppPurchaseOrders(pos), total, qp.Page, qp.Rows), nil
+}
+
+// QueryByID returns a purchase order by its ID.
+func (c *Core) QueryByID(ctx context.Context) (PurchaseOrder, error) {
+	po, err := mid.GetPurchaseOrder(ctx)
+	if err != nil {
+		return PurchaseOrder{}, errs.Newf(errs.Internal, "querybyid: %s", err)
+	}
+
+	return toAppPurchaseOrder(po), nil
+}
+
+// QueryDeliveries returns the deliveries received against the purchase order
+// in context.
+func (c *Core) QueryDeliveries(ctx context.Context) ([]Delivery, error) {
+	po, err := mid.GetPurchaseOrder(ctx)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "purchase order missing in context: %s", err)
+	}
+
+	dlvs, err := c.purchaseOrderBus.QueryDeliveries(ctx, po.ID)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "querydeliveries: purchaseOrderID[%s]: %s", po.ID, err)
+	}
+
+	return toAppDeliveries(dlvs), nil
+}
+
+// executeUnderTransaction returns a purchase order core bound to the
+// transaction the transaction middleware placed in the context.
+func (c *Core) executeUnderTransaction(ctx context.Context) (*purchaseorderbus.Core, error) {
+	tx, ok := transaction.Get(ctx)
+	if !ok {
+		return nil, errors.New("transaction missing in context")
+	}
+
+	return c.purchaseOrderBus.ExecuteUnderTransaction(tx)
+}
+
+// toAppError maps the business errors a purchase order can fail with to the
+// matching app error.
+func toAppError(err error, format string, v ...any) error {
+	switch {
+	case errors.Is(err, purchaseorderbus.ErrNoLines),
+		errors.Is(err, purchaseorderbus.ErrDuplicateLine),
+		errors.Is(err, purchaseorderbus.ErrInvalidQuantity),
+		errors.Is(err, purchaseorderbus.ErrInvalidTransition),
+		errors.Is(err, purchaseorderbus.ErrNoDeliveryLines),
+		errors.Is(err, purchaseorderbus.ErrUnknownMedicine),
+		errors.Is(err, purchaseorderbus.ErrLotMismatch),
+		errors.Is(err, purchaseorderbus.ErrDuplicateLot),
+		errors.Is(err, lotbus.ErrInvalidDates),
+		errors.Is(err, inventorybus.ErrIncompatibleStorage),
+		errors.Is(err, stockbus.ErrCountersignRequired),
+		errors.Is(err, stockbus.ErrSelfCountersign),
+		errors.Is(err, stockbus.ErrInvalidQuantity):
+		return errs.New(errs.FailedPrecondition, err)
+
+	case errors.Is(err, purchaseorderbus.ErrNotFound),
+		errors.Is(err, supplierbus.ErrNotFound),
+		errors.Is(err, medicinebus.ErrNotFound):
+		return errs.New(errs.NotFound, err)
+	}
+
+	return errs.Newf(errs.Internal, format, v...)
+}
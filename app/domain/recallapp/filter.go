@@ -0,0 +1,53 @@
+package recallapp
+
+import (
+	"github.com/EnesDemirtas/medisync/business/domain/recallbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+func parseFilter(qp QueryParams) (recallbus.QueryFilter, error) {
+	var filter recallbus.QueryFilter
+
+	if qp.ID != "" {
+		id, err := uuid.Parse(qp.ID)
+		if err != nil {
+			return recallbus.QueryFilter{}, validate.NewFieldsError("recall_id", err)
+		}
+		filter.WithRecallID(id)
+	}
+
+	if qp.MedicineID != "" {
+		id, err := uuid.Parse(qp.MedicineID)
+		if err != nil {
+			return recallbus.QueryFilter{}, validate.NewFieldsError("medicine_id", err)
+		}
+		filter.WithMedicineID(id)
+	}
+
+	if qp.LotID != "" {
+		id, err := uuid.Parse(qp.LotID)
+		if err != nil {
+			return recallbus.QueryFilter{}, validate.NewFieldsError("lot_id", err)
+		}
+		filter.WithLotID(id)
+	}
+
+	if qp.Severity != "" {
+		severity, err := recallbus.ParseSeverity(qp.Severity)
+		if err != nil {
+			return recallbus.QueryFilter{}, validate.NewFieldsError("severity", err)
+		}
+		filter.WithSeverity(severity)
+	}
+
+	if qp.Status != "" {
+		status, err := recallbus.ParseStatus(qp.Status)
+		if err != nil {
+			return recallbus.QueryFilter{}, validate.NewFieldsError("status", err)
+		}
+		filter.WithStatus(status)
+	}
+
+	return filter, nil
+}
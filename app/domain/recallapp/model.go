@@ -0,0 +1,264 @@
+package recallapp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/business/domain/recallbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Page       int    `query:"page"`
+	Rows       int    `query:"rows"`
+	OrderBy    string `query:"orderBy"`
+	ID         string `query:"recall_id"`
+	MedicineID string `query:"medicine_id"`
+	LotID      string `query:"lot_id"`
+	Severity   string `query:"severity"`
+	Status     string `query:"status"`
+}
+
+// Recall represents information about an individual recall.
+type Recall struct {
+	ID          string   `json:"id"`
+	MedicineID  string   `json:"medicineID"`
+	LotIDs      []string `json:"lotIDs"`
+	Reason      string   `json:"reason"`
+	Severity    string   `json:"severity"`
+	Status      string   `json:"status"`
+	CreatedBy   string   `json:"createdBy"`
+	ClosedBy    string   `json:"closedBy,omitempty"`
+	DateClosed  string   `json:"dateClosed,omitempty"`
+	DateCreated string   `json:"dateCreated"`
+	DateUpdated string   `json:"dateUpdated"`
+}
+
+func toAppRecall(rcl recallbus.Recall) Recall {
+	lotIDs := make([]string, len(rcl.LotIDs))
+	for i, lotID := range rcl.LotIDs {
+		lotIDs[i] = lotID.String()
+	}
+
+	app := Recall{
+		ID:          rcl.ID.String(),
+		MedicineID:  rcl.MedicineID.String(),
+		LotIDs:      lotIDs,
+		Reason:      rcl.Reason,
+		Severity:    rcl.Severity.Name(),
+		Status:      rcl.Status.Name(),
+		CreatedBy:   rcl.CreatedBy.String(),
+		DateCreated: rcl.DateCreated.Format(time.RFC3339),
+		DateUpdated: rcl.DateUpdated.Format(time.RFC3339),
+	}
+
+	if rcl.ClosedBy != uuid.Nil {
+		app.ClosedBy = rcl.ClosedBy.String()
+		app.DateClosed = rcl.DateClosed.Format(time.RFC3339)
+	}
+
+	return app
+}
+
+func toAppRecalls(rcls []recallbus.Recall) []Recall {
+	items := make([]Recall, len(rcls))
+	for i, rcl := range rcls {
+		items[i] = toAppRecall(rcl)
+	}
+
+	return items
+}
+
+// Stock represents the recalled stock of a lot held by an inventory.
+// Quarantined is what the inventory held when the recall was registered.
+type Stock struct {
+	InventoryID string `json:"inventoryID"`
+	LotID       string `json:"lotID"`
+	Quarantined int    `json:"quarantined"`
+	OnHand      int    `json:"onHand"`
+	Returned    int    `json:"returned"`
+	Destroyed   int    `json:"destroyed"`
+}
+
+// Shipment represents recalled stock that already left an inventory. The
+// reference fields are left out when it wasn't made for a record, the
+// destination fields when it didn't leave on an order, DestinationInventoryID
+// when it wasn't transferred and ReservedFor when it wasn't reserved.
+type Shipment struct {
+	MovementID             string `json:"movementID"`
+	InventoryID            string `json:"inventoryID"`
+	LotID                  string `json:"lotID"`
+	Type                   string `json:"type"`
+	Quantity               int    `json:"quantity"`
+	Reason                 string `json:"reason"`
+	ReferenceType          string `json:"referenceType,omitempty"`
+	ReferenceID            string `json:"referenceID,omitempty"`
+	DestinationType        string `json:"destinationType,omitempty"`
+	Destination            string `json:"destination,omitempty"`
+	DestinationInventoryID string `json:"destinationInventoryID,omitempty"`
+	ReservedFor            string `json:"reservedFor,omitempty"`
+	DateShipped            string `json:"dateShipped"`
+}
+
+// Report represents where the stock of a recall was held and where it was
+// already shipped to.
+type Report struct {
+	Recall    Recall     `json:"recall"`
+	Stock     []Stock    `json:"stock"`
+	Shipments []Shipment `json:"shipments"`
+}
+
+func toAppReport(rpt recallbus.Report) Report {
+	stock := make([]Stock, len(rpt.Stock))
+	for i, s := range rpt.Stock {
+		stock[i] = Stock{
+			InventoryID: s.InventoryID.String(),
+			LotID:       s.LotID.String(),
+			Quarantined: s.Quarantined,
+			OnHand:      s.OnHand,
+			Returned:    s.Returned,
+			Destroyed:   s.Destroyed,
+		}
+	}
+
+	shps := make([]Shipment, len(rpt.Shipments))
+	for i, shp := range rpt.Shipments {
+		shps[i] = Shipment{
+			MovementID:  shp.MovementID.String(),
+			InventoryID: shp.InventoryID.String(),
+			LotID:       shp.LotID.String(),
+			Type:        shp.Type.Name(),
+			Quantity:    shp.Quantity,
+			Reason:      shp.Reason,
+			ReservedFor: shp.ReservedFor,
+			DateShipped: shp.DateShipped.Format(time.RFC3339),
+		}
+
+		if shp.Reference.ID != uuid.Nil {
+			shps[i].ReferenceType = shp.Reference.Type.Name()
+			shps[i].ReferenceID = shp.Reference.ID.String()
+		}
+
+		if shp.Destination.Name != "" {
+			shps[i].DestinationType = shp.Destination.Type.Name()
+			shps[i].Destination = shp.Destination.Name
+		}
+
+		if shp.DestinationInventoryID != uuid.Nil {
+			shps[i].DestinationInventoryID = shp.DestinationInventoryID.String()
+		}
+	}
+
+	return Report{
+		Recall:    toAppRecall(rpt.Recall),
+		Stock:     stock,
+		Shipments: shps,
+	}
+}
+
+// NewRecall defines the data needed to register a recall.
+type NewRecall struct {
+	MedicineID string   `json:"medicineID" validate:"required,uuid"`
+	LotIDs     []string `json:"lotIDs" validate:"required,min=1,dive,uuid"`
+	Reason     string   `json:"reason" validate:"required"`
+	Severity   string   `json:"severity" validate:"required"`
+}
+
+func toBusNewRecall(app NewRecall, userID uuid.UUID) (recallbus.NewRecall, error) {
+	medicineID, err := uuid.Parse(app.MedicineID)
+	if err != nil {
+		return recallbus.NewRecall{}, fmt.Errorf("parse: %w", err)
+	}
+
+	lotIDs := make([]uuid.UUID, len(app.LotIDs))
+	for i, id := range app.LotIDs {
+		lotIDs[i], err = uuid.Parse(id)
+		if err != nil {
+			return recallbus.NewRecall{}, fmt.Errorf("parse: %w", err)
+		}
+	}
+
+	severity, err := recallbus.ParseSeverity(app.Severity)
+	if err != nil {
+		return recallbus.NewRecall{}, fmt.Errorf("parse: %w", err)
+	}
+
+	nr := recallbus.NewRecall{
+		MedicineID: medicineID,
+		LotIDs:     lotIDs,
+		Reason:     app.Reason,
+		Severity:   severity,
+		UserID:     userID,
+	}
+
+	return nr, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewRecall) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// Resolution defines the data needed to take recalled stock of a lot out of
+// an inventory. LocationID is left out for stock that is not put away.
+type Resolution struct {
+	InventoryID string `json:"inventoryID" validate:"required,uuid"`
+	LotID       string `json:"lotID" validate:"required,uuid"`
+	LocationID  string `json:"locationID" validate:"omitempty,uuid"`
+	Method      string `json:"method" validate:"required"`
+	Quantity    int    `json:"quantity" validate:"required,gt=0"`
+	Note        string `json:"note"`
+}
+
+func toBusResolution(app Resolution, userID uuid.UUID) (recallbus.Resolution, error) {
+	inventoryID, err := uuid.Parse(app.InventoryID)
+	if err != nil {
+		return recallbus.Resolution{}, fmt.Errorf("parse: %w", err)
+	}
+
+	lotID, err := uuid.Parse(app.LotID)
+	if err != nil {
+		return recallbus.Resolution{}, fmt.Errorf("parse: %w", err)
+	}
+
+	var locationID uuid.UUID
+	if app.LocationID != "" {
+		locationID, err = uuid.Parse(app.LocationID)
+		if err != nil {
+			return recallbus.Resolution{}, fmt.Errorf("parse: %w", err)
+		}
+	}
+
+	method, err := recallbus.ParseMethod(app.Method)
+	if err != nil {
+		return recallbus.Resolution{}, fmt.Errorf("parse: %w", err)
+	}
+
+	res := recallbus.Resolution{
+		InventoryID: inventoryID,
+		LotID:       lotID,
+		LocationID:  locationID,
+		Method:      method,
+		Quantity:    app.Quantity,
+		Note:        app.Note,
+		UserID:      userID,
+	}
+
+	return res, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app Resolution) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package recallapp
+
+import (
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/recallbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+)
+
+func parseOrder(qp QueryParams) (order.By, error) {
+	const (
+		orderByID          = "recall_id"
+		orderBySeverity    = "severity"
+		orderByStatus      = "status"
+		orderByDateCreated = "date_created"
+	)
+
+	var orderByFields = map[string]string{
+		orderByID:          recallbus.OrderByID,
+		orderBySeverity:    recallbus.OrderBySeverity,
+		orderByStatus:      recallbus.OrderByStatus,
+		orderByDateCreated: recallbus.OrderByDateCreated,
+	}
+
+	orderBy, err := order.Parse(qp.OrderBy, order.NewBy(orderByDateCreated, order.DESC))
+	if err != nil {
+		return order.By{}, err
+	}
+
+	if _, exists := orderByFields[orderBy.Field]; !exists {
+		return order.By{}, validate.NewFieldsError(orderBy.Field, errors.New("order field does not exist"))
+	}
+
+	orderBy.Field = orderByFields[orderBy.Field]
+
+	return orderBy, nil
+}
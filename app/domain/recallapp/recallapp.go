@@ -0,0 +1,208 @@
+// Package recallapp maintains the app layer api for the recall domain.
+package recallapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/recallbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+)
+
+// Core manages the set of app layer api functions for the recall domain.
+type Core struct {
+	recallBus *recallbus.Core
+}
+
+// NewCore constructs a recall core API for use.
+func NewCore(recallBus *recallbus.Core) *Core {
+	return &Core{
+		recallBus: recallBus,
+	}
+}
+
+// Create registers a recall and quarantines its lots.
+func (c *Core) Create(ctx context.Context, app NewRecall) (Recall, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Recall{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	nr, err := toBusNewRecall(app, userID)
+	if err != nil {
+		return Recall{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	recallBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Recall{}, errs.New(errs.Internal, err)
+	}
+
+	rcl, err := recallBus.Create(ctx, nr)
+	if err != nil {
+		return Recall{}, toAppError(err, "create: nr[%+v]: %s", nr, err)
+	}
+
+	return toAppRecall(rcl), nil
+}
+
+// Resolve takes recalled stock out of an inventory for the recall in
+// context.
+func (c *Core) Resolve(ctx context.Context, app Resolution) (Recall, error) {
+	rcl, err := mid.GetRecall(ctx)
+	if err != nil {
+		return Recall{}, errs.Newf(errs.Internal, "recall missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Recall{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	res, err := toBusResolution(app, userID)
+	if err != nil {
+		return Recall{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	res.CountersignedBy = mid.GetCountersignerID(ctx)
+
+	recallBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Recall{}, errs.New(errs.Internal, err)
+	}
+
+	updRcl, err := recallBus.Resolve(ctx, rcl.ID, res)
+	if err != nil {
+		return Recall{}, toAppError(err, "resolve: recallID[%s] res[%+v]: %s", rcl.ID, app, err)
+	}
+
+	return toAppRecall(updRcl), nil
+}
+
+// Cancel withdraws the recall in context and lifts the quarantine.
+func (c *Core) Cancel(ctx context.Context) (Recall, error) {
+	rcl, err := mid.GetRecall(ctx)
+	if err != nil {
+		return Recall{}, errs.Newf(errs.Internal, "recall missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Recall{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	recallBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Recall{}, errs.New(errs.Internal, err)
+	}
+
+	updRcl, err := recallBus.Cancel(ctx, rcl.ID, userID)
+	if err != nil {
+		return Recall{}, toAppError(err, "cancel: recallID[%s]: %s", rcl.ID, err)
+	}
+
+	return toAppRecall(updRcl), nil
+}
+
+// Query returns a list of recalls with paging.
+func (c *Core) Query(ctx context.Context, qp QueryParams) (page.Document[Recall], error) {
+	if err := validatePaging(qp); err != nil {
+		return page.Document[Recall]{}, err
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return page.Document[Recall]{}, err
+	}
+
+	orderBy, err := parseOrder(qp)
+	if err != nil {
+		return page.Document[Recall]{}, err
+	}
+
+	rcls, err := c.recallBus.Query(ctx, filter, orderBy, qp.Page, qp.Rows)
+	if err != nil {
+		return page.Document[Recall]{}, errs.Newf(errs.Internal, "query: %s", err)
+	}
+
+	total, err := c.recallBus.Count(ctx, filter)
+	if err != nil {
+		return page.Document[Recall]{}, errs.Newf(errs.Internal, "count: %s", err)
+	}
+
+	return page.NewDocument(toAppRecalls(rcls), total, qp.Page, qp.Rows), nil
+}
+
+// QueryByID returns a recall by its ID.
+func (c *Core) QueryByID(ctx context.Context) (Recall, error) {
+	rcl, err := mid.GetRecall(ctx)
+	if err != nil {
+		return Recall{}, errs.Newf(errs.Internal, "querybyid: %s", err)
+	}
+
+	return toAppRecall(rcl), nil
+}
+
+// QueryReport returns the recall report of the recall in context.
+func (c *Core) QueryReport(ctx context.Context) (Report, error) {
+	rcl, err := mid.GetRecall(ctx)
+	if err != nil {
+		return Report{}, errs.Newf(errs.Internal, "recall missing in context: %s", err)
+	}
+
+	rpt, err := c.recallBus.QueryReport(ctx, rcl)
+	if err != nil {
+		return Report{}, errs.Newf(errs.Internal, "queryreport: recallID[%s]: %s", rcl.ID, err)
+	}
+
+	return toAppReport(rpt), nil
+}
+
+// executeUnderTransaction returns a recall core bound to the transaction the
+// transaction middleware placed in the context.
+func (c *Core) executeUnderTransaction(ctx context.Context) (*recallbus.Core, error) {
+	tx, ok := transaction.Get(ctx)
+	if !ok {
+		return nil, errors.New("transaction missing in context")
+	}
+
+	return c.recallBus.ExecuteUnderTransaction(tx)
+}
+
+// toAppError maps the business errors a recall can fail with to the
+// matching app error.
+func toAppError(err error, format string, v ...any) error {
+	switch {
+	case errors.Is(err, recallbus.ErrNoLots),
+		errors.Is(err, recallbus.ErrReasonRequired),
+		errors.Is(err, recallbus.ErrLotMismatch),
+		errors.Is(err, recallbus.ErrAlreadyRecalled),
+		errors.Is(err, recallbus.ErrInvalidTransition),
+		errors.Is(err, recallbus.ErrLotNotRecalled),
+		errors.Is(err, recallbus.ErrInvalidQuantity),
+		errors.Is(err, inventorybus.ErrInsufficientStock),
+		errors.Is(err, inventorybus.ErrIncompatibleStorage),
+		errors.Is(err, locationbus.ErrNotBin),
+		errors.Is(err, locationbus.ErrInsufficientStock),
+		errors.Is(err, stockbus.ErrCountersignRequired),
+		errors.Is(err, stockbus.ErrSelfCountersign),
+		errors.Is(err, stockbus.ErrInvalidQuantity),
+		errors.Is(err, stockbus.ErrUnavailable):
+		return errs.New(errs.FailedPrecondition, err)
+
+	case errors.Is(err, recallbus.ErrNotFound),
+		errors.Is(err, inventorybus.ErrNotFound),
+		errors.Is(err, locationbus.ErrNotFound),
+		errors.Is(err, lotbus.ErrNotFound):
+		return errs.New(errs.NotFound, err)
+	}
+
+	return errs.Newf(errs.Internal, format, v...)
+}
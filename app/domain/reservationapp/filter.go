@@ -0,0 +1,45 @@
+package reservationapp
+
+import (
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+func parseFilter(qp QueryParams) (reservationbus.QueryFilter, error) {
+	var filter reservationbus.QueryFilter
+
+	if qp.ID != "" {
+		id, err := uuid.Parse(qp.ID)
+		if err != nil {
+			return reservationbus.QueryFilter{}, validate.NewFieldsError("reservation_id", err)
+		}
+		filter.WithReservationID(id)
+	}
+
+	if qp.InventoryID != "" {
+		id, err := uuid.Parse(qp.InventoryID)
+		if err != nil {
+			return reservationbus.QueryFilter{}, validate.NewFieldsError("inventory_id", err)
+		}
+		filter.WithInventoryID(id)
+	}
+
+	if qp.MedicineID != "" {
+		id, err := uuid.Parse(qp.MedicineID)
+		if err != nil {
+			return reservationbus.QueryFilter{}, validate.NewFieldsError("medicine_id", err)
+		}
+		filter.WithMedicineID(id)
+	}
+
+	if qp.Status != "" {
+		status, err := reservationbus.ParseStatus(qp.Status)
+		if err != nil {
+			return reservationbus.QueryFilter{}, validate.NewFieldsError("status", err)
+		}
+		filter.WithStatus(status)
+	}
+
+	return filter, nil
+}
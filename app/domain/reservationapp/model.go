@@ -0,0 +1,168 @@
+package reservationapp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Page        int    `query:"page"`
+	Rows        int    `query:"rows"`
+	OrderBy     string `query:"orderBy"`
+	ID          string `query:"reservation_id"`
+	InventoryID string `query:"inventory_id"`
+	MedicineID  string `query:"medicine_id"`
+	Status      string `query:"status"`
+}
+
+// Reservation represents information about an individual reservation.
+type Reservation struct {
+	ID          string `json:"id"`
+	InventoryID string `json:"inventoryID"`
+	MedicineID  string `json:"medicineID"`
+	Quantity    int    `json:"quantity"`
+	Status      string `json:"status"`
+	Reference   string `json:"reference"`
+	UserID      string `json:"userID"`
+	DateExpires string `json:"dateExpires"`
+	DateCreated string `json:"dateCreated"`
+	DateUpdated string `json:"dateUpdated"`
+}
+
+func toAppReservation(res reservationbus.Reservation) Reservation {
+	return Reservation{
+		ID:          res.ID.String(),
+		InventoryID: res.InventoryID.String(),
+		MedicineID:  res.MedicineID.String(),
+		Quantity:    res.Quantity,
+		Status:      res.Status.Name(),
+		Reference:   res.Reference,
+		UserID:      res.UserID.String(),
+		DateExpires: res.DateExpires.Format(time.RFC3339),
+		DateCreated: res.DateCreated.Format(time.RFC3339),
+		DateUpdated: res.DateUpdated.Format(time.RFC3339),
+	}
+}
+
+func toAppReservations(rss []reservationbus.Reservation) []Reservation {
+	items := make([]Reservation, len(rss))
+	for i, res := range rss {
+		items[i] = toAppReservation(res)
+	}
+
+	return items
+}
+
+// Availability represents the stock of a medicine in an inventory that is
+// free to be reserved or dispensed.
+type Availability struct {
+	InventoryID string `json:"inventoryID"`
+	MedicineID  string `json:"medicineID"`
+	OnHand      int    `json:"onHand"`
+	Reserved    int    `json:"reserved"`
+	Available   int    `json:"available"`
+}
+
+func toAppAvailabilities(avs []reservationbus.Availability) []Availability {
+	items := make([]Availability, len(avs))
+	for i, av := range avs {
+		items[i] = Availability{
+			InventoryID: av.InventoryID.String(),
+			MedicineID:  av.MedicineID.String(),
+			OnHand:      av.OnHand,
+			Reserved:    av.Reserved,
+			Available:   av.Available(),
+		}
+	}
+
+	return items
+}
+
+// NewReservation defines the data needed to reserve stock in the inventory
+// in the path. TTL is a duration such as "30m" or "24h".
+type NewReservation struct {
+	MedicineID string `json:"medicineID" validate:"required"`
+	Quantity   int    `json:"quantity" validate:"required,gte=1"`
+	TTL        string `json:"ttl" validate:"required"`
+	Reference  string `json:"reference"`
+}
+
+func toBusNewReservation(app NewReservation, inventoryID uuid.UUID, userID uuid.UUID) (reservationbus.NewReservation, error) {
+	medicineID, err := uuid.Parse(app.MedicineID)
+	if err != nil {
+		return reservationbus.NewReservation{}, fmt.Errorf("parse: %w", err)
+	}
+
+	ttl, err := time.ParseDuration(app.TTL)
+	if err != nil {
+		return reservationbus.NewReservation{}, fmt.Errorf("parse ttl: %w", err)
+	}
+
+	nr := reservationbus.NewReservation{
+		InventoryID: inventoryID,
+		MedicineID:  medicineID,
+		Quantity:    app.Quantity,
+		TTL:         ttl,
+		Reference:   app.Reference,
+		UserID:      userID,
+	}
+
+	return nr, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewReservation) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// ConsumptionLine defines the quantity picked from a lot.
+type ConsumptionLine struct {
+	LotID    string `json:"lotID" validate:"required"`
+	Quantity int    `json:"quantity" validate:"required"`
+}
+
+// Consumption defines the lots picked to dispense a reservation.
+type Consumption struct {
+	Lines []ConsumptionLine `json:"lines" validate:"required,min=1,dive"`
+}
+
+func toBusConsumption(app Consumption, userID uuid.UUID) (reservationbus.Consumption, error) {
+	lines := make([]reservationbus.ConsumptionLine, len(app.Lines))
+	for i, line := range app.Lines {
+		lotID, err := uuid.Parse(line.LotID)
+		if err != nil {
+			return reservationbus.Consumption{}, fmt.Errorf("parse: %w", err)
+		}
+
+		lines[i] = reservationbus.ConsumptionLine{
+			LotID:    lotID,
+			Quantity: line.Quantity,
+		}
+	}
+
+	cons := reservationbus.Consumption{
+		Lines:  lines,
+		UserID: userID,
+	}
+
+	return cons, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app Consumption) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
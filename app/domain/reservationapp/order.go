@@ -0,0 +1,38 @@
+package reservationapp
+
+import (
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+)
+
+func parseOrder(qp QueryParams) (order.By, error) {
+	const (
+		orderByID          = "reservation_id"
+		orderByStatus      = "status"
+		orderByDateExpires = "date_expires"
+		orderByDateCreated = "date_created"
+	)
+
+	var orderByFields = map[string]string{
+		orderByID:          reservationbus.OrderByID,
+		orderByStatus:      reservationbus.OrderByStatus,
+		orderByDateExpires: reservationbus.OrderByDateExpires,
+		orderByDateCreated: reservationbus.OrderByDateCreated,
+	}
+
+	orderBy, err := order.Parse(qp.OrderBy, order.NewBy(orderByDateCreated, order.ASC))
+	if err != nil {
+		return order.By{}, err
+	}
+
+	if _, exists := orderByFields[orderBy.Field]; !exists {
+		return order.By{}, validate.NewFieldsError(orderBy.Field, errors.New("order field does not exist"))
+	}
+
+	orderBy.Field = orderByFields[orderBy.Field]
+
+	return orderBy, nil
+}
@@ -0,0 +1,205 @@
+// Package reservationapp maintains the app layer api for the reservation domain.
+package reservationapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+)
+
+// Core manages the set of app layer api functions for the reservation domain.
+type Core struct {
+	reservationBus *reservationbus.Core
+}
+
+// NewCore constructs a reservation core API for use.
+func NewCore(reservationBus *reservationbus.Core) *Core {
+	return &Core{
+		reservationBus: reservationBus,
+	}
+}
+
+// Reserve holds stock in the inventory in context.
+func (c *Core) Reserve(ctx context.Context, app NewReservation) (Reservation, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return Reservation{}, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Reservation{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	nr, err := toBusNewReservation(app, inv.ID, userID)
+	if err != nil {
+		return Reservation{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	reservationBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Reservation{}, errs.New(errs.Internal, err)
+	}
+
+	res, err := reservationBus.Reserve(ctx, nr)
+	if err != nil {
+		return Reservation{}, toAppError(err, "reserve: inventoryID[%s] nr[%+v]: %s", inv.ID, app, err)
+	}
+
+	return toAppReservation(res), nil
+}
+
+// Consume dispenses the reservation in context.
+func (c *Core) Consume(ctx context.Context, app Consumption) (Reservation, error) {
+	res, err := mid.GetReservation(ctx)
+	if err != nil {
+		return Reservation{}, errs.Newf(errs.Internal, "reservation missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Reservation{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	cons, err := toBusConsumption(app, userID)
+	if err != nil {
+		return Reservation{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	cons.CountersignedBy = mid.GetCountersignerID(ctx)
+
+	reservationBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Reservation{}, errs.New(errs.Internal, err)
+	}
+
+	updRes, err := reservationBus.Consume(ctx, res.ID, cons)
+	if err != nil {
+		return Reservation{}, toAppError(err, "consume: reservationID[%s] cons[%+v]: %s", res.ID, app, err)
+	}
+
+	return toAppReservation(updRes), nil
+}
+
+// Release gives back the stock held by the reservation in context.
+func (c *Core) Release(ctx context.Context) (Reservation, error) {
+	res, err := mid.GetReservation(ctx)
+	if err != nil {
+		return Reservation{}, errs.Newf(errs.Internal, "reservation missing in context: %s", err)
+	}
+
+	reservationBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Reservation{}, errs.New(errs.Internal, err)
+	}
+
+	updRes, err := reservationBus.Release(ctx, res.ID)
+	if err != nil {
+		return Reservation{}, toAppError(err, "release: reservationID[%s]: %s", res.ID, err)
+	}
+
+	return toAppReservation(updRes), nil
+}
+
+// QueryAvailability returns the availability of every medicine held by the
+// inventory in context.
+func (c *Core) QueryAvailability(ctx context.Context) ([]Availability, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	avs, err := c.reservationBus.QueryAvailability(ctx, inv.ID)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "queryavailability: inventoryID[%s]: %s", inv.ID, err)
+	}
+
+	return toAppAvailabilities(avs), nil
+}
+
+// Query returns a list of reservations with paging.
+func (c *Core) Query(ctx context.Context, qp QueryParams) (page.Document[Reservation], error) {
+	if err := validatePaging(qp); err != nil {
+		return page.Document[Reservation]{}, err
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return page.Document[Reservation]{}, err
+	}
+
+	orderBy, err := parseOrder(qp)
+	if err != nil {
+		return page.Document[Reservation]{}, err
+	}
+
+	rss, err := c.reservationBus.Query(ctx, filter, orderBy, qp.Page, qp.Rows)
+	if err != nil {
+		return page.Document[Reservation]{}, errs.Newf(errs.Internal, "query: %s", err)
+	}
+
+	total, err := c.reservationBus.Count(ctx, filter)
+	if err != nil {
+		return page.Document[Reservation]{}, errs.Newf(errs.Internal, "count: %s", err)
+	}
+
+	return page.NewDocument(toAppReservations(rss), total, qp.Page, qp.Rows), nil
+}
+
+// QueryByID returns a reservation by its ID.
+func (c *Core) QueryByID(ctx context.Context) (Reservation, error) {
+	res, err := mid.GetReservation(ctx)
+	if err != nil {
+		return Reservation{}, errs.Newf(errs.Internal, "querybyid: %s", err)
+	}
+
+	return toAppReservation(res), nil
+}
+
+// executeUnderTransaction returns a reservation core bound to the transaction
+// the transaction middleware placed in the context.
+func (c *Core) executeUnderTransaction(ctx context.Context) (*reservationbus.Core, error) {
+	tx, ok := transaction.Get(ctx)
+	if !ok {
+		return nil, errors.New("transaction missing in context")
+	}
+
+	return c.reservationBus.ExecuteUnderTransaction(tx)
+}
+
+// toAppError maps the business errors a reservation can fail with to the
+// matching app error.
+func toAppError(err error, format string, v ...any) error {
+	switch {
+	case errors.Is(err, reservationbus.ErrInvalidQuantity),
+		errors.Is(err, reservationbus.ErrInvalidTTL),
+		errors.Is(err, reservationbus.ErrUnavailable),
+		errors.Is(err, reservationbus.ErrNotHeld),
+		errors.Is(err, reservationbus.ErrNoLines),
+		errors.Is(err, reservationbus.ErrQuantityMismatch),
+		errors.Is(err, reservationbus.ErrLotMismatch),
+		errors.Is(err, inventorybus.ErrInsufficientStock),
+		errors.Is(err, stockbus.ErrQuarantined),
+		errors.Is(err, stockbus.ErrUnavailable),
+		errors.Is(err, stockbus.ErrCountersignRequired),
+		errors.Is(err, stockbus.ErrSelfCountersign),
+		errors.Is(err, stockbus.ErrInvalidQuantity):
+		return errs.New(errs.FailedPrecondition, err)
+
+	case errors.Is(err, reservationbus.ErrNotFound),
+		errors.Is(err, medicinebus.ErrNotFound),
+		errors.Is(err, lotbus.ErrNotFound):
+		return errs.New(errs.NotFound, err)
+	}
+
+	return errs.Newf(errs.Internal, format, v...)
+}
@@ -0,0 +1,63 @@
+package stockapp
+
+import (
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+func parseFilter(qp QueryParams) (stockbus.QueryFilter, error) {
+	var filter stockbus.QueryFilter
+
+	if qp.MedicineID != "" {
+		id, err := uuid.Parse(qp.MedicineID)
+		if err != nil {
+			return stockbus.QueryFilter{}, validate.NewFieldsError("medicine_id", err)
+		}
+		filter.WithMedicineID(id)
+	}
+
+	if qp.LotID != "" {
+		id, err := uuid.Parse(qp.LotID)
+		if err != nil {
+			return stockbus.QueryFilter{}, validate.NewFieldsError("lot_id", err)
+		}
+		filter.WithLotID(id)
+	}
+
+	if qp.Type != "" {
+		typ, err := stockbus.ParseMovementType(qp.Type)
+		if err != nil {
+			return stockbus.QueryFilter{}, validate.NewFieldsError("type", err)
+		}
+		filter.WithType(typ)
+	}
+
+	if qp.UserID != "" {
+		id, err := uuid.Parse(qp.UserID)
+		if err != nil {
+			return stockbus.QueryFilter{}, validate.NewFieldsError("user_id", err)
+		}
+		filter.WithUserID(id)
+	}
+
+	if qp.StartCreatedDate != "" {
+		t, err := time.Parse(time.RFC3339, qp.StartCreatedDate)
+		if err != nil {
+			return stockbus.QueryFilter{}, validate.NewFieldsError("start_created_date", err)
+		}
+		filter.WithStartCreatedDate(t)
+	}
+
+	if qp.EndCreatedDate != "" {
+		t, err := time.Parse(time.RFC3339, qp.EndCreatedDate)
+		if err != nil {
+			return stockbus.QueryFilter{}, validate.NewFieldsError("end_created_date", err)
+		}
+		filter.WithEndCreatedDate(t)
+	}
+
+	return filter, nil
+}
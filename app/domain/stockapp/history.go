@@ -0,0 +1,125 @@
+package stockapp
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// HoldingParams represents the set of possible query strings for the
+// historical holdings report.
+type HoldingParams struct {
+	AsOf        string `query:"asOf"`
+	InventoryID string `query:"inventory_id"`
+	MedicineID  string `query:"medicine_id"`
+	LotID       string `query:"lot_id"`
+}
+
+// Holding represents the quantity of a lot an inventory held at the time
+// asked for.
+type Holding struct {
+	InventoryID string `json:"inventoryID"`
+	MedicineID  string `json:"medicineID"`
+	LotID       string `json:"lotID"`
+	Quantity    int    `json:"quantity"`
+}
+
+func toAppHoldings(hs []stockbus.Holding) []Holding {
+	items := make([]Holding, len(hs))
+	for i, h := range hs {
+		items[i] = Holding{
+			InventoryID: h.InventoryID.String(),
+			MedicineID:  h.MedicineID.String(),
+			LotID:       h.LotID.String(),
+			Quantity:    h.Quantity,
+		}
+	}
+
+	return items
+}
+
+// HoldingReport represents what the inventories held at a point in time.
+type HoldingReport struct {
+	AsOf     string    `json:"asOf"`
+	Holdings []Holding `json:"holdings"`
+}
+
+// parseAsOf parses the point in time of a historical query.
+func parseAsOf(asOf string) (time.Time, error) {
+	if asOf == "" {
+		return time.Time{}, validate.NewFieldsError("asOf", errors.New("not provided"))
+	}
+
+	t, err := time.Parse(time.RFC3339, asOf)
+	if err != nil {
+		return time.Time{}, validate.NewFieldsError("asOf", err)
+	}
+
+	return t, nil
+}
+
+func parseHoldingFilter(qp HoldingParams) (stockbus.HoldingFilter, error) {
+	var filter stockbus.HoldingFilter
+
+	if qp.InventoryID != "" {
+		id, err := uuid.Parse(qp.InventoryID)
+		if err != nil {
+			return stockbus.HoldingFilter{}, validate.NewFieldsError("inventory_id", err)
+		}
+		filter.WithInventoryID(id)
+	}
+
+	if qp.MedicineID != "" {
+		id, err := uuid.Parse(qp.MedicineID)
+		if err != nil {
+			return stockbus.HoldingFilter{}, validate.NewFieldsError("medicine_id", err)
+		}
+		filter.WithMedicineID(id)
+	}
+
+	if qp.LotID != "" {
+		id, err := uuid.Parse(qp.LotID)
+		if err != nil {
+			return stockbus.HoldingFilter{}, validate.NewFieldsError("lot_id", err)
+		}
+		filter.WithLotID(id)
+	}
+
+	return filter, nil
+}
+
+// =============================================================================
+
+// QueryHoldings returns the quantity of every lot the inventories held at the
+// time asked for, rebuilt from the stock ledger.
+func (c *Core) QueryHoldings(ctx context.Context, qp HoldingParams) (HoldingReport, error) {
+	asOf, err := parseAsOf(qp.AsOf)
+	if err != nil {
+		return HoldingReport{}, err
+	}
+
+	filter, err := parseHoldingFilter(qp)
+	if err != nil {
+		return HoldingReport{}, err
+	}
+
+	hs, err := c.stockBus.QueryHoldings(ctx, filter, asOf)
+	if err != nil {
+		if errors.Is(err, stockbus.ErrFutureAsOf) || errors.Is(err, stockbus.ErrBeforeHistory) {
+			return HoldingReport{}, errs.New(errs.FailedPrecondition, err)
+		}
+		return HoldingReport{}, errs.Newf(errs.Internal, "queryholdings: asOf[%s]: %s", qp.AsOf, err)
+	}
+
+	rpt := HoldingReport{
+		AsOf:     asOf.Format(time.RFC3339),
+		Holdings: toAppHoldings(hs),
+	}
+
+	return rpt, nil
+}
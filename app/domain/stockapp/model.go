@@ -0,0 +1,148 @@
+package stockapp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Page             int    `query:"page"`
+	Rows             int    `query:"rows"`
+	OrderBy          string `query:"orderBy"`
+	MedicineID       string `query:"medicine_id"`
+	LotID            string `query:"lot_id"`
+	Type             string `query:"type"`
+	UserID           string `query:"user_id"`
+	StartCreatedDate string `query:"start_created_date"`
+	EndCreatedDate   string `query:"end_created_date"`
+}
+
+// Movement represents information about an individual stock movement.
+type Movement struct {
+	ID              string `json:"id"`
+	InventoryID     string `json:"inventoryID"`
+	MedicineID      string `json:"medicineID"`
+	LotID           string `json:"lotID"`
+	LocationID      string `json:"locationID,omitempty"`
+	Type            string `json:"type"`
+	Quantity        int    `json:"quantity"`
+	Balance         int    `json:"balance"`
+	Reason          string `json:"reason"`
+	UserID          string `json:"userID"`
+	CountersignedBy string `json:"countersignedBy,omitempty"`
+	ReferenceType   string `json:"referenceType,omitempty"`
+	ReferenceID     string `json:"referenceID,omitempty"`
+	DateCreated     string `json:"dateCreated"`
+}
+
+func toAppMovement(mov stockbus.Movement) Movement {
+	app := Movement{
+		ID:          mov.ID.String(),
+		InventoryID: mov.InventoryID.String(),
+		MedicineID:  mov.MedicineID.String(),
+		LotID:       mov.LotID.String(),
+		Type:        mov.Type.Name(),
+		Quantity:    mov.Quantity,
+		Balance:     mov.Balance,
+		Reason:      mov.Reason,
+		UserID:      mov.UserID.String(),
+		DateCreated: mov.DateCreated.Format(time.RFC3339),
+	}
+
+	if mov.LocationID != uuid.Nil {
+		app.LocationID = mov.LocationID.String()
+	}
+
+	if mov.CountersignedBy != uuid.Nil {
+		app.CountersignedBy = mov.CountersignedBy.String()
+	}
+
+	if mov.Reference.ID != uuid.Nil {
+		app.ReferenceType = mov.Reference.Type.Name()
+		app.ReferenceID = mov.Reference.ID.String()
+	}
+
+	return app
+}
+
+func toAppMovements(movs []stockbus.Movement) []Movement {
+	items := make([]Movement, len(movs))
+	for i, mov := range movs {
+		items[i] = toAppMovement(mov)
+	}
+
+	return items
+}
+
+// NewMovement defines the data needed to record a new stock movement.
+// LocationID optionally names the bin the stock goes in or out of and Status
+// the bucket, such as writing off damaged stock.
+type NewMovement struct {
+	LotID      string `json:"lotID" validate:"required"`
+	LocationID string `json:"locationID"`
+	Status     string `json:"status"`
+	Type       string `json:"type" validate:"required"`
+	Quantity   int    `json:"quantity" validate:"required"`
+	Reason     string `json:"reason"`
+}
+
+func toBusNewMovement(app NewMovement, inventoryID uuid.UUID, userID uuid.UUID) (stockbus.NewMovement, error) {
+	lotID, err := uuid.Parse(app.LotID)
+	if err != nil {
+		return stockbus.NewMovement{}, fmt.Errorf("parse: %w", err)
+	}
+
+	typ, err := stockbus.ParseMovementType(app.Type)
+	if err != nil {
+		return stockbus.NewMovement{}, fmt.Errorf("parse: %w", err)
+	}
+
+	var locationID uuid.UUID
+	if app.LocationID != "" {
+		locationID, err = uuid.Parse(app.LocationID)
+		if err != nil {
+			return stockbus.NewMovement{}, fmt.Errorf("parse: %w", err)
+		}
+	}
+
+	var status inventorybus.Status
+	if app.Status != "" {
+		status, err = inventorybus.ParseStatus(app.Status)
+		if err != nil {
+			return stockbus.NewMovement{}, fmt.Errorf("parse: %w", err)
+		}
+	}
+
+	if typ == stockbus.TypeTransferOut || typ == stockbus.TypeTransferIn {
+		return stockbus.NewMovement{}, fmt.Errorf("movement type %q is recorded through transfers", typ.Name())
+	}
+
+	nm := stockbus.NewMovement{
+		InventoryID: inventoryID,
+		LotID:       lotID,
+		LocationID:  locationID,
+		Status:      status,
+		Type:        typ,
+		Quantity:    app.Quantity,
+		Reason:      app.Reason,
+		UserID:      userID,
+	}
+
+	return nm, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewMovement) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
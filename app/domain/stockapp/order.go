@@ -0,0 +1,40 @@
+package stockapp
+
+import (
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+)
+
+func parseOrder(qp QueryParams) (order.By, error) {
+	const (
+		orderByID          = "movement_id"
+		orderByMedicineID  = "medicine_id"
+		orderByType        = "type"
+		orderByQuantity    = "quantity"
+		orderByDateCreated = "date_created"
+	)
+
+	var orderByFields = map[string]string{
+		orderByID:          stockbus.OrderByID,
+		orderByMedicineID:  stockbus.OrderByMedicineID,
+		orderByType:        stockbus.OrderByType,
+		orderByQuantity:    stockbus.OrderByQuantity,
+		orderByDateCreated: stockbus.OrderByDateCreated,
+	}
+
+	orderBy, err := order.Parse(qp.OrderBy, order.NewBy(orderByDateCreated, order.ASC))
+	if err != nil {
+		return order.By{}, err
+	}
+
+	if _, exists := orderByFields[orderBy.Field]; !exists {
+		return order.By{}, validate.NewFieldsError(orderBy.Field, errors.New("order field does not exist"))
+	}
+
+	orderBy.Field = orderByFields[orderBy.Field]
+
+	return orderBy, nil
+}
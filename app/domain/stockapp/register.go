@@ -0,0 +1,145 @@
+package stockapp
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// RegisterParams represents the set of possible query strings for the stock
+// register. EndDate defaults to now.
+type RegisterParams struct {
+	MedicineID string `query:"medicine_id"`
+	StartDate  string `query:"start_date"`
+	EndDate    string `query:"end_date"`
+}
+
+// RegisterEntry represents a movement of the register along with the balance
+// held right after it.
+type RegisterEntry struct {
+	MovementID      string `json:"movementID"`
+	LotID           string `json:"lotID"`
+	Type            string `json:"type"`
+	Quantity        int    `json:"quantity"`
+	Balance         int    `json:"balance"`
+	Reason          string `json:"reason"`
+	UserID          string `json:"userID"`
+	CountersignedBy string `json:"countersignedBy,omitempty"`
+	DateCreated     string `json:"dateCreated"`
+}
+
+// Register represents the running balance of a medicine held by an
+// inventory over a period.
+type Register struct {
+	InventoryID    string          `json:"inventoryID"`
+	MedicineID     string          `json:"medicineID"`
+	Schedule       string          `json:"schedule"`
+	StartDate      string          `json:"startDate"`
+	EndDate        string          `json:"endDate"`
+	OpeningBalance int             `json:"openingBalance"`
+	ClosingBalance int             `json:"closingBalance"`
+	Entries        []RegisterEntry `json:"entries"`
+}
+
+func toAppRegister(rgs stockbus.Register) Register {
+	entries := make([]RegisterEntry, len(rgs.Entries))
+	for i, e := range rgs.Entries {
+		entries[i] = RegisterEntry{
+			MovementID:  e.MovementID.String(),
+			LotID:       e.LotID.String(),
+			Type:        e.Type.Name(),
+			Quantity:    e.Quantity,
+			Balance:     e.Balance,
+			Reason:      e.Reason,
+			UserID:      e.UserID.String(),
+			DateCreated: e.DateCreated.Format(time.RFC3339),
+		}
+
+		if e.CountersignedBy != uuid.Nil {
+			entries[i].CountersignedBy = e.CountersignedBy.String()
+		}
+	}
+
+	return Register{
+		InventoryID:    rgs.InventoryID.String(),
+		MedicineID:     rgs.MedicineID.String(),
+		Schedule:       rgs.Schedule.Name(),
+		StartDate:      rgs.StartDate.Format(time.RFC3339),
+		EndDate:        rgs.EndDate.Format(time.RFC3339),
+		OpeningBalance: rgs.OpeningBalance,
+		ClosingBalance: rgs.ClosingBalance,
+		Entries:        entries,
+	}
+}
+
+func parseRegisterFilter(rp RegisterParams, inventoryID uuid.UUID) (stockbus.RegisterFilter, error) {
+	filter := stockbus.RegisterFilter{
+		InventoryID: inventoryID,
+		EndDate:     time.Now(),
+	}
+
+	if rp.MedicineID == "" {
+		return stockbus.RegisterFilter{}, validate.NewFieldsError("medicine_id", errors.New("not provided"))
+	}
+
+	medicineID, err := uuid.Parse(rp.MedicineID)
+	if err != nil {
+		return stockbus.RegisterFilter{}, validate.NewFieldsError("medicine_id", err)
+	}
+	filter.MedicineID = medicineID
+
+	if rp.StartDate == "" {
+		return stockbus.RegisterFilter{}, validate.NewFieldsError("start_date", errors.New("not provided"))
+	}
+
+	filter.StartDate, err = time.Parse(time.RFC3339, rp.StartDate)
+	if err != nil {
+		return stockbus.RegisterFilter{}, validate.NewFieldsError("start_date", err)
+	}
+
+	if rp.EndDate != "" {
+		filter.EndDate, err = time.Parse(time.RFC3339, rp.EndDate)
+		if err != nil {
+			return stockbus.RegisterFilter{}, validate.NewFieldsError("end_date", err)
+		}
+	}
+
+	return filter, nil
+}
+
+// =============================================================================
+
+// QueryRegister returns the running balance register of a medicine held by
+// the inventory in context, for inspectors to review.
+func (c *Core) QueryRegister(ctx context.Context, rp RegisterParams) (Register, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return Register{}, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	filter, err := parseRegisterFilter(rp, inv.ID)
+	if err != nil {
+		return Register{}, err
+	}
+
+	rgs, err := c.stockBus.QueryRegister(ctx, filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, stockbus.ErrInvalidPeriod),
+			errors.Is(err, stockbus.ErrBeforeHistory):
+			return Register{}, errs.New(errs.FailedPrecondition, err)
+		case errors.Is(err, medicinebus.ErrNotFound):
+			return Register{}, errs.New(errs.NotFound, err)
+		}
+		return Register{}, errs.Newf(errs.Internal, "queryregister: inventoryID[%s] rp[%+v]: %s", inv.ID, rp, err)
+	}
+
+	return toAppRegister(rgs), nil
+}
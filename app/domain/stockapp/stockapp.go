@@ -0,0 +1,124 @@
+// Package stockapp maintains the app layer api for the stock ledger domain.
+package stockapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+)
+
+// Core manages the set of app layer api functions for the stock domain.
+type Core struct {
+	stockBus *stockbus.Core
+}
+
+// NewCore constructs a stock core API for use.
+func NewCore(stockBus *stockbus.Core) *Core {
+	return &Core{
+		stockBus: stockBus,
+	}
+}
+
+// Create records a new stock movement against the inventory in context.
+func (c *Core) Create(ctx context.Context, app NewMovement) (Movement, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return Movement{}, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Movement{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	nm, err := toBusNewMovement(app, inv.ID, userID)
+	if err != nil {
+		return Movement{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	nm.CountersignedBy = mid.GetCountersignerID(ctx)
+
+	stockBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Movement{}, errs.New(errs.Internal, err)
+	}
+
+	mov, err := stockBus.Create(ctx, nm)
+	if err != nil {
+		switch {
+		case errors.Is(err, inventorybus.ErrInsufficientStock),
+			errors.Is(err, inventorybus.ErrIncompatibleStorage),
+			errors.Is(err, locationbus.ErrInsufficientStock),
+			errors.Is(err, locationbus.ErrNotBin),
+			errors.Is(err, stockbus.ErrQuarantined),
+			errors.Is(err, stockbus.ErrUnavailable),
+			errors.Is(err, stockbus.ErrReserved),
+			errors.Is(err, stockbus.ErrCountersignRequired),
+			errors.Is(err, stockbus.ErrSelfCountersign),
+			errors.Is(err, stockbus.ErrInvalidQuantity),
+			errors.Is(err, stockbus.ErrReasonRequired):
+			return Movement{}, errs.New(errs.FailedPrecondition, err)
+		case errors.Is(err, lotbus.ErrNotFound),
+			errors.Is(err, locationbus.ErrNotFound):
+			return Movement{}, errs.New(errs.NotFound, err)
+		}
+		return Movement{}, errs.Newf(errs.Internal, "create: inventoryID[%s] nm[%+v]: %s", inv.ID, app, err)
+	}
+
+	return toAppMovement(mov), nil
+}
+
+// Query returns a list of movements for the inventory in context with paging.
+func (c *Core) Query(ctx context.Context, qp QueryParams) (page.Document[Movement], error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return page.Document[Movement]{}, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	if err := validatePaging(qp); err != nil {
+		return page.Document[Movement]{}, err
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return page.Document[Movement]{}, err
+	}
+	filter.WithInventoryID(inv.ID)
+
+	orderBy, err := parseOrder(qp)
+	if err != nil {
+		return page.Document[Movement]{}, err
+	}
+
+	movs, err := c.stockBus.Query(ctx, filter, orderBy, qp.Page, qp.Rows)
+	if err != nil {
+		return page.Document[Movement]{}, errs.Newf(errs.Internal, "query: %s", err)
+	}
+
+	total, err := c.stockBus.Count(ctx, filter)
+	if err != nil {
+		return page.Document[Movement]{}, errs.Newf(errs.Internal, "count: %s", err)
+	}
+
+	return page.NewDocument(toAppMovements(movs), total, qp.Page, qp.Rows), nil
+}
+
+// executeUnderTransaction returns a stock core bound to the transaction the
+// transaction middleware placed in the context. Movements must never be
+// applied outside of a transaction.
+func (c *Core) executeUnderTransaction(ctx context.Context) (*stockbus.Core, error) {
+	tx, ok := transaction.Get(ctx)
+	if !ok {
+		return nil, errors.New("transaction missing in context")
+	}
+
+	return c.stockBus.ExecuteUnderTransaction(tx)
+}
@@ -0,0 +1,37 @@
+package stocktakeapp
+
+import (
+	"github.com/EnesDemirtas/medisync/business/domain/stocktakebus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+func parseFilter(qp QueryParams) (stocktakebus.QueryFilter, error) {
+	var filter stocktakebus.QueryFilter
+
+	if qp.ID != "" {
+		id, err := uuid.Parse(qp.ID)
+		if err != nil {
+			return stocktakebus.QueryFilter{}, validate.NewFieldsError("stocktake_id", err)
+		}
+		filter.WithStocktakeID(id)
+	}
+
+	if qp.InventoryID != "" {
+		id, err := uuid.Parse(qp.InventoryID)
+		if err != nil {
+			return stocktakebus.QueryFilter{}, validate.NewFieldsError("inventory_id", err)
+		}
+		filter.WithInventoryID(id)
+	}
+
+	if qp.Status != "" {
+		status, err := stocktakebus.ParseStatus(qp.Status)
+		if err != nil {
+			return stocktakebus.QueryFilter{}, validate.NewFieldsError("status", err)
+		}
+		filter.WithStatus(status)
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,218 @@
+package stocktakeapp
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/business/domain/stocktakebus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Page        int    `query:"page"`
+	Rows        int    `query:"rows"`
+	OrderBy     string `query:"orderBy"`
+	ID          string `query:"stocktake_id"`
+	InventoryID string `query:"inventory_id"`
+	Status      string `query:"status"`
+}
+
+// Line represents the expected and counted quantity of a lot. Devices is the
+// number of devices the lot was counted from.
+type Line struct {
+	MedicineID string `json:"medicineID"`
+	LotID      string `json:"lotID"`
+	Expected   int    `json:"expected"`
+	Counted    int    `json:"counted"`
+	Devices    int    `json:"devices"`
+}
+
+// Stocktake represents information about an individual stocktake.
+type Stocktake struct {
+	ID           string `json:"id"`
+	InventoryID  string `json:"inventoryID"`
+	Status       string `json:"status"`
+	Note         string `json:"note"`
+	AllStatuses  bool   `json:"allStatuses"`
+	Lines        []Line `json:"lines"`
+	OpenedBy     string `json:"openedBy"`
+	ApprovedBy   string `json:"approvedBy,omitempty"`
+	DateApproved string `json:"dateApproved,omitempty"`
+	DateCreated  string `json:"dateCreated"`
+	DateUpdated  string `json:"dateUpdated"`
+}
+
+func toAppStocktake(stk stocktakebus.Stocktake) Stocktake {
+	lines := make([]Line, len(stk.Lines))
+	for i, line := range stk.Lines {
+		lines[i] = Line{
+			MedicineID: line.MedicineID.String(),
+			LotID:      line.LotID.String(),
+			Expected:   line.Expected,
+			Counted:    line.Counted,
+			Devices:    line.Devices,
+		}
+	}
+
+	app := Stocktake{
+		ID:          stk.ID.String(),
+		InventoryID: stk.InventoryID.String(),
+		Status:      stk.Status.Name(),
+		Note:        stk.Note,
+		AllStatuses: stk.AllStatuses,
+		Lines:       lines,
+		OpenedBy:    stk.OpenedBy.String(),
+		DateCreated: stk.DateCreated.Format(time.RFC3339),
+		DateUpdated: stk.DateUpdated.Format(time.RFC3339),
+	}
+
+	if stk.ApprovedBy != uuid.Nil {
+		app.ApprovedBy = stk.ApprovedBy.String()
+		app.DateApproved = stk.DateApproved.Format(time.RFC3339)
+	}
+
+	return app
+}
+
+func toAppStocktakes(stks []stocktakebus.Stocktake) []Stocktake {
+	items := make([]Stocktake, len(stks))
+	for i, stk := range stks {
+		items[i] = toAppStocktake(stk)
+	}
+
+	return items
+}
+
+// VarianceLine represents how far the count of a lot is from the expected
+// quantity. Percent is left out when nothing was expected.
+type VarianceLine struct {
+	MedicineID string   `json:"medicineID"`
+	LotID      string   `json:"lotID"`
+	Expected   int      `json:"expected"`
+	Counted    int      `json:"counted"`
+	Variance   int      `json:"variance"`
+	Percent    *float64 `json:"percent,omitempty"`
+	Uncounted  bool     `json:"uncounted"`
+}
+
+// VarianceReport represents the expected against the counted quantities of
+// a stocktake, line by line and in total.
+type VarianceReport struct {
+	StocktakeID   string         `json:"stocktakeID"`
+	InventoryID   string         `json:"inventoryID"`
+	Status        string         `json:"status"`
+	Lines         []VarianceLine `json:"lines"`
+	TotalExpected int            `json:"totalExpected"`
+	TotalCounted  int            `json:"totalCounted"`
+	TotalVariance int            `json:"totalVariance"`
+	TotalPercent  *float64       `json:"totalPercent,omitempty"`
+}
+
+func toAppVarianceReport(stk stocktakebus.Stocktake) VarianceReport {
+	var total stocktakebus.Line
+
+	lines := make([]VarianceLine, len(stk.Lines))
+	for i, line := range stk.Lines {
+		lines[i] = VarianceLine{
+			MedicineID: line.MedicineID.String(),
+			LotID:      line.LotID.String(),
+			Expected:   line.Expected,
+			Counted:    line.Counted,
+			Variance:   line.Variance(),
+			Percent:    toAppPercent(line),
+			Uncounted:  line.Uncounted(),
+		}
+
+		total.Expected += line.Expected
+		total.Counted += line.Counted
+	}
+
+	return VarianceReport{
+		StocktakeID:   stk.ID.String(),
+		InventoryID:   stk.InventoryID.String(),
+		Status:        stk.Status.Name(),
+		Lines:         lines,
+		TotalExpected: total.Expected,
+		TotalCounted:  total.Counted,
+		TotalVariance: total.Variance(),
+		TotalPercent:  toAppPercent(total),
+	}
+}
+
+// toAppPercent returns the variance percent of the line rounded to two
+// decimals, or nil when it is undefined.
+func toAppPercent(line stocktakebus.Line) *float64 {
+	pct, ok := line.VariancePercent()
+	if !ok {
+		return nil
+	}
+
+	pct = math.Round(pct*100) / 100
+
+	return &pct
+}
+
+// NewStocktake defines the data needed to open a stocktake for the inventory
+// in the path. Only available stock is counted unless AllStatuses is set.
+type NewStocktake struct {
+	Note        string `json:"note"`
+	AllStatuses bool   `json:"allStatuses"`
+}
+
+func toBusNewStocktake(app NewStocktake, inventoryID uuid.UUID, userID uuid.UUID) stocktakebus.NewStocktake {
+	return stocktakebus.NewStocktake{
+		InventoryID: inventoryID,
+		Note:        app.Note,
+		AllStatuses: app.AllStatuses,
+		UserID:      userID,
+	}
+}
+
+// CountLine defines the quantity of a lot counted by a device.
+type CountLine struct {
+	LotID    string `json:"lotID" validate:"required"`
+	Quantity int    `json:"quantity" validate:"min=0"`
+}
+
+// Counting defines the quantities counted by a single device. Submitting
+// again from the same device replaces its earlier counts of the same lots.
+type Counting struct {
+	DeviceID string      `json:"deviceID" validate:"required"`
+	Lines    []CountLine `json:"lines" validate:"required,min=1,dive"`
+}
+
+func toBusCounting(app Counting, userID uuid.UUID) (stocktakebus.Counting, error) {
+	lines := make([]stocktakebus.CountLine, len(app.Lines))
+	for i, line := range app.Lines {
+		lotID, err := uuid.Parse(line.LotID)
+		if err != nil {
+			return stocktakebus.Counting{}, fmt.Errorf("parse: %w", err)
+		}
+
+		lines[i] = stocktakebus.CountLine{
+			LotID:    lotID,
+			Quantity: line.Quantity,
+		}
+	}
+
+	counting := stocktakebus.Counting{
+		DeviceID: app.DeviceID,
+		Lines:    lines,
+		UserID:   userID,
+	}
+
+	return counting, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app Counting) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,217 @@
+// Package stocktakeapp maintains the app layer api for the stocktake domain.
+package stocktakeapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stocktakebus"
+)
+
+// Core manages the set of app layer api functions for the stocktake domain.
+type Core struct {
+	stocktakeBus *stocktakebus.Core
+}
+
+// NewCore constructs a stocktake core API for use.
+func NewCore(stocktakeBus *stocktakebus.Core) *Core {
+	return &Core{
+		stocktakeBus: stocktakeBus,
+	}
+}
+
+// Open starts a stocktake of the inventory in context.
+func (c *Core) Open(ctx context.Context, app NewStocktake) (Stocktake, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return Stocktake{}, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Stocktake{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	stocktakeBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Stocktake{}, errs.New(errs.Internal, err)
+	}
+
+	stk, err := stocktakeBus.Open(ctx, toBusNewStocktake(app, inv.ID, userID))
+	if err != nil {
+		return Stocktake{}, toAppError(err, "open: inventoryID[%s]: %s", inv.ID, err)
+	}
+
+	return toAppStocktake(stk), nil
+}
+
+// SubmitCounts records the quantities counted by a device for the stocktake
+// in context.
+func (c *Core) SubmitCounts(ctx context.Context, app Counting) (Stocktake, error) {
+	stk, err := mid.GetStocktake(ctx)
+	if err != nil {
+		return Stocktake{}, errs.Newf(errs.Internal, "stocktake missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Stocktake{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	counting, err := toBusCounting(app, userID)
+	if err != nil {
+		return Stocktake{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	stocktakeBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Stocktake{}, errs.New(errs.Internal, err)
+	}
+
+	updStk, err := stocktakeBus.SubmitCounts(ctx, stk.ID, counting)
+	if err != nil {
+		return Stocktake{}, toAppError(err, "submitcounts: stocktakeID[%s] counting[%+v]: %s", stk.ID, app, err)
+	}
+
+	return toAppStocktake(updStk), nil
+}
+
+// Approve closes the stocktake in context and posts its variances to the
+// inventory.
+func (c *Core) Approve(ctx context.Context) (Stocktake, error) {
+	stk, err := mid.GetStocktake(ctx)
+	if err != nil {
+		return Stocktake{}, errs.Newf(errs.Internal, "stocktake missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Stocktake{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	stocktakeBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Stocktake{}, errs.New(errs.Internal, err)
+	}
+
+	updStk, err := stocktakeBus.Approve(ctx, stk.ID, userID, mid.GetCountersignerID(ctx))
+	if err != nil {
+		return Stocktake{}, toAppError(err, "approve: stocktakeID[%s]: %s", stk.ID, err)
+	}
+
+	return toAppStocktake(updStk), nil
+}
+
+// Cancel discards the stocktake in context.
+func (c *Core) Cancel(ctx context.Context) (Stocktake, error) {
+	stk, err := mid.GetStocktake(ctx)
+	if err != nil {
+		return Stocktake{}, errs.Newf(errs.Internal, "stocktake missing in context: %s", err)
+	}
+
+	stocktakeBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Stocktake{}, errs.New(errs.Internal, err)
+	}
+
+	updStk, err := stocktakeBus.Cancel(ctx, stk.ID)
+	if err != nil {
+		return Stocktake{}, toAppError(err, "cancel: stocktakeID[%s]: %s", stk.ID, err)
+	}
+
+	return toAppStocktake(updStk), nil
+}
+
+// Query returns a list of stocktakes with paging.
+func (c *Core) Query(ctx context.Context, qp QueryParams) (page.Document[Stocktake], error) {
+	if err := validatePaging(qp); err != nil {
+		return page.Document[Stocktake]{}, err
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return page.Document[Stocktake]{}, err
+	}
+
+	orderBy, err := parseOrder(qp)
+	if err != nil {
+		return page.Document[Stocktake]{}, err
+	}
+
+	stks, err := c.stocktakeBus.Query(ctx, filter, orderBy, qp.Page, qp.Rows)
+	if err != nil {
+		return page.Document[Stocktake]{}, errs.Newf(errs.Internal, "query: %s", err)
+	}
+
+	total, err := c.stocktakeBus.Count(ctx, filter)
+	if err != nil {
+		return page.Document[Stocktake]{}, errs.Newf(errs.Internal, "count: %s", err)
+	}
+
+	return page.NewDocument(toAppStocktakes(stks), total, qp.Page, qp.Rows), nil
+}
+
+// QueryByID returns a stocktake by its ID.
+func (c *Core) QueryByID(ctx context.Context) (Stocktake, error) {
+	stk, err := mid.GetStocktake(ctx)
+	if err != nil {
+		return Stocktake{}, errs.Newf(errs.Internal, "querybyid: %s", err)
+	}
+
+	return toAppStocktake(stk), nil
+}
+
+// QueryVariances returns the variance report of the stocktake in context.
+func (c *Core) QueryVariances(ctx context.Context) (VarianceReport, error) {
+	stk, err := mid.GetStocktake(ctx)
+	if err != nil {
+		return VarianceReport{}, errs.Newf(errs.Internal, "stocktake missing in context: %s", err)
+	}
+
+	return toAppVarianceReport(stk), nil
+}
+
+// executeUnderTransaction returns a stocktake core bound to the transaction
+// the transaction middleware placed in the context.
+func (c *Core) executeUnderTransaction(ctx context.Context) (*stocktakebus.Core, error) {
+	tx, ok := transaction.Get(ctx)
+	if !ok {
+		return nil, errors.New("transaction missing in context")
+	}
+
+	return c.stocktakeBus.ExecuteUnderTransaction(tx)
+}
+
+// toAppError maps the business errors a stocktake can fail with to the
+// matching app error.
+func toAppError(err error, format string, v ...any) error {
+	switch {
+	case errors.Is(err, stocktakebus.ErrAlreadyOpen),
+		errors.Is(err, stocktakebus.ErrInvalidTransition),
+		errors.Is(err, stocktakebus.ErrDeviceRequired),
+		errors.Is(err, stocktakebus.ErrNoCountLines),
+		errors.Is(err, stocktakebus.ErrInvalidQuantity),
+		errors.Is(err, stocktakebus.ErrDuplicateLot),
+		errors.Is(err, stocktakebus.ErrUncounted),
+		errors.Is(err, inventorybus.ErrInsufficientStock),
+		errors.Is(err, inventorybus.ErrIncompatibleStorage),
+		errors.Is(err, stockbus.ErrCountersignRequired),
+		errors.Is(err, stockbus.ErrSelfCountersign),
+		errors.Is(err, stockbus.ErrInvalidQuantity):
+		return errs.New(errs.FailedPrecondition, err)
+
+	case errors.Is(err, stocktakebus.ErrNotFound),
+		errors.Is(err, inventorybus.ErrNotFound),
+		errors.Is(err, lotbus.ErrNotFound):
+		return errs.New(errs.NotFound, err)
+	}
+
+	return errs.Newf(errs.Internal, format, v...)
+}
@@ -0,0 +1,25 @@
+package supplierapp
+
+import (
+	"github.com/EnesDemirtas/medisync/business/domain/supplierbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+func parseFilter(qp QueryParams) (supplierbus.QueryFilter, error) {
+	var filter supplierbus.QueryFilter
+
+	if qp.ID != "" {
+		id, err := uuid.Parse(qp.ID)
+		if err != nil {
+			return supplierbus.QueryFilter{}, validate.NewFieldsError("supplier_id", err)
+		}
+		filter.WithSupplierID(id)
+	}
+
+	if qp.Name != "" {
+		filter.WithName(qp.Name)
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,110 @@
+package supplierapp
+
+import (
+	"time"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/business/domain/supplierbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Page    int    `query:"page"`
+	Rows    int    `query:"rows"`
+	OrderBy string `query:"orderBy"`
+	ID      string `query:"supplier_id"`
+	Name    string `query:"name"`
+}
+
+// Supplier represents information about an individual supplier.
+type Supplier struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	Phone       string `json:"phone"`
+	Address     string `json:"address"`
+	Version     int    `json:"version"`
+	DateCreated string `json:"dateCreated"`
+	DateUpdated string `json:"dateUpdated"`
+}
+
+// ETag implements the web.ETagger interface.
+func (app Supplier) ETag() string {
+	return mid.ETag(app.Version)
+}
+
+func toAppSupplier(sup supplierbus.Supplier) Supplier {
+	return Supplier{
+		ID:          sup.ID.String(),
+		Name:        sup.Name,
+		Email:       sup.Email,
+		Phone:       sup.Phone,
+		Address:     sup.Address,
+		Version:     sup.Version,
+		DateCreated: sup.DateCreated.Format(time.RFC3339),
+		DateUpdated: sup.DateUpdated.Format(time.RFC3339),
+	}
+}
+
+func toAppSuppliers(sups []supplierbus.Supplier) []Supplier {
+	items := make([]Supplier, len(sups))
+	for i, sup := range sups {
+		items[i] = toAppSupplier(sup)
+	}
+
+	return items
+}
+
+// NewSupplier defines the data needed to add a new supplier.
+type NewSupplier struct {
+	Name    string `json:"name" validate:"required"`
+	Email   string `json:"email" validate:"omitempty,email"`
+	Phone   string `json:"phone"`
+	Address string `json:"address"`
+}
+
+func toBusNewSupplier(app NewSupplier) supplierbus.NewSupplier {
+	return supplierbus.NewSupplier{
+		Name:    app.Name,
+		Email:   app.Email,
+		Phone:   app.Phone,
+		Address: app.Address,
+	}
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewSupplier) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// UpdateSupplier defines the data needed to update a supplier.
+type UpdateSupplier struct {
+	Name    *string `json:"name" validate:"omitempty,min=1"`
+	Email   *string `json:"email" validate:"omitempty,email"`
+	Phone   *string `json:"phone"`
+	Address *string `json:"address"`
+}
+
+func toBusUpdateSupplier(app UpdateSupplier) supplierbus.UpdateSupplier {
+	return supplierbus.UpdateSupplier{
+		Name:    app.Name,
+		Email:   app.Email,
+		Phone:   app.Phone,
+		Address: app.Address,
+	}
+}
+
+// Validate checks the data in the model is considered clean.
+func (app UpdateSupplier) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+// Package supplierapp maintains the app layer api for the supplier domain.
+package supplierapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/business/domain/supplierbus"
+)
+
+// Core manages the set of app layer api functions for the supplier domain.
+type Core struct {
+	supplierBus *supplierbus.Core
+}
+
+// NewCore constructs a supplier core API for use.
+func NewCore(supplierBus *supplierbus.Core) *Core {
+	return &Core{
+		supplierBus: supplierBus,
+	}
+}
+
+// Create adds a new supplier to the system.
+func (c *Core) Create(ctx context.Context, app NewSupplier) (Supplier, error) {
+	sup, err := c.supplierBus.Create(ctx, toBusNewSupplier(app))
+	if err != nil {
+		if errors.Is(err, supplierbus.ErrUniqueName) {
+			return Supplier{}, errs.New(errs.FailedPrecondition, supplierbus.ErrUniqueName)
+		}
+		return Supplier{}, errs.Newf(errs.Internal, "create: supplier[%+v]: %s", app, err)
+	}
+
+	return toAppSupplier(sup), nil
+}
+
+// Update updates an existing supplier.
+func (c *Core) Update(ctx context.Context, app UpdateSupplier) (Supplier, error) {
+	sup, err := mid.GetSupplier(ctx)
+	if err != nil {
+		return Supplier{}, errs.Newf(errs.Internal, "supplier missing in context: %s", err)
+	}
+
+	if err := mid.CheckIfMatch(ctx, sup.Version); err != nil {
+		return Supplier{}, err
+	}
+
+	updSup, err := c.supplierBus.Update(ctx, sup, toBusUpdateSupplier(app))
+	if err != nil {
+		switch {
+		case errors.Is(err, supplierbus.ErrVersionConflict):
+			return Supplier{}, errs.New(errs.PreconditionFailed, err)
+		case errors.Is(err, supplierbus.ErrUniqueName):
+			return Supplier{}, errs.New(errs.FailedPrecondition, supplierbus.ErrUniqueName)
+		}
+		return Supplier{}, errs.Newf(errs.Internal, "update: supplierID[%s] up[%+v]: %s", sup.ID, app, err)
+	}
+
+	return toAppSupplier(updSup), nil
+}
+
+// Delete removes a supplier from the system.
+func (c *Core) Delete(ctx context.Context) error {
+	sup, err := mid.GetSupplier(ctx)
+	if err != nil {
+		return errs.Newf(errs.Internal, "supplierID missing in context: %s", err)
+	}
+
+	if err := c.supplierBus.Delete(ctx, sup); err != nil {
+		if errors.Is(err, supplierbus.ErrInUse) {
+			return errs.New(errs.FailedPrecondition, supplierbus.ErrInUse)
+		}
+		return errs.Newf(errs.Internal, "delete: supplierID[%s]: %s", sup.ID, err)
+	}
+
+	return nil
+}
+
+// Query returns a list of suppliers with paging.
+func (c *Core) Query(ctx context.Context, qp QueryParams) (page.Document[Supplier], error) {
+	if err := validatePaging(qp); err != nil {
+		return page.Document[Supplier]{}, err
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return page.Document[Supplier]{}, err
+	}
+
+	orderBy, err := parseOrder(qp)
+	if err != nil {
+		return page.Document[Supplier]{}, err
+	}
+
+	sups, err := c.supplierBus.Query(ctx, filter, orderBy, qp.Page, qp.Rows)
+	if err != nil {
+		return page.Document[Supplier]{}, errs.Newf(errs.Internal, "query: %s", err)
+	}
+
+	total, err := c.supplierBus.Count(ctx, filter)
+	if err != nil {
+		return page.Document[Supplier]{}, errs.Newf(errs.Internal, "count: %s", err)
+	}
+
+	return page.NewDocument(toAppSuppliers(sups), total, qp.Page, qp.Rows), nil
+}
+
+// QueryByID returns a supplier by its ID.
+func (c *Core) QueryByID(ctx context.Context) (Supplier, error) {
+	sup, err := mid.GetSupplier(ctx)
+	if err != nil {
+		return Supplier{}, errs.Newf(errs.Internal, "querybyid: %s", err)
+	}
+
+	return toAppSupplier(sup), nil
+}
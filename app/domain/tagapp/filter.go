@@ -1,7 +1,7 @@
 package tagapp
 
 import (
-	"github.com/EnesDemirtas/medisync/business/core/crud/tagbus"
+	"github.com/EnesDemirtas/medisync/business/domain/tagbus"
 	"github.com/EnesDemirtas/medisync/foundation/validate"
 	"github.com/google/uuid"
 )
@@ -2,7 +2,8 @@ package tagapp
 
 import (
 	"github.com/EnesDemirtas/medisync/app/api/errs"
-	"github.com/EnesDemirtas/medisync/business/core/crud/tagbus"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/business/domain/tagbus"
 	"github.com/EnesDemirtas/medisync/foundation/validate"
 )
 
@@ -15,19 +16,31 @@ type QueryParams struct {
 	Name     string `query:"name"`
 }
 
+// DeleteParams represents the set of possible query strings of a delete.
+type DeleteParams struct {
+	Cascade string `query:"cascade"`
+}
+
 // Tag represents information about an individual tag.
 type Tag struct {
 	ID          string  `json:"id"`
 	Name        string  `json:"name"`
+	Version     int     `json:"version"`
 }
 
 func toAppTag(tag tagbus.Tag) Tag {
 	return Tag{
 		ID:          tag.ID.String(),
 		Name:        tag.Name,
+		Version:     tag.Version,
 	}
 }
 
+// ETag implements the web.ETagger interface.
+func (app Tag) ETag() string {
+	return mid.ETag(app.Version)
+}
+
 func toAppTags(tags []tagbus.Tag) []Tag {
 	items := make([]Tag, len(tags))
 	for i, tag := range tags {
@@ -4,7 +4,7 @@ import (
 	"errors"
 
 	"github.com/EnesDemirtas/medisync/business/api/order"
-	"github.com/EnesDemirtas/medisync/business/core/crud/tagbus"
+	"github.com/EnesDemirtas/medisync/business/domain/tagbus"
 	"github.com/EnesDemirtas/medisync/foundation/validate"
 )
 
@@ -3,11 +3,14 @@ package tagapp
 
 import (
 	"context"
+	"errors"
+	"strconv"
 
 	"github.com/EnesDemirtas/medisync/app/api/errs"
 	"github.com/EnesDemirtas/medisync/app/api/mid"
 	"github.com/EnesDemirtas/medisync/app/api/page"
-	"github.com/EnesDemirtas/medisync/business/core/crud/tagbus"
+	"github.com/EnesDemirtas/medisync/business/domain/tagbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
 )
 
 // Core manages the set of app layer api functions for the tag domain.
@@ -31,7 +34,7 @@ func (c *Core) Create(ctx context.Context, app NewTag) (Tag, error) {
 
 	tag, err := c.tagBus.Create(ctx, nt)
 	if err != nil {
-		return Tag{}, errs.Newf(errs.Internal, "create: prd[%+v]: %s", tag, err)
+		return Tag{}, errs.Newf(errs.Internal, "create: tag[%+v]: %s", app, err)
 	}
 
 	return toAppTag(tag), nil
@@ -44,22 +47,42 @@ func (c *Core) Update(ctx context.Context, app UpdateTag) (Tag, error) {
 		return Tag{}, errs.Newf(errs.Internal, "tag missing in context: %s", err)
 	}
 
+	if err := mid.CheckIfMatch(ctx, tag.Version); err != nil {
+		return Tag{}, err
+	}
+
 	updTag, err := c.tagBus.Update(ctx, tag, toBusUpdateTag(app))
 	if err != nil {
+		if errors.Is(err, tagbus.ErrVersionConflict) {
+			return Tag{}, errs.New(errs.PreconditionFailed, err)
+		}
 		return Tag{}, errs.Newf(errs.Internal, "update: tagID[%s] up[%+v]: %s", tag.ID, app, err)
 	}
 
 	return toAppTag(updTag), nil
 }
 
-// Delete removes a tag from the system.
-func (c *Core) Delete(ctx context.Context) error {
+// Delete removes a tag from the system. A tag in use is only removed when
+// cascade is requested.
+func (c *Core) Delete(ctx context.Context, dp DeleteParams) error {
+	var cascade bool
+	if dp.Cascade != "" {
+		var err error
+		cascade, err = strconv.ParseBool(dp.Cascade)
+		if err != nil {
+			return validate.NewFieldsError("cascade", err)
+		}
+	}
+
 	tag, err := mid.GetTag(ctx)
 	if err != nil {
 		return errs.Newf(errs.Internal, "tagID missing in context: %s", err)
 	}
 
-	if err := c.tagBus.Delete(ctx, tag); err != nil {
+	if err := c.tagBus.Delete(ctx, tag, cascade); err != nil {
+		if errors.Is(err, tagbus.ErrInUse) {
+			return errs.New(errs.FailedPrecondition, tagbus.ErrInUse)
+		}
 		return errs.Newf(errs.Internal, "delete: tagID[%s]: %s", tag.ID, err)
 	}
 
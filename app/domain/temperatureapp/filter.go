@@ -0,0 +1,89 @@
+package temperatureapp
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/temperaturebus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+func parseFilter(qp QueryParams) (temperaturebus.QueryFilter, error) {
+	var filter temperaturebus.QueryFilter
+
+	if qp.ID != "" {
+		id, err := uuid.Parse(qp.ID)
+		if err != nil {
+			return temperaturebus.QueryFilter{}, validate.NewFieldsError("excursion_id", err)
+		}
+		filter.WithExcursionID(id)
+	}
+
+	if qp.InventoryID != "" {
+		id, err := uuid.Parse(qp.InventoryID)
+		if err != nil {
+			return temperaturebus.QueryFilter{}, validate.NewFieldsError("inventory_id", err)
+		}
+		filter.WithInventoryID(id)
+	}
+
+	if qp.Ongoing != "" {
+		ongoing, err := strconv.ParseBool(qp.Ongoing)
+		if err != nil {
+			return temperaturebus.QueryFilter{}, validate.NewFieldsError("ongoing", err)
+		}
+		filter.WithOngoing(ongoing)
+	}
+
+	if qp.StartStartedDate != "" {
+		t, err := time.Parse(time.RFC3339, qp.StartStartedDate)
+		if err != nil {
+			return temperaturebus.QueryFilter{}, validate.NewFieldsError("start_started_date", err)
+		}
+		filter.WithStartStartedDate(t)
+	}
+
+	if qp.EndStartedDate != "" {
+		t, err := time.Parse(time.RFC3339, qp.EndStartedDate)
+		if err != nil {
+			return temperaturebus.QueryFilter{}, validate.NewFieldsError("end_started_date", err)
+		}
+		filter.WithEndStartedDate(t)
+	}
+
+	return filter, nil
+}
+
+func parseReadingFilter(rp ReadingParams, inventoryID uuid.UUID) (temperaturebus.ReadingFilter, error) {
+	filter := temperaturebus.ReadingFilter{
+		InventoryID: inventoryID,
+	}
+
+	if rp.StartDate == "" {
+		return temperaturebus.ReadingFilter{}, validate.NewFieldsError("start_date", errNotProvided)
+	}
+
+	start, err := time.Parse(time.RFC3339, rp.StartDate)
+	if err != nil {
+		return temperaturebus.ReadingFilter{}, validate.NewFieldsError("start_date", err)
+	}
+	filter.StartDate = start
+
+	if rp.EndDate == "" {
+		return temperaturebus.ReadingFilter{}, validate.NewFieldsError("end_date", errNotProvided)
+	}
+
+	end, err := time.Parse(time.RFC3339, rp.EndDate)
+	if err != nil {
+		return temperaturebus.ReadingFilter{}, validate.NewFieldsError("end_date", err)
+	}
+	filter.EndDate = end
+
+	if end.Before(start) {
+		return temperaturebus.ReadingFilter{}, validate.NewFieldsError("end_date", errors.New("before start_date"))
+	}
+
+	return filter, nil
+}
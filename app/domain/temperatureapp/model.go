@@ -0,0 +1,210 @@
+package temperatureapp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/business/domain/temperaturebus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Page             int    `query:"page"`
+	Rows             int    `query:"rows"`
+	OrderBy          string `query:"orderBy"`
+	ID               string `query:"excursion_id"`
+	InventoryID      string `query:"inventory_id"`
+	Ongoing          string `query:"ongoing"`
+	StartStartedDate string `query:"start_started_date"`
+	EndStartedDate   string `query:"end_started_date"`
+}
+
+// ReadingParams represents the set of possible query strings for the
+// readings of an inventory. The period is required.
+type ReadingParams struct {
+	StartDate string `query:"start_date"`
+	EndDate   string `query:"end_date"`
+}
+
+// Range represents the temperatures an inventory has to be kept within.
+type Range struct {
+	InventoryID string  `json:"inventoryID"`
+	MinCelsius  float64 `json:"minCelsius"`
+	MaxCelsius  float64 `json:"maxCelsius"`
+	DateCreated string  `json:"dateCreated"`
+	DateUpdated string  `json:"dateUpdated"`
+}
+
+func toAppRange(rng temperaturebus.Range) Range {
+	return Range{
+		InventoryID: rng.InventoryID.String(),
+		MinCelsius:  rng.MinCelsius,
+		MaxCelsius:  rng.MaxCelsius,
+		DateCreated: rng.DateCreated.Format(time.RFC3339),
+		DateUpdated: rng.DateUpdated.Format(time.RFC3339),
+	}
+}
+
+// Reading represents a temperature recorded in an inventory.
+type Reading struct {
+	ID           string  `json:"id"`
+	InventoryID  string  `json:"inventoryID"`
+	Celsius      float64 `json:"celsius"`
+	DateRecorded string  `json:"dateRecorded"`
+}
+
+func toAppReadings(rds []temperaturebus.Reading) []Reading {
+	items := make([]Reading, len(rds))
+	for i, rd := range rds {
+		items[i] = Reading{
+			ID:           rd.ID.String(),
+			InventoryID:  rd.InventoryID.String(),
+			Celsius:      rd.Celsius,
+			DateRecorded: rd.DateRecorded.Format(time.RFC3339),
+		}
+	}
+
+	return items
+}
+
+// Stock represents stock of a lot quarantined because of an excursion.
+type Stock struct {
+	MedicineID string `json:"medicineID"`
+	LotID      string `json:"lotID"`
+	Quantity   int    `json:"quantity"`
+}
+
+// Excursion represents information about an individual excursion. DateEnded
+// is left out while the excursion is ongoing.
+type Excursion struct {
+	ID          string  `json:"id"`
+	InventoryID string  `json:"inventoryID"`
+	MinCelsius  float64 `json:"minCelsius"`
+	MaxCelsius  float64 `json:"maxCelsius"`
+	PeakCelsius float64 `json:"peakCelsius"`
+	DatePeak    string  `json:"datePeak"`
+	DateStarted string  `json:"dateStarted"`
+	DateEnded   string  `json:"dateEnded,omitempty"`
+	Stock       []Stock `json:"stock"`
+	DateCreated string  `json:"dateCreated"`
+	DateUpdated string  `json:"dateUpdated"`
+}
+
+func toAppExcursion(exc temperaturebus.Excursion) Excursion {
+	stock := make([]Stock, len(exc.Stock))
+	for i, s := range exc.Stock {
+		stock[i] = Stock{
+			MedicineID: s.MedicineID.String(),
+			LotID:      s.LotID.String(),
+			Quantity:   s.Quantity,
+		}
+	}
+
+	app := Excursion{
+		ID:          exc.ID.String(),
+		InventoryID: exc.InventoryID.String(),
+		MinCelsius:  exc.MinCelsius,
+		MaxCelsius:  exc.MaxCelsius,
+		PeakCelsius: exc.PeakCelsius,
+		DatePeak:    exc.DatePeak.Format(time.RFC3339),
+		DateStarted: exc.DateStarted.Format(time.RFC3339),
+		Stock:       stock,
+		DateCreated: exc.DateCreated.Format(time.RFC3339),
+		DateUpdated: exc.DateUpdated.Format(time.RFC3339),
+	}
+
+	if !exc.Ongoing() {
+		app.DateEnded = exc.DateEnded.Format(time.RFC3339)
+	}
+
+	return app
+}
+
+func toAppExcursions(excs []temperaturebus.Excursion) []Excursion {
+	items := make([]Excursion, len(excs))
+	for i, exc := range excs {
+		items[i] = toAppExcursion(exc)
+	}
+
+	return items
+}
+
+// Ingestion represents the outcome of storing a batch of readings.
+type Ingestion struct {
+	InventoryID string      `json:"inventoryID"`
+	Readings    int         `json:"readings"`
+	Excursions  []Excursion `json:"excursions"`
+}
+
+func toAppIngestion(ing temperaturebus.Ingestion) Ingestion {
+	return Ingestion{
+		InventoryID: ing.InventoryID.String(),
+		Readings:    ing.Readings,
+		Excursions:  toAppExcursions(ing.Excursions),
+	}
+}
+
+// NewRange defines the temperatures the inventory in the path has to be kept
+// within. Both bounds are inclusive.
+type NewRange struct {
+	MinCelsius *float64 `json:"minCelsius" validate:"required"`
+	MaxCelsius *float64 `json:"maxCelsius" validate:"required"`
+}
+
+func toBusNewRange(app NewRange, inventoryID uuid.UUID) temperaturebus.NewRange {
+	return temperaturebus.NewRange{
+		InventoryID: inventoryID,
+		MinCelsius:  *app.MinCelsius,
+		MaxCelsius:  *app.MaxCelsius,
+	}
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewRange) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// NewReading defines a single temperature recorded by a data logger.
+type NewReading struct {
+	Celsius    *float64 `json:"celsius" validate:"required"`
+	RecordedAt string   `json:"recordedAt" validate:"required"`
+}
+
+// NewIngestion defines a batch of readings taken in the inventory in the
+// path.
+type NewIngestion struct {
+	Readings []NewReading `json:"readings" validate:"required,min=1,dive"`
+}
+
+func toBusNewReadings(app []NewReading) ([]temperaturebus.NewReading, error) {
+	nrs := make([]temperaturebus.NewReading, len(app))
+	for i, nr := range app {
+		recorded, err := time.Parse(time.RFC3339, nr.RecordedAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse: %w", err)
+		}
+
+		nrs[i] = temperaturebus.NewReading{
+			Celsius:      *nr.Celsius,
+			DateRecorded: recorded,
+		}
+	}
+
+	return nrs, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewIngestion) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package temperatureapp
+
+import (
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/temperaturebus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+)
+
+func parseOrder(qp QueryParams) (order.By, error) {
+	const (
+		orderByID          = "excursion_id"
+		orderByInventoryID = "inventory_id"
+		orderByPeakCelsius = "peak_celsius"
+		orderByDateStarted = "date_started"
+	)
+
+	var orderByFields = map[string]string{
+		orderByID:          temperaturebus.OrderByID,
+		orderByInventoryID: temperaturebus.OrderByInventoryID,
+		orderByPeakCelsius: temperaturebus.OrderByPeakCelsius,
+		orderByDateStarted: temperaturebus.OrderByDateStarted,
+	}
+
+	orderBy, err := order.Parse(qp.OrderBy, order.NewBy(orderByDateStarted, order.DESC))
+	if err != nil {
+		return order.By{}, err
+	}
+
+	if _, exists := orderByFields[orderBy.Field]; !exists {
+		return order.By{}, validate.NewFieldsError(orderBy.Field, errors.New("order field does not exist"))
+	}
+
+	orderBy.Field = orderByFields[orderBy.Field]
+
+	return orderBy, nil
+}
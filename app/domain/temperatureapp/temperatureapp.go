@@ -0,0 +1,187 @@
+// Package temperatureapp maintains the app layer api for the temperature
+// domain.
+package temperatureapp
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/temperaturebus"
+)
+
+// Core manages the set of app layer api functions for the temperature domain.
+type Core struct {
+	temperatureBus *temperaturebus.Core
+}
+
+// NewCore constructs a temperature core API for use.
+func NewCore(temperatureBus *temperaturebus.Core) *Core {
+	return &Core{
+		temperatureBus: temperatureBus,
+	}
+}
+
+// SetRange creates or replaces the temperature range of the inventory in
+// context.
+func (c *Core) SetRange(ctx context.Context, app NewRange) (Range, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return Range{}, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	rng, err := c.temperatureBus.SetRange(ctx, toBusNewRange(app, inv.ID))
+	if err != nil {
+		return Range{}, toAppError(err, "setrange: inventoryID[%s] nr[%+v]: %s", inv.ID, app, err)
+	}
+
+	return toAppRange(rng), nil
+}
+
+// QueryRange returns the temperature range of the inventory in context.
+func (c *Core) QueryRange(ctx context.Context) (Range, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return Range{}, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	rng, err := c.temperatureBus.QueryRange(ctx, inv.ID)
+	if err != nil {
+		return Range{}, toAppError(err, "queryrange: inventoryID[%s]: %s", inv.ID, err)
+	}
+
+	return toAppRange(rng), nil
+}
+
+// Ingest stores a batch of readings taken in the inventory in context.
+func (c *Core) Ingest(ctx context.Context, app NewIngestion) (Ingestion, error) {
+	nrs, err := toBusNewReadings(app.Readings)
+	if err != nil {
+		return Ingestion{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	return c.ingest(ctx, nrs)
+}
+
+// IngestCSV stores the readings of a data logger export taken in the
+// inventory in context.
+func (c *Core) IngestCSV(ctx context.Context, r io.Reader) (Ingestion, error) {
+	nrs, err := temperaturebus.DecodeCSV(r)
+	if err != nil {
+		return Ingestion{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	return c.ingest(ctx, nrs)
+}
+
+// QueryReadings returns the readings of the inventory in context recorded
+// over a period.
+func (c *Core) QueryReadings(ctx context.Context, rp ReadingParams) ([]Reading, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	filter, err := parseReadingFilter(rp, inv.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rds, err := c.temperatureBus.QueryReadings(ctx, filter)
+	if err != nil {
+		return nil, toAppError(err, "queryreadings: inventoryID[%s]: %s", inv.ID, err)
+	}
+
+	return toAppReadings(rds), nil
+}
+
+// Query returns a list of excursions with paging.
+func (c *Core) Query(ctx context.Context, qp QueryParams) (page.Document[Excursion], error) {
+	if err := validatePaging(qp); err != nil {
+		return page.Document[Excursion]{}, err
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return page.Document[Excursion]{}, err
+	}
+
+	orderBy, err := parseOrder(qp)
+	if err != nil {
+		return page.Document[Excursion]{}, err
+	}
+
+	excs, err := c.temperatureBus.Query(ctx, filter, orderBy, qp.Page, qp.Rows)
+	if err != nil {
+		return page.Document[Excursion]{}, errs.Newf(errs.Internal, "query: %s", err)
+	}
+
+	total, err := c.temperatureBus.Count(ctx, filter)
+	if err != nil {
+		return page.Document[Excursion]{}, errs.Newf(errs.Internal, "count: %s", err)
+	}
+
+	return page.NewDocument(toAppExcursions(excs), total, qp.Page, qp.Rows), nil
+}
+
+// =============================================================================
+
+func (c *Core) ingest(ctx context.Context, nrs []temperaturebus.NewReading) (Ingestion, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return Ingestion{}, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Ingestion{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	tx, ok := transaction.Get(ctx)
+	if !ok {
+		return Ingestion{}, errs.Newf(errs.Internal, "transaction missing in context")
+	}
+
+	temperatureBus, err := c.temperatureBus.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return Ingestion{}, errs.New(errs.Internal, err)
+	}
+
+	ni := temperaturebus.NewIngestion{
+		InventoryID: inv.ID,
+		UserID:      userID,
+		Readings:    nrs,
+	}
+
+	ing, err := temperatureBus.Ingest(ctx, ni)
+	if err != nil {
+		return Ingestion{}, toAppError(err, "ingest: inventoryID[%s]: %s", inv.ID, err)
+	}
+
+	return toAppIngestion(ing), nil
+}
+
+// toAppError maps the business errors a temperature call can fail with to
+// the matching app error.
+func toAppError(err error, format string, v ...any) error {
+	switch {
+	case errors.Is(err, temperaturebus.ErrInvalidRange),
+		errors.Is(err, temperaturebus.ErrNoReadings),
+		errors.Is(err, temperaturebus.ErrInvalidReading),
+		errors.Is(err, temperaturebus.ErrInvalidCSV),
+		errors.Is(err, temperaturebus.ErrInvalidPeriod):
+		return errs.New(errs.FailedPrecondition, err)
+
+	case errors.Is(err, temperaturebus.ErrNotFound),
+		errors.Is(err, temperaturebus.ErrRangeNotFound),
+		errors.Is(err, inventorybus.ErrNotFound):
+		return errs.New(errs.NotFound, err)
+	}
+
+	return errs.Newf(errs.Internal, format, v...)
+}
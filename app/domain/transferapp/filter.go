@@ -0,0 +1,45 @@
+package transferapp
+
+import (
+	"github.com/EnesDemirtas/medisync/business/domain/transferbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+func parseFilter(qp QueryParams) (transferbus.QueryFilter, error) {
+	var filter transferbus.QueryFilter
+
+	if qp.ID != "" {
+		id, err := uuid.Parse(qp.ID)
+		if err != nil {
+			return transferbus.QueryFilter{}, validate.NewFieldsError("transfer_id", err)
+		}
+		filter.WithTransferID(id)
+	}
+
+	if qp.SourceID != "" {
+		id, err := uuid.Parse(qp.SourceID)
+		if err != nil {
+			return transferbus.QueryFilter{}, validate.NewFieldsError("source_inventory_id", err)
+		}
+		filter.WithSourceID(id)
+	}
+
+	if qp.DestinationID != "" {
+		id, err := uuid.Parse(qp.DestinationID)
+		if err != nil {
+			return transferbus.QueryFilter{}, validate.NewFieldsError("destination_inventory_id", err)
+		}
+		filter.WithDestinationID(id)
+	}
+
+	if qp.Status != "" {
+		status, err := transferbus.ParseStatus(qp.Status)
+		if err != nil {
+			return transferbus.QueryFilter{}, validate.NewFieldsError("status", err)
+		}
+		filter.WithStatus(status)
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,197 @@
+package transferapp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/business/domain/transferbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Page          int    `query:"page"`
+	Rows          int    `query:"rows"`
+	OrderBy       string `query:"orderBy"`
+	ID            string `query:"transfer_id"`
+	SourceID      string `query:"source_inventory_id"`
+	DestinationID string `query:"destination_inventory_id"`
+	Status        string `query:"status"`
+}
+
+// Line represents a single lot on a transfer.
+type Line struct {
+	LotID             string `json:"lotID"`
+	MedicineID        string `json:"medicineID"`
+	Quantity          int    `json:"quantity"`
+	Received          int    `json:"received"`
+	Discrepancy       int    `json:"discrepancy"`
+	DiscrepancyReason string `json:"discrepancyReason,omitempty"`
+	InTransit         int    `json:"inTransit"`
+}
+
+// Transfer represents information about an individual transfer.
+type Transfer struct {
+	ID             string `json:"id"`
+	SourceID       string `json:"sourceInventoryID"`
+	DestinationID  string `json:"destinationInventoryID"`
+	Status         string `json:"status"`
+	Note           string `json:"note"`
+	Lines          []Line `json:"lines"`
+	DispatchedBy   string `json:"dispatchedBy"`
+	DateDispatched string `json:"dateDispatched"`
+	ReceivedBy     string `json:"receivedBy,omitempty"`
+	DateReceived   string `json:"dateReceived,omitempty"`
+	DateCreated    string `json:"dateCreated"`
+	DateUpdated    string `json:"dateUpdated"`
+}
+
+func toAppTransfer(tr transferbus.Transfer) Transfer {
+	lines := make([]Line, len(tr.Lines))
+	for i, line := range tr.Lines {
+		lines[i] = Line{
+			LotID:             line.LotID.String(),
+			MedicineID:        line.MedicineID.String(),
+			Quantity:          line.Quantity,
+			Received:          line.Received,
+			Discrepancy:       line.Discrepancy,
+			DiscrepancyReason: line.DiscrepancyReason,
+			InTransit:         line.InTransit(),
+		}
+	}
+
+	app := Transfer{
+		ID:             tr.ID.String(),
+		SourceID:       tr.SourceID.String(),
+		DestinationID:  tr.DestinationID.String(),
+		Status:         tr.Status.Name(),
+		Note:           tr.Note,
+		Lines:          lines,
+		DispatchedBy:   tr.DispatchedBy.String(),
+		DateDispatched: tr.DateDispatched.Format(time.RFC3339),
+		DateCreated:    tr.DateCreated.Format(time.RFC3339),
+		DateUpdated:    tr.DateUpdated.Format(time.RFC3339),
+	}
+
+	if tr.ReceivedBy != uuid.Nil {
+		app.ReceivedBy = tr.ReceivedBy.String()
+	}
+
+	if !tr.DateReceived.IsZero() {
+		app.DateReceived = tr.DateReceived.Format(time.RFC3339)
+	}
+
+	return app
+}
+
+func toAppTransfers(trs []transferbus.Transfer) []Transfer {
+	items := make([]Transfer, len(trs))
+	for i, tr := range trs {
+		items[i] = toAppTransfer(tr)
+	}
+
+	return items
+}
+
+// NewLine defines the data needed to add a lot to a transfer.
+type NewLine struct {
+	LotID    string `json:"lotID" validate:"required"`
+	Quantity int    `json:"quantity" validate:"required"`
+}
+
+// NewTransfer defines the data needed to dispatch a new transfer from the
+// inventory in the path.
+type NewTransfer struct {
+	DestinationID string    `json:"destinationInventoryID" validate:"required"`
+	Note          string    `json:"note"`
+	Lines         []NewLine `json:"lines" validate:"required,min=1,dive"`
+}
+
+func toBusNewTransfer(app NewTransfer, sourceID uuid.UUID, userID uuid.UUID) (transferbus.NewTransfer, error) {
+	destinationID, err := uuid.Parse(app.DestinationID)
+	if err != nil {
+		return transferbus.NewTransfer{}, fmt.Errorf("parse: %w", err)
+	}
+
+	lines := make([]transferbus.NewLine, len(app.Lines))
+	for i, line := range app.Lines {
+		lotID, err := uuid.Parse(line.LotID)
+		if err != nil {
+			return transferbus.NewTransfer{}, fmt.Errorf("parse: %w", err)
+		}
+
+		lines[i] = transferbus.NewLine{
+			LotID:    lotID,
+			Quantity: line.Quantity,
+		}
+	}
+
+	nt := transferbus.NewTransfer{
+		SourceID:      sourceID,
+		DestinationID: destinationID,
+		Note:          app.Note,
+		Lines:         lines,
+		UserID:        userID,
+	}
+
+	return nt, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewTransfer) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// ReceiptLine defines the quantity of a lot received.
+type ReceiptLine struct {
+	LotID    string `json:"lotID" validate:"required"`
+	Quantity int    `json:"quantity" validate:"required"`
+}
+
+// Receipt defines the data needed to record stock arriving at the
+// destination. Setting close records anything still in transit as a
+// discrepancy with the given reason.
+type Receipt struct {
+	Lines  []ReceiptLine `json:"lines" validate:"dive"`
+	Close  bool          `json:"close"`
+	Reason string        `json:"reason"`
+}
+
+func toBusReceipt(app Receipt, userID uuid.UUID) (transferbus.Receipt, error) {
+	lines := make([]transferbus.ReceiptLine, len(app.Lines))
+	for i, line := range app.Lines {
+		lotID, err := uuid.Parse(line.LotID)
+		if err != nil {
+			return transferbus.Receipt{}, fmt.Errorf("parse: %w", err)
+		}
+
+		lines[i] = transferbus.ReceiptLine{
+			LotID:    lotID,
+			Quantity: line.Quantity,
+		}
+	}
+
+	rc := transferbus.Receipt{
+		Lines:  lines,
+		Close:  app.Close,
+		Reason: app.Reason,
+		UserID: userID,
+	}
+
+	return rc, nil
+}
+
+// Validate checks the data in the model is considered clean.
+func (app Receipt) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
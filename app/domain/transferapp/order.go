@@ -0,0 +1,38 @@
+package transferapp
+
+import (
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/transferbus"
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+)
+
+func parseOrder(qp QueryParams) (order.By, error) {
+	const (
+		orderByID             = "transfer_id"
+		orderByStatus         = "status"
+		orderByDateDispatched = "date_dispatched"
+		orderByDateReceived   = "date_received"
+	)
+
+	var orderByFields = map[string]string{
+		orderByID:             transferbus.OrderByID,
+		orderByStatus:         transferbus.OrderByStatus,
+		orderByDateDispatched: transferbus.OrderByDateDispatched,
+		orderByDateReceived:   transferbus.OrderByDateReceived,
+	}
+
+	orderBy, err := order.Parse(qp.OrderBy, order.NewBy(orderByDateDispatched, order.ASC))
+	if err != nil {
+		return order.By{}, err
+	}
+
+	if _, exists := orderByFields[orderBy.Field]; !exists {
+		return order.By{}, validate.NewFieldsError(orderBy.Field, errors.New("order field does not exist"))
+	}
+
+	orderBy.Field = orderByFields[orderBy.Field]
+
+	return orderBy, nil
+}
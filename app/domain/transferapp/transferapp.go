@@ -0,0 +1,173 @@
+// Package transferapp maintains the app layer api for the transfer domain.
+package transferapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
+	"github.com/EnesDemirtas/medisync/app/api/page"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/transferbus"
+)
+
+// Core manages the set of app layer api functions for the transfer domain.
+type Core struct {
+	transferBus *transferbus.Core
+}
+
+// NewCore constructs a transfer core API for use.
+func NewCore(transferBus *transferbus.Core) *Core {
+	return &Core{
+		transferBus: transferBus,
+	}
+}
+
+// Dispatch creates a transfer out of the inventory in context.
+func (c *Core) Dispatch(ctx context.Context, app NewTransfer) (Transfer, error) {
+	inv, err := mid.GetInventory(ctx)
+	if err != nil {
+		return Transfer{}, errs.Newf(errs.Internal, "inventory missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Transfer{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	nt, err := toBusNewTransfer(app, inv.ID, userID)
+	if err != nil {
+		return Transfer{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	nt.CountersignedBy = mid.GetCountersignerID(ctx)
+
+	transferBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Transfer{}, errs.New(errs.Internal, err)
+	}
+
+	tr, err := transferBus.Dispatch(ctx, nt)
+	if err != nil {
+		return Transfer{}, toAppError(err, "dispatch: sourceID[%s] nt[%+v]: %s", inv.ID, app, err)
+	}
+
+	return toAppTransfer(tr), nil
+}
+
+// Receive records stock arriving for the transfer in context.
+func (c *Core) Receive(ctx context.Context, app Receipt) (Transfer, error) {
+	tr, err := mid.GetTransfer(ctx)
+	if err != nil {
+		return Transfer{}, errs.Newf(errs.Internal, "transfer missing in context: %s", err)
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Transfer{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
+	}
+
+	rc, err := toBusReceipt(app, userID)
+	if err != nil {
+		return Transfer{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	rc.CountersignedBy = mid.GetCountersignerID(ctx)
+
+	transferBus, err := c.executeUnderTransaction(ctx)
+	if err != nil {
+		return Transfer{}, errs.New(errs.Internal, err)
+	}
+
+	updTr, err := transferBus.Receive(ctx, tr.ID, rc)
+	if err != nil {
+		return Transfer{}, toAppError(err, "receive: transferID[%s] rc[%+v]: %s", tr.ID, app, err)
+	}
+
+	return toAppTransfer(updTr), nil
+}
+
+// Query returns a list of transfers with paging.
+func (c *Core) Query(ctx context.Context, qp QueryParams) (page.Document[Transfer], error) {
+	if err := validatePaging(qp); err != nil {
+		return page.Document[Transfer]{}, err
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return page.Document[Transfer]{}, err
+	}
+
+	orderBy, err := parseOrder(qp)
+	if err != nil {
+		return page.Document[Transfer]{}, err
+	}
+
+	trs, err := c.transferBus.Query(ctx, filter, orderBy, qp.Page, qp.Rows)
+	if err != nil {
+		return page.Document[Transfer]{}, errs.Newf(errs.Internal, "query: %s", err)
+	}
+
+	total, err := c.transferBus.Count(ctx, filter)
+	if err != nil {
+		return page.Document[Transfer]{}, errs.Newf(errs.Internal, "count: %s", err)
+	}
+
+	return page.NewDocument(toAppTransfers(trs), total, qp.Page, qp.Rows), nil
+}
+
+// QueryByID returns a transfer by its ID.
+func (c *Core) QueryByID(ctx context.Context) (Transfer, error) {
+	tr, err := mid.GetTransfer(ctx)
+	if err != nil {
+		return Transfer{}, errs.Newf(errs.Internal, "querybyid: %s", err)
+	}
+
+	return toAppTransfer(tr), nil
+}
+
+// executeUnderTransaction returns a transfer core bound to the transaction
+// the transaction middleware placed in the context, so every line of a
+// transfer is applied or none are.
+func (c *Core) executeUnderTransaction(ctx context.Context) (*transferbus.Core, error) {
+	tx, ok := transaction.Get(ctx)
+	if !ok {
+		return nil, errors.New("transaction missing in context")
+	}
+
+	return c.transferBus.ExecuteUnderTransaction(tx)
+}
+
+// toAppError maps the business errors a transfer can fail with to the
+// matching app error.
+func toAppError(err error, format string, v ...any) error {
+	switch {
+	case errors.Is(err, transferbus.ErrSameInventory),
+		errors.Is(err, transferbus.ErrNoLines),
+		errors.Is(err, transferbus.ErrDuplicateLine),
+		errors.Is(err, transferbus.ErrInvalidQuantity),
+		errors.Is(err, transferbus.ErrUnknownLine),
+		errors.Is(err, transferbus.ErrOverReceipt),
+		errors.Is(err, transferbus.ErrNotInTransit),
+		errors.Is(err, transferbus.ErrReasonRequired),
+		errors.Is(err, inventorybus.ErrInsufficientStock),
+		errors.Is(err, inventorybus.ErrIncompatibleStorage),
+		errors.Is(err, stockbus.ErrQuarantined),
+		errors.Is(err, stockbus.ErrUnavailable),
+		errors.Is(err, stockbus.ErrReserved),
+		errors.Is(err, stockbus.ErrCountersignRequired),
+		errors.Is(err, stockbus.ErrSelfCountersign),
+		errors.Is(err, stockbus.ErrInvalidQuantity):
+		return errs.New(errs.FailedPrecondition, err)
+
+	case errors.Is(err, inventorybus.ErrNotFound),
+		errors.Is(err, lotbus.ErrNotFound):
+		return errs.New(errs.NotFound, err)
+	}
+
+	return errs.Newf(errs.Internal, format, v...)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/EnesDemirtas/medisync/app/api/errs"
+	"github.com/EnesDemirtas/medisync/app/api/mid"
 	"github.com/EnesDemirtas/medisync/business/domain/userbus"
 	"github.com/EnesDemirtas/medisync/foundation/validate"
 )
@@ -30,6 +31,7 @@ type User struct {
 	Roles   	 []string `json:"roles"`
 	PasswordHash []byte   `json:"-"`
 	Enabled		 bool     `json:"enabled"`
+	Version		 int      `json:"version"`
 	DateCreated  string   `json:"dateCreated"`
 	DateUpdated  string   `json:"dateUpdated"`
 }
@@ -47,11 +49,17 @@ func toAppUser(usr userbus.User) User {
 		Roles:		  roles,
 		PasswordHash: usr.PasswordHash,
 		Enabled: 	  usr.Enabled,
+		Version:	  usr.Version,
 		DateCreated:  usr.DateCreated.Format(time.RFC3339),
 		DateUpdated:  usr.DateUpdated.Format(time.RFC3339),
 	}
 }
 
+// ETag implements the web.ETagger interface.
+func (app User) ETag() string {
+	return mid.ETag(app.Version)
+}
+
 func toAppUsers(users []userbus.User) []User {
 	items := make([]User, len(users))
 	for i, usr := range users {
@@ -79,8 +79,15 @@ func (c *Core) Update(ctx context.Context, app UpdateUser) (User, error) {
 		return User{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
 	}
 
+	if err := mid.CheckIfMatch(ctx, usr.Version); err != nil {
+		return User{}, err
+	}
+
 	updUsr, err := c.userBus.Update(ctx, usr, uu)
 	if err != nil {
+		if errors.Is(err, userbus.ErrVersionConflict) {
+			return User{}, errs.New(errs.PreconditionFailed, err)
+		}
 		return User{}, errs.Newf(errs.Internal, "update: userID[%s] uu[%+v]: %s", usr.ID, uu, err)
 	}
 
@@ -99,8 +106,15 @@ func (c *Core) UpdateRole(ctx context.Context, app UpdateUserRole) (User, error)
 		return User{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
 	}
 
+	if err := mid.CheckIfMatch(ctx, usr.Version); err != nil {
+		return User{}, err
+	}
+
 	updUsr, err := c.userBus.Update(ctx, usr, uu)
 	if err != nil {
+		if errors.Is(err, userbus.ErrVersionConflict) {
+			return User{}, errs.New(errs.PreconditionFailed, err)
+		}
 		return User{}, errs.Newf(errs.Internal, "updaterole: userID[%s] uu[%+v]: %s", usr.ID, uu, err)
 	}
 
@@ -10,6 +10,8 @@ default rule_user_only := false
 
 default rule_admin_or_subject := false
 
+default rule_countersign := false
+
 role_user := "USER"
 
 role_admin := "ADMIN"
@@ -43,4 +45,11 @@ rule_admin_or_subject if {
     input_user := {role_user} & claim_roles
     count(input_user) > 0
     input.UserID == input.Subject
+}
+
+rule_countersign if {
+    claim_roles := {role | some role in input.Roles}
+    input_roles := role_all & claim_roles
+    count(input_roles) > 0
+    input.UserID != input.Subject
 }
\ No newline at end of file
@@ -11,6 +11,7 @@ const (
 	RuleAdminOnly 	   = "rule_admin_only"
 	RuleUserOnly	   = "rule_user_only"
 	RuleAdminOrSubject = "rule_admin_or_subject"
+	RuleCountersign	   = "rule_countersign"
 )
 
 // Package name of our rego code.
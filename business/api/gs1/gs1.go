@@ -0,0 +1,334 @@
+// Package gs1 provides support for GS1 identifiers found on medicine packs,
+// GTINs and the element strings encoded in GS1 DataMatrix symbols.
+package gs1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Set of error variables for parsing GS1 data.
+var (
+	ErrInvalidGTIN   = errors.New("invalid GTIN")
+	ErrInvalidCode   = errors.New("invalid GS1 element string")
+	ErrMissingGTIN   = errors.New("GS1 element string does not contain a GTIN")
+	ErrUnsupportedAI = errors.New("unsupported GS1 application identifier")
+)
+
+// groupSeparator is the FNC1 character used to terminate variable length
+// fields in the raw data of a GS1 DataMatrix symbol.
+const groupSeparator = '\x1d'
+
+// Set of application identifiers understood by Parse.
+const (
+	AIGTIN           = "01"
+	AIBatch          = "10"
+	AIProductionDate = "11"
+	AIExpiryDate     = "17"
+	AISerial         = "21"
+)
+
+// ai describes the layout of the data that follows an application identifier.
+type ai struct {
+	length   int
+	variable bool
+}
+
+var ais = map[string]ai{
+	AIGTIN:           {length: 14},
+	AIBatch:          {length: 20, variable: true},
+	AIProductionDate: {length: 6},
+	AIExpiryDate:     {length: 6},
+	AISerial:         {length: 20, variable: true},
+}
+
+// aiLengths holds the number of digits of the application identifiers
+// starting with each two digit prefix, as defined by the GS1 General
+// Specifications. Prefixes missing from it are not assigned.
+var aiLengths = map[string]int{
+	"00": 2, "01": 2, "02": 2, "03": 2, "04": 2,
+	"10": 2, "11": 2, "12": 2, "13": 2, "15": 2, "16": 2, "17": 2,
+	"20": 2, "21": 2, "22": 2, "23": 3, "24": 3, "25": 3,
+	"30": 2, "31": 4, "32": 4, "33": 4, "34": 4, "35": 4, "36": 4, "37": 2, "39": 4,
+	"40": 3, "41": 3, "42": 3, "43": 4,
+	"70": 4, "71": 3, "72": 4,
+	"80": 4, "81": 4, "82": 4,
+	"90": 2, "91": 2, "92": 2, "93": 2, "94": 2, "95": 2, "96": 2, "97": 2, "98": 2, "99": 2,
+}
+
+// predefinedLengths holds the data length of the application identifiers
+// starting with each two digit prefix that are never terminated by a group
+// separator. The data of every other application identifier is variable.
+var predefinedLengths = map[string]int{
+	"00": 18, "01": 14, "02": 14, "03": 14, "04": 16,
+	"11": 6, "12": 6, "13": 6, "14": 6, "15": 6, "16": 6, "17": 6, "18": 6, "19": 6,
+	"20": 2,
+	"31": 6, "32": 6, "33": 6, "34": 6, "35": 6, "36": 6,
+	"41": 13,
+}
+
+// lookupAI returns the application identifier at the start of the raw
+// element string together with the layout of its data. Application
+// identifiers Parse doesn't use are laid out from the GS1 length tables so
+// they can be skipped.
+func lookupAI(code string) (string, ai, error) {
+	if len(code) < 2 {
+		return "", ai{}, fmt.Errorf("%w: truncated application identifier", ErrInvalidCode)
+	}
+
+	if def, exists := ais[code[:2]]; exists {
+		return code[:2], def, nil
+	}
+
+	n, exists := aiLengths[code[:2]]
+	if !exists {
+		return "", ai{}, fmt.Errorf("%w: %q", ErrUnsupportedAI, code[:2])
+	}
+
+	if len(code) < n {
+		return "", ai{}, fmt.Errorf("%w: truncated application identifier", ErrInvalidCode)
+	}
+
+	if length, exists := predefinedLengths[code[:2]]; exists {
+		return code[:n], ai{length: length}, nil
+	}
+
+	return code[:n], ai{variable: true}, nil
+}
+
+// NormalizeGTIN validates the check digit of a GTIN-8, GTIN-12, GTIN-13 or
+// GTIN-14 and returns it left padded with zeros to the 14 digit form.
+func NormalizeGTIN(gtin string) (string, error) {
+	switch len(gtin) {
+	case 8, 12, 13, 14:
+	default:
+		return "", fmt.Errorf("%w: %q: unexpected length %d", ErrInvalidGTIN, gtin, len(gtin))
+	}
+
+	for _, r := range gtin {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("%w: %q: non digit character", ErrInvalidGTIN, gtin)
+		}
+	}
+
+	gtin = strings.Repeat("0", 14-len(gtin)) + gtin
+
+	if checkDigit(gtin[:13]) != gtin[13] {
+		return "", fmt.Errorf("%w: %q: check digit mismatch", ErrInvalidGTIN, gtin)
+	}
+
+	return gtin, nil
+}
+
+// checkDigit calculates the GS1 mod 10 check digit for the digits provided.
+// Weights of 3 and 1 alternate starting from the rightmost digit.
+func checkDigit(digits string) byte {
+	var sum int
+	weight := 3
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += int(digits[i]-'0') * weight
+		weight = 4 - weight
+	}
+
+	return byte('0' + (10-sum%10)%10)
+}
+
+// Element represents the data decoded from a GS1 element string.
+type Element struct {
+	GTIN           string
+	Batch          string
+	Serial         string
+	ExpiryDate     time.Time
+	ProductionDate time.Time
+}
+
+// Parse decodes a GS1 element string as scanned from a DataMatrix symbol.
+// Both the raw form, where variable length fields are terminated by the FNC1
+// group separator, and the human readable form with application identifiers
+// in parentheses are accepted. Application identifiers other than the ones
+// listed above, such as the national reimbursement numbers printed on many
+// packs, are skipped.
+func Parse(code string) (Element, error) {
+	code = strings.TrimPrefix(code, "]d2")
+	code = strings.TrimPrefix(code, string(groupSeparator))
+
+	fields, err := split(code)
+	if err != nil {
+		return Element{}, err
+	}
+
+	var el Element
+	for _, f := range fields {
+		switch f.ai {
+		case AIGTIN:
+			gtin, err := NormalizeGTIN(f.value)
+			if err != nil {
+				return Element{}, err
+			}
+			el.GTIN = gtin
+
+		case AIBatch:
+			el.Batch = f.value
+
+		case AISerial:
+			el.Serial = f.value
+
+		case AIExpiryDate:
+			d, err := parseDate(f.value)
+			if err != nil {
+				return Element{}, err
+			}
+			el.ExpiryDate = d
+
+		case AIProductionDate:
+			d, err := parseDate(f.value)
+			if err != nil {
+				return Element{}, err
+			}
+			el.ProductionDate = d
+		}
+	}
+
+	if el.GTIN == "" {
+		return Element{}, ErrMissingGTIN
+	}
+
+	return el, nil
+}
+
+type field struct {
+	ai    string
+	value string
+}
+
+// split breaks the element string into its application identifier fields.
+func split(code string) ([]field, error) {
+	if strings.HasPrefix(code, "(") {
+		return splitReadable(code)
+	}
+
+	var fields []field
+	for len(code) > 0 {
+		id, def, err := lookupAI(code)
+		if err != nil {
+			return nil, err
+		}
+		code = code[len(id):]
+
+		var value string
+		switch {
+		case def.variable:
+			end := strings.IndexRune(code, groupSeparator)
+			if end == -1 {
+				end = len(code)
+			}
+			value = code[:end]
+			code = strings.TrimPrefix(code[end:], string(groupSeparator))
+
+		default:
+			if len(code) < def.length {
+				return nil, fmt.Errorf("%w: AI %s: expected %d characters", ErrInvalidCode, id, def.length)
+			}
+			value = code[:def.length]
+			code = strings.TrimPrefix(code[def.length:], string(groupSeparator))
+		}
+
+		if err := checkField(id, def, value); err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, field{ai: id, value: value})
+	}
+
+	return fields, nil
+}
+
+// splitReadable breaks a human readable element string such as
+// (01)05012345678900(17)261231(10)ABC123 into its fields.
+func splitReadable(code string) ([]field, error) {
+	var fields []field
+	for len(code) > 0 {
+		if code[0] != '(' {
+			return nil, fmt.Errorf("%w: expected '('", ErrInvalidCode)
+		}
+
+		end := strings.IndexByte(code, ')')
+		if end == -1 {
+			return nil, fmt.Errorf("%w: unterminated application identifier", ErrInvalidCode)
+		}
+
+		id := code[1:end]
+		code = code[end+1:]
+
+		next := strings.IndexByte(code, '(')
+		if next == -1 {
+			next = len(code)
+		}
+		value := code[:next]
+		code = code[next:]
+
+		if def, exists := ais[id]; exists {
+			if err := checkField(id, def, value); err != nil {
+				return nil, err
+			}
+		}
+
+		fields = append(fields, field{ai: id, value: value})
+	}
+
+	return fields, nil
+}
+
+func checkField(id string, def ai, value string) error {
+	switch {
+	case def.variable && def.length == 0 && len(value) == 0:
+		return fmt.Errorf("%w: AI %s: expected data", ErrInvalidCode, id)
+	case def.variable && def.length > 0 && (len(value) == 0 || len(value) > def.length):
+		return fmt.Errorf("%w: AI %s: expected 1 to %d characters", ErrInvalidCode, id, def.length)
+	case !def.variable && len(value) != def.length:
+		return fmt.Errorf("%w: AI %s: expected %d characters", ErrInvalidCode, id, def.length)
+	}
+
+	return nil
+}
+
+// parseDate decodes a GS1 YYMMDD date. A day of 00 means the last day of the
+// month. Years are placed within the century window defined by GS1, from 49
+// years in the past to 50 years in the future.
+func parseDate(value string) (time.Time, error) {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return time.Time{}, fmt.Errorf("%w: date %q", ErrInvalidCode, value)
+		}
+	}
+
+	yy := int(value[0]-'0')*10 + int(value[1]-'0')
+	mm := int(value[2]-'0')*10 + int(value[3]-'0')
+	dd := int(value[4]-'0')*10 + int(value[5]-'0')
+
+	if mm < 1 || mm > 12 || dd > 31 {
+		return time.Time{}, fmt.Errorf("%w: date %q", ErrInvalidCode, value)
+	}
+
+	current := time.Now().UTC().Year()
+	year := current - current%100 + yy
+	switch diff := year - current; {
+	case diff > 50:
+		year -= 100
+	case diff < -49:
+		year += 100
+	}
+
+	if dd == 0 {
+		return time.Date(year, time.Month(mm)+1, 0, 0, 0, 0, 0, time.UTC), nil
+	}
+
+	d := time.Date(year, time.Month(mm), dd, 0, 0, 0, 0, time.UTC)
+	if d.Month() != time.Month(mm) {
+		return time.Time{}, fmt.Errorf("%w: date %q", ErrInvalidCode, value)
+	}
+
+	return d, nil
+}
@@ -0,0 +1,188 @@
+package gs1
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_NormalizeGTIN(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		name   string
+		gtin   string
+		exp    string
+		expErr error
+	}{
+		{name: "gtin-8", gtin: "96385074", exp: "00000096385074"},
+		{name: "gtin-12", gtin: "036000291452", exp: "00036000291452"},
+		{name: "gtin-13", gtin: "5012345678900", exp: "05012345678900"},
+		{name: "gtin-14", gtin: "12345678901231", exp: "12345678901231"},
+		{name: "bad-check-digit", gtin: "5012345678901", expErr: ErrInvalidGTIN},
+		{name: "non-digit", gtin: "50123456789O0", expErr: ErrInvalidGTIN},
+		{name: "bad-length", gtin: "123456789", expErr: ErrInvalidGTIN},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeGTIN(tt.gtin)
+			if !errors.Is(err, tt.expErr) {
+				t.Fatalf("Should get error %v: got %v", tt.expErr, err)
+			}
+
+			if got != tt.exp {
+				t.Errorf("Should get %q: got %q", tt.exp, got)
+			}
+		})
+	}
+}
+
+func Test_Parse(t *testing.T) {
+	t.Parallel()
+
+	const gs = string(groupSeparator)
+
+	table := []struct {
+		name   string
+		code   string
+		exp    Element
+		expErr error
+	}{
+		{
+			name: "raw",
+			code: "]d2" + "0105012345678900" + "17261231" + "10ABC123" + gs + "21SN0001",
+			exp: Element{
+				GTIN:       "05012345678900",
+				Batch:      "ABC123",
+				Serial:     "SN0001",
+				ExpiryDate: time.Date(2026, time.December, 31, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "raw-leading-fnc1",
+			code: gs + "0105012345678900" + "10ABC123",
+			exp: Element{
+				GTIN:  "05012345678900",
+				Batch: "ABC123",
+			},
+		},
+		{
+			name: "readable",
+			code: "(01)05012345678900(11)250115(17)270200(10)LOT-7",
+			exp: Element{
+				GTIN:           "05012345678900",
+				Batch:          "LOT-7",
+				ProductionDate: time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC),
+				ExpiryDate:     time.Date(2027, time.February, 28, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "variable-last",
+			code: "0105012345678900" + "21SERIAL12345",
+			exp: Element{
+				GTIN:   "05012345678900",
+				Serial: "SERIAL12345",
+			},
+		},
+		{
+			name: "skip-unused",
+			code: "0105012345678900" + "21SN1" + gs + "7103PZN" + gs + "240ADD-ID" + gs + "3012" + gs + "17261231" + "10B1",
+			exp: Element{
+				GTIN:       "05012345678900",
+				Batch:      "B1",
+				Serial:     "SN1",
+				ExpiryDate: time.Date(2026, time.December, 31, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "skip-unused-fixed",
+			code: "0105012345678900" + "3103000250" + "10B1",
+			exp: Element{
+				GTIN:  "05012345678900",
+				Batch: "B1",
+			},
+		},
+		{
+			name: "skip-unused-readable",
+			code: "(01)05012345678900(714)1234567(10)B1",
+			exp: Element{
+				GTIN:  "05012345678900",
+				Batch: "B1",
+			},
+		},
+		{
+			name:   "unassigned-ai",
+			code:   "0105012345678900" + "55XYZ",
+			expErr: ErrUnsupportedAI,
+		},
+		{
+			name:   "missing-gtin",
+			code:   "10ABC123",
+			expErr: ErrMissingGTIN,
+		},
+		{
+			name:   "bad-gtin",
+			code:   "0105012345678901",
+			expErr: ErrInvalidGTIN,
+		},
+		{
+			name:   "truncated-gtin",
+			code:   "01050123",
+			expErr: ErrInvalidCode,
+		},
+		{
+			name:   "batch-too-long",
+			code:   "0105012345678900" + "10ABCDEFGHIJKLMNOPQRSTU",
+			expErr: ErrInvalidCode,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.code)
+			if !errors.Is(err, tt.expErr) {
+				t.Fatalf("Should get error %v: got %v", tt.expErr, err)
+			}
+
+			if diff := cmp.Diff(got, tt.exp); diff != "" {
+				t.Errorf("Should get the expected element:\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ParseDate(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		name   string
+		value  string
+		exp    time.Time
+		expErr error
+	}{
+		{name: "day", value: "261231", exp: time.Date(2026, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		{name: "day-00", value: "260200", exp: time.Date(2026, time.February, 28, 0, 0, 0, 0, time.UTC)},
+		{name: "day-00-leap", value: "280200", exp: time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "day-00-december", value: "261200", exp: time.Date(2026, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		{name: "past-century", value: "991231", exp: time.Date(1999, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		{name: "bad-month", value: "261301", expErr: ErrInvalidCode},
+		{name: "bad-day", value: "260230", expErr: ErrInvalidCode},
+		{name: "non-digit", value: "26A231", expErr: ErrInvalidCode},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDate(tt.value)
+			if !errors.Is(err, tt.expErr) {
+				t.Fatalf("Should get error %v: got %v", tt.expErr, err)
+			}
+
+			if !got.Equal(tt.exp) {
+				t.Errorf("Should get %s: got %s", tt.exp, got)
+			}
+		})
+	}
+}
@@ -14,6 +14,40 @@ import (
 	"github.com/EnesDemirtas/medisync/business/api/delegate"
 	"github.com/EnesDemirtas/medisync/business/data/migrate"
 	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/domain/allocationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/allocationbus/stores/allocationdb"
+	"github.com/EnesDemirtas/medisync/business/domain/disposalbus"
+	"github.com/EnesDemirtas/medisync/business/domain/disposalbus/stores/disposaldb"
+	"github.com/EnesDemirtas/medisync/business/domain/expirybus"
+	"github.com/EnesDemirtas/medisync/business/domain/expirybus/stores/expirydb"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus/stores/inventorydb"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus/stores/locationdb"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus/stores/lotdb"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus/stores/medicinedb"
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus"
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus/stores/orderdb"
+	"github.com/EnesDemirtas/medisync/business/domain/purchaseorderbus"
+	"github.com/EnesDemirtas/medisync/business/domain/purchaseorderbus/stores/purchaseorderdb"
+	"github.com/EnesDemirtas/medisync/business/domain/recallbus"
+	"github.com/EnesDemirtas/medisync/business/domain/recallbus/stores/recalldb"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus/stores/reservationdb"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus/stores/stockdb"
+	"github.com/EnesDemirtas/medisync/business/domain/stocktakebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stocktakebus/stores/stocktakedb"
+	"github.com/EnesDemirtas/medisync/business/domain/supplierbus"
+	"github.com/EnesDemirtas/medisync/business/domain/supplierbus/stores/supplierdb"
+	"github.com/EnesDemirtas/medisync/business/domain/tagbus"
+	"github.com/EnesDemirtas/medisync/business/domain/temperaturebus"
+	"github.com/EnesDemirtas/medisync/business/domain/temperaturebus/stores/temperaturedb"
+	"github.com/EnesDemirtas/medisync/business/domain/tagbus/stores/tagdb"
+	"github.com/EnesDemirtas/medisync/business/domain/transferbus"
+	"github.com/EnesDemirtas/medisync/business/domain/transferbus/stores/transferdb"
 	"github.com/EnesDemirtas/medisync/business/domain/userbus"
 	"github.com/EnesDemirtas/medisync/business/domain/userbus/stores/userdb"
 	"github.com/EnesDemirtas/medisync/foundation/docker"
@@ -50,17 +84,68 @@ func StopDB(c *docker.Container) {
 
 // BusDomain represents all the business domain apis needed for testing.
 type BusDomain struct {
-	Delegate *delegate.Delegate
-	User  	 *userbus.Core
+	Delegate      *delegate.Delegate
+	User          *userbus.Core
+	Tag           *tagbus.Core
+	Medicine      *medicinebus.Core
+	Lot           *lotbus.Core
+	Inventory     *inventorybus.Core
+	Location      *locationbus.Core
+	Stock         *stockbus.Core
+	Transfer      *transferbus.Core
+	Expiry        *expirybus.Core
+	Reservation   *reservationbus.Core
+	Allocation    *allocationbus.Core
+	Order         *orderbus.Core
+	Supplier      *supplierbus.Core
+	PurchaseOrder *purchaseorderbus.Core
+	Stocktake     *stocktakebus.Core
+	Recall        *recallbus.Core
+	Disposal      *disposalbus.Core
+	Temperature   *temperaturebus.Core
 }
 
 func newBusDomains(log *logger.Logger, db *sqlx.DB) BusDomain {
-	delegate := delegate.New(log)
-	userBus  := userbus.NewCore(log, delegate, userdb.NewStore(log, db))
-	
+	delegate     := delegate.New(log)
+	userBus      := userbus.NewCore(log, delegate, userdb.NewStore(log, db))
+	tagBus       := tagbus.NewCore(log, delegate, tagdb.NewStore(log, db))
+	medicineBus  := medicinebus.NewCore(log, tagBus, delegate, medicinedb.NewStore(log, db))
+	lotBus       := lotbus.NewCore(log, medicineBus, delegate, lotdb.NewStore(log, db))
+	inventoryBus := inventorybus.NewCore(log, medicineBus, lotBus, delegate, inventorydb.NewStore(log, db))
+	locationBus  := locationbus.NewCore(log, inventoryBus, delegate, locationdb.NewStore(log, db))
+	stockBus     := stockbus.NewCore(log, inventoryBus, medicineBus, lotBus, locationBus, delegate, stockdb.NewStore(log, db))
+	transferBus  := transferbus.NewCore(log, inventoryBus, stockBus, delegate, transferdb.NewStore(log, db))
+	expiryBus    := expirybus.NewCore(log, delegate, expirydb.NewStore(log, db))
+	reservationBus := reservationbus.NewCore(log, medicineBus, stockBus, delegate, reservationdb.NewStore(log, db))
+	allocationBus := allocationbus.NewCore(log, medicineBus, stockBus, 30*24*time.Hour, delegate, allocationdb.NewStore(log, db))
+	orderBus := orderbus.NewCore(log, medicineBus, lotBus, stockBus, allocationBus, delegate, orderdb.NewStore(log, db))
+	supplierBus := supplierbus.NewCore(log, delegate, supplierdb.NewStore(log, db))
+	purchaseOrderBus := purchaseorderbus.NewCore(log, supplierBus, medicineBus, lotBus, stockBus, delegate, purchaseorderdb.NewStore(log, db))
+	stocktakeBus := stocktakebus.NewCore(log, inventoryBus, lotBus, stockBus, delegate, stocktakedb.NewStore(log, db))
+	recallBus := recallbus.NewCore(log, lotBus, inventoryBus, stockBus, delegate, recalldb.NewStore(log, db))
+	disposalBus := disposalbus.NewCore(log, userBus, lotBus, stockBus, delegate, disposaldb.NewStore(log, db))
+	temperatureBus := temperaturebus.NewCore(log, inventoryBus, delegate, temperaturedb.NewStore(log, db))
+
 	return BusDomain{
-		Delegate: delegate,
-		User:     userBus,
+		Delegate:      delegate,
+		User:          userBus,
+		Tag:           tagBus,
+		Medicine:      medicineBus,
+		Lot:           lotBus,
+		Inventory:     inventoryBus,
+		Location:      locationBus,
+		Stock:         stockBus,
+		Transfer:      transferBus,
+		Expiry:        expiryBus,
+		Reservation:   reservationBus,
+		Allocation:    allocationBus,
+		Order:         orderBus,
+		Supplier:      supplierBus,
+		PurchaseOrder: purchaseOrderBus,
+		Stocktake:     stocktakeBus,
+		Recall:        recallBus,
+		Disposal:      disposalBus,
+		Temperature:   temperatureBus,
 	}
 }
 
@@ -1,6 +1,12 @@
 package dbtest
 
-import "github.com/EnesDemirtas/medisync/business/domain/userbus"
+import (
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/tagbus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+)
 
 // User represents an app user specified for the test.
 type User struct {
@@ -10,6 +16,10 @@ type User struct {
 
 // SeedData represents data that was seeded for the test.
 type SeedData struct {
-	Users  []User
-	Admins []User
+	Users  	    []User
+	Admins 	    []User
+	Medicines   []medicinebus.Medicine
+	Lots        []lotbus.Lot
+	Inventories []inventorybus.Inventory
+	Tags        []tagbus.Tag
 }
\ No newline at end of file
@@ -51,3 +51,797 @@ CREATE TABLE inventories (
     PRIMARY KEY (inventory_id)
 );
 
+
+-- Version: 1.05
+-- Description: Create table stock_movements
+CREATE TABLE stock_movements (
+	movement_id  UUID      NOT NULL,
+	inventory_id UUID      NOT NULL,
+	medicine_id  UUID      NOT NULL,
+	type         TEXT      NOT NULL,
+	quantity     INT       NOT NULL,
+	balance      INT       NOT NULL,
+	reason       TEXT      NULL,
+	user_id      UUID      NOT NULL,
+	date_created TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (movement_id),
+	FOREIGN KEY (inventory_id) REFERENCES inventories(inventory_id),
+	FOREIGN KEY (medicine_id) REFERENCES medicines(medicine_id),
+	FOREIGN KEY (user_id) REFERENCES users(user_id),
+	CHECK (quantity <> 0),
+	CHECK (balance >= 0)
+);
+
+CREATE INDEX stock_movements_inventory_idx ON stock_movements (inventory_id, date_created);
+CREATE INDEX stock_movements_medicine_idx ON stock_movements (medicine_id, date_created);
+
+-- Version: 1.06
+-- Description: Create function rejecting changes to stock_movements
+CREATE FUNCTION reject_stock_movement_change() RETURNS TRIGGER AS $$
+BEGIN
+	RAISE EXCEPTION 'stock movements are append only';
+END;
+$$ LANGUAGE plpgsql;
+
+-- Version: 1.07
+-- Description: Make stock_movements append only
+CREATE TRIGGER stock_movements_append_only
+	BEFORE UPDATE OR DELETE ON stock_movements
+	FOR EACH ROW EXECUTE FUNCTION reject_stock_movement_change();
+
+-- Version: 1.08
+-- Description: Create table lots
+CREATE TABLE lots (
+	lot_id           UUID      NOT NULL,
+	medicine_id      UUID      NOT NULL,
+	lot_number       TEXT      NOT NULL,
+	expiry_date      TIMESTAMP NOT NULL,
+	manufacture_date TIMESTAMP NULL,
+	date_created     TIMESTAMP NOT NULL,
+	date_updated     TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (lot_id),
+	FOREIGN KEY (medicine_id) REFERENCES medicines(medicine_id),
+	UNIQUE (medicine_id, lot_number)
+);
+
+CREATE INDEX lots_expiry_idx ON lots (expiry_date);
+
+-- Version: 1.09
+-- Description: Move medicine expiry dates into a legacy lot per medicine
+INSERT INTO lots (lot_id, medicine_id, lot_number, expiry_date, date_created, date_updated)
+SELECT
+	gen_random_uuid(),
+	medicine_id,
+	'LEGACY',
+	COALESCE(expiry_date, '9999-12-31 00:00:00'),
+	date_created,
+	date_updated
+FROM
+	medicines;
+
+-- Version: 1.10
+-- Description: Key inventory quantities by lot instead of medicine
+ALTER TABLE inventories RENAME COLUMN medicine_quantities TO lot_quantities;
+
+UPDATE inventories i
+SET lot_quantities = (
+	SELECT COALESCE(jsonb_object_agg(l.lot_id::TEXT, q.value), '{}'::JSONB)
+	FROM jsonb_each(i.lot_quantities) q
+	JOIN lots l ON l.medicine_id = q.key::UUID AND l.lot_number = 'LEGACY'
+)
+WHERE i.lot_quantities IS NOT NULL;
+
+-- Version: 1.11
+-- Description: Record the lot of every stock movement
+ALTER TABLE stock_movements ADD COLUMN lot_id UUID NULL REFERENCES lots(lot_id);
+
+ALTER TABLE stock_movements DISABLE TRIGGER stock_movements_append_only;
+
+UPDATE stock_movements m
+SET lot_id = l.lot_id
+FROM lots l
+WHERE l.medicine_id = m.medicine_id AND l.lot_number = 'LEGACY';
+
+ALTER TABLE stock_movements ENABLE TRIGGER stock_movements_append_only;
+
+ALTER TABLE stock_movements ALTER COLUMN lot_id SET NOT NULL;
+
+CREATE INDEX stock_movements_lot_idx ON stock_movements (lot_id, date_created);
+
+-- Version: 1.12
+-- Description: Drop expiry_date from medicines, it now lives on lots
+ALTER TABLE medicines DROP COLUMN expiry_date;
+
+-- Version: 1.13
+-- Description: Add GTIN to medicines
+ALTER TABLE medicines ADD COLUMN gtin TEXT NULL UNIQUE CHECK (gtin ~ '^[0-9]{14}$');
+
+-- Version: 1.14
+-- Description: Create table transfers
+CREATE TABLE transfers (
+	transfer_id              UUID      NOT NULL,
+	source_inventory_id      UUID      NOT NULL,
+	destination_inventory_id UUID      NOT NULL,
+	status                   TEXT      NOT NULL,
+	note                     TEXT      NULL,
+	dispatched_by            UUID      NOT NULL,
+	date_dispatched          TIMESTAMP NOT NULL,
+	received_by              UUID      NULL,
+	date_received            TIMESTAMP NULL,
+	date_created             TIMESTAMP NOT NULL,
+	date_updated             TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (transfer_id),
+	FOREIGN KEY (source_inventory_id) REFERENCES inventories(inventory_id),
+	FOREIGN KEY (destination_inventory_id) REFERENCES inventories(inventory_id),
+	FOREIGN KEY (dispatched_by) REFERENCES users(user_id),
+	FOREIGN KEY (received_by) REFERENCES users(user_id),
+	CHECK (source_inventory_id <> destination_inventory_id)
+);
+
+CREATE INDEX transfers_status_idx ON transfers (status, date_dispatched);
+
+-- Version: 1.15
+-- Description: Create table transfer_lines
+CREATE TABLE transfer_lines (
+	transfer_id        UUID NOT NULL,
+	lot_id             UUID NOT NULL,
+	medicine_id        UUID NOT NULL,
+	quantity           INT  NOT NULL,
+	received           INT  NOT NULL DEFAULT 0,
+	discrepancy        INT  NOT NULL DEFAULT 0,
+	discrepancy_reason TEXT NULL,
+
+	PRIMARY KEY (transfer_id, lot_id),
+	FOREIGN KEY (transfer_id) REFERENCES transfers(transfer_id) ON DELETE CASCADE,
+	FOREIGN KEY (lot_id) REFERENCES lots(lot_id),
+	FOREIGN KEY (medicine_id) REFERENCES medicines(medicine_id),
+	CHECK (quantity > 0),
+	CHECK (received >= 0 AND discrepancy >= 0),
+	CHECK (received + discrepancy <= quantity)
+);
+
+-- Version: 1.16
+-- Description: Create table inventory_reorder_points
+CREATE TABLE inventory_reorder_points (
+	inventory_id  UUID      NOT NULL,
+	medicine_id   UUID      NOT NULL,
+	min_quantity  INT       NOT NULL,
+	max_quantity  INT       NOT NULL,
+	reorder_point INT       NOT NULL,
+	date_created  TIMESTAMP NOT NULL,
+	date_updated  TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (inventory_id, medicine_id),
+	FOREIGN KEY (inventory_id) REFERENCES inventories(inventory_id) ON DELETE CASCADE,
+	FOREIGN KEY (medicine_id) REFERENCES medicines(medicine_id) ON DELETE CASCADE,
+	CHECK (min_quantity >= 0 AND min_quantity <= reorder_point AND reorder_point <= max_quantity)
+);
+
+-- Version: 1.17
+-- Description: Create table expiry_alerts
+CREATE TABLE expiry_alerts (
+	inventory_id UUID      NOT NULL,
+	lot_id       UUID      NOT NULL,
+	medicine_id  UUID      NOT NULL,
+	expiry_date  TIMESTAMP NOT NULL,
+	quantity     INT       NOT NULL,
+	horizon      INT       NOT NULL,
+	date_alerted TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (inventory_id, lot_id, horizon),
+	FOREIGN KEY (inventory_id) REFERENCES inventories(inventory_id) ON DELETE CASCADE,
+	FOREIGN KEY (lot_id) REFERENCES lots(lot_id) ON DELETE CASCADE,
+	FOREIGN KEY (medicine_id) REFERENCES medicines(medicine_id) ON DELETE CASCADE,
+	CHECK (horizon >= 0)
+);
+
+CREATE INDEX expiry_alerts_expired_idx ON expiry_alerts (horizon, expiry_date);
+
+-- Version: 1.18
+-- Description: Add version columns for optimistic concurrency
+ALTER TABLE users ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE tags ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE medicines ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE inventories ADD COLUMN version INT NOT NULL DEFAULT 1;
+
+-- Version: 1.19
+-- Description: Move inventory contents into table inventory_items
+CREATE TABLE inventory_items (
+	inventory_id UUID      NOT NULL,
+	lot_id       UUID      NOT NULL,
+	medicine_id  UUID      NOT NULL,
+	quantity     INT       NOT NULL,
+	date_updated TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (inventory_id, lot_id),
+	FOREIGN KEY (inventory_id) REFERENCES inventories(inventory_id) ON DELETE CASCADE,
+	FOREIGN KEY (lot_id) REFERENCES lots(lot_id),
+	FOREIGN KEY (medicine_id) REFERENCES medicines(medicine_id),
+	CHECK (quantity >= 0)
+);
+
+CREATE INDEX inventory_items_medicine_idx ON inventory_items (medicine_id, inventory_id);
+
+INSERT INTO inventory_items (inventory_id, lot_id, medicine_id, quantity, date_updated)
+SELECT
+	i.inventory_id,
+	l.lot_id,
+	l.medicine_id,
+	CAST(q.value AS INT),
+	i.date_updated
+FROM
+	inventories i
+CROSS JOIN LATERAL
+	jsonb_each_text(i.lot_quantities) q
+JOIN
+	lots l ON l.lot_id = CAST(q.key AS UUID);
+
+ALTER TABLE inventories DROP COLUMN lot_quantities;
+
+-- Version: 1.20
+-- Description: Move medicine tags into table medicine_tags
+CREATE TABLE medicine_tags (
+	medicine_id UUID NOT NULL,
+	tag_id      UUID NOT NULL,
+
+	PRIMARY KEY (medicine_id, tag_id),
+	FOREIGN KEY (medicine_id) REFERENCES medicines(medicine_id) ON DELETE CASCADE,
+	FOREIGN KEY (tag_id) REFERENCES tags(tag_id)
+);
+
+CREATE INDEX medicine_tags_tag_idx ON medicine_tags (tag_id);
+
+INSERT INTO medicine_tags (medicine_id, tag_id)
+SELECT DISTINCT
+	m.medicine_id,
+	t.tag_id
+FROM
+	medicines m
+CROSS JOIN LATERAL
+	unnest(m.tags) mt(tag_id)
+JOIN
+	tags t ON t.tag_id = mt.tag_id;
+
+ALTER TABLE medicines DROP COLUMN tags;
+
+-- Version: 1.21
+-- Description: Create table reservations
+CREATE TABLE reservations (
+	reservation_id UUID      NOT NULL,
+	inventory_id   UUID      NOT NULL,
+	medicine_id    UUID      NOT NULL,
+	quantity       INT       NOT NULL,
+	status         TEXT      NOT NULL,
+	reference      TEXT      NULL,
+	user_id        UUID      NOT NULL,
+	date_expires   TIMESTAMP NOT NULL,
+	date_created   TIMESTAMP NOT NULL,
+	date_updated   TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (reservation_id),
+	FOREIGN KEY (inventory_id) REFERENCES inventories(inventory_id) ON DELETE CASCADE,
+	FOREIGN KEY (medicine_id) REFERENCES medicines(medicine_id) ON DELETE CASCADE,
+	FOREIGN KEY (user_id) REFERENCES users(user_id),
+	CHECK (quantity > 0)
+);
+
+CREATE INDEX reservations_held_idx ON reservations (inventory_id, medicine_id, date_expires) WHERE status = 'HELD';
+
+-- Version: 1.22
+-- Description: Create table orders
+CREATE TABLE orders (
+	order_id         UUID      NOT NULL,
+	inventory_id     UUID      NOT NULL,
+	destination_type TEXT      NOT NULL,
+	destination      TEXT      NOT NULL,
+	status           TEXT      NOT NULL,
+	note             TEXT      NULL,
+	created_by       UUID      NOT NULL,
+	approved_by      UUID      NULL,
+	date_approved    TIMESTAMP NULL,
+	picked_by        UUID      NULL,
+	date_picked      TIMESTAMP NULL,
+	shipped_by       UUID      NULL,
+	date_shipped     TIMESTAMP NULL,
+	date_created     TIMESTAMP NOT NULL,
+	date_updated     TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (order_id),
+	FOREIGN KEY (inventory_id) REFERENCES inventories(inventory_id),
+	FOREIGN KEY (created_by) REFERENCES users(user_id),
+	FOREIGN KEY (approved_by) REFERENCES users(user_id),
+	FOREIGN KEY (picked_by) REFERENCES users(user_id),
+	FOREIGN KEY (shipped_by) REFERENCES users(user_id)
+);
+
+CREATE INDEX orders_status_idx ON orders (status, date_created);
+
+CREATE TABLE order_lines (
+	order_id    UUID NOT NULL,
+	medicine_id UUID NOT NULL,
+	quantity    INT  NOT NULL,
+
+	PRIMARY KEY (order_id, medicine_id),
+	FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE CASCADE,
+	FOREIGN KEY (medicine_id) REFERENCES medicines(medicine_id),
+	CHECK (quantity > 0)
+);
+
+CREATE TABLE order_picks (
+	order_id    UUID NOT NULL,
+	medicine_id UUID NOT NULL,
+	lot_id      UUID NOT NULL,
+	quantity    INT  NOT NULL,
+
+	PRIMARY KEY (order_id, lot_id),
+	FOREIGN KEY (order_id, medicine_id) REFERENCES order_lines(order_id, medicine_id) ON DELETE CASCADE,
+	FOREIGN KEY (lot_id) REFERENCES lots(lot_id),
+	CHECK (quantity > 0)
+);
+
+-- Version: 1.23
+-- Description: Create tables suppliers and purchase_orders
+CREATE TABLE suppliers (
+	supplier_id  UUID      NOT NULL,
+	name         TEXT      NOT NULL,
+	email        TEXT      NULL,
+	phone        TEXT      NULL,
+	address      TEXT      NULL,
+	version      INT       NOT NULL DEFAULT 1,
+	date_created TIMESTAMP NOT NULL,
+	date_updated TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (supplier_id),
+	UNIQUE (name)
+);
+
+CREATE TABLE purchase_orders (
+	purchase_order_id UUID      NOT NULL,
+	supplier_id       UUID      NOT NULL,
+	inventory_id      UUID      NOT NULL,
+	status            TEXT      NOT NULL,
+	note              TEXT      NULL,
+	created_by        UUID      NOT NULL,
+	approved_by       UUID      NULL,
+	date_approved     TIMESTAMP NULL,
+	sent_by           UUID      NULL,
+	date_sent         TIMESTAMP NULL,
+	date_created      TIMESTAMP NOT NULL,
+	date_updated      TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (purchase_order_id),
+	FOREIGN KEY (supplier_id) REFERENCES suppliers(supplier_id),
+	FOREIGN KEY (inventory_id) REFERENCES inventories(inventory_id),
+	FOREIGN KEY (created_by) REFERENCES users(user_id),
+	FOREIGN KEY (approved_by) REFERENCES users(user_id),
+	FOREIGN KEY (sent_by) REFERENCES users(user_id)
+);
+
+CREATE INDEX purchase_orders_status_idx ON purchase_orders (status, date_created);
+
+CREATE TABLE purchase_order_lines (
+	purchase_order_id UUID NOT NULL,
+	medicine_id       UUID NOT NULL,
+	quantity          INT  NOT NULL,
+	received          INT  NOT NULL DEFAULT 0,
+
+	PRIMARY KEY (purchase_order_id, medicine_id),
+	FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders(purchase_order_id) ON DELETE CASCADE,
+	FOREIGN KEY (medicine_id) REFERENCES medicines(medicine_id),
+	CHECK (quantity > 0),
+	CHECK (received >= 0)
+);
+
+CREATE TABLE deliveries (
+	delivery_id       UUID      NOT NULL,
+	purchase_order_id UUID      NOT NULL,
+	inventory_id      UUID      NOT NULL,
+	note              TEXT      NULL,
+	received_by       UUID      NOT NULL,
+	date_received     TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (delivery_id),
+	FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders(purchase_order_id) ON DELETE CASCADE,
+	FOREIGN KEY (inventory_id) REFERENCES inventories(inventory_id),
+	FOREIGN KEY (received_by) REFERENCES users(user_id)
+);
+
+CREATE INDEX deliveries_purchase_order_idx ON deliveries (purchase_order_id, date_received);
+
+CREATE TABLE delivery_lines (
+	delivery_id UUID NOT NULL,
+	medicine_id UUID NOT NULL,
+	lot_id      UUID NOT NULL,
+	quantity    INT  NOT NULL,
+
+	PRIMARY KEY (delivery_id, lot_id),
+	FOREIGN KEY (delivery_id) REFERENCES deliveries(delivery_id) ON DELETE CASCADE,
+	FOREIGN KEY (medicine_id) REFERENCES medicines(medicine_id),
+	FOREIGN KEY (lot_id) REFERENCES lots(lot_id),
+	CHECK (quantity > 0)
+);
+
+-- Version: 1.24
+-- Description: Create tables stocktakes, stocktake_lines and stocktake_counts
+CREATE TABLE stocktakes (
+	stocktake_id  UUID      NOT NULL,
+	inventory_id  UUID      NOT NULL,
+	status        TEXT      NOT NULL,
+	note          TEXT      NULL,
+	opened_by     UUID      NOT NULL,
+	approved_by   UUID      NULL,
+	date_approved TIMESTAMP NULL,
+	date_created  TIMESTAMP NOT NULL,
+	date_updated  TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (stocktake_id),
+	FOREIGN KEY (inventory_id) REFERENCES inventories(inventory_id) ON DELETE CASCADE,
+	FOREIGN KEY (opened_by) REFERENCES users(user_id),
+	FOREIGN KEY (approved_by) REFERENCES users(user_id)
+);
+
+CREATE UNIQUE INDEX stocktakes_open_idx ON stocktakes (inventory_id) WHERE status = 'OPEN';
+
+CREATE TABLE stocktake_lines (
+	stocktake_id UUID NOT NULL,
+	medicine_id  UUID NOT NULL,
+	lot_id       UUID NOT NULL,
+	expected     INT  NOT NULL,
+
+	PRIMARY KEY (stocktake_id, lot_id),
+	FOREIGN KEY (stocktake_id) REFERENCES stocktakes(stocktake_id) ON DELETE CASCADE,
+	FOREIGN KEY (medicine_id) REFERENCES medicines(medicine_id),
+	FOREIGN KEY (lot_id) REFERENCES lots(lot_id),
+	CHECK (expected >= 0)
+);
+
+CREATE TABLE stocktake_counts (
+	stocktake_id UUID      NOT NULL,
+	lot_id       UUID      NOT NULL,
+	device_id    TEXT      NOT NULL,
+	quantity     INT       NOT NULL,
+	counted_by   UUID      NOT NULL,
+	date_counted TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (stocktake_id, lot_id, device_id),
+	FOREIGN KEY (stocktake_id, lot_id) REFERENCES stocktake_lines(stocktake_id, lot_id) ON DELETE CASCADE,
+	FOREIGN KEY (counted_by) REFERENCES users(user_id),
+	CHECK (quantity >= 0)
+);
+
+-- Version: 1.25
+-- Description: Create tables locations and location_items
+CREATE TABLE locations (
+	location_id  UUID      NOT NULL,
+	inventory_id UUID      NOT NULL,
+	parent_id    UUID      NULL,
+	kind         TEXT      NOT NULL,
+	code         TEXT      NOT NULL,
+	name         TEXT      NOT NULL,
+	date_created TIMESTAMP NOT NULL,
+	date_updated TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (location_id),
+	UNIQUE (inventory_id, code),
+	FOREIGN KEY (inventory_id) REFERENCES inventories(inventory_id) ON DELETE CASCADE,
+	FOREIGN KEY (parent_id) REFERENCES locations(location_id)
+);
+
+CREATE INDEX locations_parent_idx ON locations (parent_id);
+
+CREATE TABLE location_items (
+	location_id  UUID      NOT NULL,
+	lot_id       UUID      NOT NULL,
+	inventory_id UUID      NOT NULL,
+	medicine_id  UUID      NOT NULL,
+	quantity     INT       NOT NULL,
+	date_updated TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (location_id, lot_id),
+	FOREIGN KEY (location_id) REFERENCES locations(location_id) ON DELETE CASCADE,
+	FOREIGN KEY (lot_id) REFERENCES lots(lot_id),
+	FOREIGN KEY (medicine_id) REFERENCES medicines(medicine_id),
+	CHECK (quantity >= 0)
+);
+
+CREATE INDEX location_items_lot_idx ON location_items (inventory_id, lot_id);
+
+ALTER TABLE stock_movements ADD COLUMN location_id UUID NULL REFERENCES locations(location_id);
+
+-- Version: 1.26
+-- Description: Create tables stock_snapshots and stock_snapshot_items
+CREATE TABLE stock_snapshots (
+	snapshot_id UUID      NOT NULL,
+	date_taken  TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (snapshot_id)
+);
+
+CREATE INDEX stock_snapshots_date_idx ON stock_snapshots (date_taken);
+
+CREATE TABLE stock_snapshot_items (
+	snapshot_id  UUID NOT NULL,
+	inventory_id UUID NOT NULL,
+	medicine_id  UUID NOT NULL,
+	lot_id       UUID NOT NULL,
+	quantity     INT  NOT NULL,
+
+	PRIMARY KEY (snapshot_id, inventory_id, lot_id),
+	FOREIGN KEY (snapshot_id) REFERENCES stock_snapshots(snapshot_id) ON DELETE CASCADE,
+	FOREIGN KEY (inventory_id) REFERENCES inventories(inventory_id),
+	FOREIGN KEY (medicine_id) REFERENCES medicines(medicine_id),
+	FOREIGN KEY (lot_id) REFERENCES lots(lot_id)
+);
+
+CREATE INDEX stock_movements_date_idx ON stock_movements (date_created);
+
+-- Inventories held stock before the ledger was introduced, so the current
+-- quantities are kept as the opening balance history is rebuilt from.
+WITH baseline AS (
+	INSERT INTO stock_snapshots (snapshot_id, date_taken)
+	VALUES (gen_random_uuid(), NOW() AT TIME ZONE 'UTC')
+	RETURNING snapshot_id
+)
+INSERT INTO stock_snapshot_items (snapshot_id, inventory_id, medicine_id, lot_id, quantity)
+SELECT
+	b.snapshot_id, ii.inventory_id, ii.medicine_id, ii.lot_id, ii.quantity
+FROM
+	inventory_items AS ii
+CROSS JOIN
+	baseline AS b
+WHERE
+	ii.quantity > 0;
+
+-- Version: 1.27
+-- Description: Create tables recalls, recall_lots, recall_stock and recall_resolutions
+ALTER TABLE lots ADD COLUMN quarantined BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE recalls (
+	recall_id    UUID      NOT NULL,
+	medicine_id  UUID      NOT NULL,
+	reason       TEXT      NOT NULL,
+	severity     TEXT      NOT NULL,
+	status       TEXT      NOT NULL,
+	created_by   UUID      NOT NULL,
+	closed_by    UUID      NULL,
+	date_closed  TIMESTAMP NULL,
+	date_created TIMESTAMP NOT NULL,
+	date_updated TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (recall_id),
+	FOREIGN KEY (medicine_id) REFERENCES medicines(medicine_id),
+	FOREIGN KEY (created_by) REFERENCES users(user_id),
+	FOREIGN KEY (closed_by) REFERENCES users(user_id)
+);
+
+CREATE TABLE recall_lots (
+	recall_id UUID NOT NULL,
+	lot_id    UUID NOT NULL,
+
+	PRIMARY KEY (recall_id, lot_id),
+	FOREIGN KEY (recall_id) REFERENCES recalls(recall_id) ON DELETE CASCADE,
+	FOREIGN KEY (lot_id) REFERENCES lots(lot_id)
+);
+
+CREATE TABLE recall_stock (
+	recall_id    UUID NOT NULL,
+	inventory_id UUID NOT NULL,
+	lot_id       UUID NOT NULL,
+	quantity     INT  NOT NULL,
+
+	PRIMARY KEY (recall_id, inventory_id, lot_id),
+	FOREIGN KEY (recall_id, lot_id) REFERENCES recall_lots(recall_id, lot_id) ON DELETE CASCADE,
+	FOREIGN KEY (inventory_id) REFERENCES inventories(inventory_id)
+);
+
+CREATE TABLE recall_resolutions (
+	resolution_id UUID      NOT NULL DEFAULT gen_random_uuid(),
+	recall_id     UUID      NOT NULL,
+	inventory_id  UUID      NOT NULL,
+	lot_id        UUID      NOT NULL,
+	method        TEXT      NOT NULL,
+	quantity      INT       NOT NULL,
+	note          TEXT      NULL,
+	resolved_by   UUID      NOT NULL,
+	date_created  TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (resolution_id),
+	FOREIGN KEY (recall_id, lot_id) REFERENCES recall_lots(recall_id, lot_id) ON DELETE CASCADE,
+	FOREIGN KEY (inventory_id) REFERENCES inventories(inventory_id),
+	FOREIGN KEY (resolved_by) REFERENCES users(user_id),
+	CHECK (quantity > 0)
+);
+
+-- Version: 1.28
+-- Description: Split inventory_items by status and create table inventory_status_moves
+ALTER TABLE inventory_items
+	ADD COLUMN quarantined INT NOT NULL DEFAULT 0,
+	ADD COLUMN damaged     INT NOT NULL DEFAULT 0,
+	ADD COLUMN expired     INT NOT NULL DEFAULT 0,
+	ADD CONSTRAINT inventory_items_buckets_check CHECK (
+		quarantined >= 0 AND damaged >= 0 AND expired >= 0 AND
+		quarantined + damaged + expired <= quantity
+	);
+
+-- Stock of lots already under a recall was only held back by the lot flag.
+UPDATE
+	inventory_items AS ii
+SET
+	quarantined = ii.quantity
+FROM
+	lots AS l
+WHERE
+	l.lot_id = ii.lot_id AND l.quarantined;
+
+CREATE TABLE inventory_status_moves (
+	move_id      UUID      NOT NULL,
+	inventory_id UUID      NOT NULL,
+	medicine_id  UUID      NOT NULL,
+	lot_id       UUID      NOT NULL,
+	from_status  TEXT      NOT NULL,
+	to_status    TEXT      NOT NULL,
+	quantity     INT       NOT NULL,
+	reason       TEXT      NOT NULL,
+	moved_by     UUID      NOT NULL,
+	date_created TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (move_id),
+	FOREIGN KEY (inventory_id) REFERENCES inventories(inventory_id) ON DELETE CASCADE,
+	FOREIGN KEY (medicine_id) REFERENCES medicines(medicine_id),
+	FOREIGN KEY (lot_id) REFERENCES lots(lot_id),
+	FOREIGN KEY (moved_by) REFERENCES users(user_id),
+	CHECK (quantity > 0)
+);
+
+ALTER TABLE stocktakes ADD COLUMN all_statuses BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Version: 1.29
+-- Description: Create tables disposals and disposal_lines
+CREATE TABLE disposals (
+	disposal_id             UUID      NOT NULL,
+	inventory_id            UUID      NOT NULL,
+	method                  TEXT      NOT NULL,
+	reason                  TEXT      NULL,
+	disposed_by             UUID      NOT NULL,
+	witnessed_by            UUID      NOT NULL,
+	certificate_number      TEXT      NULL,
+	certificate_issuer      TEXT      NULL,
+	certificate_date_issued TIMESTAMP NULL,
+	date_disposed           TIMESTAMP NOT NULL,
+	date_created            TIMESTAMP NOT NULL,
+	date_updated            TIMESTAMP NOT NULL,
+
+	PRIMARY KEY (disposal_id),
+	FOREIGN KEY (inventory_id) REFERENCES inventories(inventory_id),
+	FOREIGN KEY (disposed_by) REFERENCES users(user_id),
+	FOREIGN KEY (witnessed_by) REFERENCES users(user_id),
+	CHECK (disposed_by <> witnessed_by)
+);
+
+CREATE TABLE disposal_lines (
+	disposal_id UUID NOT NULL,
+	line_number INT  NOT NULL,
+	medicine_id UUID NOT NULL,
+	lot_id      UUID NOT NULL,
+	location_id UUID NULL,
+	status      TEXT NOT NULL,
+	quantity    INT  NOT NULL,
+
+	PRIMARY KEY (disposal_id, line_number),
+	FOREIGN KEY (disposal_id) REFERENCES disposals(disposal_id) ON DELETE CASCADE,
+	FOREIGN KEY (medicine_id) REFERENCES medicines(medicine_id),
+	FOREIGN KEY (lot_id) REFERENCES lots(lot_id),
+	FOREIGN KEY (location_id) REFERENCES locations(location_id),
+	CHECK (quantity > 0)
+);
+
+-- Version: 1.30
+-- Description: Add controlled substance schedules and countersigned stock movements
+ALTER TABLE medicines ADD COLUMN schedule TEXT NOT NULL DEFAULT 'NONE';
+ALTER TABLE stock_movements ADD COLUMN countersigned_by UUID NULL REFERENCES users(user_id);
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_countersign_check CHECK (countersigned_by <> user_id);
+
+-- Version: 1.31
+-- Description: Add medicine storage requirements and inventory storage capabilities
+ALTER TABLE medicines ADD COLUMN storage_temperature TEXT NOT NULL DEFAULT 'ANY';
+ALTER TABLE medicines ADD COLUMN protect_from_light BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE inventories ADD COLUMN capabilities TEXT[] NOT NULL DEFAULT '{AMBIENT}';
+
+-- Version: 1.32
+-- Description: Add temperature ranges, readings and excursions
+CREATE TABLE temperature_ranges (
+	inventory_id UUID             NOT NULL,
+	min_celsius  DOUBLE PRECISION NOT NULL,
+	max_celsius  DOUBLE PRECISION NOT NULL,
+	date_created TIMESTAMP        NOT NULL,
+	date_updated TIMESTAMP        NOT NULL,
+
+	PRIMARY KEY (inventory_id),
+	FOREIGN KEY (inventory_id) REFERENCES inventories(inventory_id) ON DELETE CASCADE,
+	CHECK (min_celsius <= max_celsius)
+);
+
+CREATE TABLE temperature_readings (
+	reading_id    UUID             NOT NULL,
+	inventory_id  UUID             NOT NULL,
+	celsius       DOUBLE PRECISION NOT NULL,
+	date_recorded TIMESTAMP        NOT NULL,
+	date_created  TIMESTAMP        NOT NULL,
+
+	PRIMARY KEY (reading_id),
+	FOREIGN KEY (inventory_id) REFERENCES inventories(inventory_id) ON DELETE CASCADE
+);
+
+CREATE INDEX temperature_readings_recorded_idx ON temperature_readings (inventory_id, date_recorded);
+
+CREATE TABLE excursions (
+	excursion_id UUID             NOT NULL,
+	inventory_id UUID             NOT NULL,
+	min_celsius  DOUBLE PRECISION NOT NULL,
+	max_celsius  DOUBLE PRECISION NOT NULL,
+	peak_celsius DOUBLE PRECISION NOT NULL,
+	date_peak    TIMESTAMP        NOT NULL,
+	date_started TIMESTAMP        NOT NULL,
+	date_ended   TIMESTAMP        NULL,
+	date_created TIMESTAMP        NOT NULL,
+	date_updated TIMESTAMP        NOT NULL,
+
+	PRIMARY KEY (excursion_id),
+	FOREIGN KEY (inventory_id) REFERENCES inventories(inventory_id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX excursions_ongoing_idx ON excursions (inventory_id) WHERE date_ended IS NULL;
+
+CREATE TABLE excursion_stock (
+	excursion_id UUID NOT NULL,
+	medicine_id  UUID NOT NULL,
+	lot_id       UUID NOT NULL,
+	quantity     INT  NOT NULL,
+
+	PRIMARY KEY (excursion_id, lot_id),
+	FOREIGN KEY (excursion_id) REFERENCES excursions(excursion_id) ON DELETE CASCADE,
+	FOREIGN KEY (medicine_id) REFERENCES medicines(medicine_id),
+	FOREIGN KEY (lot_id) REFERENCES lots(lot_id),
+	CHECK (quantity > 0)
+);
+
+-- Version: 1.33
+-- Description: Record the stock each recall moved into the quarantined bucket
+ALTER TABLE recall_stock ADD COLUMN held INT NOT NULL DEFAULT 0;
+
+-- Recalls registered so far quarantined everything the lots held.
+UPDATE
+	recall_stock
+SET
+	held = quantity;
+
+-- Version: 1.34
+-- Description: Add the record a stock movement was made for
+ALTER TABLE stock_movements
+	ADD COLUMN reference_type TEXT NULL,
+	ADD COLUMN reference_id   UUID NULL,
+	ADD CONSTRAINT stock_movements_reference_check CHECK ((reference_type IS NULL) = (reference_id IS NULL));
+
+CREATE INDEX stock_movements_reference_idx ON stock_movements (reference_type, reference_id);
+
+-- Movements recorded so far only named their record in the reason.
+UPDATE
+	stock_movements AS m
+SET
+	reference_type = p.type,
+	reference_id = CAST(substring(m.reason from p.pattern) AS UUID)
+FROM (
+	VALUES
+		('ORDER', '^order ([0-9a-f-]{36}) to '),
+		('TRANSFER', '^transfer ([0-9a-f-]{36})$'),
+		('RESERVATION', '^reservation ([0-9a-f-]{36})$'),
+		('PURCHASE_ORDER', '^purchase order ([0-9a-f-]{36}) delivery '),
+		('STOCKTAKE', '^stocktake ([0-9a-f-]{36})$'),
+		('RECALL', '^recall ([0-9a-f-]{36}): '),
+		('DISPOSAL', '^disposal ([0-9a-f-]{36}): ')
+) AS p (type, pattern)
+WHERE
+	m.reason ~ p.pattern;
@@ -29,7 +29,6 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -651,24 +650,29 @@ func (a Int32) Value() (driver.Value, error) {
 	return "{}", nil
 }
 
-// MedicineQuantities represents a map[uuid.UUID]int type will be marshalled/unmarshalled into/from JSONB column type in PostgreSQL.
-type MedicineQuantities map[uuid.UUID]int
+// Quantities represents a map[uuid.UUID]int type will be marshalled/unmarshalled into/from JSONB column type in PostgreSQL.
+type Quantities map[uuid.UUID]int
 
-// Make the MedicineQuantities type implement the driver.Valuer interface. This method
+// Make the Quantities type implement the driver.Valuer interface. This method
 // simply returns the JSON-encoded representation of the map.
-func (a MedicineQuantities) Value() (driver.Value, error) {
+func (a Quantities) Value() (driver.Value, error) {
     return json.Marshal(a)
 }
 
-// Make the MedicineQuantities type implement the sql.Scanner interface. This method
+// Make the Quantities type implement the sql.Scanner interface. This method
 // simply decodes a JSON-encoded value into the map.
-func (a *MedicineQuantities) Scan(value interface{}) error {
-    b, ok := value.([]byte)
-    if !ok {
-        return errors.New("type assertion to []byte failed")
-    }
+func (a *Quantities) Scan(value interface{}) error {
+	switch src := value.(type) {
+	case []byte:
+		return json.Unmarshal(src, a)
+	case string:
+		return json.Unmarshal([]byte(src), a)
+	case nil:
+		*a = nil
+		return nil
+	}
 
-    return json.Unmarshal(b, &a)
+	return fmt.Errorf("database: cannot convert %T to Quantities", value)
 }
 
 // String represents a one-dimensional array of the PostgreSQL character types.
@@ -22,6 +22,7 @@ import (
 // https://github.com/lib/pq/blob/master/error.go#L178
 const (
 	uniqueViolation = "23505"
+	foreignKeyViolation = "23503"
 	undefinedTable  = "42P01"
 )
 
@@ -29,6 +30,7 @@ const (
 var (
 	ErrDBNotFound 		 = sql.ErrNoRows
 	ErrDBDuplicatedEntry = errors.New("duplicated entry")
+	ErrDBForeignKey		 = errors.New("foreign key violation")
 	ErrUndefinedTable 	 = errors.New("undefined table")
 )
 
@@ -141,6 +143,8 @@ func NamedExecContext(ctx context.Context, log *logger.Logger, db sqlx.ExtContex
 				return ErrUndefinedTable
 			case uniqueViolation:
 				return ErrDBDuplicatedEntry
+			case foreignKeyViolation:
+				return ErrDBForeignKey
 			}
 		}
 		return err
@@ -0,0 +1,261 @@
+// Package allocationbus provides the business API for picking the lots an
+// outbound request is drawn from. Lots are allocated First-Expired-First-Out
+// across inventories, skipping stock held by reservations and lots that
+// expire before the minimum remaining shelf life. The plan is only advisory
+// until it is confirmed into stock movements.
+package allocationbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+)
+
+// Set of error variables for allocation operations.
+var (
+	ErrInvalidQuantity  = errors.New("invalid allocation quantity")
+	ErrInvalidShelfLife = errors.New("minimum shelf life can't be negative")
+	ErrNoLines          = errors.New("confirmation has no lines")
+	ErrUnavailable      = errors.New("lot stock is not available for allocation")
+)
+
+// Storer interface declares the behavior this package needs to retrieve data.
+type Storer interface {
+	ExecuteUnderTransaction(tx transaction.Transaction) (Storer, error)
+	QueryCandidates(ctx context.Context, medicineID uuid.UUID, inventoryID *uuid.UUID, usableFrom time.Time, now time.Time) ([]Candidate, error)
+	QueryCandidatesForUpdate(ctx context.Context, medicineID uuid.UUID, inventoryID uuid.UUID, usableFrom time.Time, now time.Time) ([]Candidate, error)
+}
+
+// Core manages the set of APIs for allocation access.
+type Core struct {
+	log          *logger.Logger
+	medicineCore *medicinebus.Core
+	stockCore    *stockbus.Core
+	minShelfLife time.Duration
+	delegate     *delegate.Delegate
+	storer       Storer
+}
+
+// NewCore constructs an allocation core API for use. The minimum shelf life
+// applies to every request that doesn't specify its own.
+func NewCore(log *logger.Logger, medicineCore *medicinebus.Core, stockCore *stockbus.Core, minShelfLife time.Duration, delegate *delegate.Delegate, storer Storer) *Core {
+	return &Core{
+		log:          log,
+		medicineCore: medicineCore,
+		stockCore:    stockCore,
+		minShelfLife: minShelfLife,
+		delegate:     delegate,
+		storer:       storer,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Core value that will use the
+// specified transaction in any store related calls.
+func (c *Core) ExecuteUnderTransaction(tx transaction.Transaction) (*Core, error) {
+	storer, err := c.storer.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	medicineCore, err := c.medicineCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	stockCore, err := c.stockCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	core := Core{
+		log:          c.log,
+		medicineCore: medicineCore,
+		stockCore:    stockCore,
+		minShelfLife: c.minShelfLife,
+		delegate:     c.delegate,
+		storer:       storer,
+	}
+
+	return &core, nil
+}
+
+// Plan allocates the requested quantity from the lots that expire first. The
+// plan falls short instead of failing when there isn't enough stock, the
+// caller decides whether a partial allocation is acceptable.
+func (c *Core) Plan(ctx context.Context, req Request) (Plan, error) {
+	if req.Quantity <= 0 {
+		return Plan{}, ErrInvalidQuantity
+	}
+
+	now := time.Now()
+
+	usableFrom, err := c.usableFrom(now, req.MinShelfLife)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	if _, err := c.medicineCore.QueryByID(ctx, req.MedicineID); err != nil {
+		return Plan{}, fmt.Errorf("medicine.querybyid: %s: %w", req.MedicineID, err)
+	}
+
+	cands, err := c.storer.QueryCandidates(ctx, req.MedicineID, req.InventoryID, usableFrom, now)
+	if err != nil {
+		return Plan{}, fmt.Errorf("querycandidates: %w", err)
+	}
+
+	plan := Plan{
+		MedicineID: req.MedicineID,
+		Quantity:   req.Quantity,
+		UsableFrom: usableFrom,
+	}
+
+	for _, cand := range free(cands) {
+		if plan.Allocated == plan.Quantity {
+			break
+		}
+
+		qty := min(cand.OnHand, plan.Quantity-plan.Allocated)
+
+		plan.Lines = append(plan.Lines, Line{
+			InventoryID: cand.InventoryID,
+			LotID:       cand.LotID,
+			LotNumber:   cand.LotNumber,
+			ExpiryDate:  cand.ExpiryDate,
+			Quantity:    qty,
+		})
+		plan.Allocated += qty
+	}
+
+	return plan, nil
+}
+
+// Confirm dispenses the lines of a plan. Every line is checked again against
+// the current stock, so a plan that went stale since it was made is refused
+// rather than drawing reserved or short dated stock. The caller is expected
+// to run this under a transaction so the movements are committed together.
+func (c *Core) Confirm(ctx context.Context, conf Confirmation) ([]stockbus.Movement, error) {
+	if len(conf.Lines) == 0 {
+		return nil, ErrNoLines
+	}
+
+	now := time.Now()
+
+	usableFrom, err := c.usableFrom(now, conf.MinShelfLife)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		inventoryID uuid.UUID
+		lotID       uuid.UUID
+	}
+
+	requested := make(map[key]int)
+	var inventoryIDs []uuid.UUID
+	for _, line := range conf.Lines {
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: lot[%s] quantity[%d]", ErrInvalidQuantity, line.LotID, line.Quantity)
+		}
+
+		requested[key{line.InventoryID, line.LotID}] += line.Quantity
+		if !slices.Contains(inventoryIDs, line.InventoryID) {
+			inventoryIDs = append(inventoryIDs, line.InventoryID)
+		}
+	}
+
+	// Inventories are locked in a fixed order so two confirmations drawing
+	// from the same inventories can't deadlock.
+	slices.SortFunc(inventoryIDs, func(a, b uuid.UUID) int {
+		return slices.Compare(a[:], b[:])
+	})
+
+	available := make(map[key]int)
+	for _, inventoryID := range inventoryIDs {
+		cands, err := c.storer.QueryCandidatesForUpdate(ctx, conf.MedicineID, inventoryID, usableFrom, now)
+		if err != nil {
+			return nil, fmt.Errorf("querycandidatesforupdate: inventoryID[%s]: %w", inventoryID, err)
+		}
+
+		for _, cand := range free(cands) {
+			available[key{cand.InventoryID, cand.LotID}] = cand.OnHand
+		}
+	}
+
+	for k, qty := range requested {
+		if available[k] < qty {
+			return nil, fmt.Errorf("%w: inventory[%s] lot[%s] requested[%d] available[%d]", ErrUnavailable, k.inventoryID, k.lotID, qty, available[k])
+		}
+	}
+
+	movs := make([]stockbus.Movement, len(conf.Lines))
+	for i, line := range conf.Lines {
+		nm := stockbus.NewMovement{
+			InventoryID:     line.InventoryID,
+			LotID:           line.LotID,
+			Type:            stockbus.TypeDispense,
+			Quantity:        line.Quantity,
+			Reason:          conf.Reason,
+			UserID:          conf.UserID,
+			CountersignedBy: conf.CountersignedBy,
+		}
+
+		mov, err := c.stockCore.Create(ctx, nm)
+		if err != nil {
+			return nil, fmt.Errorf("stock.create: inventory[%s] lot[%s]: %w", line.InventoryID, line.LotID, err)
+		}
+
+		movs[i] = mov
+	}
+
+	return movs, nil
+}
+
+// =============================================================================
+
+// usableFrom returns the earliest expiry date a lot may have to be allocated.
+func (c *Core) usableFrom(now time.Time, minShelfLife *time.Duration) (time.Time, error) {
+	shelfLife := c.minShelfLife
+	if minShelfLife != nil {
+		shelfLife = *minShelfLife
+	}
+
+	if shelfLife < 0 {
+		return time.Time{}, ErrInvalidShelfLife
+	}
+
+	return now.Add(shelfLife), nil
+}
+
+// free returns the candidates with the stock held by reservations taken out.
+// Reservations don't name a lot, they are assumed to draw the lots of their
+// inventory that expire first like any other allocation. Candidates are
+// expected in expiry order and the ones left with nothing are dropped.
+func free(cands []Candidate) []Candidate {
+	reserved := make(map[uuid.UUID]int)
+	for _, cand := range cands {
+		reserved[cand.InventoryID] = cand.Reserved
+	}
+
+	var items []Candidate
+	for _, cand := range cands {
+		held := min(cand.OnHand, reserved[cand.InventoryID])
+		reserved[cand.InventoryID] -= held
+
+		cand.OnHand -= held
+		cand.Reserved = held
+		if cand.OnHand > 0 {
+			items = append(items, cand)
+		}
+	}
+
+	return items
+}
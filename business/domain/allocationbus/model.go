@@ -0,0 +1,72 @@
+package allocationbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Request represents an outbound request for a quantity of a medicine. The
+// allocation can be narrowed to a single inventory. MinShelfLife overrides
+// the minimum remaining shelf life the core was configured with.
+type Request struct {
+	MedicineID   uuid.UUID
+	Quantity     int
+	InventoryID  *uuid.UUID
+	MinShelfLife *time.Duration
+}
+
+// Candidate represents stock of a lot held by an inventory that may be drawn
+// from. Reserved is the quantity of the medicine held by active reservations
+// in the inventory, so it is the same for every candidate of the inventory.
+type Candidate struct {
+	InventoryID uuid.UUID
+	LotID       uuid.UUID
+	LotNumber   string
+	ExpiryDate  time.Time
+	OnHand      int
+	Reserved    int
+}
+
+// Line represents the quantity drawn from a lot held by an inventory.
+type Line struct {
+	InventoryID uuid.UUID
+	LotID       uuid.UUID
+	LotNumber   string
+	ExpiryDate  time.Time
+	Quantity    int
+}
+
+// Plan represents the lots picked to fulfil a request, the first to expire
+// first. Allocated falls short of Quantity when there isn't enough stock
+// with the minimum remaining shelf life.
+type Plan struct {
+	MedicineID uuid.UUID
+	Quantity   int
+	Allocated  int
+	UsableFrom time.Time
+	Lines      []Line
+}
+
+// Shortfall returns the quantity of the request that could not be allocated.
+func (p Plan) Shortfall() int {
+	return p.Quantity - p.Allocated
+}
+
+// ConfirmationLine defines the quantity to draw from a lot held by an
+// inventory.
+type ConfirmationLine struct {
+	InventoryID uuid.UUID
+	LotID       uuid.UUID
+	Quantity    int
+}
+
+// Confirmation contains the lines of a plan to turn into stock movements.
+type Confirmation struct {
+	MedicineID      uuid.UUID
+	Lines           []ConfirmationLine
+	MinShelfLife    *time.Duration
+	Reason          string
+	UserID          uuid.UUID
+	CountersignedBy uuid.UUID
+}
@@ -0,0 +1,150 @@
+// Package allocationdb contains allocation related database functionality.
+package allocationdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/allocationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// candidatesQuery returns every lot of a medicine with available stock that
+// expires no earlier than usable_from, together with the quantity of the
+// medicine held by active reservations in the inventory.
+const candidatesQuery = `
+	SELECT
+		it.inventory_id, l.lot_id, l.lot_number, l.expiry_date, it.quantity - it.quarantined - it.damaged - it.expired AS on_hand,
+		COALESCE((
+			SELECT
+				SUM(r.quantity)
+			FROM
+				reservations r
+			WHERE
+				r.inventory_id = it.inventory_id AND
+				r.medicine_id = it.medicine_id AND
+				r.status = :status AND
+				r.date_expires > :now
+		), 0) AS reserved
+	FROM
+		inventory_items it
+	JOIN
+		lots l ON l.lot_id = it.lot_id
+	WHERE
+		it.medicine_id = :medicine_id AND
+		it.quantity - it.quarantined - it.damaged - it.expired > 0 AND
+		l.expiry_date >= :usable_from`
+
+// candidatesOrder sorts the candidates first to expire first. Ties are broken
+// so the same stock always produces the same plan.
+const candidatesOrder = `
+	ORDER BY
+		l.expiry_date, it.inventory_id, l.lot_number`
+
+// Store manages the set of APIs for allocation database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Store value replacing the sqlx DB
+// value with a sqlx DB value that is currently inside a transaction.
+func (s *Store) ExecuteUnderTransaction(tx transaction.Transaction) (allocationbus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	store := Store{
+		log: s.log,
+		db:  ec,
+	}
+
+	return &store, nil
+}
+
+// QueryCandidates gets the lots of the medicine that may be allocated, in
+// expiry order. The inventory is optional.
+func (s *Store) QueryCandidates(ctx context.Context, medicineID uuid.UUID, inventoryID *uuid.UUID, usableFrom time.Time, now time.Time) ([]allocationbus.Candidate, error) {
+	data := map[string]interface{}{
+		"medicine_id": medicineID.String(),
+		"status":      reservationbus.StatusHeld.Name(),
+		"usable_from": usableFrom.UTC(),
+		"now":         now.UTC(),
+	}
+
+	q := candidatesQuery
+	if inventoryID != nil {
+		data["inventory_id"] = inventoryID.String()
+		q += ` AND
+		it.inventory_id = :inventory_id`
+	}
+
+	var dbCands []dbCandidate
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q+candidatesOrder, data, &dbCands); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreCandidateSlice(dbCands), nil
+}
+
+// QueryCandidatesForUpdate gets the lots of the medicine held by the inventory
+// that may be allocated and locks its stock until the surrounding transaction
+// ends. The lock is taken first in its own statement so the reservations
+// summed afterwards include any committed while waiting for it.
+func (s *Store) QueryCandidatesForUpdate(ctx context.Context, medicineID uuid.UUID, inventoryID uuid.UUID, usableFrom time.Time, now time.Time) ([]allocationbus.Candidate, error) {
+	data := struct {
+		MedicineID  string    `db:"medicine_id"`
+		InventoryID string    `db:"inventory_id"`
+		Status      string    `db:"status"`
+		UsableFrom  time.Time `db:"usable_from"`
+		Now         time.Time `db:"now"`
+	}{
+		MedicineID:  medicineID.String(),
+		InventoryID: inventoryID.String(),
+		Status:      reservationbus.StatusHeld.Name(),
+		UsableFrom:  usableFrom.UTC(),
+		Now:         now.UTC(),
+	}
+
+	const ql = `
+	SELECT
+		lot_id
+	FROM
+		inventory_items
+	WHERE
+		inventory_id = :inventory_id AND
+		medicine_id = :medicine_id
+	FOR UPDATE`
+
+	var locked []struct {
+		LotID uuid.UUID `db:"lot_id"`
+	}
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, ql, data, &locked); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: lock: %w", err)
+	}
+
+	const q = candidatesQuery + ` AND
+		it.inventory_id = :inventory_id` + candidatesOrder
+
+	var dbCands []dbCandidate
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbCands); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreCandidateSlice(dbCands), nil
+}
@@ -0,0 +1,37 @@
+package allocationdb
+
+import (
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/allocationbus"
+	"github.com/google/uuid"
+)
+
+type dbCandidate struct {
+	InventoryID uuid.UUID `db:"inventory_id"`
+	LotID       uuid.UUID `db:"lot_id"`
+	LotNumber   string    `db:"lot_number"`
+	ExpiryDate  time.Time `db:"expiry_date"`
+	OnHand      int       `db:"on_hand"`
+	Reserved    int       `db:"reserved"`
+}
+
+func toCoreCandidate(dbCand dbCandidate) allocationbus.Candidate {
+	return allocationbus.Candidate{
+		InventoryID: dbCand.InventoryID,
+		LotID:       dbCand.LotID,
+		LotNumber:   dbCand.LotNumber,
+		ExpiryDate:  dbCand.ExpiryDate.In(time.Local),
+		OnHand:      dbCand.OnHand,
+		Reserved:    dbCand.Reserved,
+	}
+}
+
+func toCoreCandidateSlice(dbCands []dbCandidate) []allocationbus.Candidate {
+	cands := make([]allocationbus.Candidate, len(dbCands))
+	for i, dbCand := range dbCands {
+		cands[i] = toCoreCandidate(dbCand)
+	}
+
+	return cands
+}
@@ -0,0 +1,342 @@
+// Package disposalbus provides the business API for disposals. A disposal
+// takes expired, damaged or otherwise unusable stock out of an inventory and
+// keeps the evidence pharmacy regulations ask for: what was destroyed, when,
+// how, by whom and who witnessed it, along with the certificate of
+// destruction once it is issued.
+package disposalbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound            = errors.New("disposal not found")
+	ErrNoLines             = errors.New("disposal has no lines")
+	ErrInvalidQuantity     = errors.New("invalid disposal quantity")
+	ErrStatusRequired      = errors.New("disposal line status required")
+	ErrDuplicateLine       = errors.New("lot appears more than once for the same status and location")
+	ErrWitnessRequired     = errors.New("disposal witness required")
+	ErrSelfWitness         = errors.New("disposal can't be witnessed by the user disposing of the stock")
+	ErrWitnessDisabled     = errors.New("disposal witness is disabled")
+	ErrFutureDisposal      = errors.New("disposal date is in the future")
+	ErrCertificateRequired = errors.New("certificate number required")
+	ErrCertificateIssued   = errors.New("disposal already has a certificate")
+	ErrInvalidPeriod       = errors.New("register period ends before it starts")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	ExecuteUnderTransaction(tx transaction.Transaction) (Storer, error)
+	Create(ctx context.Context, dsp Disposal) error
+	Update(ctx context.Context, dsp Disposal) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Disposal, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, disposalID uuid.UUID) (Disposal, error)
+	QueryRegister(ctx context.Context, filter RegisterFilter) ([]RegisterEntry, error)
+}
+
+// Core manages the set of APIs for disposal access.
+type Core struct {
+	log       *logger.Logger
+	userCore  *userbus.Core
+	lotCore   *lotbus.Core
+	stockCore *stockbus.Core
+	delegate  *delegate.Delegate
+	storer    Storer
+}
+
+// NewCore constructs a disposal core API for use.
+func NewCore(log *logger.Logger, userCore *userbus.Core, lotCore *lotbus.Core, stockCore *stockbus.Core, delegate *delegate.Delegate, storer Storer) *Core {
+	return &Core{
+		log:       log,
+		userCore:  userCore,
+		lotCore:   lotCore,
+		stockCore: stockCore,
+		delegate:  delegate,
+		storer:    storer,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Core value that will use the
+// specified transaction in any store related calls.
+func (c *Core) ExecuteUnderTransaction(tx transaction.Transaction) (*Core, error) {
+	storer, err := c.storer.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	userCore, err := c.userCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	lotCore, err := c.lotCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	stockCore, err := c.stockCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	core := Core{
+		log:       c.log,
+		userCore:  userCore,
+		lotCore:   lotCore,
+		stockCore: stockCore,
+		delegate:  c.delegate,
+		storer:    storer,
+	}
+
+	return &core, nil
+}
+
+// Create records a disposal and writes its lines off the inventory. The
+// caller is expected to run this under a transaction so the disposal and its
+// movements are committed together.
+func (c *Core) Create(ctx context.Context, nd NewDisposal) (Disposal, error) {
+	if len(nd.Lines) == 0 {
+		return Disposal{}, ErrNoLines
+	}
+
+	if nd.WitnessID == uuid.Nil {
+		return Disposal{}, ErrWitnessRequired
+	}
+
+	if nd.WitnessID == nd.UserID {
+		return Disposal{}, ErrSelfWitness
+	}
+
+	now := time.Now()
+
+	dateDisposed := nd.DateDisposed
+	switch {
+	case dateDisposed.IsZero():
+		dateDisposed = now
+	case dateDisposed.After(now):
+		return Disposal{}, ErrFutureDisposal
+	}
+
+	type lineKey struct {
+		lotID      uuid.UUID
+		locationID uuid.UUID
+		status     inventorybus.Status
+	}
+
+	seen := make(map[lineKey]bool, len(nd.Lines))
+	lotIDs := make([]uuid.UUID, 0, len(nd.Lines))
+	for _, nl := range nd.Lines {
+		if nl.Quantity <= 0 {
+			return Disposal{}, fmt.Errorf("%w: lot[%s] quantity[%d]", ErrInvalidQuantity, nl.LotID, nl.Quantity)
+		}
+
+		if nl.Status == (inventorybus.Status{}) {
+			return Disposal{}, fmt.Errorf("%w: lot[%s]", ErrStatusRequired, nl.LotID)
+		}
+
+		key := lineKey{nl.LotID, nl.LocationID, nl.Status}
+		if seen[key] {
+			return Disposal{}, fmt.Errorf("%w: lot[%s]", ErrDuplicateLine, nl.LotID)
+		}
+		seen[key] = true
+
+		lotIDs = appendUnique(lotIDs, nl.LotID)
+	}
+
+	witness, err := c.userCore.QueryByID(ctx, nd.WitnessID)
+	if err != nil {
+		return Disposal{}, fmt.Errorf("user.querybyid: %s: %w", nd.WitnessID, err)
+	}
+
+	if !witness.Enabled {
+		return Disposal{}, ErrWitnessDisabled
+	}
+
+	lots, err := c.lotCore.QueryByIDs(ctx, lotIDs)
+	if err != nil {
+		return Disposal{}, fmt.Errorf("lot.querybyids: %w", err)
+	}
+
+	if len(lots) != len(lotIDs) {
+		return Disposal{}, fmt.Errorf("lot.querybyids: %w", lotbus.ErrNotFound)
+	}
+
+	medicines := make(map[uuid.UUID]uuid.UUID, len(lots))
+	for _, lot := range lots {
+		medicines[lot.ID] = lot.MedicineID
+	}
+
+	dsp := Disposal{
+		ID:           uuid.New(),
+		InventoryID:  nd.InventoryID,
+		Method:       nd.Method,
+		Reason:       nd.Reason,
+		Lines:        make([]Line, len(nd.Lines)),
+		DisposedBy:   nd.UserID,
+		WitnessedBy:  nd.WitnessID,
+		Certificate:  nd.Certificate,
+		DateDisposed: dateDisposed,
+		DateCreated:  now,
+		DateUpdated:  now,
+	}
+
+	for i, nl := range nd.Lines {
+		nm := stockbus.NewMovement{
+			InventoryID:     nd.InventoryID,
+			LotID:           nl.LotID,
+			LocationID:      nl.LocationID,
+			Status:          nl.Status,
+			Type:            stockbus.TypeWriteOff,
+			Quantity:        nl.Quantity,
+			Reason:          reference(dsp),
+			Reference:       stockbus.Reference{Type: stockbus.ReferenceDisposal, ID: dsp.ID},
+			UserID:          nd.UserID,
+			CountersignedBy: nd.WitnessID,
+		}
+
+		if _, err := c.stockCore.Create(ctx, nm); err != nil {
+			return Disposal{}, fmt.Errorf("stock.create: lot[%s]: %w", nl.LotID, err)
+		}
+
+		dsp.Lines[i] = Line{
+			MedicineID: medicines[nl.LotID],
+			LotID:      nl.LotID,
+			LocationID: nl.LocationID,
+			Status:     nl.Status,
+			Quantity:   nl.Quantity,
+		}
+	}
+
+	if err := c.storer.Create(ctx, dsp); err != nil {
+		return Disposal{}, fmt.Errorf("create: %w", err)
+	}
+
+	return dsp, nil
+}
+
+// Certify attaches the certificate of destruction to a disposal. A
+// certificate can't be replaced once it is attached.
+func (c *Core) Certify(ctx context.Context, dsp Disposal, cert Certificate) (Disposal, error) {
+	if dsp.Certificate.Issued() {
+		return Disposal{}, ErrCertificateIssued
+	}
+
+	if !cert.Issued() {
+		return Disposal{}, ErrCertificateRequired
+	}
+
+	dsp.Certificate = cert
+	dsp.DateUpdated = time.Now()
+
+	if err := c.storer.Update(ctx, dsp); err != nil {
+		return Disposal{}, fmt.Errorf("update: %w", err)
+	}
+
+	return dsp, nil
+}
+
+// Query retrieves a list of existing disposals.
+func (c *Core) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Disposal, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	dsps, err := c.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return dsps, nil
+}
+
+// Count returns the total number of disposals.
+func (c *Core) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	return c.storer.Count(ctx, filter)
+}
+
+// QueryByID finds the disposal by the specified ID.
+func (c *Core) QueryByID(ctx context.Context, disposalID uuid.UUID) (Disposal, error) {
+	dsp, err := c.storer.QueryByID(ctx, disposalID)
+	if err != nil {
+		return Disposal{}, fmt.Errorf("query: disposalID[%s]: %w", disposalID, err)
+	}
+
+	return dsp, nil
+}
+
+// QueryRegister returns the disposal register of a period: every line
+// disposed of in order of disposal and the total disposed of per medicine.
+func (c *Core) QueryRegister(ctx context.Context, filter RegisterFilter) (Register, error) {
+	if filter.EndDate.Before(filter.StartDate) {
+		return Register{}, ErrInvalidPeriod
+	}
+
+	entries, err := c.storer.QueryRegister(ctx, filter)
+	if err != nil {
+		return Register{}, fmt.Errorf("queryregister: %w", err)
+	}
+
+	totals := make(map[uuid.UUID]int)
+	for _, e := range entries {
+		totals[e.MedicineID] += e.Quantity
+	}
+
+	rgs := Register{
+		StartDate: filter.StartDate,
+		EndDate:   filter.EndDate,
+		Entries:   entries,
+		Totals:    make([]RegisterTotal, 0, len(totals)),
+	}
+
+	for medicineID, quantity := range totals {
+		rgs.Totals = append(rgs.Totals, RegisterTotal{
+			MedicineID: medicineID,
+			Quantity:   quantity,
+		})
+	}
+
+	sort.Slice(rgs.Totals, func(i, j int) bool {
+		return rgs.Totals[i].MedicineID.String() < rgs.Totals[j].MedicineID.String()
+	})
+
+	return rgs, nil
+}
+
+// =============================================================================
+
+func appendUnique(ids []uuid.UUID, id uuid.UUID) []uuid.UUID {
+	for _, v := range ids {
+		if v == id {
+			return ids
+		}
+	}
+
+	return append(ids, id)
+}
+
+// reference returns the reason recorded on the movements of a disposal so
+// the ledger can be traced back to it.
+func reference(dsp Disposal) string {
+	return fmt.Sprintf("disposal %s: %s", dsp.ID, dsp.Method.Name())
+}
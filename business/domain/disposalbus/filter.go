@@ -0,0 +1,80 @@
+package disposalbus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryFilter holds the available fields a query can be filtered on.
+// We are using pointer semantics because the With API mutates the value.
+type QueryFilter struct {
+	ID                *uuid.UUID
+	InventoryID       *uuid.UUID
+	Method            *Method
+	WitnessID         *uuid.UUID
+	StartDisposedDate *time.Time
+	EndDisposedDate   *time.Time
+}
+
+// Validate can perform a check of the data against the validate tags.
+func (qf *QueryFilter) Validate() error {
+	if err := validate.Check(qf); err != nil {
+		return fmt.Errorf("validate: %w", err)
+	}
+
+	return nil
+}
+
+// WithDisposalID sets the ID field of the QueryFilter value.
+func (qf *QueryFilter) WithDisposalID(disposalID uuid.UUID) {
+	qf.ID = &disposalID
+}
+
+// WithInventoryID sets the InventoryID field of the QueryFilter value.
+func (qf *QueryFilter) WithInventoryID(inventoryID uuid.UUID) {
+	qf.InventoryID = &inventoryID
+}
+
+// WithMethod sets the Method field of the QueryFilter value.
+func (qf *QueryFilter) WithMethod(method Method) {
+	qf.Method = &method
+}
+
+// WithWitnessID sets the WitnessID field of the QueryFilter value.
+func (qf *QueryFilter) WithWitnessID(witnessID uuid.UUID) {
+	qf.WitnessID = &witnessID
+}
+
+// WithStartDisposedDate sets the StartDisposedDate field of the QueryFilter value.
+func (qf *QueryFilter) WithStartDisposedDate(startDate time.Time) {
+	d := startDate.UTC()
+	qf.StartDisposedDate = &d
+}
+
+// WithEndDisposedDate sets the EndDisposedDate field of the QueryFilter value.
+func (qf *QueryFilter) WithEndDisposedDate(endDate time.Time) {
+	d := endDate.UTC()
+	qf.EndDisposedDate = &d
+}
+
+// RegisterFilter holds the available fields the disposal register can be
+// filtered on. The period is always required.
+type RegisterFilter struct {
+	StartDate   time.Time
+	EndDate     time.Time
+	InventoryID *uuid.UUID
+	MedicineID  *uuid.UUID
+}
+
+// WithInventoryID sets the InventoryID field of the RegisterFilter value.
+func (rf *RegisterFilter) WithInventoryID(inventoryID uuid.UUID) {
+	rf.InventoryID = &inventoryID
+}
+
+// WithMedicineID sets the MedicineID field of the RegisterFilter value.
+func (rf *RegisterFilter) WithMedicineID(medicineID uuid.UUID) {
+	rf.MedicineID = &medicineID
+}
@@ -0,0 +1,69 @@
+package disposalbus
+
+import "fmt"
+
+// Set of possible ways stock is destroyed.
+var (
+	MethodIncineration    = Method{"INCINERATION"}
+	MethodDenaturing      = Method{"DENATURING"}
+	MethodWasteContractor = Method{"WASTE_CONTRACTOR"}
+)
+
+// Set of known methods.
+var methods = map[string]Method{
+	MethodIncineration.name:    MethodIncineration,
+	MethodDenaturing.name:      MethodDenaturing,
+	MethodWasteContractor.name: MethodWasteContractor,
+}
+
+// Method represents how disposed stock was destroyed.
+type Method struct {
+	name string
+}
+
+// ParseMethod parses the string value and returns a method if one exists.
+func ParseMethod(value string) (Method, error) {
+	method, exists := methods[value]
+	if !exists {
+		return Method{}, fmt.Errorf("invalid method %q", value)
+	}
+
+	return method, nil
+}
+
+// MustParseMethod parses the string value and returns a method if one
+// exists. If an error occurs the function panics.
+func MustParseMethod(value string) Method {
+	method, err := ParseMethod(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return method
+}
+
+// Name returns the name of the method.
+func (m Method) Name() string {
+	return m.name
+}
+
+// UnmarshalText implement the unmarshal interface for JSON conversions.
+func (m *Method) UnmarshalText(data []byte) error {
+	method, err := ParseMethod(string(data))
+	if err != nil {
+		return err
+	}
+
+	m.name = method.name
+	return nil
+}
+
+// MarshalText implement the marshal interface for JSON conversions.
+func (m Method) MarshalText() ([]byte, error) {
+	return []byte(m.name), nil
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (m Method) Equal(m2 Method) bool {
+	return m.name == m2.name
+}
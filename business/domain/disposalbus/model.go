@@ -0,0 +1,102 @@
+package disposalbus
+
+import (
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/google/uuid"
+)
+
+// Disposal represents stock destroyed in an inventory together with the
+// evidence regulations ask for: how it was destroyed, who destroyed it and
+// who witnessed it.
+type Disposal struct {
+	ID           uuid.UUID
+	InventoryID  uuid.UUID
+	Method       Method
+	Reason       string
+	Lines        []Line
+	DisposedBy   uuid.UUID
+	WitnessedBy  uuid.UUID
+	Certificate  Certificate
+	DateDisposed time.Time
+	DateCreated  time.Time
+	DateUpdated  time.Time
+}
+
+// Line represents the quantity of a lot taken out of a status bucket by a
+// disposal.
+type Line struct {
+	MedicineID uuid.UUID
+	LotID      uuid.UUID
+	LocationID uuid.UUID
+	Status     inventorybus.Status
+	Quantity   int
+}
+
+// Certificate represents the certificate of destruction issued for a
+// disposal. It is usually issued by the waste contractor after the fact, the
+// zero value means none was issued yet.
+type Certificate struct {
+	Number     string
+	Issuer     string
+	DateIssued time.Time
+}
+
+// Issued reports whether the certificate was issued.
+func (c Certificate) Issued() bool {
+	return c.Number != ""
+}
+
+// NewDisposal contains information needed to record a disposal. DateDisposed
+// defaults to now when it is left out.
+type NewDisposal struct {
+	InventoryID  uuid.UUID
+	Method       Method
+	Reason       string
+	Lines        []NewLine
+	WitnessID    uuid.UUID
+	Certificate  Certificate
+	DateDisposed time.Time
+	UserID       uuid.UUID
+}
+
+// NewLine contains the quantity of a lot to take out of a status bucket.
+// LocationID is left out for stock that is not put away.
+type NewLine struct {
+	LotID      uuid.UUID
+	LocationID uuid.UUID
+	Status     inventorybus.Status
+	Quantity   int
+}
+
+// RegisterEntry represents a line of the disposal register.
+type RegisterEntry struct {
+	DisposalID        uuid.UUID
+	InventoryID       uuid.UUID
+	MedicineID        uuid.UUID
+	LotID             uuid.UUID
+	Status            inventorybus.Status
+	Quantity          int
+	Method            Method
+	DisposedBy        uuid.UUID
+	WitnessedBy       uuid.UUID
+	CertificateNumber string
+	DateDisposed      time.Time
+}
+
+// RegisterTotal represents the quantity of a medicine disposed of over the
+// period of a register.
+type RegisterTotal struct {
+	MedicineID uuid.UUID
+	Quantity   int
+}
+
+// Register represents every disposal over a period, line by line and in
+// total per medicine.
+type Register struct {
+	StartDate time.Time
+	EndDate   time.Time
+	Entries   []RegisterEntry
+	Totals    []RegisterTotal
+}
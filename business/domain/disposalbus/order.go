@@ -0,0 +1,13 @@
+package disposalbus
+
+import "github.com/EnesDemirtas/medisync/business/api/order"
+
+// DefaultOrderBy represents the default way we sort.
+var DefaultOrderBy = order.NewBy(OrderByDateDisposed, order.DESC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID           = "disposal_id"
+	OrderByMethod       = "method"
+	OrderByDateDisposed = "date_disposed"
+)
@@ -0,0 +1,262 @@
+// Package disposaldb contains disposal related CRUD functionality.
+package disposaldb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb/dbarray"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/disposalbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Store manages the set of APIs for disposal database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Store value replacing the sqlx DB
+// value with a sqlx DB value that is currently inside a transaction.
+func (s *Store) ExecuteUnderTransaction(tx transaction.Transaction) (disposalbus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	store := Store{
+		log: s.log,
+		db:  ec,
+	}
+
+	return &store, nil
+}
+
+// Create inserts a new disposal and its lines into the database.
+func (s *Store) Create(ctx context.Context, dsp disposalbus.Disposal) error {
+	const q = `
+	INSERT INTO disposals
+		(disposal_id, inventory_id, method, reason, disposed_by, witnessed_by, certificate_number, certificate_issuer, certificate_date_issued, date_disposed, date_created, date_updated)
+	VALUES
+		(:disposal_id, :inventory_id, :method, :reason, :disposed_by, :witnessed_by, :certificate_number, :certificate_issuer, :certificate_date_issued, :date_disposed, :date_created, :date_updated)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBDisposal(dsp)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	const qLine = `
+	INSERT INTO disposal_lines
+		(disposal_id, line_number, medicine_id, lot_id, location_id, status, quantity)
+	VALUES
+		(:disposal_id, :line_number, :medicine_id, :lot_id, :location_id, :status, :quantity)`
+
+	for _, dbLine := range toDBLines(dsp.ID, dsp.Lines) {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, qLine, dbLine); err != nil {
+			return fmt.Errorf("namedexeccontext: line: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Update replaces the certificate of a disposal in the database. The lines
+// of a disposal never change once it is recorded.
+func (s *Store) Update(ctx context.Context, dsp disposalbus.Disposal) error {
+	const q = `
+	UPDATE
+		disposals
+	SET
+		"certificate_number" = :certificate_number,
+		"certificate_issuer" = :certificate_issuer,
+		"certificate_date_issued" = :certificate_date_issued,
+		"date_updated" = :date_updated
+	WHERE
+		disposal_id = :disposal_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBDisposal(dsp)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of existing disposals from the database.
+func (s *Store) Query(ctx context.Context, filter disposalbus.QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]disposalbus.Disposal, error) {
+	data := map[string]interface{}{
+		"offset":        (pageNumber - 1) * rowsPerPage,
+		"rows_per_page": rowsPerPage,
+	}
+
+	const q = `
+	SELECT
+		disposal_id, inventory_id, method, reason, disposed_by, witnessed_by, certificate_number, certificate_issuer, certificate_date_issued, date_disposed, date_created, date_updated
+	FROM
+		disposals`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbDsps []dbDisposal
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbDsps); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	ids := make([]string, len(dbDsps))
+	for i, dbDsp := range dbDsps {
+		ids[i] = dbDsp.ID.String()
+	}
+
+	dbLines, err := s.queryLines(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCoreDisposalSlice(dbDsps, dbLines)
+}
+
+// Count returns the total number of disposals in the database.
+func (s *Store) Count(ctx context.Context, filter disposalbus.QueryFilter) (int, error) {
+	data := map[string]interface{}{}
+
+	const q = `
+	SELECT
+		count(1)
+	FROM
+		disposals`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID gets the specified disposal from the database.
+func (s *Store) QueryByID(ctx context.Context, disposalID uuid.UUID) (disposalbus.Disposal, error) {
+	data := struct {
+		ID string `db:"disposal_id"`
+	}{
+		ID: disposalID.String(),
+	}
+
+	const q = `
+	SELECT
+		disposal_id, inventory_id, method, reason, disposed_by, witnessed_by, certificate_number, certificate_issuer, certificate_date_issued, date_disposed, date_created, date_updated
+	FROM
+		disposals
+	WHERE
+		disposal_id = :disposal_id`
+
+	var dbDsp dbDisposal
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbDsp); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return disposalbus.Disposal{}, fmt.Errorf("db: %w", disposalbus.ErrNotFound)
+		}
+		return disposalbus.Disposal{}, fmt.Errorf("db: %w", err)
+	}
+
+	dbLines, err := s.queryLines(ctx, []string{dbDsp.ID.String()})
+	if err != nil {
+		return disposalbus.Disposal{}, err
+	}
+
+	return toCoreDisposal(dbDsp, dbLines)
+}
+
+// QueryRegister returns every line disposed of over the period of the filter
+// in order of disposal.
+func (s *Store) QueryRegister(ctx context.Context, filter disposalbus.RegisterFilter) ([]disposalbus.RegisterEntry, error) {
+	data := map[string]interface{}{
+		"start_date": filter.StartDate.UTC(),
+		"end_date":   filter.EndDate.UTC(),
+	}
+
+	const q = `
+	SELECT
+		d.disposal_id, d.inventory_id, dl.medicine_id, dl.lot_id, dl.status, dl.quantity,
+		d.method, d.disposed_by, d.witnessed_by, d.certificate_number, d.date_disposed
+	FROM
+		disposals AS d
+	JOIN
+		disposal_lines AS dl ON dl.disposal_id = d.disposal_id
+	WHERE
+		d.date_disposed >= :start_date AND d.date_disposed <= :end_date`
+
+	buf := bytes.NewBufferString(q)
+
+	if filter.InventoryID != nil {
+		data["inventory_id"] = *filter.InventoryID
+		buf.WriteString(" AND d.inventory_id = :inventory_id")
+	}
+
+	if filter.MedicineID != nil {
+		data["medicine_id"] = *filter.MedicineID
+		buf.WriteString(" AND dl.medicine_id = :medicine_id")
+	}
+
+	buf.WriteString(" ORDER BY d.date_disposed, d.disposal_id, dl.line_number")
+
+	var dbEntries []dbRegisterEntry
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbEntries); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreRegisterEntrySlice(dbEntries)
+}
+
+// =============================================================================
+
+// queryLines returns the lines of the disposals.
+func (s *Store) queryLines(ctx context.Context, disposalIDs []string) ([]dbLine, error) {
+	data := struct {
+		ID any `db:"disposal_id"`
+	}{
+		ID: dbarray.Array(disposalIDs),
+	}
+
+	const q = `
+	SELECT
+		disposal_id, line_number, medicine_id, lot_id, location_id, status, quantity
+	FROM
+		disposal_lines
+	WHERE
+		disposal_id = ANY(:disposal_id)
+	ORDER BY
+		disposal_id, line_number`
+
+	var dbLines []dbLine
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbLines); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: lines: %w", err)
+	}
+
+	return dbLines, nil
+}
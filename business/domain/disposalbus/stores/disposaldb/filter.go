@@ -0,0 +1,47 @@
+package disposaldb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/EnesDemirtas/medisync/business/domain/disposalbus"
+)
+
+func applyFilter(filter disposalbus.QueryFilter, data map[string]interface{}, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["disposal_id"] = *filter.ID
+		wc = append(wc, "disposal_id = :disposal_id")
+	}
+
+	if filter.InventoryID != nil {
+		data["inventory_id"] = *filter.InventoryID
+		wc = append(wc, "inventory_id = :inventory_id")
+	}
+
+	if filter.Method != nil {
+		data["method"] = filter.Method.Name()
+		wc = append(wc, "method = :method")
+	}
+
+	if filter.WitnessID != nil {
+		data["witnessed_by"] = *filter.WitnessID
+		wc = append(wc, "witnessed_by = :witnessed_by")
+	}
+
+	if filter.StartDisposedDate != nil {
+		data["start_date_disposed"] = *filter.StartDisposedDate
+		wc = append(wc, "date_disposed >= :start_date_disposed")
+	}
+
+	if filter.EndDisposedDate != nil {
+		data["end_date_disposed"] = *filter.EndDisposedDate
+		wc = append(wc, "date_disposed <= :end_date_disposed")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
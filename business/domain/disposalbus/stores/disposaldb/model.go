@@ -0,0 +1,203 @@
+package disposaldb
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/disposalbus"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/google/uuid"
+)
+
+type dbDisposal struct {
+	ID                    uuid.UUID      `db:"disposal_id"`
+	InventoryID           uuid.UUID      `db:"inventory_id"`
+	Method                string         `db:"method"`
+	Reason                sql.NullString `db:"reason"`
+	DisposedBy            uuid.UUID      `db:"disposed_by"`
+	WitnessedBy           uuid.UUID      `db:"witnessed_by"`
+	CertificateNumber     sql.NullString `db:"certificate_number"`
+	CertificateIssuer     sql.NullString `db:"certificate_issuer"`
+	CertificateDateIssued sql.NullTime   `db:"certificate_date_issued"`
+	DateDisposed          time.Time      `db:"date_disposed"`
+	DateCreated           time.Time      `db:"date_created"`
+	DateUpdated           time.Time      `db:"date_updated"`
+}
+
+type dbLine struct {
+	DisposalID uuid.UUID     `db:"disposal_id"`
+	LineNumber int           `db:"line_number"`
+	MedicineID uuid.UUID     `db:"medicine_id"`
+	LotID      uuid.UUID     `db:"lot_id"`
+	LocationID uuid.NullUUID `db:"location_id"`
+	Status     string        `db:"status"`
+	Quantity   int           `db:"quantity"`
+}
+
+type dbRegisterEntry struct {
+	DisposalID        uuid.UUID      `db:"disposal_id"`
+	InventoryID       uuid.UUID      `db:"inventory_id"`
+	MedicineID        uuid.UUID      `db:"medicine_id"`
+	LotID             uuid.UUID      `db:"lot_id"`
+	Status            string         `db:"status"`
+	Quantity          int            `db:"quantity"`
+	Method            string         `db:"method"`
+	DisposedBy        uuid.UUID      `db:"disposed_by"`
+	WitnessedBy       uuid.UUID      `db:"witnessed_by"`
+	CertificateNumber sql.NullString `db:"certificate_number"`
+	DateDisposed      time.Time      `db:"date_disposed"`
+}
+
+func toDBDisposal(dsp disposalbus.Disposal) dbDisposal {
+	return dbDisposal{
+		ID:                    dsp.ID,
+		InventoryID:           dsp.InventoryID,
+		Method:                dsp.Method.Name(),
+		Reason:                toNullString(dsp.Reason),
+		DisposedBy:            dsp.DisposedBy,
+		WitnessedBy:           dsp.WitnessedBy,
+		CertificateNumber:     toNullString(dsp.Certificate.Number),
+		CertificateIssuer:     toNullString(dsp.Certificate.Issuer),
+		CertificateDateIssued: toNullTime(dsp.Certificate.DateIssued),
+		DateDisposed:          dsp.DateDisposed.UTC(),
+		DateCreated:           dsp.DateCreated.UTC(),
+		DateUpdated:           dsp.DateUpdated.UTC(),
+	}
+}
+
+func toDBLines(disposalID uuid.UUID, lines []disposalbus.Line) []dbLine {
+	dbLines := make([]dbLine, len(lines))
+	for i, line := range lines {
+		dbLines[i] = dbLine{
+			DisposalID: disposalID,
+			LineNumber: i + 1,
+			MedicineID: line.MedicineID,
+			LotID:      line.LotID,
+			LocationID: uuid.NullUUID{
+				UUID:  line.LocationID,
+				Valid: line.LocationID != uuid.Nil,
+			},
+			Status:   line.Status.Name(),
+			Quantity: line.Quantity,
+		}
+	}
+
+	return dbLines
+}
+
+func toCoreDisposal(dbDsp dbDisposal, dbLines []dbLine) (disposalbus.Disposal, error) {
+	method, err := disposalbus.ParseMethod(dbDsp.Method)
+	if err != nil {
+		return disposalbus.Disposal{}, fmt.Errorf("parse method: %w", err)
+	}
+
+	lines := make([]disposalbus.Line, len(dbLines))
+	for i, dbLine := range dbLines {
+		status, err := inventorybus.ParseStatus(dbLine.Status)
+		if err != nil {
+			return disposalbus.Disposal{}, fmt.Errorf("parse status: %w", err)
+		}
+
+		lines[i] = disposalbus.Line{
+			MedicineID: dbLine.MedicineID,
+			LotID:      dbLine.LotID,
+			LocationID: dbLine.LocationID.UUID,
+			Status:     status,
+			Quantity:   dbLine.Quantity,
+		}
+	}
+
+	dsp := disposalbus.Disposal{
+		ID:          dbDsp.ID,
+		InventoryID: dbDsp.InventoryID,
+		Method:      method,
+		Reason:      dbDsp.Reason.String,
+		Lines:       lines,
+		DisposedBy:  dbDsp.DisposedBy,
+		WitnessedBy: dbDsp.WitnessedBy,
+		Certificate: disposalbus.Certificate{
+			Number:     dbDsp.CertificateNumber.String,
+			Issuer:     dbDsp.CertificateIssuer.String,
+			DateIssued: toCoreTime(dbDsp.CertificateDateIssued),
+		},
+		DateDisposed: dbDsp.DateDisposed.In(time.Local),
+		DateCreated:  dbDsp.DateCreated.In(time.Local),
+		DateUpdated:  dbDsp.DateUpdated.In(time.Local),
+	}
+
+	return dsp, nil
+}
+
+func toCoreDisposalSlice(dbDsps []dbDisposal, dbLines []dbLine) ([]disposalbus.Disposal, error) {
+	linesByDisposal := make(map[uuid.UUID][]dbLine, len(dbDsps))
+	for _, dbLine := range dbLines {
+		linesByDisposal[dbLine.DisposalID] = append(linesByDisposal[dbLine.DisposalID], dbLine)
+	}
+
+	dsps := make([]disposalbus.Disposal, len(dbDsps))
+	for i, dbDsp := range dbDsps {
+		var err error
+		dsps[i], err = toCoreDisposal(dbDsp, linesByDisposal[dbDsp.ID])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return dsps, nil
+}
+
+func toCoreRegisterEntrySlice(dbEntries []dbRegisterEntry) ([]disposalbus.RegisterEntry, error) {
+	entries := make([]disposalbus.RegisterEntry, len(dbEntries))
+	for i, dbE := range dbEntries {
+		status, err := inventorybus.ParseStatus(dbE.Status)
+		if err != nil {
+			return nil, fmt.Errorf("parse status: %w", err)
+		}
+
+		method, err := disposalbus.ParseMethod(dbE.Method)
+		if err != nil {
+			return nil, fmt.Errorf("parse method: %w", err)
+		}
+
+		entries[i] = disposalbus.RegisterEntry{
+			DisposalID:        dbE.DisposalID,
+			InventoryID:       dbE.InventoryID,
+			MedicineID:        dbE.MedicineID,
+			LotID:             dbE.LotID,
+			Status:            status,
+			Quantity:          dbE.Quantity,
+			Method:            method,
+			DisposedBy:        dbE.DisposedBy,
+			WitnessedBy:       dbE.WitnessedBy,
+			CertificateNumber: dbE.CertificateNumber.String,
+			DateDisposed:      dbE.DateDisposed.In(time.Local),
+		}
+	}
+
+	return entries, nil
+}
+
+// =============================================================================
+
+func toNullString(s string) sql.NullString {
+	return sql.NullString{
+		String: s,
+		Valid:  s != "",
+	}
+}
+
+func toNullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{
+		Time:  t.UTC(),
+		Valid: !t.IsZero(),
+	}
+}
+
+func toCoreTime(t sql.NullTime) time.Time {
+	if !t.Valid {
+		return time.Time{}
+	}
+
+	return t.Time.In(time.Local)
+}
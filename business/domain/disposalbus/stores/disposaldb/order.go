@@ -0,0 +1,23 @@
+package disposaldb
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/disposalbus"
+)
+
+var orderByFields = map[string]string{
+	disposalbus.OrderByID:           "disposal_id",
+	disposalbus.OrderByMethod:       "method",
+	disposalbus.OrderByDateDisposed: "date_disposed",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
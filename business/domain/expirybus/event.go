@@ -0,0 +1,68 @@
+package expirybus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/go-json-experiment/json"
+	"github.com/google/uuid"
+)
+
+// Domain represents the name of this domain.
+const Domain = "expiry"
+
+// Set of delegate actions.
+const (
+	ActionExpiring = "expiring"
+	ActionExpired  = "expired"
+)
+
+// ActionAlertParms represents the parameters for the expiring and expired
+// actions.
+type ActionAlertParms struct {
+	InventoryID uuid.UUID
+	LotID       uuid.UUID
+	MedicineID  uuid.UUID
+	ExpiryDate  time.Time
+	Quantity    int
+	Horizon     int
+}
+
+// String returns a string representation of the action parameters.
+func (aa *ActionAlertParms) String() string {
+	return fmt.Sprintf("&EventParamsAlert{InventoryID:%v, LotID:%v, Horizon:%v}", aa.InventoryID, aa.LotID, aa.Horizon)
+}
+
+// Marshal returns the event parameters encoded as JSON.
+func (aa *ActionAlertParms) Marshal() ([]byte, error) {
+	return json.Marshal(aa)
+}
+
+// ActionAlertData constructs the data for the action matching the alert.
+func ActionAlertData(alert Alert) delegate.Data {
+	params := ActionAlertParms{
+		InventoryID: alert.InventoryID,
+		LotID:       alert.LotID,
+		MedicineID:  alert.MedicineID,
+		ExpiryDate:  alert.ExpiryDate,
+		Quantity:    alert.Quantity,
+		Horizon:     alert.Horizon,
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	action := ActionExpiring
+	if alert.Expired() {
+		action = ActionExpired
+	}
+
+	return delegate.Data{
+		Domain:    Domain,
+		Action:    action,
+		RawParams: rawParams,
+	}
+}
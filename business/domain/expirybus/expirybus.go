@@ -0,0 +1,186 @@
+// Package expirybus provides the business API for watching stock that is
+// about to expire. Stock held by an inventory is checked against a set of
+// horizons and every horizon it enters is alerted once.
+package expirybus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrAlreadyAlerted = errors.New("stock already alerted for horizon")
+	ErrInvalidHorizon = errors.New("horizons must be positive number of days")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	ExecuteUnderTransaction(tx transaction.Transaction) (Storer, error)
+	Create(ctx context.Context, alert Alert) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Alert, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryHoldings(ctx context.Context, expiresBefore time.Time) ([]Holding, error)
+}
+
+// Core manages the set of APIs for expiry access.
+type Core struct {
+	log      *logger.Logger
+	delegate *delegate.Delegate
+	storer   Storer
+}
+
+// NewCore constructs an expiry core API for use.
+func NewCore(log *logger.Logger, delegate *delegate.Delegate, storer Storer) *Core {
+	return &Core{
+		log:      log,
+		delegate: delegate,
+		storer:   storer,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Core value that will use the
+// specified transaction in any store related calls.
+func (c *Core) ExecuteUnderTransaction(tx transaction.Transaction) (*Core, error) {
+	storer, err := c.storer.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	core := Core{
+		log:      c.log,
+		delegate: c.delegate,
+		storer:   storer,
+	}
+
+	return &core, nil
+}
+
+// Watch scans the stock every interval until the context is cancelled. The
+// first scan runs straight away.
+func (c *Core) Watch(ctx context.Context, interval time.Duration, horizons []int) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		alerts, err := c.Scan(ctx, time.Now(), horizons)
+		switch {
+		case err != nil:
+			c.log.Error(ctx, "expiry watcher", "status", "scan failed", "msg", err)
+		default:
+			c.log.Info(ctx, "expiry watcher", "status", "scan complete", "alerts", len(alerts))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Scan finds the stock expiring within the largest horizon and alerts every
+// holding that entered a shorter horizon, or expired, since the last scan.
+// The new alerts are returned.
+func (c *Core) Scan(ctx context.Context, now time.Time, horizons []int) ([]Alert, error) {
+	if len(horizons) == 0 {
+		return nil, ErrInvalidHorizon
+	}
+
+	horizons = slices.Clone(horizons)
+	slices.Sort(horizons)
+
+	if horizons[0] <= 0 {
+		return nil, ErrInvalidHorizon
+	}
+
+	holdings, err := c.storer.QueryHoldings(ctx, now.AddDate(0, 0, horizons[len(horizons)-1]))
+	if err != nil {
+		return nil, fmt.Errorf("queryholdings: %w", err)
+	}
+
+	var alerts []Alert
+	for _, hld := range holdings {
+		alert := Alert{
+			InventoryID: hld.InventoryID,
+			LotID:       hld.LotID,
+			MedicineID:  hld.MedicineID,
+			ExpiryDate:  hld.ExpiryDate,
+			Quantity:    hld.Quantity,
+			Horizon:     horizon(now, hld.ExpiryDate, horizons),
+			DateAlerted: now,
+		}
+
+		if err := c.storer.Create(ctx, alert); err != nil {
+			if errors.Is(err, ErrAlreadyAlerted) {
+				continue
+			}
+			return alerts, fmt.Errorf("create: inventoryID[%s] lotID[%s]: %w", alert.InventoryID, alert.LotID, err)
+		}
+
+		c.log.Warn(ctx, "expiry alert", "inventory_id", alert.InventoryID, "lot_id", alert.LotID, "medicine_id", alert.MedicineID,
+			"expiry_date", alert.ExpiryDate.Format(time.DateOnly), "quantity", alert.Quantity, "horizon", alert.Horizon, "expired", alert.Expired())
+
+		// Other domains may need to know when stock is about to expire so
+		// it can be used first or taken off the shelf. This represents a
+		// delegate call to other domains.
+		data := ActionAlertData(alert)
+		if err := c.delegate.Call(ctx, data); err != nil {
+			return alerts, fmt.Errorf("failed to execute `%s` action: %w", data.Action, err)
+		}
+
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, nil
+}
+
+// Query retrieves a list of existing alerts.
+func (c *Core) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Alert, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	alerts, err := c.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// Count returns the total number of alerts.
+func (c *Core) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	return c.storer.Count(ctx, filter)
+}
+
+// =============================================================================
+
+// horizon returns the shortest of the sorted horizons the expiry date falls
+// within, or zero if the date has already passed.
+func horizon(now time.Time, expiryDate time.Time, horizons []int) int {
+	if !expiryDate.After(now) {
+		return 0
+	}
+
+	for _, h := range horizons {
+		if expiryDate.Before(now.AddDate(0, 0, h)) {
+			return h
+		}
+	}
+
+	return horizons[len(horizons)-1]
+}
@@ -0,0 +1,46 @@
+package expirybus
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryFilter holds the available fields a query can be filtered on.
+// We are using pointer semantics because the With API mutates the value.
+type QueryFilter struct {
+	InventoryID *uuid.UUID
+	LotID       *uuid.UUID
+	MedicineID  *uuid.UUID
+	Expired     *bool
+}
+
+// Validate can perform a check of the data against the validate tags.
+func (qf *QueryFilter) Validate() error {
+	if err := validate.Check(qf); err != nil {
+		return fmt.Errorf("validate: %w", err)
+	}
+
+	return nil
+}
+
+// WithInventoryID sets the InventoryID field of the QueryFilter value.
+func (qf *QueryFilter) WithInventoryID(inventoryID uuid.UUID) {
+	qf.InventoryID = &inventoryID
+}
+
+// WithLotID sets the LotID field of the QueryFilter value.
+func (qf *QueryFilter) WithLotID(lotID uuid.UUID) {
+	qf.LotID = &lotID
+}
+
+// WithMedicineID sets the MedicineID field of the QueryFilter value.
+func (qf *QueryFilter) WithMedicineID(medicineID uuid.UUID) {
+	qf.MedicineID = &medicineID
+}
+
+// WithExpired sets the Expired field of the QueryFilter value.
+func (qf *QueryFilter) WithExpired(expired bool) {
+	qf.Expired = &expired
+}
@@ -0,0 +1,34 @@
+package expirybus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Holding represents the quantity of a lot held by an inventory.
+type Holding struct {
+	InventoryID uuid.UUID
+	LotID       uuid.UUID
+	MedicineID  uuid.UUID
+	ExpiryDate  time.Time
+	Quantity    int
+}
+
+// Alert represents stock of a lot held by an inventory that was found to
+// expire within a horizon. The horizon is in days, a zero horizon means the
+// stock had already expired when it was found.
+type Alert struct {
+	InventoryID uuid.UUID
+	LotID       uuid.UUID
+	MedicineID  uuid.UUID
+	ExpiryDate  time.Time
+	Quantity    int
+	Horizon     int
+	DateAlerted time.Time
+}
+
+// Expired reports whether the alert flags expired stock.
+func (a Alert) Expired() bool {
+	return a.Horizon == 0
+}
@@ -0,0 +1,17 @@
+package expirybus
+
+import "github.com/EnesDemirtas/medisync/business/api/order"
+
+// DefaultOrderBy represents the default way we sort. Stock that expires
+// first is listed first.
+var DefaultOrderBy = order.NewBy(OrderByExpiryDate, order.ASC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByInventoryID = "inventory_id"
+	OrderByLotID       = "lot_id"
+	OrderByMedicineID  = "medicine_id"
+	OrderByExpiryDate  = "expiry_date"
+	OrderByHorizon     = "horizon"
+	OrderByDateAlerted = "date_alerted"
+)
@@ -0,0 +1,157 @@
+// Package expirydb contains expiry alert related database functionality.
+package expirydb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/expirybus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+// Store manages the set of APIs for expiry alert database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Store value replacing the sqlx DB
+// value with a sqlx DB value that is currently inside a transaction.
+func (s *Store) ExecuteUnderTransaction(tx transaction.Transaction) (expirybus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	store := Store{
+		log: s.log,
+		db:  ec,
+	}
+
+	return &store, nil
+}
+
+// Create inserts a new alert into the database. A holding is alerted only
+// once per horizon, a second alert is reported as ErrAlreadyAlerted.
+func (s *Store) Create(ctx context.Context, alert expirybus.Alert) error {
+	const q = `
+	INSERT INTO expiry_alerts
+		(inventory_id, lot_id, medicine_id, expiry_date, quantity, horizon, date_alerted)
+	VALUES
+		(:inventory_id, :lot_id, :medicine_id, :expiry_date, :quantity, :horizon, :date_alerted)
+	ON CONFLICT (inventory_id, lot_id, horizon) DO NOTHING
+	RETURNING
+		horizon`
+
+	var result struct {
+		Horizon int `db:"horizon"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, toDBAlert(alert), &result); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return fmt.Errorf("db: %w", expirybus.ErrAlreadyAlerted)
+		}
+		return fmt.Errorf("db: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of existing alerts from the database.
+func (s *Store) Query(ctx context.Context, filter expirybus.QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]expirybus.Alert, error) {
+	data := map[string]interface{}{
+		"offset":        (pageNumber - 1) * rowsPerPage,
+		"rows_per_page": rowsPerPage,
+	}
+
+	const q = `
+	SELECT
+		inventory_id, lot_id, medicine_id, expiry_date, quantity, horizon, date_alerted
+	FROM
+		expiry_alerts`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbAlerts []dbAlert
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbAlerts); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreAlertSlice(dbAlerts), nil
+}
+
+// Count returns the total number of alerts in the database.
+func (s *Store) Count(ctx context.Context, filter expirybus.QueryFilter) (int, error) {
+	data := map[string]interface{}{}
+
+	const q = `
+	SELECT
+		count(1)
+	FROM
+		expiry_alerts`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryHoldings returns every lot with stock on hand in an inventory that
+// expires before the specified time.
+func (s *Store) QueryHoldings(ctx context.Context, expiresBefore time.Time) ([]expirybus.Holding, error) {
+	data := struct {
+		ExpiresBefore time.Time `db:"expires_before"`
+	}{
+		ExpiresBefore: expiresBefore.UTC(),
+	}
+
+	const q = `
+	SELECT
+		it.inventory_id, l.lot_id, l.medicine_id, l.expiry_date, it.quantity
+	FROM
+		inventory_items it
+	JOIN
+		lots l ON l.lot_id = it.lot_id
+	WHERE
+		it.quantity > 0 AND
+		l.expiry_date < :expires_before
+	ORDER BY
+		l.expiry_date, it.inventory_id`
+
+	var dbHoldings []dbHolding
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbHoldings); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreHoldingSlice(dbHoldings), nil
+}
@@ -0,0 +1,41 @@
+package expirydb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/EnesDemirtas/medisync/business/domain/expirybus"
+)
+
+func applyFilter(filter expirybus.QueryFilter, data map[string]interface{}, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.InventoryID != nil {
+		data["inventory_id"] = *filter.InventoryID
+		wc = append(wc, "inventory_id = :inventory_id")
+	}
+
+	if filter.LotID != nil {
+		data["lot_id"] = *filter.LotID
+		wc = append(wc, "lot_id = :lot_id")
+	}
+
+	if filter.MedicineID != nil {
+		data["medicine_id"] = *filter.MedicineID
+		wc = append(wc, "medicine_id = :medicine_id")
+	}
+
+	if filter.Expired != nil {
+		switch *filter.Expired {
+		case true:
+			wc = append(wc, "horizon = 0")
+		default:
+			wc = append(wc, "horizon > 0")
+		}
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
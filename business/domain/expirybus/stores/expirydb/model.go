@@ -0,0 +1,76 @@
+package expirydb
+
+import (
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/expirybus"
+	"github.com/google/uuid"
+)
+
+type dbAlert struct {
+	InventoryID uuid.UUID `db:"inventory_id"`
+	LotID       uuid.UUID `db:"lot_id"`
+	MedicineID  uuid.UUID `db:"medicine_id"`
+	ExpiryDate  time.Time `db:"expiry_date"`
+	Quantity    int       `db:"quantity"`
+	Horizon     int       `db:"horizon"`
+	DateAlerted time.Time `db:"date_alerted"`
+}
+
+func toDBAlert(alert expirybus.Alert) dbAlert {
+	return dbAlert{
+		InventoryID: alert.InventoryID,
+		LotID:       alert.LotID,
+		MedicineID:  alert.MedicineID,
+		ExpiryDate:  alert.ExpiryDate.UTC(),
+		Quantity:    alert.Quantity,
+		Horizon:     alert.Horizon,
+		DateAlerted: alert.DateAlerted.UTC(),
+	}
+}
+
+func toCoreAlert(dbAlert dbAlert) expirybus.Alert {
+	return expirybus.Alert{
+		InventoryID: dbAlert.InventoryID,
+		LotID:       dbAlert.LotID,
+		MedicineID:  dbAlert.MedicineID,
+		ExpiryDate:  dbAlert.ExpiryDate.In(time.Local),
+		Quantity:    dbAlert.Quantity,
+		Horizon:     dbAlert.Horizon,
+		DateAlerted: dbAlert.DateAlerted.In(time.Local),
+	}
+}
+
+func toCoreAlertSlice(dbAlerts []dbAlert) []expirybus.Alert {
+	alerts := make([]expirybus.Alert, len(dbAlerts))
+	for i, dbAlert := range dbAlerts {
+		alerts[i] = toCoreAlert(dbAlert)
+	}
+
+	return alerts
+}
+
+// =============================================================================
+
+type dbHolding struct {
+	InventoryID uuid.UUID `db:"inventory_id"`
+	LotID       uuid.UUID `db:"lot_id"`
+	MedicineID  uuid.UUID `db:"medicine_id"`
+	ExpiryDate  time.Time `db:"expiry_date"`
+	Quantity    int       `db:"quantity"`
+}
+
+func toCoreHoldingSlice(dbHoldings []dbHolding) []expirybus.Holding {
+	hlds := make([]expirybus.Holding, len(dbHoldings))
+	for i, dbHld := range dbHoldings {
+		hlds[i] = expirybus.Holding{
+			InventoryID: dbHld.InventoryID,
+			LotID:       dbHld.LotID,
+			MedicineID:  dbHld.MedicineID,
+			ExpiryDate:  dbHld.ExpiryDate.In(time.Local),
+			Quantity:    dbHld.Quantity,
+		}
+	}
+
+	return hlds
+}
@@ -0,0 +1,26 @@
+package expirydb
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/expirybus"
+)
+
+var orderByFields = map[string]string{
+	expirybus.OrderByInventoryID: "inventory_id",
+	expirybus.OrderByLotID:       "lot_id",
+	expirybus.OrderByMedicineID:  "medicine_id",
+	expirybus.OrderByExpiryDate:  "expiry_date",
+	expirybus.OrderByHorizon:     "horizon",
+	expirybus.OrderByDateAlerted: "date_alerted",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
@@ -0,0 +1,93 @@
+package inventorybus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Buckets represents stock split by status. Every unit on hand is in exactly
+// one bucket.
+type Buckets struct {
+	Available   int
+	Quarantined int
+	Damaged     int
+	Expired     int
+}
+
+// OnHand returns the quantity held over every bucket.
+func (b Buckets) OnHand() int {
+	return b.Available + b.Quarantined + b.Damaged + b.Expired
+}
+
+// Of returns the quantity held in the bucket of the specified status.
+func (b Buckets) Of(status Status) int {
+	switch status {
+	case StatusQuarantined:
+		return b.Quarantined
+	case StatusDamaged:
+		return b.Damaged
+	case StatusExpired:
+		return b.Expired
+	}
+
+	return b.Available
+}
+
+// LotBuckets represents the stock of a lot held by an inventory split by
+// status.
+type LotBuckets struct {
+	InventoryID uuid.UUID
+	MedicineID  uuid.UUID
+	LotID       uuid.UUID
+	Buckets
+}
+
+// BucketFilter holds the available fields the bucket query can be filtered
+// on.
+type BucketFilter struct {
+	InventoryID *uuid.UUID
+	MedicineID  *uuid.UUID
+	LotID       *uuid.UUID
+}
+
+// WithInventoryID sets the InventoryID field of the BucketFilter value.
+func (bf *BucketFilter) WithInventoryID(inventoryID uuid.UUID) {
+	bf.InventoryID = &inventoryID
+}
+
+// WithMedicineID sets the MedicineID field of the BucketFilter value.
+func (bf *BucketFilter) WithMedicineID(medicineID uuid.UUID) {
+	bf.MedicineID = &medicineID
+}
+
+// WithLotID sets the LotID field of the BucketFilter value.
+func (bf *BucketFilter) WithLotID(lotID uuid.UUID) {
+	bf.LotID = &lotID
+}
+
+// StatusMove contains information needed to move stock of a lot between
+// buckets. The quantity on hand is left as it is.
+type StatusMove struct {
+	InventoryID uuid.UUID
+	LotID       uuid.UUID
+	From        Status
+	To          Status
+	Quantity    int
+	Reason      string
+	UserID      uuid.UUID
+}
+
+// Move represents a recorded move of stock between buckets.
+type Move struct {
+	ID          uuid.UUID
+	InventoryID uuid.UUID
+	MedicineID  uuid.UUID
+	LotID       uuid.UUID
+	From        Status
+	To          Status
+	Quantity    int
+	Reason      string
+	UserID      uuid.UUID
+	DateCreated time.Time
+}
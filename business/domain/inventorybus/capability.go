@@ -0,0 +1,115 @@
+package inventorybus
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+)
+
+// Set of possible storage capabilities of an inventory.
+var (
+	CapabilityAmbient        = Capability{"AMBIENT"}
+	CapabilityColdRoom       = Capability{"COLD_ROOM"}
+	CapabilityFreezer        = Capability{"FREEZER"}
+	CapabilityLightProtected = Capability{"LIGHT_PROTECTED"}
+)
+
+// Set of known capabilities.
+var capabilities = map[string]Capability{
+	CapabilityAmbient.name:        CapabilityAmbient,
+	CapabilityColdRoom.name:       CapabilityColdRoom,
+	CapabilityFreezer.name:        CapabilityFreezer,
+	CapabilityLightProtected.name: CapabilityLightProtected,
+}
+
+// Capability represents a storage condition an inventory is able to provide.
+type Capability struct {
+	name string
+}
+
+// ParseCapability parses the string value and returns a capability if one
+// exists.
+func ParseCapability(value string) (Capability, error) {
+	capability, exists := capabilities[value]
+	if !exists {
+		return Capability{}, fmt.Errorf("invalid capability %q", value)
+	}
+
+	return capability, nil
+}
+
+// MustParseCapability parses the string value and returns a capability if
+// one exists. If an error occurs the function panics.
+func MustParseCapability(value string) Capability {
+	capability, err := ParseCapability(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return capability
+}
+
+// Name returns the name of the capability.
+func (c Capability) Name() string {
+	return c.name
+}
+
+// UnmarshalText implement the unmarshal interface for JSON conversions.
+func (c *Capability) UnmarshalText(data []byte) error {
+	capability, err := ParseCapability(string(data))
+	if err != nil {
+		return err
+	}
+
+	c.name = capability.name
+	return nil
+}
+
+// MarshalText implement the marshal interface for JSON conversions.
+func (c Capability) MarshalText() ([]byte, error) {
+	return []byte(c.name), nil
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (c Capability) Equal(c2 Capability) bool {
+	return c.name == c2.name
+}
+
+// =============================================================================
+
+// Set of capabilities that satisfy each temperature range. A temperature
+// range that is not listed can be stored anywhere.
+var temperatureCapabilities = map[medicinebus.Temperature][]Capability{
+	medicinebus.TemperatureBelow25:      {CapabilityAmbient, CapabilityColdRoom},
+	medicinebus.TemperatureRefrigerated: {CapabilityColdRoom},
+	medicinebus.TemperatureFrozen:       {CapabilityFreezer},
+}
+
+// Incompatibilities returns the reasons the storage requirements of a
+// medicine are not met by an inventory with the specified capabilities. An
+// empty result means the medicine can be stored there.
+func Incompatibilities(storage medicinebus.Storage, caps []Capability) []string {
+	var reasons []string
+
+	if want, exists := temperatureCapabilities[storage.Temperature]; exists && !hasAny(caps, want...) {
+		reasons = append(reasons, fmt.Sprintf("requires %s storage", storage.Temperature.Name()))
+	}
+
+	if storage.ProtectFromLight && !hasAny(caps, CapabilityLightProtected) {
+		reasons = append(reasons, "requires protection from light")
+	}
+
+	return reasons
+}
+
+func hasAny(caps []Capability, want ...Capability) bool {
+	for _, c := range caps {
+		for _, w := range want {
+			if c == w {
+				return true
+			}
+		}
+	}
+
+	return false
+}
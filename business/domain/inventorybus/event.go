@@ -0,0 +1,63 @@
+package inventorybus
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/go-json-experiment/json"
+	"github.com/google/uuid"
+)
+
+// Domain represents the name of this domain.
+const Domain = "inventory"
+
+// Set of delegate actions.
+const (
+	ActionLowStock  = "lowstock"
+	ActionRestocked = "restocked"
+)
+
+// ActionStockLevelParms represents the parameters for the low stock and
+// restocked actions.
+type ActionStockLevelParms struct {
+	InventoryID       uuid.UUID
+	MedicineID        uuid.UUID
+	LotID             uuid.UUID
+	OnHand            int
+	ReorderPoint      int
+	SuggestedQuantity int
+}
+
+// String returns a string representation of the action parameters.
+func (as *ActionStockLevelParms) String() string {
+	return fmt.Sprintf("&EventParamsStockLevel{InventoryID:%v, MedicineID:%v, OnHand:%v, ReorderPoint:%v}", as.InventoryID, as.MedicineID, as.OnHand, as.ReorderPoint)
+}
+
+// Marshal returns the event parameters encoded as JSON.
+func (as *ActionStockLevelParms) Marshal() ([]byte, error) {
+	return json.Marshal(as)
+}
+
+// ActionStockLevelData constructs the data for the low stock and restocked
+// actions.
+func ActionStockLevelData(action string, sl StockLevel, lotID uuid.UUID) delegate.Data {
+	params := ActionStockLevelParms{
+		InventoryID:       sl.InventoryID,
+		MedicineID:        sl.MedicineID,
+		LotID:             lotID,
+		OnHand:            sl.OnHand,
+		ReorderPoint:      sl.ReorderPoint.ReorderPoint,
+		SuggestedQuantity: sl.SuggestedQuantity(),
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return delegate.Data{
+		Domain:    Domain,
+		Action:    action,
+		RawParams: rawParams,
+	}
+}
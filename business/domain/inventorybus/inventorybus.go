@@ -7,11 +7,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/EnesDemirtas/medisync/business/api/delegate"
 	"github.com/EnesDemirtas/medisync/business/api/order"
 	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
 	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
 	"github.com/EnesDemirtas/medisync/foundation/logger"
 	"github.com/google/uuid"
@@ -19,8 +21,16 @@ import (
 
 // Set of error variables for CRUD operations.
 var	(
-	ErrNotFound = errors.New("inventory not found")
-	ErrUniquePK = errors.New("inventory already exists")
+	ErrNotFound 		 = errors.New("inventory not found")
+	ErrUniquePK 		 = errors.New("inventory already exists")
+	ErrInsufficientStock = errors.New("insufficient stock")
+	ErrReorderPointNotFound = errors.New("reorder point not found")
+	ErrInvalidReorderPoint  = errors.New("reorder point must satisfy 0 <= min <= reorder point <= max")
+	ErrVersionConflict      = errors.New("inventory was modified by another request")
+	ErrReasonRequired       = errors.New("status move reason required")
+	ErrSameStatus           = errors.New("stock is already in the requested status")
+	ErrInvalidQuantity      = errors.New("invalid status move quantity")
+	ErrIncompatibleStorage  = errors.New("inventory does not meet the storage requirements of the medicine")
 )
 
 // Storer interface ddeclares the behavior this package needs to persist and
@@ -34,21 +44,31 @@ type Storer interface {
 	Count(ctx context.Context, filter QueryFilter) (int, error)
 	QueryByID(ctx context.Context, inventoryID uuid.UUID) (Inventory, error)
 	QueryByIDs(ctx context.Context, inventoryIDs []uuid.UUID) ([]Inventory, error)
+	AdjustQuantity(ctx context.Context, inventoryID uuid.UUID, lotID uuid.UUID, status Status, delta int, dateUpdated time.Time) (int, error)
+	MoveStatus(ctx context.Context, mv Move) (LotBuckets, error)
+	QueryBuckets(ctx context.Context, filter BucketFilter) ([]LotBuckets, error)
+	SetReorderPoint(ctx context.Context, rp ReorderPoint) error
+	DeleteReorderPoint(ctx context.Context, rp ReorderPoint) error
+	QueryReorderPoint(ctx context.Context, inventoryID uuid.UUID, medicineID uuid.UUID) (ReorderPoint, error)
+	QueryStockLevels(ctx context.Context, filter StockLevelFilter) ([]StockLevel, error)
+	QueryStockLevelByLot(ctx context.Context, inventoryID uuid.UUID, lotID uuid.UUID) (StockLevel, error)
 }
 
 // Core manages the set of APIs for inventory access.
 type Core struct {
 	log 			*logger.Logger
 	medicineCore 	*medicinebus.Core
+	lotCore			*lotbus.Core
 	delegate		*delegate.Delegate
 	storer			Storer
 }
 
 // NewCore constructs an inventory core API for use.
-func NewCore(log *logger.Logger, medicineCore *medicinebus.Core, delegate *delegate.Delegate, storer Storer) *Core {
+func NewCore(log *logger.Logger, medicineCore *medicinebus.Core, lotCore *lotbus.Core, delegate *delegate.Delegate, storer Storer) *Core {
 	return &Core{
 		log:      log,
 		medicineCore: medicineCore,
+		lotCore:  lotCore,
 		delegate: delegate,
 		storer:   storer,
 	}
@@ -67,9 +87,15 @@ func (c *Core) ExecuteUnderTransaction(tx transaction.Transaction) (*Core, error
 		return nil, err
 	}
 
+	lotCore, err := c.lotCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
 	core := Core{
 		log:      c.log,
 		medicineCore: medicineCore,
+		lotCore:  lotCore,
 		delegate: c.delegate,
 		storer:   storer,
 	}
@@ -77,9 +103,15 @@ func (c *Core) ExecuteUnderTransaction(tx transaction.Transaction) (*Core, error
 	return &core, nil
 }
 
-// Create adds a new inventory to the system.
+// Create adds a new inventory to the system. An inventory created without
+// capabilities is taken to be an ambient store.
 func (c *Core) Create(ctx context.Context, newInventory NewInventory) (Inventory, error) {
-	medQua := make(map[uuid.UUID]int)
+	lotQua := make(map[uuid.UUID]int)
+
+	caps := newInventory.Capabilities
+	if len(caps) == 0 {
+		caps = []Capability{CapabilityAmbient}
+	}
 
 	now := time.Now()
 
@@ -87,7 +119,9 @@ func (c *Core) Create(ctx context.Context, newInventory NewInventory) (Inventory
 		ID: 				uuid.New(),
 		Name:				newInventory.Name,
 		Description: 		newInventory.Description,
-		MedicineQuantities: medQua,
+		Capabilities:		caps,
+		LotQuantities: 		lotQua,
+		Version:			1,
 		DateCreated: 		now,
 		DateUpdated: 		now,
 	}
@@ -109,23 +143,11 @@ func (c *Core) Update(ctx context.Context, inventory Inventory, updatedInventory
 		inventory.Description = *updatedInventory.Description
 	}
 
-	if updatedInventory.MedicineQuantities != nil {
-		med_ids := make([]uuid.UUID, len(updatedInventory.MedicineQuantities))
-
-		i := 0
-		for med_id := range updatedInventory.MedicineQuantities {
-			med_ids[i] = med_id
-			i++
-		}
-	
-		_, err := c.medicineCore.QueryByIDs(ctx, med_ids)
-		if err != nil {
-			return Inventory{}, fmt.Errorf("medicine.querybyids: %s: %w", med_ids, err)
-		}
-
-		inventory.MedicineQuantities = updatedInventory.MedicineQuantities
+	if updatedInventory.Capabilities != nil {
+		inventory.Capabilities = updatedInventory.Capabilities
 	}
 
+	inventory.Version++
 	inventory.DateUpdated = time.Now()
 
 	if err := c.storer.Update(ctx, inventory); err != nil {
@@ -141,6 +163,220 @@ func (c *Core) Update(ctx context.Context, inventory Inventory, updatedInventory
 	return inventory, nil
 }
 
+// AdjustQuantity atomically applies the delta to the quantity of the lot
+// held by the inventory in the bucket of the specified status and returns
+// the resulting quantity on hand. Buckets are never allowed to go below
+// zero. This is the only way stock levels change, callers should go through
+// the stock ledger rather than calling it directly. When the available stock
+// crosses the reorder point of the medicine a low stock or restocked event is
+// raised. Stock can only be added to an inventory that meets the storage
+// requirements of its medicine.
+func (c *Core) AdjustQuantity(ctx context.Context, inventoryID uuid.UUID, lotID uuid.UUID, status Status, delta int) (int, error) {
+	if delta > 0 {
+		if err := c.checkStorage(ctx, inventoryID, lotID); err != nil {
+			return 0, err
+		}
+	}
+
+	quantity, err := c.storer.AdjustQuantity(ctx, inventoryID, lotID, status, delta, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("adjustquantity: inventoryID[%s] lotID[%s] status[%s] delta[%d]: %w", inventoryID, lotID, status.Name(), delta, err)
+	}
+
+	if status != StatusAvailable {
+		return quantity, nil
+	}
+
+	if err := c.raiseStockLevel(ctx, inventoryID, lotID, delta); err != nil {
+		return 0, err
+	}
+
+	return quantity, nil
+}
+
+// MoveStatus moves stock of a lot from one bucket to another and records the
+// move with its reason. The quantity on hand is left as it is.
+func (c *Core) MoveStatus(ctx context.Context, sm StatusMove) (LotBuckets, error) {
+	if sm.Quantity <= 0 {
+		return LotBuckets{}, ErrInvalidQuantity
+	}
+
+	if sm.From == sm.To {
+		return LotBuckets{}, ErrSameStatus
+	}
+
+	if sm.Reason == "" {
+		return LotBuckets{}, ErrReasonRequired
+	}
+
+	mv := Move{
+		ID:          uuid.New(),
+		InventoryID: sm.InventoryID,
+		LotID:       sm.LotID,
+		From:        sm.From,
+		To:          sm.To,
+		Quantity:    sm.Quantity,
+		Reason:      sm.Reason,
+		UserID:      sm.UserID,
+		DateCreated: time.Now(),
+	}
+
+	lb, err := c.storer.MoveStatus(ctx, mv)
+	if err != nil {
+		return LotBuckets{}, fmt.Errorf("movestatus: inventoryID[%s] lotID[%s]: %w", sm.InventoryID, sm.LotID, err)
+	}
+
+	var delta int
+	switch {
+	case sm.From == StatusAvailable:
+		delta = -sm.Quantity
+	case sm.To == StatusAvailable:
+		delta = sm.Quantity
+	default:
+		return lb, nil
+	}
+
+	if err := c.raiseStockLevel(ctx, sm.InventoryID, sm.LotID, delta); err != nil {
+		return LotBuckets{}, err
+	}
+
+	return lb, nil
+}
+
+// QueryBuckets returns the stock of every lot matching the filter split by
+// status. Lots that are not held are left out.
+func (c *Core) QueryBuckets(ctx context.Context, filter BucketFilter) ([]LotBuckets, error) {
+	lbs, err := c.storer.QueryBuckets(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("querybuckets: %w", err)
+	}
+
+	return lbs, nil
+}
+
+// QueryViolations returns the stock matching the filter that is held by an
+// inventory not able to provide the storage conditions its medicine
+// requires.
+func (c *Core) QueryViolations(ctx context.Context, filter BucketFilter) ([]Violation, error) {
+	lbs, err := c.storer.QueryBuckets(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("querybuckets: %w", err)
+	}
+
+	if len(lbs) == 0 {
+		return nil, nil
+	}
+
+	invIDs := make([]uuid.UUID, 0, len(lbs))
+	medIDs := make([]uuid.UUID, 0, len(lbs))
+	for _, lb := range lbs {
+		invIDs = append(invIDs, lb.InventoryID)
+		medIDs = append(medIDs, lb.MedicineID)
+	}
+
+	invs, err := c.storer.QueryByIDs(ctx, invIDs)
+	if err != nil {
+		return nil, fmt.Errorf("querybyids: %w", err)
+	}
+
+	caps := make(map[uuid.UUID][]Capability, len(invs))
+	for _, inv := range invs {
+		caps[inv.ID] = inv.Capabilities
+	}
+
+	meds, err := c.medicineCore.QueryByIDs(ctx, medIDs)
+	if err != nil {
+		return nil, fmt.Errorf("medicine.querybyids: %w", err)
+	}
+
+	storage := make(map[uuid.UUID]medicinebus.Storage, len(meds))
+	for _, med := range meds {
+		storage[med.ID] = med.Storage
+	}
+
+	var vls []Violation
+	for _, lb := range lbs {
+		reasons := Incompatibilities(storage[lb.MedicineID], caps[lb.InventoryID])
+		if len(reasons) == 0 {
+			continue
+		}
+
+		vls = append(vls, Violation{
+			InventoryID:  lb.InventoryID,
+			MedicineID:   lb.MedicineID,
+			LotID:        lb.LotID,
+			OnHand:       lb.OnHand(),
+			Storage:      storage[lb.MedicineID],
+			Capabilities: caps[lb.InventoryID],
+			Reasons:      reasons,
+		})
+	}
+
+	return vls, nil
+}
+
+// SetReorderPoint creates or replaces the stock level settings of a medicine
+// in an inventory.
+func (c *Core) SetReorderPoint(ctx context.Context, nrp NewReorderPoint) (ReorderPoint, error) {
+	if nrp.MinQuantity < 0 || nrp.MinQuantity > nrp.ReorderPoint || nrp.ReorderPoint > nrp.MaxQuantity {
+		return ReorderPoint{}, ErrInvalidReorderPoint
+	}
+
+	if _, err := c.medicineCore.QueryByID(ctx, nrp.MedicineID); err != nil {
+		return ReorderPoint{}, fmt.Errorf("medicine.querybyid: %s: %w", nrp.MedicineID, err)
+	}
+
+	now := time.Now()
+
+	rp := ReorderPoint{
+		InventoryID:  nrp.InventoryID,
+		MedicineID:   nrp.MedicineID,
+		MinQuantity:  nrp.MinQuantity,
+		MaxQuantity:  nrp.MaxQuantity,
+		ReorderPoint: nrp.ReorderPoint,
+		DateCreated:  now,
+		DateUpdated:  now,
+	}
+
+	if err := c.storer.SetReorderPoint(ctx, rp); err != nil {
+		return ReorderPoint{}, fmt.Errorf("setreorderpoint: %w", err)
+	}
+
+	return c.QueryReorderPoint(ctx, rp.InventoryID, rp.MedicineID)
+}
+
+// DeleteReorderPoint removes the stock level settings of a medicine in an
+// inventory.
+func (c *Core) DeleteReorderPoint(ctx context.Context, rp ReorderPoint) error {
+	if err := c.storer.DeleteReorderPoint(ctx, rp); err != nil {
+		return fmt.Errorf("deletereorderpoint: %w", err)
+	}
+
+	return nil
+}
+
+// QueryReorderPoint finds the stock level settings of a medicine in an
+// inventory.
+func (c *Core) QueryReorderPoint(ctx context.Context, inventoryID uuid.UUID, medicineID uuid.UUID) (ReorderPoint, error) {
+	rp, err := c.storer.QueryReorderPoint(ctx, inventoryID, medicineID)
+	if err != nil {
+		return ReorderPoint{}, fmt.Errorf("query: inventoryID[%s] medicineID[%s]: %w", inventoryID, medicineID, err)
+	}
+
+	return rp, nil
+}
+
+// QueryStockLevels retrieves the quantity on hand of every medicine that has
+// reorder point settings.
+func (c *Core) QueryStockLevels(ctx context.Context, filter StockLevelFilter) ([]StockLevel, error) {
+	sls, err := c.storer.QueryStockLevels(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("querystocklevels: %w", err)
+	}
+
+	return sls, nil
+}
+
 // Delete removes the specified inventory.
 func (c *Core) Delete(ctx context.Context, inventory Inventory) error {
 	if err := c.storer.Delete(ctx, inventory); err != nil {
@@ -193,3 +429,64 @@ func (c *Core) QueryByIDs(ctx context.Context, inventoryIDs []uuid.UUID) ([]Inve
 
 	return inventories, nil
 }
+
+// =============================================================================
+
+// checkStorage makes sure the inventory meets the storage requirements of the
+// medicine of the lot before stock of it is added.
+func (c *Core) checkStorage(ctx context.Context, inventoryID uuid.UUID, lotID uuid.UUID) error {
+	inv, err := c.storer.QueryByID(ctx, inventoryID)
+	if err != nil {
+		return fmt.Errorf("querybyid: inventoryID[%s]: %w", inventoryID, err)
+	}
+
+	lot, err := c.lotCore.QueryByID(ctx, lotID)
+	if err != nil {
+		return fmt.Errorf("lot.querybyid: lotID[%s]: %w", lotID, err)
+	}
+
+	med, err := c.medicineCore.QueryByID(ctx, lot.MedicineID)
+	if err != nil {
+		return fmt.Errorf("medicine.querybyid: medicineID[%s]: %w", lot.MedicineID, err)
+	}
+
+	if reasons := Incompatibilities(med.Storage, inv.Capabilities); len(reasons) > 0 {
+		return fmt.Errorf("inventoryID[%s] medicineID[%s]: %s: %w", inventoryID, med.ID, strings.Join(reasons, ", "), ErrIncompatibleStorage)
+	}
+
+	return nil
+}
+
+// raiseStockLevel raises a low stock or restocked event when a change of the
+// available stock of a lot by delta crossed the reorder point of its
+// medicine.
+func (c *Core) raiseStockLevel(ctx context.Context, inventoryID uuid.UUID, lotID uuid.UUID, delta int) error {
+	sl, err := c.storer.QueryStockLevelByLot(ctx, inventoryID, lotID)
+	if err != nil {
+		if errors.Is(err, ErrReorderPointNotFound) {
+			return nil
+		}
+		return fmt.Errorf("querystocklevelbylot: inventoryID[%s] lotID[%s]: %w", inventoryID, lotID, err)
+	}
+
+	before := sl
+	before.OnHand -= delta
+
+	var action string
+	switch {
+	case sl.BelowThreshold() && !before.BelowThreshold():
+		action = ActionLowStock
+	case !sl.BelowThreshold() && before.BelowThreshold():
+		action = ActionRestocked
+	default:
+		return nil
+	}
+
+	// Other domains may need to know when stock runs low so it can be
+	// reordered. This represents a delegate call to other domains.
+	if err := c.delegate.Call(ctx, ActionStockLevelData(action, sl, lotID)); err != nil {
+		return fmt.Errorf("failed to execute `%s` action: %w", action, err)
+	}
+
+	return nil
+}
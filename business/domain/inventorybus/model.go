@@ -9,24 +9,34 @@ import (
 // TODO: Keep track of number of medicines.
 
 // Inventory represents a single inventory that keeps medicine(s) in itself.
+// Quantities on hand are held per lot and keyed by the lot ID, the split of
+// the stock by status is summed per medicine. Version is incremented by every
+// update, stock movements leave it alone. Capabilities are the storage
+// conditions the inventory is able to provide.
 type Inventory struct {
 	ID 					uuid.UUID
 	Name				string
 	Description 		string
-	MedicineQuantities 	map[uuid.UUID]int
+	Capabilities		[]Capability
+	LotQuantities 		map[uuid.UUID]int
+	MedicineBuckets		map[uuid.UUID]Buckets
+	Version				int
 	DateCreated 		time.Time
 	DateUpdated			time.Time
 }
 
 // NewInventory contains information needed to create a new inventory.
+// Capabilities default to ambient.
 type NewInventory struct {
 	Name				string
 	Description			string
+	Capabilities		[]Capability
 }
 
 // UpdateInventory contains information needed to update an inventory.
+// Quantities are changed through the stock ledger, not through updates.
 type UpdateInventory struct {
 	Name 				*string
 	Description			*string
-	MedicineQuantities	map[uuid.UUID]int
+	Capabilities		[]Capability
 }
\ No newline at end of file
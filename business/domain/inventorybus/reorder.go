@@ -0,0 +1,73 @@
+package inventorybus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReorderPoint represents the stock level settings of a medicine held by an
+// inventory. The quantity on hand is the available stock summed over every
+// lot of the medicine.
+type ReorderPoint struct {
+	InventoryID  uuid.UUID
+	MedicineID   uuid.UUID
+	MinQuantity  int
+	MaxQuantity  int
+	ReorderPoint int
+	DateCreated  time.Time
+	DateUpdated  time.Time
+}
+
+// NewReorderPoint contains information needed to set the stock level
+// settings of a medicine in an inventory.
+type NewReorderPoint struct {
+	InventoryID  uuid.UUID
+	MedicineID   uuid.UUID
+	MinQuantity  int
+	MaxQuantity  int
+	ReorderPoint int
+}
+
+// StockLevel represents the available quantity of a medicine in an
+// inventory together with its reorder point settings. Quarantined, damaged
+// and expired stock is not counted.
+type StockLevel struct {
+	ReorderPoint
+	OnHand int
+}
+
+// BelowThreshold reports whether the stock has dropped to the reorder point.
+func (sl StockLevel) BelowThreshold() bool {
+	return sl.OnHand <= sl.ReorderPoint.ReorderPoint
+}
+
+// SuggestedQuantity returns the quantity that brings the stock back up to
+// the maximum.
+func (sl StockLevel) SuggestedQuantity() int {
+	return max(sl.MaxQuantity-sl.OnHand, 0)
+}
+
+// StockLevelFilter holds the available fields the stock level query can be
+// filtered on.
+type StockLevelFilter struct {
+	InventoryID    *uuid.UUID
+	MedicineID     *uuid.UUID
+	BelowThreshold *bool
+}
+
+// WithInventoryID sets the InventoryID field of the StockLevelFilter value.
+func (sf *StockLevelFilter) WithInventoryID(inventoryID uuid.UUID) {
+	sf.InventoryID = &inventoryID
+}
+
+// WithMedicineID sets the MedicineID field of the StockLevelFilter value.
+func (sf *StockLevelFilter) WithMedicineID(medicineID uuid.UUID) {
+	sf.MedicineID = &medicineID
+}
+
+// WithBelowThreshold restricts the result to stock at or below the reorder
+// point.
+func (sf *StockLevelFilter) WithBelowThreshold(below bool) {
+	sf.BelowThreshold = &below
+}
@@ -0,0 +1,72 @@
+package inventorybus
+
+import "fmt"
+
+// Set of possible statuses for stock held by an inventory. Only available
+// stock can be dispensed, transferred, allocated or reserved.
+var (
+	StatusAvailable   = Status{"AVAILABLE"}
+	StatusQuarantined = Status{"QUARANTINED"}
+	StatusDamaged     = Status{"DAMAGED"}
+	StatusExpired     = Status{"EXPIRED"}
+)
+
+// Set of known statuses.
+var statuses = map[string]Status{
+	StatusAvailable.name:   StatusAvailable,
+	StatusQuarantined.name: StatusQuarantined,
+	StatusDamaged.name:     StatusDamaged,
+	StatusExpired.name:     StatusExpired,
+}
+
+// Status represents the bucket a quantity of stock is held in.
+type Status struct {
+	name string
+}
+
+// ParseStatus parses the string value and returns a status if one exists.
+func ParseStatus(value string) (Status, error) {
+	status, exists := statuses[value]
+	if !exists {
+		return Status{}, fmt.Errorf("invalid status %q", value)
+	}
+
+	return status, nil
+}
+
+// MustParseStatus parses the string value and returns a status if one
+// exists. If an error occurs the function panics.
+func MustParseStatus(value string) Status {
+	status, err := ParseStatus(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return status
+}
+
+// Name returns the name of the status.
+func (m Status) Name() string {
+	return m.name
+}
+
+// UnmarshalText implement the unmarshal interface for JSON conversions.
+func (m *Status) UnmarshalText(data []byte) error {
+	status, err := ParseStatus(string(data))
+	if err != nil {
+		return err
+	}
+
+	m.name = status.name
+	return nil
+}
+
+// MarshalText implement the marshal interface for JSON conversions.
+func (m Status) MarshalText() ([]byte, error) {
+	return []byte(m.name), nil
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (m Status) Equal(m2 Status) bool {
+	return m.name == m2.name
+}
@@ -5,7 +5,8 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/EnesDemirtas/medisync/business/core/crud/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb/dbarray"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
 )
 
 func applyFilter(filter inventorybus.QueryFilter, data map[string]interface{}, buf *bytes.Buffer) {
@@ -26,17 +27,30 @@ func applyFilter(filter inventorybus.QueryFilter, data map[string]interface{}, b
 		wc = append(wc, "description LIKE :description")
 	}
 
+	if filter.Medicine != nil {
+		data["medicine_id"] = filter.Medicine.String()
+		wc = append(wc, "inventory_id IN (SELECT inventory_id FROM inventory_items WHERE medicine_id = :medicine_id AND quantity > 0)")
+	}
+
+	if len(filter.Medicines) > 0 {
+		ids := make([]string, len(filter.Medicines))
+		for i, medicineID := range filter.Medicines {
+			ids[i] = medicineID.String()
+		}
+		data["medicine_ids"] = dbarray.Array(ids)
+		wc = append(wc, "inventory_id IN (SELECT inventory_id FROM inventory_items WHERE medicine_id = ANY(:medicine_ids) AND quantity > 0)")
+	}
+
 	if filter.StartExpiryDate != nil {
-		data["start_expiry_date"] = *filter.StartExpiryDate
-		wc = append(wc, "expiry_date >= :start_expiry_date")
+		data["start_expiry_date"] = filter.StartExpiryDate.UTC()
+		wc = append(wc, "inventory_id IN (SELECT it.inventory_id FROM inventory_items it JOIN lots l ON l.lot_id = it.lot_id WHERE l.expiry_date >= :start_expiry_date AND it.quantity > 0)")
 	}
 
 	if filter.EndExpiryDate != nil {
-		data["end_expiry_date"] = *filter.EndExpiryDate
-		wc = append(wc, "expiry_date <= :end_expiry_date")
+		data["end_expiry_date"] = filter.EndExpiryDate.UTC()
+		wc = append(wc, "inventory_id IN (SELECT it.inventory_id FROM inventory_items it JOIN lots l ON l.lot_id = it.lot_id WHERE l.expiry_date <= :end_expiry_date AND it.quantity > 0)")
 	}
 
-
 	if len(wc) > 0 {
 		buf.WriteString(" WHERE ")
 		buf.WriteString(strings.Join(wc, " AND "))
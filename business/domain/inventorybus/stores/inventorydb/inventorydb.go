@@ -6,9 +6,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/EnesDemirtas/medisync/business/api/order"
-	"github.com/EnesDemirtas/medisync/business/core/crud/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
 	"github.com/EnesDemirtas/medisync/business/data/sqldb"
 	"github.com/EnesDemirtas/medisync/business/data/sqldb/dbarray"
 	"github.com/EnesDemirtas/medisync/business/data/transaction"
@@ -17,6 +19,53 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// lotQuantities collects the items held by an inventory into a single JSONB
+// object keyed by lot so the inventory can be read back in one row.
+const lotQuantities = `
+		(
+			SELECT
+				COALESCE(jsonb_object_agg(CAST(it.lot_id AS TEXT), it.quantity), CAST('{}' AS JSONB))
+			FROM
+				inventory_items it
+			WHERE
+				it.inventory_id = inventories.inventory_id
+		) AS lot_quantities`
+
+// medicineBuckets collects the stock held by an inventory split by status
+// into a single JSONB object keyed by medicine.
+const medicineBuckets = `
+		(
+			SELECT
+				COALESCE(jsonb_object_agg(CAST(b.medicine_id AS TEXT), jsonb_build_object(
+					'available', b.available,
+					'quarantined', b.quarantined,
+					'damaged', b.damaged,
+					'expired', b.expired
+				)), CAST('{}' AS JSONB))
+			FROM (
+				SELECT
+					it.medicine_id,
+					SUM(it.quantity - it.quarantined - it.damaged - it.expired) AS available,
+					SUM(it.quarantined) AS quarantined,
+					SUM(it.damaged) AS damaged,
+					SUM(it.expired) AS expired
+				FROM
+					inventory_items it
+				WHERE
+					it.inventory_id = inventories.inventory_id
+				GROUP BY
+					it.medicine_id
+			) b
+		) AS medicine_buckets`
+
+// bucketColumns maps every status that has its own column to the column.
+// Available stock is what is left of the quantity on hand.
+var bucketColumns = map[inventorybus.Status]string{
+	inventorybus.StatusQuarantined: "quarantined",
+	inventorybus.StatusDamaged:     "damaged",
+	inventorybus.StatusExpired:     "expired",
+}
+
 // Store manages the set of APIs for inventory database access.
 type Store struct {
 	log *logger.Logger
@@ -51,9 +100,9 @@ func (s *Store) ExecuteUnderTransaction(tx transaction.Transaction) (inventorybu
 func (s *Store) Create(ctx context.Context, inv inventorybus.Inventory) error {
 	const q = `
 	INSERT INTO inventories
-		(inventory_id, name, description, medicines, date_created, date_updated)
+		(inventory_id, name, description, capabilities, version, date_created, date_updated)
 	VALUES
-		(:inventory_id, :name, :description, :medicines, :date_created, :date_updated)`
+		(:inventory_id, :name, :description, :capabilities, :version, :date_created, :date_updated)`
 
 	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBInventory(inv)); err != nil {
 		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
@@ -73,20 +122,238 @@ func (s *Store) Update(ctx context.Context, inv inventorybus.Inventory) error {
 	SET
 		"name" = :name,
 		"description" = :description,
-		"medicines" = :medicines
+		"capabilities" = :capabilities,
+		"version" = :version,
+		"date_updated" = :date_updated
 	WHERE
-		inventory_id = :inventory_id`
+		inventory_id = :inventory_id AND
+		version = :version - 1
+	RETURNING
+		version`
 
-	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBInventory(inv)); err != nil {
+	var result struct {
+		Version int `db:"version"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, toDBInventory(inv), &result); err != nil {
 		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
 			return inventorybus.ErrUniquePK
 		}
-		return fmt.Errorf("namedexeccontext: %w", err)
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return fmt.Errorf("db: %w", inventorybus.ErrVersionConflict)
+		}
+		return fmt.Errorf("db: %w", err)
 	}
 
 	return nil
 }
 
+// AdjustQuantity applies the delta to the quantity of the specified lot and
+// to the bucket of the status in a single statement so concurrent movements
+// can't overwrite each other. The item is created on the first receipt of a
+// lot and is left untouched if the bucket would drop below zero.
+func (s *Store) AdjustQuantity(ctx context.Context, inventoryID uuid.UUID, lotID uuid.UUID, status inventorybus.Status, delta int, dateUpdated time.Time) (int, error) {
+	data := struct {
+		InventoryID string    `db:"inventory_id"`
+		LotID       string    `db:"lot_id"`
+		Delta       int       `db:"delta"`
+		DateUpdated time.Time `db:"date_updated"`
+	}{
+		InventoryID: inventoryID.String(),
+		LotID:       lotID.String(),
+		Delta:       delta,
+		DateUpdated: dateUpdated.UTC(),
+	}
+
+	col, held := bucketColumns[status]
+	if !held && status != inventorybus.StatusAvailable {
+		return 0, fmt.Errorf("unknown status %q", status.Name())
+	}
+
+	// A negative row can't be proposed to an upsert since the check
+	// constraint is evaluated before the conflict is detected, so receipts
+	// and removals take different statements.
+	var q string
+	switch {
+	case delta >= 0 && !held:
+		q = `
+	INSERT INTO inventory_items
+		(inventory_id, lot_id, medicine_id, quantity, date_updated)
+	SELECT
+		:inventory_id, l.lot_id, l.medicine_id, :delta, :date_updated
+	FROM
+		lots l
+	WHERE
+		l.lot_id = :lot_id
+	ON CONFLICT (inventory_id, lot_id) DO UPDATE SET
+		"quantity" = inventory_items.quantity + EXCLUDED.quantity,
+		"date_updated" = EXCLUDED.date_updated
+	RETURNING
+		quantity`
+
+	case delta >= 0:
+		q = `
+	INSERT INTO inventory_items
+		(inventory_id, lot_id, medicine_id, quantity, ` + col + `, date_updated)
+	SELECT
+		:inventory_id, l.lot_id, l.medicine_id, :delta, :delta, :date_updated
+	FROM
+		lots l
+	WHERE
+		l.lot_id = :lot_id
+	ON CONFLICT (inventory_id, lot_id) DO UPDATE SET
+		"quantity" = inventory_items.quantity + EXCLUDED.quantity,
+		"` + col + `" = inventory_items.` + col + ` + EXCLUDED.` + col + `,
+		"date_updated" = EXCLUDED.date_updated
+	RETURNING
+		quantity`
+
+	case !held:
+		q = `
+	UPDATE
+		inventory_items
+	SET
+		"quantity" = quantity + :delta,
+		"date_updated" = :date_updated
+	WHERE
+		inventory_id = :inventory_id AND
+		lot_id = :lot_id AND
+		quantity + :delta >= quarantined + damaged + expired
+	RETURNING
+		quantity`
+
+	default:
+		q = `
+	UPDATE
+		inventory_items
+	SET
+		"quantity" = quantity + :delta,
+		"` + col + `" = ` + col + ` + :delta,
+		"date_updated" = :date_updated
+	WHERE
+		inventory_id = :inventory_id AND
+		lot_id = :lot_id AND
+		` + col + ` + :delta >= 0
+	RETURNING
+		quantity`
+	}
+
+	var result struct {
+		Quantity int `db:"quantity"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &result); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) && delta < 0 {
+			return 0, fmt.Errorf("db: %w", inventorybus.ErrInsufficientStock)
+		}
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return result.Quantity, nil
+}
+
+// MoveStatus moves stock of a lot between buckets and records the move. The
+// buckets are left untouched if the source bucket doesn't hold enough.
+func (s *Store) MoveStatus(ctx context.Context, mv inventorybus.Move) (inventorybus.LotBuckets, error) {
+	from, fromHeld := bucketColumns[mv.From]
+	to, toHeld := bucketColumns[mv.To]
+
+	var set []string
+	var wc string
+
+	switch {
+	case fromHeld:
+		set = append(set, `"`+from+`" = `+from+` - :quantity`)
+		wc = from + ` >= :quantity`
+	default:
+		wc = `quantity - quarantined - damaged - expired >= :quantity`
+	}
+
+	if toHeld {
+		set = append(set, `"`+to+`" = `+to+` + :quantity`)
+	}
+
+	set = append(set, `"date_updated" = :date_created`)
+
+	q := `
+	UPDATE
+		inventory_items
+	SET
+		` + strings.Join(set, ",\n\t\t") + `
+	WHERE
+		inventory_id = :inventory_id AND
+		lot_id = :lot_id AND
+		` + wc + `
+	RETURNING
+		inventory_id, medicine_id, lot_id,
+		quantity - quarantined - damaged - expired AS available,
+		quarantined, damaged, expired`
+
+	dbMv := toDBMove(mv)
+
+	var dbLB dbLotBuckets
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, dbMv, &dbLB); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return inventorybus.LotBuckets{}, fmt.Errorf("db: %w", inventorybus.ErrInsufficientStock)
+		}
+		return inventorybus.LotBuckets{}, fmt.Errorf("db: %w", err)
+	}
+
+	dbMv.MedicineID = dbLB.MedicineID
+
+	const qm = `
+	INSERT INTO inventory_status_moves
+		(move_id, inventory_id, medicine_id, lot_id, from_status, to_status, quantity, reason, moved_by, date_created)
+	VALUES
+		(:move_id, :inventory_id, :medicine_id, :lot_id, :from_status, :to_status, :quantity, :reason, :moved_by, :date_created)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, qm, dbMv); err != nil {
+		return inventorybus.LotBuckets{}, fmt.Errorf("namedexeccontext: move: %w", err)
+	}
+
+	return toCoreLotBuckets(dbLB), nil
+}
+
+// QueryBuckets retrieves the stock of every lot held matching the filter
+// split by status.
+func (s *Store) QueryBuckets(ctx context.Context, filter inventorybus.BucketFilter) ([]inventorybus.LotBuckets, error) {
+	data := map[string]interface{}{}
+
+	wc := []string{"quantity > 0"}
+	if filter.InventoryID != nil {
+		data["inventory_id"] = filter.InventoryID.String()
+		wc = append(wc, "inventory_id = :inventory_id")
+	}
+
+	if filter.MedicineID != nil {
+		data["medicine_id"] = filter.MedicineID.String()
+		wc = append(wc, "medicine_id = :medicine_id")
+	}
+
+	if filter.LotID != nil {
+		data["lot_id"] = filter.LotID.String()
+		wc = append(wc, "lot_id = :lot_id")
+	}
+
+	const q = `
+	SELECT
+		inventory_id, medicine_id, lot_id,
+		quantity - quarantined - damaged - expired AS available,
+		quarantined, damaged, expired
+	FROM
+		inventory_items`
+
+	buf := bytes.NewBufferString(q)
+	buf.WriteString(" WHERE ")
+	buf.WriteString(strings.Join(wc, " AND "))
+	buf.WriteString(" ORDER BY inventory_id, medicine_id, lot_id")
+
+	var dbLBs []dbLotBuckets
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbLBs); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreLotBucketsSlice(dbLBs), nil
+}
+
 // Delete removes an inventory from the database.
 func (s *Store) Delete(ctx context.Context, inv inventorybus.Inventory) error {
 	data := struct {
@@ -117,7 +384,7 @@ func (s *Store) Query(ctx context.Context, filter inventorybus.QueryFilter, orde
 
 	const q = `
 	SELECT
-		inventory_id, name, description, medicines, date_created, date_updated
+		inventory_id, name, description, capabilities, ` + lotQuantities + `, ` + medicineBuckets + `, version, date_created, date_updated
 	FROM
 		inventories`
 
@@ -173,7 +440,7 @@ func (s *Store) QueryByID(ctx context.Context, inventoryID uuid.UUID) (inventory
 
 	const q = `
 	SELECT
-		inventory_id, name, description, medicines, date_created, date_updated
+		inventory_id, name, description, capabilities, ` + lotQuantities + `, ` + medicineBuckets + `, version, date_created, date_updated
 	FROM
 		inventories
 	WHERE
@@ -205,7 +472,7 @@ func (s *Store) QueryByIDs(ctx context.Context, inventoryIDs []uuid.UUID) ([]inv
 
 	const q = `
 	SELECT
-		inventory_id, name, description, medicines, date_created, date_updated
+		inventory_id, name, description, capabilities, ` + lotQuantities + `, ` + medicineBuckets + `, version, date_created, date_updated
 	FROM
 		inventories
 	WHERE
@@ -232,7 +499,7 @@ func (s *Store) QueryByName(ctx context.Context, name string) (inventorybus.Inve
 
 	const q = `
 	SELECT
-		inventory_id, name, description, medicines, date_created, date_updated
+		inventory_id, name, description, capabilities, ` + lotQuantities + `, version, date_created, date_updated
 	FROM
 		inventories
 	WHERE
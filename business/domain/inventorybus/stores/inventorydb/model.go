@@ -2,9 +2,11 @@ package inventorydb
 
 import (
 	"database/sql"
+	"encoding/json"
+	"fmt"
 	"time"
 
-	"github.com/EnesDemirtas/medisync/business/core/crud/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
 	"github.com/EnesDemirtas/medisync/business/data/sqldb/dbarray"
 	"github.com/google/uuid"
 )
@@ -15,7 +17,10 @@ type dbInventory struct {
 	ID 			 		uuid.UUID					`db:"inventory_id"`
 	Name		 		string						`db:"name"`
 	Description  		sql.NullString				`db:"description"`
-	MedicineQuantities	dbarray.MedicineQuantities	`db:"medicine_quantities"`
+	Capabilities		dbarray.String				`db:"capabilities"`
+	LotQuantities		dbarray.Quantities			`db:"lot_quantities"`
+	MedicineBuckets		dbMedicineBuckets			`db:"medicine_buckets"`
+	Version				int							`db:"version"`
 	DateCreated  		time.Time					`db:"date_created"`
 	DateUpdated  		time.Time					`db:"date_updated"`
 }
@@ -27,6 +32,11 @@ func toDBInventory(inv inventorybus.Inventory) dbInventory {
 	// 	meds[i] = med.String()
 	// }
 
+	caps := make([]string, len(inv.Capabilities))
+	for i, c := range inv.Capabilities {
+		caps[i] = c.Name()
+	}
+
 	return dbInventory{
 		ID:			  		inv.ID,
 		Name:		  		inv.Name,
@@ -34,18 +44,28 @@ func toDBInventory(inv inventorybus.Inventory) dbInventory {
 			String: inv.Description,
 			Valid:	inv.Description != "",
 		},
-		MedicineQuantities: inv.MedicineQuantities,
+		Capabilities:		caps,
+		LotQuantities: 		inv.LotQuantities,
+		Version:			inv.Version,
 		DateCreated:  		inv.DateCreated,
 		DateUpdated:  		inv.DateUpdated,
 	}
 }
 
 func toCoreInventory(dbInventory dbInventory) inventorybus.Inventory {
+	caps := make([]inventorybus.Capability, len(dbInventory.Capabilities))
+	for i, c := range dbInventory.Capabilities {
+		caps[i] = inventorybus.MustParseCapability(c)
+	}
+
 	inv := inventorybus.Inventory{
 		ID:			  		dbInventory.ID,
 		Name:		  		dbInventory.Name,
 		Description:  		dbInventory.Description.String,
-		MedicineQuantities: dbInventory.MedicineQuantities,
+		Capabilities:		caps,
+		LotQuantities: 		dbInventory.LotQuantities,
+		MedicineBuckets: 	toCoreMedicineBuckets(dbInventory.MedicineBuckets),
+		Version:			dbInventory.Version,
 		DateCreated:  		dbInventory.DateCreated,
 		DateUpdated:  		dbInventory.DateUpdated,
 	}
@@ -61,4 +81,159 @@ func toCoreInventorySlice(dbInventories []dbInventory) []inventorybus.Inventory
 	}
 
 	return invs
-}
\ No newline at end of file
+}
+
+// =============================================================================
+
+type dbReorderPoint struct {
+	InventoryID  uuid.UUID `db:"inventory_id"`
+	MedicineID   uuid.UUID `db:"medicine_id"`
+	MinQuantity  int       `db:"min_quantity"`
+	MaxQuantity  int       `db:"max_quantity"`
+	ReorderPoint int       `db:"reorder_point"`
+	DateCreated  time.Time `db:"date_created"`
+	DateUpdated  time.Time `db:"date_updated"`
+}
+
+type dbStockLevel struct {
+	dbReorderPoint
+	OnHand int `db:"on_hand"`
+}
+
+func toDBReorderPoint(rp inventorybus.ReorderPoint) dbReorderPoint {
+	return dbReorderPoint{
+		InventoryID:  rp.InventoryID,
+		MedicineID:   rp.MedicineID,
+		MinQuantity:  rp.MinQuantity,
+		MaxQuantity:  rp.MaxQuantity,
+		ReorderPoint: rp.ReorderPoint,
+		DateCreated:  rp.DateCreated.UTC(),
+		DateUpdated:  rp.DateUpdated.UTC(),
+	}
+}
+
+func toCoreReorderPoint(db dbReorderPoint) inventorybus.ReorderPoint {
+	return inventorybus.ReorderPoint{
+		InventoryID:  db.InventoryID,
+		MedicineID:   db.MedicineID,
+		MinQuantity:  db.MinQuantity,
+		MaxQuantity:  db.MaxQuantity,
+		ReorderPoint: db.ReorderPoint,
+		DateCreated:  db.DateCreated.In(time.Local),
+		DateUpdated:  db.DateUpdated.In(time.Local),
+	}
+}
+
+func toCoreStockLevel(db dbStockLevel) inventorybus.StockLevel {
+	return inventorybus.StockLevel{
+		ReorderPoint: toCoreReorderPoint(db.dbReorderPoint),
+		OnHand:       db.OnHand,
+	}
+}
+
+func toCoreStockLevelSlice(dbSLs []dbStockLevel) []inventorybus.StockLevel {
+	sls := make([]inventorybus.StockLevel, len(dbSLs))
+	for i, db := range dbSLs {
+		sls[i] = toCoreStockLevel(db)
+	}
+
+	return sls
+}
+
+// =============================================================================
+
+type dbBuckets struct {
+	Available   int `db:"available" json:"available"`
+	Quarantined int `db:"quarantined" json:"quarantined"`
+	Damaged     int `db:"damaged" json:"damaged"`
+	Expired     int `db:"expired" json:"expired"`
+}
+
+// dbMedicineBuckets is read from a JSONB object keyed by medicine.
+type dbMedicineBuckets map[uuid.UUID]dbBuckets
+
+// Scan implements the sql.Scanner interface.
+func (m *dbMedicineBuckets) Scan(value interface{}) error {
+	switch src := value.(type) {
+	case []byte:
+		return json.Unmarshal(src, m)
+	case string:
+		return json.Unmarshal([]byte(src), m)
+	case nil:
+		*m = nil
+		return nil
+	}
+
+	return fmt.Errorf("database: cannot convert %T to medicine buckets", value)
+}
+
+type dbLotBuckets struct {
+	InventoryID uuid.UUID `db:"inventory_id"`
+	MedicineID  uuid.UUID `db:"medicine_id"`
+	LotID       uuid.UUID `db:"lot_id"`
+	dbBuckets
+}
+
+type dbMove struct {
+	ID          uuid.UUID `db:"move_id"`
+	InventoryID uuid.UUID `db:"inventory_id"`
+	MedicineID  uuid.UUID `db:"medicine_id"`
+	LotID       uuid.UUID `db:"lot_id"`
+	From        string    `db:"from_status"`
+	To          string    `db:"to_status"`
+	Quantity    int       `db:"quantity"`
+	Reason      string    `db:"reason"`
+	MovedBy     uuid.UUID `db:"moved_by"`
+	DateCreated time.Time `db:"date_created"`
+}
+
+func toDBMove(mv inventorybus.Move) dbMove {
+	return dbMove{
+		ID:          mv.ID,
+		InventoryID: mv.InventoryID,
+		MedicineID:  mv.MedicineID,
+		LotID:       mv.LotID,
+		From:        mv.From.Name(),
+		To:          mv.To.Name(),
+		Quantity:    mv.Quantity,
+		Reason:      mv.Reason,
+		MovedBy:     mv.UserID,
+		DateCreated: mv.DateCreated.UTC(),
+	}
+}
+
+func toCoreBuckets(db dbBuckets) inventorybus.Buckets {
+	return inventorybus.Buckets{
+		Available:   db.Available,
+		Quarantined: db.Quarantined,
+		Damaged:     db.Damaged,
+		Expired:     db.Expired,
+	}
+}
+
+func toCoreMedicineBuckets(db dbMedicineBuckets) map[uuid.UUID]inventorybus.Buckets {
+	mbs := make(map[uuid.UUID]inventorybus.Buckets, len(db))
+	for medicineID, b := range db {
+		mbs[medicineID] = toCoreBuckets(b)
+	}
+
+	return mbs
+}
+
+func toCoreLotBuckets(db dbLotBuckets) inventorybus.LotBuckets {
+	return inventorybus.LotBuckets{
+		InventoryID: db.InventoryID,
+		MedicineID:  db.MedicineID,
+		LotID:       db.LotID,
+		Buckets:     toCoreBuckets(db.dbBuckets),
+	}
+}
+
+func toCoreLotBucketsSlice(dbLBs []dbLotBuckets) []inventorybus.LotBuckets {
+	lbs := make([]inventorybus.LotBuckets, len(dbLBs))
+	for i, db := range dbLBs {
+		lbs[i] = toCoreLotBuckets(db)
+	}
+
+	return lbs
+}
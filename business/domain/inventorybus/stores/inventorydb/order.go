@@ -4,7 +4,7 @@ import (
 	"fmt"
 
 	"github.com/EnesDemirtas/medisync/business/api/order"
-	"github.com/EnesDemirtas/medisync/business/core/crud/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
 )
 
 var orderByFields = map[string]string{
@@ -0,0 +1,168 @@
+package inventorydb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/google/uuid"
+)
+
+// stockLevelQuery returns every reorder point together with the available
+// quantity, summed over the lots of the medicine held by the inventory.
+const stockLevelQuery = `
+	SELECT
+		*
+	FROM (
+		SELECT
+			rp.inventory_id, rp.medicine_id, rp.min_quantity, rp.max_quantity, rp.reorder_point,
+			rp.date_created, rp.date_updated,
+			(
+				SELECT
+					COALESCE(SUM(it.quantity - it.quarantined - it.damaged - it.expired), 0)
+				FROM
+					inventory_items it
+				WHERE
+					it.inventory_id = rp.inventory_id AND
+					it.medicine_id = rp.medicine_id
+			) AS on_hand
+		FROM
+			inventory_reorder_points rp
+	) sl`
+
+// SetReorderPoint inserts or replaces the reorder point of a medicine in an
+// inventory. The creation date of an existing row is kept.
+func (s *Store) SetReorderPoint(ctx context.Context, rp inventorybus.ReorderPoint) error {
+	const q = `
+	INSERT INTO inventory_reorder_points
+		(inventory_id, medicine_id, min_quantity, max_quantity, reorder_point, date_created, date_updated)
+	VALUES
+		(:inventory_id, :medicine_id, :min_quantity, :max_quantity, :reorder_point, :date_created, :date_updated)
+	ON CONFLICT (inventory_id, medicine_id) DO UPDATE SET
+		"min_quantity" = EXCLUDED.min_quantity,
+		"max_quantity" = EXCLUDED.max_quantity,
+		"reorder_point" = EXCLUDED.reorder_point,
+		"date_updated" = EXCLUDED.date_updated`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBReorderPoint(rp)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteReorderPoint removes the reorder point of a medicine in an inventory.
+func (s *Store) DeleteReorderPoint(ctx context.Context, rp inventorybus.ReorderPoint) error {
+	const q = `
+	DELETE FROM
+		inventory_reorder_points
+	WHERE
+		inventory_id = :inventory_id AND
+		medicine_id = :medicine_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBReorderPoint(rp)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// QueryReorderPoint gets the reorder point of a medicine in an inventory.
+func (s *Store) QueryReorderPoint(ctx context.Context, inventoryID uuid.UUID, medicineID uuid.UUID) (inventorybus.ReorderPoint, error) {
+	data := struct {
+		InventoryID string `db:"inventory_id"`
+		MedicineID  string `db:"medicine_id"`
+	}{
+		InventoryID: inventoryID.String(),
+		MedicineID:  medicineID.String(),
+	}
+
+	const q = `
+	SELECT
+		inventory_id, medicine_id, min_quantity, max_quantity, reorder_point, date_created, date_updated
+	FROM
+		inventory_reorder_points
+	WHERE
+		inventory_id = :inventory_id AND
+		medicine_id = :medicine_id`
+
+	var dbRP dbReorderPoint
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbRP); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return inventorybus.ReorderPoint{}, fmt.Errorf("db: %w", inventorybus.ErrReorderPointNotFound)
+		}
+		return inventorybus.ReorderPoint{}, fmt.Errorf("db: %w", err)
+	}
+
+	return toCoreReorderPoint(dbRP), nil
+}
+
+// QueryStockLevels retrieves the available quantity of every medicine that
+// has a reorder point.
+func (s *Store) QueryStockLevels(ctx context.Context, filter inventorybus.StockLevelFilter) ([]inventorybus.StockLevel, error) {
+	data := map[string]interface{}{}
+
+	var wc []string
+	if filter.InventoryID != nil {
+		data["inventory_id"] = filter.InventoryID.String()
+		wc = append(wc, "sl.inventory_id = :inventory_id")
+	}
+
+	if filter.MedicineID != nil {
+		data["medicine_id"] = filter.MedicineID.String()
+		wc = append(wc, "sl.medicine_id = :medicine_id")
+	}
+
+	if filter.BelowThreshold != nil {
+		if *filter.BelowThreshold {
+			wc = append(wc, "sl.on_hand <= sl.reorder_point")
+		} else {
+			wc = append(wc, "sl.on_hand > sl.reorder_point")
+		}
+	}
+
+	buf := bytes.NewBufferString(stockLevelQuery)
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+	buf.WriteString(" ORDER BY sl.inventory_id, sl.medicine_id")
+
+	var dbSLs []dbStockLevel
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbSLs); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreStockLevelSlice(dbSLs), nil
+}
+
+// QueryStockLevelByLot gets the available quantity of the medicine the lot
+// belongs to, if the medicine has a reorder point in the inventory.
+func (s *Store) QueryStockLevelByLot(ctx context.Context, inventoryID uuid.UUID, lotID uuid.UUID) (inventorybus.StockLevel, error) {
+	data := struct {
+		InventoryID string `db:"inventory_id"`
+		LotID       string `db:"lot_id"`
+	}{
+		InventoryID: inventoryID.String(),
+		LotID:       lotID.String(),
+	}
+
+	q := stockLevelQuery + `
+	WHERE
+		sl.inventory_id = :inventory_id AND
+		sl.medicine_id = (SELECT medicine_id FROM lots WHERE lot_id = :lot_id)`
+
+	var dbSL dbStockLevel
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbSL); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return inventorybus.StockLevel{}, fmt.Errorf("db: %w", inventorybus.ErrReorderPointNotFound)
+		}
+		return inventorybus.StockLevel{}, fmt.Errorf("db: %w", err)
+	}
+
+	return toCoreStockLevel(dbSL), nil
+}
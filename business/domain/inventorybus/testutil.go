@@ -0,0 +1,43 @@
+package inventorybus
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+)
+
+// TestGenerateNewInventories is a helper method for testing.
+func TestGenerateNewInventories(n int) []NewInventory {
+	newInvs := make([]NewInventory, n)
+
+	idx := rand.Intn(10000)
+	for i := 0; i < n; i++ {
+		idx++
+
+		ni := NewInventory{
+			Name:        fmt.Sprintf("Name%d", idx),
+			Description: fmt.Sprintf("Description%d", idx),
+		}
+
+		newInvs[i] = ni
+	}
+
+	return newInvs
+}
+
+// TestGenerateSeedInventories is a helper method for testing.
+func TestGenerateSeedInventories(ctx context.Context, n int, api *Core) ([]Inventory, error) {
+	newInvs := TestGenerateNewInventories(n)
+
+	invs := make([]Inventory, len(newInvs))
+	for i, ni := range newInvs {
+		inv, err := api.Create(ctx, ni)
+		if err != nil {
+			return nil, fmt.Errorf("seeding inventory: idx: %d : %w", i, err)
+		}
+
+		invs[i] = inv
+	}
+
+	return invs, nil
+}
@@ -0,0 +1,18 @@
+package inventorybus
+
+import (
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/google/uuid"
+)
+
+// Violation represents stock of a lot held by an inventory that is not able
+// to provide the storage conditions its medicine requires.
+type Violation struct {
+	InventoryID  uuid.UUID
+	MedicineID   uuid.UUID
+	LotID        uuid.UUID
+	OnHand       int
+	Storage      medicinebus.Storage
+	Capabilities []Capability
+	Reasons      []string
+}
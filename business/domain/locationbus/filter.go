@@ -0,0 +1,75 @@
+package locationbus
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryFilter holds the available fields a query can be filtered on.
+// We are using pointer semantics because the With API mutates the value.
+type QueryFilter struct {
+	ID          *uuid.UUID
+	InventoryID *uuid.UUID
+	ParentID    *uuid.UUID
+	Kind        *Kind
+}
+
+// Validate can perform a check of the data against the validate tags.
+func (qf *QueryFilter) Validate() error {
+	if err := validate.Check(qf); err != nil {
+		return fmt.Errorf("validate: %w", err)
+	}
+
+	return nil
+}
+
+// WithLocationID sets the ID field of the QueryFilter value.
+func (qf *QueryFilter) WithLocationID(locationID uuid.UUID) {
+	qf.ID = &locationID
+}
+
+// WithInventoryID sets the InventoryID field of the QueryFilter value.
+func (qf *QueryFilter) WithInventoryID(inventoryID uuid.UUID) {
+	qf.InventoryID = &inventoryID
+}
+
+// WithParentID sets the ParentID field of the QueryFilter value.
+func (qf *QueryFilter) WithParentID(parentID uuid.UUID) {
+	qf.ParentID = &parentID
+}
+
+// WithKind sets the Kind field of the QueryFilter value.
+func (qf *QueryFilter) WithKind(kind Kind) {
+	qf.Kind = &kind
+}
+
+// PlacementFilter holds the available fields the placement query can be
+// filtered on.
+type PlacementFilter struct {
+	InventoryID *uuid.UUID
+	LocationID  *uuid.UUID
+	MedicineID  *uuid.UUID
+	LotID       *uuid.UUID
+}
+
+// WithInventoryID sets the InventoryID field of the PlacementFilter value.
+func (pf *PlacementFilter) WithInventoryID(inventoryID uuid.UUID) {
+	pf.InventoryID = &inventoryID
+}
+
+// WithLocationID restricts the result to the bins at or below the location.
+func (pf *PlacementFilter) WithLocationID(locationID uuid.UUID) {
+	pf.LocationID = &locationID
+}
+
+// WithMedicineID sets the MedicineID field of the PlacementFilter value.
+func (pf *PlacementFilter) WithMedicineID(medicineID uuid.UUID) {
+	pf.MedicineID = &medicineID
+}
+
+// WithLotID sets the LotID field of the PlacementFilter value.
+func (pf *PlacementFilter) WithLotID(lotID uuid.UUID) {
+	pf.LotID = &lotID
+}
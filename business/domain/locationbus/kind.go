@@ -0,0 +1,86 @@
+package locationbus
+
+import "fmt"
+
+// Set of possible kinds of location, from the outermost to the innermost.
+var (
+	KindRoom  = Kind{"ROOM"}
+	KindRack  = Kind{"RACK"}
+	KindShelf = Kind{"SHELF"}
+	KindBin   = Kind{"BIN"}
+)
+
+// Set of known kinds.
+var kinds = map[string]Kind{
+	KindRoom.name:  KindRoom,
+	KindRack.name:  KindRack,
+	KindShelf.name: KindShelf,
+	KindBin.name:   KindBin,
+}
+
+// parentKinds maps every kind to the kind of location it has to be placed
+// in. Rooms sit directly under the inventory.
+var parentKinds = map[Kind]Kind{
+	KindRack:  KindRoom,
+	KindShelf: KindRack,
+	KindBin:   KindShelf,
+}
+
+// Kind represents the level of a location in the hierarchy.
+type Kind struct {
+	name string
+}
+
+// ParseKind parses the string value and returns a kind if one exists.
+func ParseKind(value string) (Kind, error) {
+	kind, exists := kinds[value]
+	if !exists {
+		return Kind{}, fmt.Errorf("invalid kind %q", value)
+	}
+
+	return kind, nil
+}
+
+// MustParseKind parses the string value and returns a kind if one exists. If
+// an error occurs the function panics.
+func MustParseKind(value string) Kind {
+	kind, err := ParseKind(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return kind
+}
+
+// Name returns the name of the kind.
+func (k Kind) Name() string {
+	return k.name
+}
+
+// Parent returns the kind of location this kind has to be placed in. It
+// reports false for rooms, which have no parent.
+func (k Kind) Parent() (Kind, bool) {
+	parent, exists := parentKinds[k]
+	return parent, exists
+}
+
+// UnmarshalText implement the unmarshal interface for JSON conversions.
+func (k *Kind) UnmarshalText(data []byte) error {
+	kind, err := ParseKind(string(data))
+	if err != nil {
+		return err
+	}
+
+	k.name = kind.name
+	return nil
+}
+
+// MarshalText implement the marshal interface for JSON conversions.
+func (k Kind) MarshalText() ([]byte, error) {
+	return []byte(k.name), nil
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (k Kind) Equal(k2 Kind) bool {
+	return k.name == k2.name
+}
@@ -0,0 +1,342 @@
+// Package locationbus provides the business API for the storage locations
+// inside an inventory. Locations form a hierarchy of rooms, racks, shelves and
+// bins. Stock of a lot is held in bins, the quantity the inventory holds that
+// has not been put away into a bin is unplaced. The inventory total is never
+// changed here, relocating stock only moves it between bins, so the stock
+// ledger stays the single place where quantities enter and leave.
+package locationbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound          = errors.New("location not found")
+	ErrUniqueCode        = errors.New("location code is not unique within the inventory")
+	ErrInvalidParent     = errors.New("location can't be placed under the requested parent")
+	ErrInUse             = errors.New("location holds stock, has children or has recorded movements")
+	ErrNotBin            = errors.New("stock can only be held in a bin")
+	ErrInvalidQuantity   = errors.New("invalid relocation quantity")
+	ErrSameLocation      = errors.New("relocation source and destination are the same")
+	ErrInsufficientStock = errors.New("insufficient stock at the location")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	ExecuteUnderTransaction(tx transaction.Transaction) (Storer, error)
+	Create(ctx context.Context, loc Location) error
+	Update(ctx context.Context, loc Location) error
+	Delete(ctx context.Context, loc Location) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Location, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, locationID uuid.UUID) (Location, error)
+	AdjustQuantity(ctx context.Context, loc Location, lotID uuid.UUID, delta int, dateUpdated time.Time) (int, error)
+	QueryPlaced(ctx context.Context, inventoryID uuid.UUID, lotID uuid.UUID) (int, int, error)
+	QueryPlacements(ctx context.Context, filter PlacementFilter) ([]Placement, error)
+	QueryRollups(ctx context.Context, filter PlacementFilter) ([]Rollup, error)
+}
+
+// Core manages the set of APIs for location access.
+type Core struct {
+	log           *logger.Logger
+	inventoryCore *inventorybus.Core
+	delegate      *delegate.Delegate
+	storer        Storer
+}
+
+// NewCore constructs a location core API for use.
+func NewCore(log *logger.Logger, inventoryCore *inventorybus.Core, delegate *delegate.Delegate, storer Storer) *Core {
+	return &Core{
+		log:           log,
+		inventoryCore: inventoryCore,
+		delegate:      delegate,
+		storer:        storer,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Core value that will use the
+// specified transaction in any store related calls.
+func (c *Core) ExecuteUnderTransaction(tx transaction.Transaction) (*Core, error) {
+	storer, err := c.storer.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryCore, err := c.inventoryCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	core := Core{
+		log:           c.log,
+		inventoryCore: inventoryCore,
+		delegate:      c.delegate,
+		storer:        storer,
+	}
+
+	return &core, nil
+}
+
+// Create adds a new location to an inventory. Rooms sit directly under the
+// inventory, racks in rooms, shelves on racks and bins on shelves.
+func (c *Core) Create(ctx context.Context, nl NewLocation) (Location, error) {
+	if _, err := c.inventoryCore.QueryByID(ctx, nl.InventoryID); err != nil {
+		return Location{}, fmt.Errorf("inventory.querybyid: %s: %w", nl.InventoryID, err)
+	}
+
+	parentKind, hasParent := nl.Kind.Parent()
+
+	switch {
+	case !hasParent && nl.ParentID != uuid.Nil:
+		return Location{}, fmt.Errorf("%w: %s has no parent", ErrInvalidParent, nl.Kind.Name())
+
+	case hasParent:
+		parent, err := c.storer.QueryByID(ctx, nl.ParentID)
+		if err != nil {
+			return Location{}, fmt.Errorf("query: parentID[%s]: %w", nl.ParentID, err)
+		}
+
+		if parent.InventoryID != nl.InventoryID || parent.Kind != parentKind {
+			return Location{}, fmt.Errorf("%w: %s under %s", ErrInvalidParent, nl.Kind.Name(), parent.Kind.Name())
+		}
+	}
+
+	now := time.Now()
+
+	loc := Location{
+		ID:          uuid.New(),
+		InventoryID: nl.InventoryID,
+		ParentID:    nl.ParentID,
+		Kind:        nl.Kind,
+		Code:        nl.Code,
+		Name:        nl.Name,
+		DateCreated: now,
+		DateUpdated: now,
+	}
+
+	if err := c.storer.Create(ctx, loc); err != nil {
+		return Location{}, fmt.Errorf("create: %w", err)
+	}
+
+	return c.QueryByID(ctx, loc.ID)
+}
+
+// Update modifies information about a location. The location keeps its
+// place in the hierarchy.
+func (c *Core) Update(ctx context.Context, loc Location, ul UpdateLocation) (Location, error) {
+	if ul.Code != nil {
+		loc.Code = *ul.Code
+	}
+
+	if ul.Name != nil {
+		loc.Name = *ul.Name
+	}
+
+	loc.DateUpdated = time.Now()
+
+	if err := c.storer.Update(ctx, loc); err != nil {
+		return Location{}, fmt.Errorf("update: %w", err)
+	}
+
+	return c.QueryByID(ctx, loc.ID)
+}
+
+// Delete removes an empty location that has no children.
+func (c *Core) Delete(ctx context.Context, loc Location) error {
+	var filter PlacementFilter
+	filter.WithLocationID(loc.ID)
+
+	placements, err := c.storer.QueryPlacements(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("queryplacements: locationID[%s]: %w", loc.ID, err)
+	}
+
+	if len(placements) > 0 {
+		return ErrInUse
+	}
+
+	if err := c.storer.Delete(ctx, loc); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of existing locations.
+func (c *Core) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Location, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	locs, err := c.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return locs, nil
+}
+
+// Count returns the total number of locations.
+func (c *Core) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	return c.storer.Count(ctx, filter)
+}
+
+// QueryByID finds the location by the specified ID.
+func (c *Core) QueryByID(ctx context.Context, locationID uuid.UUID) (Location, error) {
+	loc, err := c.storer.QueryByID(ctx, locationID)
+	if err != nil {
+		return Location{}, fmt.Errorf("query: locationID[%s]: %w", locationID, err)
+	}
+
+	return loc, nil
+}
+
+// Relocate moves stock of a lot between the bins of an inventory, puts
+// unplaced stock away into a bin or takes it back out. The inventory total is
+// left untouched. The caller is expected to run this under a transaction so
+// both sides of the move are committed together.
+func (c *Core) Relocate(ctx context.Context, r Relocation) ([]Placement, error) {
+	if r.Quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+
+	if r.FromID == r.ToID {
+		return nil, ErrSameLocation
+	}
+
+	if r.FromID == uuid.Nil {
+		onHand, placed, err := c.storer.QueryPlaced(ctx, r.InventoryID, r.LotID)
+		if err != nil {
+			return nil, fmt.Errorf("queryplaced: inventoryID[%s] lotID[%s]: %w", r.InventoryID, r.LotID, err)
+		}
+
+		if unplaced := onHand - placed; unplaced < r.Quantity {
+			return nil, fmt.Errorf("%w: unplaced[%d] quantity[%d]", ErrInsufficientStock, unplaced, r.Quantity)
+		}
+	} else if _, err := c.AdjustBin(ctx, r.InventoryID, r.FromID, r.LotID, -r.Quantity); err != nil {
+		return nil, err
+	}
+
+	if r.ToID != uuid.Nil {
+		if _, err := c.AdjustBin(ctx, r.InventoryID, r.ToID, r.LotID, r.Quantity); err != nil {
+			return nil, err
+		}
+	}
+
+	var filter PlacementFilter
+	filter.WithInventoryID(r.InventoryID)
+	filter.WithLotID(r.LotID)
+
+	return c.QueryPlacements(ctx, filter)
+}
+
+// AdjustBin applies the delta to the quantity of the lot held in a bin of
+// the inventory and returns the resulting quantity. Quantities are never
+// allowed to go below zero. The stock ledger calls this for movements that
+// name the bin they go in or out of.
+func (c *Core) AdjustBin(ctx context.Context, inventoryID uuid.UUID, locationID uuid.UUID, lotID uuid.UUID, delta int) (int, error) {
+	loc, err := c.storer.QueryByID(ctx, locationID)
+	if err != nil {
+		return 0, fmt.Errorf("query: locationID[%s]: %w", locationID, err)
+	}
+
+	if loc.InventoryID != inventoryID {
+		return 0, fmt.Errorf("query: locationID[%s] inventoryID[%s]: %w", locationID, inventoryID, ErrNotFound)
+	}
+
+	if loc.Kind != KindBin {
+		return 0, fmt.Errorf("%w: %s is a %s", ErrNotBin, loc.Path, loc.Kind.Name())
+	}
+
+	quantity, err := c.storer.AdjustQuantity(ctx, loc, lotID, delta, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("adjustquantity: locationID[%s] lotID[%s] delta[%d]: %w", locationID, lotID, delta, err)
+	}
+
+	return quantity, nil
+}
+
+// Draw takes the quantity of a lot out of the bins of the inventory for a
+// removal that doesn't name a bin. Unplaced stock is used up first, the rest
+// is drawn from the bins holding the lot in the order of their path. Stock
+// the inventory doesn't hold at all is left for the inventory to refuse. The
+// stock ledger calls this before applying such a movement, under the same
+// transaction.
+func (c *Core) Draw(ctx context.Context, inventoryID uuid.UUID, lotID uuid.UUID, quantity int) error {
+	onHand, placed, err := c.storer.QueryPlaced(ctx, inventoryID, lotID)
+	if err != nil {
+		return fmt.Errorf("queryplaced: inventoryID[%s] lotID[%s]: %w", inventoryID, lotID, err)
+	}
+
+	remaining := quantity - (onHand - placed)
+	if remaining <= 0 || quantity > onHand {
+		return nil
+	}
+
+	var filter PlacementFilter
+	filter.WithInventoryID(inventoryID)
+	filter.WithLotID(lotID)
+
+	placements, err := c.storer.QueryPlacements(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("queryplacements: %w", err)
+	}
+
+	for _, p := range placements {
+		if remaining == 0 {
+			break
+		}
+
+		if p.Unplaced() {
+			continue
+		}
+
+		taken := min(p.Quantity, remaining)
+		if _, err := c.AdjustBin(ctx, inventoryID, p.LocationID, lotID, -taken); err != nil {
+			return err
+		}
+
+		remaining -= taken
+	}
+
+	return nil
+}
+
+// QueryPlacements answers where stock is held. It returns the quantity of
+// every lot in every bin matching the filter, together with the stock that
+// has not been put away yet.
+func (c *Core) QueryPlacements(ctx context.Context, filter PlacementFilter) ([]Placement, error) {
+	placements, err := c.storer.QueryPlacements(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("queryplacements: %w", err)
+	}
+
+	return placements, nil
+}
+
+// QueryRollups returns every location matching the filter with the total
+// quantity held in the bins at or below it.
+func (c *Core) QueryRollups(ctx context.Context, filter PlacementFilter) ([]Rollup, error) {
+	rollups, err := c.storer.QueryRollups(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("queryrollups: %w", err)
+	}
+
+	return rollups, nil
+}
@@ -0,0 +1,74 @@
+package locationbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Location represents a room, rack, shelf or bin inside an inventory. Only
+// bins hold stock, every other level rolls up the bins below it. Path is the
+// codes of the location and its ancestors joined with a slash.
+type Location struct {
+	ID          uuid.UUID
+	InventoryID uuid.UUID
+	ParentID    uuid.UUID
+	Kind        Kind
+	Code        string
+	Name        string
+	Path        string
+	DateCreated time.Time
+	DateUpdated time.Time
+}
+
+// NewLocation contains information needed to create a new location. ParentID
+// is left as the zero value for rooms.
+type NewLocation struct {
+	InventoryID uuid.UUID
+	ParentID    uuid.UUID
+	Kind        Kind
+	Code        string
+	Name        string
+}
+
+// UpdateLocation contains information needed to update a location. Fields
+// that are not set are left unchanged.
+type UpdateLocation struct {
+	Code *string
+	Name *string
+}
+
+// Placement represents the quantity of a lot held in a bin. Stock the
+// inventory holds that has not been put away into a bin is reported with a
+// zero LocationID and an empty Path.
+type Placement struct {
+	InventoryID uuid.UUID
+	LocationID  uuid.UUID
+	Path        string
+	MedicineID  uuid.UUID
+	LotID       uuid.UUID
+	Quantity    int
+}
+
+// Unplaced reports whether the placement is stock that is not in a bin.
+func (p Placement) Unplaced() bool {
+	return p.LocationID == uuid.Nil
+}
+
+// Rollup represents the total quantity held in the bins at or below a
+// location.
+type Rollup struct {
+	Location
+	Quantity int
+}
+
+// Relocation contains information needed to move stock of a lot between the
+// bins of an inventory. A zero FromID puts unplaced stock away into a bin and
+// a zero ToID takes stock out of a bin without placing it anywhere.
+type Relocation struct {
+	InventoryID uuid.UUID
+	LotID       uuid.UUID
+	FromID      uuid.UUID
+	ToID        uuid.UUID
+	Quantity    int
+}
@@ -0,0 +1,14 @@
+package locationbus
+
+import "github.com/EnesDemirtas/medisync/business/api/order"
+
+// DefaultOrderBy represents the default way we sort.
+var DefaultOrderBy = order.NewBy(OrderByPath, order.ASC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID          = "location_id"
+	OrderByPath        = "path"
+	OrderByKind        = "kind"
+	OrderByDateCreated = "date_created"
+)
@@ -0,0 +1,104 @@
+package locationdb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+)
+
+func applyFilter(filter locationbus.QueryFilter, data map[string]interface{}, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["location_id"] = *filter.ID
+		wc = append(wc, "location_id = :location_id")
+	}
+
+	if filter.InventoryID != nil {
+		data["inventory_id"] = *filter.InventoryID
+		wc = append(wc, "inventory_id = :inventory_id")
+	}
+
+	if filter.ParentID != nil {
+		data["parent_id"] = *filter.ParentID
+		wc = append(wc, "parent_id = :parent_id")
+	}
+
+	if filter.Kind != nil {
+		data["kind"] = filter.Kind.Name()
+		wc = append(wc, "kind = :kind")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
+
+func applyPlacementFilter(filter locationbus.PlacementFilter, data map[string]interface{}, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.InventoryID != nil {
+		data["inventory_id"] = *filter.InventoryID
+		wc = append(wc, "inventory_id = :inventory_id")
+	}
+
+	if filter.LocationID != nil {
+		data["location_id"] = *filter.LocationID
+		wc = append(wc, "location_id IN (SELECT location_id FROM tree WHERE ancestor_id = :location_id)")
+	}
+
+	if filter.MedicineID != nil {
+		data["medicine_id"] = *filter.MedicineID
+		wc = append(wc, "medicine_id = :medicine_id")
+	}
+
+	if filter.LotID != nil {
+		data["lot_id"] = *filter.LotID
+		wc = append(wc, "lot_id = :lot_id")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
+
+// applyRollupFilter restricts the locations of the rollup to the inventory
+// and subtree asked for, while the medicine and lot restrict which stock is
+// added up so locations without matching stock still show with a zero total.
+func applyRollupFilter(filter locationbus.PlacementFilter, data map[string]interface{}, buf *bytes.Buffer) {
+	var jc []string
+	var wc []string
+
+	if filter.MedicineID != nil {
+		data["medicine_id"] = *filter.MedicineID
+		jc = append(jc, "li.medicine_id = :medicine_id")
+	}
+
+	if filter.LotID != nil {
+		data["lot_id"] = *filter.LotID
+		jc = append(jc, "li.lot_id = :lot_id")
+	}
+
+	if filter.InventoryID != nil {
+		data["inventory_id"] = *filter.InventoryID
+		wc = append(wc, "loc.inventory_id = :inventory_id")
+	}
+
+	if filter.LocationID != nil {
+		data["location_id"] = *filter.LocationID
+		wc = append(wc, "loc.location_id IN (SELECT location_id FROM tree WHERE ancestor_id = :location_id)")
+	}
+
+	for _, c := range jc {
+		buf.WriteString(" AND ")
+		buf.WriteString(c)
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
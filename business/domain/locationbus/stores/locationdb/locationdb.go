@@ -0,0 +1,425 @@
+// Package locationdb contains location related CRUD functionality.
+package locationdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// withPaths builds the path of every location from the codes of its
+// ancestors.
+const withPaths = `
+	WITH RECURSIVE paths AS (
+		SELECT
+			location_id, CAST(code AS TEXT) AS path
+		FROM
+			locations
+		WHERE
+			parent_id IS NULL
+		UNION ALL
+		SELECT
+			l.location_id, p.path || '/' || l.code
+		FROM
+			locations AS l
+		JOIN
+			paths AS p ON p.location_id = l.parent_id
+	)`
+
+// withTree pairs every location with itself and each of its descendants. It
+// is appended to withPaths.
+const withTree = `,
+	tree AS (
+		SELECT
+			location_id AS ancestor_id, location_id
+		FROM
+			locations
+		UNION ALL
+		SELECT
+			t.ancestor_id, l.location_id
+		FROM
+			tree AS t
+		JOIN
+			locations AS l ON l.parent_id = t.location_id
+	)`
+
+// Store manages the set of APIs for location database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Store value replacing the sqlx DB
+// value with a sqlx DB value that is currently inside a transaction.
+func (s *Store) ExecuteUnderTransaction(tx transaction.Transaction) (locationbus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	store := Store{
+		log: s.log,
+		db:  ec,
+	}
+
+	return &store, nil
+}
+
+// Create inserts a new location into the database.
+func (s *Store) Create(ctx context.Context, loc locationbus.Location) error {
+	const q = `
+	INSERT INTO locations
+		(location_id, inventory_id, parent_id, kind, code, name, date_created, date_updated)
+	VALUES
+		(:location_id, :inventory_id, :parent_id, :kind, :code, :name, :date_created, :date_updated)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBLocation(loc)); err != nil {
+		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+			return fmt.Errorf("namedexeccontext: %w", locationbus.ErrUniqueCode)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Update replaces a location document in the database.
+func (s *Store) Update(ctx context.Context, loc locationbus.Location) error {
+	const q = `
+	UPDATE
+		locations
+	SET
+		"code" = :code,
+		"name" = :name,
+		"date_updated" = :date_updated
+	WHERE
+		location_id = :location_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBLocation(loc)); err != nil {
+		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+			return fmt.Errorf("namedexeccontext: %w", locationbus.ErrUniqueCode)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a location from the database together with the emptied
+// rows of the lots it used to hold.
+func (s *Store) Delete(ctx context.Context, loc locationbus.Location) error {
+	data := struct {
+		ID string `db:"location_id"`
+	}{
+		ID: loc.ID.String(),
+	}
+
+	const q = `
+	DELETE FROM
+		locations
+	WHERE
+		location_id = :location_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, data); err != nil {
+		if errors.Is(err, sqldb.ErrDBForeignKey) {
+			return fmt.Errorf("namedexeccontext: %w", locationbus.ErrInUse)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of existing locations from the database.
+func (s *Store) Query(ctx context.Context, filter locationbus.QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]locationbus.Location, error) {
+	data := map[string]interface{}{
+		"offset":        (pageNumber - 1) * rowsPerPage,
+		"rows_per_page": rowsPerPage,
+	}
+
+	const q = withPaths + `
+	SELECT
+		location_id, inventory_id, parent_id, kind, code, name, path, date_created, date_updated
+	FROM (
+		SELECT
+			l.location_id, l.inventory_id, l.parent_id, l.kind, l.code, l.name, p.path, l.date_created, l.date_updated
+		FROM
+			locations AS l
+		JOIN
+			paths AS p ON p.location_id = l.location_id
+	) AS loc`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbLocs []dbLocation
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbLocs); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreLocationSlice(dbLocs)
+}
+
+// Count returns the total number of locations in the database.
+func (s *Store) Count(ctx context.Context, filter locationbus.QueryFilter) (int, error) {
+	data := map[string]interface{}{}
+
+	const q = `
+	SELECT
+		count(1)
+	FROM
+		locations`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID gets the specified location from the database.
+func (s *Store) QueryByID(ctx context.Context, locationID uuid.UUID) (locationbus.Location, error) {
+	data := struct {
+		ID string `db:"location_id"`
+	}{
+		ID: locationID.String(),
+	}
+
+	const q = withPaths + `
+	SELECT
+		l.location_id, l.inventory_id, l.parent_id, l.kind, l.code, l.name, p.path, l.date_created, l.date_updated
+	FROM
+		locations AS l
+	JOIN
+		paths AS p ON p.location_id = l.location_id
+	WHERE
+		l.location_id = :location_id`
+
+	var dbLoc dbLocation
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbLoc); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return locationbus.Location{}, fmt.Errorf("db: %w", locationbus.ErrNotFound)
+		}
+		return locationbus.Location{}, fmt.Errorf("db: %w", err)
+	}
+
+	return toCoreLocation(dbLoc)
+}
+
+// AdjustQuantity atomically applies the delta to the quantity of the lot
+// held in the bin and returns the resulting quantity.
+func (s *Store) AdjustQuantity(ctx context.Context, loc locationbus.Location, lotID uuid.UUID, delta int, dateUpdated time.Time) (int, error) {
+	data := struct {
+		LocationID  string    `db:"location_id"`
+		InventoryID string    `db:"inventory_id"`
+		LotID       string    `db:"lot_id"`
+		Delta       int       `db:"delta"`
+		DateUpdated time.Time `db:"date_updated"`
+	}{
+		LocationID:  loc.ID.String(),
+		InventoryID: loc.InventoryID.String(),
+		LotID:       lotID.String(),
+		Delta:       delta,
+		DateUpdated: dateUpdated.UTC(),
+	}
+
+	// Same as for inventory items, removals can't go through the upsert
+	// since the check constraint fires before the conflict is detected.
+	q := `
+	INSERT INTO location_items
+		(location_id, lot_id, inventory_id, medicine_id, quantity, date_updated)
+	SELECT
+		:location_id, l.lot_id, :inventory_id, l.medicine_id, :delta, :date_updated
+	FROM
+		lots l
+	WHERE
+		l.lot_id = :lot_id
+	ON CONFLICT (location_id, lot_id) DO UPDATE SET
+		"quantity" = location_items.quantity + EXCLUDED.quantity,
+		"date_updated" = EXCLUDED.date_updated
+	RETURNING
+		quantity`
+
+	if delta < 0 {
+		q = `
+	UPDATE
+		location_items
+	SET
+		"quantity" = quantity + :delta,
+		"date_updated" = :date_updated
+	WHERE
+		location_id = :location_id AND
+		lot_id = :lot_id AND
+		quantity + :delta >= 0
+	RETURNING
+		quantity`
+	}
+
+	var result struct {
+		Quantity int `db:"quantity"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &result); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) && delta < 0 {
+			return 0, fmt.Errorf("db: %w", locationbus.ErrInsufficientStock)
+		}
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return result.Quantity, nil
+}
+
+// QueryPlaced returns the quantity of the lot the inventory holds and how
+// much of it is held in its bins. The inventory row is locked until the
+// surrounding transaction ends so the stock can't be placed twice.
+func (s *Store) QueryPlaced(ctx context.Context, inventoryID uuid.UUID, lotID uuid.UUID) (int, int, error) {
+	data := struct {
+		InventoryID string `db:"inventory_id"`
+		LotID       string `db:"lot_id"`
+	}{
+		InventoryID: inventoryID.String(),
+		LotID:       lotID.String(),
+	}
+
+	const q = `
+	SELECT
+		quantity
+	FROM
+		inventory_items
+	WHERE
+		inventory_id = :inventory_id AND
+		lot_id = :lot_id
+	FOR UPDATE`
+
+	var onHand struct {
+		Quantity int `db:"quantity"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &onHand); err != nil {
+		if !errors.Is(err, sqldb.ErrDBNotFound) {
+			return 0, 0, fmt.Errorf("db: on hand: %w", err)
+		}
+	}
+
+	const qp = `
+	SELECT
+		COALESCE(SUM(quantity), 0) AS quantity
+	FROM
+		location_items
+	WHERE
+		inventory_id = :inventory_id AND
+		lot_id = :lot_id`
+
+	var placed struct {
+		Quantity int `db:"quantity"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, qp, data, &placed); err != nil {
+		return 0, 0, fmt.Errorf("db: placed: %w", err)
+	}
+
+	return onHand.Quantity, placed.Quantity, nil
+}
+
+// QueryPlacements retrieves the quantity of every lot held in a bin, along
+// with the unplaced remainder of every inventory item.
+func (s *Store) QueryPlacements(ctx context.Context, filter locationbus.PlacementFilter) ([]locationbus.Placement, error) {
+	data := map[string]interface{}{}
+
+	const q = withPaths + withTree + `,
+	placements AS (
+		SELECT
+			li.inventory_id, li.location_id, p.path, li.medicine_id, li.lot_id, li.quantity
+		FROM
+			location_items AS li
+		JOIN
+			paths AS p ON p.location_id = li.location_id
+		WHERE
+			li.quantity > 0
+		UNION ALL
+		SELECT
+			ii.inventory_id, NULL, '', ii.medicine_id, ii.lot_id, ii.quantity - COALESCE(SUM(li.quantity), 0)
+		FROM
+			inventory_items AS ii
+		LEFT JOIN
+			location_items AS li ON li.inventory_id = ii.inventory_id AND li.lot_id = ii.lot_id
+		GROUP BY
+			ii.inventory_id, ii.medicine_id, ii.lot_id, ii.quantity
+		HAVING
+			ii.quantity - COALESCE(SUM(li.quantity), 0) > 0
+	)
+	SELECT
+		inventory_id, location_id, path, medicine_id, lot_id, quantity
+	FROM
+		placements`
+
+	buf := bytes.NewBufferString(q)
+	applyPlacementFilter(filter, data, buf)
+	buf.WriteString(" ORDER BY inventory_id, medicine_id, lot_id, path")
+
+	var dbPlacements []dbPlacement
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbPlacements); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCorePlacementSlice(dbPlacements), nil
+}
+
+// QueryRollups retrieves every location with the total quantity held in the
+// bins at or below it.
+func (s *Store) QueryRollups(ctx context.Context, filter locationbus.PlacementFilter) ([]locationbus.Rollup, error) {
+	data := map[string]interface{}{}
+
+	const q = withPaths + withTree + `
+	SELECT
+		loc.location_id, loc.inventory_id, loc.parent_id, loc.kind, loc.code, loc.name, p.path, loc.date_created, loc.date_updated,
+		COALESCE(SUM(li.quantity), 0) AS quantity
+	FROM
+		locations AS loc
+	JOIN
+		paths AS p ON p.location_id = loc.location_id
+	JOIN
+		tree AS t ON t.ancestor_id = loc.location_id
+	LEFT JOIN
+		location_items AS li ON li.location_id = t.location_id`
+
+	buf := bytes.NewBufferString(q)
+	applyRollupFilter(filter, data, buf)
+	buf.WriteString(" GROUP BY loc.location_id, p.path ORDER BY loc.inventory_id, p.path")
+
+	var dbRollups []dbRollup
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbRollups); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreRollupSlice(dbRollups)
+}
@@ -0,0 +1,118 @@
+package locationdb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+	"github.com/google/uuid"
+)
+
+type dbLocation struct {
+	ID          uuid.UUID     `db:"location_id"`
+	InventoryID uuid.UUID     `db:"inventory_id"`
+	ParentID    uuid.NullUUID `db:"parent_id"`
+	Kind        string        `db:"kind"`
+	Code        string        `db:"code"`
+	Name        string        `db:"name"`
+	Path        string        `db:"path"`
+	DateCreated time.Time     `db:"date_created"`
+	DateUpdated time.Time     `db:"date_updated"`
+}
+
+type dbPlacement struct {
+	InventoryID uuid.UUID     `db:"inventory_id"`
+	LocationID  uuid.NullUUID `db:"location_id"`
+	Path        string        `db:"path"`
+	MedicineID  uuid.UUID     `db:"medicine_id"`
+	LotID       uuid.UUID     `db:"lot_id"`
+	Quantity    int           `db:"quantity"`
+}
+
+type dbRollup struct {
+	dbLocation
+	Quantity int `db:"quantity"`
+}
+
+func toDBLocation(loc locationbus.Location) dbLocation {
+	return dbLocation{
+		ID:          loc.ID,
+		InventoryID: loc.InventoryID,
+		ParentID: uuid.NullUUID{
+			UUID:  loc.ParentID,
+			Valid: loc.ParentID != uuid.Nil,
+		},
+		Kind:        loc.Kind.Name(),
+		Code:        loc.Code,
+		Name:        loc.Name,
+		DateCreated: loc.DateCreated.UTC(),
+		DateUpdated: loc.DateUpdated.UTC(),
+	}
+}
+
+func toCoreLocation(dbLoc dbLocation) (locationbus.Location, error) {
+	kind, err := locationbus.ParseKind(dbLoc.Kind)
+	if err != nil {
+		return locationbus.Location{}, fmt.Errorf("parse kind: %w", err)
+	}
+
+	loc := locationbus.Location{
+		ID:          dbLoc.ID,
+		InventoryID: dbLoc.InventoryID,
+		ParentID:    dbLoc.ParentID.UUID,
+		Kind:        kind,
+		Code:        dbLoc.Code,
+		Name:        dbLoc.Name,
+		Path:        dbLoc.Path,
+		DateCreated: dbLoc.DateCreated.In(time.Local),
+		DateUpdated: dbLoc.DateUpdated.In(time.Local),
+	}
+
+	return loc, nil
+}
+
+func toCoreLocationSlice(dbLocs []dbLocation) ([]locationbus.Location, error) {
+	locs := make([]locationbus.Location, len(dbLocs))
+	for i, dbLoc := range dbLocs {
+		var err error
+		locs[i], err = toCoreLocation(dbLoc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return locs, nil
+}
+
+func toCorePlacementSlice(dbPlacements []dbPlacement) []locationbus.Placement {
+	placements := make([]locationbus.Placement, len(dbPlacements))
+	for i, dbPl := range dbPlacements {
+		placements[i] = locationbus.Placement{
+			InventoryID: dbPl.InventoryID,
+			LocationID:  dbPl.LocationID.UUID,
+			Path:        dbPl.Path,
+			MedicineID:  dbPl.MedicineID,
+			LotID:       dbPl.LotID,
+			Quantity:    dbPl.Quantity,
+		}
+	}
+
+	return placements
+}
+
+func toCoreRollupSlice(dbRollups []dbRollup) ([]locationbus.Rollup, error) {
+	rollups := make([]locationbus.Rollup, len(dbRollups))
+	for i, dbRollup := range dbRollups {
+		loc, err := toCoreLocation(dbRollup.dbLocation)
+		if err != nil {
+			return nil, err
+		}
+
+		rollups[i] = locationbus.Rollup{
+			Location: loc,
+			Quantity: dbRollup.Quantity,
+		}
+	}
+
+	return rollups, nil
+}
@@ -0,0 +1,24 @@
+package locationdb
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+)
+
+var orderByFields = map[string]string{
+	locationbus.OrderByID:          "location_id",
+	locationbus.OrderByPath:        "path",
+	locationbus.OrderByKind:        "kind",
+	locationbus.OrderByDateCreated: "date_created",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
@@ -0,0 +1,61 @@
+package lotbus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryFilter holds the available fields a query can be filtered on.
+// We are using pointer semantics because the With API mutates the value.
+type QueryFilter struct {
+	ID              *uuid.UUID
+	MedicineID      *uuid.UUID
+	Number          *string
+	StartExpiryDate *time.Time
+	EndExpiryDate   *time.Time
+	Quarantined     *bool
+}
+
+// Validate can perform a check of the data against the validate tags.
+func (qf *QueryFilter) Validate() error {
+	if err := validate.Check(qf); err != nil {
+		return fmt.Errorf("validate: %w", err)
+	}
+
+	return nil
+}
+
+// WithLotID sets the ID field of the QueryFilter value.
+func (qf *QueryFilter) WithLotID(lotID uuid.UUID) {
+	qf.ID = &lotID
+}
+
+// WithMedicineID sets the MedicineID field of the QueryFilter value.
+func (qf *QueryFilter) WithMedicineID(medicineID uuid.UUID) {
+	qf.MedicineID = &medicineID
+}
+
+// WithNumber sets the Number field of the QueryFilter value.
+func (qf *QueryFilter) WithNumber(number string) {
+	qf.Number = &number
+}
+
+// WithStartExpiryDate sets the StartExpiryDate field of the QueryFilter value.
+func (qf *QueryFilter) WithStartExpiryDate(startDate time.Time) {
+	d := startDate.UTC()
+	qf.StartExpiryDate = &d
+}
+
+// WithEndExpiryDate sets the EndExpiryDate field of the QueryFilter value.
+func (qf *QueryFilter) WithEndExpiryDate(endDate time.Time) {
+	d := endDate.UTC()
+	qf.EndExpiryDate = &d
+}
+
+// WithQuarantined sets the Quarantined field of the QueryFilter value.
+func (qf *QueryFilter) WithQuarantined(quarantined bool) {
+	qf.Quarantined = &quarantined
+}
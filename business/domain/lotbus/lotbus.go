@@ -0,0 +1,211 @@
+// Package lotbus provides the business API for medicine lots. A lot ties a
+// delivered batch of a medicine to its lot number, expiry and manufacture
+// date so stock can be tracked per batch.
+package lotbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound     = errors.New("lot not found")
+	ErrUniqueNumber = errors.New("lot number already exists for medicine")
+	ErrInvalidDates = errors.New("manufacture date must be before expiry date")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	ExecuteUnderTransaction(tx transaction.Transaction) (Storer, error)
+	Create(ctx context.Context, lot Lot) error
+	Update(ctx context.Context, lot Lot) error
+	Delete(ctx context.Context, lot Lot) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Lot, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, lotID uuid.UUID) (Lot, error)
+	QueryByIDs(ctx context.Context, lotIDs []uuid.UUID) ([]Lot, error)
+}
+
+// Core manages the set of APIs for lot access.
+type Core struct {
+	log          *logger.Logger
+	medicineCore *medicinebus.Core
+	delegate     *delegate.Delegate
+	storer       Storer
+}
+
+// NewCore constructs a lot core API for use.
+func NewCore(log *logger.Logger, medicineCore *medicinebus.Core, delegate *delegate.Delegate, storer Storer) *Core {
+	return &Core{
+		log:          log,
+		medicineCore: medicineCore,
+		delegate:     delegate,
+		storer:       storer,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Core value that will use the
+// specified transaction in any store related calls.
+func (c *Core) ExecuteUnderTransaction(tx transaction.Transaction) (*Core, error) {
+	storer, err := c.storer.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	medicineCore, err := c.medicineCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	core := Core{
+		log:          c.log,
+		medicineCore: medicineCore,
+		delegate:     c.delegate,
+		storer:       storer,
+	}
+
+	return &core, nil
+}
+
+// Create adds a new lot to the system.
+func (c *Core) Create(ctx context.Context, nl NewLot) (Lot, error) {
+	if _, err := c.medicineCore.QueryByID(ctx, nl.MedicineID); err != nil {
+		return Lot{}, fmt.Errorf("medicine.querybyid: %s: %w", nl.MedicineID, err)
+	}
+
+	if !validDates(nl.ManufactureDate, nl.ExpiryDate) {
+		return Lot{}, ErrInvalidDates
+	}
+
+	now := time.Now()
+
+	lot := Lot{
+		ID:              uuid.New(),
+		MedicineID:      nl.MedicineID,
+		Number:          nl.Number,
+		ExpiryDate:      nl.ExpiryDate,
+		ManufactureDate: nl.ManufactureDate,
+		DateCreated:     now,
+		DateUpdated:     now,
+	}
+
+	if err := c.storer.Create(ctx, lot); err != nil {
+		return Lot{}, fmt.Errorf("create: %w", err)
+	}
+
+	return lot, nil
+}
+
+// Update modifies information about a lot.
+func (c *Core) Update(ctx context.Context, lot Lot, ul UpdateLot) (Lot, error) {
+	if ul.Number != nil {
+		lot.Number = *ul.Number
+	}
+
+	if ul.ExpiryDate != nil {
+		lot.ExpiryDate = *ul.ExpiryDate
+	}
+
+	if ul.ManufactureDate != nil {
+		lot.ManufactureDate = *ul.ManufactureDate
+	}
+
+	if !validDates(lot.ManufactureDate, lot.ExpiryDate) {
+		return Lot{}, ErrInvalidDates
+	}
+
+	lot.DateUpdated = time.Now()
+
+	if err := c.storer.Update(ctx, lot); err != nil {
+		return Lot{}, fmt.Errorf("update: %w", err)
+	}
+
+	return lot, nil
+}
+
+// SetQuarantined puts the lot in or takes it out of quarantine.
+func (c *Core) SetQuarantined(ctx context.Context, lot Lot, quarantined bool) (Lot, error) {
+	lot.Quarantined = quarantined
+	lot.DateUpdated = time.Now()
+
+	if err := c.storer.Update(ctx, lot); err != nil {
+		return Lot{}, fmt.Errorf("update: %w", err)
+	}
+
+	return lot, nil
+}
+
+// Delete removes the specified lot.
+func (c *Core) Delete(ctx context.Context, lot Lot) error {
+	if err := c.storer.Delete(ctx, lot); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of existing lots.
+func (c *Core) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Lot, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	lots, err := c.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return lots, nil
+}
+
+// Count returns the total number of lots.
+func (c *Core) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	return c.storer.Count(ctx, filter)
+}
+
+// QueryByID finds the lot by the specified ID.
+func (c *Core) QueryByID(ctx context.Context, lotID uuid.UUID) (Lot, error) {
+	lot, err := c.storer.QueryByID(ctx, lotID)
+	if err != nil {
+		return Lot{}, fmt.Errorf("query: lotID[%s]: %w", lotID, err)
+	}
+
+	return lot, nil
+}
+
+// QueryByIDs finds the lots by the specified lot IDs.
+func (c *Core) QueryByIDs(ctx context.Context, lotIDs []uuid.UUID) ([]Lot, error) {
+	lots, err := c.storer.QueryByIDs(ctx, lotIDs)
+	if err != nil {
+		return nil, fmt.Errorf("query: lotIDs[%s]: %w", lotIDs, err)
+	}
+
+	return lots, nil
+}
+
+// =============================================================================
+
+// validDates reports whether a known manufacture date falls before the expiry
+// date. An unknown manufacture date is always accepted.
+func validDates(manufactureDate time.Time, expiryDate time.Time) bool {
+	if manufactureDate.IsZero() {
+		return true
+	}
+
+	return manufactureDate.Before(expiryDate)
+}
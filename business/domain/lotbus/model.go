@@ -0,0 +1,37 @@
+package lotbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Lot represents a single delivered batch of a medicine. Every lot carries
+// its own expiry and manufacture date. Stock of a quarantined lot can't be
+// dispensed, transferred or allocated until the quarantine is lifted.
+type Lot struct {
+	ID              uuid.UUID
+	MedicineID      uuid.UUID
+	Number          string
+	ExpiryDate      time.Time
+	ManufactureDate time.Time
+	Quarantined     bool
+	DateCreated     time.Time
+	DateUpdated     time.Time
+}
+
+// NewLot contains information needed to create a new lot. ManufactureDate
+// may be left as the zero value when it is not known.
+type NewLot struct {
+	MedicineID      uuid.UUID
+	Number          string
+	ExpiryDate      time.Time
+	ManufactureDate time.Time
+}
+
+// UpdateLot contains information needed to update a lot.
+type UpdateLot struct {
+	Number          *string
+	ExpiryDate      *time.Time
+	ManufactureDate *time.Time
+}
@@ -0,0 +1,16 @@
+package lotbus
+
+import "github.com/EnesDemirtas/medisync/business/api/order"
+
+// DefaultOrderBy represents the default way we sort. Lots that expire first
+// are listed first.
+var DefaultOrderBy = order.NewBy(OrderByExpiryDate, order.ASC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID              = "lot_id"
+	OrderByMedicineID      = "medicine_id"
+	OrderByNumber          = "lot_number"
+	OrderByExpiryDate      = "expiry_date"
+	OrderByManufactureDate = "manufacture_date"
+)
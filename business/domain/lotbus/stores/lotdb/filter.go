@@ -0,0 +1,47 @@
+package lotdb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+)
+
+func applyFilter(filter lotbus.QueryFilter, data map[string]interface{}, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["lot_id"] = *filter.ID
+		wc = append(wc, "lot_id = :lot_id")
+	}
+
+	if filter.MedicineID != nil {
+		data["medicine_id"] = *filter.MedicineID
+		wc = append(wc, "medicine_id = :medicine_id")
+	}
+
+	if filter.Number != nil {
+		data["lot_number"] = *filter.Number
+		wc = append(wc, "lot_number = :lot_number")
+	}
+
+	if filter.StartExpiryDate != nil {
+		data["start_expiry_date"] = *filter.StartExpiryDate
+		wc = append(wc, "expiry_date >= :start_expiry_date")
+	}
+
+	if filter.EndExpiryDate != nil {
+		data["end_expiry_date"] = *filter.EndExpiryDate
+		wc = append(wc, "expiry_date <= :end_expiry_date")
+	}
+
+	if filter.Quarantined != nil {
+		data["quarantined"] = *filter.Quarantined
+		wc = append(wc, "quarantined = :quarantined")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
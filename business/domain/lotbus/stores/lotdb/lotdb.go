@@ -0,0 +1,222 @@
+// Package lotdb contains lot related CRUD functionality.
+package lotdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb/dbarray"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Store manages the set of APIs for lot database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Store value replacing the sqlx DB
+// value with a sqlx DB value that is currently inside a transaction.
+func (s *Store) ExecuteUnderTransaction(tx transaction.Transaction) (lotbus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	store := Store{
+		log: s.log,
+		db:  ec,
+	}
+
+	return &store, nil
+}
+
+// Create inserts a new lot into the database.
+func (s *Store) Create(ctx context.Context, lot lotbus.Lot) error {
+	const q = `
+	INSERT INTO lots
+		(lot_id, medicine_id, lot_number, expiry_date, manufacture_date, quarantined, date_created, date_updated)
+	VALUES
+		(:lot_id, :medicine_id, :lot_number, :expiry_date, :manufacture_date, :quarantined, :date_created, :date_updated)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBLot(lot)); err != nil {
+		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+			return fmt.Errorf("namedexeccontext: %w", lotbus.ErrUniqueNumber)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Update replaces a lot document in the database.
+func (s *Store) Update(ctx context.Context, lot lotbus.Lot) error {
+	const q = `
+	UPDATE
+		lots
+	SET
+		"lot_number" = :lot_number,
+		"expiry_date" = :expiry_date,
+		"manufacture_date" = :manufacture_date,
+		"quarantined" = :quarantined,
+		"date_updated" = :date_updated
+	WHERE
+		lot_id = :lot_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBLot(lot)); err != nil {
+		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+			return lotbus.ErrUniqueNumber
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a lot from the database.
+func (s *Store) Delete(ctx context.Context, lot lotbus.Lot) error {
+	data := struct {
+		ID string `db:"lot_id"`
+	}{
+		ID: lot.ID.String(),
+	}
+
+	const q = `
+	DELETE FROM
+		lots
+	WHERE
+		lot_id = :lot_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, data); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of existing lots from the database.
+func (s *Store) Query(ctx context.Context, filter lotbus.QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]lotbus.Lot, error) {
+	data := map[string]interface{}{
+		"offset":        (pageNumber - 1) * rowsPerPage,
+		"rows_per_page": rowsPerPage,
+	}
+
+	const q = `
+	SELECT
+		lot_id, medicine_id, lot_number, expiry_date, manufacture_date, quarantined, date_created, date_updated
+	FROM
+		lots`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbLots []dbLot
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbLots); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreLotSlice(dbLots), nil
+}
+
+// Count returns the total number of lots in the database.
+func (s *Store) Count(ctx context.Context, filter lotbus.QueryFilter) (int, error) {
+	data := map[string]interface{}{}
+
+	const q = `
+	SELECT
+		count(1)
+	FROM
+		lots`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID gets the specified lot from the database.
+func (s *Store) QueryByID(ctx context.Context, lotID uuid.UUID) (lotbus.Lot, error) {
+	data := struct {
+		ID string `db:"lot_id"`
+	}{
+		ID: lotID.String(),
+	}
+
+	const q = `
+	SELECT
+		lot_id, medicine_id, lot_number, expiry_date, manufacture_date, quarantined, date_created, date_updated
+	FROM
+		lots
+	WHERE
+		lot_id = :lot_id`
+
+	var dbLot dbLot
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbLot); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return lotbus.Lot{}, fmt.Errorf("db: %w", lotbus.ErrNotFound)
+		}
+		return lotbus.Lot{}, fmt.Errorf("db: %w", err)
+	}
+
+	return toCoreLot(dbLot), nil
+}
+
+// QueryByIDs gets the specified lots from the database.
+func (s *Store) QueryByIDs(ctx context.Context, lotIDs []uuid.UUID) ([]lotbus.Lot, error) {
+	ids := make([]string, len(lotIDs))
+	for i, lotID := range lotIDs {
+		ids[i] = lotID.String()
+	}
+
+	data := struct {
+		ID any `db:"lot_id"`
+	}{
+		ID: dbarray.Array(ids),
+	}
+
+	const q = `
+	SELECT
+		lot_id, medicine_id, lot_number, expiry_date, manufacture_date, quarantined, date_created, date_updated
+	FROM
+		lots
+	WHERE
+		lot_id = ANY(:lot_id)`
+
+	var dbLots []dbLot
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbLots); err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+
+	return toCoreLotSlice(dbLots), nil
+}
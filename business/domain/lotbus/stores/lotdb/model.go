@@ -0,0 +1,66 @@
+package lotdb
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/google/uuid"
+)
+
+type dbLot struct {
+	ID              uuid.UUID    `db:"lot_id"`
+	MedicineID      uuid.UUID    `db:"medicine_id"`
+	Number          string       `db:"lot_number"`
+	ExpiryDate      time.Time    `db:"expiry_date"`
+	ManufactureDate sql.NullTime `db:"manufacture_date"`
+	Quarantined     bool         `db:"quarantined"`
+	DateCreated     time.Time    `db:"date_created"`
+	DateUpdated     time.Time    `db:"date_updated"`
+}
+
+func toDBLot(lot lotbus.Lot) dbLot {
+	return dbLot{
+		ID:         lot.ID,
+		MedicineID: lot.MedicineID,
+		Number:     lot.Number,
+		ExpiryDate: lot.ExpiryDate.UTC(),
+		ManufactureDate: sql.NullTime{
+			Time:  lot.ManufactureDate.UTC(),
+			Valid: !lot.ManufactureDate.IsZero(),
+		},
+		Quarantined: lot.Quarantined,
+		DateCreated: lot.DateCreated.UTC(),
+		DateUpdated: lot.DateUpdated.UTC(),
+	}
+}
+
+func toCoreLot(dbLot dbLot) lotbus.Lot {
+	var manufactureDate time.Time
+	if dbLot.ManufactureDate.Valid {
+		manufactureDate = dbLot.ManufactureDate.Time.In(time.Local)
+	}
+
+	lot := lotbus.Lot{
+		ID:              dbLot.ID,
+		MedicineID:      dbLot.MedicineID,
+		Number:          dbLot.Number,
+		ExpiryDate:      dbLot.ExpiryDate.In(time.Local),
+		ManufactureDate: manufactureDate,
+		Quarantined:     dbLot.Quarantined,
+		DateCreated:     dbLot.DateCreated.In(time.Local),
+		DateUpdated:     dbLot.DateUpdated.In(time.Local),
+	}
+
+	return lot
+}
+
+func toCoreLotSlice(dbLots []dbLot) []lotbus.Lot {
+	lots := make([]lotbus.Lot, len(dbLots))
+
+	for i, dbLot := range dbLots {
+		lots[i] = toCoreLot(dbLot)
+	}
+
+	return lots
+}
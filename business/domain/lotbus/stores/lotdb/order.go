@@ -0,0 +1,25 @@
+package lotdb
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+)
+
+var orderByFields = map[string]string{
+	lotbus.OrderByID:              "lot_id",
+	lotbus.OrderByMedicineID:      "medicine_id",
+	lotbus.OrderByNumber:          "lot_number",
+	lotbus.OrderByExpiryDate:      "expiry_date",
+	lotbus.OrderByManufactureDate: "manufacture_date",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
@@ -0,0 +1,48 @@
+package lotbus
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TestGenerateNewLots is a helper method for testing.
+func TestGenerateNewLots(n int, medicineID uuid.UUID) []NewLot {
+	newLots := make([]NewLot, n)
+
+	idx := rand.Intn(10000)
+	for i := 0; i < n; i++ {
+		idx++
+
+		nl := NewLot{
+			MedicineID:      medicineID,
+			Number:          fmt.Sprintf("LOT%d", idx),
+			ExpiryDate:      time.Now().AddDate(1, i, 0),
+			ManufactureDate: time.Now().AddDate(-1, 0, 0),
+		}
+
+		newLots[i] = nl
+	}
+
+	return newLots
+}
+
+// TestGenerateSeedLots is a helper method for testing.
+func TestGenerateSeedLots(ctx context.Context, n int, api *Core, medicineID uuid.UUID) ([]Lot, error) {
+	newLots := TestGenerateNewLots(n, medicineID)
+
+	lots := make([]Lot, len(newLots))
+	for i, nl := range newLots {
+		lot, err := api.Create(ctx, nl)
+		if err != nil {
+			return nil, fmt.Errorf("seeding lot: idx: %d : %w", i, err)
+		}
+
+		lots[i] = lot
+	}
+
+	return lots, nil
+}
@@ -18,6 +18,8 @@ type QueryFilter struct {
 	Description 		*string
 	Manufacturer		*string
 	Type 				*string
+	GTIN				*string
+	Schedule			*Schedule
 	Tag					*uuid.UUID
 	Tags 				[]uuid.UUID
 	StartExpiryDate		*time.Time
@@ -58,6 +60,16 @@ func (qf *QueryFilter) WithType(mtype string) {
 	qf.Type = &mtype
 }
 
+// WithGTIN sets the GTIN field of the QueryFilter value.
+func (qf *QueryFilter) WithGTIN(gtin string) {
+	qf.GTIN = &gtin
+}
+
+// WithSchedule sets the Schedule field of the QueryFilter value.
+func (qf *QueryFilter) WithSchedule(schedule Schedule) {
+	qf.Schedule = &schedule
+}
+
 // WithTag sets the Tag field of the QueryFilter value.
 func (qf *QueryFilter) WithTag(tagID uuid.UUID) {
 	qf.Tag = &tagID
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/EnesDemirtas/medisync/business/api/gs1"
 	"github.com/EnesDemirtas/medisync/business/api/order"
 	"github.com/EnesDemirtas/medisync/business/data/transaction"
 	"github.com/EnesDemirtas/medisync/business/domain/tagbus"
@@ -19,8 +20,11 @@ import (
 
 // Set of error variables for CRUD operations.
 var	(
-	ErrNotFound = errors.New("medicine not found")
-	ErrUniquePK = errors.New("medicine already exists")
+	ErrNotFound 	= errors.New("medicine not found")
+	ErrUniquePK 	= errors.New("medicine already exists")
+	ErrUniqueGTIN	= errors.New("gtin already in use")
+	ErrInvalidGTIN	= errors.New("invalid gtin")
+	ErrVersionConflict = errors.New("medicine was modified by another request")
 )
 
 // Storer interface ddeclares the behavior this package needs to persist and
@@ -34,6 +38,7 @@ type Storer interface {
 	Count(ctx context.Context, filter QueryFilter) (int, error)
 	QueryByID(ctx context.Context, medicineID uuid.UUID) (Medicine, error)
 	QueryByIDs(ctx context.Context, medicineIDs []uuid.UUID) ([]Medicine, error)
+	QueryByGTIN(ctx context.Context, gtin string) (Medicine, error)
 }
 
 // Core manages the set of APIs for medicine access.
@@ -84,6 +89,21 @@ func (c *Core) Create(ctx context.Context, newMed NewMedicine) (Medicine, error)
 		return Medicine{}, fmt.Errorf("tag.querybyids: %s: %w", newMed.Tags, err)
 	}
 
+	gtin, err := normalizeGTIN(newMed.GTIN)
+	if err != nil {
+		return Medicine{}, err
+	}
+
+	schedule := newMed.Schedule
+	if schedule == (Schedule{}) {
+		schedule = ScheduleNone
+	}
+
+	storage := newMed.Storage
+	if storage.Temperature == (Temperature{}) {
+		storage.Temperature = TemperatureAny
+	}
+
 	now := time.Now()
 
 	med := Medicine{
@@ -92,8 +112,11 @@ func (c *Core) Create(ctx context.Context, newMed NewMedicine) (Medicine, error)
 		Description: 	newMed.Description,
 		Manufacturer: 	newMed.Manufacturer,
 		Type:			newMed.Type,
+		GTIN:			gtin,
+		Schedule:		schedule,
+		Storage:		storage,
 		Tags:			newMed.Tags,
-		ExpiryDate: 	newMed.ExpiryDate,
+		Version:		1,
 		DateCreated: 	now,
 		DateUpdated: 	now,
 	}
@@ -123,6 +146,26 @@ func (c *Core) Update(ctx context.Context, med Medicine, updatedMed UpdateMedici
 		med.Type = *updatedMed.Type
 	}
 
+	if updatedMed.GTIN != nil {
+		gtin, err := normalizeGTIN(*updatedMed.GTIN)
+		if err != nil {
+			return Medicine{}, err
+		}
+
+		med.GTIN = gtin
+	}
+
+	if updatedMed.Schedule != nil {
+		med.Schedule = *updatedMed.Schedule
+	}
+
+	if updatedMed.Storage != nil {
+		med.Storage = *updatedMed.Storage
+		if med.Storage.Temperature == (Temperature{}) {
+			med.Storage.Temperature = TemperatureAny
+		}
+	}
+
 	if updatedMed.Tags != nil {
 		_, err := c.tagCore.QueryByIDs(ctx, updatedMed.Tags)
 		if err != nil {
@@ -132,10 +175,7 @@ func (c *Core) Update(ctx context.Context, med Medicine, updatedMed UpdateMedici
 		med.Tags = updatedMed.Tags
 	}
 
-	if updatedMed.ExpiryDate != nil {
-		med.ExpiryDate = *updatedMed.ExpiryDate
-	}
-
+	med.Version++
 	med.DateUpdated = time.Now()
 
 	if err := c.storer.Update(ctx, med); err != nil {
@@ -201,4 +241,35 @@ func (c *Core) QueryByIDs(ctx context.Context, medicineIDs []uuid.UUID) ([]Medic
 	}
 
 	return medicines, nil
-}
\ No newline at end of file
+}
+// QueryByGTIN finds the medicine carrying the specified GTIN.
+func (c *Core) QueryByGTIN(ctx context.Context, gtin string) (Medicine, error) {
+	gtin, err := normalizeGTIN(gtin)
+	if err != nil {
+		return Medicine{}, err
+	}
+
+	medicine, err := c.storer.QueryByGTIN(ctx, gtin)
+	if err != nil {
+		return Medicine{}, fmt.Errorf("query: gtin[%s]: %w", gtin, err)
+	}
+
+	return medicine, nil
+}
+
+// =============================================================================
+
+// normalizeGTIN validates the check digit of the GTIN and returns it in the
+// 14 digit form it is stored in. An empty GTIN is left empty.
+func normalizeGTIN(gtin string) (string, error) {
+	if gtin == "" {
+		return "", nil
+	}
+
+	normalized, err := gs1.NormalizeGTIN(gtin)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidGTIN, err)
+	}
+
+	return normalized, nil
+}
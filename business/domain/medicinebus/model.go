@@ -6,29 +6,38 @@ import (
 	"github.com/google/uuid"
 )
 
-// TODO: Add barcode.
-
-// Medicine represents information about a single medicine.
+// Medicine represents information about a single medicine. Expiry dates are
+// tracked per lot in the lotbus package. Schedule classifies controlled
+// substances, every stock movement of one has to be countersigned. Storage
+// holds the conditions the medicine has to be kept under. Version is
+// incremented by every update.
 type Medicine struct {
 	ID 				uuid.UUID
 	Name 			string
 	Description 	string
 	Manufacturer	string
 	Type 			string
+	GTIN			string
+	Schedule		Schedule
+	Storage			Storage
 	Tags 			[]uuid.UUID
-	ExpiryDate		time.Time
+	Version			int
 	DateCreated		time.Time
 	DateUpdated		time.Time
 }
 
-// NewMedicine contains information needed to create a new medicine.
+// NewMedicine contains information needed to create a new medicine. GTIN
+// is optional and may be given in any of the GTIN-8/12/13/14 forms. Schedule
+// defaults to none and the storage temperature to any.
 type NewMedicine struct {
 	Name 			string
 	Description		string
 	Manufacturer	string
 	Type 			string
+	GTIN			string
+	Schedule		Schedule
+	Storage			Storage
 	Tags			[]uuid.UUID
-	ExpiryDate		time.Time
 }
 
 // UpdateMedicine contains information needed to update a medicine.
@@ -37,6 +46,8 @@ type UpdateMedicine struct {
 	Description		*string
 	Manufacturer	*string
 	Type 			*string
+	GTIN			*string
+	Schedule		*Schedule
+	Storage			*Storage
 	Tags			[]uuid.UUID
-	ExpiryDate		*time.Time
 }
\ No newline at end of file
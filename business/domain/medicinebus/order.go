@@ -5,7 +5,8 @@ import "github.com/EnesDemirtas/medisync/business/api/order"
 // DefaultOrderBy represents the default way we sort.
 var DefaultOrderBy = order.NewBy(OrderByID, order.ASC)
 
-// Set of fields that the results can be ordered by.
+// Set of fields that the results can be ordered by. Ordering by expiry date
+// uses the earliest expiry among the lots of each medicine.
 const (
 	OrderByID			= "medicine_id"
 	OrderByName			= "name"
@@ -0,0 +1,82 @@
+package medicinebus
+
+import "fmt"
+
+// Set of possible controlled substance schedules of a medicine.
+var (
+	ScheduleNone = Schedule{"NONE"}
+	ScheduleI    = Schedule{"SCHEDULE_I"}
+	ScheduleII   = Schedule{"SCHEDULE_II"}
+	ScheduleIII  = Schedule{"SCHEDULE_III"}
+	ScheduleIV   = Schedule{"SCHEDULE_IV"}
+	ScheduleV    = Schedule{"SCHEDULE_V"}
+)
+
+// Set of known schedules.
+var schedules = map[string]Schedule{
+	ScheduleNone.name: ScheduleNone,
+	ScheduleI.name:    ScheduleI,
+	ScheduleII.name:   ScheduleII,
+	ScheduleIII.name:  ScheduleIII,
+	ScheduleIV.name:   ScheduleIV,
+	ScheduleV.name:    ScheduleV,
+}
+
+// Schedule represents the controlled substance schedule a medicine is
+// classified under.
+type Schedule struct {
+	name string
+}
+
+// ParseSchedule parses the string value and returns a schedule if one exists.
+func ParseSchedule(value string) (Schedule, error) {
+	schedule, exists := schedules[value]
+	if !exists {
+		return Schedule{}, fmt.Errorf("invalid schedule %q", value)
+	}
+
+	return schedule, nil
+}
+
+// MustParseSchedule parses the string value and returns a schedule if one
+// exists. If an error occurs the function panics.
+func MustParseSchedule(value string) Schedule {
+	schedule, err := ParseSchedule(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return schedule
+}
+
+// Name returns the name of the schedule.
+func (s Schedule) Name() string {
+	return s.name
+}
+
+// Controlled reports whether the schedule makes the medicine a controlled
+// substance.
+func (s Schedule) Controlled() bool {
+	return s.name != "" && s != ScheduleNone
+}
+
+// UnmarshalText implement the unmarshal interface for JSON conversions.
+func (s *Schedule) UnmarshalText(data []byte) error {
+	schedule, err := ParseSchedule(string(data))
+	if err != nil {
+		return err
+	}
+
+	s.name = schedule.name
+	return nil
+}
+
+// MarshalText implement the marshal interface for JSON conversions.
+func (s Schedule) MarshalText() ([]byte, error) {
+	return []byte(s.name), nil
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (s Schedule) Equal(s2 Schedule) bool {
+	return s.name == s2.name
+}
@@ -0,0 +1,81 @@
+package medicinebus
+
+import "fmt"
+
+// Set of possible temperature ranges a medicine has to be stored in.
+var (
+	TemperatureAny          = Temperature{"ANY"}
+	TemperatureBelow25      = Temperature{"BELOW_25C"}
+	TemperatureRefrigerated = Temperature{"REFRIGERATED_2_8C"}
+	TemperatureFrozen       = Temperature{"FROZEN"}
+)
+
+// Set of known temperature ranges.
+var temperatures = map[string]Temperature{
+	TemperatureAny.name:          TemperatureAny,
+	TemperatureBelow25.name:      TemperatureBelow25,
+	TemperatureRefrigerated.name: TemperatureRefrigerated,
+	TemperatureFrozen.name:       TemperatureFrozen,
+}
+
+// Temperature represents the range of temperatures a medicine has to be
+// stored in.
+type Temperature struct {
+	name string
+}
+
+// ParseTemperature parses the string value and returns a temperature range if
+// one exists.
+func ParseTemperature(value string) (Temperature, error) {
+	temperature, exists := temperatures[value]
+	if !exists {
+		return Temperature{}, fmt.Errorf("invalid temperature %q", value)
+	}
+
+	return temperature, nil
+}
+
+// MustParseTemperature parses the string value and returns a temperature
+// range if one exists. If an error occurs the function panics.
+func MustParseTemperature(value string) Temperature {
+	temperature, err := ParseTemperature(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return temperature
+}
+
+// Name returns the name of the temperature range.
+func (t Temperature) Name() string {
+	return t.name
+}
+
+// UnmarshalText implement the unmarshal interface for JSON conversions.
+func (t *Temperature) UnmarshalText(data []byte) error {
+	temperature, err := ParseTemperature(string(data))
+	if err != nil {
+		return err
+	}
+
+	t.name = temperature.name
+	return nil
+}
+
+// MarshalText implement the marshal interface for JSON conversions.
+func (t Temperature) MarshalText() ([]byte, error) {
+	return []byte(t.name), nil
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (t Temperature) Equal(t2 Temperature) bool {
+	return t.name == t2.name
+}
+
+// =============================================================================
+
+// Storage represents the conditions a medicine has to be stored under.
+type Storage struct {
+	Temperature      Temperature
+	ProtectFromLight bool
+}
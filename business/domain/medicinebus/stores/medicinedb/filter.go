@@ -5,7 +5,8 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/EnesDemirtas/medisync/business/core/crud/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb/dbarray"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
 )
 
 func applyFilter(filter medicinebus.QueryFilter, data map[string]interface{}, buf *bytes.Buffer) {
@@ -32,20 +33,52 @@ func applyFilter(filter medicinebus.QueryFilter, data map[string]interface{}, bu
 	}
 
 	if filter.Type != nil {
-		data["type"] = fmt.Sprintf("%%%s%%", *filter.Tag)
+		data["type"] = fmt.Sprintf("%%%s%%", *filter.Type)
 		wc = append(wc, "type LIKE :type")
 	}
 
+	if filter.GTIN != nil {
+		data["gtin"] = *filter.GTIN
+		wc = append(wc, "gtin = :gtin")
+	}
+
+	if filter.Schedule != nil {
+		data["schedule"] = filter.Schedule.Name()
+		wc = append(wc, "schedule = :schedule")
+	}
+
+	if filter.Tag != nil {
+		data["tag_id"] = filter.Tag.String()
+		wc = append(wc, "EXISTS (SELECT 1 FROM medicine_tags mt WHERE mt.medicine_id = medicines.medicine_id AND mt.tag_id = :tag_id)")
+	}
+
+	// A medicine matches the tags filter when it carries any of the tags.
+	if len(filter.Tags) > 0 {
+		ids := make([]string, len(filter.Tags))
+		for i, tagID := range filter.Tags {
+			ids[i] = tagID.String()
+		}
+		data["tag_ids"] = dbarray.Array(ids)
+		wc = append(wc, "EXISTS (SELECT 1 FROM medicine_tags mt WHERE mt.medicine_id = medicines.medicine_id AND mt.tag_id = ANY(:tag_ids))")
+	}
+
+	// Expiry dates belong to lots, so a medicine matches when at least one of
+	// its lots falls inside the requested range.
+	var lwc []string
+
 	if filter.StartExpiryDate != nil {
 		data["start_expiry_date"] = *filter.StartExpiryDate
-		wc = append(wc, "expiry_date >= :start_expiry_date")
+		lwc = append(lwc, "l.expiry_date >= :start_expiry_date")
 	}
 
 	if filter.EndExpiryDate != nil {
 		data["end_expiry_date"] = *filter.EndExpiryDate
-		wc = append(wc, "expiry_date <= :end_expiry_date")
+		lwc = append(lwc, "l.expiry_date <= :end_expiry_date")
 	}
 
+	if len(lwc) > 0 {
+		wc = append(wc, "EXISTS (SELECT 1 FROM lots l WHERE l.medicine_id = medicines.medicine_id AND "+strings.Join(lwc, " AND ")+")")
+	}
 
 	if len(wc) > 0 {
 		buf.WriteString(" WHERE ")
@@ -8,7 +8,7 @@ import (
 	"fmt"
 
 	"github.com/EnesDemirtas/medisync/business/api/order"
-	"github.com/EnesDemirtas/medisync/business/core/crud/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
 	"github.com/EnesDemirtas/medisync/business/data/sqldb"
 	"github.com/EnesDemirtas/medisync/business/data/sqldb/dbarray"
 	"github.com/EnesDemirtas/medisync/business/data/transaction"
@@ -17,6 +17,20 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// medicineTags collects the tags attached to a medicine into a single array
+// so the medicine can be read back in one row.
+const medicineTags = `
+		ARRAY(
+			SELECT
+				mt.tag_id
+			FROM
+				medicine_tags mt
+			WHERE
+				mt.medicine_id = medicines.medicine_id
+			ORDER BY
+				mt.tag_id
+		) AS tags`
+
 // Store manages the set of APIs for medicine database access.
 type Store struct {
 	log *logger.Logger
@@ -47,17 +61,29 @@ func (s *Store) ExecuteUnderTransaction(tx transaction.Transaction) (medicinebus
 	return &store, nil
 }
 
-// Create inserts a new medicine into the database.
+// Create inserts a new medicine and attaches its tags in a single statement.
 func (s *Store) Create(ctx context.Context, med medicinebus.Medicine) error {
 	const q = `
-	INSERT INTO medicines
-		(medicine_id, name, description, manufacturer, type, tags, expiry_date, date_created, date_updated)
-	VALUES
-		(:medicine_id, :name, :description, :manufacturer, :type, :tags, :expiry_date, :date_created, :date_updated)`
+	WITH med AS (
+		INSERT INTO medicines
+			(medicine_id, name, description, manufacturer, type, gtin, schedule, storage_temperature, protect_from_light, version, date_created, date_updated)
+		VALUES
+			(:medicine_id, :name, :description, :manufacturer, :type, :gtin, :schedule, :storage_temperature, :protect_from_light, :version, :date_created, :date_updated)
+		RETURNING
+			medicine_id
+	)
+	INSERT INTO medicine_tags
+		(medicine_id, tag_id)
+	SELECT DISTINCT
+		med.medicine_id, t.tag_id
+	FROM
+		med
+	CROSS JOIN
+		unnest(CAST(:tags AS UUID[])) t(tag_id)`
 
 	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBMedicine(med)); err != nil {
 		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
-			return fmt.Errorf("namedexeccontext: %w", medicinebus.ErrUniquePK)
+			return fmt.Errorf("namedexeccontext: %w", medicinebus.ErrUniqueGTIN)
 		}
 		return fmt.Errorf("namedexeccontext: %w", err)
 	}
@@ -65,26 +91,63 @@ func (s *Store) Create(ctx context.Context, med medicinebus.Medicine) error {
 	return nil
 }
 
-// Update replaces a medicine document in the dabase.
+// Update replaces a medicine document in the dabase. Tags that are no longer
+// listed are detached and new ones attached in the same statement, which only
+// touches them when the version check passes.
 func (s *Store) Update(ctx context.Context, med medicinebus.Medicine) error {
 	const q = `
-	UPDATE
-		medicines
-	SET
-		"name" = :name,
-		"description" = :description,
-		"manufacturer" = :manufacturer,
-		"type" = :type,
-		"tags" = :tags,
-		"expiry_date" = :expiry_date
-	WHERE
-		medicine_id = :medicine_id`
+	WITH med AS (
+		UPDATE
+			medicines
+		SET
+			"name" = :name,
+			"description" = :description,
+			"manufacturer" = :manufacturer,
+			"type" = :type,
+			"gtin" = :gtin,
+			"schedule" = :schedule,
+			"storage_temperature" = :storage_temperature,
+			"protect_from_light" = :protect_from_light,
+			"version" = :version,
+			"date_updated" = :date_updated
+		WHERE
+			medicine_id = :medicine_id AND
+			version = :version - 1
+		RETURNING
+			medicine_id, version
+	), detached AS (
+		DELETE FROM
+			medicine_tags
+		WHERE
+			medicine_id IN (SELECT medicine_id FROM med) AND
+			NOT (tag_id = ANY(CAST(:tags AS UUID[])))
+	), attached AS (
+		INSERT INTO medicine_tags
+			(medicine_id, tag_id)
+		SELECT DISTINCT
+			med.medicine_id, t.tag_id
+		FROM
+			med
+		CROSS JOIN
+			unnest(CAST(:tags AS UUID[])) t(tag_id)
+		ON CONFLICT DO NOTHING
+	)
+	SELECT
+		version
+	FROM
+		med`
 
-	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBMedicine(med)); err != nil {
+	var result struct {
+		Version int `db:"version"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, toDBMedicine(med), &result); err != nil {
 		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
-			return medicinebus.ErrUniquePK
+			return medicinebus.ErrUniqueGTIN
 		}
-		return fmt.Errorf("namedexeccontext: %w", err)
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return fmt.Errorf("db: %w", medicinebus.ErrVersionConflict)
+		}
+		return fmt.Errorf("db: %w", err)
 	}
 
 	return nil
@@ -120,7 +183,7 @@ func (s *Store) Query(ctx context.Context, filter medicinebus.QueryFilter, order
 
 	const q = `
 	SELECT
-		medicine_id, name, description, manufacturer, type, tags, expiry_date, date_created, date_updated
+		medicine_id, name, description, manufacturer, type, gtin, schedule, storage_temperature, protect_from_light, ` + medicineTags + `, version, date_created, date_updated
 	FROM
 		medicines`
 
@@ -176,7 +239,7 @@ func (s *Store) QueryByID(ctx context.Context, medicineID uuid.UUID) (medicinebu
 
 	const q = `
 	SELECT
-		medicine_id, name, description, manufacturer, type, tags, expiry_date, date_created, date_updated
+		medicine_id, name, description, manufacturer, type, gtin, schedule, storage_temperature, protect_from_light, ` + medicineTags + `, version, date_created, date_updated
 	FROM
 		medicines
 	WHERE
@@ -208,7 +271,7 @@ func (s *Store) QueryByIDs(ctx context.Context, medicineIDs []uuid.UUID) ([]medi
 
 	const q = `
 	SELECT
-		medicine_id, name, description, manufacturer, type, tags, expiry_date, date_created, date_updated
+		medicine_id, name, description, manufacturer, type, gtin, schedule, storage_temperature, protect_from_light, ` + medicineTags + `, version, date_created, date_updated
 	FROM
 		medicines
 	WHERE
@@ -225,6 +288,33 @@ func (s *Store) QueryByIDs(ctx context.Context, medicineIDs []uuid.UUID) ([]medi
 	return toCoreMedicineSlice(dbMedicines), nil
 }
 
+// QueryByGTIN gets the specified medicine from the database by GTIN.
+func (s *Store) QueryByGTIN(ctx context.Context, gtin string) (medicinebus.Medicine, error) {
+	data := struct {
+		GTIN string `db:"gtin"`
+	}{
+		GTIN: gtin,
+	}
+
+	const q = `
+	SELECT
+		medicine_id, name, description, manufacturer, type, gtin, schedule, storage_temperature, protect_from_light, ` + medicineTags + `, version, date_created, date_updated
+	FROM
+		medicines
+	WHERE
+		gtin = :gtin`
+
+	var dbMedicine dbMedicine
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbMedicine); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return medicinebus.Medicine{}, fmt.Errorf("db: %w", medicinebus.ErrNotFound)
+		}
+		return medicinebus.Medicine{}, fmt.Errorf("db: %w", err)
+	}
+
+	return toCoreMedicine(dbMedicine), nil
+}
+
 // QueryByName gets the specified medicine from the database by name.
 func (s *Store) QueryByName(ctx context.Context, name string) (medicinebus.Medicine, error) {
 	data := struct {
@@ -235,7 +325,7 @@ func (s *Store) QueryByName(ctx context.Context, name string) (medicinebus.Medic
 
 	const q = `
 	SELECT
-		medicine_id, name, description, manufacturer, type, tags, expiry_date, date_created, date_updated
+		medicine_id, name, description, manufacturer, type, gtin, schedule, storage_temperature, protect_from_light, ` + medicineTags + `, version, date_created, date_updated
 	FROM
 		medicines
 	WHERE
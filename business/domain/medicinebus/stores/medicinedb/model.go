@@ -4,7 +4,7 @@ import (
 	"database/sql"
 	"time"
 
-	"github.com/EnesDemirtas/medisync/business/core/crud/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
 	"github.com/EnesDemirtas/medisync/business/data/sqldb/dbarray"
 	"github.com/google/uuid"
 )
@@ -15,8 +15,12 @@ type dbMedicine struct {
 	Description  sql.NullString	`db:"description"`
 	Manufacturer sql.NullString	`db:"manufacturer"`
 	Type		 sql.NullString `db:"type"`
+	GTIN		 sql.NullString `db:"gtin"`
+	Schedule	 string			`db:"schedule"`
+	StorageTemperature string	`db:"storage_temperature"`
+	ProtectFromLight   bool		`db:"protect_from_light"`
 	Tags		 dbarray.String	`db:"tags"`
-	ExpiryDate	 time.Time		`db:"expiry_date"`
+	Version		 int			`db:"version"`
 	DateCreated  time.Time		`db:"date_created"`
 	DateUpdated  time.Time		`db:"date_updated"`
 }
@@ -42,8 +46,15 @@ func toDBMedicine(med medicinebus.Medicine) dbMedicine {
 			String:	med.Type,
 			Valid:  med.Type != "",
 		},
+		GTIN: 		  sql.NullString{
+			String: med.GTIN,
+			Valid:  med.GTIN != "",
+		},
+		Schedule:	  med.Schedule.Name(),
+		StorageTemperature: med.Storage.Temperature.Name(),
+		ProtectFromLight:	med.Storage.ProtectFromLight,
 		Tags: 		  tags,
-		ExpiryDate:   med.ExpiryDate,
+		Version:	  med.Version,
 		DateCreated:  med.DateCreated,
 		DateUpdated:  med.DateUpdated,
 	}
@@ -60,8 +71,14 @@ func toCoreMedicine(dbMedicine dbMedicine) medicinebus.Medicine {
 		Description:  dbMedicine.Description.String,
 		Manufacturer: dbMedicine.Manufacturer.String,
 		Type:		  dbMedicine.Type.String,
+		GTIN:		  dbMedicine.GTIN.String,
+		Schedule:	  medicinebus.MustParseSchedule(dbMedicine.Schedule),
+		Storage:	  medicinebus.Storage{
+			Temperature:	  medicinebus.MustParseTemperature(dbMedicine.StorageTemperature),
+			ProtectFromLight: dbMedicine.ProtectFromLight,
+		},
 		Tags:		  tags,
-		ExpiryDate:   dbMedicine.ExpiryDate,
+		Version:	  dbMedicine.Version,
 		DateCreated:  dbMedicine.DateCreated,
 		DateUpdated:  dbMedicine.DateUpdated,
 	}
@@ -4,7 +4,7 @@ import (
 	"fmt"
 
 	"github.com/EnesDemirtas/medisync/business/api/order"
-	"github.com/EnesDemirtas/medisync/business/core/crud/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
 )
 
 var orderByFields = map[string]string{
@@ -13,7 +13,7 @@ var orderByFields = map[string]string{
 	medicinebus.OrderByDescription:	"description",
 	medicinebus.OrderByManufacturer:	"manufacturer",
 	medicinebus.OrderByType:			"type",
-	medicinebus.OrderByExpiryDate:		"expiry_date",
+	medicinebus.OrderByExpiryDate:		"(SELECT MIN(l.expiry_date) FROM lots l WHERE l.medicine_id = medicines.medicine_id)",
 }
 
 func orderByClause(orderBy order.By) (string, error) {
@@ -0,0 +1,45 @@
+package medicinebus
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+)
+
+// TestGenerateNewMedicines is a helper method for testing.
+func TestGenerateNewMedicines(n int) []NewMedicine {
+	newMeds := make([]NewMedicine, n)
+
+	idx := rand.Intn(10000)
+	for i := 0; i < n; i++ {
+		idx++
+
+		nm := NewMedicine{
+			Name:         fmt.Sprintf("Name%d", idx),
+			Description:  fmt.Sprintf("Description%d", idx),
+			Manufacturer: fmt.Sprintf("Manufacturer%d", idx),
+			Type:         fmt.Sprintf("Type%d", idx),
+		}
+
+		newMeds[i] = nm
+	}
+
+	return newMeds
+}
+
+// TestGenerateSeedMedicines is a helper method for testing.
+func TestGenerateSeedMedicines(ctx context.Context, n int, api *Core) ([]Medicine, error) {
+	newMeds := TestGenerateNewMedicines(n)
+
+	meds := make([]Medicine, len(newMeds))
+	for i, nm := range newMeds {
+		med, err := api.Create(ctx, nm)
+		if err != nil {
+			return nil, fmt.Errorf("seeding medicine: idx: %d : %w", i, err)
+		}
+
+		meds[i] = med
+	}
+
+	return meds, nil
+}
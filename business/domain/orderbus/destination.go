@@ -0,0 +1,71 @@
+package orderbus
+
+import "fmt"
+
+// Set of possible destination types for an order.
+var (
+	DestinationWard    = DestinationType{"WARD"}
+	DestinationClinic  = DestinationType{"CLINIC"}
+	DestinationPatient = DestinationType{"PATIENT"}
+)
+
+// Set of known destination types.
+var destinationTypes = map[string]DestinationType{
+	DestinationWard.name:    DestinationWard,
+	DestinationClinic.name:  DestinationClinic,
+	DestinationPatient.name: DestinationPatient,
+}
+
+// DestinationType represents the kind of place stock on an order leaves the
+// warehouse for.
+type DestinationType struct {
+	name string
+}
+
+// ParseDestinationType parses the string value and returns a destination
+// type if one exists.
+func ParseDestinationType(value string) (DestinationType, error) {
+	dt, exists := destinationTypes[value]
+	if !exists {
+		return DestinationType{}, fmt.Errorf("invalid destination type %q", value)
+	}
+
+	return dt, nil
+}
+
+// MustParseDestinationType parses the string value and returns a destination
+// type if one exists. If an error occurs the function panics.
+func MustParseDestinationType(value string) DestinationType {
+	dt, err := ParseDestinationType(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return dt
+}
+
+// Name returns the name of the destination type.
+func (dt DestinationType) Name() string {
+	return dt.name
+}
+
+// UnmarshalText implement the unmarshal interface for JSON conversions.
+func (dt *DestinationType) UnmarshalText(data []byte) error {
+	d, err := ParseDestinationType(string(data))
+	if err != nil {
+		return err
+	}
+
+	dt.name = d.name
+	return nil
+}
+
+// MarshalText implement the marshal interface for JSON conversions.
+func (dt DestinationType) MarshalText() ([]byte, error) {
+	return []byte(dt.name), nil
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (dt DestinationType) Equal(dt2 DestinationType) bool {
+	return dt.name == dt2.name
+}
@@ -0,0 +1,46 @@
+package orderbus
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryFilter holds the available fields a query can be filtered on.
+// We are using pointer semantics because the With API mutates the value.
+type QueryFilter struct {
+	ID              *uuid.UUID
+	InventoryID     *uuid.UUID
+	Status          *Status
+	DestinationType *DestinationType
+}
+
+// Validate can perform a check of the data against the validate tags.
+func (qf *QueryFilter) Validate() error {
+	if err := validate.Check(qf); err != nil {
+		return fmt.Errorf("validate: %w", err)
+	}
+
+	return nil
+}
+
+// WithOrderID sets the ID field of the QueryFilter value.
+func (qf *QueryFilter) WithOrderID(orderID uuid.UUID) {
+	qf.ID = &orderID
+}
+
+// WithInventoryID sets the InventoryID field of the QueryFilter value.
+func (qf *QueryFilter) WithInventoryID(inventoryID uuid.UUID) {
+	qf.InventoryID = &inventoryID
+}
+
+// WithStatus sets the Status field of the QueryFilter value.
+func (qf *QueryFilter) WithStatus(status Status) {
+	qf.Status = &status
+}
+
+// WithDestinationType sets the DestinationType field of the QueryFilter value.
+func (qf *QueryFilter) WithDestinationType(dt DestinationType) {
+	qf.DestinationType = &dt
+}
@@ -0,0 +1,99 @@
+package orderbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Destination represents where the stock on an order is sent. Name
+// identifies the ward, clinic or patient within its type.
+type Destination struct {
+	Type DestinationType
+	Name string
+}
+
+func (d Destination) valid() bool {
+	return d.Type != DestinationType{} && d.Name != ""
+}
+
+// Order represents a request for stock to leave an inventory for a
+// destination. Stock is only taken out of the inventory once the order is
+// shipped.
+type Order struct {
+	ID           uuid.UUID
+	InventoryID  uuid.UUID
+	Destination  Destination
+	Status       Status
+	Note         string
+	Lines        []Line
+	CreatedBy    uuid.UUID
+	ApprovedBy   uuid.UUID
+	DateApproved time.Time
+	PickedBy     uuid.UUID
+	DatePicked   time.Time
+	ShippedBy    uuid.UUID
+	DateShipped  time.Time
+	DateCreated  time.Time
+	DateUpdated  time.Time
+}
+
+// Line represents the quantity of a medicine requested on an order and the
+// lots it was picked from.
+type Line struct {
+	MedicineID uuid.UUID
+	Quantity   int
+	Picks      []Pick
+}
+
+// Picked returns the quantity picked for the line.
+func (l Line) Picked() int {
+	var total int
+	for _, pick := range l.Picks {
+		total += pick.Quantity
+	}
+
+	return total
+}
+
+// Pick represents the quantity of a lot picked for a line.
+type Pick struct {
+	LotID    uuid.UUID
+	Quantity int
+}
+
+// NewOrder contains information needed to create a new order.
+type NewOrder struct {
+	InventoryID uuid.UUID
+	Destination Destination
+	Note        string
+	Lines       []NewLine
+	UserID      uuid.UUID
+}
+
+// NewLine contains information needed to add a medicine to an order.
+type NewLine struct {
+	MedicineID uuid.UUID
+	Quantity   int
+}
+
+// UpdateOrder contains information needed to update a draft order. Lines,
+// when set, replace the lines of the order.
+type UpdateOrder struct {
+	Destination *Destination
+	Note        *string
+	Lines       []NewLine
+}
+
+// Picking contains the lots picked for an order. When no lines are given
+// the lots are allocated First-Expired-First-Out.
+type Picking struct {
+	Lines  []PickLine
+	UserID uuid.UUID
+}
+
+// PickLine contains the quantity picked from a lot.
+type PickLine struct {
+	LotID    uuid.UUID
+	Quantity int
+}
@@ -0,0 +1,14 @@
+package orderbus
+
+import "github.com/EnesDemirtas/medisync/business/api/order"
+
+// DefaultOrderBy represents the default way we sort.
+var DefaultOrderBy = order.NewBy(OrderByDateCreated, order.ASC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID          = "order_id"
+	OrderByStatus      = "status"
+	OrderByDateCreated = "date_created"
+	OrderByDateShipped = "date_shipped"
+)
@@ -0,0 +1,467 @@
+// Package orderbus provides the business API for stock leaving the warehouse
+// for a ward, clinic or patient. An order is drafted, approved, picked and
+// finally shipped, which is when its stock is taken out of the inventory
+// through the stock ledger. An order can be cancelled until it is shipped.
+package orderbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/allocationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound           = errors.New("order not found")
+	ErrNoLines            = errors.New("order has no lines")
+	ErrDuplicateLine      = errors.New("medicine listed more than once")
+	ErrInvalidQuantity    = errors.New("invalid order quantity")
+	ErrDestinationMissing = errors.New("order destination type and name required")
+	ErrInvalidTransition  = errors.New("order can't move to the requested status")
+	ErrUnknownMedicine    = errors.New("lot medicine is not on the order")
+	ErrDuplicatePick      = errors.New("lot picked more than once")
+	ErrPickMismatch       = errors.New("picked quantity does not match the order line")
+	ErrShortPick          = errors.New("not enough stock to pick the order line")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	ExecuteUnderTransaction(tx transaction.Transaction) (Storer, error)
+	Create(ctx context.Context, ord Order) error
+	Update(ctx context.Context, ord Order) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Order, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, orderID uuid.UUID) (Order, error)
+	QueryByIDForUpdate(ctx context.Context, orderID uuid.UUID) (Order, error)
+}
+
+// Core manages the set of APIs for order access.
+type Core struct {
+	log            *logger.Logger
+	medicineCore   *medicinebus.Core
+	lotCore        *lotbus.Core
+	stockCore      *stockbus.Core
+	allocationCore *allocationbus.Core
+	delegate       *delegate.Delegate
+	storer         Storer
+}
+
+// NewCore constructs an order core API for use.
+func NewCore(log *logger.Logger, medicineCore *medicinebus.Core, lotCore *lotbus.Core, stockCore *stockbus.Core, allocationCore *allocationbus.Core, delegate *delegate.Delegate, storer Storer) *Core {
+	return &Core{
+		log:            log,
+		medicineCore:   medicineCore,
+		lotCore:        lotCore,
+		stockCore:      stockCore,
+		allocationCore: allocationCore,
+		delegate:       delegate,
+		storer:         storer,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Core value that will use the
+// specified transaction in any store related calls.
+func (c *Core) ExecuteUnderTransaction(tx transaction.Transaction) (*Core, error) {
+	storer, err := c.storer.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	medicineCore, err := c.medicineCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	lotCore, err := c.lotCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	stockCore, err := c.stockCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	allocationCore, err := c.allocationCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	core := Core{
+		log:            c.log,
+		medicineCore:   medicineCore,
+		lotCore:        lotCore,
+		stockCore:      stockCore,
+		allocationCore: allocationCore,
+		delegate:       c.delegate,
+		storer:         storer,
+	}
+
+	return &core, nil
+}
+
+// Create adds a new draft order to the system.
+func (c *Core) Create(ctx context.Context, no NewOrder) (Order, error) {
+	if !no.Destination.valid() {
+		return Order{}, ErrDestinationMissing
+	}
+
+	lines, err := c.newLines(ctx, no.Lines)
+	if err != nil {
+		return Order{}, err
+	}
+
+	now := time.Now()
+
+	ord := Order{
+		ID:          uuid.New(),
+		InventoryID: no.InventoryID,
+		Destination: no.Destination,
+		Status:      StatusDraft,
+		Note:        no.Note,
+		Lines:       lines,
+		CreatedBy:   no.UserID,
+		DateCreated: now,
+		DateUpdated: now,
+	}
+
+	if err := c.storer.Create(ctx, ord); err != nil {
+		return Order{}, fmt.Errorf("create: %w", err)
+	}
+
+	return ord, nil
+}
+
+// Update modifies information about a draft order.
+func (c *Core) Update(ctx context.Context, orderID uuid.UUID, uo UpdateOrder) (Order, error) {
+	ord, err := c.storer.QueryByIDForUpdate(ctx, orderID)
+	if err != nil {
+		return Order{}, fmt.Errorf("query: orderID[%s]: %w", orderID, err)
+	}
+
+	if ord.Status != StatusDraft {
+		return Order{}, fmt.Errorf("%w: update %s order", ErrInvalidTransition, ord.Status.Name())
+	}
+
+	if uo.Destination != nil {
+		if !uo.Destination.valid() {
+			return Order{}, ErrDestinationMissing
+		}
+		ord.Destination = *uo.Destination
+	}
+
+	if uo.Note != nil {
+		ord.Note = *uo.Note
+	}
+
+	if uo.Lines != nil {
+		if ord.Lines, err = c.newLines(ctx, uo.Lines); err != nil {
+			return Order{}, err
+		}
+	}
+
+	ord.DateUpdated = time.Now()
+
+	if err := c.storer.Update(ctx, ord); err != nil {
+		return Order{}, fmt.Errorf("update: %w", err)
+	}
+
+	return ord, nil
+}
+
+// Approve releases a draft order for picking.
+func (c *Core) Approve(ctx context.Context, orderID uuid.UUID, userID uuid.UUID) (Order, error) {
+	ord, err := c.storer.QueryByIDForUpdate(ctx, orderID)
+	if err != nil {
+		return Order{}, fmt.Errorf("query: orderID[%s]: %w", orderID, err)
+	}
+
+	if ord.Status != StatusDraft {
+		return Order{}, fmt.Errorf("%w: approve %s order", ErrInvalidTransition, ord.Status.Name())
+	}
+
+	now := time.Now()
+
+	ord.Status = StatusApproved
+	ord.ApprovedBy = userID
+	ord.DateApproved = now
+	ord.DateUpdated = now
+
+	if err := c.storer.Update(ctx, ord); err != nil {
+		return Order{}, fmt.Errorf("update: %w", err)
+	}
+
+	return ord, nil
+}
+
+// Pick records the lots an approved order is drawn from. The picked lots are
+// checked against the lines of the order, when none are given they are
+// allocated First-Expired-First-Out from the inventory of the order. Picking
+// doesn't hold the stock, it is taken out when the order is shipped.
+func (c *Core) Pick(ctx context.Context, orderID uuid.UUID, pk Picking) (Order, error) {
+	ord, err := c.storer.QueryByIDForUpdate(ctx, orderID)
+	if err != nil {
+		return Order{}, fmt.Errorf("query: orderID[%s]: %w", orderID, err)
+	}
+
+	if ord.Status != StatusApproved {
+		return Order{}, fmt.Errorf("%w: pick %s order", ErrInvalidTransition, ord.Status.Name())
+	}
+
+	switch len(pk.Lines) {
+	case 0:
+		err = c.allocate(ctx, &ord)
+	default:
+		err = c.pick(ctx, &ord, pk.Lines)
+	}
+	if err != nil {
+		return Order{}, err
+	}
+
+	now := time.Now()
+
+	ord.Status = StatusPicked
+	ord.PickedBy = pk.UserID
+	ord.DatePicked = now
+	ord.DateUpdated = now
+
+	if err := c.storer.Update(ctx, ord); err != nil {
+		return Order{}, fmt.Errorf("update: %w", err)
+	}
+
+	return ord, nil
+}
+
+// Ship dispenses the picked lots of the order out of its inventory.
+// countersignedBy is the second user signing off the shipment, it is needed
+// when the order holds a scheduled medicine. The caller is expected to run
+// this under a transaction so a failure on any lot leaves the inventory
+// untouched.
+func (c *Core) Ship(ctx context.Context, orderID uuid.UUID, userID uuid.UUID, countersignedBy uuid.UUID) (Order, error) {
+	ord, err := c.storer.QueryByIDForUpdate(ctx, orderID)
+	if err != nil {
+		return Order{}, fmt.Errorf("query: orderID[%s]: %w", orderID, err)
+	}
+
+	if ord.Status != StatusPicked {
+		return Order{}, fmt.Errorf("%w: ship %s order", ErrInvalidTransition, ord.Status.Name())
+	}
+
+	for _, line := range ord.Lines {
+		for _, pick := range line.Picks {
+			nm := stockbus.NewMovement{
+				InventoryID:     ord.InventoryID,
+				LotID:           pick.LotID,
+				Type:            stockbus.TypeDispense,
+				Quantity:        pick.Quantity,
+				Reason:          reference(ord),
+				Reference:       stockbus.Reference{Type: stockbus.ReferenceOrder, ID: ord.ID},
+				UserID:          userID,
+				CountersignedBy: countersignedBy,
+			}
+
+			if _, err := c.stockCore.Create(ctx, nm); err != nil {
+				return Order{}, fmt.Errorf("stock.create: lot[%s]: %w", pick.LotID, err)
+			}
+		}
+	}
+
+	now := time.Now()
+
+	ord.Status = StatusShipped
+	ord.ShippedBy = userID
+	ord.DateShipped = now
+	ord.DateUpdated = now
+
+	if err := c.storer.Update(ctx, ord); err != nil {
+		return Order{}, fmt.Errorf("update: %w", err)
+	}
+
+	return ord, nil
+}
+
+// Cancel stops an order that hasn't been shipped yet.
+func (c *Core) Cancel(ctx context.Context, orderID uuid.UUID) (Order, error) {
+	ord, err := c.storer.QueryByIDForUpdate(ctx, orderID)
+	if err != nil {
+		return Order{}, fmt.Errorf("query: orderID[%s]: %w", orderID, err)
+	}
+
+	if ord.Status == StatusShipped || ord.Status == StatusCancelled {
+		return Order{}, fmt.Errorf("%w: cancel %s order", ErrInvalidTransition, ord.Status.Name())
+	}
+
+	ord.Status = StatusCancelled
+	ord.DateUpdated = time.Now()
+
+	if err := c.storer.Update(ctx, ord); err != nil {
+		return Order{}, fmt.Errorf("update: %w", err)
+	}
+
+	return ord, nil
+}
+
+// Query retrieves a list of existing orders.
+func (c *Core) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Order, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	ords, err := c.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return ords, nil
+}
+
+// Count returns the total number of orders.
+func (c *Core) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	return c.storer.Count(ctx, filter)
+}
+
+// QueryByID finds the order by the specified ID.
+func (c *Core) QueryByID(ctx context.Context, orderID uuid.UUID) (Order, error) {
+	ord, err := c.storer.QueryByID(ctx, orderID)
+	if err != nil {
+		return Order{}, fmt.Errorf("query: orderID[%s]: %w", orderID, err)
+	}
+
+	return ord, nil
+}
+
+// =============================================================================
+
+// newLines validates the requested lines of an order.
+func (c *Core) newLines(ctx context.Context, nls []NewLine) ([]Line, error) {
+	if len(nls) == 0 {
+		return nil, ErrNoLines
+	}
+
+	lines := make([]Line, 0, len(nls))
+	seen := make(map[uuid.UUID]bool, len(nls))
+	for _, nl := range nls {
+		if seen[nl.MedicineID] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateLine, nl.MedicineID)
+		}
+		seen[nl.MedicineID] = true
+
+		if nl.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: medicine[%s] quantity[%d]", ErrInvalidQuantity, nl.MedicineID, nl.Quantity)
+		}
+
+		if _, err := c.medicineCore.QueryByID(ctx, nl.MedicineID); err != nil {
+			return nil, fmt.Errorf("medicine.querybyid: %s: %w", nl.MedicineID, err)
+		}
+
+		lines = append(lines, Line{
+			MedicineID: nl.MedicineID,
+			Quantity:   nl.Quantity,
+		})
+	}
+
+	return lines, nil
+}
+
+// pick assigns the picked lots to the lines of the order. Every line has to
+// be picked in full.
+func (c *Core) pick(ctx context.Context, ord *Order, pls []PickLine) error {
+	lines := make(map[uuid.UUID]int, len(ord.Lines))
+	for i := range ord.Lines {
+		ord.Lines[i].Picks = nil
+		lines[ord.Lines[i].MedicineID] = i
+	}
+
+	seen := make(map[uuid.UUID]bool, len(pls))
+	for _, pl := range pls {
+		if seen[pl.LotID] {
+			return fmt.Errorf("%w: %s", ErrDuplicatePick, pl.LotID)
+		}
+		seen[pl.LotID] = true
+
+		if pl.Quantity <= 0 {
+			return fmt.Errorf("%w: lot[%s] quantity[%d]", ErrInvalidQuantity, pl.LotID, pl.Quantity)
+		}
+
+		lot, err := c.lotCore.QueryByID(ctx, pl.LotID)
+		if err != nil {
+			return fmt.Errorf("lot.querybyid: %s: %w", pl.LotID, err)
+		}
+
+		if lot.Quarantined {
+			return fmt.Errorf("%w: lot[%s]", stockbus.ErrQuarantined, lot.Number)
+		}
+
+		idx, exists := lines[lot.MedicineID]
+		if !exists {
+			return fmt.Errorf("%w: lot[%s]", ErrUnknownMedicine, pl.LotID)
+		}
+
+		ord.Lines[idx].Picks = append(ord.Lines[idx].Picks, Pick{
+			LotID:    pl.LotID,
+			Quantity: pl.Quantity,
+		})
+	}
+
+	for _, line := range ord.Lines {
+		if line.Picked() != line.Quantity {
+			return fmt.Errorf("%w: medicine[%s] picked[%d] ordered[%d]", ErrPickMismatch, line.MedicineID, line.Picked(), line.Quantity)
+		}
+	}
+
+	return nil
+}
+
+// allocate picks every line of the order from the lots of its inventory that
+// expire first.
+func (c *Core) allocate(ctx context.Context, ord *Order) error {
+	for i, line := range ord.Lines {
+		req := allocationbus.Request{
+			MedicineID:  line.MedicineID,
+			Quantity:    line.Quantity,
+			InventoryID: &ord.InventoryID,
+		}
+
+		plan, err := c.allocationCore.Plan(ctx, req)
+		if err != nil {
+			return fmt.Errorf("allocation.plan: medicine[%s]: %w", line.MedicineID, err)
+		}
+
+		if plan.Shortfall() > 0 {
+			return fmt.Errorf("%w: medicine[%s] shortfall[%d]", ErrShortPick, line.MedicineID, plan.Shortfall())
+		}
+
+		picks := make([]Pick, len(plan.Lines))
+		for j, pl := range plan.Lines {
+			picks[j] = Pick{
+				LotID:    pl.LotID,
+				Quantity: pl.Quantity,
+			}
+		}
+		ord.Lines[i].Picks = picks
+	}
+
+	return nil
+}
+
+// reference returns the reason recorded on the stock movements of an order
+// so the ledger can be traced back to it and its destination.
+func reference(ord Order) string {
+	return fmt.Sprintf("order %s to %s %s", ord.ID, ord.Destination.Type.Name(), ord.Destination.Name)
+}
@@ -0,0 +1,37 @@
+package orderdb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus"
+)
+
+func applyFilter(filter orderbus.QueryFilter, data map[string]interface{}, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["order_id"] = *filter.ID
+		wc = append(wc, "order_id = :order_id")
+	}
+
+	if filter.InventoryID != nil {
+		data["inventory_id"] = *filter.InventoryID
+		wc = append(wc, "inventory_id = :inventory_id")
+	}
+
+	if filter.Status != nil {
+		data["status"] = filter.Status.Name()
+		wc = append(wc, "status = :status")
+	}
+
+	if filter.DestinationType != nil {
+		data["destination_type"] = filter.DestinationType.Name()
+		wc = append(wc, "destination_type = :destination_type")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
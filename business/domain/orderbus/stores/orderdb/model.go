@@ -0,0 +1,186 @@
+package orderdb
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus"
+	"github.com/google/uuid"
+)
+
+type dbOrder struct {
+	ID              uuid.UUID      `db:"order_id"`
+	InventoryID     uuid.UUID      `db:"inventory_id"`
+	DestinationType string         `db:"destination_type"`
+	Destination     string         `db:"destination"`
+	Status          string         `db:"status"`
+	Note            sql.NullString `db:"note"`
+	CreatedBy       uuid.UUID      `db:"created_by"`
+	ApprovedBy      uuid.NullUUID  `db:"approved_by"`
+	DateApproved    sql.NullTime   `db:"date_approved"`
+	PickedBy        uuid.NullUUID  `db:"picked_by"`
+	DatePicked      sql.NullTime   `db:"date_picked"`
+	ShippedBy       uuid.NullUUID  `db:"shipped_by"`
+	DateShipped     sql.NullTime   `db:"date_shipped"`
+	DateCreated     time.Time      `db:"date_created"`
+	DateUpdated     time.Time      `db:"date_updated"`
+}
+
+type dbLine struct {
+	OrderID    uuid.UUID `db:"order_id"`
+	MedicineID uuid.UUID `db:"medicine_id"`
+	Quantity   int       `db:"quantity"`
+}
+
+type dbPick struct {
+	OrderID    uuid.UUID `db:"order_id"`
+	MedicineID uuid.UUID `db:"medicine_id"`
+	LotID      uuid.UUID `db:"lot_id"`
+	Quantity   int       `db:"quantity"`
+}
+
+func toDBOrder(ord orderbus.Order) dbOrder {
+	return dbOrder{
+		ID:              ord.ID,
+		InventoryID:     ord.InventoryID,
+		DestinationType: ord.Destination.Type.Name(),
+		Destination:     ord.Destination.Name,
+		Status:          ord.Status.Name(),
+		Note: sql.NullString{
+			String: ord.Note,
+			Valid:  ord.Note != "",
+		},
+		CreatedBy:    ord.CreatedBy,
+		ApprovedBy:   toNullUUID(ord.ApprovedBy),
+		DateApproved: toNullTime(ord.DateApproved),
+		PickedBy:     toNullUUID(ord.PickedBy),
+		DatePicked:   toNullTime(ord.DatePicked),
+		ShippedBy:    toNullUUID(ord.ShippedBy),
+		DateShipped:  toNullTime(ord.DateShipped),
+		DateCreated:  ord.DateCreated.UTC(),
+		DateUpdated:  ord.DateUpdated.UTC(),
+	}
+}
+
+func toDBLines(ord orderbus.Order) ([]dbLine, []dbPick) {
+	lines := make([]dbLine, len(ord.Lines))
+	var picks []dbPick
+	for i, line := range ord.Lines {
+		lines[i] = dbLine{
+			OrderID:    ord.ID,
+			MedicineID: line.MedicineID,
+			Quantity:   line.Quantity,
+		}
+
+		for _, pick := range line.Picks {
+			picks = append(picks, dbPick{
+				OrderID:    ord.ID,
+				MedicineID: line.MedicineID,
+				LotID:      pick.LotID,
+				Quantity:   pick.Quantity,
+			})
+		}
+	}
+
+	return lines, picks
+}
+
+func toCoreOrder(dbOrd dbOrder, dbLines []dbLine, dbPicks []dbPick) (orderbus.Order, error) {
+	status, err := orderbus.ParseStatus(dbOrd.Status)
+	if err != nil {
+		return orderbus.Order{}, fmt.Errorf("parse status: %w", err)
+	}
+
+	dt, err := orderbus.ParseDestinationType(dbOrd.DestinationType)
+	if err != nil {
+		return orderbus.Order{}, fmt.Errorf("parse destination type: %w", err)
+	}
+
+	picks := make(map[uuid.UUID][]orderbus.Pick)
+	for _, dbPick := range dbPicks {
+		picks[dbPick.MedicineID] = append(picks[dbPick.MedicineID], orderbus.Pick{
+			LotID:    dbPick.LotID,
+			Quantity: dbPick.Quantity,
+		})
+	}
+
+	lines := make([]orderbus.Line, len(dbLines))
+	for i, dbLine := range dbLines {
+		lines[i] = orderbus.Line{
+			MedicineID: dbLine.MedicineID,
+			Quantity:   dbLine.Quantity,
+			Picks:      picks[dbLine.MedicineID],
+		}
+	}
+
+	ord := orderbus.Order{
+		ID:          dbOrd.ID,
+		InventoryID: dbOrd.InventoryID,
+		Destination: orderbus.Destination{
+			Type: dt,
+			Name: dbOrd.Destination,
+		},
+		Status:       status,
+		Note:         dbOrd.Note.String,
+		Lines:        lines,
+		CreatedBy:    dbOrd.CreatedBy,
+		ApprovedBy:   dbOrd.ApprovedBy.UUID,
+		DateApproved: toCoreTime(dbOrd.DateApproved),
+		PickedBy:     dbOrd.PickedBy.UUID,
+		DatePicked:   toCoreTime(dbOrd.DatePicked),
+		ShippedBy:    dbOrd.ShippedBy.UUID,
+		DateShipped:  toCoreTime(dbOrd.DateShipped),
+		DateCreated:  dbOrd.DateCreated.In(time.Local),
+		DateUpdated:  dbOrd.DateUpdated.In(time.Local),
+	}
+
+	return ord, nil
+}
+
+func toCoreOrderSlice(dbOrds []dbOrder, dbLines []dbLine, dbPicks []dbPick) ([]orderbus.Order, error) {
+	linesByOrder := make(map[uuid.UUID][]dbLine, len(dbOrds))
+	for _, dbLine := range dbLines {
+		linesByOrder[dbLine.OrderID] = append(linesByOrder[dbLine.OrderID], dbLine)
+	}
+
+	picksByOrder := make(map[uuid.UUID][]dbPick, len(dbOrds))
+	for _, dbPick := range dbPicks {
+		picksByOrder[dbPick.OrderID] = append(picksByOrder[dbPick.OrderID], dbPick)
+	}
+
+	ords := make([]orderbus.Order, len(dbOrds))
+	for i, dbOrd := range dbOrds {
+		var err error
+		ords[i], err = toCoreOrder(dbOrd, linesByOrder[dbOrd.ID], picksByOrder[dbOrd.ID])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ords, nil
+}
+
+// =============================================================================
+
+func toNullUUID(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{
+		UUID:  id,
+		Valid: id != uuid.Nil,
+	}
+}
+
+func toNullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{
+		Time:  t.UTC(),
+		Valid: !t.IsZero(),
+	}
+}
+
+func toCoreTime(t sql.NullTime) time.Time {
+	if !t.Valid {
+		return time.Time{}
+	}
+
+	return t.Time.In(time.Local)
+}
@@ -0,0 +1,24 @@
+package orderdb
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus"
+)
+
+var orderByFields = map[string]string{
+	orderbus.OrderByID:          "order_id",
+	orderbus.OrderByStatus:      "status",
+	orderbus.OrderByDateCreated: "date_created",
+	orderbus.OrderByDateShipped: "date_shipped",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
@@ -0,0 +1,278 @@
+// Package orderdb contains order related CRUD functionality.
+package orderdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb/dbarray"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Store manages the set of APIs for order database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Store value replacing the sqlx DB
+// value with a sqlx DB value that is currently inside a transaction.
+func (s *Store) ExecuteUnderTransaction(tx transaction.Transaction) (orderbus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	store := Store{
+		log: s.log,
+		db:  ec,
+	}
+
+	return &store, nil
+}
+
+// Create inserts a new order and its lines into the database.
+func (s *Store) Create(ctx context.Context, ord orderbus.Order) error {
+	const q = `
+	INSERT INTO orders
+		(order_id, inventory_id, destination_type, destination, status, note, created_by, approved_by, date_approved, picked_by, date_picked, shipped_by, date_shipped, date_created, date_updated)
+	VALUES
+		(:order_id, :inventory_id, :destination_type, :destination, :status, :note, :created_by, :approved_by, :date_approved, :picked_by, :date_picked, :shipped_by, :date_shipped, :date_created, :date_updated)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBOrder(ord)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return s.insertLines(ctx, ord)
+}
+
+// Update replaces the state of an order in the database. The lines and picks
+// are replaced as a whole.
+func (s *Store) Update(ctx context.Context, ord orderbus.Order) error {
+	const q = `
+	UPDATE
+		orders
+	SET
+		"destination_type" = :destination_type,
+		"destination" = :destination,
+		"status" = :status,
+		"note" = :note,
+		"approved_by" = :approved_by,
+		"date_approved" = :date_approved,
+		"picked_by" = :picked_by,
+		"date_picked" = :date_picked,
+		"shipped_by" = :shipped_by,
+		"date_shipped" = :date_shipped,
+		"date_updated" = :date_updated
+	WHERE
+		order_id = :order_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBOrder(ord)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	const qd = `
+	DELETE FROM
+		order_lines
+	WHERE
+		order_id = :order_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, qd, toDBOrder(ord)); err != nil {
+		return fmt.Errorf("namedexeccontext: lines: %w", err)
+	}
+
+	return s.insertLines(ctx, ord)
+}
+
+// Query retrieves a list of existing orders from the database.
+func (s *Store) Query(ctx context.Context, filter orderbus.QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]orderbus.Order, error) {
+	data := map[string]interface{}{
+		"offset":        (pageNumber - 1) * rowsPerPage,
+		"rows_per_page": rowsPerPage,
+	}
+
+	const q = `
+	SELECT
+		order_id, inventory_id, destination_type, destination, status, note, created_by, approved_by, date_approved, picked_by, date_picked, shipped_by, date_shipped, date_created, date_updated
+	FROM
+		orders`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbOrds []dbOrder
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbOrds); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	ids := make([]string, len(dbOrds))
+	for i, dbOrd := range dbOrds {
+		ids[i] = dbOrd.ID.String()
+	}
+
+	dbLines, dbPicks, err := s.queryLines(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCoreOrderSlice(dbOrds, dbLines, dbPicks)
+}
+
+// Count returns the total number of orders in the database.
+func (s *Store) Count(ctx context.Context, filter orderbus.QueryFilter) (int, error) {
+	data := map[string]interface{}{}
+
+	const q = `
+	SELECT
+		count(1)
+	FROM
+		orders`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID gets the specified order from the database.
+func (s *Store) QueryByID(ctx context.Context, orderID uuid.UUID) (orderbus.Order, error) {
+	return s.queryByID(ctx, orderID, "")
+}
+
+// QueryByIDForUpdate gets the specified order from the database and locks it
+// until the surrounding transaction ends.
+func (s *Store) QueryByIDForUpdate(ctx context.Context, orderID uuid.UUID) (orderbus.Order, error) {
+	return s.queryByID(ctx, orderID, " FOR UPDATE")
+}
+
+func (s *Store) queryByID(ctx context.Context, orderID uuid.UUID, lock string) (orderbus.Order, error) {
+	data := struct {
+		ID string `db:"order_id"`
+	}{
+		ID: orderID.String(),
+	}
+
+	const q = `
+	SELECT
+		order_id, inventory_id, destination_type, destination, status, note, created_by, approved_by, date_approved, picked_by, date_picked, shipped_by, date_shipped, date_created, date_updated
+	FROM
+		orders
+	WHERE
+		order_id = :order_id`
+
+	var dbOrd dbOrder
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q+lock, data, &dbOrd); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return orderbus.Order{}, fmt.Errorf("db: %w", orderbus.ErrNotFound)
+		}
+		return orderbus.Order{}, fmt.Errorf("db: %w", err)
+	}
+
+	dbLines, dbPicks, err := s.queryLines(ctx, []string{dbOrd.ID.String()})
+	if err != nil {
+		return orderbus.Order{}, err
+	}
+
+	return toCoreOrder(dbOrd, dbLines, dbPicks)
+}
+
+func (s *Store) insertLines(ctx context.Context, ord orderbus.Order) error {
+	lines, picks := toDBLines(ord)
+
+	const ql = `
+	INSERT INTO order_lines
+		(order_id, medicine_id, quantity)
+	VALUES
+		(:order_id, :medicine_id, :quantity)`
+
+	for _, line := range lines {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, ql, line); err != nil {
+			return fmt.Errorf("namedexeccontext: line: %w", err)
+		}
+	}
+
+	const qp = `
+	INSERT INTO order_picks
+		(order_id, medicine_id, lot_id, quantity)
+	VALUES
+		(:order_id, :medicine_id, :lot_id, :quantity)`
+
+	for _, pick := range picks {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, qp, pick); err != nil {
+			return fmt.Errorf("namedexeccontext: pick: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) queryLines(ctx context.Context, orderIDs []string) ([]dbLine, []dbPick, error) {
+	data := struct {
+		ID any `db:"order_id"`
+	}{
+		ID: dbarray.Array(orderIDs),
+	}
+
+	const ql = `
+	SELECT
+		order_id, medicine_id, quantity
+	FROM
+		order_lines
+	WHERE
+		order_id = ANY(:order_id)
+	ORDER BY
+		order_id, medicine_id`
+
+	var dbLines []dbLine
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, ql, data, &dbLines); err != nil {
+		return nil, nil, fmt.Errorf("namedqueryslice: lines: %w", err)
+	}
+
+	const qp = `
+	SELECT
+		order_id, medicine_id, lot_id, quantity
+	FROM
+		order_picks
+	WHERE
+		order_id = ANY(:order_id)
+	ORDER BY
+		order_id, medicine_id, lot_id`
+
+	var dbPicks []dbPick
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, qp, data, &dbPicks); err != nil {
+		return nil, nil, fmt.Errorf("namedqueryslice: picks: %w", err)
+	}
+
+	return dbLines, dbPicks, nil
+}
@@ -0,0 +1,46 @@
+package purchaseorderbus
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryFilter holds the available fields a query can be filtered on.
+// We are using pointer semantics because the With API mutates the value.
+type QueryFilter struct {
+	ID          *uuid.UUID
+	SupplierID  *uuid.UUID
+	InventoryID *uuid.UUID
+	Status      *Status
+}
+
+// Validate can perform a check of the data against the validate tags.
+func (qf *QueryFilter) Validate() error {
+	if err := validate.Check(qf); err != nil {
+		return fmt.Errorf("validate: %w", err)
+	}
+
+	return nil
+}
+
+// WithPurchaseOrderID sets the ID field of the QueryFilter value.
+func (qf *QueryFilter) WithPurchaseOrderID(purchaseOrderID uuid.UUID) {
+	qf.ID = &purchaseOrderID
+}
+
+// WithSupplierID sets the SupplierID field of the QueryFilter value.
+func (qf *QueryFilter) WithSupplierID(supplierID uuid.UUID) {
+	qf.SupplierID = &supplierID
+}
+
+// WithInventoryID sets the InventoryID field of the QueryFilter value.
+func (qf *QueryFilter) WithInventoryID(inventoryID uuid.UUID) {
+	qf.InventoryID = &inventoryID
+}
+
+// WithStatus sets the Status field of the QueryFilter value.
+func (qf *QueryFilter) WithStatus(status Status) {
+	qf.Status = &status
+}
@@ -0,0 +1,106 @@
+package purchaseorderbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PurchaseOrder represents stock ordered from a supplier for an inventory.
+// Stock only enters the inventory as deliveries are received against it.
+type PurchaseOrder struct {
+	ID           uuid.UUID
+	SupplierID   uuid.UUID
+	InventoryID  uuid.UUID
+	Status       Status
+	Note         string
+	Lines        []Line
+	CreatedBy    uuid.UUID
+	ApprovedBy   uuid.UUID
+	DateApproved time.Time
+	SentBy       uuid.UUID
+	DateSent     time.Time
+	DateCreated  time.Time
+	DateUpdated  time.Time
+}
+
+// Line represents the quantity of a medicine ordered and how much of it has
+// been received so far.
+type Line struct {
+	MedicineID uuid.UUID
+	Quantity   int
+	Received   int
+}
+
+// Short returns the quantity still missing from the line.
+func (l Line) Short() int {
+	return max(l.Quantity-l.Received, 0)
+}
+
+// Over returns the quantity received beyond what was ordered.
+func (l Line) Over() int {
+	return max(l.Received-l.Quantity, 0)
+}
+
+// Delivery represents a single shipment received against a purchase order.
+type Delivery struct {
+	ID              uuid.UUID
+	PurchaseOrderID uuid.UUID
+	InventoryID     uuid.UUID
+	Note            string
+	Lines           []DeliveryLine
+	ReceivedBy      uuid.UUID
+	DateReceived    time.Time
+}
+
+// DeliveryLine represents the quantity of a lot received in a delivery.
+type DeliveryLine struct {
+	MedicineID uuid.UUID
+	LotID      uuid.UUID
+	LotNumber  string
+	ExpiryDate time.Time
+	Quantity   int
+}
+
+// NewPurchaseOrder contains information needed to create a new purchase
+// order.
+type NewPurchaseOrder struct {
+	SupplierID  uuid.UUID
+	InventoryID uuid.UUID
+	Note        string
+	Lines       []NewLine
+	UserID      uuid.UUID
+}
+
+// NewLine contains information needed to add a medicine to a purchase order.
+type NewLine struct {
+	MedicineID uuid.UUID
+	Quantity   int
+}
+
+// UpdatePurchaseOrder contains information needed to update a draft purchase
+// order. Lines, when set, replace the lines of the purchase order.
+type UpdatePurchaseOrder struct {
+	SupplierID *uuid.UUID
+	Note       *string
+	Lines      []NewLine
+}
+
+// NewDelivery contains information needed to receive a delivery.
+type NewDelivery struct {
+	Note            string
+	Lines           []NewDeliveryLine
+	UserID          uuid.UUID
+	CountersignedBy uuid.UUID
+}
+
+// NewDeliveryLine contains the quantity of a lot received. The lot is looked
+// up by its number and created when the medicine has no lot with that number
+// yet. ManufactureDate may be left as the zero value when it is not known.
+type NewDeliveryLine struct {
+	MedicineID      uuid.UUID
+	LotNumber       string
+	ExpiryDate      time.Time
+	ManufactureDate time.Time
+	Quantity        int
+}
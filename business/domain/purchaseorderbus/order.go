@@ -0,0 +1,14 @@
+package purchaseorderbus
+
+import "github.com/EnesDemirtas/medisync/business/api/order"
+
+// DefaultOrderBy represents the default way we sort.
+var DefaultOrderBy = order.NewBy(OrderByDateCreated, order.ASC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID          = "purchase_order_id"
+	OrderByStatus      = "status"
+	OrderByDateCreated = "date_created"
+	OrderByDateSent    = "date_sent"
+)
@@ -0,0 +1,504 @@
+// Package purchaseorderbus provides the business API for stock bought from a
+// supplier. A purchase order is drafted, approved and sent to the supplier,
+// after which deliveries are received against it. Every delivery records the
+// lots it brought in and posts them to the inventory of the purchase order
+// through the stock ledger. The lines of the purchase order keep track of the
+// quantity received so short and over deliveries are visible.
+package purchaseorderbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/supplierbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound          = errors.New("purchase order not found")
+	ErrNoLines           = errors.New("purchase order has no lines")
+	ErrDuplicateLine     = errors.New("medicine listed more than once")
+	ErrInvalidQuantity   = errors.New("invalid purchase order quantity")
+	ErrInvalidTransition = errors.New("purchase order can't move to the requested status")
+	ErrNoDeliveryLines   = errors.New("delivery has no lines")
+	ErrUnknownMedicine   = errors.New("delivered medicine is not on the purchase order")
+	ErrLotMismatch       = errors.New("delivered lot expiry does not match the recorded lot")
+	ErrDuplicateLot      = errors.New("lot listed more than once on the delivery")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	ExecuteUnderTransaction(tx transaction.Transaction) (Storer, error)
+	Create(ctx context.Context, po PurchaseOrder) error
+	Update(ctx context.Context, po PurchaseOrder) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]PurchaseOrder, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, purchaseOrderID uuid.UUID) (PurchaseOrder, error)
+	QueryByIDForUpdate(ctx context.Context, purchaseOrderID uuid.UUID) (PurchaseOrder, error)
+	CreateDelivery(ctx context.Context, dlv Delivery) error
+	QueryDeliveries(ctx context.Context, purchaseOrderID uuid.UUID) ([]Delivery, error)
+}
+
+// Core manages the set of APIs for purchase order access.
+type Core struct {
+	log          *logger.Logger
+	supplierCore *supplierbus.Core
+	medicineCore *medicinebus.Core
+	lotCore      *lotbus.Core
+	stockCore    *stockbus.Core
+	delegate     *delegate.Delegate
+	storer       Storer
+}
+
+// NewCore constructs a purchase order core API for use.
+func NewCore(log *logger.Logger, supplierCore *supplierbus.Core, medicineCore *medicinebus.Core, lotCore *lotbus.Core, stockCore *stockbus.Core, delegate *delegate.Delegate, storer Storer) *Core {
+	return &Core{
+		log:          log,
+		supplierCore: supplierCore,
+		medicineCore: medicineCore,
+		lotCore:      lotCore,
+		stockCore:    stockCore,
+		delegate:     delegate,
+		storer:       storer,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Core value that will use the
+// specified transaction in any store related calls.
+func (c *Core) ExecuteUnderTransaction(tx transaction.Transaction) (*Core, error) {
+	storer, err := c.storer.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	supplierCore, err := c.supplierCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	medicineCore, err := c.medicineCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	lotCore, err := c.lotCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	stockCore, err := c.stockCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	core := Core{
+		log:          c.log,
+		supplierCore: supplierCore,
+		medicineCore: medicineCore,
+		lotCore:      lotCore,
+		stockCore:    stockCore,
+		delegate:     c.delegate,
+		storer:       storer,
+	}
+
+	return &core, nil
+}
+
+// Create adds a new draft purchase order to the system.
+func (c *Core) Create(ctx context.Context, npo NewPurchaseOrder) (PurchaseOrder, error) {
+	if _, err := c.supplierCore.QueryByID(ctx, npo.SupplierID); err != nil {
+		return PurchaseOrder{}, fmt.Errorf("supplier.querybyid: %s: %w", npo.SupplierID, err)
+	}
+
+	lines, err := c.newLines(ctx, npo.Lines)
+	if err != nil {
+		return PurchaseOrder{}, err
+	}
+
+	now := time.Now()
+
+	po := PurchaseOrder{
+		ID:          uuid.New(),
+		SupplierID:  npo.SupplierID,
+		InventoryID: npo.InventoryID,
+		Status:      StatusDraft,
+		Note:        npo.Note,
+		Lines:       lines,
+		CreatedBy:   npo.UserID,
+		DateCreated: now,
+		DateUpdated: now,
+	}
+
+	if err := c.storer.Create(ctx, po); err != nil {
+		return PurchaseOrder{}, fmt.Errorf("create: %w", err)
+	}
+
+	return po, nil
+}
+
+// Update modifies information about a draft purchase order.
+func (c *Core) Update(ctx context.Context, purchaseOrderID uuid.UUID, upo UpdatePurchaseOrder) (PurchaseOrder, error) {
+	po, err := c.storer.QueryByIDForUpdate(ctx, purchaseOrderID)
+	if err != nil {
+		return PurchaseOrder{}, fmt.Errorf("query: purchaseOrderID[%s]: %w", purchaseOrderID, err)
+	}
+
+	if po.Status != StatusDraft {
+		return PurchaseOrder{}, fmt.Errorf("%w: update %s purchase order", ErrInvalidTransition, po.Status.Name())
+	}
+
+	if upo.SupplierID != nil {
+		if _, err := c.supplierCore.QueryByID(ctx, *upo.SupplierID); err != nil {
+			return PurchaseOrder{}, fmt.Errorf("supplier.querybyid: %s: %w", *upo.SupplierID, err)
+		}
+		po.SupplierID = *upo.SupplierID
+	}
+
+	if upo.Note != nil {
+		po.Note = *upo.Note
+	}
+
+	if upo.Lines != nil {
+		if po.Lines, err = c.newLines(ctx, upo.Lines); err != nil {
+			return PurchaseOrder{}, err
+		}
+	}
+
+	po.DateUpdated = time.Now()
+
+	if err := c.storer.Update(ctx, po); err != nil {
+		return PurchaseOrder{}, fmt.Errorf("update: %w", err)
+	}
+
+	return po, nil
+}
+
+// Approve releases a draft purchase order to be sent to the supplier.
+func (c *Core) Approve(ctx context.Context, purchaseOrderID uuid.UUID, userID uuid.UUID) (PurchaseOrder, error) {
+	po, err := c.storer.QueryByIDForUpdate(ctx, purchaseOrderID)
+	if err != nil {
+		return PurchaseOrder{}, fmt.Errorf("query: purchaseOrderID[%s]: %w", purchaseOrderID, err)
+	}
+
+	if po.Status != StatusDraft {
+		return PurchaseOrder{}, fmt.Errorf("%w: approve %s purchase order", ErrInvalidTransition, po.Status.Name())
+	}
+
+	now := time.Now()
+
+	po.Status = StatusApproved
+	po.ApprovedBy = userID
+	po.DateApproved = now
+	po.DateUpdated = now
+
+	if err := c.storer.Update(ctx, po); err != nil {
+		return PurchaseOrder{}, fmt.Errorf("update: %w", err)
+	}
+
+	return po, nil
+}
+
+// Send records that an approved purchase order went out to the supplier.
+// Deliveries can only be received once it has been sent.
+func (c *Core) Send(ctx context.Context, purchaseOrderID uuid.UUID, userID uuid.UUID) (PurchaseOrder, error) {
+	po, err := c.storer.QueryByIDForUpdate(ctx, purchaseOrderID)
+	if err != nil {
+		return PurchaseOrder{}, fmt.Errorf("query: purchaseOrderID[%s]: %w", purchaseOrderID, err)
+	}
+
+	if po.Status != StatusApproved {
+		return PurchaseOrder{}, fmt.Errorf("%w: send %s purchase order", ErrInvalidTransition, po.Status.Name())
+	}
+
+	now := time.Now()
+
+	po.Status = StatusSent
+	po.SentBy = userID
+	po.DateSent = now
+	po.DateUpdated = now
+
+	if err := c.storer.Update(ctx, po); err != nil {
+		return PurchaseOrder{}, fmt.Errorf("update: %w", err)
+	}
+
+	return po, nil
+}
+
+// Receive records a delivery against a sent purchase order and posts the
+// delivered lots to its inventory. More than was ordered may be delivered,
+// the excess is kept on the line so it shows up as an over delivery. The
+// purchase order is received once every line has been delivered in full.
+// The caller is expected to run this under a transaction so the delivery,
+// its lots and its movements are committed together.
+func (c *Core) Receive(ctx context.Context, purchaseOrderID uuid.UUID, nd NewDelivery) (Delivery, PurchaseOrder, error) {
+	po, err := c.storer.QueryByIDForUpdate(ctx, purchaseOrderID)
+	if err != nil {
+		return Delivery{}, PurchaseOrder{}, fmt.Errorf("query: purchaseOrderID[%s]: %w", purchaseOrderID, err)
+	}
+
+	if po.Status != StatusSent && po.Status != StatusPartiallyReceived {
+		return Delivery{}, PurchaseOrder{}, fmt.Errorf("%w: receive %s purchase order", ErrInvalidTransition, po.Status.Name())
+	}
+
+	if len(nd.Lines) == 0 {
+		return Delivery{}, PurchaseOrder{}, ErrNoDeliveryLines
+	}
+
+	lines := make(map[uuid.UUID]int, len(po.Lines))
+	for i, line := range po.Lines {
+		lines[line.MedicineID] = i
+	}
+
+	now := time.Now()
+
+	dlv := Delivery{
+		ID:              uuid.New(),
+		PurchaseOrderID: po.ID,
+		InventoryID:     po.InventoryID,
+		Note:            nd.Note,
+		Lines:           make([]DeliveryLine, len(nd.Lines)),
+		ReceivedBy:      nd.UserID,
+		DateReceived:    now,
+	}
+
+	seen := make(map[uuid.UUID]bool, len(nd.Lines))
+
+	for i, ndl := range nd.Lines {
+		idx, exists := lines[ndl.MedicineID]
+		if !exists {
+			return Delivery{}, PurchaseOrder{}, fmt.Errorf("%w: medicine[%s]", ErrUnknownMedicine, ndl.MedicineID)
+		}
+
+		if ndl.Quantity <= 0 {
+			return Delivery{}, PurchaseOrder{}, fmt.Errorf("%w: medicine[%s] quantity[%d]", ErrInvalidQuantity, ndl.MedicineID, ndl.Quantity)
+		}
+
+		lot, err := c.resolveLot(ctx, ndl)
+		if err != nil {
+			return Delivery{}, PurchaseOrder{}, err
+		}
+
+		if seen[lot.ID] {
+			return Delivery{}, PurchaseOrder{}, fmt.Errorf("%w: %s", ErrDuplicateLot, ndl.LotNumber)
+		}
+		seen[lot.ID] = true
+
+		nm := stockbus.NewMovement{
+			InventoryID:     po.InventoryID,
+			LotID:           lot.ID,
+			Type:            stockbus.TypeReceive,
+			Quantity:        ndl.Quantity,
+			Reason:          reference(po, dlv),
+			Reference:       stockbus.Reference{Type: stockbus.ReferencePurchaseOrder, ID: po.ID},
+			UserID:          nd.UserID,
+			CountersignedBy: nd.CountersignedBy,
+		}
+
+		if _, err := c.stockCore.Create(ctx, nm); err != nil {
+			return Delivery{}, PurchaseOrder{}, fmt.Errorf("stock.create: lot[%s]: %w", lot.ID, err)
+		}
+
+		dlv.Lines[i] = DeliveryLine{
+			MedicineID: ndl.MedicineID,
+			LotID:      lot.ID,
+			LotNumber:  lot.Number,
+			ExpiryDate: lot.ExpiryDate,
+			Quantity:   ndl.Quantity,
+		}
+		po.Lines[idx].Received += ndl.Quantity
+	}
+
+	if err := c.storer.CreateDelivery(ctx, dlv); err != nil {
+		return Delivery{}, PurchaseOrder{}, fmt.Errorf("createdelivery: %w", err)
+	}
+
+	po.Status = StatusReceived
+	for _, line := range po.Lines {
+		if line.Short() > 0 {
+			po.Status = StatusPartiallyReceived
+			break
+		}
+	}
+	po.DateUpdated = now
+
+	if err := c.storer.Update(ctx, po); err != nil {
+		return Delivery{}, PurchaseOrder{}, fmt.Errorf("update: %w", err)
+	}
+
+	return dlv, po, nil
+}
+
+// Close stops waiting on the rest of a partially received purchase order.
+// The missing quantity stays on the lines as a short delivery.
+func (c *Core) Close(ctx context.Context, purchaseOrderID uuid.UUID) (PurchaseOrder, error) {
+	po, err := c.storer.QueryByIDForUpdate(ctx, purchaseOrderID)
+	if err != nil {
+		return PurchaseOrder{}, fmt.Errorf("query: purchaseOrderID[%s]: %w", purchaseOrderID, err)
+	}
+
+	if po.Status != StatusPartiallyReceived {
+		return PurchaseOrder{}, fmt.Errorf("%w: close %s purchase order", ErrInvalidTransition, po.Status.Name())
+	}
+
+	po.Status = StatusReceived
+	po.DateUpdated = time.Now()
+
+	if err := c.storer.Update(ctx, po); err != nil {
+		return PurchaseOrder{}, fmt.Errorf("update: %w", err)
+	}
+
+	return po, nil
+}
+
+// Cancel stops a purchase order nothing has been received against yet.
+func (c *Core) Cancel(ctx context.Context, purchaseOrderID uuid.UUID) (PurchaseOrder, error) {
+	po, err := c.storer.QueryByIDForUpdate(ctx, purchaseOrderID)
+	if err != nil {
+		return PurchaseOrder{}, fmt.Errorf("query: purchaseOrderID[%s]: %w", purchaseOrderID, err)
+	}
+
+	switch po.Status {
+	case StatusDraft, StatusApproved, StatusSent:
+	default:
+		return PurchaseOrder{}, fmt.Errorf("%w: cancel %s purchase order", ErrInvalidTransition, po.Status.Name())
+	}
+
+	po.Status = StatusCancelled
+	po.DateUpdated = time.Now()
+
+	if err := c.storer.Update(ctx, po); err != nil {
+		return PurchaseOrder{}, fmt.Errorf("update: %w", err)
+	}
+
+	return po, nil
+}
+
+// Query retrieves a list of existing purchase orders.
+func (c *Core) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]PurchaseOrder, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	pos, err := c.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return pos, nil
+}
+
+// Count returns the total number of purchase orders.
+func (c *Core) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	return c.storer.Count(ctx, filter)
+}
+
+// QueryByID finds the purchase order by the specified ID.
+func (c *Core) QueryByID(ctx context.Context, purchaseOrderID uuid.UUID) (PurchaseOrder, error) {
+	po, err := c.storer.QueryByID(ctx, purchaseOrderID)
+	if err != nil {
+		return PurchaseOrder{}, fmt.Errorf("query: purchaseOrderID[%s]: %w", purchaseOrderID, err)
+	}
+
+	return po, nil
+}
+
+// QueryDeliveries retrieves the deliveries received against the specified
+// purchase order, oldest first.
+func (c *Core) QueryDeliveries(ctx context.Context, purchaseOrderID uuid.UUID) ([]Delivery, error) {
+	dlvs, err := c.storer.QueryDeliveries(ctx, purchaseOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("querydeliveries: purchaseOrderID[%s]: %w", purchaseOrderID, err)
+	}
+
+	return dlvs, nil
+}
+
+// =============================================================================
+
+// newLines validates the requested lines of a purchase order.
+func (c *Core) newLines(ctx context.Context, nls []NewLine) ([]Line, error) {
+	if len(nls) == 0 {
+		return nil, ErrNoLines
+	}
+
+	lines := make([]Line, 0, len(nls))
+	seen := make(map[uuid.UUID]bool, len(nls))
+	for _, nl := range nls {
+		if seen[nl.MedicineID] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateLine, nl.MedicineID)
+		}
+		seen[nl.MedicineID] = true
+
+		if nl.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: medicine[%s] quantity[%d]", ErrInvalidQuantity, nl.MedicineID, nl.Quantity)
+		}
+
+		if _, err := c.medicineCore.QueryByID(ctx, nl.MedicineID); err != nil {
+			return nil, fmt.Errorf("medicine.querybyid: %s: %w", nl.MedicineID, err)
+		}
+
+		lines = append(lines, Line{
+			MedicineID: nl.MedicineID,
+			Quantity:   nl.Quantity,
+		})
+	}
+
+	return lines, nil
+}
+
+// resolveLot returns the lot a delivery line refers to, creating it when the
+// medicine has no lot with that number yet. An existing lot has to carry the
+// same expiry date as the delivery.
+func (c *Core) resolveLot(ctx context.Context, ndl NewDeliveryLine) (lotbus.Lot, error) {
+	var filter lotbus.QueryFilter
+	filter.WithMedicineID(ndl.MedicineID)
+	filter.WithNumber(ndl.LotNumber)
+
+	lots, err := c.lotCore.Query(ctx, filter, lotbus.DefaultOrderBy, 1, 1)
+	if err != nil {
+		return lotbus.Lot{}, fmt.Errorf("lot.query: medicine[%s] number[%s]: %w", ndl.MedicineID, ndl.LotNumber, err)
+	}
+
+	if len(lots) > 0 {
+		if !lots[0].ExpiryDate.Equal(ndl.ExpiryDate) {
+			return lotbus.Lot{}, fmt.Errorf("%w: lot[%s] expiry[%s] delivered[%s]", ErrLotMismatch, lots[0].ID, lots[0].ExpiryDate.Format(time.DateOnly), ndl.ExpiryDate.Format(time.DateOnly))
+		}
+
+		return lots[0], nil
+	}
+
+	nl := lotbus.NewLot{
+		MedicineID:      ndl.MedicineID,
+		Number:          ndl.LotNumber,
+		ExpiryDate:      ndl.ExpiryDate,
+		ManufactureDate: ndl.ManufactureDate,
+	}
+
+	lot, err := c.lotCore.Create(ctx, nl)
+	if err != nil {
+		return lotbus.Lot{}, fmt.Errorf("lot.create: medicine[%s] number[%s]: %w", ndl.MedicineID, ndl.LotNumber, err)
+	}
+
+	return lot, nil
+}
+
+// reference returns the reason recorded on the stock movements of a delivery
+// so the ledger can be traced back to the purchase order.
+func reference(po PurchaseOrder, dlv Delivery) string {
+	return fmt.Sprintf("purchase order %s delivery %s", po.ID, dlv.ID)
+}
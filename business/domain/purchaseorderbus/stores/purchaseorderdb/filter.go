@@ -0,0 +1,37 @@
+package purchaseorderdb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/EnesDemirtas/medisync/business/domain/purchaseorderbus"
+)
+
+func applyFilter(filter purchaseorderbus.QueryFilter, data map[string]interface{}, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["purchase_order_id"] = *filter.ID
+		wc = append(wc, "purchase_order_id = :purchase_order_id")
+	}
+
+	if filter.SupplierID != nil {
+		data["supplier_id"] = *filter.SupplierID
+		wc = append(wc, "supplier_id = :supplier_id")
+	}
+
+	if filter.InventoryID != nil {
+		data["inventory_id"] = *filter.InventoryID
+		wc = append(wc, "inventory_id = :inventory_id")
+	}
+
+	if filter.Status != nil {
+		data["status"] = filter.Status.Name()
+		wc = append(wc, "status = :status")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
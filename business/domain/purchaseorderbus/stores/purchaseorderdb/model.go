@@ -0,0 +1,214 @@
+package purchaseorderdb
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/purchaseorderbus"
+	"github.com/google/uuid"
+)
+
+type dbPurchaseOrder struct {
+	ID           uuid.UUID      `db:"purchase_order_id"`
+	SupplierID   uuid.UUID      `db:"supplier_id"`
+	InventoryID  uuid.UUID      `db:"inventory_id"`
+	Status       string         `db:"status"`
+	Note         sql.NullString `db:"note"`
+	CreatedBy    uuid.UUID      `db:"created_by"`
+	ApprovedBy   uuid.NullUUID  `db:"approved_by"`
+	DateApproved sql.NullTime   `db:"date_approved"`
+	SentBy       uuid.NullUUID  `db:"sent_by"`
+	DateSent     sql.NullTime   `db:"date_sent"`
+	DateCreated  time.Time      `db:"date_created"`
+	DateUpdated  time.Time      `db:"date_updated"`
+}
+
+type dbLine struct {
+	PurchaseOrderID uuid.UUID `db:"purchase_order_id"`
+	MedicineID      uuid.UUID `db:"medicine_id"`
+	Quantity        int       `db:"quantity"`
+	Received        int       `db:"received"`
+}
+
+type dbDelivery struct {
+	ID              uuid.UUID      `db:"delivery_id"`
+	PurchaseOrderID uuid.UUID      `db:"purchase_order_id"`
+	InventoryID     uuid.UUID      `db:"inventory_id"`
+	Note            sql.NullString `db:"note"`
+	ReceivedBy      uuid.UUID      `db:"received_by"`
+	DateReceived    time.Time      `db:"date_received"`
+}
+
+type dbDeliveryLine struct {
+	DeliveryID uuid.UUID `db:"delivery_id"`
+	MedicineID uuid.UUID `db:"medicine_id"`
+	LotID      uuid.UUID `db:"lot_id"`
+	LotNumber  string    `db:"lot_number"`
+	ExpiryDate time.Time `db:"expiry_date"`
+	Quantity   int       `db:"quantity"`
+}
+
+func toDBPurchaseOrder(po purchaseorderbus.PurchaseOrder) dbPurchaseOrder {
+	return dbPurchaseOrder{
+		ID:          po.ID,
+		SupplierID:  po.SupplierID,
+		InventoryID: po.InventoryID,
+		Status:      po.Status.Name(),
+		Note: sql.NullString{
+			String: po.Note,
+			Valid:  po.Note != "",
+		},
+		CreatedBy:    po.CreatedBy,
+		ApprovedBy:   toNullUUID(po.ApprovedBy),
+		DateApproved: toNullTime(po.DateApproved),
+		SentBy:       toNullUUID(po.SentBy),
+		DateSent:     toNullTime(po.DateSent),
+		DateCreated:  po.DateCreated.UTC(),
+		DateUpdated:  po.DateUpdated.UTC(),
+	}
+}
+
+func toDBLines(po purchaseorderbus.PurchaseOrder) []dbLine {
+	lines := make([]dbLine, len(po.Lines))
+	for i, line := range po.Lines {
+		lines[i] = dbLine{
+			PurchaseOrderID: po.ID,
+			MedicineID:      line.MedicineID,
+			Quantity:        line.Quantity,
+			Received:        line.Received,
+		}
+	}
+
+	return lines
+}
+
+func toCorePurchaseOrder(dbPO dbPurchaseOrder, dbLines []dbLine) (purchaseorderbus.PurchaseOrder, error) {
+	status, err := purchaseorderbus.ParseStatus(dbPO.Status)
+	if err != nil {
+		return purchaseorderbus.PurchaseOrder{}, fmt.Errorf("parse status: %w", err)
+	}
+
+	lines := make([]purchaseorderbus.Line, len(dbLines))
+	for i, dbLine := range dbLines {
+		lines[i] = purchaseorderbus.Line{
+			MedicineID: dbLine.MedicineID,
+			Quantity:   dbLine.Quantity,
+			Received:   dbLine.Received,
+		}
+	}
+
+	po := purchaseorderbus.PurchaseOrder{
+		ID:           dbPO.ID,
+		SupplierID:   dbPO.SupplierID,
+		InventoryID:  dbPO.InventoryID,
+		Status:       status,
+		Note:         dbPO.Note.String,
+		Lines:        lines,
+		CreatedBy:    dbPO.CreatedBy,
+		ApprovedBy:   dbPO.ApprovedBy.UUID,
+		DateApproved: toCoreTime(dbPO.DateApproved),
+		SentBy:       dbPO.SentBy.UUID,
+		DateSent:     toCoreTime(dbPO.DateSent),
+		DateCreated:  dbPO.DateCreated.In(time.Local),
+		DateUpdated:  dbPO.DateUpdated.In(time.Local),
+	}
+
+	return po, nil
+}
+
+func toCorePurchaseOrderSlice(dbPOs []dbPurchaseOrder, dbLines []dbLine) ([]purchaseorderbus.PurchaseOrder, error) {
+	linesByPO := make(map[uuid.UUID][]dbLine, len(dbPOs))
+	for _, dbLine := range dbLines {
+		linesByPO[dbLine.PurchaseOrderID] = append(linesByPO[dbLine.PurchaseOrderID], dbLine)
+	}
+
+	pos := make([]purchaseorderbus.PurchaseOrder, len(dbPOs))
+	for i, dbPO := range dbPOs {
+		var err error
+		pos[i], err = toCorePurchaseOrder(dbPO, linesByPO[dbPO.ID])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pos, nil
+}
+
+func toDBDelivery(dlv purchaseorderbus.Delivery) (dbDelivery, []dbDeliveryLine) {
+	d := dbDelivery{
+		ID:              dlv.ID,
+		PurchaseOrderID: dlv.PurchaseOrderID,
+		InventoryID:     dlv.InventoryID,
+		Note: sql.NullString{
+			String: dlv.Note,
+			Valid:  dlv.Note != "",
+		},
+		ReceivedBy:   dlv.ReceivedBy,
+		DateReceived: dlv.DateReceived.UTC(),
+	}
+
+	lines := make([]dbDeliveryLine, len(dlv.Lines))
+	for i, line := range dlv.Lines {
+		lines[i] = dbDeliveryLine{
+			DeliveryID: dlv.ID,
+			MedicineID: line.MedicineID,
+			LotID:      line.LotID,
+			Quantity:   line.Quantity,
+		}
+	}
+
+	return d, lines
+}
+
+func toCoreDeliverySlice(dbDlvs []dbDelivery, dbLines []dbDeliveryLine) []purchaseorderbus.Delivery {
+	linesByDelivery := make(map[uuid.UUID][]purchaseorderbus.DeliveryLine, len(dbDlvs))
+	for _, dbLine := range dbLines {
+		linesByDelivery[dbLine.DeliveryID] = append(linesByDelivery[dbLine.DeliveryID], purchaseorderbus.DeliveryLine{
+			MedicineID: dbLine.MedicineID,
+			LotID:      dbLine.LotID,
+			LotNumber:  dbLine.LotNumber,
+			ExpiryDate: dbLine.ExpiryDate.In(time.Local),
+			Quantity:   dbLine.Quantity,
+		})
+	}
+
+	dlvs := make([]purchaseorderbus.Delivery, len(dbDlvs))
+	for i, dbDlv := range dbDlvs {
+		dlvs[i] = purchaseorderbus.Delivery{
+			ID:              dbDlv.ID,
+			PurchaseOrderID: dbDlv.PurchaseOrderID,
+			InventoryID:     dbDlv.InventoryID,
+			Note:            dbDlv.Note.String,
+			Lines:           linesByDelivery[dbDlv.ID],
+			ReceivedBy:      dbDlv.ReceivedBy,
+			DateReceived:    dbDlv.DateReceived.In(time.Local),
+		}
+	}
+
+	return dlvs
+}
+
+// =============================================================================
+
+func toNullUUID(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{
+		UUID:  id,
+		Valid: id != uuid.Nil,
+	}
+}
+
+func toNullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{
+		Time:  t.UTC(),
+		Valid: !t.IsZero(),
+	}
+}
+
+func toCoreTime(t sql.NullTime) time.Time {
+	if !t.Valid {
+		return time.Time{}
+	}
+
+	return t.Time.In(time.Local)
+}
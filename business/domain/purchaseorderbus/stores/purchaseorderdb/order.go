@@ -0,0 +1,24 @@
+package purchaseorderdb
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/purchaseorderbus"
+)
+
+var orderByFields = map[string]string{
+	purchaseorderbus.OrderByID:          "purchase_order_id",
+	purchaseorderbus.OrderByStatus:      "status",
+	purchaseorderbus.OrderByDateCreated: "date_created",
+	purchaseorderbus.OrderByDateSent:    "date_sent",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
@@ -0,0 +1,323 @@
+// Package purchaseorderdb contains purchase order related CRUD functionality.
+package purchaseorderdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb/dbarray"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/purchaseorderbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Store manages the set of APIs for purchase order database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Store value replacing the sqlx DB
+// value with a sqlx DB value that is currently inside a transaction.
+func (s *Store) ExecuteUnderTransaction(tx transaction.Transaction) (purchaseorderbus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	store := Store{
+		log: s.log,
+		db:  ec,
+	}
+
+	return &store, nil
+}
+
+// Create inserts a new purchase order and its lines into the database.
+func (s *Store) Create(ctx context.Context, po purchaseorderbus.PurchaseOrder) error {
+	const q = `
+	INSERT INTO purchase_orders
+		(purchase_order_id, supplier_id, inventory_id, status, note, created_by, approved_by, date_approved, sent_by, date_sent, date_created, date_updated)
+	VALUES
+		(:purchase_order_id, :supplier_id, :inventory_id, :status, :note, :created_by, :approved_by, :date_approved, :sent_by, :date_sent, :date_created, :date_updated)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBPurchaseOrder(po)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return s.insertLines(ctx, po)
+}
+
+// Update replaces the state of a purchase order in the database. The lines
+// are replaced as a whole.
+func (s *Store) Update(ctx context.Context, po purchaseorderbus.PurchaseOrder) error {
+	const q = `
+	UPDATE
+		purchase_orders
+	SET
+		"supplier_id" = :supplier_id,
+		"status" = :status,
+		"note" = :note,
+		"approved_by" = :approved_by,
+		"date_approved" = :date_approved,
+		"sent_by" = :sent_by,
+		"date_sent" = :date_sent,
+		"date_updated" = :date_updated
+	WHERE
+		purchase_order_id = :purchase_order_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBPurchaseOrder(po)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	const qd = `
+	DELETE FROM
+		purchase_order_lines
+	WHERE
+		purchase_order_id = :purchase_order_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, qd, toDBPurchaseOrder(po)); err != nil {
+		return fmt.Errorf("namedexeccontext: lines: %w", err)
+	}
+
+	return s.insertLines(ctx, po)
+}
+
+// Query retrieves a list of existing purchase orders from the database.
+func (s *Store) Query(ctx context.Context, filter purchaseorderbus.QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]purchaseorderbus.PurchaseOrder, error) {
+	data := map[string]interface{}{
+		"offset":        (pageNumber - 1) * rowsPerPage,
+		"rows_per_page": rowsPerPage,
+	}
+
+	const q = `
+	SELECT
+		purchase_order_id, supplier_id, inventory_id, status, note, created_by, approved_by, date_approved, sent_by, date_sent, date_created, date_updated
+	FROM
+		purchase_orders`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbPOs []dbPurchaseOrder
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbPOs); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	ids := make([]string, len(dbPOs))
+	for i, dbPO := range dbPOs {
+		ids[i] = dbPO.ID.String()
+	}
+
+	dbLines, err := s.queryLines(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCorePurchaseOrderSlice(dbPOs, dbLines)
+}
+
+// Count returns the total number of purchase orders in the database.
+func (s *Store) Count(ctx context.Context, filter purchaseorderbus.QueryFilter) (int, error) {
+	data := map[string]interface{}{}
+
+	const q = `
+	SELECT
+		count(1)
+	FROM
+		purchase_orders`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID gets the specified purchase order from the database.
+func (s *Store) QueryByID(ctx context.Context, purchaseOrderID uuid.UUID) (purchaseorderbus.PurchaseOrder, error) {
+	return s.queryByID(ctx, purchaseOrderID, "")
+}
+
+// QueryByIDForUpdate gets the specified purchase order from the database and
+// locks it until the surrounding transaction ends.
+func (s *Store) QueryByIDForUpdate(ctx context.Context, purchaseOrderID uuid.UUID) (purchaseorderbus.PurchaseOrder, error) {
+	return s.queryByID(ctx, purchaseOrderID, " FOR UPDATE")
+}
+
+// CreateDelivery inserts a received delivery and its lines into the database.
+func (s *Store) CreateDelivery(ctx context.Context, dlv purchaseorderbus.Delivery) error {
+	d, lines := toDBDelivery(dlv)
+
+	const q = `
+	INSERT INTO deliveries
+		(delivery_id, purchase_order_id, inventory_id, note, received_by, date_received)
+	VALUES
+		(:delivery_id, :purchase_order_id, :inventory_id, :note, :received_by, :date_received)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, d); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	const ql = `
+	INSERT INTO delivery_lines
+		(delivery_id, medicine_id, lot_id, quantity)
+	VALUES
+		(:delivery_id, :medicine_id, :lot_id, :quantity)`
+
+	for _, line := range lines {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, ql, line); err != nil {
+			return fmt.Errorf("namedexeccontext: line: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// QueryDeliveries gets the deliveries received against the specified
+// purchase order from the database.
+func (s *Store) QueryDeliveries(ctx context.Context, purchaseOrderID uuid.UUID) ([]purchaseorderbus.Delivery, error) {
+	data := struct {
+		ID string `db:"purchase_order_id"`
+	}{
+		ID: purchaseOrderID.String(),
+	}
+
+	const q = `
+	SELECT
+		delivery_id, purchase_order_id, inventory_id, note, received_by, date_received
+	FROM
+		deliveries
+	WHERE
+		purchase_order_id = :purchase_order_id
+	ORDER BY
+		date_received`
+
+	var dbDlvs []dbDelivery
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbDlvs); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	const ql = `
+	SELECT
+		dl.delivery_id, dl.medicine_id, dl.lot_id, l.lot_number, l.expiry_date, dl.quantity
+	FROM
+		delivery_lines AS dl
+	JOIN
+		deliveries AS d ON d.delivery_id = dl.delivery_id
+	JOIN
+		lots AS l ON l.lot_id = dl.lot_id
+	WHERE
+		d.purchase_order_id = :purchase_order_id
+	ORDER BY
+		dl.delivery_id, dl.medicine_id, l.lot_number`
+
+	var dbLines []dbDeliveryLine
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, ql, data, &dbLines); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: lines: %w", err)
+	}
+
+	return toCoreDeliverySlice(dbDlvs, dbLines), nil
+}
+
+// =============================================================================
+
+func (s *Store) queryByID(ctx context.Context, purchaseOrderID uuid.UUID, lock string) (purchaseorderbus.PurchaseOrder, error) {
+	data := struct {
+		ID string `db:"purchase_order_id"`
+	}{
+		ID: purchaseOrderID.String(),
+	}
+
+	const q = `
+	SELECT
+		purchase_order_id, supplier_id, inventory_id, status, note, created_by, approved_by, date_approved, sent_by, date_sent, date_created, date_updated
+	FROM
+		purchase_orders
+	WHERE
+		purchase_order_id = :purchase_order_id`
+
+	var dbPO dbPurchaseOrder
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q+lock, data, &dbPO); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return purchaseorderbus.PurchaseOrder{}, fmt.Errorf("db: %w", purchaseorderbus.ErrNotFound)
+		}
+		return purchaseorderbus.PurchaseOrder{}, fmt.Errorf("db: %w", err)
+	}
+
+	dbLines, err := s.queryLines(ctx, []string{dbPO.ID.String()})
+	if err != nil {
+		return purchaseorderbus.PurchaseOrder{}, err
+	}
+
+	return toCorePurchaseOrder(dbPO, dbLines)
+}
+
+func (s *Store) insertLines(ctx context.Context, po purchaseorderbus.PurchaseOrder) error {
+	const q = `
+	INSERT INTO purchase_order_lines
+		(purchase_order_id, medicine_id, quantity, received)
+	VALUES
+		(:purchase_order_id, :medicine_id, :quantity, :received)`
+
+	for _, line := range toDBLines(po) {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, line); err != nil {
+			return fmt.Errorf("namedexeccontext: line: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) queryLines(ctx context.Context, purchaseOrderIDs []string) ([]dbLine, error) {
+	data := struct {
+		ID any `db:"purchase_order_id"`
+	}{
+		ID: dbarray.Array(purchaseOrderIDs),
+	}
+
+	const q = `
+	SELECT
+		purchase_order_id, medicine_id, quantity, received
+	FROM
+		purchase_order_lines
+	WHERE
+		purchase_order_id = ANY(:purchase_order_id)
+	ORDER BY
+		purchase_order_id, medicine_id`
+
+	var dbLines []dbLine
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbLines); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: lines: %w", err)
+	}
+
+	return dbLines, nil
+}
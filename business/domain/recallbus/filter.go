@@ -0,0 +1,52 @@
+package recallbus
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryFilter holds the available fields a query can be filtered on.
+// We are using pointer semantics because the With API mutates the value.
+type QueryFilter struct {
+	ID         *uuid.UUID
+	MedicineID *uuid.UUID
+	LotID      *uuid.UUID
+	Severity   *Severity
+	Status     *Status
+}
+
+// Validate can perform a check of the data against the validate tags.
+func (qf *QueryFilter) Validate() error {
+	if err := validate.Check(qf); err != nil {
+		return fmt.Errorf("validate: %w", err)
+	}
+
+	return nil
+}
+
+// WithRecallID sets the ID field of the QueryFilter value.
+func (qf *QueryFilter) WithRecallID(recallID uuid.UUID) {
+	qf.ID = &recallID
+}
+
+// WithMedicineID sets the MedicineID field of the QueryFilter value.
+func (qf *QueryFilter) WithMedicineID(medicineID uuid.UUID) {
+	qf.MedicineID = &medicineID
+}
+
+// WithLotID sets the LotID field of the QueryFilter value.
+func (qf *QueryFilter) WithLotID(lotID uuid.UUID) {
+	qf.LotID = &lotID
+}
+
+// WithSeverity sets the Severity field of the QueryFilter value.
+func (qf *QueryFilter) WithSeverity(severity Severity) {
+	qf.Severity = &severity
+}
+
+// WithStatus sets the Status field of the QueryFilter value.
+func (qf *QueryFilter) WithStatus(status Status) {
+	qf.Status = &status
+}
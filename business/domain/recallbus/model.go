@@ -0,0 +1,95 @@
+package recallbus
+
+import (
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/google/uuid"
+)
+
+// Recall represents a manufacturer recall of one or more lots of a medicine.
+// Every lot on an open recall is quarantined so its stock can't leave an
+// inventory other than through a resolution.
+type Recall struct {
+	ID          uuid.UUID
+	MedicineID  uuid.UUID
+	LotIDs      []uuid.UUID
+	Reason      string
+	Severity    Severity
+	Status      Status
+	CreatedBy   uuid.UUID
+	ClosedBy    uuid.UUID
+	DateClosed  time.Time
+	DateCreated time.Time
+	DateUpdated time.Time
+}
+
+// NewRecall contains information needed to register a recall.
+type NewRecall struct {
+	MedicineID uuid.UUID
+	LotIDs     []uuid.UUID
+	Reason     string
+	Severity   Severity
+	UserID     uuid.UUID
+}
+
+// Resolution contains information needed to take recalled stock of a lot out
+// of an inventory. LocationID is left out for stock that is not put away.
+type Resolution struct {
+	InventoryID     uuid.UUID
+	LotID           uuid.UUID
+	LocationID      uuid.UUID
+	Method          Method
+	Quantity        int
+	Note            string
+	UserID          uuid.UUID
+	CountersignedBy uuid.UUID
+}
+
+// Stock represents the recalled stock of a lot held by an inventory.
+// Quarantined is the quantity held when the recall was registered and OnHand
+// is what is still held now.
+type Stock struct {
+	InventoryID uuid.UUID
+	LotID       uuid.UUID
+	Quarantined int
+	OnHand      int
+	Returned    int
+	Destroyed   int
+}
+
+// Hold represents stock of a lot an inventory moved into the quarantined
+// bucket because of a recall. It is what cancelling the recall releases.
+type Hold struct {
+	InventoryID uuid.UUID
+	LotID       uuid.UUID
+	Quantity    int
+}
+
+// Shipment represents recalled stock that already left an inventory by being
+// dispensed or transferred out. Reference names the record it left for, if
+// any. Destination is set when it left on an order, DestinationInventoryID
+// when it was transferred to another inventory and ReservedFor when it was
+// dispensed against a reservation. Quantity is the positive amount that left.
+type Shipment struct {
+	MovementID             uuid.UUID
+	InventoryID            uuid.UUID
+	LotID                  uuid.UUID
+	Type                   stockbus.MovementType
+	Quantity               int
+	Reason                 string
+	Reference              stockbus.Reference
+	Destination            orderbus.Destination
+	DestinationInventoryID uuid.UUID
+	ReservedFor            string
+	DateShipped            time.Time
+}
+
+// Report represents where the stock of a recall was held and where it was
+// already shipped to.
+type Report struct {
+	Recall    Recall
+	Stock     []Stock
+	Shipments []Shipment
+}
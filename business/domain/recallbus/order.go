@@ -0,0 +1,14 @@
+package recallbus
+
+import "github.com/EnesDemirtas/medisync/business/api/order"
+
+// DefaultOrderBy represents the default way we sort.
+var DefaultOrderBy = order.NewBy(OrderByDateCreated, order.DESC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID          = "recall_id"
+	OrderBySeverity    = "severity"
+	OrderByStatus      = "status"
+	OrderByDateCreated = "date_created"
+)
@@ -0,0 +1,463 @@
+// Package recallbus provides the business API for manufacturer recalls. A
+// recall names the lots of a medicine that must be pulled. Registering it
+// quarantines those lots and moves their available stock into the quarantined
+// bucket of every inventory so it can't be dispensed, transferred or
+// allocated. The recalled stock is then resolved by
+// returning it to the supplier or destroying it, and the recall closes once
+// no inventory holds any of it.
+package recallbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound          = errors.New("recall not found")
+	ErrNoLots            = errors.New("recall has no lots")
+	ErrReasonRequired    = errors.New("recall reason required")
+	ErrLotMismatch       = errors.New("lot does not belong to the recalled medicine")
+	ErrAlreadyRecalled   = errors.New("lot is already quarantined")
+	ErrInvalidTransition = errors.New("recall can't move to the requested status")
+	ErrLotNotRecalled    = errors.New("lot is not on the recall")
+	ErrInvalidQuantity   = errors.New("invalid resolution quantity")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	ExecuteUnderTransaction(tx transaction.Transaction) (Storer, error)
+	Create(ctx context.Context, rcl Recall, holds []Hold) error
+	Update(ctx context.Context, rcl Recall) error
+	AddResolution(ctx context.Context, recallID uuid.UUID, res Resolution, dateCreated time.Time) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Recall, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, recallID uuid.UUID) (Recall, error)
+	QueryByIDForUpdate(ctx context.Context, recallID uuid.UUID) (Recall, error)
+	QueryStock(ctx context.Context, recallID uuid.UUID) ([]Stock, error)
+	QueryHolds(ctx context.Context, recallID uuid.UUID) ([]Hold, error)
+	QueryShipments(ctx context.Context, recallID uuid.UUID) ([]Shipment, error)
+}
+
+// Core manages the set of APIs for recall access.
+type Core struct {
+	log           *logger.Logger
+	lotCore       *lotbus.Core
+	inventoryCore *inventorybus.Core
+	stockCore     *stockbus.Core
+	delegate      *delegate.Delegate
+	storer        Storer
+}
+
+// NewCore constructs a recall core API for use.
+func NewCore(log *logger.Logger, lotCore *lotbus.Core, inventoryCore *inventorybus.Core, stockCore *stockbus.Core, delegate *delegate.Delegate, storer Storer) *Core {
+	return &Core{
+		log:           log,
+		lotCore:       lotCore,
+		inventoryCore: inventoryCore,
+		stockCore:     stockCore,
+		delegate:      delegate,
+		storer:        storer,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Core value that will use the
+// specified transaction in any store related calls.
+func (c *Core) ExecuteUnderTransaction(tx transaction.Transaction) (*Core, error) {
+	storer, err := c.storer.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	lotCore, err := c.lotCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryCore, err := c.inventoryCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	stockCore, err := c.stockCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	core := Core{
+		log:           c.log,
+		lotCore:       lotCore,
+		inventoryCore: inventoryCore,
+		stockCore:     stockCore,
+		delegate:      c.delegate,
+		storer:        storer,
+	}
+
+	return &core, nil
+}
+
+// Create registers a recall and quarantines every lot on it. The available
+// stock of the lots is moved into the quarantined bucket and, along with the
+// quantity each inventory holds, is recorded with the recall. The caller is
+// expected to run this under a transaction so the quarantine and the recall
+// are committed together.
+func (c *Core) Create(ctx context.Context, nr NewRecall) (Recall, error) {
+	if len(nr.LotIDs) == 0 {
+		return Recall{}, ErrNoLots
+	}
+
+	if nr.Reason == "" {
+		return Recall{}, ErrReasonRequired
+	}
+
+	lotIDs := make([]uuid.UUID, 0, len(nr.LotIDs))
+	seen := make(map[uuid.UUID]bool, len(nr.LotIDs))
+	for _, lotID := range nr.LotIDs {
+		if !seen[lotID] {
+			seen[lotID] = true
+			lotIDs = append(lotIDs, lotID)
+		}
+	}
+
+	lots, err := c.lotCore.QueryByIDs(ctx, lotIDs)
+	if err != nil {
+		return Recall{}, fmt.Errorf("lot.querybyids: %w", err)
+	}
+
+	if len(lots) != len(lotIDs) {
+		return Recall{}, fmt.Errorf("lot.querybyids: %w", lotbus.ErrNotFound)
+	}
+
+	for _, lot := range lots {
+		if lot.MedicineID != nr.MedicineID {
+			return Recall{}, fmt.Errorf("%w: lot[%s]", ErrLotMismatch, lot.Number)
+		}
+
+		if lot.Quarantined {
+			return Recall{}, fmt.Errorf("%w: lot[%s]", ErrAlreadyRecalled, lot.Number)
+		}
+	}
+
+	for _, lot := range lots {
+		if _, err := c.lotCore.SetQuarantined(ctx, lot, true); err != nil {
+			return Recall{}, fmt.Errorf("lot.setquarantined: lot[%s]: %w", lot.ID, err)
+		}
+	}
+
+	now := time.Now()
+
+	rcl := Recall{
+		ID:          uuid.New(),
+		MedicineID:  nr.MedicineID,
+		LotIDs:      lotIDs,
+		Reason:      nr.Reason,
+		Severity:    nr.Severity,
+		Status:      StatusOpen,
+		CreatedBy:   nr.UserID,
+		DateCreated: now,
+		DateUpdated: now,
+	}
+
+	holds, err := c.quarantine(ctx, rcl, nr.UserID)
+	if err != nil {
+		return Recall{}, err
+	}
+
+	if err := c.storer.Create(ctx, rcl, holds); err != nil {
+		return Recall{}, fmt.Errorf("create: %w", err)
+	}
+
+	return rcl, nil
+}
+
+// Resolve takes recalled stock of a lot out of an inventory by returning it
+// to the supplier or destroying it. The recall is resolved and its lots stay
+// quarantined once no inventory holds any of the recalled stock.
+func (c *Core) Resolve(ctx context.Context, recallID uuid.UUID, res Resolution) (Recall, error) {
+	rcl, err := c.storer.QueryByIDForUpdate(ctx, recallID)
+	if err != nil {
+		return Recall{}, fmt.Errorf("query: recallID[%s]: %w", recallID, err)
+	}
+
+	if rcl.Status != StatusOpen {
+		return Recall{}, fmt.Errorf("%w: resolve %s recall", ErrInvalidTransition, rcl.Status.Name())
+	}
+
+	if !rcl.hasLot(res.LotID) {
+		return Recall{}, fmt.Errorf("%w: lot[%s]", ErrLotNotRecalled, res.LotID)
+	}
+
+	if res.Quantity <= 0 {
+		return Recall{}, ErrInvalidQuantity
+	}
+
+	typ := stockbus.TypeWriteOff
+	if res.Method == MethodReturn {
+		typ = stockbus.TypeReturn
+	}
+
+	nm := stockbus.NewMovement{
+		InventoryID:     res.InventoryID,
+		LotID:           res.LotID,
+		LocationID:      res.LocationID,
+		Type:            typ,
+		Quantity:        res.Quantity,
+		Reason:          reference(rcl, res.Method),
+		Reference:       stockbus.Reference{Type: stockbus.ReferenceRecall, ID: rcl.ID},
+		UserID:          res.UserID,
+		CountersignedBy: res.CountersignedBy,
+	}
+
+	if _, err := c.stockCore.Create(ctx, nm); err != nil {
+		return Recall{}, fmt.Errorf("stock.create: lot[%s]: %w", res.LotID, err)
+	}
+
+	now := time.Now()
+
+	if err := c.storer.AddResolution(ctx, rcl.ID, res, now); err != nil {
+		return Recall{}, fmt.Errorf("addresolution: %w", err)
+	}
+
+	stock, err := c.storer.QueryStock(ctx, rcl.ID)
+	if err != nil {
+		return Recall{}, fmt.Errorf("querystock: %w", err)
+	}
+
+	var onHand int
+	for _, s := range stock {
+		onHand += s.OnHand
+	}
+
+	rcl.DateUpdated = now
+
+	if onHand == 0 {
+		rcl.Status = StatusResolved
+		rcl.ClosedBy = res.UserID
+		rcl.DateClosed = now
+	}
+
+	if err := c.storer.Update(ctx, rcl); err != nil {
+		return Recall{}, fmt.Errorf("update: %w", err)
+	}
+
+	return rcl, nil
+}
+
+// Cancel withdraws an open recall registered in error and lifts the
+// quarantine of its lots. Only the stock the recall moved into the
+// quarantined bucket is made available again, less what was already resolved.
+// Stock quarantined for any other reason stays where it is.
+func (c *Core) Cancel(ctx context.Context, recallID uuid.UUID, userID uuid.UUID) (Recall, error) {
+	rcl, err := c.storer.QueryByIDForUpdate(ctx, recallID)
+	if err != nil {
+		return Recall{}, fmt.Errorf("query: recallID[%s]: %w", recallID, err)
+	}
+
+	if rcl.Status != StatusOpen {
+		return Recall{}, fmt.Errorf("%w: cancel %s recall", ErrInvalidTransition, rcl.Status.Name())
+	}
+
+	lots, err := c.lotCore.QueryByIDs(ctx, rcl.LotIDs)
+	if err != nil {
+		return Recall{}, fmt.Errorf("lot.querybyids: %w", err)
+	}
+
+	for _, lot := range lots {
+		if _, err := c.lotCore.SetQuarantined(ctx, lot, false); err != nil {
+			return Recall{}, fmt.Errorf("lot.setquarantined: lot[%s]: %w", lot.ID, err)
+		}
+	}
+
+	holds, err := c.storer.QueryHolds(ctx, rcl.ID)
+	if err != nil {
+		return Recall{}, fmt.Errorf("queryholds: %w", err)
+	}
+
+	if err := c.release(ctx, rcl, holds, userID); err != nil {
+		return Recall{}, err
+	}
+
+	now := time.Now()
+
+	rcl.Status = StatusCancelled
+	rcl.ClosedBy = userID
+	rcl.DateClosed = now
+	rcl.DateUpdated = now
+
+	if err := c.storer.Update(ctx, rcl); err != nil {
+		return Recall{}, fmt.Errorf("update: %w", err)
+	}
+
+	return rcl, nil
+}
+
+// Query retrieves a list of existing recalls.
+func (c *Core) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Recall, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	rcls, err := c.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return rcls, nil
+}
+
+// Count returns the total number of recalls.
+func (c *Core) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	return c.storer.Count(ctx, filter)
+}
+
+// QueryByID finds the recall by the specified ID.
+func (c *Core) QueryByID(ctx context.Context, recallID uuid.UUID) (Recall, error) {
+	rcl, err := c.storer.QueryByID(ctx, recallID)
+	if err != nil {
+		return Recall{}, fmt.Errorf("query: recallID[%s]: %w", recallID, err)
+	}
+
+	return rcl, nil
+}
+
+// QueryReport returns where the stock of the recall was held, what is still
+// held and what already left by being dispensed or transferred out.
+func (c *Core) QueryReport(ctx context.Context, rcl Recall) (Report, error) {
+	stock, err := c.storer.QueryStock(ctx, rcl.ID)
+	if err != nil {
+		return Report{}, fmt.Errorf("querystock: recallID[%s]: %w", rcl.ID, err)
+	}
+
+	shipments, err := c.storer.QueryShipments(ctx, rcl.ID)
+	if err != nil {
+		return Report{}, fmt.Errorf("queryshipments: recallID[%s]: %w", rcl.ID, err)
+	}
+
+	rpt := Report{
+		Recall:    rcl,
+		Stock:     stock,
+		Shipments: shipments,
+	}
+
+	return rpt, nil
+}
+
+// =============================================================================
+
+func (rcl Recall) hasLot(lotID uuid.UUID) bool {
+	for _, id := range rcl.LotIDs {
+		if id == lotID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// quarantine moves the available stock of the recalled lots held by every
+// inventory into the quarantined bucket and returns what it moved.
+func (c *Core) quarantine(ctx context.Context, rcl Recall, userID uuid.UUID) ([]Hold, error) {
+	var holds []Hold
+	for _, lotID := range rcl.LotIDs {
+		var filter inventorybus.BucketFilter
+		filter.WithLotID(lotID)
+
+		lbs, err := c.inventoryCore.QueryBuckets(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("inventory.querybuckets: lot[%s]: %w", lotID, err)
+		}
+
+		for _, lb := range lbs {
+			if lb.Available == 0 {
+				continue
+			}
+
+			if err := c.moveStatus(ctx, rcl, lb, inventorybus.StatusAvailable, inventorybus.StatusQuarantined, lb.Available, userID); err != nil {
+				return nil, err
+			}
+
+			holds = append(holds, Hold{
+				InventoryID: lb.InventoryID,
+				LotID:       lb.LotID,
+				Quantity:    lb.Available,
+			})
+		}
+	}
+
+	return holds, nil
+}
+
+// release moves the stock held back by the recall out of the quarantined
+// bucket and makes it available again. Stock that already left the bucket
+// some other way is not released.
+func (c *Core) release(ctx context.Context, rcl Recall, holds []Hold, userID uuid.UUID) error {
+	for _, h := range holds {
+		var filter inventorybus.BucketFilter
+		filter.WithInventoryID(h.InventoryID)
+		filter.WithLotID(h.LotID)
+
+		lbs, err := c.inventoryCore.QueryBuckets(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("inventory.querybuckets: inventory[%s] lot[%s]: %w", h.InventoryID, h.LotID, err)
+		}
+
+		for _, lb := range lbs {
+			quantity := min(h.Quantity, lb.Quarantined)
+			if quantity == 0 {
+				continue
+			}
+
+			if err := c.moveStatus(ctx, rcl, lb, inventorybus.StatusQuarantined, inventorybus.StatusAvailable, quantity, userID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// moveStatus moves stock of a lot held by an inventory between buckets on
+// behalf of the recall.
+func (c *Core) moveStatus(ctx context.Context, rcl Recall, lb inventorybus.LotBuckets, from inventorybus.Status, to inventorybus.Status, quantity int, userID uuid.UUID) error {
+	sm := inventorybus.StatusMove{
+		InventoryID: lb.InventoryID,
+		LotID:       lb.LotID,
+		From:        from,
+		To:          to,
+		Quantity:    quantity,
+		Reason:      fmt.Sprintf("recall %s", rcl.ID),
+		UserID:      userID,
+	}
+
+	if _, err := c.inventoryCore.MoveStatus(ctx, sm); err != nil {
+		return fmt.Errorf("inventory.movestatus: inventory[%s] lot[%s]: %w", lb.InventoryID, lb.LotID, err)
+	}
+
+	return nil
+}
+
+// reference returns the reason recorded on the movements resolving a recall
+// so the ledger can be traced back to it.
+func reference(rcl Recall, method Method) string {
+	if method == MethodReturn {
+		return fmt.Sprintf("recall %s: returned to supplier", rcl.ID)
+	}
+
+	return fmt.Sprintf("recall %s: destroyed", rcl.ID)
+}
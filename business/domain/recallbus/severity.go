@@ -0,0 +1,70 @@
+package recallbus
+
+import "fmt"
+
+// Set of possible severities for a recall. Class I recalls are the most
+// serious, where use of the stock can cause serious harm.
+var (
+	SeverityClassI   = Severity{"CLASS_I"}
+	SeverityClassII  = Severity{"CLASS_II"}
+	SeverityClassIII = Severity{"CLASS_III"}
+)
+
+// Set of known severities.
+var severities = map[string]Severity{
+	SeverityClassI.name:   SeverityClassI,
+	SeverityClassII.name:  SeverityClassII,
+	SeverityClassIII.name: SeverityClassIII,
+}
+
+// Severity represents how serious the hazard behind a recall is.
+type Severity struct {
+	name string
+}
+
+// ParseSeverity parses the string value and returns a severity if one exists.
+func ParseSeverity(value string) (Severity, error) {
+	severity, exists := severities[value]
+	if !exists {
+		return Severity{}, fmt.Errorf("invalid severity %q", value)
+	}
+
+	return severity, nil
+}
+
+// MustParseSeverity parses the string value and returns a severity if one
+// exists. If an error occurs the function panics.
+func MustParseSeverity(value string) Severity {
+	severity, err := ParseSeverity(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return severity
+}
+
+// Name returns the name of the severity.
+func (s Severity) Name() string {
+	return s.name
+}
+
+// UnmarshalText implement the unmarshal interface for JSON conversions.
+func (s *Severity) UnmarshalText(data []byte) error {
+	severity, err := ParseSeverity(string(data))
+	if err != nil {
+		return err
+	}
+
+	s.name = severity.name
+	return nil
+}
+
+// MarshalText implement the marshal interface for JSON conversions.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.name), nil
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (s Severity) Equal(s2 Severity) bool {
+	return s.name == s2.name
+}
@@ -0,0 +1,42 @@
+package recalldb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/EnesDemirtas/medisync/business/domain/recallbus"
+)
+
+func applyFilter(filter recallbus.QueryFilter, data map[string]interface{}, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["recall_id"] = *filter.ID
+		wc = append(wc, "recall_id = :recall_id")
+	}
+
+	if filter.MedicineID != nil {
+		data["medicine_id"] = *filter.MedicineID
+		wc = append(wc, "medicine_id = :medicine_id")
+	}
+
+	if filter.LotID != nil {
+		data["lot_id"] = *filter.LotID
+		wc = append(wc, "recall_id IN (SELECT recall_id FROM recall_lots WHERE lot_id = :lot_id)")
+	}
+
+	if filter.Severity != nil {
+		data["severity"] = filter.Severity.Name()
+		wc = append(wc, "severity = :severity")
+	}
+
+	if filter.Status != nil {
+		data["status"] = filter.Status.Name()
+		wc = append(wc, "status = :status")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
@@ -0,0 +1,265 @@
+package recalldb
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus"
+	"github.com/EnesDemirtas/medisync/business/domain/recallbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/google/uuid"
+)
+
+type dbRecall struct {
+	ID          uuid.UUID     `db:"recall_id"`
+	MedicineID  uuid.UUID     `db:"medicine_id"`
+	Reason      string        `db:"reason"`
+	Severity    string        `db:"severity"`
+	Status      string        `db:"status"`
+	CreatedBy   uuid.UUID     `db:"created_by"`
+	ClosedBy    uuid.NullUUID `db:"closed_by"`
+	DateClosed  sql.NullTime  `db:"date_closed"`
+	DateCreated time.Time     `db:"date_created"`
+	DateUpdated time.Time     `db:"date_updated"`
+}
+
+type dbRecallLot struct {
+	RecallID uuid.UUID `db:"recall_id"`
+	LotID    uuid.UUID `db:"lot_id"`
+}
+
+type dbResolution struct {
+	RecallID    uuid.UUID      `db:"recall_id"`
+	InventoryID uuid.UUID      `db:"inventory_id"`
+	LotID       uuid.UUID      `db:"lot_id"`
+	Method      string         `db:"method"`
+	Quantity    int            `db:"quantity"`
+	Note        sql.NullString `db:"note"`
+	ResolvedBy  uuid.UUID      `db:"resolved_by"`
+	DateCreated time.Time      `db:"date_created"`
+}
+
+type dbStock struct {
+	InventoryID uuid.UUID `db:"inventory_id"`
+	LotID       uuid.UUID `db:"lot_id"`
+	Quarantined int       `db:"quarantined"`
+	OnHand      int       `db:"on_hand"`
+	Returned    int       `db:"returned"`
+	Destroyed   int       `db:"destroyed"`
+}
+
+type dbHold struct {
+	RecallID    uuid.UUID `db:"recall_id"`
+	InventoryID uuid.UUID `db:"inventory_id"`
+	LotID       uuid.UUID `db:"lot_id"`
+	Held        int       `db:"held"`
+}
+
+type dbShipment struct {
+	MovementID             uuid.UUID      `db:"movement_id"`
+	InventoryID            uuid.UUID      `db:"inventory_id"`
+	LotID                  uuid.UUID      `db:"lot_id"`
+	Type                   string         `db:"type"`
+	Quantity               int            `db:"quantity"`
+	Reason                 string         `db:"reason"`
+	ReferenceType          sql.NullString `db:"reference_type"`
+	ReferenceID            uuid.NullUUID  `db:"reference_id"`
+	DestinationType        sql.NullString `db:"destination_type"`
+	Destination            sql.NullString `db:"destination"`
+	DestinationInventoryID uuid.NullUUID  `db:"destination_inventory_id"`
+	ReservedFor            sql.NullString `db:"reserved_for"`
+	DateShipped            time.Time      `db:"date_created"`
+}
+
+func toDBRecall(rcl recallbus.Recall) dbRecall {
+	return dbRecall{
+		ID:          rcl.ID,
+		MedicineID:  rcl.MedicineID,
+		Reason:      rcl.Reason,
+		Severity:    rcl.Severity.Name(),
+		Status:      rcl.Status.Name(),
+		CreatedBy:   rcl.CreatedBy,
+		ClosedBy:    toNullUUID(rcl.ClosedBy),
+		DateClosed:  toNullTime(rcl.DateClosed),
+		DateCreated: rcl.DateCreated.UTC(),
+		DateUpdated: rcl.DateUpdated.UTC(),
+	}
+}
+
+func toDBResolution(recallID uuid.UUID, res recallbus.Resolution, dateCreated time.Time) dbResolution {
+	return dbResolution{
+		RecallID:    recallID,
+		InventoryID: res.InventoryID,
+		LotID:       res.LotID,
+		Method:      res.Method.Name(),
+		Quantity:    res.Quantity,
+		Note: sql.NullString{
+			String: res.Note,
+			Valid:  res.Note != "",
+		},
+		ResolvedBy:  res.UserID,
+		DateCreated: dateCreated.UTC(),
+	}
+}
+
+func toCoreRecall(dbRcl dbRecall, dbLots []dbRecallLot) (recallbus.Recall, error) {
+	severity, err := recallbus.ParseSeverity(dbRcl.Severity)
+	if err != nil {
+		return recallbus.Recall{}, fmt.Errorf("parse severity: %w", err)
+	}
+
+	status, err := recallbus.ParseStatus(dbRcl.Status)
+	if err != nil {
+		return recallbus.Recall{}, fmt.Errorf("parse status: %w", err)
+	}
+
+	lotIDs := make([]uuid.UUID, len(dbLots))
+	for i, dbLot := range dbLots {
+		lotIDs[i] = dbLot.LotID
+	}
+
+	rcl := recallbus.Recall{
+		ID:          dbRcl.ID,
+		MedicineID:  dbRcl.MedicineID,
+		LotIDs:      lotIDs,
+		Reason:      dbRcl.Reason,
+		Severity:    severity,
+		Status:      status,
+		CreatedBy:   dbRcl.CreatedBy,
+		ClosedBy:    dbRcl.ClosedBy.UUID,
+		DateClosed:  toCoreTime(dbRcl.DateClosed),
+		DateCreated: dbRcl.DateCreated.In(time.Local),
+		DateUpdated: dbRcl.DateUpdated.In(time.Local),
+	}
+
+	return rcl, nil
+}
+
+func toCoreRecallSlice(dbRcls []dbRecall, dbLots []dbRecallLot) ([]recallbus.Recall, error) {
+	lotsByRecall := make(map[uuid.UUID][]dbRecallLot, len(dbRcls))
+	for _, dbLot := range dbLots {
+		lotsByRecall[dbLot.RecallID] = append(lotsByRecall[dbLot.RecallID], dbLot)
+	}
+
+	rcls := make([]recallbus.Recall, len(dbRcls))
+	for i, dbRcl := range dbRcls {
+		var err error
+		rcls[i], err = toCoreRecall(dbRcl, lotsByRecall[dbRcl.ID])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rcls, nil
+}
+
+func toCoreStockSlice(dbStock []dbStock) []recallbus.Stock {
+	stock := make([]recallbus.Stock, len(dbStock))
+	for i, dbS := range dbStock {
+		stock[i] = recallbus.Stock{
+			InventoryID: dbS.InventoryID,
+			LotID:       dbS.LotID,
+			Quarantined: dbS.Quarantined,
+			OnHand:      dbS.OnHand,
+			Returned:    dbS.Returned,
+			Destroyed:   dbS.Destroyed,
+		}
+	}
+
+	return stock
+}
+
+func toDBHold(recallID uuid.UUID, h recallbus.Hold) dbHold {
+	return dbHold{
+		RecallID:    recallID,
+		InventoryID: h.InventoryID,
+		LotID:       h.LotID,
+		Held:        h.Quantity,
+	}
+}
+
+func toCoreHoldSlice(dbHolds []dbHold) []recallbus.Hold {
+	holds := make([]recallbus.Hold, len(dbHolds))
+	for i, dbH := range dbHolds {
+		holds[i] = recallbus.Hold{
+			InventoryID: dbH.InventoryID,
+			LotID:       dbH.LotID,
+			Quantity:    dbH.Held,
+		}
+	}
+
+	return holds
+}
+
+func toCoreShipmentSlice(dbShps []dbShipment) ([]recallbus.Shipment, error) {
+	shps := make([]recallbus.Shipment, len(dbShps))
+	for i, dbShp := range dbShps {
+		typ, err := stockbus.ParseMovementType(dbShp.Type)
+		if err != nil {
+			return nil, fmt.Errorf("parse type: %w", err)
+		}
+
+		shps[i] = recallbus.Shipment{
+			MovementID:             dbShp.MovementID,
+			InventoryID:            dbShp.InventoryID,
+			LotID:                  dbShp.LotID,
+			Type:                   typ,
+			Quantity:               dbShp.Quantity,
+			Reason:                 dbShp.Reason,
+			DestinationInventoryID: dbShp.DestinationInventoryID.UUID,
+			ReservedFor:            dbShp.ReservedFor.String,
+			DateShipped:            dbShp.DateShipped.In(time.Local),
+		}
+
+		if dbShp.ReferenceType.Valid {
+			refType, err := stockbus.ParseReferenceType(dbShp.ReferenceType.String)
+			if err != nil {
+				return nil, fmt.Errorf("parse reference type: %w", err)
+			}
+
+			shps[i].Reference = stockbus.Reference{
+				Type: refType,
+				ID:   dbShp.ReferenceID.UUID,
+			}
+		}
+
+		if dbShp.DestinationType.Valid {
+			destType, err := orderbus.ParseDestinationType(dbShp.DestinationType.String)
+			if err != nil {
+				return nil, fmt.Errorf("parse destination type: %w", err)
+			}
+
+			shps[i].Destination = orderbus.Destination{
+				Type: destType,
+				Name: dbShp.Destination.String,
+			}
+		}
+	}
+
+	return shps, nil
+}
+
+// =============================================================================
+
+func toNullUUID(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{
+		UUID:  id,
+		Valid: id != uuid.Nil,
+	}
+}
+
+func toNullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{
+		Time:  t.UTC(),
+		Valid: !t.IsZero(),
+	}
+}
+
+func toCoreTime(t sql.NullTime) time.Time {
+	if !t.Valid {
+		return time.Time{}
+	}
+
+	return t.Time.In(time.Local)
+}
@@ -0,0 +1,24 @@
+package recalldb
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/recallbus"
+)
+
+var orderByFields = map[string]string{
+	recallbus.OrderByID:          "recall_id",
+	recallbus.OrderBySeverity:    "severity",
+	recallbus.OrderByStatus:      "status",
+	recallbus.OrderByDateCreated: "date_created",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
@@ -0,0 +1,428 @@
+// Package recalldb contains recall related CRUD functionality.
+package recalldb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb/dbarray"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/recallbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Store manages the set of APIs for recall database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Store value replacing the sqlx DB
+// value with a sqlx DB value that is currently inside a transaction.
+func (s *Store) ExecuteUnderTransaction(tx transaction.Transaction) (recallbus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	store := Store{
+		log: s.log,
+		db:  ec,
+	}
+
+	return &store, nil
+}
+
+// Create inserts a new recall and its lots into the database and records the
+// quantity of the lots every inventory holds right now, along with what the
+// recall held back from it.
+func (s *Store) Create(ctx context.Context, rcl recallbus.Recall, holds []recallbus.Hold) error {
+	const q = `
+	INSERT INTO recalls
+		(recall_id, medicine_id, reason, severity, status, created_by, closed_by, date_closed, date_created, date_updated)
+	VALUES
+		(:recall_id, :medicine_id, :reason, :severity, :status, :created_by, :closed_by, :date_closed, :date_created, :date_updated)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBRecall(rcl)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	const qLot = `
+	INSERT INTO recall_lots
+		(recall_id, lot_id)
+	VALUES
+		(:recall_id, :lot_id)`
+
+	for _, lotID := range rcl.LotIDs {
+		lot := dbRecallLot{
+			RecallID: rcl.ID,
+			LotID:    lotID,
+		}
+
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, qLot, lot); err != nil {
+			return fmt.Errorf("namedexeccontext: lot: %w", err)
+		}
+	}
+
+	data := struct {
+		ID string `db:"recall_id"`
+	}{
+		ID: rcl.ID.String(),
+	}
+
+	const qStock = `
+	INSERT INTO recall_stock
+		(recall_id, inventory_id, lot_id, quantity)
+	SELECT
+		rl.recall_id, ii.inventory_id, ii.lot_id, ii.quantity
+	FROM
+		inventory_items AS ii
+	JOIN
+		recall_lots AS rl ON rl.lot_id = ii.lot_id
+	WHERE
+		rl.recall_id = :recall_id AND ii.quantity > 0`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, qStock, data); err != nil {
+		return fmt.Errorf("namedexeccontext: stock: %w", err)
+	}
+
+	const qHold = `
+	UPDATE
+		recall_stock
+	SET
+		"held" = :held
+	WHERE
+		recall_id = :recall_id AND
+		inventory_id = :inventory_id AND
+		lot_id = :lot_id`
+
+	for _, h := range holds {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, qHold, toDBHold(rcl.ID, h)); err != nil {
+			return fmt.Errorf("namedexeccontext: hold: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Update replaces the state of a recall in the database. Lots and
+// resolutions are stored separately and are left untouched.
+func (s *Store) Update(ctx context.Context, rcl recallbus.Recall) error {
+	const q = `
+	UPDATE
+		recalls
+	SET
+		"status" = :status,
+		"closed_by" = :closed_by,
+		"date_closed" = :date_closed,
+		"date_updated" = :date_updated
+	WHERE
+		recall_id = :recall_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBRecall(rcl)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// AddResolution records recalled stock taken out of an inventory.
+func (s *Store) AddResolution(ctx context.Context, recallID uuid.UUID, res recallbus.Resolution, dateCreated time.Time) error {
+	const q = `
+	INSERT INTO recall_resolutions
+		(recall_id, inventory_id, lot_id, method, quantity, note, resolved_by, date_created)
+	VALUES
+		(:recall_id, :inventory_id, :lot_id, :method, :quantity, :note, :resolved_by, :date_created)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBResolution(recallID, res, dateCreated)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of existing recalls from the database.
+func (s *Store) Query(ctx context.Context, filter recallbus.QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]recallbus.Recall, error) {
+	data := map[string]interface{}{
+		"offset":        (pageNumber - 1) * rowsPerPage,
+		"rows_per_page": rowsPerPage,
+	}
+
+	const q = `
+	SELECT
+		recall_id, medicine_id, reason, severity, status, created_by, closed_by, date_closed, date_created, date_updated
+	FROM
+		recalls`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbRcls []dbRecall
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbRcls); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	ids := make([]string, len(dbRcls))
+	for i, dbRcl := range dbRcls {
+		ids[i] = dbRcl.ID.String()
+	}
+
+	dbLots, err := s.queryLots(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCoreRecallSlice(dbRcls, dbLots)
+}
+
+// Count returns the total number of recalls in the database.
+func (s *Store) Count(ctx context.Context, filter recallbus.QueryFilter) (int, error) {
+	data := map[string]interface{}{}
+
+	const q = `
+	SELECT
+		count(1)
+	FROM
+		recalls`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID gets the specified recall from the database.
+func (s *Store) QueryByID(ctx context.Context, recallID uuid.UUID) (recallbus.Recall, error) {
+	return s.queryByID(ctx, recallID, "")
+}
+
+// QueryByIDForUpdate gets the specified recall from the database and locks
+// it until the surrounding transaction ends.
+func (s *Store) QueryByIDForUpdate(ctx context.Context, recallID uuid.UUID) (recallbus.Recall, error) {
+	return s.queryByID(ctx, recallID, " FOR UPDATE")
+}
+
+// QueryStock returns the recalled stock of every inventory that held it when
+// the recall was registered, holds it now or resolved any of it.
+func (s *Store) QueryStock(ctx context.Context, recallID uuid.UUID) ([]recallbus.Stock, error) {
+	data := map[string]interface{}{
+		"recall_id":      recallID,
+		"method_return":  recallbus.MethodReturn.Name(),
+		"method_destroy": recallbus.MethodDestroy.Name(),
+	}
+
+	const q = `
+	WITH held AS (
+		SELECT
+			inventory_id, lot_id, quantity AS quarantined, 0 AS on_hand, 0 AS returned, 0 AS destroyed
+		FROM
+			recall_stock
+		WHERE
+			recall_id = :recall_id
+		UNION ALL
+		SELECT
+			ii.inventory_id, ii.lot_id, 0, ii.quantity, 0, 0
+		FROM
+			inventory_items AS ii
+		JOIN
+			recall_lots AS rl ON rl.lot_id = ii.lot_id
+		WHERE
+			rl.recall_id = :recall_id AND ii.quantity > 0
+		UNION ALL
+		SELECT
+			inventory_id, lot_id, 0, 0,
+			CASE WHEN method = :method_return THEN quantity ELSE 0 END,
+			CASE WHEN method = :method_destroy THEN quantity ELSE 0 END
+		FROM
+			recall_resolutions
+		WHERE
+			recall_id = :recall_id
+	)
+	SELECT
+		inventory_id, lot_id,
+		SUM(quarantined) AS quarantined,
+		SUM(on_hand) AS on_hand,
+		SUM(returned) AS returned,
+		SUM(destroyed) AS destroyed
+	FROM
+		held
+	GROUP BY
+		inventory_id, lot_id
+	ORDER BY
+		inventory_id, lot_id`
+
+	var dbStock []dbStock
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbStock); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreStockSlice(dbStock), nil
+}
+
+// QueryHolds returns the stock the recall held back in every inventory that
+// has not been resolved yet.
+func (s *Store) QueryHolds(ctx context.Context, recallID uuid.UUID) ([]recallbus.Hold, error) {
+	data := struct {
+		ID string `db:"recall_id"`
+	}{
+		ID: recallID.String(),
+	}
+
+	const q = `
+	SELECT
+		rs.recall_id, rs.inventory_id, rs.lot_id, rs.held - COALESCE(SUM(rr.quantity), 0) AS held
+	FROM
+		recall_stock AS rs
+	LEFT JOIN
+		recall_resolutions AS rr ON rr.recall_id = rs.recall_id AND rr.inventory_id = rs.inventory_id AND rr.lot_id = rs.lot_id
+	WHERE
+		rs.recall_id = :recall_id
+	GROUP BY
+		rs.recall_id, rs.inventory_id, rs.lot_id, rs.held
+	HAVING
+		rs.held - COALESCE(SUM(rr.quantity), 0) > 0
+	ORDER BY
+		rs.inventory_id, rs.lot_id`
+
+	var dbHolds []dbHold
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbHolds); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreHoldSlice(dbHolds), nil
+}
+
+// QueryShipments returns the recalled stock that left an inventory, built
+// from the dispense and transfer out movements of the recalled lots. The
+// record each movement was made for gives the order destination, the
+// receiving inventory or the holder of the reservation.
+func (s *Store) QueryShipments(ctx context.Context, recallID uuid.UUID) ([]recallbus.Shipment, error) {
+	data := map[string]interface{}{
+		"recall_id":       recallID,
+		"dispense":        stockbus.TypeDispense.Name(),
+		"transfer_out":    stockbus.TypeTransferOut.Name(),
+		"ref_order":       stockbus.ReferenceOrder.Name(),
+		"ref_transfer":    stockbus.ReferenceTransfer.Name(),
+		"ref_reservation": stockbus.ReferenceReservation.Name(),
+	}
+
+	const q = `
+	SELECT
+		m.movement_id, m.inventory_id, m.lot_id, m.type, -m.quantity AS quantity, COALESCE(m.reason, '') AS reason,
+		m.reference_type, m.reference_id,
+		o.destination_type, o.destination,
+		t.destination_inventory_id,
+		r.reference AS reserved_for,
+		m.date_created
+	FROM
+		stock_movements AS m
+	JOIN
+		recall_lots AS rl ON rl.lot_id = m.lot_id
+	LEFT JOIN
+		orders AS o ON m.reference_type = :ref_order AND o.order_id = m.reference_id
+	LEFT JOIN
+		transfers AS t ON m.reference_type = :ref_transfer AND t.transfer_id = m.reference_id
+	LEFT JOIN
+		reservations AS r ON m.reference_type = :ref_reservation AND r.reservation_id = m.reference_id
+	WHERE
+		rl.recall_id = :recall_id AND m.type IN (:dispense, :transfer_out)
+	ORDER BY
+		m.date_created, m.movement_id`
+
+	var dbShps []dbShipment
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbShps); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreShipmentSlice(dbShps)
+}
+
+// =============================================================================
+
+func (s *Store) queryByID(ctx context.Context, recallID uuid.UUID, lock string) (recallbus.Recall, error) {
+	data := struct {
+		ID string `db:"recall_id"`
+	}{
+		ID: recallID.String(),
+	}
+
+	const q = `
+	SELECT
+		recall_id, medicine_id, reason, severity, status, created_by, closed_by, date_closed, date_created, date_updated
+	FROM
+		recalls
+	WHERE
+		recall_id = :recall_id`
+
+	var dbRcl dbRecall
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q+lock, data, &dbRcl); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return recallbus.Recall{}, fmt.Errorf("db: %w", recallbus.ErrNotFound)
+		}
+		return recallbus.Recall{}, fmt.Errorf("db: %w", err)
+	}
+
+	dbLots, err := s.queryLots(ctx, []string{dbRcl.ID.String()})
+	if err != nil {
+		return recallbus.Recall{}, err
+	}
+
+	return toCoreRecall(dbRcl, dbLots)
+}
+
+// queryLots returns the lots named by the recalls.
+func (s *Store) queryLots(ctx context.Context, recallIDs []string) ([]dbRecallLot, error) {
+	data := struct {
+		ID any `db:"recall_id"`
+	}{
+		ID: dbarray.Array(recallIDs),
+	}
+
+	const q = `
+	SELECT
+		recall_id, lot_id
+	FROM
+		recall_lots
+	WHERE
+		recall_id = ANY(:recall_id)
+	ORDER BY
+		recall_id, lot_id`
+
+	var dbLots []dbRecallLot
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbLots); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: lots: %w", err)
+	}
+
+	return dbLots, nil
+}
@@ -0,0 +1,46 @@
+package reservationbus
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryFilter holds the available fields a query can be filtered on.
+// We are using pointer semantics because the With API mutates the value.
+type QueryFilter struct {
+	ID          *uuid.UUID
+	InventoryID *uuid.UUID
+	MedicineID  *uuid.UUID
+	Status      *Status
+}
+
+// Validate can perform a check of the data against the validate tags.
+func (qf *QueryFilter) Validate() error {
+	if err := validate.Check(qf); err != nil {
+		return fmt.Errorf("validate: %w", err)
+	}
+
+	return nil
+}
+
+// WithReservationID sets the ID field of the QueryFilter value.
+func (qf *QueryFilter) WithReservationID(reservationID uuid.UUID) {
+	qf.ID = &reservationID
+}
+
+// WithInventoryID sets the InventoryID field of the QueryFilter value.
+func (qf *QueryFilter) WithInventoryID(inventoryID uuid.UUID) {
+	qf.InventoryID = &inventoryID
+}
+
+// WithMedicineID sets the MedicineID field of the QueryFilter value.
+func (qf *QueryFilter) WithMedicineID(medicineID uuid.UUID) {
+	qf.MedicineID = &medicineID
+}
+
+// WithStatus sets the Status field of the QueryFilter value.
+func (qf *QueryFilter) WithStatus(status Status) {
+	qf.Status = &status
+}
@@ -0,0 +1,67 @@
+package reservationbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reservation represents a quantity of a medicine held in an inventory for a
+// pending request. A held reservation stops counting against the available
+// stock once it expires, even before the sweeper marks it as expired.
+type Reservation struct {
+	ID          uuid.UUID
+	InventoryID uuid.UUID
+	MedicineID  uuid.UUID
+	Quantity    int
+	Status      Status
+	Reference   string
+	UserID      uuid.UUID
+	DateExpires time.Time
+	DateCreated time.Time
+	DateUpdated time.Time
+}
+
+// Active reports whether the reservation still holds stock at the given time.
+func (r Reservation) Active(now time.Time) bool {
+	return r.Status == StatusHeld && now.Before(r.DateExpires)
+}
+
+// NewReservation contains information needed to reserve stock.
+type NewReservation struct {
+	InventoryID uuid.UUID
+	MedicineID  uuid.UUID
+	Quantity    int
+	TTL         time.Duration
+	Reference   string
+	UserID      uuid.UUID
+}
+
+// Consumption contains information needed to dispense against a reservation.
+// The lines say which lots are picked and must add up to the reserved
+// quantity.
+type Consumption struct {
+	Lines           []ConsumptionLine
+	UserID          uuid.UUID
+	CountersignedBy uuid.UUID
+}
+
+// ConsumptionLine is the quantity picked from a single lot.
+type ConsumptionLine struct {
+	LotID    uuid.UUID
+	Quantity int
+}
+
+// Availability represents the stock of a medicine in an inventory that is
+// free to be reserved or dispensed.
+type Availability struct {
+	InventoryID uuid.UUID
+	MedicineID  uuid.UUID
+	OnHand      int
+	Reserved    int
+}
+
+// Available returns the quantity on hand that is not held by a reservation.
+func (a Availability) Available() int {
+	return max(a.OnHand-a.Reserved, 0)
+}
@@ -0,0 +1,14 @@
+package reservationbus
+
+import "github.com/EnesDemirtas/medisync/business/api/order"
+
+// DefaultOrderBy represents the default way we sort.
+var DefaultOrderBy = order.NewBy(OrderByDateCreated, order.ASC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID          = "reservation_id"
+	OrderByStatus      = "status"
+	OrderByDateExpires = "date_expires"
+	OrderByDateCreated = "date_created"
+)
@@ -0,0 +1,316 @@
+// Package reservationbus provides the business API for holding stock for
+// pending requests before it is physically picked. A reservation holds a
+// quantity of a medicine in an inventory until it is consumed by dispensing
+// against it, released, or it expires and is swept.
+package reservationbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound         = errors.New("reservation not found")
+	ErrInvalidQuantity  = errors.New("invalid reservation quantity")
+	ErrInvalidTTL       = errors.New("reservation ttl must be positive")
+	ErrUnavailable      = errors.New("not enough stock available to reserve")
+	ErrNotHeld          = errors.New("reservation is no longer held")
+	ErrNoLines          = errors.New("consumption has no lines")
+	ErrQuantityMismatch = errors.New("picked quantity does not match the reservation")
+	ErrLotMismatch      = errors.New("lot does not belong to the reserved medicine")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	ExecuteUnderTransaction(tx transaction.Transaction) (Storer, error)
+	Create(ctx context.Context, res Reservation) error
+	Update(ctx context.Context, res Reservation) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Reservation, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, reservationID uuid.UUID) (Reservation, error)
+	QueryByIDForUpdate(ctx context.Context, reservationID uuid.UUID) (Reservation, error)
+	QueryAvailability(ctx context.Context, inventoryID uuid.UUID, now time.Time) ([]Availability, error)
+	QueryAvailabilityForUpdate(ctx context.Context, inventoryID uuid.UUID, medicineID uuid.UUID, now time.Time) (Availability, error)
+	Expire(ctx context.Context, now time.Time) (int, error)
+}
+
+// Core manages the set of APIs for reservation access.
+type Core struct {
+	log          *logger.Logger
+	medicineCore *medicinebus.Core
+	stockCore    *stockbus.Core
+	delegate     *delegate.Delegate
+	storer       Storer
+}
+
+// NewCore constructs a reservation core API for use.
+func NewCore(log *logger.Logger, medicineCore *medicinebus.Core, stockCore *stockbus.Core, delegate *delegate.Delegate, storer Storer) *Core {
+	return &Core{
+		log:          log,
+		medicineCore: medicineCore,
+		stockCore:    stockCore,
+		delegate:     delegate,
+		storer:       storer,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Core value that will use the
+// specified transaction in any store related calls.
+func (c *Core) ExecuteUnderTransaction(tx transaction.Transaction) (*Core, error) {
+	storer, err := c.storer.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	medicineCore, err := c.medicineCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	stockCore, err := c.stockCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	core := Core{
+		log:          c.log,
+		medicineCore: medicineCore,
+		stockCore:    stockCore,
+		delegate:     c.delegate,
+		storer:       storer,
+	}
+
+	return &core, nil
+}
+
+// Reserve holds the quantity of the medicine in the inventory until the TTL
+// runs out. The stock of the medicine is locked while the available quantity
+// is checked, so the caller is expected to run this under a transaction.
+func (c *Core) Reserve(ctx context.Context, nr NewReservation) (Reservation, error) {
+	if nr.Quantity <= 0 {
+		return Reservation{}, ErrInvalidQuantity
+	}
+
+	if nr.TTL <= 0 {
+		return Reservation{}, ErrInvalidTTL
+	}
+
+	if _, err := c.medicineCore.QueryByID(ctx, nr.MedicineID); err != nil {
+		return Reservation{}, fmt.Errorf("medicine.querybyid: %s: %w", nr.MedicineID, err)
+	}
+
+	now := time.Now()
+
+	av, err := c.storer.QueryAvailabilityForUpdate(ctx, nr.InventoryID, nr.MedicineID, now)
+	if err != nil {
+		return Reservation{}, fmt.Errorf("queryavailabilityforupdate: %w", err)
+	}
+
+	if av.Available() < nr.Quantity {
+		return Reservation{}, fmt.Errorf("%w: requested[%d] available[%d]", ErrUnavailable, nr.Quantity, av.Available())
+	}
+
+	res := Reservation{
+		ID:          uuid.New(),
+		InventoryID: nr.InventoryID,
+		MedicineID:  nr.MedicineID,
+		Quantity:    nr.Quantity,
+		Status:      StatusHeld,
+		Reference:   nr.Reference,
+		UserID:      nr.UserID,
+		DateExpires: now.Add(nr.TTL),
+		DateCreated: now,
+		DateUpdated: now,
+	}
+
+	if err := c.storer.Create(ctx, res); err != nil {
+		return Reservation{}, fmt.Errorf("create: %w", err)
+	}
+
+	return res, nil
+}
+
+// Consume dispenses the picked lots against the reservation and marks it as
+// consumed. The caller is expected to run this under a transaction so the
+// dispensing movements and the reservation are committed together.
+func (c *Core) Consume(ctx context.Context, reservationID uuid.UUID, cons Consumption) (Reservation, error) {
+	res, err := c.storer.QueryByIDForUpdate(ctx, reservationID)
+	if err != nil {
+		return Reservation{}, fmt.Errorf("query: reservationID[%s]: %w", reservationID, err)
+	}
+
+	now := time.Now()
+
+	if !res.Active(now) {
+		return Reservation{}, ErrNotHeld
+	}
+
+	if len(cons.Lines) == 0 {
+		return Reservation{}, ErrNoLines
+	}
+
+	var picked int
+	for _, line := range cons.Lines {
+		if line.Quantity <= 0 {
+			return Reservation{}, fmt.Errorf("%w: lot[%s] quantity[%d]", ErrInvalidQuantity, line.LotID, line.Quantity)
+		}
+		picked += line.Quantity
+	}
+
+	if picked != res.Quantity {
+		return Reservation{}, fmt.Errorf("%w: picked[%d] reserved[%d]", ErrQuantityMismatch, picked, res.Quantity)
+	}
+
+	for _, line := range cons.Lines {
+		nm := stockbus.NewMovement{
+			InventoryID:     res.InventoryID,
+			LotID:           line.LotID,
+			Type:            stockbus.TypeDispense,
+			Quantity:        line.Quantity,
+			Reason:          reference(res.ID),
+			Reference:       stockbus.Reference{Type: stockbus.ReferenceReservation, ID: res.ID},
+			UserID:          cons.UserID,
+			CountersignedBy: cons.CountersignedBy,
+			ReservationID:   res.ID,
+		}
+
+		mov, err := c.stockCore.Create(ctx, nm)
+		if err != nil {
+			return Reservation{}, fmt.Errorf("stock.create: lot[%s]: %w", line.LotID, err)
+		}
+
+		if mov.MedicineID != res.MedicineID {
+			return Reservation{}, fmt.Errorf("%w: lot[%s]", ErrLotMismatch, line.LotID)
+		}
+	}
+
+	res.Status = StatusConsumed
+	res.DateUpdated = now
+
+	if err := c.storer.Update(ctx, res); err != nil {
+		return Reservation{}, fmt.Errorf("update: %w", err)
+	}
+
+	return res, nil
+}
+
+// Release gives the held stock back before the reservation expires.
+func (c *Core) Release(ctx context.Context, reservationID uuid.UUID) (Reservation, error) {
+	res, err := c.storer.QueryByIDForUpdate(ctx, reservationID)
+	if err != nil {
+		return Reservation{}, fmt.Errorf("query: reservationID[%s]: %w", reservationID, err)
+	}
+
+	now := time.Now()
+
+	if !res.Active(now) {
+		return Reservation{}, ErrNotHeld
+	}
+
+	res.Status = StatusReleased
+	res.DateUpdated = now
+
+	if err := c.storer.Update(ctx, res); err != nil {
+		return Reservation{}, fmt.Errorf("update: %w", err)
+	}
+
+	return res, nil
+}
+
+// Sweep marks every held reservation that expired by now as expired and
+// returns how many were released.
+func (c *Core) Sweep(ctx context.Context, now time.Time) (int, error) {
+	n, err := c.storer.Expire(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("expire: %w", err)
+	}
+
+	return n, nil
+}
+
+// Watch sweeps the expired reservations every interval until the context is
+// cancelled. The first sweep runs straight away.
+func (c *Core) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := c.Sweep(ctx, time.Now())
+		switch {
+		case err != nil:
+			c.log.Error(ctx, "reservation sweeper", "status", "sweep failed", "msg", err)
+		case n > 0:
+			c.log.Info(ctx, "reservation sweeper", "status", "sweep complete", "expired", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// QueryAvailability returns the on hand, reserved and available quantity of
+// every medicine held by the inventory.
+func (c *Core) QueryAvailability(ctx context.Context, inventoryID uuid.UUID) ([]Availability, error) {
+	avs, err := c.storer.QueryAvailability(ctx, inventoryID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("queryavailability: inventoryID[%s]: %w", inventoryID, err)
+	}
+
+	return avs, nil
+}
+
+// Query retrieves a list of existing reservations.
+func (c *Core) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Reservation, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	rss, err := c.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return rss, nil
+}
+
+// Count returns the total number of reservations.
+func (c *Core) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	return c.storer.Count(ctx, filter)
+}
+
+// QueryByID finds the reservation by the specified ID.
+func (c *Core) QueryByID(ctx context.Context, reservationID uuid.UUID) (Reservation, error) {
+	res, err := c.storer.QueryByID(ctx, reservationID)
+	if err != nil {
+		return Reservation{}, fmt.Errorf("query: reservationID[%s]: %w", reservationID, err)
+	}
+
+	return res, nil
+}
+
+// =============================================================================
+
+// reference returns the reason recorded on the stock movements dispensed
+// against a reservation so the ledger can be traced back to it.
+func reference(reservationID uuid.UUID) string {
+	return "reservation " + reservationID.String()
+}
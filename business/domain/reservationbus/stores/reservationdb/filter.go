@@ -0,0 +1,37 @@
+package reservationdb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+)
+
+func applyFilter(filter reservationbus.QueryFilter, data map[string]interface{}, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["reservation_id"] = *filter.ID
+		wc = append(wc, "reservation_id = :reservation_id")
+	}
+
+	if filter.InventoryID != nil {
+		data["inventory_id"] = *filter.InventoryID
+		wc = append(wc, "inventory_id = :inventory_id")
+	}
+
+	if filter.MedicineID != nil {
+		data["medicine_id"] = *filter.MedicineID
+		wc = append(wc, "medicine_id = :medicine_id")
+	}
+
+	if filter.Status != nil {
+		data["status"] = filter.Status.Name()
+		wc = append(wc, "status = :status")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
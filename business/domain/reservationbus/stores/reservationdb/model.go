@@ -0,0 +1,103 @@
+package reservationdb
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+	"github.com/google/uuid"
+)
+
+type dbReservation struct {
+	ID          uuid.UUID      `db:"reservation_id"`
+	InventoryID uuid.UUID      `db:"inventory_id"`
+	MedicineID  uuid.UUID      `db:"medicine_id"`
+	Quantity    int            `db:"quantity"`
+	Status      string         `db:"status"`
+	Reference   sql.NullString `db:"reference"`
+	UserID      uuid.UUID      `db:"user_id"`
+	DateExpires time.Time      `db:"date_expires"`
+	DateCreated time.Time      `db:"date_created"`
+	DateUpdated time.Time      `db:"date_updated"`
+}
+
+func toDBReservation(res reservationbus.Reservation) dbReservation {
+	return dbReservation{
+		ID:          res.ID,
+		InventoryID: res.InventoryID,
+		MedicineID:  res.MedicineID,
+		Quantity:    res.Quantity,
+		Status:      res.Status.Name(),
+		Reference: sql.NullString{
+			String: res.Reference,
+			Valid:  res.Reference != "",
+		},
+		UserID:      res.UserID,
+		DateExpires: res.DateExpires.UTC(),
+		DateCreated: res.DateCreated.UTC(),
+		DateUpdated: res.DateUpdated.UTC(),
+	}
+}
+
+func toCoreReservation(db dbReservation) (reservationbus.Reservation, error) {
+	status, err := reservationbus.ParseStatus(db.Status)
+	if err != nil {
+		return reservationbus.Reservation{}, fmt.Errorf("parse status: %w", err)
+	}
+
+	res := reservationbus.Reservation{
+		ID:          db.ID,
+		InventoryID: db.InventoryID,
+		MedicineID:  db.MedicineID,
+		Quantity:    db.Quantity,
+		Status:      status,
+		Reference:   db.Reference.String,
+		UserID:      db.UserID,
+		DateExpires: db.DateExpires.In(time.Local),
+		DateCreated: db.DateCreated.In(time.Local),
+		DateUpdated: db.DateUpdated.In(time.Local),
+	}
+
+	return res, nil
+}
+
+func toCoreReservationSlice(dbRss []dbReservation) ([]reservationbus.Reservation, error) {
+	rss := make([]reservationbus.Reservation, len(dbRss))
+	for i, db := range dbRss {
+		res, err := toCoreReservation(db)
+		if err != nil {
+			return nil, err
+		}
+		rss[i] = res
+	}
+
+	return rss, nil
+}
+
+// =============================================================================
+
+type dbAvailability struct {
+	InventoryID uuid.UUID `db:"inventory_id"`
+	MedicineID  uuid.UUID `db:"medicine_id"`
+	OnHand      int       `db:"on_hand"`
+	Reserved    int       `db:"reserved"`
+}
+
+func toCoreAvailability(db dbAvailability) reservationbus.Availability {
+	return reservationbus.Availability{
+		InventoryID: db.InventoryID,
+		MedicineID:  db.MedicineID,
+		OnHand:      db.OnHand,
+		Reserved:    db.Reserved,
+	}
+}
+
+func toCoreAvailabilitySlice(dbAvs []dbAvailability) []reservationbus.Availability {
+	avs := make([]reservationbus.Availability, len(dbAvs))
+	for i, db := range dbAvs {
+		avs[i] = toCoreAvailability(db)
+	}
+
+	return avs
+}
@@ -0,0 +1,24 @@
+package reservationdb
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+)
+
+var orderByFields = map[string]string{
+	reservationbus.OrderByID:          "reservation_id",
+	reservationbus.OrderByStatus:      "status",
+	reservationbus.OrderByDateExpires: "date_expires",
+	reservationbus.OrderByDateCreated: "date_created",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
@@ -0,0 +1,315 @@
+// Package reservationdb contains reservation related CRUD functionality.
+package reservationdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// availabilityQuery returns the quantity on hand of every medicine held by
+// an inventory together with the quantity held by active reservations.
+// Only the available bucket is counted as on hand, quarantined, damaged and
+// expired stock can not be reserved.
+const availabilityQuery = `
+	SELECT
+		it.inventory_id, it.medicine_id, SUM(it.quantity - it.quarantined - it.damaged - it.expired) AS on_hand,
+		COALESCE((
+			SELECT
+				SUM(r.quantity)
+			FROM
+				reservations r
+			WHERE
+				r.inventory_id = it.inventory_id AND
+				r.medicine_id = it.medicine_id AND
+				r.status = :status AND
+				r.date_expires > :now
+		), 0) AS reserved
+	FROM
+		inventory_items it
+	WHERE
+		it.inventory_id = :inventory_id`
+
+// Store manages the set of APIs for reservation database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Store value replacing the sqlx DB
+// value with a sqlx DB value that is currently inside a transaction.
+func (s *Store) ExecuteUnderTransaction(tx transaction.Transaction) (reservationbus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	store := Store{
+		log: s.log,
+		db:  ec,
+	}
+
+	return &store, nil
+}
+
+// Create inserts a new reservation into the database.
+func (s *Store) Create(ctx context.Context, res reservationbus.Reservation) error {
+	const q = `
+	INSERT INTO reservations
+		(reservation_id, inventory_id, medicine_id, quantity, status, reference, user_id, date_expires, date_created, date_updated)
+	VALUES
+		(:reservation_id, :inventory_id, :medicine_id, :quantity, :status, :reference, :user_id, :date_expires, :date_created, :date_updated)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBReservation(res)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Update replaces the state of a reservation in the database.
+func (s *Store) Update(ctx context.Context, res reservationbus.Reservation) error {
+	const q = `
+	UPDATE
+		reservations
+	SET
+		"status" = :status,
+		"date_updated" = :date_updated
+	WHERE
+		reservation_id = :reservation_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBReservation(res)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Expire marks every held reservation that expired by now as expired and
+// returns how many rows were changed.
+func (s *Store) Expire(ctx context.Context, now time.Time) (int, error) {
+	data := struct {
+		Held    string    `db:"held"`
+		Expired string    `db:"expired"`
+		Now     time.Time `db:"now"`
+	}{
+		Held:    reservationbus.StatusHeld.Name(),
+		Expired: reservationbus.StatusExpired.Name(),
+		Now:     now.UTC(),
+	}
+
+	const q = `
+	WITH expired AS (
+		UPDATE
+			reservations
+		SET
+			"status" = :expired,
+			"date_updated" = :now
+		WHERE
+			status = :held AND
+			date_expires <= :now
+		RETURNING
+			reservation_id
+	)
+	SELECT
+		count(1)
+	FROM
+		expired`
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// Query retrieves a list of existing reservations from the database.
+func (s *Store) Query(ctx context.Context, filter reservationbus.QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]reservationbus.Reservation, error) {
+	data := map[string]interface{}{
+		"offset":        (pageNumber - 1) * rowsPerPage,
+		"rows_per_page": rowsPerPage,
+	}
+
+	const q = `
+	SELECT
+		reservation_id, inventory_id, medicine_id, quantity, status, reference, user_id, date_expires, date_created, date_updated
+	FROM
+		reservations`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbRss []dbReservation
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbRss); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreReservationSlice(dbRss)
+}
+
+// Count returns the total number of reservations in the database.
+func (s *Store) Count(ctx context.Context, filter reservationbus.QueryFilter) (int, error) {
+	data := map[string]interface{}{}
+
+	const q = `
+	SELECT
+		count(1)
+	FROM
+		reservations`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID gets the specified reservation from the database.
+func (s *Store) QueryByID(ctx context.Context, reservationID uuid.UUID) (reservationbus.Reservation, error) {
+	return s.queryByID(ctx, reservationID, "")
+}
+
+// QueryByIDForUpdate gets the specified reservation from the database and
+// locks it until the surrounding transaction ends.
+func (s *Store) QueryByIDForUpdate(ctx context.Context, reservationID uuid.UUID) (reservationbus.Reservation, error) {
+	return s.queryByID(ctx, reservationID, " FOR UPDATE")
+}
+
+func (s *Store) queryByID(ctx context.Context, reservationID uuid.UUID, lock string) (reservationbus.Reservation, error) {
+	data := struct {
+		ID string `db:"reservation_id"`
+	}{
+		ID: reservationID.String(),
+	}
+
+	const q = `
+	SELECT
+		reservation_id, inventory_id, medicine_id, quantity, status, reference, user_id, date_expires, date_created, date_updated
+	FROM
+		reservations
+	WHERE
+		reservation_id = :reservation_id`
+
+	var dbRes dbReservation
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q+lock, data, &dbRes); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return reservationbus.Reservation{}, fmt.Errorf("db: %w", reservationbus.ErrNotFound)
+		}
+		return reservationbus.Reservation{}, fmt.Errorf("db: %w", err)
+	}
+
+	return toCoreReservation(dbRes)
+}
+
+// QueryAvailability gets the availability of every medicine held by the
+// inventory.
+func (s *Store) QueryAvailability(ctx context.Context, inventoryID uuid.UUID, now time.Time) ([]reservationbus.Availability, error) {
+	data := struct {
+		InventoryID string    `db:"inventory_id"`
+		Status      string    `db:"status"`
+		Now         time.Time `db:"now"`
+	}{
+		InventoryID: inventoryID.String(),
+		Status:      reservationbus.StatusHeld.Name(),
+		Now:         now.UTC(),
+	}
+
+	const q = availabilityQuery + `
+	GROUP BY
+		it.inventory_id, it.medicine_id
+	ORDER BY
+		it.medicine_id`
+
+	var dbAvs []dbAvailability
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbAvs); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreAvailabilitySlice(dbAvs), nil
+}
+
+// QueryAvailabilityForUpdate gets the availability of a medicine held by the
+// inventory and locks its stock until the surrounding transaction ends, which
+// serializes reservations and movements of the medicine. The lock is taken
+// first in its own statement so the reservations summed afterwards include
+// any committed while waiting for it.
+func (s *Store) QueryAvailabilityForUpdate(ctx context.Context, inventoryID uuid.UUID, medicineID uuid.UUID, now time.Time) (reservationbus.Availability, error) {
+	data := struct {
+		InventoryID string    `db:"inventory_id"`
+		MedicineID  string    `db:"medicine_id"`
+		Status      string    `db:"status"`
+		Now         time.Time `db:"now"`
+	}{
+		InventoryID: inventoryID.String(),
+		MedicineID:  medicineID.String(),
+		Status:      reservationbus.StatusHeld.Name(),
+		Now:         now.UTC(),
+	}
+
+	const ql = `
+	SELECT
+		lot_id
+	FROM
+		inventory_items
+	WHERE
+		inventory_id = :inventory_id AND
+		medicine_id = :medicine_id
+	FOR UPDATE`
+
+	var locked []struct {
+		LotID uuid.UUID `db:"lot_id"`
+	}
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, ql, data, &locked); err != nil {
+		return reservationbus.Availability{}, fmt.Errorf("namedqueryslice: lock: %w", err)
+	}
+
+	const q = availabilityQuery + ` AND
+		it.medicine_id = :medicine_id
+	GROUP BY
+		it.inventory_id, it.medicine_id`
+
+	var dbAv dbAvailability
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbAv); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return reservationbus.Availability{InventoryID: inventoryID, MedicineID: medicineID}, nil
+		}
+		return reservationbus.Availability{}, fmt.Errorf("db: %w", err)
+	}
+
+	return toCoreAvailability(dbAv), nil
+}
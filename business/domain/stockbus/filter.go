@@ -0,0 +1,105 @@
+package stockbus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryFilter holds the available fields a query can be filtered on.
+// We are using pointer semantics because the With API mutates the value.
+type QueryFilter struct {
+	ID               *uuid.UUID
+	InventoryID      *uuid.UUID
+	MedicineID       *uuid.UUID
+	LotID            *uuid.UUID
+	Type             *MovementType
+	UserID           *uuid.UUID
+	StartCreatedDate *time.Time
+	EndCreatedDate   *time.Time
+}
+
+// Validate can perform a check of the data against the validate tags.
+func (qf *QueryFilter) Validate() error {
+	if err := validate.Check(qf); err != nil {
+		return fmt.Errorf("validate: %w", err)
+	}
+
+	return nil
+}
+
+// WithMovementID sets the ID field of the QueryFilter value.
+func (qf *QueryFilter) WithMovementID(movementID uuid.UUID) {
+	qf.ID = &movementID
+}
+
+// WithInventoryID sets the InventoryID field of the QueryFilter value.
+func (qf *QueryFilter) WithInventoryID(inventoryID uuid.UUID) {
+	qf.InventoryID = &inventoryID
+}
+
+// WithMedicineID sets the MedicineID field of the QueryFilter value.
+func (qf *QueryFilter) WithMedicineID(medicineID uuid.UUID) {
+	qf.MedicineID = &medicineID
+}
+
+// WithLotID sets the LotID field of the QueryFilter value.
+func (qf *QueryFilter) WithLotID(lotID uuid.UUID) {
+	qf.LotID = &lotID
+}
+
+// WithType sets the Type field of the QueryFilter value.
+func (qf *QueryFilter) WithType(typ MovementType) {
+	qf.Type = &typ
+}
+
+// WithUserID sets the UserID field of the QueryFilter value.
+func (qf *QueryFilter) WithUserID(userID uuid.UUID) {
+	qf.UserID = &userID
+}
+
+// WithStartCreatedDate sets the StartCreatedDate field of the QueryFilter value.
+func (qf *QueryFilter) WithStartCreatedDate(startDate time.Time) {
+	d := startDate.UTC()
+	qf.StartCreatedDate = &d
+}
+
+// WithEndCreatedDate sets the EndCreatedDate field of the QueryFilter value.
+func (qf *QueryFilter) WithEndCreatedDate(endDate time.Time) {
+	d := endDate.UTC()
+	qf.EndCreatedDate = &d
+}
+
+// HoldingFilter holds the available fields the historical holdings query can
+// be filtered on.
+type HoldingFilter struct {
+	InventoryID *uuid.UUID
+	MedicineID  *uuid.UUID
+	LotID       *uuid.UUID
+}
+
+// WithInventoryID sets the InventoryID field of the HoldingFilter value.
+func (hf *HoldingFilter) WithInventoryID(inventoryID uuid.UUID) {
+	hf.InventoryID = &inventoryID
+}
+
+// WithMedicineID sets the MedicineID field of the HoldingFilter value.
+func (hf *HoldingFilter) WithMedicineID(medicineID uuid.UUID) {
+	hf.MedicineID = &medicineID
+}
+
+// WithLotID sets the LotID field of the HoldingFilter value.
+func (hf *HoldingFilter) WithLotID(lotID uuid.UUID) {
+	hf.LotID = &lotID
+}
+
+// RegisterFilter holds the medicine, inventory and period a register is
+// queried for. Every field is required.
+type RegisterFilter struct {
+	InventoryID uuid.UUID
+	MedicineID  uuid.UUID
+	StartDate   time.Time
+	EndDate     time.Time
+}
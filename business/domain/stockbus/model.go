@@ -0,0 +1,124 @@
+package stockbus
+
+import (
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/google/uuid"
+)
+
+// Movement represents a single immutable change to the stock of a medicine
+// lot held by an inventory. LocationID is the bin the stock went in or out
+// of, it is the zero value for unplaced stock. CountersignedBy is the second
+// user who signed off the movement, it is the zero value when nobody did.
+// Reference names the record the movement was made for, if any.
+type Movement struct {
+	ID              uuid.UUID
+	InventoryID     uuid.UUID
+	MedicineID      uuid.UUID
+	LotID           uuid.UUID
+	LocationID      uuid.UUID
+	Type            MovementType
+	Quantity        int
+	Balance         int
+	Reason          string
+	UserID          uuid.UUID
+	CountersignedBy uuid.UUID
+	Reference       Reference
+	DateCreated     time.Time
+}
+
+// NewMovement contains information needed to record a new stock movement.
+// Quantity is the amount moved. It must be positive for every type except
+// adjustments, where the sign gives the direction of the correction.
+// LocationID optionally names the bin of the inventory the stock goes in or
+// out of. Status optionally names the bucket it goes in or out of, it
+// defaults to available or to quarantined for a quarantined lot.
+// CountersignedBy is required for medicines under a controlled substance
+// schedule and must name a user other than the one recording the movement.
+// ReservationID is set when the movement dispenses against a reservation, the
+// stock held by it is then free to be taken.
+// Reference optionally names the record the movement is made for, such as
+// the order it ships or the transfer it dispatches.
+type NewMovement struct {
+	InventoryID     uuid.UUID
+	LotID           uuid.UUID
+	LocationID      uuid.UUID
+	Status          inventorybus.Status
+	Type            MovementType
+	Quantity        int
+	Reason          string
+	UserID          uuid.UUID
+	CountersignedBy uuid.UUID
+	ReservationID   uuid.UUID
+	Reference       Reference
+}
+
+// Reference names the record a movement was made for.
+type Reference struct {
+	Type ReferenceType
+	ID   uuid.UUID
+}
+
+// Availability represents the stock of a medicine in an inventory that is
+// free to be dispensed or transferred out.
+type Availability struct {
+	InventoryID uuid.UUID
+	MedicineID  uuid.UUID
+	OnHand      int
+	Reserved    int
+}
+
+// Available returns the quantity on hand that is not held by a reservation.
+func (a Availability) Available() int {
+	return max(a.OnHand-a.Reserved, 0)
+}
+
+// Holding represents the quantity of a lot an inventory held at a point in
+// time, rebuilt from the ledger.
+type Holding struct {
+	InventoryID uuid.UUID
+	MedicineID  uuid.UUID
+	LotID       uuid.UUID
+	Quantity    int
+}
+
+// Snapshot represents the quantities every inventory held at the time it was
+// taken. Historical queries start from the latest snapshot before the time
+// asked for and only replay the movements recorded after it. Lots is the
+// number of lots that were held.
+type Snapshot struct {
+	ID        uuid.UUID
+	DateTaken time.Time
+	Lots      int
+}
+
+// RegisterEntry represents a movement of the register along with the balance
+// of the medicine held by the inventory right after it.
+type RegisterEntry struct {
+	MovementID      uuid.UUID
+	LotID           uuid.UUID
+	Type            MovementType
+	Quantity        int
+	Balance         int
+	Reason          string
+	UserID          uuid.UUID
+	CountersignedBy uuid.UUID
+	DateCreated     time.Time
+}
+
+// Register represents every movement of a medicine held by an inventory over
+// a period with the running balance after each of them. OpeningBalance is
+// what the inventory held when the period started and ClosingBalance what it
+// held when it ended.
+type Register struct {
+	InventoryID    uuid.UUID
+	MedicineID     uuid.UUID
+	Schedule       medicinebus.Schedule
+	StartDate      time.Time
+	EndDate        time.Time
+	OpeningBalance int
+	ClosingBalance int
+	Entries        []RegisterEntry
+}
@@ -0,0 +1,81 @@
+package stockbus
+
+import "fmt"
+
+// Set of possible types for a stock movement.
+var (
+	TypeReceive  = MovementType{"RECEIVE"}
+	TypeDispense = MovementType{"DISPENSE"}
+	TypeAdjust   = MovementType{"ADJUST"}
+	TypeWriteOff = MovementType{"WRITE_OFF"}
+	TypeReturn   = MovementType{"RETURN"}
+
+	// Transfer movements are only recorded by the transfer domain.
+	TypeTransferOut = MovementType{"TRANSFER_OUT"}
+	TypeTransferIn  = MovementType{"TRANSFER_IN"}
+)
+
+// Set of known movement types.
+var movementTypes = map[string]MovementType{
+	TypeReceive.name:  TypeReceive,
+	TypeDispense.name: TypeDispense,
+	TypeAdjust.name:   TypeAdjust,
+	TypeWriteOff.name: TypeWriteOff,
+	TypeReturn.name:   TypeReturn,
+
+	TypeTransferOut.name: TypeTransferOut,
+	TypeTransferIn.name:  TypeTransferIn,
+}
+
+// MovementType represents the kind of change a stock movement applies.
+type MovementType struct {
+	name string
+}
+
+// ParseMovementType parses the string value and returns a movement type if
+// one exists.
+func ParseMovementType(value string) (MovementType, error) {
+	typ, exists := movementTypes[value]
+	if !exists {
+		return MovementType{}, fmt.Errorf("invalid movement type %q", value)
+	}
+
+	return typ, nil
+}
+
+// MustParseMovementType parses the string value and returns a movement type
+// if one exists. If an error occurs the function panics.
+func MustParseMovementType(value string) MovementType {
+	typ, err := ParseMovementType(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return typ
+}
+
+// Name returns the name of the movement type.
+func (t MovementType) Name() string {
+	return t.name
+}
+
+// UnmarshalText implement the unmarshal interface for JSON conversions.
+func (t *MovementType) UnmarshalText(data []byte) error {
+	typ, err := ParseMovementType(string(data))
+	if err != nil {
+		return err
+	}
+
+	t.name = typ.name
+	return nil
+}
+
+// MarshalText implement the marshal interface for JSON conversions.
+func (t MovementType) MarshalText() ([]byte, error) {
+	return []byte(t.name), nil
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (t MovementType) Equal(t2 MovementType) bool {
+	return t.name == t2.name
+}
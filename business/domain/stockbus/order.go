@@ -0,0 +1,15 @@
+package stockbus
+
+import "github.com/EnesDemirtas/medisync/business/api/order"
+
+// DefaultOrderBy represents the default way we sort.
+var DefaultOrderBy = order.NewBy(OrderByDateCreated, order.ASC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID          = "movement_id"
+	OrderByMedicineID  = "medicine_id"
+	OrderByType        = "type"
+	OrderByQuantity    = "quantity"
+	OrderByDateCreated = "date_created"
+)
@@ -0,0 +1,78 @@
+package stockbus
+
+import "fmt"
+
+// Set of possible types of record a stock movement can be made for.
+var (
+	ReferenceOrder         = ReferenceType{"ORDER"}
+	ReferenceTransfer      = ReferenceType{"TRANSFER"}
+	ReferenceReservation   = ReferenceType{"RESERVATION"}
+	ReferencePurchaseOrder = ReferenceType{"PURCHASE_ORDER"}
+	ReferenceStocktake     = ReferenceType{"STOCKTAKE"}
+	ReferenceRecall        = ReferenceType{"RECALL"}
+	ReferenceDisposal      = ReferenceType{"DISPOSAL"}
+)
+
+// Set of known reference types.
+var referenceTypes = map[string]ReferenceType{
+	ReferenceOrder.name:         ReferenceOrder,
+	ReferenceTransfer.name:      ReferenceTransfer,
+	ReferenceReservation.name:   ReferenceReservation,
+	ReferencePurchaseOrder.name: ReferencePurchaseOrder,
+	ReferenceStocktake.name:     ReferenceStocktake,
+	ReferenceRecall.name:        ReferenceRecall,
+	ReferenceDisposal.name:      ReferenceDisposal,
+}
+
+// ReferenceType represents the kind of record a stock movement was made for.
+type ReferenceType struct {
+	name string
+}
+
+// ParseReferenceType parses the string value and returns a reference type if
+// one exists.
+func ParseReferenceType(value string) (ReferenceType, error) {
+	typ, exists := referenceTypes[value]
+	if !exists {
+		return ReferenceType{}, fmt.Errorf("invalid reference type %q", value)
+	}
+
+	return typ, nil
+}
+
+// MustParseReferenceType parses the string value and returns a reference
+// type if one exists. If an error occurs the function panics.
+func MustParseReferenceType(value string) ReferenceType {
+	typ, err := ParseReferenceType(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return typ
+}
+
+// Name returns the name of the reference type.
+func (t ReferenceType) Name() string {
+	return t.name
+}
+
+// UnmarshalText implement the unmarshal interface for JSON conversions.
+func (t *ReferenceType) UnmarshalText(data []byte) error {
+	typ, err := ParseReferenceType(string(data))
+	if err != nil {
+		return err
+	}
+
+	t.name = typ.name
+	return nil
+}
+
+// MarshalText implement the marshal interface for JSON conversions.
+func (t ReferenceType) MarshalText() ([]byte, error) {
+	return []byte(t.name), nil
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (t ReferenceType) Equal(t2 ReferenceType) bool {
+	return t.name == t2.name
+}
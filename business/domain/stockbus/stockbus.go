@@ -0,0 +1,463 @@
+// Package stockbus provides the business API for the stock ledger. Every
+// change to the quantity of a medicine lot held by an inventory is recorded as an
+// immutable movement and applied to the inventory in the same call.
+package stockbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound            = errors.New("movement not found")
+	ErrInvalidQuantity     = errors.New("invalid movement quantity")
+	ErrReasonRequired      = errors.New("movement reason required")
+	ErrFutureAsOf          = errors.New("as of time is in the future")
+	ErrBeforeHistory       = errors.New("as of time is before the earliest snapshot")
+	ErrQuarantined         = errors.New("lot is quarantined")
+	ErrUnavailable         = errors.New("stock is not available")
+	ErrReserved            = errors.New("stock is held by reservations")
+	ErrNoSnapshot          = errors.New("no snapshot taken yet")
+	ErrCountersignRequired = errors.New("movement of a scheduled medicine requires a countersign")
+	ErrSelfCountersign     = errors.New("movement can't be countersigned by the user recording it")
+	ErrInvalidPeriod       = errors.New("register period ends before it starts")
+)
+
+// snapshotSettle is how far behind the current time snapshots are taken, so
+// movements of transactions still in flight are not left out of them.
+const snapshotSettle = time.Minute
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data. Movements are append only so there is no update or delete.
+type Storer interface {
+	ExecuteUnderTransaction(tx transaction.Transaction) (Storer, error)
+	Create(ctx context.Context, mov Movement) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Movement, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, movementID uuid.UUID) (Movement, error)
+	QueryHoldings(ctx context.Context, filter HoldingFilter, asOf time.Time) ([]Holding, error)
+	CreateSnapshot(ctx context.Context, snap Snapshot) (int, error)
+	QueryLatestSnapshot(ctx context.Context) (Snapshot, error)
+	QueryEarliestSnapshot(ctx context.Context) (Snapshot, error)
+	QueryRegister(ctx context.Context, filter RegisterFilter) ([]Movement, error)
+	QueryAvailabilityForUpdate(ctx context.Context, inventoryID uuid.UUID, medicineID uuid.UUID, now time.Time) (Availability, error)
+}
+
+// Core manages the set of APIs for stock ledger access.
+type Core struct {
+	log           *logger.Logger
+	inventoryCore *inventorybus.Core
+	medicineCore  *medicinebus.Core
+	lotCore       *lotbus.Core
+	locationCore  *locationbus.Core
+	delegate      *delegate.Delegate
+	storer        Storer
+}
+
+// NewCore constructs a stock core API for use.
+func NewCore(log *logger.Logger, inventoryCore *inventorybus.Core, medicineCore *medicinebus.Core, lotCore *lotbus.Core, locationCore *locationbus.Core, delegate *delegate.Delegate, storer Storer) *Core {
+	return &Core{
+		log:           log,
+		inventoryCore: inventoryCore,
+		medicineCore:  medicineCore,
+		lotCore:       lotCore,
+		locationCore:  locationCore,
+		delegate:      delegate,
+		storer:        storer,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Core value that will use the
+// specified transaction in any store related calls.
+func (c *Core) ExecuteUnderTransaction(tx transaction.Transaction) (*Core, error) {
+	storer, err := c.storer.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryCore, err := c.inventoryCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	medicineCore, err := c.medicineCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	lotCore, err := c.lotCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	locationCore, err := c.locationCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	core := Core{
+		log:           c.log,
+		inventoryCore: inventoryCore,
+		medicineCore:  medicineCore,
+		lotCore:       lotCore,
+		locationCore:  locationCore,
+		delegate:      c.delegate,
+		storer:        storer,
+	}
+
+	return &core, nil
+}
+
+// Create records a new movement and applies it to the inventory. A movement
+// that names a bin is applied to the bin as well. One that takes stock out
+// without naming a bin uses up unplaced stock first and then draws on the
+// bins holding the lot. Only available stock
+// can be dispensed or transferred out, the rest can only leave by being
+// written off or returned. Stock held by reservations can only be dispensed
+// against the reservation holding it. Movements of a scheduled medicine have
+// to be countersigned by a second user. The caller is expected to run this
+// under a transaction so the ledger row and the inventory quantity are
+// committed together.
+func (c *Core) Create(ctx context.Context, nm NewMovement) (Movement, error) {
+	delta, err := movementDelta(nm)
+	if err != nil {
+		return Movement{}, err
+	}
+
+	lot, err := c.lotCore.QueryByID(ctx, nm.LotID)
+	if err != nil {
+		return Movement{}, fmt.Errorf("lot.querybyid: %s: %w", nm.LotID, err)
+	}
+
+	if nm.CountersignedBy != uuid.Nil && nm.CountersignedBy == nm.UserID {
+		return Movement{}, ErrSelfCountersign
+	}
+
+	med, err := c.medicineCore.QueryByID(ctx, lot.MedicineID)
+	if err != nil {
+		return Movement{}, fmt.Errorf("medicine.querybyid: %s: %w", lot.MedicineID, err)
+	}
+
+	if med.Schedule.Controlled() && nm.CountersignedBy == uuid.Nil {
+		return Movement{}, fmt.Errorf("%w: medicine[%s] schedule[%s]", ErrCountersignRequired, med.ID, med.Schedule.Name())
+	}
+
+	if lot.Quarantined && (nm.Type == TypeDispense || nm.Type == TypeTransferOut) {
+		return Movement{}, fmt.Errorf("%w: lot[%s]", ErrQuarantined, lot.Number)
+	}
+
+	status := nm.Status
+	if status == (inventorybus.Status{}) {
+		status = inventorybus.StatusAvailable
+		if lot.Quarantined {
+			status = inventorybus.StatusQuarantined
+		}
+	}
+
+	if status != inventorybus.StatusAvailable && (nm.Type == TypeDispense || nm.Type == TypeTransferOut) {
+		return Movement{}, fmt.Errorf("%w: status[%s]", ErrUnavailable, status.Name())
+	}
+
+	if nm.ReservationID == uuid.Nil && (nm.Type == TypeDispense || nm.Type == TypeTransferOut) {
+		if err := c.checkReserved(ctx, nm, lot.MedicineID); err != nil {
+			return Movement{}, err
+		}
+	}
+
+	switch {
+	case nm.LocationID != uuid.Nil:
+		if _, err := c.locationCore.AdjustBin(ctx, nm.InventoryID, nm.LocationID, nm.LotID, delta); err != nil {
+			return Movement{}, fmt.Errorf("location.adjustbin: %w", err)
+		}
+
+	case delta < 0:
+		if err := c.locationCore.Draw(ctx, nm.InventoryID, nm.LotID, -delta); err != nil {
+			return Movement{}, fmt.Errorf("location.draw: %w", err)
+		}
+	}
+
+	balance, err := c.inventoryCore.AdjustQuantity(ctx, nm.InventoryID, nm.LotID, status, delta)
+	if err != nil {
+		return Movement{}, fmt.Errorf("inventory.adjustquantity: %w", err)
+	}
+
+	mov := Movement{
+		ID:              uuid.New(),
+		InventoryID:     nm.InventoryID,
+		MedicineID:      lot.MedicineID,
+		LotID:           nm.LotID,
+		LocationID:      nm.LocationID,
+		Type:            nm.Type,
+		Quantity:        delta,
+		Balance:         balance,
+		Reason:          nm.Reason,
+		UserID:          nm.UserID,
+		CountersignedBy: nm.CountersignedBy,
+		Reference:       nm.Reference,
+		DateCreated:     time.Now(),
+	}
+
+	if err := c.storer.Create(ctx, mov); err != nil {
+		return Movement{}, fmt.Errorf("create: %w", err)
+	}
+
+	return mov, nil
+}
+
+// Query retrieves a list of existing movements.
+func (c *Core) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Movement, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	movs, err := c.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return movs, nil
+}
+
+// Count returns the total number of movements.
+func (c *Core) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	return c.storer.Count(ctx, filter)
+}
+
+// QueryByID finds the movement by the specified ID.
+func (c *Core) QueryByID(ctx context.Context, movementID uuid.UUID) (Movement, error) {
+	mov, err := c.storer.QueryByID(ctx, movementID)
+	if err != nil {
+		return Movement{}, fmt.Errorf("query: movementID[%s]: %w", movementID, err)
+	}
+
+	return mov, nil
+}
+
+// QueryHoldings rebuilds the quantity of every lot held by the inventories
+// matching the filter at the specified time. Lots that were not held are left
+// out.
+func (c *Core) QueryHoldings(ctx context.Context, filter HoldingFilter, asOf time.Time) ([]Holding, error) {
+	if asOf.After(time.Now()) {
+		return nil, ErrFutureAsOf
+	}
+
+	if err := c.checkHistory(ctx, asOf); err != nil {
+		return nil, err
+	}
+
+	hs, err := c.storer.QueryHoldings(ctx, filter, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("queryholdings: asOf[%s]: %w", asOf, err)
+	}
+
+	return hs, nil
+}
+
+// QueryRegister returns the register of a medicine held by an inventory: the
+// balance held at the start of the period and every movement recorded after
+// it up to the end of the period with the balance that followed.
+func (c *Core) QueryRegister(ctx context.Context, filter RegisterFilter) (Register, error) {
+	if filter.EndDate.Before(filter.StartDate) {
+		return Register{}, ErrInvalidPeriod
+	}
+
+	if err := c.checkHistory(ctx, filter.StartDate); err != nil {
+		return Register{}, err
+	}
+
+	med, err := c.medicineCore.QueryByID(ctx, filter.MedicineID)
+	if err != nil {
+		return Register{}, fmt.Errorf("medicine.querybyid: %s: %w", filter.MedicineID, err)
+	}
+
+	var hf HoldingFilter
+	hf.WithInventoryID(filter.InventoryID)
+	hf.WithMedicineID(filter.MedicineID)
+
+	hs, err := c.storer.QueryHoldings(ctx, hf, filter.StartDate)
+	if err != nil {
+		return Register{}, fmt.Errorf("queryholdings: asOf[%s]: %w", filter.StartDate, err)
+	}
+
+	var balance int
+	for _, h := range hs {
+		balance += h.Quantity
+	}
+
+	movs, err := c.storer.QueryRegister(ctx, filter)
+	if err != nil {
+		return Register{}, fmt.Errorf("queryregister: %w", err)
+	}
+
+	rgs := Register{
+		InventoryID:    filter.InventoryID,
+		MedicineID:     filter.MedicineID,
+		Schedule:       med.Schedule,
+		StartDate:      filter.StartDate,
+		EndDate:        filter.EndDate,
+		OpeningBalance: balance,
+		Entries:        make([]RegisterEntry, len(movs)),
+	}
+
+	for i, mov := range movs {
+		balance += mov.Quantity
+
+		rgs.Entries[i] = RegisterEntry{
+			MovementID:      mov.ID,
+			LotID:           mov.LotID,
+			Type:            mov.Type,
+			Quantity:        mov.Quantity,
+			Balance:         balance,
+			Reason:          mov.Reason,
+			UserID:          mov.UserID,
+			CountersignedBy: mov.CountersignedBy,
+			DateCreated:     mov.DateCreated,
+		}
+	}
+
+	rgs.ClosingBalance = balance
+
+	return rgs, nil
+}
+
+// TakeSnapshot records the quantities every inventory held at the specified
+// time so later historical queries don't have to replay the whole ledger.
+func (c *Core) TakeSnapshot(ctx context.Context, asOf time.Time) (Snapshot, error) {
+	snap := Snapshot{
+		ID:        uuid.New(),
+		DateTaken: asOf,
+	}
+
+	lots, err := c.storer.CreateSnapshot(ctx, snap)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("createsnapshot: asOf[%s]: %w", asOf, err)
+	}
+
+	snap.Lots = lots
+
+	return snap, nil
+}
+
+// Watch takes a snapshot whenever the latest one is older than the interval,
+// checking every interval until the context is cancelled. The first check
+// runs straight away.
+func (c *Core) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		asOf := time.Now().Add(-snapshotSettle)
+
+		latest, err := c.storer.QueryLatestSnapshot(ctx)
+		switch {
+		case err != nil && !errors.Is(err, ErrNoSnapshot):
+			c.log.Error(ctx, "stock snapshotter", "status", "query latest failed", "msg", err)
+
+		case err != nil || asOf.Sub(latest.DateTaken) >= interval:
+			snap, err := c.TakeSnapshot(ctx, asOf)
+			if err != nil {
+				c.log.Error(ctx, "stock snapshotter", "status", "snapshot failed", "msg", err)
+				break
+			}
+			c.log.Info(ctx, "stock snapshotter", "status", "snapshot taken", "snapshotID", snap.ID, "lots", snap.Lots)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// =============================================================================
+
+// checkHistory makes sure the stock held at the specified time can be rebuilt.
+// Stock held before the earliest snapshot was never recorded, so holdings
+// rebuilt from the ledger alone would be wrong.
+func (c *Core) checkHistory(ctx context.Context, asOf time.Time) error {
+	earliest, err := c.storer.QueryEarliestSnapshot(ctx)
+	if err != nil {
+		if errors.Is(err, ErrNoSnapshot) {
+			return nil
+		}
+		return fmt.Errorf("queryearliestsnapshot: %w", err)
+	}
+
+	if asOf.Before(earliest.DateTaken) {
+		return fmt.Errorf("asOf[%s] earliest[%s]: %w", asOf, earliest.DateTaken, ErrBeforeHistory)
+	}
+
+	return nil
+}
+
+// checkReserved locks the stock of the medicine held by the inventory and
+// refuses to take out stock held by reservations. A quantity beyond what is
+// on hand is left for the inventory to refuse.
+func (c *Core) checkReserved(ctx context.Context, nm NewMovement, medicineID uuid.UUID) error {
+	av, err := c.storer.QueryAvailabilityForUpdate(ctx, nm.InventoryID, medicineID, time.Now())
+	if err != nil {
+		return fmt.Errorf("queryavailabilityforupdate: %w", err)
+	}
+
+	if nm.Quantity <= av.OnHand && nm.Quantity > av.Available() {
+		return fmt.Errorf("%w: requested[%d] available[%d] reserved[%d]", ErrReserved, nm.Quantity, av.Available(), av.Reserved)
+	}
+
+	return nil
+}
+
+// movementDelta validates the new movement and returns the signed change it
+// applies to the on-hand quantity.
+func movementDelta(nm NewMovement) (int, error) {
+	switch nm.Type {
+	case TypeReceive, TypeTransferIn:
+		if nm.Quantity <= 0 {
+			return 0, ErrInvalidQuantity
+		}
+		return nm.Quantity, nil
+
+	case TypeDispense, TypeTransferOut:
+		if nm.Quantity <= 0 {
+			return 0, ErrInvalidQuantity
+		}
+		return -nm.Quantity, nil
+
+	case TypeWriteOff, TypeReturn:
+		if nm.Quantity <= 0 {
+			return 0, ErrInvalidQuantity
+		}
+		if nm.Reason == "" {
+			return 0, ErrReasonRequired
+		}
+		return -nm.Quantity, nil
+
+	case TypeAdjust:
+		if nm.Quantity == 0 {
+			return 0, ErrInvalidQuantity
+		}
+		if nm.Reason == "" {
+			return 0, ErrReasonRequired
+		}
+		return nm.Quantity, nil
+	}
+
+	return 0, fmt.Errorf("unknown movement type %q", nm.Type.Name())
+}
@@ -0,0 +1,162 @@
+package stockdb
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/google/uuid"
+)
+
+type dbMovement struct {
+	ID              uuid.UUID      `db:"movement_id"`
+	InventoryID     uuid.UUID      `db:"inventory_id"`
+	MedicineID      uuid.UUID      `db:"medicine_id"`
+	LotID           uuid.UUID      `db:"lot_id"`
+	LocationID      uuid.NullUUID  `db:"location_id"`
+	Type            string         `db:"type"`
+	Quantity        int            `db:"quantity"`
+	Balance         int            `db:"balance"`
+	Reason          sql.NullString `db:"reason"`
+	UserID          uuid.UUID      `db:"user_id"`
+	CountersignedBy uuid.NullUUID  `db:"countersigned_by"`
+	ReferenceType   sql.NullString `db:"reference_type"`
+	ReferenceID     uuid.NullUUID  `db:"reference_id"`
+	DateCreated     time.Time      `db:"date_created"`
+}
+
+func toDBMovement(mov stockbus.Movement) dbMovement {
+	return dbMovement{
+		ID:          mov.ID,
+		InventoryID: mov.InventoryID,
+		MedicineID:  mov.MedicineID,
+		LotID:       mov.LotID,
+		LocationID: uuid.NullUUID{
+			UUID:  mov.LocationID,
+			Valid: mov.LocationID != uuid.Nil,
+		},
+		Type:     mov.Type.Name(),
+		Quantity: mov.Quantity,
+		Balance:  mov.Balance,
+		Reason: sql.NullString{
+			String: mov.Reason,
+			Valid:  mov.Reason != "",
+		},
+		UserID: mov.UserID,
+		CountersignedBy: uuid.NullUUID{
+			UUID:  mov.CountersignedBy,
+			Valid: mov.CountersignedBy != uuid.Nil,
+		},
+		ReferenceType: sql.NullString{
+			String: mov.Reference.Type.Name(),
+			Valid:  mov.Reference.ID != uuid.Nil,
+		},
+		ReferenceID: uuid.NullUUID{
+			UUID:  mov.Reference.ID,
+			Valid: mov.Reference.ID != uuid.Nil,
+		},
+		DateCreated: mov.DateCreated.UTC(),
+	}
+}
+
+func toCoreMovement(dbMov dbMovement) (stockbus.Movement, error) {
+	typ, err := stockbus.ParseMovementType(dbMov.Type)
+	if err != nil {
+		return stockbus.Movement{}, fmt.Errorf("parse type: %w", err)
+	}
+
+	mov := stockbus.Movement{
+		ID:              dbMov.ID,
+		InventoryID:     dbMov.InventoryID,
+		MedicineID:      dbMov.MedicineID,
+		LotID:           dbMov.LotID,
+		LocationID:      dbMov.LocationID.UUID,
+		Type:            typ,
+		Quantity:        dbMov.Quantity,
+		Balance:         dbMov.Balance,
+		Reason:          dbMov.Reason.String,
+		UserID:          dbMov.UserID,
+		CountersignedBy: dbMov.CountersignedBy.UUID,
+		DateCreated:     dbMov.DateCreated.In(time.Local),
+	}
+
+	if dbMov.ReferenceType.Valid {
+		refType, err := stockbus.ParseReferenceType(dbMov.ReferenceType.String)
+		if err != nil {
+			return stockbus.Movement{}, fmt.Errorf("parse reference type: %w", err)
+		}
+
+		mov.Reference = stockbus.Reference{
+			Type: refType,
+			ID:   dbMov.ReferenceID.UUID,
+		}
+	}
+
+	return mov, nil
+}
+
+func toCoreMovementSlice(dbMovs []dbMovement) ([]stockbus.Movement, error) {
+	movs := make([]stockbus.Movement, len(dbMovs))
+
+	for i, dbMov := range dbMovs {
+		var err error
+		movs[i], err = toCoreMovement(dbMov)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return movs, nil
+}
+
+type dbHolding struct {
+	InventoryID uuid.UUID `db:"inventory_id"`
+	MedicineID  uuid.UUID `db:"medicine_id"`
+	LotID       uuid.UUID `db:"lot_id"`
+	Quantity    int       `db:"quantity"`
+}
+
+func toCoreHoldingSlice(dbHs []dbHolding) []stockbus.Holding {
+	hs := make([]stockbus.Holding, len(dbHs))
+	for i, dbH := range dbHs {
+		hs[i] = stockbus.Holding{
+			InventoryID: dbH.InventoryID,
+			MedicineID:  dbH.MedicineID,
+			LotID:       dbH.LotID,
+			Quantity:    dbH.Quantity,
+		}
+	}
+
+	return hs
+}
+
+type dbSnapshot struct {
+	ID        uuid.UUID `db:"snapshot_id"`
+	DateTaken time.Time `db:"date_taken"`
+	Lots      int       `db:"lots"`
+}
+
+func toCoreSnapshot(dbSnap dbSnapshot) stockbus.Snapshot {
+	return stockbus.Snapshot{
+		ID:        dbSnap.ID,
+		DateTaken: dbSnap.DateTaken.In(time.Local),
+		Lots:      dbSnap.Lots,
+	}
+}
+
+type dbAvailability struct {
+	InventoryID uuid.UUID `db:"inventory_id"`
+	MedicineID  uuid.UUID `db:"medicine_id"`
+	OnHand      int       `db:"on_hand"`
+	Reserved    int       `db:"reserved"`
+}
+
+func toCoreAvailability(dbAv dbAvailability) stockbus.Availability {
+	return stockbus.Availability{
+		InventoryID: dbAv.InventoryID,
+		MedicineID:  dbAv.MedicineID,
+		OnHand:      dbAv.OnHand,
+		Reserved:    dbAv.Reserved,
+	}
+}
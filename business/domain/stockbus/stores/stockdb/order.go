@@ -0,0 +1,25 @@
+package stockdb
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+)
+
+var orderByFields = map[string]string{
+	stockbus.OrderByID:          "movement_id",
+	stockbus.OrderByMedicineID:  "medicine_id",
+	stockbus.OrderByType:        "type",
+	stockbus.OrderByQuantity:    "quantity",
+	stockbus.OrderByDateCreated: "date_created",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
@@ -0,0 +1,406 @@
+// Package stockdb contains stock ledger related database functionality.
+package stockdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// withHoldings rebuilds the quantity of every lot held by every inventory at
+// :as_of. It starts from the latest snapshot taken before then and adds the
+// movements recorded after it.
+const withHoldings = `
+	WITH latest AS (
+		SELECT
+			snapshot_id, date_taken
+		FROM
+			stock_snapshots
+		WHERE
+			date_taken <= :as_of
+		ORDER BY
+			date_taken DESC
+		LIMIT 1
+	),
+	holdings AS (
+		SELECT
+			inventory_id, medicine_id, lot_id, SUM(quantity) AS quantity
+		FROM (
+			SELECT
+				si.inventory_id, si.medicine_id, si.lot_id, si.quantity
+			FROM
+				stock_snapshot_items AS si
+			JOIN
+				latest AS l ON l.snapshot_id = si.snapshot_id
+			UNION ALL
+			SELECT
+				m.inventory_id, m.medicine_id, m.lot_id, m.quantity
+			FROM
+				stock_movements AS m
+			WHERE
+				m.date_created <= :as_of AND
+				m.date_created > COALESCE((SELECT date_taken FROM latest), '-infinity')
+		) AS h
+		GROUP BY
+			inventory_id, medicine_id, lot_id
+		HAVING
+			SUM(quantity) <> 0
+	)`
+
+// Store manages the set of APIs for stock movement database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Store value replacing the sqlx DB
+// value with a sqlx DB value that is currently inside a transaction.
+func (s *Store) ExecuteUnderTransaction(tx transaction.Transaction) (stockbus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	store := Store{
+		log: s.log,
+		db:  ec,
+	}
+
+	return &store, nil
+}
+
+// Create inserts a new movement into the database.
+func (s *Store) Create(ctx context.Context, mov stockbus.Movement) error {
+	const q = `
+	INSERT INTO stock_movements
+		(movement_id, inventory_id, medicine_id, lot_id, location_id, type, quantity, balance, reason, user_id, countersigned_by, reference_type, reference_id, date_created)
+	VALUES
+		(:movement_id, :inventory_id, :medicine_id, :lot_id, :location_id, :type, :quantity, :balance, :reason, :user_id, :countersigned_by, :reference_type, :reference_id, :date_created)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBMovement(mov)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of existing movements from the database.
+func (s *Store) Query(ctx context.Context, filter stockbus.QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]stockbus.Movement, error) {
+	data := map[string]interface{}{
+		"offset":        (pageNumber - 1) * rowsPerPage,
+		"rows_per_page": rowsPerPage,
+	}
+
+	const q = `
+	SELECT
+		movement_id, inventory_id, medicine_id, lot_id, location_id, type, quantity, balance, reason, user_id, countersigned_by, reference_type, reference_id, date_created
+	FROM
+		stock_movements`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbMovs []dbMovement
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbMovs); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreMovementSlice(dbMovs)
+}
+
+// Count returns the total number of movements in the database.
+func (s *Store) Count(ctx context.Context, filter stockbus.QueryFilter) (int, error) {
+	data := map[string]interface{}{}
+
+	const q = `
+	SELECT
+		count(1)
+	FROM
+		stock_movements`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID gets the specified movement from the database.
+func (s *Store) QueryByID(ctx context.Context, movementID uuid.UUID) (stockbus.Movement, error) {
+	data := struct {
+		ID string `db:"movement_id"`
+	}{
+		ID: movementID.String(),
+	}
+
+	const q = `
+	SELECT
+		movement_id, inventory_id, medicine_id, lot_id, location_id, type, quantity, balance, reason, user_id, countersigned_by, reference_type, reference_id, date_created
+	FROM
+		stock_movements
+	WHERE
+		movement_id = :movement_id`
+
+	var dbMov dbMovement
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbMov); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return stockbus.Movement{}, fmt.Errorf("db: %w", stockbus.ErrNotFound)
+		}
+		return stockbus.Movement{}, fmt.Errorf("db: %w", err)
+	}
+
+	return toCoreMovement(dbMov)
+}
+
+// QueryHoldings rebuilds the quantities held as of the specified time.
+func (s *Store) QueryHoldings(ctx context.Context, filter stockbus.HoldingFilter, asOf time.Time) ([]stockbus.Holding, error) {
+	data := map[string]interface{}{
+		"as_of": asOf.UTC(),
+	}
+
+	const q = withHoldings + `
+	SELECT
+		inventory_id, medicine_id, lot_id, quantity
+	FROM
+		holdings`
+
+	buf := bytes.NewBufferString(q)
+	applyHoldingFilter(filter, data, buf)
+	buf.WriteString(" ORDER BY inventory_id, medicine_id, lot_id")
+
+	var dbHs []dbHolding
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbHs); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreHoldingSlice(dbHs), nil
+}
+
+// QueryRegister retrieves the movements of a medicine held by an inventory
+// recorded after the start of the period up to its end, oldest first.
+func (s *Store) QueryRegister(ctx context.Context, filter stockbus.RegisterFilter) ([]stockbus.Movement, error) {
+	data := map[string]interface{}{
+		"inventory_id": filter.InventoryID,
+		"medicine_id":  filter.MedicineID,
+		"start_date":   filter.StartDate.UTC(),
+		"end_date":     filter.EndDate.UTC(),
+	}
+
+	const q = `
+	SELECT
+		movement_id, inventory_id, medicine_id, lot_id, location_id, type, quantity, balance, reason, user_id, countersigned_by, reference_type, reference_id, date_created
+	FROM
+		stock_movements
+	WHERE
+		inventory_id = :inventory_id AND
+		medicine_id = :medicine_id AND
+		date_created > :start_date AND
+		date_created <= :end_date
+	ORDER BY
+		date_created, movement_id`
+
+	var dbMovs []dbMovement
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbMovs); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreMovementSlice(dbMovs)
+}
+
+// CreateSnapshot records the quantities held as of the time of the snapshot
+// and returns the number of lots recorded. The snapshot and its items are
+// written by a single statement so a partial snapshot is never seen.
+func (s *Store) CreateSnapshot(ctx context.Context, snap stockbus.Snapshot) (int, error) {
+	data := map[string]interface{}{
+		"snapshot_id": snap.ID,
+		"as_of":       snap.DateTaken.UTC(),
+	}
+
+	const q = withHoldings + `,
+	snapshot AS (
+		INSERT INTO stock_snapshots
+			(snapshot_id, date_taken)
+		VALUES
+			(:snapshot_id, :as_of)
+		RETURNING
+			snapshot_id
+	),
+	items AS (
+		INSERT INTO stock_snapshot_items
+			(snapshot_id, inventory_id, medicine_id, lot_id, quantity)
+		SELECT
+			s.snapshot_id, h.inventory_id, h.medicine_id, h.lot_id, h.quantity
+		FROM
+			holdings AS h
+		CROSS JOIN
+			snapshot AS s
+		RETURNING
+			lot_id
+	)
+	SELECT
+		count(1) AS lots
+	FROM
+		items`
+
+	var result struct {
+		Lots int `db:"lots"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &result); err != nil {
+		return 0, fmt.Errorf("namedquerystruct: %w", err)
+	}
+
+	return result.Lots, nil
+}
+
+// QueryLatestSnapshot gets the most recent snapshot from the database.
+func (s *Store) QueryLatestSnapshot(ctx context.Context) (stockbus.Snapshot, error) {
+	const q = `
+	SELECT
+		s.snapshot_id, s.date_taken, count(si.lot_id) AS lots
+	FROM
+		stock_snapshots AS s
+	LEFT JOIN
+		stock_snapshot_items AS si ON si.snapshot_id = s.snapshot_id
+	GROUP BY
+		s.snapshot_id, s.date_taken
+	ORDER BY
+		s.date_taken DESC
+	LIMIT 1`
+
+	var dbSnap dbSnapshot
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, map[string]interface{}{}, &dbSnap); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return stockbus.Snapshot{}, fmt.Errorf("db: %w", stockbus.ErrNoSnapshot)
+		}
+		return stockbus.Snapshot{}, fmt.Errorf("db: %w", err)
+	}
+
+	return toCoreSnapshot(dbSnap), nil
+}
+
+// QueryEarliestSnapshot gets the first snapshot from the database.
+func (s *Store) QueryEarliestSnapshot(ctx context.Context) (stockbus.Snapshot, error) {
+	const q = `
+	SELECT
+		s.snapshot_id, s.date_taken, count(si.lot_id) AS lots
+	FROM
+		stock_snapshots AS s
+	LEFT JOIN
+		stock_snapshot_items AS si ON si.snapshot_id = s.snapshot_id
+	GROUP BY
+		s.snapshot_id, s.date_taken
+	ORDER BY
+		s.date_taken
+	LIMIT 1`
+
+	var dbSnap dbSnapshot
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, map[string]interface{}{}, &dbSnap); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return stockbus.Snapshot{}, fmt.Errorf("db: %w", stockbus.ErrNoSnapshot)
+		}
+		return stockbus.Snapshot{}, fmt.Errorf("db: %w", err)
+	}
+
+	return toCoreSnapshot(dbSnap), nil
+}
+
+// QueryAvailabilityForUpdate gets the available stock of a medicine held by
+// the inventory together with the quantity held by active reservations, and
+// locks the stock until the surrounding transaction ends. It takes the same
+// lock as reservations do, so movements and reservations of the medicine are
+// serialized.
+func (s *Store) QueryAvailabilityForUpdate(ctx context.Context, inventoryID uuid.UUID, medicineID uuid.UUID, now time.Time) (stockbus.Availability, error) {
+	data := struct {
+		InventoryID string    `db:"inventory_id"`
+		MedicineID  string    `db:"medicine_id"`
+		Status      string    `db:"status"`
+		Now         time.Time `db:"now"`
+	}{
+		InventoryID: inventoryID.String(),
+		MedicineID:  medicineID.String(),
+		Status:      reservationbus.StatusHeld.Name(),
+		Now:         now.UTC(),
+	}
+
+	const ql = `
+	SELECT
+		lot_id
+	FROM
+		inventory_items
+	WHERE
+		inventory_id = :inventory_id AND
+		medicine_id = :medicine_id
+	FOR UPDATE`
+
+	var locked []struct {
+		LotID uuid.UUID `db:"lot_id"`
+	}
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, ql, data, &locked); err != nil {
+		return stockbus.Availability{}, fmt.Errorf("namedqueryslice: lock: %w", err)
+	}
+
+	const q = `
+	SELECT
+		it.inventory_id, it.medicine_id, SUM(it.quantity - it.quarantined - it.damaged - it.expired) AS on_hand,
+		COALESCE((
+			SELECT
+				SUM(r.quantity)
+			FROM
+				reservations r
+			WHERE
+				r.inventory_id = it.inventory_id AND
+				r.medicine_id = it.medicine_id AND
+				r.status = :status AND
+				r.date_expires > :now
+		), 0) AS reserved
+	FROM
+		inventory_items it
+	WHERE
+		it.inventory_id = :inventory_id AND
+		it.medicine_id = :medicine_id
+	GROUP BY
+		it.inventory_id, it.medicine_id`
+
+	var dbAv dbAvailability
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbAv); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return stockbus.Availability{InventoryID: inventoryID, MedicineID: medicineID}, nil
+		}
+		return stockbus.Availability{}, fmt.Errorf("db: %w", err)
+	}
+
+	return toCoreAvailability(dbAv), nil
+}
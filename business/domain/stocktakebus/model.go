@@ -0,0 +1,82 @@
+package stocktakebus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stocktake represents a physical count of an inventory. The expected
+// quantity of every lot is frozen when the stocktake is opened so movements
+// posted while counting don't change what the count is compared against.
+// AllStatuses reports whether stock in every status is counted rather than
+// only the available stock.
+type Stocktake struct {
+	ID           uuid.UUID
+	InventoryID  uuid.UUID
+	Status       Status
+	Note         string
+	AllStatuses  bool
+	Lines        []Line
+	OpenedBy     uuid.UUID
+	ApprovedBy   uuid.UUID
+	DateApproved time.Time
+	DateCreated  time.Time
+	DateUpdated  time.Time
+}
+
+// Line represents the expected and counted quantity of a lot. Counted is the
+// sum of the counts submitted by every device and Devices is the number of
+// devices that counted the lot. A lot no device has counted yet is uncounted.
+type Line struct {
+	MedicineID uuid.UUID
+	LotID      uuid.UUID
+	Expected   int
+	Counted    int
+	Devices    int
+}
+
+// Uncounted reports whether no count has been submitted for the line.
+func (l Line) Uncounted() bool {
+	return l.Devices == 0
+}
+
+// Variance returns the counted quantity minus the expected quantity.
+func (l Line) Variance() int {
+	return l.Counted - l.Expected
+}
+
+// VariancePercent returns the variance as a percentage of the expected
+// quantity. It reports false when nothing was expected since the percentage
+// is undefined then.
+func (l Line) VariancePercent() (float64, bool) {
+	if l.Expected == 0 {
+		return 0, false
+	}
+
+	return float64(l.Variance()) * 100 / float64(l.Expected), true
+}
+
+// NewStocktake contains information needed to open a stocktake. AllStatuses
+// counts quarantined, damaged and expired stock along with available stock.
+type NewStocktake struct {
+	InventoryID uuid.UUID
+	Note        string
+	AllStatuses bool
+	UserID      uuid.UUID
+}
+
+// Counting contains the quantities counted by a single device. Counting the
+// same lot again from the same device replaces its previous count.
+type Counting struct {
+	DeviceID string
+	Lines    []CountLine
+	UserID   uuid.UUID
+}
+
+// CountLine contains the quantity of a lot counted by a device. A lot that
+// was not expected in the inventory is added to the stocktake as found stock.
+type CountLine struct {
+	LotID    uuid.UUID
+	Quantity int
+}
@@ -0,0 +1,13 @@
+package stocktakebus
+
+import "github.com/EnesDemirtas/medisync/business/api/order"
+
+// DefaultOrderBy represents the default way we sort.
+var DefaultOrderBy = order.NewBy(OrderByDateCreated, order.ASC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID          = "stocktake_id"
+	OrderByStatus      = "status"
+	OrderByDateCreated = "date_created"
+)
@@ -0,0 +1,412 @@
+// Package stocktakebus provides the business API for counting the stock held
+// by an inventory. A stocktake freezes the expected quantity of every lot when
+// it is opened and collects the counted quantities from any number of
+// devices. Once every lot has been counted the stocktake is approved and the
+// variances are posted to the inventory as adjustments through the stock
+// ledger.
+package stocktakebus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound          = errors.New("stocktake not found")
+	ErrAlreadyOpen       = errors.New("inventory already has an open stocktake")
+	ErrInvalidTransition = errors.New("stocktake can't move to the requested status")
+	ErrDeviceRequired    = errors.New("counting device required")
+	ErrNoCountLines      = errors.New("counting has no lines")
+	ErrInvalidQuantity   = errors.New("invalid counted quantity")
+	ErrDuplicateLot      = errors.New("lot counted more than once")
+	ErrUncounted         = errors.New("stocktake has uncounted lots")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	ExecuteUnderTransaction(tx transaction.Transaction) (Storer, error)
+	Create(ctx context.Context, stk Stocktake) error
+	Update(ctx context.Context, stk Stocktake) error
+	AddLines(ctx context.Context, stocktakeID uuid.UUID, lines []Line) error
+	SaveCounts(ctx context.Context, stocktakeID uuid.UUID, counting Counting, dateCounted time.Time) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Stocktake, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, stocktakeID uuid.UUID) (Stocktake, error)
+	QueryByIDForUpdate(ctx context.Context, stocktakeID uuid.UUID) (Stocktake, error)
+}
+
+// Core manages the set of APIs for stocktake access.
+type Core struct {
+	log           *logger.Logger
+	inventoryCore *inventorybus.Core
+	lotCore       *lotbus.Core
+	stockCore     *stockbus.Core
+	delegate      *delegate.Delegate
+	storer        Storer
+}
+
+// NewCore constructs a stocktake core API for use.
+func NewCore(log *logger.Logger, inventoryCore *inventorybus.Core, lotCore *lotbus.Core, stockCore *stockbus.Core, delegate *delegate.Delegate, storer Storer) *Core {
+	return &Core{
+		log:           log,
+		inventoryCore: inventoryCore,
+		lotCore:       lotCore,
+		stockCore:     stockCore,
+		delegate:      delegate,
+		storer:        storer,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Core value that will use the
+// specified transaction in any store related calls.
+func (c *Core) ExecuteUnderTransaction(tx transaction.Transaction) (*Core, error) {
+	storer, err := c.storer.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryCore, err := c.inventoryCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	lotCore, err := c.lotCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	stockCore, err := c.stockCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	core := Core{
+		log:           c.log,
+		inventoryCore: inventoryCore,
+		lotCore:       lotCore,
+		stockCore:     stockCore,
+		delegate:      c.delegate,
+		storer:        storer,
+	}
+
+	return &core, nil
+}
+
+// Open starts a stocktake for an inventory and freezes the quantity it holds
+// of every lot as the expected quantity. Only available stock is expected
+// unless the stocktake counts every status. An inventory can only have one
+// open stocktake at a time.
+func (c *Core) Open(ctx context.Context, ns NewStocktake) (Stocktake, error) {
+	if _, err := c.inventoryCore.QueryByID(ctx, ns.InventoryID); err != nil {
+		return Stocktake{}, fmt.Errorf("inventory.querybyid: %s: %w", ns.InventoryID, err)
+	}
+
+	var filter inventorybus.BucketFilter
+	filter.WithInventoryID(ns.InventoryID)
+
+	lbs, err := c.inventoryCore.QueryBuckets(ctx, filter)
+	if err != nil {
+		return Stocktake{}, fmt.Errorf("inventory.querybuckets: %s: %w", ns.InventoryID, err)
+	}
+
+	lines := make([]Line, 0, len(lbs))
+	for _, lb := range lbs {
+		expected := lb.Available
+		if ns.AllStatuses {
+			expected = lb.OnHand()
+		}
+
+		if expected == 0 {
+			continue
+		}
+
+		lines = append(lines, Line{
+			MedicineID: lb.MedicineID,
+			LotID:      lb.LotID,
+			Expected:   expected,
+		})
+	}
+
+	now := time.Now()
+
+	stk := Stocktake{
+		ID:          uuid.New(),
+		InventoryID: ns.InventoryID,
+		Status:      StatusOpen,
+		Note:        ns.Note,
+		AllStatuses: ns.AllStatuses,
+		Lines:       lines,
+		OpenedBy:    ns.UserID,
+		DateCreated: now,
+		DateUpdated: now,
+	}
+
+	if err := c.storer.Create(ctx, stk); err != nil {
+		return Stocktake{}, fmt.Errorf("create: %w", err)
+	}
+
+	return c.storer.QueryByID(ctx, stk.ID)
+}
+
+// SubmitCounts records the quantities counted by a device. Counts from
+// different devices are added up per lot, a device counting a lot again
+// replaces its earlier count. Lots the inventory was not expected to hold are
+// added to the stocktake with an expected quantity of zero.
+func (c *Core) SubmitCounts(ctx context.Context, stocktakeID uuid.UUID, counting Counting) (Stocktake, error) {
+	stk, err := c.storer.QueryByIDForUpdate(ctx, stocktakeID)
+	if err != nil {
+		return Stocktake{}, fmt.Errorf("query: stocktakeID[%s]: %w", stocktakeID, err)
+	}
+
+	if stk.Status != StatusOpen {
+		return Stocktake{}, fmt.Errorf("%w: count %s stocktake", ErrInvalidTransition, stk.Status.Name())
+	}
+
+	if counting.DeviceID == "" {
+		return Stocktake{}, ErrDeviceRequired
+	}
+
+	if len(counting.Lines) == 0 {
+		return Stocktake{}, ErrNoCountLines
+	}
+
+	expected := make(map[uuid.UUID]bool, len(stk.Lines))
+	for _, line := range stk.Lines {
+		expected[line.LotID] = true
+	}
+
+	seen := make(map[uuid.UUID]bool, len(counting.Lines))
+	var found []uuid.UUID
+
+	for _, cl := range counting.Lines {
+		if seen[cl.LotID] {
+			return Stocktake{}, fmt.Errorf("%w: %s", ErrDuplicateLot, cl.LotID)
+		}
+		seen[cl.LotID] = true
+
+		if cl.Quantity < 0 {
+			return Stocktake{}, fmt.Errorf("%w: lot[%s] quantity[%d]", ErrInvalidQuantity, cl.LotID, cl.Quantity)
+		}
+
+		if !expected[cl.LotID] {
+			found = append(found, cl.LotID)
+		}
+	}
+
+	if len(found) > 0 {
+		lots, err := c.queryLots(ctx, found)
+		if err != nil {
+			return Stocktake{}, err
+		}
+
+		lines := make([]Line, len(lots))
+		for i, lot := range lots {
+			lines[i] = Line{
+				MedicineID: lot.MedicineID,
+				LotID:      lot.ID,
+			}
+		}
+
+		if err := c.storer.AddLines(ctx, stk.ID, lines); err != nil {
+			return Stocktake{}, fmt.Errorf("addlines: %w", err)
+		}
+	}
+
+	now := time.Now()
+
+	if err := c.storer.SaveCounts(ctx, stk.ID, counting, now); err != nil {
+		return Stocktake{}, fmt.Errorf("savecounts: %w", err)
+	}
+
+	stk.DateUpdated = now
+
+	if err := c.storer.Update(ctx, stk); err != nil {
+		return Stocktake{}, fmt.Errorf("update: %w", err)
+	}
+
+	return c.storer.QueryByID(ctx, stk.ID)
+}
+
+// Approve closes a fully counted stocktake and posts every variance to the
+// inventory as an adjustment. The counts are taken to reflect the stock held
+// at approval, so each adjustment is made against what the inventory holds
+// then rather than what it held when the stocktake was opened. Stock moved
+// through the ledger or between buckets while it was open is not adjusted
+// twice.
+// countersignedBy is the second user signing off the adjustments, it is
+// needed when the stocktake counts a scheduled medicine. The caller is
+// expected to run this under a transaction so the stocktake and its
+// movements are committed together.
+func (c *Core) Approve(ctx context.Context, stocktakeID uuid.UUID, userID uuid.UUID, countersignedBy uuid.UUID) (Stocktake, error) {
+	stk, err := c.storer.QueryByIDForUpdate(ctx, stocktakeID)
+	if err != nil {
+		return Stocktake{}, fmt.Errorf("query: stocktakeID[%s]: %w", stocktakeID, err)
+	}
+
+	if stk.Status != StatusOpen {
+		return Stocktake{}, fmt.Errorf("%w: approve %s stocktake", ErrInvalidTransition, stk.Status.Name())
+	}
+
+	for _, line := range stk.Lines {
+		if line.Uncounted() {
+			return Stocktake{}, fmt.Errorf("%w: lot[%s]", ErrUncounted, line.LotID)
+		}
+	}
+
+	held, err := c.held(ctx, stk)
+	if err != nil {
+		return Stocktake{}, err
+	}
+
+	for _, line := range stk.Lines {
+		adjustment := line.Counted - held[line.LotID]
+		if adjustment == 0 {
+			continue
+		}
+
+		nm := stockbus.NewMovement{
+			InventoryID:     stk.InventoryID,
+			LotID:           line.LotID,
+			Type:            stockbus.TypeAdjust,
+			Quantity:        adjustment,
+			Reason:          reference(stk),
+			Reference:       stockbus.Reference{Type: stockbus.ReferenceStocktake, ID: stk.ID},
+			UserID:          userID,
+			CountersignedBy: countersignedBy,
+		}
+
+		if _, err := c.stockCore.Create(ctx, nm); err != nil {
+			return Stocktake{}, fmt.Errorf("stock.create: lot[%s]: %w", line.LotID, err)
+		}
+	}
+
+	now := time.Now()
+
+	stk.Status = StatusApproved
+	stk.ApprovedBy = userID
+	stk.DateApproved = now
+	stk.DateUpdated = now
+
+	if err := c.storer.Update(ctx, stk); err != nil {
+		return Stocktake{}, fmt.Errorf("update: %w", err)
+	}
+
+	return stk, nil
+}
+
+// Cancel discards an open stocktake without touching the inventory.
+func (c *Core) Cancel(ctx context.Context, stocktakeID uuid.UUID) (Stocktake, error) {
+	stk, err := c.storer.QueryByIDForUpdate(ctx, stocktakeID)
+	if err != nil {
+		return Stocktake{}, fmt.Errorf("query: stocktakeID[%s]: %w", stocktakeID, err)
+	}
+
+	if stk.Status != StatusOpen {
+		return Stocktake{}, fmt.Errorf("%w: cancel %s stocktake", ErrInvalidTransition, stk.Status.Name())
+	}
+
+	stk.Status = StatusCancelled
+	stk.DateUpdated = time.Now()
+
+	if err := c.storer.Update(ctx, stk); err != nil {
+		return Stocktake{}, fmt.Errorf("update: %w", err)
+	}
+
+	return stk, nil
+}
+
+// Query retrieves a list of existing stocktakes.
+func (c *Core) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Stocktake, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	stks, err := c.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return stks, nil
+}
+
+// Count returns the total number of stocktakes.
+func (c *Core) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	return c.storer.Count(ctx, filter)
+}
+
+// QueryByID finds the stocktake by the specified ID.
+func (c *Core) QueryByID(ctx context.Context, stocktakeID uuid.UUID) (Stocktake, error) {
+	stk, err := c.storer.QueryByID(ctx, stocktakeID)
+	if err != nil {
+		return Stocktake{}, fmt.Errorf("query: stocktakeID[%s]: %w", stocktakeID, err)
+	}
+
+	return stk, nil
+}
+
+// =============================================================================
+
+// held returns the quantity of every lot the inventory of the stocktake
+// holds right now. Only available stock is included unless the stocktake
+// counts every status, the same way the expected quantities were frozen.
+func (c *Core) held(ctx context.Context, stk Stocktake) (map[uuid.UUID]int, error) {
+	var filter inventorybus.BucketFilter
+	filter.WithInventoryID(stk.InventoryID)
+
+	lbs, err := c.inventoryCore.QueryBuckets(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("inventory.querybuckets: %s: %w", stk.InventoryID, err)
+	}
+
+	held := make(map[uuid.UUID]int, len(lbs))
+	for _, lb := range lbs {
+		held[lb.LotID] = lb.Available
+		if stk.AllStatuses {
+			held[lb.LotID] = lb.OnHand()
+		}
+	}
+
+	return held, nil
+}
+
+// queryLots returns the specified lots and fails when any of them does not
+// exist.
+func (c *Core) queryLots(ctx context.Context, lotIDs []uuid.UUID) ([]lotbus.Lot, error) {
+	if len(lotIDs) == 0 {
+		return nil, nil
+	}
+
+	lots, err := c.lotCore.QueryByIDs(ctx, lotIDs)
+	if err != nil {
+		return nil, fmt.Errorf("lot.querybyids: %w", err)
+	}
+
+	if len(lots) != len(lotIDs) {
+		return nil, fmt.Errorf("lot.querybyids: %w", lotbus.ErrNotFound)
+	}
+
+	return lots, nil
+}
+
+// reference returns the reason recorded on the adjustments of a stocktake so
+// the ledger can be traced back to the count.
+func reference(stk Stocktake) string {
+	return fmt.Sprintf("stocktake %s", stk.ID)
+}
@@ -0,0 +1,165 @@
+package stocktakedb
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/stocktakebus"
+	"github.com/google/uuid"
+)
+
+type dbStocktake struct {
+	ID           uuid.UUID      `db:"stocktake_id"`
+	InventoryID  uuid.UUID      `db:"inventory_id"`
+	Status       string         `db:"status"`
+	Note         sql.NullString `db:"note"`
+	AllStatuses  bool           `db:"all_statuses"`
+	OpenedBy     uuid.UUID      `db:"opened_by"`
+	ApprovedBy   uuid.NullUUID  `db:"approved_by"`
+	DateApproved sql.NullTime   `db:"date_approved"`
+	DateCreated  time.Time      `db:"date_created"`
+	DateUpdated  time.Time      `db:"date_updated"`
+}
+
+type dbLine struct {
+	StocktakeID uuid.UUID `db:"stocktake_id"`
+	MedicineID  uuid.UUID `db:"medicine_id"`
+	LotID       uuid.UUID `db:"lot_id"`
+	Expected    int       `db:"expected"`
+	Counted     int       `db:"counted"`
+	Devices     int       `db:"devices"`
+}
+
+type dbCount struct {
+	StocktakeID uuid.UUID `db:"stocktake_id"`
+	LotID       uuid.UUID `db:"lot_id"`
+	DeviceID    string    `db:"device_id"`
+	Quantity    int       `db:"quantity"`
+	CountedBy   uuid.UUID `db:"counted_by"`
+	DateCounted time.Time `db:"date_counted"`
+}
+
+func toDBStocktake(stk stocktakebus.Stocktake) dbStocktake {
+	return dbStocktake{
+		ID:          stk.ID,
+		InventoryID: stk.InventoryID,
+		Status:      stk.Status.Name(),
+		Note: sql.NullString{
+			String: stk.Note,
+			Valid:  stk.Note != "",
+		},
+		AllStatuses:  stk.AllStatuses,
+		OpenedBy:     stk.OpenedBy,
+		ApprovedBy:   toNullUUID(stk.ApprovedBy),
+		DateApproved: toNullTime(stk.DateApproved),
+		DateCreated:  stk.DateCreated.UTC(),
+		DateUpdated:  stk.DateUpdated.UTC(),
+	}
+}
+
+func toDBLines(stocktakeID uuid.UUID, lines []stocktakebus.Line) []dbLine {
+	dbLines := make([]dbLine, len(lines))
+	for i, line := range lines {
+		dbLines[i] = dbLine{
+			StocktakeID: stocktakeID,
+			MedicineID:  line.MedicineID,
+			LotID:       line.LotID,
+			Expected:    line.Expected,
+		}
+	}
+
+	return dbLines
+}
+
+func toDBCounts(stocktakeID uuid.UUID, counting stocktakebus.Counting, dateCounted time.Time) []dbCount {
+	counts := make([]dbCount, len(counting.Lines))
+	for i, cl := range counting.Lines {
+		counts[i] = dbCount{
+			StocktakeID: stocktakeID,
+			LotID:       cl.LotID,
+			DeviceID:    counting.DeviceID,
+			Quantity:    cl.Quantity,
+			CountedBy:   counting.UserID,
+			DateCounted: dateCounted.UTC(),
+		}
+	}
+
+	return counts
+}
+
+func toCoreStocktake(dbStk dbStocktake, dbLines []dbLine) (stocktakebus.Stocktake, error) {
+	status, err := stocktakebus.ParseStatus(dbStk.Status)
+	if err != nil {
+		return stocktakebus.Stocktake{}, fmt.Errorf("parse status: %w", err)
+	}
+
+	lines := make([]stocktakebus.Line, len(dbLines))
+	for i, dbLine := range dbLines {
+		lines[i] = stocktakebus.Line{
+			MedicineID: dbLine.MedicineID,
+			LotID:      dbLine.LotID,
+			Expected:   dbLine.Expected,
+			Counted:    dbLine.Counted,
+			Devices:    dbLine.Devices,
+		}
+	}
+
+	stk := stocktakebus.Stocktake{
+		ID:           dbStk.ID,
+		InventoryID:  dbStk.InventoryID,
+		Status:       status,
+		Note:         dbStk.Note.String,
+		AllStatuses:  dbStk.AllStatuses,
+		Lines:        lines,
+		OpenedBy:     dbStk.OpenedBy,
+		ApprovedBy:   dbStk.ApprovedBy.UUID,
+		DateApproved: toCoreTime(dbStk.DateApproved),
+		DateCreated:  dbStk.DateCreated.In(time.Local),
+		DateUpdated:  dbStk.DateUpdated.In(time.Local),
+	}
+
+	return stk, nil
+}
+
+func toCoreStocktakeSlice(dbStks []dbStocktake, dbLines []dbLine) ([]stocktakebus.Stocktake, error) {
+	linesByStocktake := make(map[uuid.UUID][]dbLine, len(dbStks))
+	for _, dbLine := range dbLines {
+		linesByStocktake[dbLine.StocktakeID] = append(linesByStocktake[dbLine.StocktakeID], dbLine)
+	}
+
+	stks := make([]stocktakebus.Stocktake, len(dbStks))
+	for i, dbStk := range dbStks {
+		var err error
+		stks[i], err = toCoreStocktake(dbStk, linesByStocktake[dbStk.ID])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stks, nil
+}
+
+// =============================================================================
+
+func toNullUUID(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{
+		UUID:  id,
+		Valid: id != uuid.Nil,
+	}
+}
+
+func toNullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{
+		Time:  t.UTC(),
+		Valid: !t.IsZero(),
+	}
+}
+
+func toCoreTime(t sql.NullTime) time.Time {
+	if !t.Valid {
+		return time.Time{}
+	}
+
+	return t.Time.In(time.Local)
+}
@@ -0,0 +1,23 @@
+package stocktakedb
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/stocktakebus"
+)
+
+var orderByFields = map[string]string{
+	stocktakebus.OrderByID:          "stocktake_id",
+	stocktakebus.OrderByStatus:      "status",
+	stocktakebus.OrderByDateCreated: "date_created",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
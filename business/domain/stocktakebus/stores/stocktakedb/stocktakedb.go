@@ -0,0 +1,272 @@
+// Package stocktakedb contains stocktake related CRUD functionality.
+package stocktakedb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb/dbarray"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/stocktakebus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Store manages the set of APIs for stocktake database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Store value replacing the sqlx DB
+// value with a sqlx DB value that is currently inside a transaction.
+func (s *Store) ExecuteUnderTransaction(tx transaction.Transaction) (stocktakebus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	store := Store{
+		log: s.log,
+		db:  ec,
+	}
+
+	return &store, nil
+}
+
+// Create inserts a new stocktake and its expected lines into the database.
+func (s *Store) Create(ctx context.Context, stk stocktakebus.Stocktake) error {
+	const q = `
+	INSERT INTO stocktakes
+		(stocktake_id, inventory_id, status, note, all_statuses, opened_by, approved_by, date_approved, date_created, date_updated)
+	VALUES
+		(:stocktake_id, :inventory_id, :status, :note, :all_statuses, :opened_by, :approved_by, :date_approved, :date_created, :date_updated)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBStocktake(stk)); err != nil {
+		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+			return fmt.Errorf("namedexeccontext: %w", stocktakebus.ErrAlreadyOpen)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return s.AddLines(ctx, stk.ID, stk.Lines)
+}
+
+// Update replaces the state of a stocktake in the database. Lines and counts
+// are stored separately and are left untouched.
+func (s *Store) Update(ctx context.Context, stk stocktakebus.Stocktake) error {
+	const q = `
+	UPDATE
+		stocktakes
+	SET
+		"status" = :status,
+		"note" = :note,
+		"approved_by" = :approved_by,
+		"date_approved" = :date_approved,
+		"date_updated" = :date_updated
+	WHERE
+		stocktake_id = :stocktake_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBStocktake(stk)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// AddLines inserts lines into a stocktake. Lines the stocktake already has
+// are left as they are.
+func (s *Store) AddLines(ctx context.Context, stocktakeID uuid.UUID, lines []stocktakebus.Line) error {
+	const q = `
+	INSERT INTO stocktake_lines
+		(stocktake_id, medicine_id, lot_id, expected)
+	VALUES
+		(:stocktake_id, :medicine_id, :lot_id, :expected)
+	ON CONFLICT (stocktake_id, lot_id) DO NOTHING`
+
+	for _, line := range toDBLines(stocktakeID, lines) {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, line); err != nil {
+			return fmt.Errorf("namedexeccontext: line: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// SaveCounts stores the quantities counted by a device, replacing whatever
+// the device counted for the same lots before.
+func (s *Store) SaveCounts(ctx context.Context, stocktakeID uuid.UUID, counting stocktakebus.Counting, dateCounted time.Time) error {
+	const q = `
+	INSERT INTO stocktake_counts
+		(stocktake_id, lot_id, device_id, quantity, counted_by, date_counted)
+	VALUES
+		(:stocktake_id, :lot_id, :device_id, :quantity, :counted_by, :date_counted)
+	ON CONFLICT (stocktake_id, lot_id, device_id) DO UPDATE SET
+		quantity = EXCLUDED.quantity,
+		counted_by = EXCLUDED.counted_by,
+		date_counted = EXCLUDED.date_counted`
+
+	for _, count := range toDBCounts(stocktakeID, counting, dateCounted) {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, count); err != nil {
+			return fmt.Errorf("namedexeccontext: count: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Query retrieves a list of existing stocktakes from the database.
+func (s *Store) Query(ctx context.Context, filter stocktakebus.QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]stocktakebus.Stocktake, error) {
+	data := map[string]interface{}{
+		"offset":        (pageNumber - 1) * rowsPerPage,
+		"rows_per_page": rowsPerPage,
+	}
+
+	const q = `
+	SELECT
+		stocktake_id, inventory_id, status, note, all_statuses, opened_by, approved_by, date_approved, date_created, date_updated
+	FROM
+		stocktakes`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbStks []dbStocktake
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbStks); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	ids := make([]string, len(dbStks))
+	for i, dbStk := range dbStks {
+		ids[i] = dbStk.ID.String()
+	}
+
+	dbLines, err := s.queryLines(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCoreStocktakeSlice(dbStks, dbLines)
+}
+
+// Count returns the total number of stocktakes in the database.
+func (s *Store) Count(ctx context.Context, filter stocktakebus.QueryFilter) (int, error) {
+	data := map[string]interface{}{}
+
+	const q = `
+	SELECT
+		count(1)
+	FROM
+		stocktakes`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID gets the specified stocktake from the database.
+func (s *Store) QueryByID(ctx context.Context, stocktakeID uuid.UUID) (stocktakebus.Stocktake, error) {
+	return s.queryByID(ctx, stocktakeID, "")
+}
+
+// QueryByIDForUpdate gets the specified stocktake from the database and locks
+// it until the surrounding transaction ends.
+func (s *Store) QueryByIDForUpdate(ctx context.Context, stocktakeID uuid.UUID) (stocktakebus.Stocktake, error) {
+	return s.queryByID(ctx, stocktakeID, " FOR UPDATE")
+}
+
+// =============================================================================
+
+func (s *Store) queryByID(ctx context.Context, stocktakeID uuid.UUID, lock string) (stocktakebus.Stocktake, error) {
+	data := struct {
+		ID string `db:"stocktake_id"`
+	}{
+		ID: stocktakeID.String(),
+	}
+
+	const q = `
+	SELECT
+		stocktake_id, inventory_id, status, note, all_statuses, opened_by, approved_by, date_approved, date_created, date_updated
+	FROM
+		stocktakes
+	WHERE
+		stocktake_id = :stocktake_id`
+
+	var dbStk dbStocktake
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q+lock, data, &dbStk); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return stocktakebus.Stocktake{}, fmt.Errorf("db: %w", stocktakebus.ErrNotFound)
+		}
+		return stocktakebus.Stocktake{}, fmt.Errorf("db: %w", err)
+	}
+
+	dbLines, err := s.queryLines(ctx, []string{dbStk.ID.String()})
+	if err != nil {
+		return stocktakebus.Stocktake{}, err
+	}
+
+	return toCoreStocktake(dbStk, dbLines)
+}
+
+// queryLines returns the lines of the stocktakes with the counts of every
+// device added up per lot.
+func (s *Store) queryLines(ctx context.Context, stocktakeIDs []string) ([]dbLine, error) {
+	data := struct {
+		ID any `db:"stocktake_id"`
+	}{
+		ID: dbarray.Array(stocktakeIDs),
+	}
+
+	const q = `
+	SELECT
+		sl.stocktake_id, sl.medicine_id, sl.lot_id, sl.expected,
+		COALESCE(SUM(sc.quantity), 0) AS counted,
+		COUNT(sc.device_id) AS devices
+	FROM
+		stocktake_lines AS sl
+	LEFT JOIN
+		stocktake_counts AS sc ON sc.stocktake_id = sl.stocktake_id AND sc.lot_id = sl.lot_id
+	WHERE
+		sl.stocktake_id = ANY(:stocktake_id)
+	GROUP BY
+		sl.stocktake_id, sl.medicine_id, sl.lot_id, sl.expected
+	ORDER BY
+		sl.stocktake_id, sl.medicine_id, sl.lot_id`
+
+	var dbLines []dbLine
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbLines); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: lines: %w", err)
+	}
+
+	return dbLines, nil
+}
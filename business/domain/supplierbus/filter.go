@@ -0,0 +1,34 @@
+package supplierbus
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryFilter holds the available fields a query can be filtered on.
+// We are using pointer semantics because the With API mutates the value.
+type QueryFilter struct {
+	ID   *uuid.UUID
+	Name *string `validate:"omitempty,min=3"`
+}
+
+// Validate can perform a check of the data against the validate tags.
+func (qf *QueryFilter) Validate() error {
+	if err := validate.Check(qf); err != nil {
+		return fmt.Errorf("validate: %w", err)
+	}
+
+	return nil
+}
+
+// WithSupplierID sets the ID field of the QueryFilter value.
+func (qf *QueryFilter) WithSupplierID(supplierID uuid.UUID) {
+	qf.ID = &supplierID
+}
+
+// WithName sets the Name field of the QueryFilter value.
+func (qf *QueryFilter) WithName(name string) {
+	qf.Name = &name
+}
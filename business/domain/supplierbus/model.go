@@ -0,0 +1,36 @@
+package supplierbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Supplier represents a vendor medicines are purchased from. Version is
+// incremented by every update.
+type Supplier struct {
+	ID          uuid.UUID
+	Name        string
+	Email       string
+	Phone       string
+	Address     string
+	Version     int
+	DateCreated time.Time
+	DateUpdated time.Time
+}
+
+// NewSupplier contains information needed to create a new supplier.
+type NewSupplier struct {
+	Name    string
+	Email   string
+	Phone   string
+	Address string
+}
+
+// UpdateSupplier contains information needed to update a supplier.
+type UpdateSupplier struct {
+	Name    *string
+	Email   *string
+	Phone   *string
+	Address *string
+}
@@ -0,0 +1,13 @@
+package supplierbus
+
+import "github.com/EnesDemirtas/medisync/business/api/order"
+
+// DefaultOrderBy represents the default way we sort.
+var DefaultOrderBy = order.NewBy(OrderByName, order.ASC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID          = "supplier_id"
+	OrderByName        = "name"
+	OrderByDateCreated = "date_created"
+)
@@ -0,0 +1,28 @@
+package supplierdb
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/EnesDemirtas/medisync/business/domain/supplierbus"
+)
+
+func applyFilter(filter supplierbus.QueryFilter, data map[string]interface{}, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["supplier_id"] = *filter.ID
+		wc = append(wc, "supplier_id = :supplier_id")
+	}
+
+	if filter.Name != nil {
+		data["name"] = fmt.Sprintf("%%%s%%", *filter.Name)
+		wc = append(wc, "name LIKE :name")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
@@ -0,0 +1,62 @@
+package supplierdb
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/supplierbus"
+	"github.com/google/uuid"
+)
+
+type dbSupplier struct {
+	ID          uuid.UUID      `db:"supplier_id"`
+	Name        string         `db:"name"`
+	Email       sql.NullString `db:"email"`
+	Phone       sql.NullString `db:"phone"`
+	Address     sql.NullString `db:"address"`
+	Version     int            `db:"version"`
+	DateCreated time.Time      `db:"date_created"`
+	DateUpdated time.Time      `db:"date_updated"`
+}
+
+func toDBSupplier(sup supplierbus.Supplier) dbSupplier {
+	return dbSupplier{
+		ID:          sup.ID,
+		Name:        sup.Name,
+		Email:       toNullString(sup.Email),
+		Phone:       toNullString(sup.Phone),
+		Address:     toNullString(sup.Address),
+		Version:     sup.Version,
+		DateCreated: sup.DateCreated.UTC(),
+		DateUpdated: sup.DateUpdated.UTC(),
+	}
+}
+
+func toCoreSupplier(dbSup dbSupplier) supplierbus.Supplier {
+	return supplierbus.Supplier{
+		ID:          dbSup.ID,
+		Name:        dbSup.Name,
+		Email:       dbSup.Email.String,
+		Phone:       dbSup.Phone.String,
+		Address:     dbSup.Address.String,
+		Version:     dbSup.Version,
+		DateCreated: dbSup.DateCreated.In(time.Local),
+		DateUpdated: dbSup.DateUpdated.In(time.Local),
+	}
+}
+
+func toCoreSupplierSlice(dbSups []dbSupplier) []supplierbus.Supplier {
+	sups := make([]supplierbus.Supplier, len(dbSups))
+	for i, dbSup := range dbSups {
+		sups[i] = toCoreSupplier(dbSup)
+	}
+
+	return sups
+}
+
+func toNullString(s string) sql.NullString {
+	return sql.NullString{
+		String: s,
+		Valid:  s != "",
+	}
+}
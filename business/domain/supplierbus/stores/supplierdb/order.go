@@ -0,0 +1,23 @@
+package supplierdb
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/supplierbus"
+)
+
+var orderByFields = map[string]string{
+	supplierbus.OrderByID:          "supplier_id",
+	supplierbus.OrderByName:        "name",
+	supplierbus.OrderByDateCreated: "date_created",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
@@ -0,0 +1,207 @@
+// Package supplierdb contains supplier related CRUD functionality.
+package supplierdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/supplierbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Store manages the set of APIs for supplier database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Store value replacing the sqlx DB
+// value with a sqlx DB value that is currently inside a transaction.
+func (s *Store) ExecuteUnderTransaction(tx transaction.Transaction) (supplierbus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	store := Store{
+		log: s.log,
+		db:  ec,
+	}
+
+	return &store, nil
+}
+
+// Create inserts a new supplier into the database.
+func (s *Store) Create(ctx context.Context, sup supplierbus.Supplier) error {
+	const q = `
+	INSERT INTO suppliers
+		(supplier_id, name, email, phone, address, version, date_created, date_updated)
+	VALUES
+		(:supplier_id, :name, :email, :phone, :address, :version, :date_created, :date_updated)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBSupplier(sup)); err != nil {
+		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+			return fmt.Errorf("namedexeccontext: %w", supplierbus.ErrUniqueName)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Update replaces a supplier document in the database. The update is refused
+// when the stored version isn't the one the change was based on.
+func (s *Store) Update(ctx context.Context, sup supplierbus.Supplier) error {
+	const q = `
+	UPDATE
+		suppliers
+	SET
+		"name" = :name,
+		"email" = :email,
+		"phone" = :phone,
+		"address" = :address,
+		"version" = :version,
+		"date_updated" = :date_updated
+	WHERE
+		supplier_id = :supplier_id AND
+		version = :version - 1
+	RETURNING
+		version`
+
+	var result struct {
+		Version int `db:"version"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, toDBSupplier(sup), &result); err != nil {
+		switch {
+		case errors.Is(err, sqldb.ErrDBNotFound):
+			return fmt.Errorf("db: %w", supplierbus.ErrVersionConflict)
+		case errors.Is(err, sqldb.ErrDBDuplicatedEntry):
+			return fmt.Errorf("db: %w", supplierbus.ErrUniqueName)
+		}
+		return fmt.Errorf("db: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a supplier from the database. The foreign key on
+// purchase_orders refuses the delete while the supplier is in use.
+func (s *Store) Delete(ctx context.Context, sup supplierbus.Supplier) error {
+	data := struct {
+		ID string `db:"supplier_id"`
+	}{
+		ID: sup.ID.String(),
+	}
+
+	const q = `
+	DELETE FROM
+		suppliers
+	WHERE
+		supplier_id = :supplier_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, data); err != nil {
+		if errors.Is(err, sqldb.ErrDBForeignKey) {
+			return fmt.Errorf("namedexeccontext: %w", supplierbus.ErrInUse)
+		}
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of existing suppliers from the database.
+func (s *Store) Query(ctx context.Context, filter supplierbus.QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]supplierbus.Supplier, error) {
+	data := map[string]interface{}{
+		"offset":        (pageNumber - 1) * rowsPerPage,
+		"rows_per_page": rowsPerPage,
+	}
+
+	const q = `
+	SELECT
+		supplier_id, name, email, phone, address, version, date_created, date_updated
+	FROM
+		suppliers`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbSups []dbSupplier
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbSups); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreSupplierSlice(dbSups), nil
+}
+
+// Count returns the total number of suppliers in the database.
+func (s *Store) Count(ctx context.Context, filter supplierbus.QueryFilter) (int, error) {
+	data := map[string]interface{}{}
+
+	const q = `
+	SELECT
+		count(1)
+	FROM
+		suppliers`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID gets the specified supplier from the database.
+func (s *Store) QueryByID(ctx context.Context, supplierID uuid.UUID) (supplierbus.Supplier, error) {
+	data := struct {
+		ID string `db:"supplier_id"`
+	}{
+		ID: supplierID.String(),
+	}
+
+	const q = `
+	SELECT
+		supplier_id, name, email, phone, address, version, date_created, date_updated
+	FROM
+		suppliers
+	WHERE
+		supplier_id = :supplier_id`
+
+	var dbSup dbSupplier
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbSup); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return supplierbus.Supplier{}, fmt.Errorf("db: %w", supplierbus.ErrNotFound)
+		}
+		return supplierbus.Supplier{}, fmt.Errorf("db: %w", err)
+	}
+
+	return toCoreSupplier(dbSup), nil
+}
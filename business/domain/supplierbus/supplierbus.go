@@ -0,0 +1,162 @@
+// Package supplierbus provides the business API for the vendors medicines
+// are purchased from.
+package supplierbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound        = errors.New("supplier not found")
+	ErrUniqueName      = errors.New("supplier name already exists")
+	ErrVersionConflict = errors.New("supplier was modified by another request")
+	ErrInUse           = errors.New("supplier is in use by purchase orders")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	ExecuteUnderTransaction(tx transaction.Transaction) (Storer, error)
+	Create(ctx context.Context, sup Supplier) error
+	Update(ctx context.Context, sup Supplier) error
+	Delete(ctx context.Context, sup Supplier) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Supplier, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, supplierID uuid.UUID) (Supplier, error)
+}
+
+// Core manages the set of APIs for supplier access.
+type Core struct {
+	log      *logger.Logger
+	delegate *delegate.Delegate
+	storer   Storer
+}
+
+// NewCore constructs a supplier core API for use.
+func NewCore(log *logger.Logger, delegate *delegate.Delegate, storer Storer) *Core {
+	return &Core{
+		log:      log,
+		delegate: delegate,
+		storer:   storer,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Core value that will use the
+// specified transaction in any store related calls.
+func (c *Core) ExecuteUnderTransaction(tx transaction.Transaction) (*Core, error) {
+	storer, err := c.storer.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	core := Core{
+		log:      c.log,
+		delegate: c.delegate,
+		storer:   storer,
+	}
+
+	return &core, nil
+}
+
+// Create adds a new supplier to the system.
+func (c *Core) Create(ctx context.Context, ns NewSupplier) (Supplier, error) {
+	now := time.Now()
+
+	sup := Supplier{
+		ID:          uuid.New(),
+		Name:        ns.Name,
+		Email:       ns.Email,
+		Phone:       ns.Phone,
+		Address:     ns.Address,
+		Version:     1,
+		DateCreated: now,
+		DateUpdated: now,
+	}
+
+	if err := c.storer.Create(ctx, sup); err != nil {
+		return Supplier{}, fmt.Errorf("create: %w", err)
+	}
+
+	return sup, nil
+}
+
+// Update modifies information about a supplier.
+func (c *Core) Update(ctx context.Context, sup Supplier, us UpdateSupplier) (Supplier, error) {
+	if us.Name != nil {
+		sup.Name = *us.Name
+	}
+
+	if us.Email != nil {
+		sup.Email = *us.Email
+	}
+
+	if us.Phone != nil {
+		sup.Phone = *us.Phone
+	}
+
+	if us.Address != nil {
+		sup.Address = *us.Address
+	}
+
+	sup.Version++
+	sup.DateUpdated = time.Now()
+
+	if err := c.storer.Update(ctx, sup); err != nil {
+		return Supplier{}, fmt.Errorf("update: %w", err)
+	}
+
+	return sup, nil
+}
+
+// Delete removes the specified supplier. A supplier with purchase orders
+// can't be removed.
+func (c *Core) Delete(ctx context.Context, sup Supplier) error {
+	if err := c.storer.Delete(ctx, sup); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of existing suppliers.
+func (c *Core) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Supplier, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	sups, err := c.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return sups, nil
+}
+
+// Count returns the total number of suppliers.
+func (c *Core) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	return c.storer.Count(ctx, filter)
+}
+
+// QueryByID finds the supplier by the specified ID.
+func (c *Core) QueryByID(ctx context.Context, supplierID uuid.UUID) (Supplier, error) {
+	sup, err := c.storer.QueryByID(ctx, supplierID)
+	if err != nil {
+		return Supplier{}, fmt.Errorf("query: supplierID[%s]: %w", supplierID, err)
+	}
+
+	return sup, nil
+}
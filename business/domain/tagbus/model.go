@@ -2,10 +2,11 @@ package tagbus
 
 import "github.com/google/uuid"
 
-// Tag represents a single tag.
+// Tag represents a single tag. Version is incremented by every update.
 type Tag struct {
 	ID		uuid.UUID
 	Name 	string
+	Version	int
 }
 
 // NewTag contains information needed to create a new tag.
@@ -4,14 +4,14 @@ import (
 	"bytes"
 	"strings"
 
-	"github.com/EnesDemirtas/medisync/business/core/crud/tagbus"
+	"github.com/EnesDemirtas/medisync/business/domain/tagbus"
 )
 
 func (s *Store) applyFilter(filter tagbus.QueryFilter, data map[string]interface{}, buf *bytes.Buffer) {
 	var wc []string
 
 	if filter.ID != nil {
-		data["id"] = *filter.ID
+		data["tag_id"] = *filter.ID
 		wc = append(wc, "tag_id = :tag_id")
 	}
 
@@ -3,19 +3,21 @@ package tagdb
 import (
 	"fmt"
 
-	"github.com/EnesDemirtas/medisync/business/core/crud/tagbus"
+	"github.com/EnesDemirtas/medisync/business/domain/tagbus"
 	"github.com/google/uuid"
 )
 
 type dbTag struct {
 	ID		uuid.UUID `db:"tag_id"`
 	Name	string	  `db:"name"`
+	Version	int		  `db:"version"`
 }
 
 func toDBTag(tag tagbus.Tag) dbTag {
 	tagDB := dbTag{
 		ID:		tag.ID,
 		Name:	tag.Name,
+		Version: tag.Version,
 	}
 
 	return tagDB
@@ -25,6 +27,7 @@ func toCoreTag(dbTag dbTag) (tagbus.Tag, error) {
 	tag := tagbus.Tag{
 		ID:		dbTag.ID,
 		Name:	dbTag.Name,
+		Version: dbTag.Version,
 	}
 
 	return tag, nil
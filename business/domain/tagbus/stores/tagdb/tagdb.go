@@ -8,7 +8,7 @@ import (
 	"fmt"
 
 	"github.com/EnesDemirtas/medisync/business/api/order"
-	"github.com/EnesDemirtas/medisync/business/core/crud/tagbus"
+	"github.com/EnesDemirtas/medisync/business/domain/tagbus"
 	"github.com/EnesDemirtas/medisync/business/data/sqldb"
 	"github.com/EnesDemirtas/medisync/business/data/sqldb/dbarray"
 	"github.com/EnesDemirtas/medisync/business/data/transaction"
@@ -52,9 +52,9 @@ func (s *Store) ExecuteUnderTransaction(tx transaction.Transaction) (tagbus.Stor
 func (s *Store) Create(ctx context.Context, tag tagbus.Tag) error {
 	const q = `
 	INSERT INTO tags
-		(tag_id, name)
+		(tag_id, name, version)
 	VALUES
-		(:tag_id, :name)`
+		(:tag_id, :name, :version)`
 	
 	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBTag(tag)); err != nil {
 		return fmt.Errorf("namedexeccontext: %w", err)
@@ -63,21 +63,50 @@ func (s *Store) Create(ctx context.Context, tag tagbus.Tag) error {
 	return nil
 }
 
-// Delete removes a tag from the database.
-func (s *Store) Delete(ctx context.Context, tag tagbus.Tag) error {
+// Delete removes a tag from the database. Without cascade the foreign key on
+// medicine_tags refuses the delete while the tag is in use. With cascade the
+// tag is detached from its medicines, whose versions are bumped since their
+// representation changes, all in the same statement.
+func (s *Store) Delete(ctx context.Context, tag tagbus.Tag, cascade bool) error {
 	data := struct {
 		ID string `db:"tag_id"`
 	}{
 		ID: tag.ID.String(),
 	}
 
-	const q = `
+	q := `
 	DELETE FROM
 		tags
 	WHERE
 		tag_id = :tag_id`
-	
+
+	if cascade {
+		q = `
+	WITH detached AS (
+		DELETE FROM
+			medicine_tags
+		WHERE
+			tag_id = :tag_id
+		RETURNING
+			medicine_id
+	), bumped AS (
+		UPDATE
+			medicines
+		SET
+			"version" = version + 1
+		WHERE
+			medicine_id IN (SELECT medicine_id FROM detached)
+	)
+	DELETE FROM
+		tags
+	WHERE
+		tag_id = :tag_id`
+	}
+
 	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, data); err != nil {
+		if errors.Is(err, sqldb.ErrDBForeignKey) {
+			return fmt.Errorf("namedexeccontext: %w", tagbus.ErrInUse)
+		}
 		return fmt.Errorf("namedexeccontext: %w", err)
 	}
 
@@ -90,12 +119,22 @@ func (s *Store) Update(ctx context.Context, tag tagbus.Tag) error {
 	UPDATE
 		tags
 	SET
-		"name"	= :name
+		"name"	= :name,
+		"version" = :version
 	WHERE
-		tag_id = :tag_id`
-	
-	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBTag(tag)); err != nil {
-		return fmt.Errorf("namedexeccontext: %w", err)
+		tag_id = :tag_id AND
+		version = :version - 1
+	RETURNING
+		version`
+
+	var result struct {
+		Version int `db:"version"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, toDBTag(tag), &result); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return fmt.Errorf("db: %w", tagbus.ErrVersionConflict)
+		}
+		return fmt.Errorf("db: %w", err)
 	}
 
 	return nil
@@ -110,7 +149,7 @@ func (s *Store) Query(ctx context.Context, filter tagbus.QueryFilter, orderBy or
 
 	const q = `
 	SELECT
-		tag_id, name
+		tag_id, name, version
 	FROM
 		tags`
 	
@@ -171,7 +210,7 @@ func (s *Store) QueryByID(ctx context.Context, tagID uuid.UUID) (tagbus.Tag, err
 
 	const q = `
 	SELECT
-		tag_id, name
+		tag_id, name, version
 	FROM
 		tags
 	WHERE
@@ -203,7 +242,7 @@ func (s *Store) QueryByIDs(ctx context.Context, tagIDs []uuid.UUID) ([]tagbus.Ta
 
 	const q = `
 	SELECT
-		tag_id, name
+		tag_id, name, version
 	FROM
 		tags
 	WHERE
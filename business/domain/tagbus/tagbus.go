@@ -16,7 +16,11 @@ import (
 )
 
 // Set of error variables for CRUD operations.
-var ErrNotFound = errors.New("tag not found")
+var (
+	ErrNotFound        = errors.New("tag not found")
+	ErrVersionConflict = errors.New("tag was modified by another request")
+	ErrInUse           = errors.New("tag is in use by medicines")
+)
 
 // Storer interface declares the behavior this package needs to persist and
 // retrieve data.
@@ -24,7 +28,7 @@ type Storer interface {
 	ExecuteUnderTransaction(tx transaction.Transaction) (Storer, error)
 	Create(ctx context.Context, tag Tag) error
 	Update(ctx context.Context, tag Tag) error
-	Delete(ctx context.Context, tag Tag) error
+	Delete(ctx context.Context, tag Tag, cascade bool) error
 	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Tag, error)
 	Count(ctx context.Context, filter QueryFilter) (int, error)
 	QueryByID(ctx context.Context, tagID uuid.UUID) (Tag, error)
@@ -70,6 +74,7 @@ func (c *Core) Create(ctx context.Context, newTag NewTag) (Tag, error) {
 	tag := Tag{
 		ID:		uuid.New(),
 		Name:	newTag.Name,
+		Version: 1,
 	}
 
 	if err := c.storer.Create(ctx, tag); err != nil {
@@ -85,6 +90,8 @@ func (c *Core) Update(ctx context.Context, tag Tag, updatedTag UpdateTag) (Tag,
 		tag.Name = *updatedTag.Name
 	}
 
+	tag.Version++
+
 	if err := c.storer.Update(ctx, tag); err != nil {
 		return Tag{}, fmt.Errorf("update: %w", err)
 	}
@@ -92,9 +99,10 @@ func (c *Core) Update(ctx context.Context, tag Tag, updatedTag UpdateTag) (Tag,
 	return tag, nil
 }
 
-// Delete removes the specified tag.
-func (c *Core) Delete(ctx context.Context, tag Tag) error {
-	if err := c.storer.Delete(ctx, tag); err != nil {
+// Delete removes the specified tag. A tag still attached to medicines is only
+// removed when cascade is set, in which case it is detached from them first.
+func (c *Core) Delete(ctx context.Context, tag Tag, cascade bool) error {
+	if err := c.storer.Delete(ctx, tag, cascade); err != nil {
 		return fmt.Errorf("delete: %w", err)
 	}
 
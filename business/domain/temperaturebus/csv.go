@@ -0,0 +1,52 @@
+package temperaturebus
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DecodeCSV reads readings exported by a data logger. Every record holds the
+// time a reading was taken in RFC3339 and the temperature in celsius, an
+// optional header row naming the columns is skipped.
+func DecodeCSV(r io.Reader) ([]NewReading, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 2
+	cr.TrimLeadingSpace = true
+
+	var nrs []NewReading
+	for line := 1; ; line++ {
+		record, err := cr.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCSV, err)
+		}
+
+		if line == 1 && strings.EqualFold(record[0], "recorded_at") {
+			continue
+		}
+
+		recorded, err := time.Parse(time.RFC3339, record[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %s", ErrInvalidCSV, line, err)
+		}
+
+		celsius, err := strconv.ParseFloat(record[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %s", ErrInvalidCSV, line, err)
+		}
+
+		nrs = append(nrs, NewReading{
+			Celsius:      celsius,
+			DateRecorded: recorded,
+		})
+	}
+
+	return nrs, nil
+}
@@ -0,0 +1,72 @@
+package temperaturebus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/go-json-experiment/json"
+	"github.com/google/uuid"
+)
+
+// Domain represents the name of this domain.
+const Domain = "temperature"
+
+// Set of delegate actions.
+const (
+	ActionExcursionStarted = "excursionstarted"
+	ActionExcursionEnded   = "excursionended"
+)
+
+// ActionExcursionParms represents the parameters for the excursion started
+// and ended actions. DateEnded is zero for a started excursion that is still
+// ongoing.
+type ActionExcursionParms struct {
+	ExcursionID uuid.UUID
+	InventoryID uuid.UUID
+	MinCelsius  float64
+	MaxCelsius  float64
+	PeakCelsius float64
+	DateStarted time.Time
+	DateEnded   time.Time
+	Quarantined int
+}
+
+// String returns a string representation of the action parameters.
+func (ae *ActionExcursionParms) String() string {
+	return fmt.Sprintf("&EventParamsExcursion{ExcursionID:%v, InventoryID:%v, PeakCelsius:%v}", ae.ExcursionID, ae.InventoryID, ae.PeakCelsius)
+}
+
+// Marshal returns the event parameters encoded as JSON.
+func (ae *ActionExcursionParms) Marshal() ([]byte, error) {
+	return json.Marshal(ae)
+}
+
+// ActionExcursionData constructs the data for the specified excursion
+// action.
+func ActionExcursionData(action string, exc Excursion) delegate.Data {
+	params := ActionExcursionParms{
+		ExcursionID: exc.ID,
+		InventoryID: exc.InventoryID,
+		MinCelsius:  exc.MinCelsius,
+		MaxCelsius:  exc.MaxCelsius,
+		PeakCelsius: exc.PeakCelsius,
+		DateStarted: exc.DateStarted,
+		DateEnded:   exc.DateEnded,
+	}
+
+	for _, s := range exc.Stock {
+		params.Quarantined += s.Quantity
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return delegate.Data{
+		Domain:    Domain,
+		Action:    action,
+		RawParams: rawParams,
+	}
+}
@@ -0,0 +1,65 @@
+package temperaturebus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryFilter holds the available fields a query can be filtered on.
+// We are using pointer semantics because the With API mutates the value.
+type QueryFilter struct {
+	ID               *uuid.UUID
+	InventoryID      *uuid.UUID
+	Ongoing          *bool
+	StartStartedDate *time.Time
+	EndStartedDate   *time.Time
+}
+
+// Validate can perform a check of the data against the validate tags.
+func (qf *QueryFilter) Validate() error {
+	if err := validate.Check(qf); err != nil {
+		return fmt.Errorf("validate: %w", err)
+	}
+
+	return nil
+}
+
+// WithExcursionID sets the ID field of the QueryFilter value.
+func (qf *QueryFilter) WithExcursionID(excursionID uuid.UUID) {
+	qf.ID = &excursionID
+}
+
+// WithInventoryID sets the InventoryID field of the QueryFilter value.
+func (qf *QueryFilter) WithInventoryID(inventoryID uuid.UUID) {
+	qf.InventoryID = &inventoryID
+}
+
+// WithOngoing sets the Ongoing field of the QueryFilter value.
+func (qf *QueryFilter) WithOngoing(ongoing bool) {
+	qf.Ongoing = &ongoing
+}
+
+// WithStartStartedDate sets the StartStartedDate field of the QueryFilter
+// value.
+func (qf *QueryFilter) WithStartStartedDate(startDate time.Time) {
+	d := startDate.UTC()
+	qf.StartStartedDate = &d
+}
+
+// WithEndStartedDate sets the EndStartedDate field of the QueryFilter value.
+func (qf *QueryFilter) WithEndStartedDate(endDate time.Time) {
+	d := endDate.UTC()
+	qf.EndStartedDate = &d
+}
+
+// ReadingFilter holds the fields the readings of an inventory are queried
+// by. Readings recorded from StartDate up to and including EndDate are
+// returned.
+type ReadingFilter struct {
+	InventoryID uuid.UUID
+	StartDate   time.Time
+	EndDate     time.Time
+}
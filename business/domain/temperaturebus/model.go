@@ -0,0 +1,94 @@
+package temperaturebus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Range represents the temperatures an inventory has to be kept within.
+// Readings outside of it start an excursion.
+type Range struct {
+	InventoryID uuid.UUID
+	MinCelsius  float64
+	MaxCelsius  float64
+	DateCreated time.Time
+	DateUpdated time.Time
+}
+
+// Contains reports whether the temperature is within the range.
+func (rng Range) Contains(celsius float64) bool {
+	return celsius >= rng.MinCelsius && celsius <= rng.MaxCelsius
+}
+
+// NewRange contains information needed to configure the range of an
+// inventory.
+type NewRange struct {
+	InventoryID uuid.UUID
+	MinCelsius  float64
+	MaxCelsius  float64
+}
+
+// Reading represents a temperature recorded by a data logger in an
+// inventory.
+type Reading struct {
+	ID           uuid.UUID
+	InventoryID  uuid.UUID
+	Celsius      float64
+	DateRecorded time.Time
+	DateCreated  time.Time
+}
+
+// NewReading contains a single temperature recorded by a data logger.
+type NewReading struct {
+	Celsius      float64
+	DateRecorded time.Time
+}
+
+// NewIngestion contains information needed to store a batch of readings
+// taken in an inventory. UserID is who uploaded the batch, stock flagged for
+// review is moved under their name.
+type NewIngestion struct {
+	InventoryID uuid.UUID
+	UserID      uuid.UUID
+	Readings    []NewReading
+}
+
+// Ingestion represents the outcome of storing a batch of readings. Excursions
+// holds every excursion the batch started, extended or ended.
+type Ingestion struct {
+	InventoryID uuid.UUID
+	Readings    int
+	Excursions  []Excursion
+}
+
+// Excursion represents a period of time the temperature of an inventory was
+// outside of its range. The range is copied from the one configured when the
+// excursion started. DateEnded is left zero while the excursion is ongoing.
+// Stock is what was available in the inventory when the excursion started,
+// it is moved into the quarantined bucket until QA reviews it.
+type Excursion struct {
+	ID          uuid.UUID
+	InventoryID uuid.UUID
+	MinCelsius  float64
+	MaxCelsius  float64
+	PeakCelsius float64
+	DatePeak    time.Time
+	DateStarted time.Time
+	DateEnded   time.Time
+	Stock       []Stock
+	DateCreated time.Time
+	DateUpdated time.Time
+}
+
+// Ongoing reports whether the excursion has not ended yet.
+func (exc Excursion) Ongoing() bool {
+	return exc.DateEnded.IsZero()
+}
+
+// Stock represents stock of a lot quarantined because of an excursion.
+type Stock struct {
+	MedicineID uuid.UUID
+	LotID      uuid.UUID
+	Quantity   int
+}
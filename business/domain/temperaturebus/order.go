@@ -0,0 +1,14 @@
+package temperaturebus
+
+import "github.com/EnesDemirtas/medisync/business/api/order"
+
+// DefaultOrderBy represents the default way we sort.
+var DefaultOrderBy = order.NewBy(OrderByDateStarted, order.DESC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID          = "excursion_id"
+	OrderByInventoryID = "inventory_id"
+	OrderByPeakCelsius = "peak_celsius"
+	OrderByDateStarted = "date_started"
+)
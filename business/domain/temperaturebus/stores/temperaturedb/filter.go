@@ -0,0 +1,45 @@
+package temperaturedb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/EnesDemirtas/medisync/business/domain/temperaturebus"
+)
+
+func applyFilter(filter temperaturebus.QueryFilter, data map[string]interface{}, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["excursion_id"] = *filter.ID
+		wc = append(wc, "excursion_id = :excursion_id")
+	}
+
+	if filter.InventoryID != nil {
+		data["inventory_id"] = *filter.InventoryID
+		wc = append(wc, "inventory_id = :inventory_id")
+	}
+
+	if filter.Ongoing != nil {
+		if *filter.Ongoing {
+			wc = append(wc, "date_ended IS NULL")
+		} else {
+			wc = append(wc, "date_ended IS NOT NULL")
+		}
+	}
+
+	if filter.StartStartedDate != nil {
+		data["start_date_started"] = *filter.StartStartedDate
+		wc = append(wc, "date_started >= :start_date_started")
+	}
+
+	if filter.EndStartedDate != nil {
+		data["end_date_started"] = *filter.EndStartedDate
+		wc = append(wc, "date_started <= :end_date_started")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
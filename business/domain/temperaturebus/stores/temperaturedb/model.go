@@ -0,0 +1,170 @@
+package temperaturedb
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/temperaturebus"
+	"github.com/google/uuid"
+)
+
+type dbRange struct {
+	InventoryID uuid.UUID `db:"inventory_id"`
+	MinCelsius  float64   `db:"min_celsius"`
+	MaxCelsius  float64   `db:"max_celsius"`
+	DateCreated time.Time `db:"date_created"`
+	DateUpdated time.Time `db:"date_updated"`
+}
+
+type dbReading struct {
+	ID           uuid.UUID `db:"reading_id"`
+	InventoryID  uuid.UUID `db:"inventory_id"`
+	Celsius      float64   `db:"celsius"`
+	DateRecorded time.Time `db:"date_recorded"`
+	DateCreated  time.Time `db:"date_created"`
+}
+
+type dbExcursion struct {
+	ID          uuid.UUID    `db:"excursion_id"`
+	InventoryID uuid.UUID    `db:"inventory_id"`
+	MinCelsius  float64      `db:"min_celsius"`
+	MaxCelsius  float64      `db:"max_celsius"`
+	PeakCelsius float64      `db:"peak_celsius"`
+	DatePeak    time.Time    `db:"date_peak"`
+	DateStarted time.Time    `db:"date_started"`
+	DateEnded   sql.NullTime `db:"date_ended"`
+	DateCreated time.Time    `db:"date_created"`
+	DateUpdated time.Time    `db:"date_updated"`
+}
+
+type dbStock struct {
+	ExcursionID uuid.UUID `db:"excursion_id"`
+	MedicineID  uuid.UUID `db:"medicine_id"`
+	LotID       uuid.UUID `db:"lot_id"`
+	Quantity    int       `db:"quantity"`
+}
+
+func toDBRange(rng temperaturebus.Range) dbRange {
+	return dbRange{
+		InventoryID: rng.InventoryID,
+		MinCelsius:  rng.MinCelsius,
+		MaxCelsius:  rng.MaxCelsius,
+		DateCreated: rng.DateCreated.UTC(),
+		DateUpdated: rng.DateUpdated.UTC(),
+	}
+}
+
+func toCoreRange(dbRng dbRange) temperaturebus.Range {
+	return temperaturebus.Range{
+		InventoryID: dbRng.InventoryID,
+		MinCelsius:  dbRng.MinCelsius,
+		MaxCelsius:  dbRng.MaxCelsius,
+		DateCreated: dbRng.DateCreated.In(time.Local),
+		DateUpdated: dbRng.DateUpdated.In(time.Local),
+	}
+}
+
+func toDBReading(rd temperaturebus.Reading) dbReading {
+	return dbReading{
+		ID:           rd.ID,
+		InventoryID:  rd.InventoryID,
+		Celsius:      rd.Celsius,
+		DateRecorded: rd.DateRecorded.UTC(),
+		DateCreated:  rd.DateCreated.UTC(),
+	}
+}
+
+func toCoreReadingSlice(dbRds []dbReading) []temperaturebus.Reading {
+	rds := make([]temperaturebus.Reading, len(dbRds))
+	for i, dbRd := range dbRds {
+		rds[i] = temperaturebus.Reading{
+			ID:           dbRd.ID,
+			InventoryID:  dbRd.InventoryID,
+			Celsius:      dbRd.Celsius,
+			DateRecorded: dbRd.DateRecorded.In(time.Local),
+			DateCreated:  dbRd.DateCreated.In(time.Local),
+		}
+	}
+
+	return rds
+}
+
+func toDBExcursion(exc temperaturebus.Excursion) dbExcursion {
+	return dbExcursion{
+		ID:          exc.ID,
+		InventoryID: exc.InventoryID,
+		MinCelsius:  exc.MinCelsius,
+		MaxCelsius:  exc.MaxCelsius,
+		PeakCelsius: exc.PeakCelsius,
+		DatePeak:    exc.DatePeak.UTC(),
+		DateStarted: exc.DateStarted.UTC(),
+		DateEnded:   toNullTime(exc.DateEnded),
+		DateCreated: exc.DateCreated.UTC(),
+		DateUpdated: exc.DateUpdated.UTC(),
+	}
+}
+
+func toDBStock(excursionID uuid.UUID, s temperaturebus.Stock) dbStock {
+	return dbStock{
+		ExcursionID: excursionID,
+		MedicineID:  s.MedicineID,
+		LotID:       s.LotID,
+		Quantity:    s.Quantity,
+	}
+}
+
+func toCoreExcursion(dbExc dbExcursion, dbStk []dbStock) temperaturebus.Excursion {
+	stock := make([]temperaturebus.Stock, len(dbStk))
+	for i, dbS := range dbStk {
+		stock[i] = temperaturebus.Stock{
+			MedicineID: dbS.MedicineID,
+			LotID:      dbS.LotID,
+			Quantity:   dbS.Quantity,
+		}
+	}
+
+	return temperaturebus.Excursion{
+		ID:          dbExc.ID,
+		InventoryID: dbExc.InventoryID,
+		MinCelsius:  dbExc.MinCelsius,
+		MaxCelsius:  dbExc.MaxCelsius,
+		PeakCelsius: dbExc.PeakCelsius,
+		DatePeak:    dbExc.DatePeak.In(time.Local),
+		DateStarted: dbExc.DateStarted.In(time.Local),
+		DateEnded:   toCoreTime(dbExc.DateEnded),
+		Stock:       stock,
+		DateCreated: dbExc.DateCreated.In(time.Local),
+		DateUpdated: dbExc.DateUpdated.In(time.Local),
+	}
+}
+
+func toCoreExcursionSlice(dbExcs []dbExcursion, dbStk []dbStock) []temperaturebus.Excursion {
+	stockByExcursion := make(map[uuid.UUID][]dbStock, len(dbExcs))
+	for _, dbS := range dbStk {
+		stockByExcursion[dbS.ExcursionID] = append(stockByExcursion[dbS.ExcursionID], dbS)
+	}
+
+	excs := make([]temperaturebus.Excursion, len(dbExcs))
+	for i, dbExc := range dbExcs {
+		excs[i] = toCoreExcursion(dbExc, stockByExcursion[dbExc.ID])
+	}
+
+	return excs
+}
+
+// =============================================================================
+
+func toNullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{
+		Time:  t.UTC(),
+		Valid: !t.IsZero(),
+	}
+}
+
+func toCoreTime(t sql.NullTime) time.Time {
+	if !t.Valid {
+		return time.Time{}
+	}
+
+	return t.Time.In(time.Local)
+}
@@ -0,0 +1,24 @@
+package temperaturedb
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/temperaturebus"
+)
+
+var orderByFields = map[string]string{
+	temperaturebus.OrderByID:          "excursion_id",
+	temperaturebus.OrderByInventoryID: "inventory_id",
+	temperaturebus.OrderByPeakCelsius: "peak_celsius",
+	temperaturebus.OrderByDateStarted: "date_started",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
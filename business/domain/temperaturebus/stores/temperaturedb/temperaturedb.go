@@ -0,0 +1,328 @@
+// Package temperaturedb contains temperature reading and excursion related
+// CRUD functionality.
+package temperaturedb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb/dbarray"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/temperaturebus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Store manages the set of APIs for temperature database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Store value replacing the sqlx DB
+// value with a sqlx DB value that is currently inside a transaction.
+func (s *Store) ExecuteUnderTransaction(tx transaction.Transaction) (temperaturebus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	store := Store{
+		log: s.log,
+		db:  ec,
+	}
+
+	return &store, nil
+}
+
+// SetRange inserts or replaces the temperature range of an inventory. The
+// creation date of an existing row is kept.
+func (s *Store) SetRange(ctx context.Context, rng temperaturebus.Range) error {
+	const q = `
+	INSERT INTO temperature_ranges
+		(inventory_id, min_celsius, max_celsius, date_created, date_updated)
+	VALUES
+		(:inventory_id, :min_celsius, :max_celsius, :date_created, :date_updated)
+	ON CONFLICT (inventory_id) DO UPDATE SET
+		"min_celsius" = EXCLUDED.min_celsius,
+		"max_celsius" = EXCLUDED.max_celsius,
+		"date_updated" = EXCLUDED.date_updated`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBRange(rng)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// QueryRange gets the temperature range of an inventory from the database.
+func (s *Store) QueryRange(ctx context.Context, inventoryID uuid.UUID) (temperaturebus.Range, error) {
+	return s.queryRange(ctx, inventoryID, "")
+}
+
+// QueryRangeForUpdate gets the temperature range of an inventory from the
+// database and locks it until the surrounding transaction ends.
+func (s *Store) QueryRangeForUpdate(ctx context.Context, inventoryID uuid.UUID) (temperaturebus.Range, error) {
+	return s.queryRange(ctx, inventoryID, " FOR UPDATE")
+}
+
+// AddReadings inserts a batch of readings into the database.
+func (s *Store) AddReadings(ctx context.Context, rds []temperaturebus.Reading) error {
+	const q = `
+	INSERT INTO temperature_readings
+		(reading_id, inventory_id, celsius, date_recorded, date_created)
+	VALUES
+		(:reading_id, :inventory_id, :celsius, :date_recorded, :date_created)`
+
+	for _, rd := range rds {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBReading(rd)); err != nil {
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// QueryReadings returns the readings of an inventory recorded over a period.
+func (s *Store) QueryReadings(ctx context.Context, filter temperaturebus.ReadingFilter) ([]temperaturebus.Reading, error) {
+	data := map[string]interface{}{
+		"inventory_id": filter.InventoryID,
+		"start_date":   filter.StartDate.UTC(),
+		"end_date":     filter.EndDate.UTC(),
+	}
+
+	const q = `
+	SELECT
+		reading_id, inventory_id, celsius, date_recorded, date_created
+	FROM
+		temperature_readings
+	WHERE
+		inventory_id = :inventory_id AND
+		date_recorded >= :start_date AND
+		date_recorded <= :end_date
+	ORDER BY
+		date_recorded, reading_id`
+
+	var dbRds []dbReading
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbRds); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toCoreReadingSlice(dbRds), nil
+}
+
+// Create inserts a new excursion and the stock it quarantined into the
+// database.
+func (s *Store) Create(ctx context.Context, exc temperaturebus.Excursion) error {
+	const q = `
+	INSERT INTO excursions
+		(excursion_id, inventory_id, min_celsius, max_celsius, peak_celsius, date_peak, date_started, date_ended, date_created, date_updated)
+	VALUES
+		(:excursion_id, :inventory_id, :min_celsius, :max_celsius, :peak_celsius, :date_peak, :date_started, :date_ended, :date_created, :date_updated)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBExcursion(exc)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	const qStock = `
+	INSERT INTO excursion_stock
+		(excursion_id, medicine_id, lot_id, quantity)
+	VALUES
+		(:excursion_id, :medicine_id, :lot_id, :quantity)`
+
+	for _, stock := range exc.Stock {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, qStock, toDBStock(exc.ID, stock)); err != nil {
+			return fmt.Errorf("namedexeccontext: stock: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Update replaces the peak and end of an excursion in the database. The
+// quarantined stock is left untouched.
+func (s *Store) Update(ctx context.Context, exc temperaturebus.Excursion) error {
+	const q = `
+	UPDATE
+		excursions
+	SET
+		"peak_celsius" = :peak_celsius,
+		"date_peak" = :date_peak,
+		"date_ended" = :date_ended,
+		"date_updated" = :date_updated
+	WHERE
+		excursion_id = :excursion_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBExcursion(exc)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of existing excursions from the database.
+func (s *Store) Query(ctx context.Context, filter temperaturebus.QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]temperaturebus.Excursion, error) {
+	data := map[string]interface{}{
+		"offset":        (pageNumber - 1) * rowsPerPage,
+		"rows_per_page": rowsPerPage,
+	}
+
+	const q = `
+	SELECT
+		excursion_id, inventory_id, min_celsius, max_celsius, peak_celsius, date_peak, date_started, date_ended, date_created, date_updated
+	FROM
+		excursions`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbExcs []dbExcursion
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbExcs); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	ids := make([]string, len(dbExcs))
+	for i, dbExc := range dbExcs {
+		ids[i] = dbExc.ID.String()
+	}
+
+	dbStk, err := s.queryStock(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCoreExcursionSlice(dbExcs, dbStk), nil
+}
+
+// Count returns the total number of excursions in the database.
+func (s *Store) Count(ctx context.Context, filter temperaturebus.QueryFilter) (int, error) {
+	data := map[string]interface{}{}
+
+	const q = `
+	SELECT
+		count(1)
+	FROM
+		excursions`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryOngoing gets the excursion of an inventory that has not ended yet and
+// locks it until the surrounding transaction ends.
+func (s *Store) QueryOngoing(ctx context.Context, inventoryID uuid.UUID) (temperaturebus.Excursion, error) {
+	data := struct {
+		InventoryID string `db:"inventory_id"`
+	}{
+		InventoryID: inventoryID.String(),
+	}
+
+	const q = `
+	SELECT
+		excursion_id, inventory_id, min_celsius, max_celsius, peak_celsius, date_peak, date_started, date_ended, date_created, date_updated
+	FROM
+		excursions
+	WHERE
+		inventory_id = :inventory_id AND
+		date_ended IS NULL
+	FOR UPDATE`
+
+	var dbExc dbExcursion
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbExc); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return temperaturebus.Excursion{}, fmt.Errorf("db: %w", temperaturebus.ErrNotFound)
+		}
+		return temperaturebus.Excursion{}, fmt.Errorf("db: %w", err)
+	}
+
+	dbStk, err := s.queryStock(ctx, []string{dbExc.ID.String()})
+	if err != nil {
+		return temperaturebus.Excursion{}, err
+	}
+
+	return toCoreExcursion(dbExc, dbStk), nil
+}
+
+// =============================================================================
+
+func (s *Store) queryRange(ctx context.Context, inventoryID uuid.UUID, lock string) (temperaturebus.Range, error) {
+	data := struct {
+		InventoryID string `db:"inventory_id"`
+	}{
+		InventoryID: inventoryID.String(),
+	}
+
+	const q = `
+	SELECT
+		inventory_id, min_celsius, max_celsius, date_created, date_updated
+	FROM
+		temperature_ranges
+	WHERE
+		inventory_id = :inventory_id`
+
+	var dbRng dbRange
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q+lock, data, &dbRng); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return temperaturebus.Range{}, fmt.Errorf("db: %w", temperaturebus.ErrRangeNotFound)
+		}
+		return temperaturebus.Range{}, fmt.Errorf("db: %w", err)
+	}
+
+	return toCoreRange(dbRng), nil
+}
+
+// queryStock returns the stock quarantined by the excursions.
+func (s *Store) queryStock(ctx context.Context, excursionIDs []string) ([]dbStock, error) {
+	data := struct {
+		ID any `db:"excursion_id"`
+	}{
+		ID: dbarray.Array(excursionIDs),
+	}
+
+	const q = `
+	SELECT
+		excursion_id, medicine_id, lot_id, quantity
+	FROM
+		excursion_stock
+	WHERE
+		excursion_id = ANY(:excursion_id)
+	ORDER BY
+		excursion_id, medicine_id, lot_id`
+
+	var dbStk []dbStock
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbStk); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: stock: %w", err)
+	}
+
+	return dbStk, nil
+}
@@ -0,0 +1,372 @@
+// Package temperaturebus provides the business API for temperature readings
+// taken by data loggers in inventories. Every inventory can be configured
+// with the range it has to be kept within. A reading outside of the range
+// starts an excursion which lasts until a reading is back within it, the
+// excursion keeps its start, end and the peak temperature reached. When an
+// excursion starts the available stock of the inventory is moved into the
+// quarantined bucket so QA can review it before it is released again. Other
+// domains are told when an excursion starts and ends through the delegate.
+package temperaturebus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound       = errors.New("excursion not found")
+	ErrRangeNotFound  = errors.New("temperature range not found")
+	ErrInvalidRange   = errors.New("temperature range minimum must not be above its maximum")
+	ErrNoReadings     = errors.New("no readings provided")
+	ErrInvalidReading = errors.New("reading time required")
+	ErrInvalidCSV     = errors.New("invalid readings csv")
+	ErrInvalidPeriod  = errors.New("period end must not be before its start")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	ExecuteUnderTransaction(tx transaction.Transaction) (Storer, error)
+	SetRange(ctx context.Context, rng Range) error
+	QueryRange(ctx context.Context, inventoryID uuid.UUID) (Range, error)
+	QueryRangeForUpdate(ctx context.Context, inventoryID uuid.UUID) (Range, error)
+	AddReadings(ctx context.Context, rds []Reading) error
+	QueryReadings(ctx context.Context, filter ReadingFilter) ([]Reading, error)
+	Create(ctx context.Context, exc Excursion) error
+	Update(ctx context.Context, exc Excursion) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Excursion, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryOngoing(ctx context.Context, inventoryID uuid.UUID) (Excursion, error)
+}
+
+// Core manages the set of APIs for temperature access.
+type Core struct {
+	log           *logger.Logger
+	inventoryCore *inventorybus.Core
+	delegate      *delegate.Delegate
+	storer        Storer
+}
+
+// NewCore constructs a temperature core API for use.
+func NewCore(log *logger.Logger, inventoryCore *inventorybus.Core, delegate *delegate.Delegate, storer Storer) *Core {
+	return &Core{
+		log:           log,
+		inventoryCore: inventoryCore,
+		delegate:      delegate,
+		storer:        storer,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Core value that will use the
+// specified transaction in any store related calls.
+func (c *Core) ExecuteUnderTransaction(tx transaction.Transaction) (*Core, error) {
+	storer, err := c.storer.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryCore, err := c.inventoryCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	core := Core{
+		log:           c.log,
+		inventoryCore: inventoryCore,
+		delegate:      c.delegate,
+		storer:        storer,
+	}
+
+	return &core, nil
+}
+
+// SetRange creates or replaces the temperature range of an inventory. An
+// ongoing excursion keeps the range it started with.
+func (c *Core) SetRange(ctx context.Context, nr NewRange) (Range, error) {
+	if nr.MinCelsius > nr.MaxCelsius {
+		return Range{}, ErrInvalidRange
+	}
+
+	now := time.Now()
+
+	rng := Range{
+		InventoryID: nr.InventoryID,
+		MinCelsius:  nr.MinCelsius,
+		MaxCelsius:  nr.MaxCelsius,
+		DateCreated: now,
+		DateUpdated: now,
+	}
+
+	if err := c.storer.SetRange(ctx, rng); err != nil {
+		return Range{}, fmt.Errorf("setrange: %w", err)
+	}
+
+	return c.QueryRange(ctx, rng.InventoryID)
+}
+
+// QueryRange finds the temperature range of an inventory.
+func (c *Core) QueryRange(ctx context.Context, inventoryID uuid.UUID) (Range, error) {
+	rng, err := c.storer.QueryRange(ctx, inventoryID)
+	if err != nil {
+		return Range{}, fmt.Errorf("queryrange: inventoryID[%s]: %w", inventoryID, err)
+	}
+
+	return rng, nil
+}
+
+// Ingest stores a batch of readings taken in an inventory. The readings are
+// evaluated in the order they were recorded against the range of the
+// inventory, readings of an inventory without a range are only stored. The
+// caller is expected to run this under a transaction so the readings, the
+// excursions and the quarantine are committed together.
+func (c *Core) Ingest(ctx context.Context, ni NewIngestion) (Ingestion, error) {
+	if len(ni.Readings) == 0 {
+		return Ingestion{}, ErrNoReadings
+	}
+
+	now := time.Now()
+
+	rds := make([]Reading, len(ni.Readings))
+	for i, nr := range ni.Readings {
+		if nr.DateRecorded.IsZero() {
+			return Ingestion{}, ErrInvalidReading
+		}
+
+		rds[i] = Reading{
+			ID:           uuid.New(),
+			InventoryID:  ni.InventoryID,
+			Celsius:      nr.Celsius,
+			DateRecorded: nr.DateRecorded,
+			DateCreated:  now,
+		}
+	}
+
+	sort.SliceStable(rds, func(i, j int) bool {
+		return rds[i].DateRecorded.Before(rds[j].DateRecorded)
+	})
+
+	if _, err := c.inventoryCore.QueryByID(ctx, ni.InventoryID); err != nil {
+		return Ingestion{}, fmt.Errorf("inventory.querybyid: %w", err)
+	}
+
+	if err := c.storer.AddReadings(ctx, rds); err != nil {
+		return Ingestion{}, fmt.Errorf("addreadings: %w", err)
+	}
+
+	ing := Ingestion{
+		InventoryID: ni.InventoryID,
+		Readings:    len(rds),
+	}
+
+	rng, err := c.storer.QueryRangeForUpdate(ctx, ni.InventoryID)
+	if err != nil {
+		if errors.Is(err, ErrRangeNotFound) {
+			return ing, nil
+		}
+		return Ingestion{}, fmt.Errorf("queryrange: inventoryID[%s]: %w", ni.InventoryID, err)
+	}
+
+	exc, err := c.storer.QueryOngoing(ctx, ni.InventoryID)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return Ingestion{}, fmt.Errorf("queryongoing: inventoryID[%s]: %w", ni.InventoryID, err)
+	}
+
+	var dirty, isNew bool
+	for _, rd := range rds {
+		inRange := rng.Contains(rd.Celsius)
+
+		switch {
+		case exc.ID == uuid.Nil && !inRange:
+			exc = Excursion{
+				ID:          uuid.New(),
+				InventoryID: ni.InventoryID,
+				MinCelsius:  rng.MinCelsius,
+				MaxCelsius:  rng.MaxCelsius,
+				PeakCelsius: rd.Celsius,
+				DatePeak:    rd.DateRecorded,
+				DateStarted: rd.DateRecorded,
+				DateCreated: now,
+			}
+			dirty, isNew = true, true
+
+		case exc.ID != uuid.Nil && !inRange:
+			if exc.deviation(rd.Celsius) > exc.deviation(exc.PeakCelsius) {
+				exc.PeakCelsius = rd.Celsius
+				exc.DatePeak = rd.DateRecorded
+				dirty = true
+			}
+
+		case exc.ID != uuid.Nil && inRange:
+			exc.DateEnded = rd.DateRecorded
+
+			saved, err := c.save(ctx, exc, isNew, ni.UserID, now)
+			if err != nil {
+				return Ingestion{}, err
+			}
+			ing.Excursions = append(ing.Excursions, saved)
+
+			exc, dirty, isNew = Excursion{}, false, false
+		}
+	}
+
+	if dirty {
+		saved, err := c.save(ctx, exc, isNew, ni.UserID, now)
+		if err != nil {
+			return Ingestion{}, err
+		}
+		ing.Excursions = append(ing.Excursions, saved)
+	}
+
+	return ing, nil
+}
+
+// QueryReadings returns the readings of an inventory recorded over a period
+// in the order they were taken.
+func (c *Core) QueryReadings(ctx context.Context, filter ReadingFilter) ([]Reading, error) {
+	if filter.EndDate.Before(filter.StartDate) {
+		return nil, ErrInvalidPeriod
+	}
+
+	rds, err := c.storer.QueryReadings(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("queryreadings: inventoryID[%s]: %w", filter.InventoryID, err)
+	}
+
+	return rds, nil
+}
+
+// Query retrieves a list of existing excursions.
+func (c *Core) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Excursion, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	excs, err := c.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return excs, nil
+}
+
+// Count returns the total number of excursions.
+func (c *Core) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	return c.storer.Count(ctx, filter)
+}
+
+// =============================================================================
+
+// deviation returns how far the temperature is outside of the range of the
+// excursion.
+func (exc Excursion) deviation(celsius float64) float64 {
+	switch {
+	case celsius < exc.MinCelsius:
+		return exc.MinCelsius - celsius
+	case celsius > exc.MaxCelsius:
+		return celsius - exc.MaxCelsius
+	}
+
+	return 0
+}
+
+// save stores the state of an excursion. A new excursion quarantines the
+// available stock of its inventory first. Other domains are told when the
+// excursion starts and when it ends.
+func (c *Core) save(ctx context.Context, exc Excursion, isNew bool, userID uuid.UUID, now time.Time) (Excursion, error) {
+	exc.DateUpdated = now
+
+	switch {
+	case isNew:
+		if err := c.quarantine(ctx, &exc, userID); err != nil {
+			return Excursion{}, err
+		}
+
+		if err := c.storer.Create(ctx, exc); err != nil {
+			return Excursion{}, fmt.Errorf("create: %w", err)
+		}
+
+		// Let QA know stock of the inventory is waiting for review. This
+		// represents a delegate call to other domains.
+		if err := c.call(ctx, ActionExcursionStarted, exc); err != nil {
+			return Excursion{}, err
+		}
+
+	default:
+		if err := c.storer.Update(ctx, exc); err != nil {
+			return Excursion{}, fmt.Errorf("update: excursionID[%s]: %w", exc.ID, err)
+		}
+	}
+
+	if !exc.Ongoing() {
+		if err := c.call(ctx, ActionExcursionEnded, exc); err != nil {
+			return Excursion{}, err
+		}
+	}
+
+	return exc, nil
+}
+
+// call executes the delegate action for the excursion.
+func (c *Core) call(ctx context.Context, action string, exc Excursion) error {
+	data := ActionExcursionData(action, exc)
+	if err := c.delegate.Call(ctx, data); err != nil {
+		return fmt.Errorf("failed to execute `%s` action: %w", data.Action, err)
+	}
+
+	return nil
+}
+
+// quarantine moves the available stock of the inventory of the excursion
+// into the quarantined bucket and records it with the excursion.
+func (c *Core) quarantine(ctx context.Context, exc *Excursion, userID uuid.UUID) error {
+	var filter inventorybus.BucketFilter
+	filter.WithInventoryID(exc.InventoryID)
+
+	lbs, err := c.inventoryCore.QueryBuckets(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("inventory.querybuckets: inventoryID[%s]: %w", exc.InventoryID, err)
+	}
+
+	for _, lb := range lbs {
+		if lb.Available == 0 {
+			continue
+		}
+
+		sm := inventorybus.StatusMove{
+			InventoryID: lb.InventoryID,
+			LotID:       lb.LotID,
+			From:        inventorybus.StatusAvailable,
+			To:          inventorybus.StatusQuarantined,
+			Quantity:    lb.Available,
+			Reason:      fmt.Sprintf("temperature excursion %s", exc.ID),
+			UserID:      userID,
+		}
+
+		if _, err := c.inventoryCore.MoveStatus(ctx, sm); err != nil {
+			return fmt.Errorf("inventory.movestatus: inventory[%s] lot[%s]: %w", lb.InventoryID, lb.LotID, err)
+		}
+
+		exc.Stock = append(exc.Stock, Stock{
+			MedicineID: lb.MedicineID,
+			LotID:      lb.LotID,
+			Quantity:   lb.Available,
+		})
+	}
+
+	return nil
+}
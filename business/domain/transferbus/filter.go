@@ -0,0 +1,46 @@
+package transferbus
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryFilter holds the available fields a query can be filtered on.
+// We are using pointer semantics because the With API mutates the value.
+type QueryFilter struct {
+	ID            *uuid.UUID
+	SourceID      *uuid.UUID
+	DestinationID *uuid.UUID
+	Status        *Status
+}
+
+// Validate can perform a check of the data against the validate tags.
+func (qf *QueryFilter) Validate() error {
+	if err := validate.Check(qf); err != nil {
+		return fmt.Errorf("validate: %w", err)
+	}
+
+	return nil
+}
+
+// WithTransferID sets the ID field of the QueryFilter value.
+func (qf *QueryFilter) WithTransferID(transferID uuid.UUID) {
+	qf.ID = &transferID
+}
+
+// WithSourceID sets the SourceID field of the QueryFilter value.
+func (qf *QueryFilter) WithSourceID(inventoryID uuid.UUID) {
+	qf.SourceID = &inventoryID
+}
+
+// WithDestinationID sets the DestinationID field of the QueryFilter value.
+func (qf *QueryFilter) WithDestinationID(inventoryID uuid.UUID) {
+	qf.DestinationID = &inventoryID
+}
+
+// WithStatus sets the Status field of the QueryFilter value.
+func (qf *QueryFilter) WithStatus(status Status) {
+	qf.Status = &status
+}
@@ -0,0 +1,76 @@
+package transferbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Transfer represents stock moving from one inventory to another. Stock
+// leaves the source when the transfer is dispatched and lands in the
+// destination as it is received.
+type Transfer struct {
+	ID             uuid.UUID
+	SourceID       uuid.UUID
+	DestinationID  uuid.UUID
+	Status         Status
+	Note           string
+	Lines          []Line
+	DispatchedBy   uuid.UUID
+	DateDispatched time.Time
+	ReceivedBy     uuid.UUID
+	DateReceived   time.Time
+	DateCreated    time.Time
+	DateUpdated    time.Time
+}
+
+// Line represents the quantity of a single lot on a transfer. Received and
+// Discrepancy grow as receipts are recorded against the line.
+type Line struct {
+	LotID             uuid.UUID
+	MedicineID        uuid.UUID
+	Quantity          int
+	Received          int
+	Discrepancy       int
+	DiscrepancyReason string
+}
+
+// InTransit returns the quantity that left the source but has neither been
+// received nor written off as a discrepancy.
+func (l Line) InTransit() int {
+	return l.Quantity - l.Received - l.Discrepancy
+}
+
+// NewTransfer contains information needed to dispatch a new transfer.
+type NewTransfer struct {
+	SourceID        uuid.UUID
+	DestinationID   uuid.UUID
+	Note            string
+	Lines           []NewLine
+	UserID          uuid.UUID
+	CountersignedBy uuid.UUID
+}
+
+// NewLine contains information needed to add a lot to a new transfer.
+type NewLine struct {
+	LotID    uuid.UUID
+	Quantity int
+}
+
+// Receipt contains information about stock arriving at the destination.
+// When Close is set, whatever is still in transit after this receipt is
+// recorded as a discrepancy with the given reason and the transfer is
+// completed.
+type Receipt struct {
+	Lines           []ReceiptLine
+	Close           bool
+	Reason          string
+	UserID          uuid.UUID
+	CountersignedBy uuid.UUID
+}
+
+// ReceiptLine contains the quantity of a lot received.
+type ReceiptLine struct {
+	LotID    uuid.UUID
+	Quantity int
+}
@@ -0,0 +1,14 @@
+package transferbus
+
+import "github.com/EnesDemirtas/medisync/business/api/order"
+
+// DefaultOrderBy represents the default way we sort.
+var DefaultOrderBy = order.NewBy(OrderByDateDispatched, order.ASC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID             = "transfer_id"
+	OrderByStatus         = "status"
+	OrderByDateDispatched = "date_dispatched"
+	OrderByDateReceived   = "date_received"
+)
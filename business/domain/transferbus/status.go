@@ -0,0 +1,69 @@
+package transferbus
+
+import "fmt"
+
+// Set of possible statuses for a transfer.
+var (
+	StatusInTransit         = Status{"IN_TRANSIT"}
+	StatusPartiallyReceived = Status{"PARTIALLY_RECEIVED"}
+	StatusReceived          = Status{"RECEIVED"}
+)
+
+// Set of known statuses.
+var statuses = map[string]Status{
+	StatusInTransit.name:         StatusInTransit,
+	StatusPartiallyReceived.name: StatusPartiallyReceived,
+	StatusReceived.name:          StatusReceived,
+}
+
+// Status represents where a transfer is in its lifecycle.
+type Status struct {
+	name string
+}
+
+// ParseStatus parses the string value and returns a status if one exists.
+func ParseStatus(value string) (Status, error) {
+	status, exists := statuses[value]
+	if !exists {
+		return Status{}, fmt.Errorf("invalid status %q", value)
+	}
+
+	return status, nil
+}
+
+// MustParseStatus parses the string value and returns a status if one
+// exists. If an error occurs the function panics.
+func MustParseStatus(value string) Status {
+	status, err := ParseStatus(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return status
+}
+
+// Name returns the name of the status.
+func (s Status) Name() string {
+	return s.name
+}
+
+// UnmarshalText implement the unmarshal interface for JSON conversions.
+func (s *Status) UnmarshalText(data []byte) error {
+	status, err := ParseStatus(string(data))
+	if err != nil {
+		return err
+	}
+
+	s.name = status.name
+	return nil
+}
+
+// MarshalText implement the marshal interface for JSON conversions.
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.name), nil
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (s Status) Equal(s2 Status) bool {
+	return s.name == s2.name
+}
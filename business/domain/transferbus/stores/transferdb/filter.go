@@ -0,0 +1,37 @@
+package transferdb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/EnesDemirtas/medisync/business/domain/transferbus"
+)
+
+func applyFilter(filter transferbus.QueryFilter, data map[string]interface{}, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["transfer_id"] = *filter.ID
+		wc = append(wc, "transfer_id = :transfer_id")
+	}
+
+	if filter.SourceID != nil {
+		data["source_inventory_id"] = *filter.SourceID
+		wc = append(wc, "source_inventory_id = :source_inventory_id")
+	}
+
+	if filter.DestinationID != nil {
+		data["destination_inventory_id"] = *filter.DestinationID
+		wc = append(wc, "destination_inventory_id = :destination_inventory_id")
+	}
+
+	if filter.Status != nil {
+		data["status"] = filter.Status.Name()
+		wc = append(wc, "status = :status")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
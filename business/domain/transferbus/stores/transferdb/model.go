@@ -0,0 +1,138 @@
+package transferdb
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/domain/transferbus"
+	"github.com/google/uuid"
+)
+
+type dbTransfer struct {
+	ID             uuid.UUID      `db:"transfer_id"`
+	SourceID       uuid.UUID      `db:"source_inventory_id"`
+	DestinationID  uuid.UUID      `db:"destination_inventory_id"`
+	Status         string         `db:"status"`
+	Note           sql.NullString `db:"note"`
+	DispatchedBy   uuid.UUID      `db:"dispatched_by"`
+	DateDispatched time.Time      `db:"date_dispatched"`
+	ReceivedBy     uuid.NullUUID  `db:"received_by"`
+	DateReceived   sql.NullTime   `db:"date_received"`
+	DateCreated    time.Time      `db:"date_created"`
+	DateUpdated    time.Time      `db:"date_updated"`
+}
+
+type dbLine struct {
+	TransferID        uuid.UUID      `db:"transfer_id"`
+	LotID             uuid.UUID      `db:"lot_id"`
+	MedicineID        uuid.UUID      `db:"medicine_id"`
+	Quantity          int            `db:"quantity"`
+	Received          int            `db:"received"`
+	Discrepancy       int            `db:"discrepancy"`
+	DiscrepancyReason sql.NullString `db:"discrepancy_reason"`
+}
+
+func toDBTransfer(tr transferbus.Transfer) dbTransfer {
+	return dbTransfer{
+		ID:            tr.ID,
+		SourceID:      tr.SourceID,
+		DestinationID: tr.DestinationID,
+		Status:        tr.Status.Name(),
+		Note: sql.NullString{
+			String: tr.Note,
+			Valid:  tr.Note != "",
+		},
+		DispatchedBy:   tr.DispatchedBy,
+		DateDispatched: tr.DateDispatched.UTC(),
+		ReceivedBy: uuid.NullUUID{
+			UUID:  tr.ReceivedBy,
+			Valid: tr.ReceivedBy != uuid.Nil,
+		},
+		DateReceived: sql.NullTime{
+			Time:  tr.DateReceived.UTC(),
+			Valid: !tr.DateReceived.IsZero(),
+		},
+		DateCreated: tr.DateCreated.UTC(),
+		DateUpdated: tr.DateUpdated.UTC(),
+	}
+}
+
+func toDBLines(tr transferbus.Transfer) []dbLine {
+	lines := make([]dbLine, len(tr.Lines))
+	for i, line := range tr.Lines {
+		lines[i] = dbLine{
+			TransferID:  tr.ID,
+			LotID:       line.LotID,
+			MedicineID:  line.MedicineID,
+			Quantity:    line.Quantity,
+			Received:    line.Received,
+			Discrepancy: line.Discrepancy,
+			DiscrepancyReason: sql.NullString{
+				String: line.DiscrepancyReason,
+				Valid:  line.DiscrepancyReason != "",
+			},
+		}
+	}
+
+	return lines
+}
+
+func toCoreTransfer(dbTr dbTransfer, dbLines []dbLine) (transferbus.Transfer, error) {
+	status, err := transferbus.ParseStatus(dbTr.Status)
+	if err != nil {
+		return transferbus.Transfer{}, fmt.Errorf("parse status: %w", err)
+	}
+
+	lines := make([]transferbus.Line, len(dbLines))
+	for i, dbLine := range dbLines {
+		lines[i] = transferbus.Line{
+			LotID:             dbLine.LotID,
+			MedicineID:        dbLine.MedicineID,
+			Quantity:          dbLine.Quantity,
+			Received:          dbLine.Received,
+			Discrepancy:       dbLine.Discrepancy,
+			DiscrepancyReason: dbLine.DiscrepancyReason.String,
+		}
+	}
+
+	var dateReceived time.Time
+	if dbTr.DateReceived.Valid {
+		dateReceived = dbTr.DateReceived.Time.In(time.Local)
+	}
+
+	tr := transferbus.Transfer{
+		ID:             dbTr.ID,
+		SourceID:       dbTr.SourceID,
+		DestinationID:  dbTr.DestinationID,
+		Status:         status,
+		Note:           dbTr.Note.String,
+		Lines:          lines,
+		DispatchedBy:   dbTr.DispatchedBy,
+		DateDispatched: dbTr.DateDispatched.In(time.Local),
+		ReceivedBy:     dbTr.ReceivedBy.UUID,
+		DateReceived:   dateReceived,
+		DateCreated:    dbTr.DateCreated.In(time.Local),
+		DateUpdated:    dbTr.DateUpdated.In(time.Local),
+	}
+
+	return tr, nil
+}
+
+func toCoreTransferSlice(dbTrs []dbTransfer, dbLines []dbLine) ([]transferbus.Transfer, error) {
+	byTransfer := make(map[uuid.UUID][]dbLine, len(dbTrs))
+	for _, dbLine := range dbLines {
+		byTransfer[dbLine.TransferID] = append(byTransfer[dbLine.TransferID], dbLine)
+	}
+
+	trs := make([]transferbus.Transfer, len(dbTrs))
+	for i, dbTr := range dbTrs {
+		var err error
+		trs[i], err = toCoreTransfer(dbTr, byTransfer[dbTr.ID])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return trs, nil
+}
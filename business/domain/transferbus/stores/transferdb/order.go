@@ -0,0 +1,24 @@
+package transferdb
+
+import (
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/domain/transferbus"
+)
+
+var orderByFields = map[string]string{
+	transferbus.OrderByID:             "transfer_id",
+	transferbus.OrderByStatus:         "status",
+	transferbus.OrderByDateDispatched: "date_dispatched",
+	transferbus.OrderByDateReceived:   "date_received",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
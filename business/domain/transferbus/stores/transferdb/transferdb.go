@@ -0,0 +1,244 @@
+// Package transferdb contains transfer related CRUD functionality.
+package transferdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb"
+	"github.com/EnesDemirtas/medisync/business/data/sqldb/dbarray"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/transferbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Store manages the set of APIs for transfer database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the API for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Store value replacing the sqlx DB
+// value with a sqlx DB value that is currently inside a transaction.
+func (s *Store) ExecuteUnderTransaction(tx transaction.Transaction) (transferbus.Storer, error) {
+	ec, err := sqldb.GetExtContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	store := Store{
+		log: s.log,
+		db:  ec,
+	}
+
+	return &store, nil
+}
+
+// Create inserts a new transfer and its lines into the database.
+func (s *Store) Create(ctx context.Context, tr transferbus.Transfer) error {
+	const q = `
+	INSERT INTO transfers
+		(transfer_id, source_inventory_id, destination_inventory_id, status, note, dispatched_by, date_dispatched, received_by, date_received, date_created, date_updated)
+	VALUES
+		(:transfer_id, :source_inventory_id, :destination_inventory_id, :status, :note, :dispatched_by, :date_dispatched, :received_by, :date_received, :date_created, :date_updated)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBTransfer(tr)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	const ql = `
+	INSERT INTO transfer_lines
+		(transfer_id, lot_id, medicine_id, quantity, received, discrepancy, discrepancy_reason)
+	VALUES
+		(:transfer_id, :lot_id, :medicine_id, :quantity, :received, :discrepancy, :discrepancy_reason)`
+
+	for _, line := range toDBLines(tr) {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, ql, line); err != nil {
+			return fmt.Errorf("namedexeccontext: line: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Update replaces the state of a transfer and its lines in the database.
+func (s *Store) Update(ctx context.Context, tr transferbus.Transfer) error {
+	const q = `
+	UPDATE
+		transfers
+	SET
+		"status" = :status,
+		"note" = :note,
+		"received_by" = :received_by,
+		"date_received" = :date_received,
+		"date_updated" = :date_updated
+	WHERE
+		transfer_id = :transfer_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBTransfer(tr)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	const ql = `
+	UPDATE
+		transfer_lines
+	SET
+		"received" = :received,
+		"discrepancy" = :discrepancy,
+		"discrepancy_reason" = :discrepancy_reason
+	WHERE
+		transfer_id = :transfer_id AND lot_id = :lot_id`
+
+	for _, line := range toDBLines(tr) {
+		if err := sqldb.NamedExecContext(ctx, s.log, s.db, ql, line); err != nil {
+			return fmt.Errorf("namedexeccontext: line: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Query retrieves a list of existing transfers from the database.
+func (s *Store) Query(ctx context.Context, filter transferbus.QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]transferbus.Transfer, error) {
+	data := map[string]interface{}{
+		"offset":        (pageNumber - 1) * rowsPerPage,
+		"rows_per_page": rowsPerPage,
+	}
+
+	const q = `
+	SELECT
+		transfer_id, source_inventory_id, destination_inventory_id, status, note, dispatched_by, date_dispatched, received_by, date_received, date_created, date_updated
+	FROM
+		transfers`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbTrs []dbTransfer
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbTrs); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	ids := make([]string, len(dbTrs))
+	for i, dbTr := range dbTrs {
+		ids[i] = dbTr.ID.String()
+	}
+
+	dbLines, err := s.queryLines(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCoreTransferSlice(dbTrs, dbLines)
+}
+
+// Count returns the total number of transfers in the database.
+func (s *Store) Count(ctx context.Context, filter transferbus.QueryFilter) (int, error) {
+	data := map[string]interface{}{}
+
+	const q = `
+	SELECT
+		count(1)
+	FROM
+		transfers`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID gets the specified transfer from the database.
+func (s *Store) QueryByID(ctx context.Context, transferID uuid.UUID) (transferbus.Transfer, error) {
+	return s.queryByID(ctx, transferID, "")
+}
+
+// QueryByIDForUpdate gets the specified transfer from the database and locks
+// it until the surrounding transaction ends.
+func (s *Store) QueryByIDForUpdate(ctx context.Context, transferID uuid.UUID) (transferbus.Transfer, error) {
+	return s.queryByID(ctx, transferID, " FOR UPDATE")
+}
+
+func (s *Store) queryByID(ctx context.Context, transferID uuid.UUID, lock string) (transferbus.Transfer, error) {
+	data := struct {
+		ID string `db:"transfer_id"`
+	}{
+		ID: transferID.String(),
+	}
+
+	const q = `
+	SELECT
+		transfer_id, source_inventory_id, destination_inventory_id, status, note, dispatched_by, date_dispatched, received_by, date_received, date_created, date_updated
+	FROM
+		transfers
+	WHERE
+		transfer_id = :transfer_id`
+
+	var dbTr dbTransfer
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q+lock, data, &dbTr); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return transferbus.Transfer{}, fmt.Errorf("db: %w", transferbus.ErrNotFound)
+		}
+		return transferbus.Transfer{}, fmt.Errorf("db: %w", err)
+	}
+
+	dbLines, err := s.queryLines(ctx, []string{dbTr.ID.String()})
+	if err != nil {
+		return transferbus.Transfer{}, err
+	}
+
+	return toCoreTransfer(dbTr, dbLines)
+}
+
+func (s *Store) queryLines(ctx context.Context, transferIDs []string) ([]dbLine, error) {
+	data := struct {
+		ID any `db:"transfer_id"`
+	}{
+		ID: dbarray.Array(transferIDs),
+	}
+
+	const q = `
+	SELECT
+		transfer_id, lot_id, medicine_id, quantity, received, discrepancy, discrepancy_reason
+	FROM
+		transfer_lines
+	WHERE
+		transfer_id = ANY(:transfer_id)
+	ORDER BY
+		transfer_id, lot_id`
+
+	var dbLines []dbLine
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbLines); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: lines: %w", err)
+	}
+
+	return dbLines, nil
+}
@@ -0,0 +1,304 @@
+// Package transferbus provides the business API for moving stock between
+// inventories. Dispatching a transfer takes the stock out of the source
+// inventory, where it stays in transit until it is received at the
+// destination. Every quantity change goes through the stock ledger.
+package transferbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/EnesDemirtas/medisync/business/api/order"
+	"github.com/EnesDemirtas/medisync/business/data/transaction"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/foundation/logger"
+	"github.com/google/uuid"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound        = errors.New("transfer not found")
+	ErrSameInventory   = errors.New("source and destination must differ")
+	ErrNoLines         = errors.New("transfer has no lines")
+	ErrDuplicateLine   = errors.New("lot listed more than once")
+	ErrInvalidQuantity = errors.New("invalid transfer quantity")
+	ErrUnknownLine     = errors.New("lot is not on the transfer")
+	ErrOverReceipt     = errors.New("received quantity exceeds quantity in transit")
+	ErrNotInTransit    = errors.New("transfer has already been received")
+	ErrReasonRequired  = errors.New("discrepancy reason required")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	ExecuteUnderTransaction(tx transaction.Transaction) (Storer, error)
+	Create(ctx context.Context, tr Transfer) error
+	Update(ctx context.Context, tr Transfer) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Transfer, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, transferID uuid.UUID) (Transfer, error)
+	QueryByIDForUpdate(ctx context.Context, transferID uuid.UUID) (Transfer, error)
+}
+
+// Core manages the set of APIs for transfer access.
+type Core struct {
+	log           *logger.Logger
+	inventoryCore *inventorybus.Core
+	stockCore     *stockbus.Core
+	delegate      *delegate.Delegate
+	storer        Storer
+}
+
+// NewCore constructs a transfer core API for use.
+func NewCore(log *logger.Logger, inventoryCore *inventorybus.Core, stockCore *stockbus.Core, delegate *delegate.Delegate, storer Storer) *Core {
+	return &Core{
+		log:           log,
+		inventoryCore: inventoryCore,
+		stockCore:     stockCore,
+		delegate:      delegate,
+		storer:        storer,
+	}
+}
+
+// ExecuteUnderTransaction constructs a new Core value that will use the
+// specified transaction in any store related calls.
+func (c *Core) ExecuteUnderTransaction(tx transaction.Transaction) (*Core, error) {
+	storer, err := c.storer.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryCore, err := c.inventoryCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	stockCore, err := c.stockCore.ExecuteUnderTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	core := Core{
+		log:           c.log,
+		inventoryCore: inventoryCore,
+		stockCore:     stockCore,
+		delegate:      c.delegate,
+		storer:        storer,
+	}
+
+	return &core, nil
+}
+
+// Dispatch creates a transfer and takes its lines out of the source
+// inventory. The caller is expected to run this under a transaction so a
+// failure on any line leaves the source untouched.
+func (c *Core) Dispatch(ctx context.Context, nt NewTransfer) (Transfer, error) {
+	if nt.SourceID == nt.DestinationID {
+		return Transfer{}, ErrSameInventory
+	}
+
+	if len(nt.Lines) == 0 {
+		return Transfer{}, ErrNoLines
+	}
+
+	if _, err := c.inventoryCore.QueryByID(ctx, nt.DestinationID); err != nil {
+		return Transfer{}, fmt.Errorf("inventory.querybyid: destination[%s]: %w", nt.DestinationID, err)
+	}
+
+	now := time.Now()
+
+	tr := Transfer{
+		ID:             uuid.New(),
+		SourceID:       nt.SourceID,
+		DestinationID:  nt.DestinationID,
+		Status:         StatusInTransit,
+		Note:           nt.Note,
+		Lines:          make([]Line, 0, len(nt.Lines)),
+		DispatchedBy:   nt.UserID,
+		DateDispatched: now,
+		DateCreated:    now,
+		DateUpdated:    now,
+	}
+
+	seen := make(map[uuid.UUID]bool, len(nt.Lines))
+	for _, nl := range nt.Lines {
+		if seen[nl.LotID] {
+			return Transfer{}, fmt.Errorf("%w: %s", ErrDuplicateLine, nl.LotID)
+		}
+		seen[nl.LotID] = true
+
+		if nl.Quantity <= 0 {
+			return Transfer{}, fmt.Errorf("%w: lot[%s] quantity[%d]", ErrInvalidQuantity, nl.LotID, nl.Quantity)
+		}
+
+		nm := stockbus.NewMovement{
+			InventoryID:     nt.SourceID,
+			LotID:           nl.LotID,
+			Type:            stockbus.TypeTransferOut,
+			Quantity:        nl.Quantity,
+			Reason:          reference(tr.ID),
+			Reference:       stockbus.Reference{Type: stockbus.ReferenceTransfer, ID: tr.ID},
+			UserID:          nt.UserID,
+			CountersignedBy: nt.CountersignedBy,
+		}
+
+		mov, err := c.stockCore.Create(ctx, nm)
+		if err != nil {
+			return Transfer{}, fmt.Errorf("stock.create: lot[%s]: %w", nl.LotID, err)
+		}
+
+		tr.Lines = append(tr.Lines, Line{
+			LotID:      nl.LotID,
+			MedicineID: mov.MedicineID,
+			Quantity:   nl.Quantity,
+		})
+	}
+
+	if err := c.storer.Create(ctx, tr); err != nil {
+		return Transfer{}, fmt.Errorf("create: %w", err)
+	}
+
+	return tr, nil
+}
+
+// Receive records stock arriving at the destination of the transfer. A
+// receipt may cover only part of what is in transit, further receipts can
+// follow until the transfer is closed. The caller is expected to run this
+// under a transaction.
+func (c *Core) Receive(ctx context.Context, transferID uuid.UUID, rc Receipt) (Transfer, error) {
+	tr, err := c.storer.QueryByIDForUpdate(ctx, transferID)
+	if err != nil {
+		return Transfer{}, fmt.Errorf("query: transferID[%s]: %w", transferID, err)
+	}
+
+	if tr.Status == StatusReceived {
+		return Transfer{}, ErrNotInTransit
+	}
+
+	if len(rc.Lines) == 0 && !rc.Close {
+		return Transfer{}, ErrNoLines
+	}
+
+	lines := make(map[uuid.UUID]int, len(tr.Lines))
+	for i, line := range tr.Lines {
+		lines[line.LotID] = i
+	}
+
+	for _, rl := range rc.Lines {
+		idx, exists := lines[rl.LotID]
+		if !exists {
+			return Transfer{}, fmt.Errorf("%w: %s", ErrUnknownLine, rl.LotID)
+		}
+
+		if rl.Quantity <= 0 {
+			return Transfer{}, fmt.Errorf("%w: lot[%s] quantity[%d]", ErrInvalidQuantity, rl.LotID, rl.Quantity)
+		}
+
+		if rl.Quantity > tr.Lines[idx].InTransit() {
+			return Transfer{}, fmt.Errorf("%w: lot[%s] quantity[%d] in transit[%d]", ErrOverReceipt, rl.LotID, rl.Quantity, tr.Lines[idx].InTransit())
+		}
+
+		nm := stockbus.NewMovement{
+			InventoryID:     tr.DestinationID,
+			LotID:           rl.LotID,
+			Type:            stockbus.TypeTransferIn,
+			Quantity:        rl.Quantity,
+			Reason:          reference(tr.ID),
+			Reference:       stockbus.Reference{Type: stockbus.ReferenceTransfer, ID: tr.ID},
+			UserID:          rc.UserID,
+			CountersignedBy: rc.CountersignedBy,
+		}
+
+		if _, err := c.stockCore.Create(ctx, nm); err != nil {
+			return Transfer{}, fmt.Errorf("stock.create: lot[%s]: %w", rl.LotID, err)
+		}
+
+		tr.Lines[idx].Received += rl.Quantity
+	}
+
+	if rc.Close {
+		for i, line := range tr.Lines {
+			missing := line.InTransit()
+			if missing == 0 {
+				continue
+			}
+
+			if rc.Reason == "" {
+				return Transfer{}, ErrReasonRequired
+			}
+
+			tr.Lines[i].Discrepancy += missing
+			tr.Lines[i].DiscrepancyReason = rc.Reason
+		}
+	}
+
+	now := time.Now()
+
+	tr.Status = StatusPartiallyReceived
+	if inTransit(tr) == 0 {
+		tr.Status = StatusReceived
+		tr.ReceivedBy = rc.UserID
+		tr.DateReceived = now
+	}
+	tr.DateUpdated = now
+
+	if err := c.storer.Update(ctx, tr); err != nil {
+		return Transfer{}, fmt.Errorf("update: %w", err)
+	}
+
+	return tr, nil
+}
+
+// Query retrieves a list of existing transfers.
+func (c *Core) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Transfer, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	trs, err := c.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return trs, nil
+}
+
+// Count returns the total number of transfers.
+func (c *Core) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	return c.storer.Count(ctx, filter)
+}
+
+// QueryByID finds the transfer by the specified ID.
+func (c *Core) QueryByID(ctx context.Context, transferID uuid.UUID) (Transfer, error) {
+	tr, err := c.storer.QueryByID(ctx, transferID)
+	if err != nil {
+		return Transfer{}, fmt.Errorf("query: transferID[%s]: %w", transferID, err)
+	}
+
+	return tr, nil
+}
+
+// =============================================================================
+
+// reference returns the reason recorded on the stock movements of a transfer
+// so the ledger can be traced back to it.
+func reference(transferID uuid.UUID) string {
+	return "transfer " + transferID.String()
+}
+
+func inTransit(tr Transfer) int {
+	var total int
+	for _, line := range tr.Lines {
+		total += line.InTransit()
+	}
+
+	return total
+}
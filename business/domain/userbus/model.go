@@ -7,7 +7,8 @@ import (
 	"github.com/google/uuid"
 )
 
-// User represents information about an individual user.
+// User represents information about an individual user. Version starts at
+// one and is incremented by every update.
 type User struct {
 	ID           uuid.UUID
 	Name         string
@@ -15,6 +16,7 @@ type User struct {
 	Roles        []Role
 	PasswordHash []byte
 	Enabled      bool
+	Version      int
 	DateCreated  time.Time
 	DateUpdated  time.Time
 }
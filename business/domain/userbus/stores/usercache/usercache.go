@@ -7,7 +7,7 @@ import (
 	"sync"
 
 	"github.com/EnesDemirtas/medisync/business/api/order"
-	"github.com/EnesDemirtas/medisync/business/core/crud/userbus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
 	"github.com/EnesDemirtas/medisync/business/data/transaction"
 	"github.com/EnesDemirtas/medisync/foundation/logger"
 	"github.com/google/uuid"
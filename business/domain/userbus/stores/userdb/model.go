@@ -17,6 +17,7 @@ type dbUser struct {
 	Roles        dbarray.String `db:"roles"`
 	PasswordHash []byte         `db:"password_hash"`
 	Enabled      bool           `db:"enabled"`
+	Version      int            `db:"version"`
 	DateCreated  time.Time      `db:"date_created"`
 	DateUpdated  time.Time      `db:"date_updated"`
 }
@@ -34,6 +35,7 @@ func toDBUser(usr userbus.User) dbUser {
 		Roles:        roles,
 		PasswordHash: usr.PasswordHash,
 		Enabled:      usr.Enabled,
+		Version:      usr.Version,
 		DateCreated:  usr.DateCreated.UTC(),
 		DateUpdated:  usr.DateUpdated.UTC(),
 	}
@@ -60,6 +62,7 @@ func toCoreUser(dbUsr dbUser) (userbus.User, error) {
 		Roles:        roles,
 		PasswordHash: dbUsr.PasswordHash,
 		Enabled:      dbUsr.Enabled,
+		Version:      dbUsr.Version,
 		DateCreated:  dbUsr.DateCreated.In(time.Local),
 		DateUpdated:  dbUsr.DateUpdated.In(time.Local),
 	}
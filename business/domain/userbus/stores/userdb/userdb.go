@@ -52,9 +52,9 @@ func (s *Store) ExecuteUnderTransaction(tx transaction.Transaction) (userbus.Sto
 func (s *Store) Create(ctx context.Context, usr userbus.User) error {
 	const q = `
 	INSERT INTO users
-		(user_id, name, email, password_hash, roles, enabled, date_created, date_updated)
+		(user_id, name, email, password_hash, roles, enabled, version, date_created, date_updated)
 	VALUES
-		(:user_id, :name, :email, :password_hash, :roles, :enabled, :date_created, :date_updated)`
+		(:user_id, :name, :email, :password_hash, :roles, :enabled, :version, :date_created, :date_updated)`
 
 	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBUser(usr)); err != nil {
 		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
@@ -77,15 +77,25 @@ func (s *Store) Update(ctx context.Context, usr userbus.User) error {
 		"roles" = :roles,
 		"password_hash" = :password_hash,
 		"enabled" = :enabled,
+		"version" = :version,
 		"date_updated" = :date_updated
 	WHERE
-		user_id = :user_id`
+		user_id = :user_id AND
+		version = :version - 1
+	RETURNING
+		version`
 
-	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBUser(usr)); err != nil {
+	var result struct {
+		Version int `db:"version"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, toDBUser(usr), &result); err != nil {
 		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
 			return userbus.ErrUniqueEmail
 		}
-		return fmt.Errorf("namedexeccontext: %w", err)
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return fmt.Errorf("db: %w", userbus.ErrVersionConflict)
+		}
+		return fmt.Errorf("db: %w", err)
 	}
 
 	return nil
@@ -121,7 +131,7 @@ func (s *Store) Query(ctx context.Context, filter userbus.QueryFilter, orderBy o
 
 	const q = `
 	SELECT
-		user_id, name, email, password_hash, roles, enabled, date_created, date_updated
+		user_id, name, email, password_hash, roles, enabled, version, date_created, date_updated
 	FROM
 		users`
 
@@ -177,7 +187,7 @@ func (s *Store) QueryByID(ctx context.Context, userID uuid.UUID) (userbus.User,
 
 	const q = `
 	SELECT
-        user_id, name, email, password_hash, roles, enabled, date_created, date_updated
+        user_id, name, email, password_hash, roles, enabled, version, date_created, date_updated
 	FROM
 		users
 	WHERE 
@@ -209,7 +219,7 @@ func (s *Store) QueryByIDs(ctx context.Context, userIDs []uuid.UUID) ([]userbus.
 
 	const q = `
 	SELECT
-        user_id, name, email, password_hash, roles, enabled, date_created, date_updated
+        user_id, name, email, password_hash, roles, enabled, version, date_created, date_updated
 	FROM
 		users
 	WHERE
@@ -236,7 +246,7 @@ func (s *Store) QueryByEmail(ctx context.Context, email mail.Address) (userbus.U
 
 	const q = `
 	SELECT
-        user_id, name, email, password_hash, roles, enabled, date_created, date_updated
+        user_id, name, email, password_hash, roles, enabled, version, date_created, date_updated
 	FROM
 		users
 	WHERE
@@ -23,6 +23,7 @@ var (
 	ErrNotFound              = errors.New("user not found")
 	ErrUniqueEmail           = errors.New("email is not unique")
 	ErrAuthenticationFailure = errors.New("authentication failed")
+	ErrVersionConflict       = errors.New("user was modified by another request")
 )
 
 // Storer interface declares the behavior this package needs to perists and
@@ -88,6 +89,7 @@ func (c *Core) Create(ctx context.Context, nu NewUser) (User, error) {
 		PasswordHash: hash,
 		Roles:        nu.Roles,
 		Enabled:      true,
+		Version:      1,
 		DateCreated:  now,
 		DateUpdated:  now,
 	}
@@ -124,6 +126,7 @@ func (c *Core) Update(ctx context.Context, usr User, uu UpdateUser) (User, error
 	if uu.Enabled != nil {
 		usr.Enabled = *uu.Enabled
 	}
+	usr.Version++
 	usr.DateUpdated = time.Now()
 
 	if err := c.storer.Update(ctx, usr); err != nil {
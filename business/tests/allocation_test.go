@@ -0,0 +1,253 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/data/dbtest"
+	"github.com/EnesDemirtas/medisync/business/domain/allocationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func Test_Allocation(t *testing.T) {
+	t.Parallel()
+
+	dbTest := dbtest.NewTest(t, c, "Test_Allocation")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log(r)
+			t.Error(string(debug.Stack()))
+		}
+		dbTest.Teardown()
+	}()
+
+	sd, err := insertAllocationSeedData(dbTest)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	// -------------------------------------------------------------------------
+
+	dbtest.UnitTest(t, allocationFlow(dbTest, sd), "allocation-flow")
+}
+
+// =============================================================================
+
+// insertAllocationSeedData seeds three lots of a medicine expiring in 10, 60
+// and 120 days. The first inventory holds 10 of the first lot and 5 of the
+// second with 2 reserved, the second inventory holds 10 of the third lot.
+func insertAllocationSeedData(dbTest *dbtest.Test) (dbtest.SeedData, error) {
+	ctx := context.Background()
+	busDomain := dbTest.BusDomain
+
+	usrs, err := userbus.TestGenerateSeedUsers(ctx, 1, userbus.RoleAdmin, busDomain.User)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	meds, err := medicinebus.TestGenerateSeedMedicines(ctx, 1, busDomain.Medicine)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding medicines : %w", err)
+	}
+
+	var lots []lotbus.Lot
+	for i, days := range []int{10, 60, 120} {
+		nl := lotbus.NewLot{
+			MedicineID:      meds[0].ID,
+			Number:          fmt.Sprintf("FEFO%d", i),
+			ExpiryDate:      time.Now().AddDate(0, 0, days),
+			ManufactureDate: time.Now().AddDate(-1, 0, 0),
+		}
+
+		lot, err := busDomain.Lot.Create(ctx, nl)
+		if err != nil {
+			return dbtest.SeedData{}, fmt.Errorf("seeding lots : %w", err)
+		}
+
+		lots = append(lots, lot)
+	}
+
+	invs, err := inventorybus.TestGenerateSeedInventories(ctx, 2, busDomain.Inventory)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding inventories : %w", err)
+	}
+
+	receipts := []struct {
+		inventoryID uuid.UUID
+		lotID       uuid.UUID
+		quantity    int
+	}{
+		{invs[0].ID, lots[0].ID, 10},
+		{invs[0].ID, lots[1].ID, 5},
+		{invs[1].ID, lots[2].ID, 10},
+	}
+
+	for _, rcpt := range receipts {
+		nm := stockbus.NewMovement{
+			InventoryID: rcpt.inventoryID,
+			LotID:       rcpt.lotID,
+			Type:        stockbus.TypeReceive,
+			Quantity:    rcpt.quantity,
+			UserID:      usrs[0].ID,
+		}
+
+		if _, err := busDomain.Stock.Create(ctx, nm); err != nil {
+			return dbtest.SeedData{}, fmt.Errorf("seeding stock : %w", err)
+		}
+	}
+
+	nr := reservationbus.NewReservation{
+		InventoryID: invs[0].ID,
+		MedicineID:  meds[0].ID,
+		Quantity:    2,
+		TTL:         time.Hour,
+		UserID:      usrs[0].ID,
+	}
+
+	if _, err := busDomain.Reservation.Reserve(ctx, nr); err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding reservations : %w", err)
+	}
+
+	sd := dbtest.SeedData{
+		Admins:      []dbtest.User{{User: usrs[0]}},
+		Medicines:   meds,
+		Lots:        lots,
+		Inventories: invs,
+	}
+
+	return sd, nil
+}
+
+// =============================================================================
+
+func allocationFlow(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
+	type pick struct {
+		LotID    uuid.UUID
+		Quantity int
+	}
+
+	picks := func(plan allocationbus.Plan) []pick {
+		items := make([]pick, len(plan.Lines))
+		for i, line := range plan.Lines {
+			items[i] = pick{line.LotID, line.Quantity}
+		}
+		return items
+	}
+
+	var plan allocationbus.Plan
+	noShelfLife := time.Duration(0)
+
+	table := []dbtest.UnitTable{
+		{
+			Name: "plan",
+			ExpResp: []pick{
+				{sd.Lots[1].ID, 3},
+				{sd.Lots[2].ID, 5},
+			},
+			ExcFunc: func(ctx context.Context) any {
+				req := allocationbus.Request{
+					MedicineID: sd.Medicines[0].ID,
+					Quantity:   8,
+				}
+
+				var err error
+				plan, err = dbt.BusDomain.Allocation.Plan(ctx, req)
+				if err != nil {
+					return err
+				}
+
+				return picks(plan)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name: "plan-no-shelf-life",
+			ExpResp: []pick{
+				{sd.Lots[0].ID, 8},
+			},
+			ExcFunc: func(ctx context.Context) any {
+				req := allocationbus.Request{
+					MedicineID:   sd.Medicines[0].ID,
+					Quantity:     8,
+					MinShelfLife: &noShelfLife,
+				}
+
+				plan, err := dbt.BusDomain.Allocation.Plan(ctx, req)
+				if err != nil {
+					return err
+				}
+
+				return picks(plan)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "confirm",
+			ExpResp: []int{2, 5},
+			ExcFunc: func(ctx context.Context) any {
+				conf := allocationbus.Confirmation{
+					MedicineID: sd.Medicines[0].ID,
+					UserID:     sd.Admins[0].ID,
+				}
+				for _, line := range plan.Lines {
+					conf.Lines = append(conf.Lines, allocationbus.ConfirmationLine{
+						InventoryID: line.InventoryID,
+						LotID:       line.LotID,
+						Quantity:    line.Quantity,
+					})
+				}
+
+				movs, err := dbt.BusDomain.Allocation.Confirm(ctx, conf)
+				if err != nil {
+					return err
+				}
+
+				balances := make([]int, len(movs))
+				for i, mov := range movs {
+					balances[i] = mov.Balance
+				}
+
+				return balances
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "confirm-short-dated",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				conf := allocationbus.Confirmation{
+					MedicineID: sd.Medicines[0].ID,
+					Lines: []allocationbus.ConfirmationLine{
+						{InventoryID: sd.Inventories[0].ID, LotID: sd.Lots[0].ID, Quantity: 1},
+					},
+					UserID: sd.Admins[0].ID,
+				}
+
+				_, err := dbt.BusDomain.Allocation.Confirm(ctx, conf)
+				return errors.Is(err, allocationbus.ErrUnavailable)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
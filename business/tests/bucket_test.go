@@ -0,0 +1,237 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/data/dbtest"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Bucket(t *testing.T) {
+	t.Parallel()
+
+	dbTest := dbtest.NewTest(t, c, "Test_Bucket")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log(r)
+			t.Error(string(debug.Stack()))
+		}
+		dbTest.Teardown()
+	}()
+
+	sd, err := insertBucketSeedData(dbTest)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	// -------------------------------------------------------------------------
+
+	dbtest.UnitTest(t, bucketFlow(dbTest, sd), "bucket-flow")
+}
+
+// =============================================================================
+
+// insertBucketSeedData seeds a single lot with 10 available units in one
+// inventory.
+func insertBucketSeedData(dbTest *dbtest.Test) (dbtest.SeedData, error) {
+	ctx := context.Background()
+	busDomain := dbTest.BusDomain
+
+	usrs, err := userbus.TestGenerateSeedUsers(ctx, 1, userbus.RoleAdmin, busDomain.User)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	meds, err := medicinebus.TestGenerateSeedMedicines(ctx, 1, busDomain.Medicine)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding medicines : %w", err)
+	}
+
+	lots, err := lotbus.TestGenerateSeedLots(ctx, 1, busDomain.Lot, meds[0].ID)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding lots : %w", err)
+	}
+
+	invs, err := inventorybus.TestGenerateSeedInventories(ctx, 1, busDomain.Inventory)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding inventories : %w", err)
+	}
+
+	nm := stockbus.NewMovement{
+		InventoryID: invs[0].ID,
+		LotID:       lots[0].ID,
+		Type:        stockbus.TypeReceive,
+		Quantity:    10,
+		UserID:      usrs[0].ID,
+	}
+
+	if _, err := busDomain.Stock.Create(ctx, nm); err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding stock : %w", err)
+	}
+
+	sd := dbtest.SeedData{
+		Admins:      []dbtest.User{{User: usrs[0]}},
+		Medicines:   meds,
+		Lots:        lots,
+		Inventories: invs,
+	}
+
+	return sd, nil
+}
+
+// =============================================================================
+
+func bucketFlow(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
+	movement := func(ctx context.Context, typ stockbus.MovementType, status inventorybus.Status, quantity int) error {
+		nm := stockbus.NewMovement{
+			InventoryID: sd.Inventories[0].ID,
+			LotID:       sd.Lots[0].ID,
+			Status:      status,
+			Type:        typ,
+			Quantity:    quantity,
+			Reason:      "bucket test",
+			UserID:      sd.Admins[0].ID,
+		}
+
+		_, err := dbt.BusDomain.Stock.Create(ctx, nm)
+		return err
+	}
+
+	table := []dbtest.UnitTable{
+		{
+			Name:    "move-damaged",
+			ExpResp: inventorybus.Buckets{Available: 7, Damaged: 3},
+			ExcFunc: func(ctx context.Context) any {
+				sm := inventorybus.StatusMove{
+					InventoryID: sd.Inventories[0].ID,
+					LotID:       sd.Lots[0].ID,
+					From:        inventorybus.StatusAvailable,
+					To:          inventorybus.StatusDamaged,
+					Quantity:    3,
+					Reason:      "Damaged in transit",
+					UserID:      sd.Admins[0].ID,
+				}
+
+				lb, err := dbt.BusDomain.Inventory.MoveStatus(ctx, sm)
+				if err != nil {
+					return err
+				}
+
+				return lb.Buckets
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "move-without-reason",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				sm := inventorybus.StatusMove{
+					InventoryID: sd.Inventories[0].ID,
+					LotID:       sd.Lots[0].ID,
+					From:        inventorybus.StatusAvailable,
+					To:          inventorybus.StatusQuarantined,
+					Quantity:    1,
+					UserID:      sd.Admins[0].ID,
+				}
+
+				_, err := dbt.BusDomain.Inventory.MoveStatus(ctx, sm)
+				return errors.Is(err, inventorybus.ErrReasonRequired)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "dispense-beyond-available",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				err := movement(ctx, stockbus.TypeDispense, inventorybus.Status{}, 8)
+				return errors.Is(err, inventorybus.ErrInsufficientStock)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "dispense-damaged",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				err := movement(ctx, stockbus.TypeDispense, inventorybus.StatusDamaged, 1)
+				return errors.Is(err, stockbus.ErrUnavailable)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name: "write-off-damaged",
+			ExpResp: inventorybus.Buckets{
+				Available: 7,
+				Damaged:   1,
+			},
+			ExcFunc: func(ctx context.Context) any {
+				if err := movement(ctx, stockbus.TypeWriteOff, inventorybus.StatusDamaged, 2); err != nil {
+					return err
+				}
+
+				inv, err := dbt.BusDomain.Inventory.QueryByID(ctx, sd.Inventories[0].ID)
+				if err != nil {
+					return err
+				}
+
+				return inv.MedicineBuckets[sd.Medicines[0].ID]
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "reserve-quarantined",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				sm := inventorybus.StatusMove{
+					InventoryID: sd.Inventories[0].ID,
+					LotID:       sd.Lots[0].ID,
+					From:        inventorybus.StatusAvailable,
+					To:          inventorybus.StatusQuarantined,
+					Quantity:    7,
+					Reason:      "Held for QA",
+					UserID:      sd.Admins[0].ID,
+				}
+
+				if _, err := dbt.BusDomain.Inventory.MoveStatus(ctx, sm); err != nil {
+					return err
+				}
+
+				nr := reservationbus.NewReservation{
+					InventoryID: sd.Inventories[0].ID,
+					MedicineID:  sd.Medicines[0].ID,
+					Quantity:    1,
+					TTL:         time.Hour,
+					UserID:      sd.Admins[0].ID,
+				}
+
+				_, err := dbt.BusDomain.Reservation.Reserve(ctx, nr)
+				return errors.Is(err, reservationbus.ErrUnavailable)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
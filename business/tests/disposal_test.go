@@ -0,0 +1,224 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/data/dbtest"
+	"github.com/EnesDemirtas/medisync/business/domain/disposalbus"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Disposal(t *testing.T) {
+	t.Parallel()
+
+	dbTest := dbtest.NewTest(t, c, "Test_Disposal")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log(r)
+			t.Error(string(debug.Stack()))
+		}
+		dbTest.Teardown()
+	}()
+
+	sd, err := insertDisposalSeedData(dbTest)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	// -------------------------------------------------------------------------
+
+	dbtest.UnitTest(t, disposalFlow(dbTest, sd), "disposal-flow")
+}
+
+// =============================================================================
+
+// insertDisposalSeedData seeds a lot with 10 units in one inventory, 4 of
+// them expired, along with a second user to witness disposals.
+func insertDisposalSeedData(dbTest *dbtest.Test) (dbtest.SeedData, error) {
+	ctx := context.Background()
+	busDomain := dbTest.BusDomain
+
+	admins, err := userbus.TestGenerateSeedUsers(ctx, 1, userbus.RoleAdmin, busDomain.User)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding admins : %w", err)
+	}
+
+	usrs, err := userbus.TestGenerateSeedUsers(ctx, 1, userbus.RoleUser, busDomain.User)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	meds, err := medicinebus.TestGenerateSeedMedicines(ctx, 1, busDomain.Medicine)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding medicines : %w", err)
+	}
+
+	lots, err := lotbus.TestGenerateSeedLots(ctx, 1, busDomain.Lot, meds[0].ID)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding lots : %w", err)
+	}
+
+	invs, err := inventorybus.TestGenerateSeedInventories(ctx, 1, busDomain.Inventory)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding inventories : %w", err)
+	}
+
+	nm := stockbus.NewMovement{
+		InventoryID: invs[0].ID,
+		LotID:       lots[0].ID,
+		Type:        stockbus.TypeReceive,
+		Quantity:    10,
+		UserID:      admins[0].ID,
+	}
+
+	if _, err := busDomain.Stock.Create(ctx, nm); err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding stock : %w", err)
+	}
+
+	sm := inventorybus.StatusMove{
+		InventoryID: invs[0].ID,
+		LotID:       lots[0].ID,
+		From:        inventorybus.StatusAvailable,
+		To:          inventorybus.StatusExpired,
+		Quantity:    4,
+		Reason:      "Expired on shelf",
+		UserID:      admins[0].ID,
+	}
+
+	if _, err := busDomain.Inventory.MoveStatus(ctx, sm); err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding expired stock : %w", err)
+	}
+
+	sd := dbtest.SeedData{
+		Admins:      []dbtest.User{{User: admins[0]}},
+		Users:       []dbtest.User{{User: usrs[0]}},
+		Medicines:   meds,
+		Lots:        lots,
+		Inventories: invs,
+	}
+
+	return sd, nil
+}
+
+// =============================================================================
+
+func disposalFlow(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
+	var dsp disposalbus.Disposal
+
+	start := time.Now().Add(-time.Hour)
+
+	newDisposal := func(witness dbtest.User) disposalbus.NewDisposal {
+		return disposalbus.NewDisposal{
+			InventoryID: sd.Inventories[0].ID,
+			Method:      disposalbus.MethodIncineration,
+			Reason:      "Expired stock",
+			Lines: []disposalbus.NewLine{
+				{LotID: sd.Lots[0].ID, Status: inventorybus.StatusExpired, Quantity: 4},
+			},
+			WitnessID: witness.ID,
+			UserID:    sd.Admins[0].ID,
+		}
+	}
+
+	table := []dbtest.UnitTable{
+		{
+			Name:    "self-witness",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				_, err := dbt.BusDomain.Disposal.Create(ctx, newDisposal(sd.Admins[0]))
+				return errors.Is(err, disposalbus.ErrSelfWitness)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "create",
+			ExpResp: inventorybus.Buckets{Available: 6},
+			ExcFunc: func(ctx context.Context) any {
+				var err error
+				dsp, err = dbt.BusDomain.Disposal.Create(ctx, newDisposal(sd.Users[0]))
+				if err != nil {
+					return err
+				}
+
+				var filter inventorybus.BucketFilter
+				filter.WithInventoryID(sd.Inventories[0].ID)
+
+				lbs, err := dbt.BusDomain.Inventory.QueryBuckets(ctx, filter)
+				if err != nil {
+					return err
+				}
+
+				if len(lbs) != 1 {
+					return fmt.Errorf("expected 1 lot, got %d", len(lbs))
+				}
+
+				return lbs[0].Buckets
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "certify-twice",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				cert := disposalbus.Certificate{
+					Number: "COD-2024-0042",
+					Issuer: "Medical Waste Ltd",
+				}
+
+				var err error
+				dsp, err = dbt.BusDomain.Disposal.Certify(ctx, dsp, cert)
+				if err != nil {
+					return err
+				}
+
+				_, err = dbt.BusDomain.Disposal.Certify(ctx, dsp, cert)
+				return errors.Is(err, disposalbus.ErrCertificateIssued)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name: "register",
+			ExpResp: []disposalbus.RegisterTotal{
+				{MedicineID: sd.Medicines[0].ID, Quantity: 4},
+			},
+			ExcFunc: func(ctx context.Context) any {
+				filter := disposalbus.RegisterFilter{
+					StartDate: start,
+					EndDate:   time.Now(),
+				}
+
+				rgs, err := dbt.BusDomain.Disposal.QueryRegister(ctx, filter)
+				if err != nil {
+					return err
+				}
+
+				if len(rgs.Entries) != 1 || rgs.Entries[0].CertificateNumber != "COD-2024-0042" {
+					return fmt.Errorf("unexpected entries: %+v", rgs.Entries)
+				}
+
+				return rgs.Totals
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
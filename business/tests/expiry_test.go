@@ -0,0 +1,183 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/data/dbtest"
+	"github.com/EnesDemirtas/medisync/business/domain/expirybus"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Expiry(t *testing.T) {
+	t.Parallel()
+
+	dbTest := dbtest.NewTest(t, c, "Test_Expiry")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log(r)
+			t.Error(string(debug.Stack()))
+		}
+		dbTest.Teardown()
+	}()
+
+	sd, err := insertExpirySeedData(dbTest)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	// -------------------------------------------------------------------------
+
+	dbtest.UnitTest(t, expiryScan(dbTest, sd), "expiry-scan")
+}
+
+// =============================================================================
+
+func insertExpirySeedData(dbTest *dbtest.Test) (dbtest.SeedData, error) {
+	ctx := context.Background()
+	busDomain := dbTest.BusDomain
+
+	usrs, err := userbus.TestGenerateSeedUsers(ctx, 1, userbus.RoleAdmin, busDomain.User)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	meds, err := medicinebus.TestGenerateSeedMedicines(ctx, 1, busDomain.Medicine)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding medicines : %w", err)
+	}
+
+	lots, err := lotbus.TestGenerateSeedLots(ctx, 4, busDomain.Lot, meds[0].ID)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding lots : %w", err)
+	}
+
+	invs, err := inventorybus.TestGenerateSeedInventories(ctx, 1, busDomain.Inventory)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding inventories : %w", err)
+	}
+
+	// Lots expiring in 5 days, 60 days and yesterday. The last lot keeps its
+	// expiry a year out.
+	for i, days := range []int{5, 60, -1} {
+		expiryDate := time.Now().AddDate(0, 0, days)
+
+		lot, err := busDomain.Lot.Update(ctx, lots[i], lotbus.UpdateLot{ExpiryDate: &expiryDate})
+		if err != nil {
+			return dbtest.SeedData{}, fmt.Errorf("updating lot : %w", err)
+		}
+		lots[i] = lot
+	}
+
+	for _, lot := range lots {
+		nm := stockbus.NewMovement{
+			InventoryID: invs[0].ID,
+			LotID:       lot.ID,
+			Type:        stockbus.TypeReceive,
+			Quantity:    10,
+			UserID:      usrs[0].ID,
+		}
+
+		if _, err := busDomain.Stock.Create(ctx, nm); err != nil {
+			return dbtest.SeedData{}, fmt.Errorf("seeding stock : %w", err)
+		}
+	}
+
+	sd := dbtest.SeedData{
+		Admins:      []dbtest.User{{User: usrs[0]}},
+		Medicines:   meds,
+		Lots:        lots,
+		Inventories: invs,
+	}
+
+	return sd, nil
+}
+
+// =============================================================================
+
+func expiryScan(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
+	horizons := []int{90, 30, 7}
+
+	scan := func(ctx context.Context, now time.Time) any {
+		alerts, err := dbt.BusDomain.Expiry.Scan(ctx, now, horizons)
+		if err != nil {
+			return err
+		}
+
+		resp := make(map[string]int)
+		for _, alert := range alerts {
+			resp[alert.LotID.String()] = alert.Horizon
+		}
+
+		return resp
+	}
+
+	table := []dbtest.UnitTable{
+		{
+			Name: "first",
+			ExpResp: map[string]int{
+				sd.Lots[0].ID.String(): 7,
+				sd.Lots[1].ID.String(): 90,
+				sd.Lots[2].ID.String(): 0,
+			},
+			ExcFunc: func(ctx context.Context) any {
+				return scan(ctx, time.Now())
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "repeat",
+			ExpResp: map[string]int{},
+			ExcFunc: func(ctx context.Context) any {
+				return scan(ctx, time.Now())
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name: "later",
+			ExpResp: map[string]int{
+				sd.Lots[0].ID.String(): 0,
+				sd.Lots[1].ID.String(): 30,
+			},
+			ExcFunc: func(ctx context.Context) any {
+				return scan(ctx, time.Now().AddDate(0, 0, 40))
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "expired",
+			ExpResp: 2,
+			ExcFunc: func(ctx context.Context) any {
+				var filter expirybus.QueryFilter
+				filter.WithExpired(true)
+				filter.WithInventoryID(sd.Inventories[0].ID)
+
+				n, err := dbt.BusDomain.Expiry.Count(ctx, filter)
+				if err != nil {
+					return err
+				}
+
+				return n
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
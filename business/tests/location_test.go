@@ -0,0 +1,264 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"testing"
+
+	"github.com/EnesDemirtas/medisync/business/data/dbtest"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func Test_Location(t *testing.T) {
+	t.Parallel()
+
+	dbTest := dbtest.NewTest(t, c, "Test_Location")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log(r)
+			t.Error(string(debug.Stack()))
+		}
+		dbTest.Teardown()
+	}()
+
+	sd, err := insertLocationSeedData(dbTest)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	// -------------------------------------------------------------------------
+
+	dbtest.UnitTest(t, locationFlow(dbTest, sd), "location-flow")
+}
+
+// =============================================================================
+
+// insertLocationSeedData seeds an inventory holding 10 units of a single lot
+// that has not been put away yet.
+func insertLocationSeedData(dbTest *dbtest.Test) (dbtest.SeedData, error) {
+	ctx := context.Background()
+	busDomain := dbTest.BusDomain
+
+	usrs, err := userbus.TestGenerateSeedUsers(ctx, 1, userbus.RoleAdmin, busDomain.User)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	meds, err := medicinebus.TestGenerateSeedMedicines(ctx, 1, busDomain.Medicine)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding medicines : %w", err)
+	}
+
+	lots, err := lotbus.TestGenerateSeedLots(ctx, 1, busDomain.Lot, meds[0].ID)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding lots : %w", err)
+	}
+
+	invs, err := inventorybus.TestGenerateSeedInventories(ctx, 1, busDomain.Inventory)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding inventories : %w", err)
+	}
+
+	nm := stockbus.NewMovement{
+		InventoryID: invs[0].ID,
+		LotID:       lots[0].ID,
+		Type:        stockbus.TypeReceive,
+		Quantity:    10,
+		UserID:      usrs[0].ID,
+	}
+
+	if _, err := busDomain.Stock.Create(ctx, nm); err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding stock : %w", err)
+	}
+
+	sd := dbtest.SeedData{
+		Admins:      []dbtest.User{{User: usrs[0]}},
+		Medicines:   meds,
+		Lots:        lots,
+		Inventories: invs,
+	}
+
+	return sd, nil
+}
+
+// =============================================================================
+
+func locationFlow(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
+	var room, bin locationbus.Location
+
+	dispense := func(ctx context.Context, locationID uuid.UUID, quantity int) error {
+		nm := stockbus.NewMovement{
+			InventoryID: sd.Inventories[0].ID,
+			LotID:       sd.Lots[0].ID,
+			LocationID:  locationID,
+			Type:        stockbus.TypeDispense,
+			Quantity:    quantity,
+			UserID:      sd.Admins[0].ID,
+		}
+
+		_, err := dbt.BusDomain.Stock.Create(ctx, nm)
+		return err
+	}
+
+	table := []dbtest.UnitTable{
+		{
+			Name:    "create-hierarchy",
+			ExpResp: "R1/A/3/07",
+			ExcFunc: func(ctx context.Context) any {
+				var parentID uuid.UUID
+
+				kinds := []locationbus.Kind{locationbus.KindRoom, locationbus.KindRack, locationbus.KindShelf, locationbus.KindBin}
+				for i, code := range []string{"R1", "A", "3", "07"} {
+					nl := locationbus.NewLocation{
+						InventoryID: sd.Inventories[0].ID,
+						ParentID:    parentID,
+						Kind:        kinds[i],
+						Code:        code,
+						Name:        kinds[i].Name() + " " + code,
+					}
+
+					loc, err := dbt.BusDomain.Location.Create(ctx, nl)
+					if err != nil {
+						return err
+					}
+
+					if i == 0 {
+						room = loc
+					}
+
+					bin = loc
+					parentID = loc.ID
+				}
+
+				return bin.Path
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "invalid-parent",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				nl := locationbus.NewLocation{
+					InventoryID: sd.Inventories[0].ID,
+					ParentID:    room.ID,
+					Kind:        locationbus.KindBin,
+					Code:        "99",
+					Name:        "Misplaced bin",
+				}
+
+				_, err := dbt.BusDomain.Location.Create(ctx, nl)
+				return errors.Is(err, locationbus.ErrInvalidParent)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name: "dispense-placed",
+			ExpResp: map[string]int{
+				"R1/A/3/07": 7,
+			},
+			ExcFunc: func(ctx context.Context) any {
+				r := locationbus.Relocation{
+					InventoryID: sd.Inventories[0].ID,
+					LotID:       sd.Lots[0].ID,
+					ToID:        bin.ID,
+					Quantity:    8,
+				}
+
+				if _, err := dbt.BusDomain.Location.Relocate(ctx, r); err != nil {
+					return err
+				}
+
+				// Only 2 units are unplaced, the rest is drawn from the bin.
+				if err := dispense(ctx, uuid.Nil, 3); err != nil {
+					return err
+				}
+
+				var filter locationbus.PlacementFilter
+				filter.WithLotID(sd.Lots[0].ID)
+
+				placements, err := dbt.BusDomain.Location.QueryPlacements(ctx, filter)
+				if err != nil {
+					return err
+				}
+
+				m := make(map[string]int, len(placements))
+				for _, p := range placements {
+					m[p.Path] = p.Quantity
+				}
+
+				return m
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "dispense-bin",
+			ExpResp: 4,
+			ExcFunc: func(ctx context.Context) any {
+				if err := dispense(ctx, bin.ID, 3); err != nil {
+					return err
+				}
+
+				var filter locationbus.PlacementFilter
+				filter.WithInventoryID(sd.Inventories[0].ID)
+
+				rollups, err := dbt.BusDomain.Location.QueryRollups(ctx, filter)
+				if err != nil {
+					return err
+				}
+
+				for _, r := range rollups {
+					if r.ID == room.ID {
+						return r.Quantity
+					}
+				}
+
+				return fmt.Errorf("room %s missing from rollups", room.ID)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name: "where-is-lot",
+			ExpResp: map[string]int{
+				"R1/A/3/07": 4,
+			},
+			ExcFunc: func(ctx context.Context) any {
+				var filter locationbus.PlacementFilter
+				filter.WithLotID(sd.Lots[0].ID)
+
+				placements, err := dbt.BusDomain.Location.QueryPlacements(ctx, filter)
+				if err != nil {
+					return err
+				}
+
+				m := make(map[string]int, len(placements))
+				for _, p := range placements {
+					m[p.Path] = p.Quantity
+				}
+
+				return m
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
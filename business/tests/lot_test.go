@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/data/dbtest"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Lot(t *testing.T) {
+	t.Parallel()
+
+	dbTest := dbtest.NewTest(t, c, "Test_Lot")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log(r)
+			t.Error(string(debug.Stack()))
+		}
+		dbTest.Teardown()
+	}()
+
+	sd, err := insertLotSeedData(dbTest)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	// -------------------------------------------------------------------------
+
+	dbtest.UnitTest(t, lotExpiry(dbTest, sd), "lot-expiry")
+}
+
+// =============================================================================
+
+func insertLotSeedData(dbTest *dbtest.Test) (dbtest.SeedData, error) {
+	ctx := context.Background()
+	busDomain := dbTest.BusDomain
+
+	meds, err := medicinebus.TestGenerateSeedMedicines(ctx, 2, busDomain.Medicine)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding medicines : %w", err)
+	}
+
+	nl := lotbus.NewLot{
+		MedicineID: meds[0].ID,
+		Number:     "SHORT",
+		ExpiryDate: time.Now().AddDate(0, 0, 10),
+	}
+
+	short, err := busDomain.Lot.Create(ctx, nl)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding lots : %w", err)
+	}
+
+	long, err := lotbus.TestGenerateSeedLots(ctx, 1, busDomain.Lot, meds[1].ID)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding lots : %w", err)
+	}
+
+	sd := dbtest.SeedData{
+		Medicines: meds,
+		Lots:      []lotbus.Lot{short, long[0]},
+	}
+
+	return sd, nil
+}
+
+// =============================================================================
+
+func lotExpiry(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
+	table := []dbtest.UnitTable{
+		{
+			Name:    "lots",
+			ExpResp: []string{"SHORT"},
+			ExcFunc: func(ctx context.Context) any {
+				var filter lotbus.QueryFilter
+				filter.WithEndExpiryDate(time.Now().AddDate(0, 1, 0))
+
+				lots, err := dbt.BusDomain.Lot.Query(ctx, filter, lotbus.DefaultOrderBy, 1, 10)
+				if err != nil {
+					return err
+				}
+
+				numbers := make([]string, len(lots))
+				for i, lot := range lots {
+					numbers[i] = lot.Number
+				}
+
+				return numbers
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "medicines",
+			ExpResp: []string{sd.Medicines[0].ID.String()},
+			ExcFunc: func(ctx context.Context) any {
+				var filter medicinebus.QueryFilter
+				filter.WithEndExpiryDate(time.Now().AddDate(0, 1, 0))
+
+				meds, err := dbt.BusDomain.Medicine.Query(ctx, filter, medicinebus.DefaultOrderBy, 1, 10)
+				if err != nil {
+					return err
+				}
+
+				ids := make([]string, len(meds))
+				for i, med := range meds {
+					ids[i] = med.ID.String()
+				}
+
+				return ids
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
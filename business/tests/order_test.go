@@ -0,0 +1,279 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/data/dbtest"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/locationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func Test_Order(t *testing.T) {
+	t.Parallel()
+
+	dbTest := dbtest.NewTest(t, c, "Test_Order")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log(r)
+			t.Error(string(debug.Stack()))
+		}
+		dbTest.Teardown()
+	}()
+
+	sd, err := insertOrderSeedData(dbTest)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	// -------------------------------------------------------------------------
+
+	dbtest.UnitTest(t, orderFlow(dbTest, sd), "order-flow")
+}
+
+// =============================================================================
+
+// insertOrderSeedData seeds an inventory holding 5 of a lot expiring in 60
+// days and 10 of a lot of the same medicine expiring in 120 days. All of the
+// first lot and 8 of the second are put away into a bin.
+func insertOrderSeedData(dbTest *dbtest.Test) (dbtest.SeedData, error) {
+	ctx := context.Background()
+	busDomain := dbTest.BusDomain
+
+	usrs, err := userbus.TestGenerateSeedUsers(ctx, 1, userbus.RoleAdmin, busDomain.User)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	meds, err := medicinebus.TestGenerateSeedMedicines(ctx, 1, busDomain.Medicine)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding medicines : %w", err)
+	}
+
+	invs, err := inventorybus.TestGenerateSeedInventories(ctx, 1, busDomain.Inventory)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding inventories : %w", err)
+	}
+
+	var lots []lotbus.Lot
+	for i, stock := range []struct{ days, quantity int }{{60, 5}, {120, 10}} {
+		nl := lotbus.NewLot{
+			MedicineID:      meds[0].ID,
+			Number:          fmt.Sprintf("ORD%d", i),
+			ExpiryDate:      time.Now().AddDate(0, 0, stock.days),
+			ManufactureDate: time.Now().AddDate(-1, 0, 0),
+		}
+
+		lot, err := busDomain.Lot.Create(ctx, nl)
+		if err != nil {
+			return dbtest.SeedData{}, fmt.Errorf("seeding lots : %w", err)
+		}
+
+		nm := stockbus.NewMovement{
+			InventoryID: invs[0].ID,
+			LotID:       lot.ID,
+			Type:        stockbus.TypeReceive,
+			Quantity:    stock.quantity,
+			UserID:      usrs[0].ID,
+		}
+
+		if _, err := busDomain.Stock.Create(ctx, nm); err != nil {
+			return dbtest.SeedData{}, fmt.Errorf("seeding stock : %w", err)
+		}
+
+		lots = append(lots, lot)
+	}
+
+	var parentID uuid.UUID
+	kinds := []locationbus.Kind{locationbus.KindRoom, locationbus.KindRack, locationbus.KindShelf, locationbus.KindBin}
+	for i, code := range []string{"R1", "A", "1", "01"} {
+		nl := locationbus.NewLocation{
+			InventoryID: invs[0].ID,
+			ParentID:    parentID,
+			Kind:        kinds[i],
+			Code:        code,
+			Name:        kinds[i].Name() + " " + code,
+		}
+
+		loc, err := busDomain.Location.Create(ctx, nl)
+		if err != nil {
+			return dbtest.SeedData{}, fmt.Errorf("seeding locations : %w", err)
+		}
+
+		parentID = loc.ID
+	}
+
+	for i, quantity := range []int{5, 8} {
+		r := locationbus.Relocation{
+			InventoryID: invs[0].ID,
+			LotID:       lots[i].ID,
+			ToID:        parentID,
+			Quantity:    quantity,
+		}
+
+		if _, err := busDomain.Location.Relocate(ctx, r); err != nil {
+			return dbtest.SeedData{}, fmt.Errorf("seeding placements : %w", err)
+		}
+	}
+
+	sd := dbtest.SeedData{
+		Admins:      []dbtest.User{{User: usrs[0]}},
+		Medicines:   meds,
+		Lots:        lots,
+		Inventories: invs,
+	}
+
+	return sd, nil
+}
+
+// =============================================================================
+
+func orderFlow(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
+	var ord orderbus.Order
+
+	table := []dbtest.UnitTable{
+		{
+			Name:    "create",
+			ExpResp: orderbus.StatusDraft,
+			ExcFunc: func(ctx context.Context) any {
+				no := orderbus.NewOrder{
+					InventoryID: sd.Inventories[0].ID,
+					Destination: orderbus.Destination{
+						Type: orderbus.DestinationWard,
+						Name: "Cardiology",
+					},
+					Lines: []orderbus.NewLine{
+						{MedicineID: sd.Medicines[0].ID, Quantity: 8},
+					},
+					UserID: sd.Admins[0].ID,
+				}
+
+				var err error
+				ord, err = dbt.BusDomain.Order.Create(ctx, no)
+				if err != nil {
+					return err
+				}
+
+				return ord.Status
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "ship-unpicked",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				_, err := dbt.BusDomain.Order.Ship(ctx, ord.ID, sd.Admins[0].ID, uuid.Nil)
+				return errors.Is(err, orderbus.ErrInvalidTransition)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name: "pick",
+			ExpResp: []orderbus.Pick{
+				{LotID: sd.Lots[0].ID, Quantity: 5},
+				{LotID: sd.Lots[1].ID, Quantity: 3},
+			},
+			ExcFunc: func(ctx context.Context) any {
+				if _, err := dbt.BusDomain.Order.Approve(ctx, ord.ID, sd.Admins[0].ID); err != nil {
+					return err
+				}
+
+				pk := orderbus.Picking{
+					UserID: sd.Admins[0].ID,
+				}
+
+				picked, err := dbt.BusDomain.Order.Pick(ctx, ord.ID, pk)
+				if err != nil {
+					return err
+				}
+
+				return picked.Lines[0].Picks
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name: "ship",
+			ExpResp: map[uuid.UUID]int{
+				sd.Lots[0].ID: 0,
+				sd.Lots[1].ID: 7,
+			},
+			ExcFunc: func(ctx context.Context) any {
+				shipped, err := dbt.BusDomain.Order.Ship(ctx, ord.ID, sd.Admins[0].ID, uuid.Nil)
+				if err != nil {
+					return err
+				}
+
+				if shipped.Status != orderbus.StatusShipped {
+					return fmt.Errorf("expected status %s, got %s", orderbus.StatusShipped.Name(), shipped.Status.Name())
+				}
+
+				inv, err := dbt.BusDomain.Inventory.QueryByID(ctx, sd.Inventories[0].ID)
+				if err != nil {
+					return err
+				}
+
+				return inv.LotQuantities
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name: "ship-drew-bins",
+			ExpResp: map[uuid.UUID]int{
+				sd.Lots[1].ID: 7,
+			},
+			ExcFunc: func(ctx context.Context) any {
+				var filter locationbus.PlacementFilter
+				filter.WithInventoryID(sd.Inventories[0].ID)
+
+				placements, err := dbt.BusDomain.Location.QueryPlacements(ctx, filter)
+				if err != nil {
+					return err
+				}
+
+				// The unplaced stock of the second lot went first, the rest
+				// of the shipment was drawn from the bin.
+				m := make(map[uuid.UUID]int, len(placements))
+				for _, p := range placements {
+					m[p.LotID] += p.Quantity
+				}
+
+				return m
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "cancel-shipped",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				_, err := dbt.BusDomain.Order.Cancel(ctx, ord.ID)
+				return errors.Is(err, orderbus.ErrInvalidTransition)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
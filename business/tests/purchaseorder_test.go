@@ -0,0 +1,236 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/data/dbtest"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/purchaseorderbus"
+	"github.com/EnesDemirtas/medisync/business/domain/supplierbus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func Test_PurchaseOrder(t *testing.T) {
+	t.Parallel()
+
+	dbTest := dbtest.NewTest(t, c, "Test_PurchaseOrder")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log(r)
+			t.Error(string(debug.Stack()))
+		}
+		dbTest.Teardown()
+	}()
+
+	sd, sup, err := insertPurchaseOrderSeedData(dbTest)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	// -------------------------------------------------------------------------
+
+	dbtest.UnitTest(t, purchaseOrderFlow(dbTest, sd, sup), "purchase-order-flow")
+}
+
+// =============================================================================
+
+// insertPurchaseOrderSeedData seeds an empty inventory, two medicines and the
+// supplier they are bought from.
+func insertPurchaseOrderSeedData(dbTest *dbtest.Test) (dbtest.SeedData, supplierbus.Supplier, error) {
+	ctx := context.Background()
+	busDomain := dbTest.BusDomain
+
+	usrs, err := userbus.TestGenerateSeedUsers(ctx, 1, userbus.RoleAdmin, busDomain.User)
+	if err != nil {
+		return dbtest.SeedData{}, supplierbus.Supplier{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	meds, err := medicinebus.TestGenerateSeedMedicines(ctx, 2, busDomain.Medicine)
+	if err != nil {
+		return dbtest.SeedData{}, supplierbus.Supplier{}, fmt.Errorf("seeding medicines : %w", err)
+	}
+
+	invs, err := inventorybus.TestGenerateSeedInventories(ctx, 1, busDomain.Inventory)
+	if err != nil {
+		return dbtest.SeedData{}, supplierbus.Supplier{}, fmt.Errorf("seeding inventories : %w", err)
+	}
+
+	ns := supplierbus.NewSupplier{
+		Name:  "Acme Pharma",
+		Email: "orders@acme.example",
+	}
+
+	sup, err := busDomain.Supplier.Create(ctx, ns)
+	if err != nil {
+		return dbtest.SeedData{}, supplierbus.Supplier{}, fmt.Errorf("seeding suppliers : %w", err)
+	}
+
+	sd := dbtest.SeedData{
+		Admins:      []dbtest.User{{User: usrs[0]}},
+		Medicines:   meds,
+		Inventories: invs,
+	}
+
+	return sd, sup, nil
+}
+
+// =============================================================================
+
+func purchaseOrderFlow(dbt *dbtest.Test, sd dbtest.SeedData, sup supplierbus.Supplier) []dbtest.UnitTable {
+	type variance struct {
+		Received int
+		Short    int
+		Over     int
+	}
+
+	variances := func(po purchaseorderbus.PurchaseOrder) map[uuid.UUID]variance {
+		m := make(map[uuid.UUID]variance, len(po.Lines))
+		for _, line := range po.Lines {
+			m[line.MedicineID] = variance{Received: line.Received, Short: line.Short(), Over: line.Over()}
+		}
+		return m
+	}
+
+	expiry := time.Now().AddDate(1, 0, 0).Truncate(time.Second)
+
+	var po purchaseorderbus.PurchaseOrder
+	var dlv purchaseorderbus.Delivery
+
+	table := []dbtest.UnitTable{
+		{
+			Name:    "create",
+			ExpResp: purchaseorderbus.StatusDraft,
+			ExcFunc: func(ctx context.Context) any {
+				npo := purchaseorderbus.NewPurchaseOrder{
+					SupplierID:  sup.ID,
+					InventoryID: sd.Inventories[0].ID,
+					Lines: []purchaseorderbus.NewLine{
+						{MedicineID: sd.Medicines[0].ID, Quantity: 10},
+						{MedicineID: sd.Medicines[1].ID, Quantity: 5},
+					},
+					UserID: sd.Admins[0].ID,
+				}
+
+				var err error
+				po, err = dbt.BusDomain.PurchaseOrder.Create(ctx, npo)
+				if err != nil {
+					return err
+				}
+
+				return po.Status
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "receive-unsent",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				nd := purchaseorderbus.NewDelivery{
+					Lines: []purchaseorderbus.NewDeliveryLine{
+						{MedicineID: sd.Medicines[0].ID, LotNumber: "PO-A", ExpiryDate: expiry, Quantity: 1},
+					},
+					UserID: sd.Admins[0].ID,
+				}
+
+				_, _, err := dbt.BusDomain.PurchaseOrder.Receive(ctx, po.ID, nd)
+				return errors.Is(err, purchaseorderbus.ErrInvalidTransition)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name: "receive-partial",
+			ExpResp: map[uuid.UUID]variance{
+				sd.Medicines[0].ID: {Received: 6, Short: 4},
+				sd.Medicines[1].ID: {Received: 7, Over: 2},
+			},
+			ExcFunc: func(ctx context.Context) any {
+				if _, err := dbt.BusDomain.PurchaseOrder.Approve(ctx, po.ID, sd.Admins[0].ID); err != nil {
+					return err
+				}
+
+				if _, err := dbt.BusDomain.PurchaseOrder.Send(ctx, po.ID, sd.Admins[0].ID); err != nil {
+					return err
+				}
+
+				nd := purchaseorderbus.NewDelivery{
+					Lines: []purchaseorderbus.NewDeliveryLine{
+						{MedicineID: sd.Medicines[0].ID, LotNumber: "PO-A", ExpiryDate: expiry, Quantity: 6},
+						{MedicineID: sd.Medicines[1].ID, LotNumber: "PO-B", ExpiryDate: expiry, Quantity: 7},
+					},
+					UserID: sd.Admins[0].ID,
+				}
+
+				var err error
+				dlv, po, err = dbt.BusDomain.PurchaseOrder.Receive(ctx, po.ID, nd)
+				if err != nil {
+					return err
+				}
+
+				if po.Status != purchaseorderbus.StatusPartiallyReceived {
+					return fmt.Errorf("expected status %s, got %s", purchaseorderbus.StatusPartiallyReceived.Name(), po.Status.Name())
+				}
+
+				return variances(po)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "receive-lot-mismatch",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				nd := purchaseorderbus.NewDelivery{
+					Lines: []purchaseorderbus.NewDeliveryLine{
+						{MedicineID: sd.Medicines[0].ID, LotNumber: "PO-A", ExpiryDate: expiry.AddDate(0, 1, 0), Quantity: 4},
+					},
+					UserID: sd.Admins[0].ID,
+				}
+
+				_, _, err := dbt.BusDomain.PurchaseOrder.Receive(ctx, po.ID, nd)
+				return errors.Is(err, purchaseorderbus.ErrLotMismatch)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "close-short",
+			ExpResp: []int{6, 7},
+			ExcFunc: func(ctx context.Context) any {
+				closed, err := dbt.BusDomain.PurchaseOrder.Close(ctx, po.ID)
+				if err != nil {
+					return err
+				}
+
+				if closed.Status != purchaseorderbus.StatusReceived {
+					return fmt.Errorf("expected status %s, got %s", purchaseorderbus.StatusReceived.Name(), closed.Status.Name())
+				}
+
+				inv, err := dbt.BusDomain.Inventory.QueryByID(ctx, sd.Inventories[0].ID)
+				if err != nil {
+					return err
+				}
+
+				return []int{inv.LotQuantities[dlv.Lines[0].LotID], inv.LotQuantities[dlv.Lines[1].LotID]}
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
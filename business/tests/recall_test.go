@@ -0,0 +1,381 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/data/dbtest"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/orderbus"
+	"github.com/EnesDemirtas/medisync/business/domain/recallbus"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func Test_Recall(t *testing.T) {
+	t.Parallel()
+
+	dbTest := dbtest.NewTest(t, c, "Test_Recall")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log(r)
+			t.Error(string(debug.Stack()))
+		}
+		dbTest.Teardown()
+	}()
+
+	sd, err := insertRecallSeedData(dbTest)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	// -------------------------------------------------------------------------
+
+	dbtest.UnitTest(t, recallFlow(dbTest, sd), "recall-flow")
+}
+
+// =============================================================================
+
+// insertRecallSeedData seeds a lot held by two inventories, 10 units in the
+// first and 5 in the second. Three units of the first were already shipped to
+// a ward, one of the second was dispensed directly and one more against a
+// reservation. The first inventory
+// also holds 6 units of a second lot, 2 of them on a QC hold.
+func insertRecallSeedData(dbTest *dbtest.Test) (dbtest.SeedData, error) {
+	ctx := context.Background()
+	busDomain := dbTest.BusDomain
+
+	usrs, err := userbus.TestGenerateSeedUsers(ctx, 1, userbus.RoleAdmin, busDomain.User)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	meds, err := medicinebus.TestGenerateSeedMedicines(ctx, 1, busDomain.Medicine)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding medicines : %w", err)
+	}
+
+	lots, err := lotbus.TestGenerateSeedLots(ctx, 2, busDomain.Lot, meds[0].ID)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding lots : %w", err)
+	}
+
+	invs, err := inventorybus.TestGenerateSeedInventories(ctx, 2, busDomain.Inventory)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding inventories : %w", err)
+	}
+
+	for i, qty := range []int{10, 5} {
+		nm := stockbus.NewMovement{
+			InventoryID: invs[i].ID,
+			LotID:       lots[0].ID,
+			Type:        stockbus.TypeReceive,
+			Quantity:    qty,
+			UserID:      usrs[0].ID,
+		}
+
+		if _, err := busDomain.Stock.Create(ctx, nm); err != nil {
+			return dbtest.SeedData{}, fmt.Errorf("seeding stock : %w", err)
+		}
+	}
+
+	no := orderbus.NewOrder{
+		InventoryID: invs[0].ID,
+		Destination: orderbus.Destination{
+			Type: orderbus.DestinationWard,
+			Name: "Oncology",
+		},
+		Lines: []orderbus.NewLine{
+			{MedicineID: meds[0].ID, Quantity: 3},
+		},
+		UserID: usrs[0].ID,
+	}
+
+	ord, err := busDomain.Order.Create(ctx, no)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding order : %w", err)
+	}
+
+	if _, err := busDomain.Order.Approve(ctx, ord.ID, usrs[0].ID); err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("approving order : %w", err)
+	}
+
+	if _, err := busDomain.Order.Pick(ctx, ord.ID, orderbus.Picking{UserID: usrs[0].ID}); err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("picking order : %w", err)
+	}
+
+	if _, err := busDomain.Order.Ship(ctx, ord.ID, usrs[0].ID, uuid.Nil); err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("shipping order : %w", err)
+	}
+
+	dispense := stockbus.NewMovement{
+		InventoryID: invs[1].ID,
+		LotID:       lots[0].ID,
+		Type:        stockbus.TypeDispense,
+		Quantity:    1,
+		UserID:      usrs[0].ID,
+	}
+
+	if _, err := busDomain.Stock.Create(ctx, dispense); err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding dispense : %w", err)
+	}
+
+	nr := reservationbus.NewReservation{
+		InventoryID: invs[1].ID,
+		MedicineID:  meds[0].ID,
+		Quantity:    1,
+		TTL:         time.Hour,
+		Reference:   "ICU bed 4",
+		UserID:      usrs[0].ID,
+	}
+
+	res, err := busDomain.Reservation.Reserve(ctx, nr)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding reservation : %w", err)
+	}
+
+	cons := reservationbus.Consumption{
+		Lines:  []reservationbus.ConsumptionLine{{LotID: lots[0].ID, Quantity: 1}},
+		UserID: usrs[0].ID,
+	}
+
+	if _, err := busDomain.Reservation.Consume(ctx, res.ID, cons); err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("consuming reservation : %w", err)
+	}
+
+	held := stockbus.NewMovement{
+		InventoryID: invs[0].ID,
+		LotID:       lots[1].ID,
+		Type:        stockbus.TypeReceive,
+		Quantity:    6,
+		UserID:      usrs[0].ID,
+	}
+
+	if _, err := busDomain.Stock.Create(ctx, held); err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding stock : %w", err)
+	}
+
+	sm := inventorybus.StatusMove{
+		InventoryID: invs[0].ID,
+		LotID:       lots[1].ID,
+		From:        inventorybus.StatusAvailable,
+		To:          inventorybus.StatusQuarantined,
+		Quantity:    2,
+		Reason:      "QC hold",
+		UserID:      usrs[0].ID,
+	}
+
+	if _, err := busDomain.Inventory.MoveStatus(ctx, sm); err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding qc hold : %w", err)
+	}
+
+	sd := dbtest.SeedData{
+		Admins:      []dbtest.User{{User: usrs[0]}},
+		Medicines:   meds,
+		Lots:        lots,
+		Inventories: invs,
+	}
+
+	return sd, nil
+}
+
+// =============================================================================
+
+func recallFlow(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
+	var rcl recallbus.Recall
+
+	resolve := func(ctx context.Context, inventoryID uuid.UUID, method recallbus.Method, quantity int) (recallbus.Recall, error) {
+		res := recallbus.Resolution{
+			InventoryID: inventoryID,
+			LotID:       sd.Lots[0].ID,
+			Method:      method,
+			Quantity:    quantity,
+			UserID:      sd.Admins[0].ID,
+		}
+
+		return dbt.BusDomain.Recall.Resolve(ctx, rcl.ID, res)
+	}
+
+	table := []dbtest.UnitTable{
+		{
+			Name:    "create",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				nr := recallbus.NewRecall{
+					MedicineID: sd.Medicines[0].ID,
+					LotIDs:     []uuid.UUID{sd.Lots[0].ID},
+					Reason:     "Particulate contamination",
+					Severity:   recallbus.SeverityClassI,
+					UserID:     sd.Admins[0].ID,
+				}
+
+				var err error
+				rcl, err = dbt.BusDomain.Recall.Create(ctx, nr)
+				if err != nil {
+					return err
+				}
+
+				lot, err := dbt.BusDomain.Lot.QueryByID(ctx, sd.Lots[0].ID)
+				if err != nil {
+					return err
+				}
+
+				return lot.Quarantined
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "dispense-quarantined",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				nm := stockbus.NewMovement{
+					InventoryID: sd.Inventories[1].ID,
+					LotID:       sd.Lots[0].ID,
+					Type:        stockbus.TypeDispense,
+					Quantity:    1,
+					UserID:      sd.Admins[0].ID,
+				}
+
+				_, err := dbt.BusDomain.Stock.Create(ctx, nm)
+				return errors.Is(err, stockbus.ErrQuarantined)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name: "report",
+			ExpResp: map[string]int{
+				sd.Inventories[0].ID.String(): 7,
+				sd.Inventories[1].ID.String(): 3,
+				"shipped":                     5,
+				"on-order":                    3,
+				"to-ward":                     3,
+				"reserved":                    1,
+			},
+			ExcFunc: func(ctx context.Context) any {
+				rpt, err := dbt.BusDomain.Recall.QueryReport(ctx, rcl)
+				if err != nil {
+					return err
+				}
+
+				m := make(map[string]int)
+				for _, s := range rpt.Stock {
+					m[s.InventoryID.String()] = s.Quarantined
+				}
+
+				for _, shp := range rpt.Shipments {
+					m["shipped"] += shp.Quantity
+					if shp.Reference.Type == stockbus.ReferenceOrder {
+						m["on-order"] += shp.Quantity
+					}
+					if shp.Destination.Type == orderbus.DestinationWard {
+						m["to-ward"] += shp.Quantity
+					}
+					if shp.ReservedFor == "ICU bed 4" {
+						m["reserved"] += shp.Quantity
+					}
+				}
+
+				return m
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "resolve",
+			ExpResp: recallbus.StatusResolved,
+			ExcFunc: func(ctx context.Context) any {
+				updRcl, err := resolve(ctx, sd.Inventories[0].ID, recallbus.MethodReturn, 7)
+				if err != nil {
+					return err
+				}
+
+				if updRcl.Status != recallbus.StatusOpen {
+					return fmt.Errorf("expected status %s, got %s", recallbus.StatusOpen.Name(), updRcl.Status.Name())
+				}
+
+				updRcl, err = resolve(ctx, sd.Inventories[1].ID, recallbus.MethodDestroy, 3)
+				if err != nil {
+					return err
+				}
+
+				return updRcl.Status
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name: "cancel",
+			ExpResp: inventorybus.Buckets{
+				Available:   3,
+				Quarantined: 2,
+			},
+			ExcFunc: func(ctx context.Context) any {
+				nr := recallbus.NewRecall{
+					MedicineID: sd.Medicines[0].ID,
+					LotIDs:     []uuid.UUID{sd.Lots[1].ID},
+					Reason:     "Registered in error",
+					Severity:   recallbus.SeverityClassIII,
+					UserID:     sd.Admins[0].ID,
+				}
+
+				cnl, err := dbt.BusDomain.Recall.Create(ctx, nr)
+				if err != nil {
+					return err
+				}
+
+				res := recallbus.Resolution{
+					InventoryID: sd.Inventories[0].ID,
+					LotID:       sd.Lots[1].ID,
+					Method:      recallbus.MethodDestroy,
+					Quantity:    1,
+					UserID:      sd.Admins[0].ID,
+				}
+
+				if _, err := dbt.BusDomain.Recall.Resolve(ctx, cnl.ID, res); err != nil {
+					return err
+				}
+
+				if _, err := dbt.BusDomain.Recall.Cancel(ctx, cnl.ID, sd.Admins[0].ID); err != nil {
+					return err
+				}
+
+				// Only what the recall held back and was not resolved is
+				// released, the QC hold stays in quarantine.
+				var filter inventorybus.BucketFilter
+				filter.WithInventoryID(sd.Inventories[0].ID)
+				filter.WithLotID(sd.Lots[1].ID)
+
+				lbs, err := dbt.BusDomain.Inventory.QueryBuckets(ctx, filter)
+				if err != nil {
+					return err
+				}
+
+				if len(lbs) != 1 {
+					return fmt.Errorf("expected 1 lot, got %d", len(lbs))
+				}
+
+				return lbs[0].Buckets
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
@@ -0,0 +1,197 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"testing"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/EnesDemirtas/medisync/business/data/dbtest"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Reorder(t *testing.T) {
+	t.Parallel()
+
+	dbTest := dbtest.NewTest(t, c, "Test_Reorder")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log(r)
+			t.Error(string(debug.Stack()))
+		}
+		dbTest.Teardown()
+	}()
+
+	sd, err := insertReorderSeedData(dbTest)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	// -------------------------------------------------------------------------
+
+	dbtest.UnitTest(t, reorderCrossing(dbTest, sd), "reorder-crossing")
+}
+
+// =============================================================================
+
+func insertReorderSeedData(dbTest *dbtest.Test) (dbtest.SeedData, error) {
+	ctx := context.Background()
+	busDomain := dbTest.BusDomain
+
+	usrs, err := userbus.TestGenerateSeedUsers(ctx, 1, userbus.RoleAdmin, busDomain.User)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	meds, err := medicinebus.TestGenerateSeedMedicines(ctx, 1, busDomain.Medicine)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding medicines : %w", err)
+	}
+
+	lots, err := lotbus.TestGenerateSeedLots(ctx, 2, busDomain.Lot, meds[0].ID)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding lots : %w", err)
+	}
+
+	invs, err := inventorybus.TestGenerateSeedInventories(ctx, 1, busDomain.Inventory)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding inventories : %w", err)
+	}
+
+	nrp := inventorybus.NewReorderPoint{
+		InventoryID:  invs[0].ID,
+		MedicineID:   meds[0].ID,
+		MinQuantity:  2,
+		MaxQuantity:  20,
+		ReorderPoint: 5,
+	}
+
+	if _, err := busDomain.Inventory.SetReorderPoint(ctx, nrp); err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding reorder point : %w", err)
+	}
+
+	sd := dbtest.SeedData{
+		Admins:      []dbtest.User{{User: usrs[0]}},
+		Medicines:   meds,
+		Lots:        lots,
+		Inventories: invs,
+	}
+
+	return sd, nil
+}
+
+// =============================================================================
+
+func reorderCrossing(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
+	var actions []string
+	record := func(action string) delegate.Func {
+		return func(ctx context.Context, data delegate.Data) error {
+			actions = append(actions, action)
+			return nil
+		}
+	}
+	dbt.BusDomain.Delegate.Register(inventorybus.Domain, inventorybus.ActionLowStock, record(inventorybus.ActionLowStock))
+	dbt.BusDomain.Delegate.Register(inventorybus.Domain, inventorybus.ActionRestocked, record(inventorybus.ActionRestocked))
+
+	move := func(ctx context.Context, lot int, typ stockbus.MovementType, quantity int) error {
+		nm := stockbus.NewMovement{
+			InventoryID: sd.Inventories[0].ID,
+			LotID:       sd.Lots[lot].ID,
+			Type:        typ,
+			Quantity:    quantity,
+			UserID:      sd.Admins[0].ID,
+		}
+
+		_, err := dbt.BusDomain.Stock.Create(ctx, nm)
+		return err
+	}
+
+	lowStock := func(ctx context.Context) ([]int, error) {
+		var filter inventorybus.StockLevelFilter
+		filter.WithBelowThreshold(true)
+
+		sls, err := dbt.BusDomain.Inventory.QueryStockLevels(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp []int
+		for _, sl := range sls {
+			resp = append(resp, sl.OnHand, sl.SuggestedQuantity())
+		}
+
+		return resp, nil
+	}
+
+	table := []dbtest.UnitTable{
+		{
+			Name:    "below-without-crossing",
+			ExpResp: []any{[]int{4, 16}, []string(nil)},
+			ExcFunc: func(ctx context.Context) any {
+				if err := move(ctx, 0, stockbus.TypeReceive, 4); err != nil {
+					return err
+				}
+
+				resp, err := lowStock(ctx)
+				if err != nil {
+					return err
+				}
+
+				return []any{resp, actions}
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "restocked",
+			ExpResp: []any{[]int(nil), []string{inventorybus.ActionRestocked}},
+			ExcFunc: func(ctx context.Context) any {
+				if err := move(ctx, 1, stockbus.TypeReceive, 6); err != nil {
+					return err
+				}
+
+				resp, err := lowStock(ctx)
+				if err != nil {
+					return err
+				}
+
+				return []any{resp, actions}
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "low-stock",
+			ExpResp: []any{[]int{3, 17}, []string{inventorybus.ActionRestocked, inventorybus.ActionLowStock}},
+			ExcFunc: func(ctx context.Context) any {
+				if err := move(ctx, 0, stockbus.TypeDispense, 4); err != nil {
+					return err
+				}
+
+				if err := move(ctx, 1, stockbus.TypeDispense, 3); err != nil {
+					return err
+				}
+
+				resp, err := lowStock(ctx)
+				if err != nil {
+					return err
+				}
+
+				return []any{resp, actions}
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
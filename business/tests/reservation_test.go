@@ -0,0 +1,224 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/data/dbtest"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/reservationbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Reservation(t *testing.T) {
+	t.Parallel()
+
+	dbTest := dbtest.NewTest(t, c, "Test_Reservation")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log(r)
+			t.Error(string(debug.Stack()))
+		}
+		dbTest.Teardown()
+	}()
+
+	sd, err := insertReservationSeedData(dbTest)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	// -------------------------------------------------------------------------
+
+	dbtest.UnitTest(t, reservationFlow(dbTest, sd), "reservation-flow")
+}
+
+// =============================================================================
+
+func insertReservationSeedData(dbTest *dbtest.Test) (dbtest.SeedData, error) {
+	ctx := context.Background()
+	busDomain := dbTest.BusDomain
+
+	usrs, err := userbus.TestGenerateSeedUsers(ctx, 1, userbus.RoleAdmin, busDomain.User)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	meds, err := medicinebus.TestGenerateSeedMedicines(ctx, 1, busDomain.Medicine)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding medicines : %w", err)
+	}
+
+	lots, err := lotbus.TestGenerateSeedLots(ctx, 1, busDomain.Lot, meds[0].ID)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding lots : %w", err)
+	}
+
+	invs, err := inventorybus.TestGenerateSeedInventories(ctx, 1, busDomain.Inventory)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding inventories : %w", err)
+	}
+
+	nm := stockbus.NewMovement{
+		InventoryID: invs[0].ID,
+		LotID:       lots[0].ID,
+		Type:        stockbus.TypeReceive,
+		Quantity:    10,
+		UserID:      usrs[0].ID,
+	}
+
+	if _, err := busDomain.Stock.Create(ctx, nm); err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding stock : %w", err)
+	}
+
+	sd := dbtest.SeedData{
+		Admins:      []dbtest.User{{User: usrs[0]}},
+		Medicines:   meds,
+		Lots:        lots,
+		Inventories: invs,
+	}
+
+	return sd, nil
+}
+
+// =============================================================================
+
+func reservationFlow(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
+	var res reservationbus.Reservation
+
+	newReservation := func(quantity int, ttl time.Duration) reservationbus.NewReservation {
+		return reservationbus.NewReservation{
+			InventoryID: sd.Inventories[0].ID,
+			MedicineID:  sd.Medicines[0].ID,
+			Quantity:    quantity,
+			TTL:         ttl,
+			UserID:      sd.Admins[0].ID,
+		}
+	}
+
+	available := func(ctx context.Context) ([3]int, error) {
+		avs, err := dbt.BusDomain.Reservation.QueryAvailability(ctx, sd.Inventories[0].ID)
+		if err != nil {
+			return [3]int{}, err
+		}
+
+		if len(avs) != 1 {
+			return [3]int{}, fmt.Errorf("expected 1 availability, got %d", len(avs))
+		}
+
+		return [3]int{avs[0].OnHand, avs[0].Reserved, avs[0].Available()}, nil
+	}
+
+	table := []dbtest.UnitTable{
+		{
+			Name:    "reserve",
+			ExpResp: [3]int{10, 6, 4},
+			ExcFunc: func(ctx context.Context) any {
+				var err error
+				res, err = dbt.BusDomain.Reservation.Reserve(ctx, newReservation(6, time.Hour))
+				if err != nil {
+					return err
+				}
+
+				av, err := available(ctx)
+				if err != nil {
+					return err
+				}
+
+				return av
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "unavailable",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				_, err := dbt.BusDomain.Reservation.Reserve(ctx, newReservation(5, time.Hour))
+				return errors.Is(err, reservationbus.ErrUnavailable)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "dispense-reserved",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				nm := stockbus.NewMovement{
+					InventoryID: sd.Inventories[0].ID,
+					LotID:       sd.Lots[0].ID,
+					Type:        stockbus.TypeDispense,
+					Quantity:    5,
+					UserID:      sd.Admins[0].ID,
+				}
+
+				_, err := dbt.BusDomain.Stock.Create(ctx, nm)
+				return errors.Is(err, stockbus.ErrReserved)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "consume",
+			ExpResp: [3]int{4, 0, 4},
+			ExcFunc: func(ctx context.Context) any {
+				cons := reservationbus.Consumption{
+					Lines: []reservationbus.ConsumptionLine{
+						{LotID: sd.Lots[0].ID, Quantity: 6},
+					},
+					UserID: sd.Admins[0].ID,
+				}
+
+				consumed, err := dbt.BusDomain.Reservation.Consume(ctx, res.ID, cons)
+				if err != nil {
+					return err
+				}
+
+				if consumed.Status != reservationbus.StatusConsumed {
+					return fmt.Errorf("expected status %s, got %s", reservationbus.StatusConsumed.Name(), consumed.Status.Name())
+				}
+
+				av, err := available(ctx)
+				if err != nil {
+					return err
+				}
+
+				return av
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "sweep",
+			ExpResp: 1,
+			ExcFunc: func(ctx context.Context) any {
+				if _, err := dbt.BusDomain.Reservation.Reserve(ctx, newReservation(4, time.Minute)); err != nil {
+					return err
+				}
+
+				n, err := dbt.BusDomain.Reservation.Sweep(ctx, time.Now().Add(time.Hour))
+				if err != nil {
+					return err
+				}
+
+				return n
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
@@ -0,0 +1,205 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/data/dbtest"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Schedule(t *testing.T) {
+	t.Parallel()
+
+	dbTest := dbtest.NewTest(t, c, "Test_Schedule")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log(r)
+			t.Error(string(debug.Stack()))
+		}
+		dbTest.Teardown()
+	}()
+
+	sd, err := insertScheduleSeedData(dbTest)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	// -------------------------------------------------------------------------
+
+	dbtest.UnitTest(t, scheduleFlow(dbTest, sd), "schedule-flow")
+}
+
+// =============================================================================
+
+// insertScheduleSeedData seeds a schedule II medicine with 10 units of a lot
+// received into one inventory, countersigned by a second user.
+func insertScheduleSeedData(dbTest *dbtest.Test) (dbtest.SeedData, error) {
+	ctx := context.Background()
+	busDomain := dbTest.BusDomain
+
+	admins, err := userbus.TestGenerateSeedUsers(ctx, 1, userbus.RoleAdmin, busDomain.User)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding admins : %w", err)
+	}
+
+	usrs, err := userbus.TestGenerateSeedUsers(ctx, 1, userbus.RoleUser, busDomain.User)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	nms := medicinebus.TestGenerateNewMedicines(1)
+	nms[0].Schedule = medicinebus.ScheduleII
+
+	med, err := busDomain.Medicine.Create(ctx, nms[0])
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding medicines : %w", err)
+	}
+
+	lots, err := lotbus.TestGenerateSeedLots(ctx, 1, busDomain.Lot, med.ID)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding lots : %w", err)
+	}
+
+	invs, err := inventorybus.TestGenerateSeedInventories(ctx, 1, busDomain.Inventory)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding inventories : %w", err)
+	}
+
+	nm := stockbus.NewMovement{
+		InventoryID:     invs[0].ID,
+		LotID:           lots[0].ID,
+		Type:            stockbus.TypeReceive,
+		Quantity:        10,
+		UserID:          admins[0].ID,
+		CountersignedBy: usrs[0].ID,
+	}
+
+	if _, err := busDomain.Stock.Create(ctx, nm); err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding stock : %w", err)
+	}
+
+	sd := dbtest.SeedData{
+		Admins:      []dbtest.User{{User: admins[0]}},
+		Users:       []dbtest.User{{User: usrs[0]}},
+		Medicines:   []medicinebus.Medicine{med},
+		Lots:        lots,
+		Inventories: invs,
+	}
+
+	return sd, nil
+}
+
+// =============================================================================
+
+func scheduleFlow(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
+	start := time.Now().Add(-time.Hour)
+
+	dispense := stockbus.NewMovement{
+		InventoryID: sd.Inventories[0].ID,
+		LotID:       sd.Lots[0].ID,
+		Type:        stockbus.TypeDispense,
+		Quantity:    3,
+		UserID:      sd.Admins[0].ID,
+	}
+
+	type balances struct {
+		Opening int
+		Entries []int
+		Closing int
+	}
+
+	table := []dbtest.UnitTable{
+		{
+			Name:    "countersign-required",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				_, err := dbt.BusDomain.Stock.Create(ctx, dispense)
+				return errors.Is(err, stockbus.ErrCountersignRequired)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "self-countersign",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				nm := dispense
+				nm.CountersignedBy = sd.Admins[0].ID
+
+				_, err := dbt.BusDomain.Stock.Create(ctx, nm)
+				return errors.Is(err, stockbus.ErrSelfCountersign)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "countersigned-dispense",
+			ExpResp: sd.Users[0].ID.String(),
+			ExcFunc: func(ctx context.Context) any {
+				nm := dispense
+				nm.CountersignedBy = sd.Users[0].ID
+
+				mov, err := dbt.BusDomain.Stock.Create(ctx, nm)
+				if err != nil {
+					return err
+				}
+
+				mov, err = dbt.BusDomain.Stock.QueryByID(ctx, mov.ID)
+				if err != nil {
+					return err
+				}
+
+				return mov.CountersignedBy.String()
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "register",
+			ExpResp: balances{Opening: 0, Entries: []int{10, 7}, Closing: 7},
+			ExcFunc: func(ctx context.Context) any {
+				filter := stockbus.RegisterFilter{
+					InventoryID: sd.Inventories[0].ID,
+					MedicineID:  sd.Medicines[0].ID,
+					StartDate:   start,
+					EndDate:     time.Now(),
+				}
+
+				rgs, err := dbt.BusDomain.Stock.QueryRegister(ctx, filter)
+				if err != nil {
+					return err
+				}
+
+				got := balances{
+					Opening: rgs.OpeningBalance,
+					Entries: make([]int, len(rgs.Entries)),
+					Closing: rgs.ClosingBalance,
+				}
+
+				for i, e := range rgs.Entries {
+					got.Entries[i] = e.Balance
+				}
+
+				return got
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
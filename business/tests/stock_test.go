@@ -0,0 +1,317 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/data/dbtest"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func Test_Stock(t *testing.T) {
+	t.Parallel()
+
+	dbTest := dbtest.NewTest(t, c, "Test_Stock")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log(r)
+			t.Error(string(debug.Stack()))
+		}
+		dbTest.Teardown()
+	}()
+
+	sd, err := insertStockSeedData(dbTest)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	// -------------------------------------------------------------------------
+
+	dbtest.UnitTest(t, stockCreate(dbTest, sd), "stock-create")
+	dbtest.UnitTest(t, stockHistory(dbTest, sd), "stock-history")
+}
+
+// =============================================================================
+
+func insertStockSeedData(dbTest *dbtest.Test) (dbtest.SeedData, error) {
+	ctx := context.Background()
+	busDomain := dbTest.BusDomain
+
+	usrs, err := userbus.TestGenerateSeedUsers(ctx, 1, userbus.RoleAdmin, busDomain.User)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	meds, err := medicinebus.TestGenerateSeedMedicines(ctx, 1, busDomain.Medicine)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding medicines : %w", err)
+	}
+
+	lots, err := lotbus.TestGenerateSeedLots(ctx, 1, busDomain.Lot, meds[0].ID)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding lots : %w", err)
+	}
+
+	invs, err := inventorybus.TestGenerateSeedInventories(ctx, 1, busDomain.Inventory)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding inventories : %w", err)
+	}
+
+	sd := dbtest.SeedData{
+		Admins:      []dbtest.User{{User: usrs[0]}},
+		Medicines:   meds,
+		Lots:        lots,
+		Inventories: invs,
+	}
+
+	return sd, nil
+}
+
+// =============================================================================
+
+func stockCreate(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
+	newMovement := func(typ stockbus.MovementType, quantity int, reason string) stockbus.NewMovement {
+		return stockbus.NewMovement{
+			InventoryID: sd.Inventories[0].ID,
+			LotID:       sd.Lots[0].ID,
+			Type:        typ,
+			Quantity:    quantity,
+			Reason:      reason,
+			UserID:      sd.Admins[0].ID,
+		}
+	}
+
+	table := []dbtest.UnitTable{
+		{
+			Name:    "receive",
+			ExpResp: 10,
+			ExcFunc: func(ctx context.Context) any {
+				mov, err := dbt.BusDomain.Stock.Create(ctx, newMovement(stockbus.TypeReceive, 10, ""))
+				if err != nil {
+					return err
+				}
+
+				return mov.Balance
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "dispense",
+			ExpResp: 7,
+			ExcFunc: func(ctx context.Context) any {
+				mov, err := dbt.BusDomain.Stock.Create(ctx, newMovement(stockbus.TypeDispense, 3, ""))
+				if err != nil {
+					return err
+				}
+
+				return mov.Balance
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "insufficient",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				_, err := dbt.BusDomain.Stock.Create(ctx, newMovement(stockbus.TypeDispense, 8, ""))
+				return errors.Is(err, inventorybus.ErrInsufficientStock)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "write-off-reason",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				_, err := dbt.BusDomain.Stock.Create(ctx, newMovement(stockbus.TypeWriteOff, 1, ""))
+				return errors.Is(err, stockbus.ErrReasonRequired)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "ledger",
+			ExpResp: 2,
+			ExcFunc: func(ctx context.Context) any {
+				filter := stockbus.QueryFilter{
+					InventoryID: &sd.Inventories[0].ID,
+				}
+
+				n, err := dbt.BusDomain.Stock.Count(ctx, filter)
+				if err != nil {
+					return err
+				}
+
+				return n
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "held-by-medicine",
+			ExpResp: []uuid.UUID{sd.Inventories[0].ID},
+			ExcFunc: func(ctx context.Context) any {
+				var filter inventorybus.QueryFilter
+				filter.WithMedicine(sd.Medicines[0].ID)
+
+				invs, err := dbt.BusDomain.Inventory.Query(ctx, filter, inventorybus.DefaultOrderBy, 1, 10)
+				if err != nil {
+					return err
+				}
+
+				ids := make([]uuid.UUID, len(invs))
+				for i, inv := range invs {
+					ids[i] = inv.ID
+				}
+
+				return ids
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
+
+// stockHistory continues from the 7 units stockCreate left in the inventory.
+func stockHistory(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
+	var beforeSnapshot, afterSnapshot time.Time
+
+	dispense := func(ctx context.Context, quantity int) error {
+		nm := stockbus.NewMovement{
+			InventoryID: sd.Inventories[0].ID,
+			LotID:       sd.Lots[0].ID,
+			Type:        stockbus.TypeDispense,
+			Quantity:    quantity,
+			UserID:      sd.Admins[0].ID,
+		}
+
+		_, err := dbt.BusDomain.Stock.Create(ctx, nm)
+		return err
+	}
+
+	holding := func(ctx context.Context, asOf time.Time) (int, error) {
+		var filter stockbus.HoldingFilter
+		filter.WithInventoryID(sd.Inventories[0].ID)
+		filter.WithLotID(sd.Lots[0].ID)
+
+		hs, err := dbt.BusDomain.Stock.QueryHoldings(ctx, filter, asOf)
+		if err != nil {
+			return 0, err
+		}
+
+		var quantity int
+		for _, h := range hs {
+			quantity += h.Quantity
+		}
+
+		return quantity, nil
+	}
+
+	table := []dbtest.UnitTable{
+		{
+			Name:    "snapshot",
+			ExpResp: 1,
+			ExcFunc: func(ctx context.Context) any {
+				beforeSnapshot = time.Now()
+
+				if err := dispense(ctx, 2); err != nil {
+					return err
+				}
+
+				snap, err := dbt.BusDomain.Stock.TakeSnapshot(ctx, time.Now())
+				if err != nil {
+					return err
+				}
+
+				afterSnapshot = time.Now()
+
+				if err := dispense(ctx, 1); err != nil {
+					return err
+				}
+
+				return snap.Lots
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "as-of",
+			ExpResp: []int{7, 5, 4},
+			ExcFunc: func(ctx context.Context) any {
+				var quantities []int
+				for _, asOf := range []time.Time{beforeSnapshot, afterSnapshot, time.Now()} {
+					quantity, err := holding(ctx, asOf)
+					if err != nil {
+						return err
+					}
+					quantities = append(quantities, quantity)
+				}
+
+				return quantities
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "future",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				_, err := holding(ctx, time.Now().Add(time.Hour))
+				return errors.Is(err, stockbus.ErrFutureAsOf)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "before-history",
+			ExpResp: []bool{true, true},
+			ExcFunc: func(ctx context.Context) any {
+				// The migration snapshots the stock held when the ledger was
+				// introduced, so nothing before it can be rebuilt.
+				asOf := time.Now().AddDate(-1, 0, 0)
+
+				_, err := holding(ctx, asOf)
+				beforeHoldings := errors.Is(err, stockbus.ErrBeforeHistory)
+
+				filter := stockbus.RegisterFilter{
+					InventoryID: sd.Inventories[0].ID,
+					MedicineID:  sd.Medicines[0].ID,
+					StartDate:   asOf,
+					EndDate:     time.Now(),
+				}
+
+				_, err = dbt.BusDomain.Stock.QueryRegister(ctx, filter)
+				beforeRegister := errors.Is(err, stockbus.ErrBeforeHistory)
+
+				return []bool{beforeHoldings, beforeRegister}
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
@@ -0,0 +1,281 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"testing"
+
+	"github.com/EnesDemirtas/medisync/business/data/dbtest"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/stocktakebus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func Test_Stocktake(t *testing.T) {
+	t.Parallel()
+
+	dbTest := dbtest.NewTest(t, c, "Test_Stocktake")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log(r)
+			t.Error(string(debug.Stack()))
+		}
+		dbTest.Teardown()
+	}()
+
+	sd, err := insertStocktakeSeedData(dbTest)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	// -------------------------------------------------------------------------
+
+	dbtest.UnitTest(t, stocktakeFlow(dbTest, sd), "stocktake-flow")
+}
+
+// =============================================================================
+
+// insertStocktakeSeedData seeds an inventory holding the first two of three
+// lots. The third lot is only found while counting.
+func insertStocktakeSeedData(dbTest *dbtest.Test) (dbtest.SeedData, error) {
+	ctx := context.Background()
+	busDomain := dbTest.BusDomain
+
+	usrs, err := userbus.TestGenerateSeedUsers(ctx, 1, userbus.RoleAdmin, busDomain.User)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	meds, err := medicinebus.TestGenerateSeedMedicines(ctx, 1, busDomain.Medicine)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding medicines : %w", err)
+	}
+
+	lots, err := lotbus.TestGenerateSeedLots(ctx, 3, busDomain.Lot, meds[0].ID)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding lots : %w", err)
+	}
+
+	invs, err := inventorybus.TestGenerateSeedInventories(ctx, 1, busDomain.Inventory)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding inventories : %w", err)
+	}
+
+	for i, quantity := range []int{10, 5} {
+		nm := stockbus.NewMovement{
+			InventoryID: invs[0].ID,
+			LotID:       lots[i].ID,
+			Type:        stockbus.TypeReceive,
+			Quantity:    quantity,
+			UserID:      usrs[0].ID,
+		}
+
+		if _, err := busDomain.Stock.Create(ctx, nm); err != nil {
+			return dbtest.SeedData{}, fmt.Errorf("seeding stock : %w", err)
+		}
+	}
+
+	sd := dbtest.SeedData{
+		Admins:      []dbtest.User{{User: usrs[0]}},
+		Medicines:   meds,
+		Lots:        lots,
+		Inventories: invs,
+	}
+
+	return sd, nil
+}
+
+// =============================================================================
+
+func stocktakeFlow(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
+	var stk stocktakebus.Stocktake
+
+	count := func(ctx context.Context, deviceID string, quantities ...int) error {
+		counting := stocktakebus.Counting{
+			DeviceID: deviceID,
+			UserID:   sd.Admins[0].ID,
+		}
+
+		for i, quantity := range quantities {
+			counting.Lines = append(counting.Lines, stocktakebus.CountLine{LotID: sd.Lots[i].ID, Quantity: quantity})
+		}
+
+		var err error
+		stk, err = dbt.BusDomain.Stocktake.SubmitCounts(ctx, stk.ID, counting)
+		return err
+	}
+
+	variances := func(stk stocktakebus.Stocktake) map[uuid.UUID]int {
+		m := make(map[uuid.UUID]int, len(stk.Lines))
+		for _, line := range stk.Lines {
+			m[line.LotID] = line.Variance()
+		}
+		return m
+	}
+
+	table := []dbtest.UnitTable{
+		{
+			Name: "open",
+			ExpResp: map[uuid.UUID]int{
+				sd.Lots[0].ID: 10,
+				sd.Lots[1].ID: 5,
+			},
+			ExcFunc: func(ctx context.Context) any {
+				ns := stocktakebus.NewStocktake{
+					InventoryID: sd.Inventories[0].ID,
+					UserID:      sd.Admins[0].ID,
+				}
+
+				var err error
+				stk, err = dbt.BusDomain.Stocktake.Open(ctx, ns)
+				if err != nil {
+					return err
+				}
+
+				expected := make(map[uuid.UUID]int, len(stk.Lines))
+				for _, line := range stk.Lines {
+					expected[line.LotID] = line.Expected
+				}
+
+				return expected
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "open-twice",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				ns := stocktakebus.NewStocktake{
+					InventoryID: sd.Inventories[0].ID,
+					UserID:      sd.Admins[0].ID,
+				}
+
+				_, err := dbt.BusDomain.Stocktake.Open(ctx, ns)
+				return errors.Is(err, stocktakebus.ErrAlreadyOpen)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "approve-uncounted",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				if err := count(ctx, "scanner-a", 6); err != nil {
+					return err
+				}
+
+				_, err := dbt.BusDomain.Stocktake.Approve(ctx, stk.ID, sd.Admins[0].ID, uuid.Nil)
+				return errors.Is(err, stocktakebus.ErrUncounted)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name: "count-devices",
+			ExpResp: map[uuid.UUID]int{
+				sd.Lots[0].ID: -3,
+				sd.Lots[1].ID: 0,
+				sd.Lots[2].ID: 2,
+			},
+			ExcFunc: func(ctx context.Context) any {
+				if err := count(ctx, "scanner-b", 3, 5, 2); err != nil {
+					return err
+				}
+
+				// A recount from the same device replaces its earlier count.
+				if err := count(ctx, "scanner-a", 4); err != nil {
+					return err
+				}
+
+				return variances(stk)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name: "damage-while-open",
+			ExpResp: map[uuid.UUID]int{
+				sd.Lots[0].ID: -3,
+				sd.Lots[1].ID: -1,
+				sd.Lots[2].ID: 2,
+			},
+			ExcFunc: func(ctx context.Context) any {
+				sm := inventorybus.StatusMove{
+					InventoryID: sd.Inventories[0].ID,
+					LotID:       sd.Lots[1].ID,
+					From:        inventorybus.StatusAvailable,
+					To:          inventorybus.StatusDamaged,
+					Quantity:    1,
+					Reason:      "Crushed carton",
+					UserID:      sd.Admins[0].ID,
+				}
+
+				if _, err := dbt.BusDomain.Inventory.MoveStatus(ctx, sm); err != nil {
+					return err
+				}
+
+				// The damaged unit is no longer counted as available.
+				if err := count(ctx, "scanner-b", 3, 4, 2); err != nil {
+					return err
+				}
+
+				return variances(stk)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "approve",
+			ExpResp: []int{7, 5, 2},
+			ExcFunc: func(ctx context.Context) any {
+				// Stock dispensed while the stocktake is open is already
+				// missing from the counts and must not be taken off twice.
+				nm := stockbus.NewMovement{
+					InventoryID: sd.Inventories[0].ID,
+					LotID:       sd.Lots[0].ID,
+					Type:        stockbus.TypeDispense,
+					Quantity:    2,
+					UserID:      sd.Admins[0].ID,
+				}
+
+				if _, err := dbt.BusDomain.Stock.Create(ctx, nm); err != nil {
+					return err
+				}
+
+				approved, err := dbt.BusDomain.Stocktake.Approve(ctx, stk.ID, sd.Admins[0].ID, uuid.Nil)
+				if err != nil {
+					return err
+				}
+
+				if approved.Status != stocktakebus.StatusApproved {
+					return fmt.Errorf("expected status %s, got %s", stocktakebus.StatusApproved.Name(), approved.Status.Name())
+				}
+
+				inv, err := dbt.BusDomain.Inventory.QueryByID(ctx, sd.Inventories[0].ID)
+				if err != nil {
+					return err
+				}
+
+				return []int{inv.LotQuantities[sd.Lots[0].ID], inv.LotQuantities[sd.Lots[1].ID], inv.LotQuantities[sd.Lots[2].ID]}
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
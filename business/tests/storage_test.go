@@ -0,0 +1,182 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"testing"
+
+	"github.com/EnesDemirtas/medisync/business/data/dbtest"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Storage(t *testing.T) {
+	t.Parallel()
+
+	dbTest := dbtest.NewTest(t, c, "Test_Storage")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log(r)
+			t.Error(string(debug.Stack()))
+		}
+		dbTest.Teardown()
+	}()
+
+	sd, err := insertStorageSeedData(dbTest)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	// -------------------------------------------------------------------------
+
+	dbtest.UnitTest(t, storageFlow(dbTest, sd), "storage-flow")
+}
+
+// =============================================================================
+
+// insertStorageSeedData seeds a refrigerated medicine with one lot, an
+// ambient inventory and a cold room inventory.
+func insertStorageSeedData(dbTest *dbtest.Test) (dbtest.SeedData, error) {
+	ctx := context.Background()
+	busDomain := dbTest.BusDomain
+
+	admins, err := userbus.TestGenerateSeedUsers(ctx, 1, userbus.RoleAdmin, busDomain.User)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding admins : %w", err)
+	}
+
+	nms := medicinebus.TestGenerateNewMedicines(1)
+	nms[0].Storage = medicinebus.Storage{Temperature: medicinebus.TemperatureRefrigerated}
+
+	med, err := busDomain.Medicine.Create(ctx, nms[0])
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding medicines : %w", err)
+	}
+
+	lots, err := lotbus.TestGenerateSeedLots(ctx, 1, busDomain.Lot, med.ID)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding lots : %w", err)
+	}
+
+	nis := inventorybus.TestGenerateNewInventories(2)
+	nis[1].Capabilities = []inventorybus.Capability{inventorybus.CapabilityColdRoom}
+
+	invs := make([]inventorybus.Inventory, len(nis))
+	for i, ni := range nis {
+		invs[i], err = busDomain.Inventory.Create(ctx, ni)
+		if err != nil {
+			return dbtest.SeedData{}, fmt.Errorf("seeding inventories : %w", err)
+		}
+	}
+
+	sd := dbtest.SeedData{
+		Admins:      []dbtest.User{{User: admins[0]}},
+		Medicines:   []medicinebus.Medicine{med},
+		Lots:        lots,
+		Inventories: invs,
+	}
+
+	return sd, nil
+}
+
+// =============================================================================
+
+func storageFlow(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
+	receive := stockbus.NewMovement{
+		LotID:    sd.Lots[0].ID,
+		Type:     stockbus.TypeReceive,
+		Quantity: 5,
+		UserID:   sd.Admins[0].ID,
+	}
+
+	type violation struct {
+		LotID   string
+		OnHand  int
+		Reasons []string
+	}
+
+	table := []dbtest.UnitTable{
+		{
+			Name:    "ambient-refused",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				nm := receive
+				nm.InventoryID = sd.Inventories[0].ID
+
+				_, err := dbt.BusDomain.Stock.Create(ctx, nm)
+				return errors.Is(err, inventorybus.ErrIncompatibleStorage)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "cold-room-accepted",
+			ExpResp: 5,
+			ExcFunc: func(ctx context.Context) any {
+				nm := receive
+				nm.InventoryID = sd.Inventories[1].ID
+
+				mov, err := dbt.BusDomain.Stock.Create(ctx, nm)
+				if err != nil {
+					return err
+				}
+
+				return mov.Quantity
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name: "violations",
+			ExpResp: []violation{
+				{
+					LotID:   sd.Lots[0].ID.String(),
+					OnHand:  5,
+					Reasons: []string{"requires REFRIGERATED_2_8C storage"},
+				},
+			},
+			ExcFunc: func(ctx context.Context) any {
+				ui := inventorybus.UpdateInventory{
+					Capabilities: []inventorybus.Capability{inventorybus.CapabilityAmbient},
+				}
+
+				inv, err := dbt.BusDomain.Inventory.Update(ctx, sd.Inventories[1], ui)
+				if err != nil {
+					return err
+				}
+
+				var filter inventorybus.BucketFilter
+				filter.WithInventoryID(inv.ID)
+
+				vls, err := dbt.BusDomain.Inventory.QueryViolations(ctx, filter)
+				if err != nil {
+					return err
+				}
+
+				got := make([]violation, len(vls))
+				for i, vl := range vls {
+					got[i] = violation{
+						LotID:   vl.LotID.String(),
+						OnHand:  vl.OnHand,
+						Reasons: vl.Reasons,
+					}
+				}
+
+				return got
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"testing"
+
+	"github.com/EnesDemirtas/medisync/business/data/dbtest"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/tagbus"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func Test_Tag(t *testing.T) {
+	t.Parallel()
+
+	dbTest := dbtest.NewTest(t, c, "Test_Tag")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log(r)
+			t.Error(string(debug.Stack()))
+		}
+		dbTest.Teardown()
+	}()
+
+	sd, err := insertTagSeedData(dbTest)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	// -------------------------------------------------------------------------
+
+	dbtest.UnitTest(t, tagDelete(dbTest, sd), "tag-delete")
+}
+
+// =============================================================================
+
+func insertTagSeedData(dbTest *dbtest.Test) (dbtest.SeedData, error) {
+	ctx := context.Background()
+	busDomain := dbTest.BusDomain
+
+	tag, err := busDomain.Tag.Create(ctx, tagbus.NewTag{Name: "Antibiotic"})
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding tags : %w", err)
+	}
+
+	nm := medicinebus.NewMedicine{
+		Name: "Amoxicillin",
+		Tags: []uuid.UUID{tag.ID},
+	}
+
+	med, err := busDomain.Medicine.Create(ctx, nm)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding medicines : %w", err)
+	}
+
+	sd := dbtest.SeedData{
+		Medicines: []medicinebus.Medicine{med},
+		Tags:      []tagbus.Tag{tag},
+	}
+
+	return sd, nil
+}
+
+// =============================================================================
+
+func tagDelete(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
+	table := []dbtest.UnitTable{
+		{
+			Name:    "filter",
+			ExpResp: 1,
+			ExcFunc: func(ctx context.Context) any {
+				var filter medicinebus.QueryFilter
+				filter.WithTag(sd.Tags[0].ID)
+
+				n, err := dbt.BusDomain.Medicine.Count(ctx, filter)
+				if err != nil {
+					return err
+				}
+
+				return n
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "in-use",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				err := dbt.BusDomain.Tag.Delete(ctx, sd.Tags[0], false)
+				return errors.Is(err, tagbus.ErrInUse)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "cascade",
+			ExpResp: []uuid.UUID{},
+			ExcFunc: func(ctx context.Context) any {
+				if err := dbt.BusDomain.Tag.Delete(ctx, sd.Tags[0], true); err != nil {
+					return err
+				}
+
+				med, err := dbt.BusDomain.Medicine.QueryByID(ctx, sd.Medicines[0].ID)
+				if err != nil {
+					return err
+				}
+
+				return med.Tags
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
@@ -0,0 +1,252 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/EnesDemirtas/medisync/business/api/delegate"
+	"github.com/EnesDemirtas/medisync/business/data/dbtest"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/temperaturebus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Temperature(t *testing.T) {
+	t.Parallel()
+
+	dbTest := dbtest.NewTest(t, c, "Test_Temperature")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log(r)
+			t.Error(string(debug.Stack()))
+		}
+		dbTest.Teardown()
+	}()
+
+	sd, err := insertTemperatureSeedData(dbTest)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	// -------------------------------------------------------------------------
+
+	dbtest.UnitTest(t, temperatureFlow(dbTest, sd), "temperature-flow")
+}
+
+// =============================================================================
+
+// insertTemperatureSeedData seeds an inventory holding five units of a lot
+// with a 2-8C range configured.
+func insertTemperatureSeedData(dbTest *dbtest.Test) (dbtest.SeedData, error) {
+	ctx := context.Background()
+	busDomain := dbTest.BusDomain
+
+	admins, err := userbus.TestGenerateSeedUsers(ctx, 1, userbus.RoleAdmin, busDomain.User)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding admins : %w", err)
+	}
+
+	meds, err := medicinebus.TestGenerateSeedMedicines(ctx, 1, busDomain.Medicine)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding medicines : %w", err)
+	}
+
+	lots, err := lotbus.TestGenerateSeedLots(ctx, 1, busDomain.Lot, meds[0].ID)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding lots : %w", err)
+	}
+
+	invs, err := inventorybus.TestGenerateSeedInventories(ctx, 1, busDomain.Inventory)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding inventories : %w", err)
+	}
+
+	nm := stockbus.NewMovement{
+		InventoryID: invs[0].ID,
+		LotID:       lots[0].ID,
+		Type:        stockbus.TypeReceive,
+		Quantity:    5,
+		UserID:      admins[0].ID,
+	}
+
+	if _, err := busDomain.Stock.Create(ctx, nm); err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding stock : %w", err)
+	}
+
+	nr := temperaturebus.NewRange{
+		InventoryID: invs[0].ID,
+		MinCelsius:  2,
+		MaxCelsius:  8,
+	}
+
+	if _, err := busDomain.Temperature.SetRange(ctx, nr); err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding range : %w", err)
+	}
+
+	sd := dbtest.SeedData{
+		Admins:      []dbtest.User{{User: admins[0]}},
+		Medicines:   meds,
+		Lots:        lots,
+		Inventories: invs,
+	}
+
+	return sd, nil
+}
+
+// =============================================================================
+
+func temperatureFlow(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
+	type excursion struct {
+		PeakCelsius float64
+		DatePeak    string
+		DateStarted string
+		DateEnded   string
+		Quarantined int
+	}
+
+	var actions []string
+	record := func(action string) delegate.Func {
+		return func(ctx context.Context, data delegate.Data) error {
+			actions = append(actions, action)
+			return nil
+		}
+	}
+	dbt.BusDomain.Delegate.Register(temperaturebus.Domain, temperaturebus.ActionExcursionStarted, record(temperaturebus.ActionExcursionStarted))
+	dbt.BusDomain.Delegate.Register(temperaturebus.Domain, temperaturebus.ActionExcursionEnded, record(temperaturebus.ActionExcursionEnded))
+
+	format := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	table := []dbtest.UnitTable{
+		{
+			Name: "csv-excursions",
+			ExpResp: []excursion{
+				{
+					PeakCelsius: 11.2,
+					DatePeak:    "2024-03-01T10:30:00Z",
+					DateStarted: "2024-03-01T10:15:00Z",
+					DateEnded:   "2024-03-01T10:45:00Z",
+					Quarantined: 5,
+				},
+				{
+					PeakCelsius: 1.5,
+					DatePeak:    "2024-03-01T11:00:00Z",
+					DateStarted: "2024-03-01T11:00:00Z",
+				},
+			},
+			ExcFunc: func(ctx context.Context) any {
+				f, err := os.Open("testdata/readings.csv")
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+
+				nrs, err := temperaturebus.DecodeCSV(f)
+				if err != nil {
+					return err
+				}
+
+				ni := temperaturebus.NewIngestion{
+					InventoryID: sd.Inventories[0].ID,
+					UserID:      sd.Admins[0].ID,
+					Readings:    nrs,
+				}
+
+				ing, err := dbt.BusDomain.Temperature.Ingest(ctx, ni)
+				if err != nil {
+					return err
+				}
+
+				got := make([]excursion, len(ing.Excursions))
+				for i, exc := range ing.Excursions {
+					got[i] = excursion{
+						PeakCelsius: exc.PeakCelsius,
+						DatePeak:    format(exc.DatePeak),
+						DateStarted: format(exc.DateStarted),
+						DateEnded:   format(exc.DateEnded),
+					}
+
+					for _, s := range exc.Stock {
+						got[i].Quarantined += s.Quantity
+					}
+				}
+
+				return got
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name: "events",
+			ExpResp: []string{
+				temperaturebus.ActionExcursionStarted,
+				temperaturebus.ActionExcursionEnded,
+				temperaturebus.ActionExcursionStarted,
+			},
+			ExcFunc: func(ctx context.Context) any {
+				return actions
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name: "stock-quarantined",
+			ExpResp: inventorybus.Buckets{
+				Quarantined: 5,
+			},
+			ExcFunc: func(ctx context.Context) any {
+				var filter inventorybus.BucketFilter
+				filter.WithInventoryID(sd.Inventories[0].ID)
+
+				lbs, err := dbt.BusDomain.Inventory.QueryBuckets(ctx, filter)
+				if err != nil {
+					return err
+				}
+
+				if len(lbs) != 1 {
+					return fmt.Errorf("expected 1 lot, got %d", len(lbs))
+				}
+
+				return lbs[0].Buckets
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "ongoing",
+			ExpResp: 1,
+			ExcFunc: func(ctx context.Context) any {
+				var filter temperaturebus.QueryFilter
+				filter.WithInventoryID(sd.Inventories[0].ID)
+				filter.WithOngoing(true)
+
+				n, err := dbt.BusDomain.Temperature.Count(ctx, filter)
+				if err != nil {
+					return err
+				}
+
+				return n
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
@@ -0,0 +1,202 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"testing"
+
+	"github.com/EnesDemirtas/medisync/business/data/dbtest"
+	"github.com/EnesDemirtas/medisync/business/domain/inventorybus"
+	"github.com/EnesDemirtas/medisync/business/domain/lotbus"
+	"github.com/EnesDemirtas/medisync/business/domain/medicinebus"
+	"github.com/EnesDemirtas/medisync/business/domain/stockbus"
+	"github.com/EnesDemirtas/medisync/business/domain/transferbus"
+	"github.com/EnesDemirtas/medisync/business/domain/userbus"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Transfer(t *testing.T) {
+	t.Parallel()
+
+	dbTest := dbtest.NewTest(t, c, "Test_Transfer")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log(r)
+			t.Error(string(debug.Stack()))
+		}
+		dbTest.Teardown()
+	}()
+
+	sd, err := insertTransferSeedData(dbTest)
+	if err != nil {
+		t.Fatalf("Seeding error: %s", err)
+	}
+
+	// -------------------------------------------------------------------------
+
+	dbtest.UnitTest(t, transferFlow(dbTest, sd), "transfer-flow")
+}
+
+// =============================================================================
+
+func insertTransferSeedData(dbTest *dbtest.Test) (dbtest.SeedData, error) {
+	ctx := context.Background()
+	busDomain := dbTest.BusDomain
+
+	usrs, err := userbus.TestGenerateSeedUsers(ctx, 1, userbus.RoleAdmin, busDomain.User)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding users : %w", err)
+	}
+
+	meds, err := medicinebus.TestGenerateSeedMedicines(ctx, 1, busDomain.Medicine)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding medicines : %w", err)
+	}
+
+	lots, err := lotbus.TestGenerateSeedLots(ctx, 1, busDomain.Lot, meds[0].ID)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding lots : %w", err)
+	}
+
+	invs, err := inventorybus.TestGenerateSeedInventories(ctx, 2, busDomain.Inventory)
+	if err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding inventories : %w", err)
+	}
+
+	nm := stockbus.NewMovement{
+		InventoryID: invs[0].ID,
+		LotID:       lots[0].ID,
+		Type:        stockbus.TypeReceive,
+		Quantity:    10,
+		UserID:      usrs[0].ID,
+	}
+
+	if _, err := busDomain.Stock.Create(ctx, nm); err != nil {
+		return dbtest.SeedData{}, fmt.Errorf("seeding stock : %w", err)
+	}
+
+	sd := dbtest.SeedData{
+		Admins:      []dbtest.User{{User: usrs[0]}},
+		Medicines:   meds,
+		Lots:        lots,
+		Inventories: invs,
+	}
+
+	return sd, nil
+}
+
+// =============================================================================
+
+func transferFlow(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
+	var tr transferbus.Transfer
+
+	quantities := func(ctx context.Context) ([2]int, error) {
+		var q [2]int
+		for i, inv := range sd.Inventories {
+			inv, err := dbt.BusDomain.Inventory.QueryByID(ctx, inv.ID)
+			if err != nil {
+				return q, err
+			}
+			q[i] = inv.LotQuantities[sd.Lots[0].ID]
+		}
+
+		return q, nil
+	}
+
+	table := []dbtest.UnitTable{
+		{
+			Name:    "dispatch",
+			ExpResp: [2]int{6, 0},
+			ExcFunc: func(ctx context.Context) any {
+				nt := transferbus.NewTransfer{
+					SourceID:      sd.Inventories[0].ID,
+					DestinationID: sd.Inventories[1].ID,
+					Lines:         []transferbus.NewLine{{LotID: sd.Lots[0].ID, Quantity: 4}},
+					UserID:        sd.Admins[0].ID,
+				}
+
+				var err error
+				tr, err = dbt.BusDomain.Transfer.Dispatch(ctx, nt)
+				if err != nil {
+					return err
+				}
+
+				q, err := quantities(ctx)
+				if err != nil {
+					return err
+				}
+
+				return q
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "over-receipt",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				rc := transferbus.Receipt{
+					Lines:  []transferbus.ReceiptLine{{LotID: sd.Lots[0].ID, Quantity: 5}},
+					UserID: sd.Admins[0].ID,
+				}
+
+				_, err := dbt.BusDomain.Transfer.Receive(ctx, tr.ID, rc)
+				return errors.Is(err, transferbus.ErrOverReceipt)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "partial",
+			ExpResp: transferbus.StatusPartiallyReceived.Name(),
+			ExcFunc: func(ctx context.Context) any {
+				rc := transferbus.Receipt{
+					Lines:  []transferbus.ReceiptLine{{LotID: sd.Lots[0].ID, Quantity: 3}},
+					UserID: sd.Admins[0].ID,
+				}
+
+				tr, err := dbt.BusDomain.Transfer.Receive(ctx, tr.ID, rc)
+				if err != nil {
+					return err
+				}
+
+				return tr.Status.Name()
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "close",
+			ExpResp: []any{transferbus.StatusReceived.Name(), 1, [2]int{6, 3}},
+			ExcFunc: func(ctx context.Context) any {
+				rc := transferbus.Receipt{
+					Close:  true,
+					Reason: "damaged in transit",
+					UserID: sd.Admins[0].ID,
+				}
+
+				tr, err := dbt.BusDomain.Transfer.Receive(ctx, tr.ID, rc)
+				if err != nil {
+					return err
+				}
+
+				q, err := quantities(ctx)
+				if err != nil {
+					return err
+				}
+
+				return []any{tr.Status.Name(), tr.Lines[0].Discrepancy, q}
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+	}
+
+	return table
+}
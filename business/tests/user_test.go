@@ -2,6 +2,7 @@ package tests
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/mail"
 	"runtime/debug"
@@ -184,6 +185,7 @@ func userCreate(dbt *dbtest.Test) []dbtest.UnitTable {
 				Email:      *email,
 				Roles:      []userbus.Role{userbus.RoleAdmin},
 				Enabled:    true,
+				Version:    1,
 			},
 			ExcFunc: func(ctx context.Context) any {
 				nu := userbus.NewUser{
@@ -238,6 +240,7 @@ func userUpdate(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
 				Email:       *email,
 				Roles:       []userbus.Role{userbus.RoleAdmin},
 				Enabled:     true,
+				Version:     2,
 				DateCreated: sd.Users[0].DateCreated,
 			},
 			ExcFunc: func(ctx context.Context) any {
@@ -274,6 +277,56 @@ func userUpdate(dbt *dbtest.Test, sd dbtest.SeedData) []dbtest.UnitTable {
 				return cmp.Diff(gotResp, expResp)
 			},
 		},
+		{
+			Name:    "stale-version",
+			ExpResp: true,
+			ExcFunc: func(ctx context.Context) any {
+				uu := userbus.UpdateUser{
+					Name: dbtest.StringPointer("Stale Doe"),
+				}
+
+				// The seeded user still carries the version the update
+				// above replaced.
+				_, err := dbt.BusDomain.User.Update(ctx, sd.Users[0].User, uu)
+				return errors.Is(err, userbus.ErrVersionConflict)
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
+		{
+			Name:    "next-version",
+			ExpResp: []int{3, 3},
+			ExcFunc: func(ctx context.Context) any {
+				usr, err := dbt.BusDomain.User.QueryByID(ctx, sd.Users[0].ID)
+				if err != nil {
+					return err
+				}
+
+				if usr.Name != "Jane Doe" {
+					return fmt.Errorf("expected the stale update to be discarded, got name %q", usr.Name)
+				}
+
+				uu := userbus.UpdateUser{
+					Name: dbtest.StringPointer("Janet Doe"),
+				}
+
+				updUsr, err := dbt.BusDomain.User.Update(ctx, usr, uu)
+				if err != nil {
+					return err
+				}
+
+				stored, err := dbt.BusDomain.User.QueryByID(ctx, sd.Users[0].ID)
+				if err != nil {
+					return err
+				}
+
+				return []int{updUsr.Version, stored.Version}
+			},
+			CmpFunc: func(got any, exp any) string {
+				return cmp.Diff(got, exp)
+			},
+		},
 	}
 
 	return table
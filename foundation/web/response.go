@@ -8,7 +8,14 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 )
 
-// Respond converts a Go value to JSON and sends it to the client.
+// ETagger is implemented by values that carry a version the client can send
+// back in an If-Match header.
+type ETagger interface {
+	ETag() string
+}
+
+// Respond converts a Go value to JSON and sends it to the client. If the
+// value implements ETagger the ETag header is set.
 func Respond(ctx context.Context, w http.ResponseWriter, data any, statusCode int) error {
 	ctx, span := AddSpan(ctx, "foundation.web.response", attribute.Int("status", statusCode))
 	defer span.End()
@@ -26,6 +33,9 @@ func Respond(ctx context.Context, w http.ResponseWriter, data any, statusCode in
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	if et, ok := data.(ETagger); ok {
+		w.Header().Set("ETag", et.ETag())
+	}
 	w.WriteHeader(statusCode)
 
 	if _, err := w.Write(jsonData); err != nil {